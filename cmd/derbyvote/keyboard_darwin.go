@@ -8,13 +8,14 @@ import (
 	"os"
 	"strings"
 
+	"github.com/abrezinsky/derbyvote/internal/app"
 	"github.com/abrezinsky/derbyvote/internal/browser"
 	"github.com/abrezinsky/derbyvote/internal/logger"
 	"golang.org/x/sys/unix"
 )
 
 // listenForKeyboard listens for keyboard input and performs actions
-func listenForKeyboard(adminURL string, appLog *logger.SlogLogger) {
+func listenForKeyboard(adminURL string, appLog *logger.SlogLogger, a *app.App) {
 	// Get the current terminal state
 	fd := int(os.Stdin.Fd())
 	oldState, err := unix.IoctlGetTermios(fd, unix.TIOCGETA)
@@ -63,6 +64,10 @@ func listenForKeyboard(adminURL string, appLog *logger.SlogLogger) {
 			}
 		case "l":
 			cycleLogLevel(appLog)
+		case "s":
+			openResultsPage(adminURL)
+		case "t":
+			printStats(a)
 		case "q":
 			fmt.Printf("%sShutting down server...%s\n", yellow, reset)
 			unix.IoctlSetTermios(fd, unix.TIOCSETA, oldState)
@@ -10,12 +10,13 @@ import (
 	"syscall"
 	"unsafe"
 
+	"github.com/abrezinsky/derbyvote/internal/app"
 	"github.com/abrezinsky/derbyvote/internal/browser"
 	"github.com/abrezinsky/derbyvote/internal/logger"
 )
 
 // listenForKeyboard listens for keyboard input and performs actions
-func listenForKeyboard(adminURL string, appLog *logger.SlogLogger) {
+func listenForKeyboard(adminURL string, appLog *logger.SlogLogger, a *app.App) {
 	// Get the current terminal state
 	fd := int(os.Stdin.Fd())
 	var oldState syscall.Termios
@@ -66,6 +67,10 @@ func listenForKeyboard(adminURL string, appLog *logger.SlogLogger) {
 			}
 		case "l":
 			cycleLogLevel(appLog)
+		case "s":
+			openResultsPage(adminURL)
+		case "t":
+			printStats(a)
 		case "q":
 			fmt.Printf("%sShutting down server...%s\n", yellow, reset)
 			syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.TCSETS, uintptr(unsafe.Pointer(&oldState)))
@@ -8,12 +8,13 @@ import (
 	"os"
 	"strings"
 
+	"github.com/abrezinsky/derbyvote/internal/app"
 	"github.com/abrezinsky/derbyvote/internal/browser"
 	"github.com/abrezinsky/derbyvote/internal/logger"
 )
 
 // listenForKeyboard listens for keyboard input on Windows
-func listenForKeyboard(adminURL string, appLog *logger.SlogLogger) {
+func listenForKeyboard(adminURL string, appLog *logger.SlogLogger, a *app.App) {
 	// Simple line-based reading on Windows (terminal manipulation is more complex)
 	buf := make([]byte, 1)
 	for {
@@ -39,6 +40,10 @@ func listenForKeyboard(adminURL string, appLog *logger.SlogLogger) {
 			}
 		case "l":
 			cycleLogLevel(appLog)
+		case "s":
+			openResultsPage(adminURL)
+		case "t":
+			printStats(a)
 		case "q":
 			fmt.Printf("%sShutting down server...%s\n", yellow, reset)
 			os.Exit(0)
@@ -1,16 +1,24 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"math/rand"
+	"net"
 	"os"
+	"runtime"
+	"strings"
 	"time"
 
 	"github.com/abrezinsky/derbyvote/internal/app"
 	"github.com/abrezinsky/derbyvote/internal/auth"
+	"github.com/abrezinsky/derbyvote/internal/browser"
+	"github.com/abrezinsky/derbyvote/internal/eventlog"
 	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/models"
 	"github.com/abrezinsky/derbyvote/pkg/derbynet"
 	"github.com/abrezinsky/derbyvote/web"
 )
@@ -28,8 +36,36 @@ const (
 	bold      = "\033[1m"
 )
 
-// showStartupAnimation displays DerbyVote logo then animated race
-func showStartupAnimation(skipRace bool) {
+// pickLaneLabels labels up to three racing lanes with random cars from the
+// database. Lanes without a car get an empty label.
+func pickLaneLabels(cars []models.Car) []string {
+	labels := make([]string, 3)
+	for i, j := range rand.Perm(len(cars)) {
+		if i == len(labels) {
+			break
+		}
+		car := cars[j]
+		name := car.CarName
+		if name == "" {
+			name = car.RacerName
+		}
+		labels[i] = strings.TrimSpace(fmt.Sprintf("#%s %s", car.CarNumber, name))
+	}
+	return labels
+}
+
+// laneText fits a lane label into width columns, padding with spaces
+func laneText(label string, width int) string {
+	runes := []rune(label)
+	if len(runes) > width {
+		runes = runes[:width]
+	}
+	return string(runes) + strings.Repeat(" ", width-len(runes))
+}
+
+// showStartupAnimation displays DerbyVote logo then animated race, with each
+// lane labelled by laneLabels (generic cars when the labels are empty)
+func showStartupAnimation(skipRace bool, laneLabels []string) {
 	width := 62
 	border := ""
 	for i := 0; i < width; i++ {
@@ -68,10 +104,16 @@ func showStartupAnimation(skipRace bool) {
 	cars := []struct {
 		art   string
 		color string
+		label string
 	}{
-		{`__/¯¯\__`, red},
-		{`=<[##]>=`, blue},
-		{`-=[==]=-`, green},
+		{`__/¯¯\__`, red, ""},
+		{`=<[##]>=`, blue, ""},
+		{`-=[==]=-`, green, ""},
+	}
+	for i := range cars {
+		if i < len(laneLabels) {
+			cars[i].label = laneLabels[i]
+		}
 	}
 
 	trackLen := 62
@@ -112,10 +154,8 @@ func showStartupAnimation(skipRace bool) {
 		}
 
 		for i, car := range cars {
-			padding := ""
-			for j := 0; j < positions[i]; j++ {
-				padding += " "
-			}
+			// The lane label trails behind the car as it pulls away
+			padding := laneText(car.label, positions[i])
 			remaining := trackLen - positions[i] - 8
 			if remaining < 0 {
 				remaining = 0
@@ -154,19 +194,41 @@ func showStartupAnimation(skipRace bool) {
 		// Format: ║ 0.72s WINNER [padding] car ║
 		// 62 total - 8 car - 13 (time+winner) = 41 spaces
 		timeStr := fmt.Sprintf(" %.2fs %s", t, winnerStr)
-		spaces := ""
-		for j := 0; j < 41; j++ {
-			spaces += " "
-		}
+		spaces := laneText(" "+car.label, 41)
 		fmt.Printf("%s  %s║%s%s%s%s%s║%s\n", clearLine, cyan, timeStr, spaces, car.color, car.art, cyan, reset)
 	}
 	fmt.Printf("%s  %s╚%s╝%s\n\n", clearLine, cyan, border, reset)
 }
 
+// Set at build time with -ldflags "-X main.version=... -X main.commit=... -X main.built=..."
 var (
 	version = "dev"
+	commit  = ""
+	built   = ""
 )
 
+// versionInfo is what -version -json prints, for deployment scripts
+type versionInfo struct {
+	Version string `json:"version"`
+	Go      string `json:"go"`
+	Commit  string `json:"commit"`
+	Built   string `json:"built"`
+}
+
+// printVersion writes the version line, or versionInfo as JSON when asJSON is set
+func printVersion(asJSON bool) {
+	if !asJSON {
+		fmt.Printf("derbyvote %s\n", version)
+		return
+	}
+	json.NewEncoder(os.Stdout).Encode(versionInfo{
+		Version: version,
+		Go:      runtime.Version(),
+		Commit:  commit,
+		Built:   built,
+	})
+}
+
 // cycleLogLevel cycles through debug -> info -> warn -> error
 func cycleLogLevel(appLog *logger.SlogLogger) {
 	current := appLog.GetLevel()
@@ -195,24 +257,95 @@ func cycleLogLevel(appLog *logger.SlogLogger) {
 	fmt.Printf("%sLog level: %s%s%s\n", green, yellow, next, reset)
 }
 
+// openResultsPage opens the admin results page in the browser
+func openResultsPage(adminURL string) {
+	fmt.Printf("%sOpening results page in browser...%s\n", cyan, reset)
+	if err := browser.Open(adminURL + "/results"); err != nil {
+		fmt.Printf("%sError opening browser: %v%s\n", red, err, reset)
+	}
+}
+
+// openAdminOnStartup opens the admin page once the server accepts connections.
+// It does nothing when no one is likely to see the browser: the session is
+// headless or over SSH, or stdout is not a terminal.
+func openAdminOnStartup(adminURL string, port int) {
+	if browser.Headless(runtime.GOOS, os.Getenv) || !isTerminal(os.Stdout) {
+		fmt.Printf("%sNo display available, not opening the admin page (%s)%s\n", yellow, adminURL, reset)
+		return
+	}
+
+	addr := fmt.Sprintf("localhost:%d", port)
+	for i := 0; i < 50; i++ {
+		conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			fmt.Printf("%sOpening admin page in browser...%s\n", cyan, reset)
+			if err := browser.Open(adminURL); err != nil {
+				fmt.Printf("%sError opening browser: %v%s\n", red, err, reset)
+			}
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	fmt.Printf("%sServer did not start in time, not opening the admin page%s\n", red, reset)
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// printStats prints the current voting statistics
+func printStats(a *app.App) {
+	stats, err := a.Stats(context.Background())
+	if err != nil {
+		fmt.Printf("%sError loading stats: %v%s\n", red, err, reset)
+		return
+	}
+
+	status := red + "closed" + reset
+	if open, _ := stats["voting_open"].(bool); open {
+		status = green + "open" + reset
+	}
+	fmt.Printf("\n%s%s  Voting Stats:%s\n", bold, green, reset)
+	fmt.Printf("    Voting:     %s\n", status)
+	fmt.Printf("    Voters:     %s%v%s (%v voted)\n", cyan, stats["total_voters"], reset, stats["voters_who_voted"])
+	fmt.Printf("    Votes:      %s%v%s\n", cyan, stats["total_votes"], reset)
+	fmt.Printf("    Categories: %s%v%s\n", cyan, stats["total_categories"], reset)
+	fmt.Printf("    Cars:       %s%v%s\n\n", cyan, stats["total_cars"], reset)
+}
+
 // printKeyboardHelp displays all available keyboard shortcuts
 func printKeyboardHelp() {
 	fmt.Printf("\n%s%s  Keyboard Shortcuts:%s\n", bold, green, reset)
 	fmt.Printf("    %sa%s      - Open admin page in browser\n", cyan, reset)
 	fmt.Printf("    %sh%s      - Toggle HTTP request logging\n", cyan, reset)
 	fmt.Printf("    %sl%s      - Cycle log level (debug → info → warn → error)\n", cyan, reset)
+	fmt.Printf("    %ss%s      - Open results page in browser\n", cyan, reset)
+	fmt.Printf("    %st%s      - Print voting stats\n", cyan, reset)
 	fmt.Printf("    %sq%s      - Quit server\n", cyan, reset)
 	fmt.Printf("    %s?%s      - Show this help\n\n", cyan, reset)
 }
 
 func main() {
-	port := flag.Int("port", 8081, "HTTP server port")
+	port := flag.Int("port", 8081, "HTTP server port (0 picks a free port)")
 	dbPath := flag.String("db", "voting.db", "SQLite database path")
 	adminPw := flag.String("adminpw", "", "Admin password (auto-generated if not set)")
 	logLevel := flag.String("loglevel", "info", "Log level (debug, info, warn, error)")
 	noAnimate := flag.Bool("noanimate", false, "Show logo only, skip race animation")
 	noKeyboard := flag.Bool("nokeyboard", false, "Disable keyboard shortcuts")
+	quiet := flag.Bool("quiet", false, "Print only the listening address and admin password (implies -noanimate and -nokeyboard)")
 	showVersion := flag.Bool("version", false, "Show version and exit")
+	versionJSON := flag.Bool("json", false, "With -version, print version, Go version, commit and build time as JSON")
+	maxBody := flag.Int("max-body", 10, "Maximum request body size in MB (0 for no limit)")
+	gzipResponses := flag.Bool("gzip", true, "Gzip responses for clients that accept it")
+	requestTimeout := flag.Int("request-timeout", 15, "Seconds a request may run before it is cancelled with 503 (0 for no limit)")
+	eventLog := flag.String("event-log", "", "Append a JSON line per voting event to this file for post-event analysis")
+	readOnly := flag.Bool("readonly", false, "Open the database read-only and reject all changes (e.g. for a results projector)")
+	openAdmin := flag.Bool("open", false, "Open the admin page in the default browser on startup")
+	trustLocalhost := flag.Bool("trust-localhost", false, "Treat admin requests from this machine as logged in (weakens security)")
+	secret := flag.String("secret", "", "Server secret keying anonymous voter IDs in anonymized ballot exports (a generated key stored in the database if not set)")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, `DerbyVote - Pinewood Derby Voting System
@@ -221,19 +354,36 @@ Usage:
   derbyvote [options]
 
 Options:
-  -port int      HTTP server port (default 8081)
+  -port int      HTTP server port, 0 to let the OS pick a free one (default 8081)
   -db string     SQLite database path (default "voting.db")
   -adminpw str   Admin password (auto-generated if not set)
   -loglevel str  Log level: debug, info, warn, error (default "info")
   -noanimate     Show logo only, skip race animation
   -nokeyboard    Disable keyboard shortcuts
+  -quiet         Skip the logo, animation and keyboard help; print only the
+                 listening address and admin password (implies -noanimate, -nokeyboard)
+  -open          Open the admin page in the default browser on startup
+                 (skipped when headless or over SSH)
+  -max-body int  Maximum request body size in MB, 0 for no limit (default 10)
+  -gzip          Gzip text and JSON responses for clients that accept it;
+                 -gzip=false to turn off (default true)
+  -request-timeout int
+                 Seconds a request may run before it is cancelled with 503, 0 for no limit (default 15)
+  -event-log str Append a JSON line per voting event to this file
+  -readonly      Open the database read-only and reject POST/PUT/DELETE with 405
+  -trust-localhost
+                 Treat admin requests from 127.0.0.1/::1 as logged in, for kiosks
+                 (weakens security: anything running on this machine gets admin access)
   -version       Show version and exit
+  -json          With -version, print {"version","go","commit","built"} as JSON
   -help          Show this help message
 
 Keyboard Shortcuts (when enabled):
   a              Open admin page in browser
   h              Toggle HTTP request logging
   l              Cycle log level (debug → info → warn → error)
+  s              Open results page in browser
+  t              Print voting stats
   q              Quit server
   ?              Show keyboard help
 
@@ -243,7 +393,12 @@ Examples:
   derbyvote -db /data/derby.db       # Use custom database path
   derbyvote -adminpw secret123       # Use specific admin password
   derbyvote -nokeyboard              # Disable keyboard shortcuts
+  derbyvote -quiet                   # Clean output for systemd/journald
+  derbyvote -open                    # Open the admin page once started
+  derbyvote -open -trust-localhost   # Kiosk: admin page opens without a password
   derbyvote -port 80 -db prod.db     # Production example
+  derbyvote -port 8082 -readonly     # Results projector sharing voting.db
+  derbyvote -version -json           # Machine-readable version for scripts
 
 `)
 	}
@@ -251,12 +406,13 @@ Examples:
 	flag.Parse()
 
 	if *showVersion {
-		fmt.Printf("derbyvote %s\n", version)
+		printVersion(*versionJSON)
 		os.Exit(0)
 	}
-
-	// Show startup animation or just logo
-	showStartupAnimation(*noAnimate)
+	if *quiet {
+		*noAnimate = true
+		*noKeyboard = true
+	}
 
 	// Setup admin authentication
 	password := *adminPw
@@ -268,28 +424,64 @@ Examples:
 	// Create logger with specified level
 	appLog := logger.NewWithLevel(logger.ParseLevel(*logLevel))
 
+	if *trustLocalhost {
+		adminAuth.SetTrustLocalhost(true)
+		appLog.Warn("Admin login is bypassed for requests from localhost (-trust-localhost); anything running on this machine has admin access")
+	}
+
 	// Create DerbyNet client - URL is set dynamically from settings
 	derbynetClient := derbynet.NewHTTPClient("", appLog)
 
-	a, err := app.New(appLog, *dbPath, derbynetClient, web.GetTemplatesFS(), web.GetStaticFS(), adminAuth)
+	newApp := app.New
+	if *readOnly {
+		newApp = app.NewReadOnly
+	}
+	a, err := newApp(appLog, *dbPath, derbynetClient, web.GetTemplatesFS(), web.GetStaticFS(), adminAuth)
 	if err != nil {
 		log.Fatal("Failed to initialize application:", err)
 	}
+	a.SetMaxBodySize(int64(*maxBody) << 20)
+	a.SetRequestTimeout(time.Duration(*requestTimeout) * time.Second)
+	a.SetGzip(*gzipResponses)
+	if *secret != "" {
+		a.SetBallotSecret(*secret)
+	}
+	if *eventLog != "" {
+		events, err := eventlog.Open(*eventLog)
+		if err != nil {
+			log.Fatal("Failed to open event log:", err)
+		}
+		defer events.Close()
+		a.SetEventSink(events)
+	}
 
-	addr := fmt.Sprintf(":%d", *port)
+	// Show startup animation or just logo, racing cars from the database if there are any
+	if !*quiet {
+		var laneLabels []string
+		if cars, err := a.ListCars(context.Background()); err == nil {
+			laneLabels = pickLaneLabels(cars)
+		}
+		showStartupAnimation(*noAnimate, laneLabels)
+	}
+
+	// Bind before serving so -port 0 reports the port the OS picked
+	if _, err := a.Listen(fmt.Sprintf(":%d", *port)); err != nil {
+		log.Fatal("Failed to listen:", err)
+	}
 	appLog.Info("Admin password", "password", password)
 
 	// Start server in goroutine
 	serverErr := make(chan error, 1)
 	go func() {
-		serverErr <- a.Run(addr)
+		serverErr <- a.Serve()
 	}()
 
-	// Wait a moment for server to start
-	time.Sleep(100 * time.Millisecond)
-
 	// Get base URL for browser opening
-	adminURL := fmt.Sprintf("http://localhost:%d/admin", *port)
+	adminURL := fmt.Sprintf("http://localhost:%d/admin", a.Port())
+
+	if *openAdmin {
+		go openAdminOnStartup(adminURL, a.Port())
+	}
 
 	// Print keyboard shortcuts and start listener (unless disabled)
 	if !*noKeyboard {
@@ -297,12 +489,14 @@ Examples:
 		fmt.Printf("    %sa%s      - Open admin page in browser\n", cyan, reset)
 		fmt.Printf("    %sh%s      - Toggle HTTP request logging\n", cyan, reset)
 		fmt.Printf("    %sl%s      - Cycle log level (debug → info → warn → error)\n", cyan, reset)
+		fmt.Printf("    %ss%s      - Open results page in browser\n", cyan, reset)
+		fmt.Printf("    %st%s      - Print voting stats\n", cyan, reset)
 		fmt.Printf("    %sq%s      - Quit server\n", cyan, reset)
 		fmt.Printf("    %s?%s      - Show help\n\n", cyan, reset)
 
 		// Start keyboard listener in goroutine
-		go listenForKeyboard(adminURL, appLog)
-	} else {
+		go listenForKeyboard(adminURL, appLog, a)
+	} else if !*quiet {
 		fmt.Printf("\n%sKeyboard shortcuts disabled (use -nokeyboard=false to enable)%s\n\n", yellow, reset)
 	}
 
@@ -12,4 +12,4 @@ require (
 	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 )
 
-require golang.org/x/sys v0.39.0 // indirect
+require golang.org/x/sys v0.39.0
@@ -0,0 +1,22 @@
+// Package actor carries the admin making a request through a context, so
+// lower layers can record who made a change without depending on auth.
+package actor
+
+import "context"
+
+// key is the context key for the admin making the request
+type key struct{}
+
+// With returns a copy of ctx naming the admin making the request
+func With(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, key{}, actor)
+}
+
+// FromContext returns the admin named in ctx, or "" if there is none
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actor, _ := ctx.Value(key{}).(string)
+	return actor
+}
@@ -0,0 +1,15 @@
+package actor
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	if actor := FromContext(context.Background()); actor != "" {
+		t.Errorf("expected no actor, got %q", actor)
+	}
+	if actor := FromContext(With(context.Background(), "session-abc")); actor != "session-abc" {
+		t.Errorf("expected session-abc, got %q", actor)
+	}
+}
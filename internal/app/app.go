@@ -6,13 +6,16 @@ import (
 	"io/fs"
 	"net"
 	"net/http"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/abrezinsky/derbyvote/internal/auth"
 	"github.com/abrezinsky/derbyvote/internal/handlers"
 	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/models"
 	"github.com/abrezinsky/derbyvote/internal/repository"
 	"github.com/abrezinsky/derbyvote/internal/services"
 	"github.com/abrezinsky/derbyvote/internal/websocket"
@@ -24,7 +27,13 @@ type App struct {
 	log            logger.Logger
 	handlers       *handlers.Handlers
 	repo           *repository.Repository
+	results        *services.ResultsService
+	settings       *services.SettingsService
+	voting         *services.VotingService
+	voters         *services.VoterService
 	cancelCountdown context.CancelFunc
+	readOnly       bool
+	listener       net.Listener
 }
 
 // New creates and initializes a new application instance
@@ -33,6 +42,22 @@ func New(log logger.Logger, dbPath string, derbynetClient derbynet.Client, templ
 	if err != nil {
 		return nil, err
 	}
+	return newApp(log, repo, dbPath, derbynetClient, templatesFS, staticFS, adminAuth, false)
+}
+
+// NewReadOnly creates an application instance that opens the database
+// read-only and rejects every mutating request, for displaying results
+// from the database of another instance
+func NewReadOnly(log logger.Logger, dbPath string, derbynetClient derbynet.Client, templatesFS, staticFS fs.FS, adminAuth *auth.Auth) (*App, error) {
+	repo, err := repository.NewReadOnly(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return newApp(log, repo, dbPath, derbynetClient, templatesFS, staticFS, adminAuth, true)
+}
+
+// newApp wires the services and handlers around an open repository
+func newApp(log logger.Logger, repo *repository.Repository, dbPath string, derbynetClient derbynet.Client, templatesFS, staticFS fs.FS, adminAuth *auth.Auth, readOnly bool) (*App, error) {
 
 	// Initialize services
 	categoryService := services.NewCategoryService(log, repo, derbynetClient)
@@ -42,14 +67,38 @@ func New(log logger.Logger, dbPath string, derbynetClient derbynet.Client, templ
 	votingService := services.NewVotingService(log, repo, categoryService, carService, settingsService)
 	resultsService := services.NewResultsService(log, repo, settingsService, derbynetClient)
 
+	// Anonymous voter IDs use a stored key unless SetBallotSecret replaces it.
+	// Without one they could be reversed by hashing QR codes, so refuse to start.
+	ballotKey, err := settingsService.BallotKey(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ballot anonymization key: %w", err)
+	}
+	pseudo := services.NewPseudonymizer(ballotKey)
+	votingService.SetPseudonymizer(pseudo)
+	voterService.SetPseudonymizer(pseudo)
+	resultsService.SetPseudonymizer(pseudo)
+
+	// Vote writes are timed so the stats endpoint can show when SQLite falls behind
+	writeGauge := services.NewWriteGauge()
+	votingService.SetWriteGauge(writeGauge)
+	resultsService.SetWriteGauge(writeGauge)
+
 	// Initialize WebSocket hub with DI
 	hub := websocket.New(log, settingsService)
+	hub.SetReadOnly(readOnly)
 	hub.Start()
 	settingsService.SetBroadcaster(hub)
 
-	// Start countdown with context for graceful shutdown
+	// Start countdown and DerbyNet health polling with context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	go hub.StartVotingCountdown(ctx)
+	// The health checker repoints its client on every check, so it gets its own
+	// rather than racing request handlers for the shared one
+	derbyNetHealth := services.NewDerbyNetHealthService(log, settingsService, derbynetClient.Clone())
+	go derbyNetHealth.Run(ctx)
+	if !readOnly {
+		go votingService.RunIdleAutoClose(ctx, services.IdleAutoCloseCheckInterval)
+	}
 
 	// Create static file server
 	staticServer := handlers.NewStaticServer(staticFS)
@@ -69,15 +118,25 @@ func New(log logger.Logger, dbPath string, derbynetClient derbynet.Client, templ
 		log,
 	)
 	if err != nil {
-		cancel() // Clean up countdown goroutine
+		cancel() // Clean up background goroutines
 		return nil, fmt.Errorf("failed to initialize handlers: %w", err)
 	}
+	h.SetDerbyNetHealth(derbyNetHealth)
+	h.SetReadOnly(readOnly)
+	if dbPath != ":memory:" {
+		h.SetUploadDir(filepath.Join(filepath.Dir(dbPath), "uploads"))
+	}
 
 	return &App{
 		log:             log,
 		handlers:        h,
 		repo:            repo,
+		results:         resultsService,
+		settings:        settingsService,
+		voting:          votingService,
+		voters:          voterService,
 		cancelCountdown: cancel,
+		readOnly:        readOnly,
 	}, nil
 }
 
@@ -86,23 +145,112 @@ func (a *App) Router() chi.Router {
 	return a.handlers.Router()
 }
 
+// ListCars returns the active cars in the database
+func (a *App) ListCars(ctx context.Context) ([]models.Car, error) {
+	return a.repo.ListCars(ctx)
+}
+
+// Stats returns the current voting statistics
+func (a *App) Stats(ctx context.Context) (map[string]interface{}, error) {
+	return a.results.GetStats(ctx)
+}
+
+// SetMaxBodySize sets the largest request body accepted, in bytes
+func (a *App) SetMaxBodySize(n int64) {
+	a.handlers.SetMaxBodySize(n)
+}
+
+// SetRequestTimeout sets how long a request may run before it is cancelled
+func (a *App) SetRequestTimeout(d time.Duration) {
+	a.handlers.SetRequestTimeout(d)
+}
+
+// SetGzip turns gzip compression of responses on or off
+func (a *App) SetGzip(enabled bool) {
+	a.handlers.SetGzip(enabled)
+}
+
+// SetEventSink sets where the services record events for post-event analysis
+func (a *App) SetEventSink(sink services.EventSink) {
+	a.settings.SetEventSink(sink)
+	a.voting.SetEventSink(sink)
+	a.voters.SetEventSink(sink)
+	a.results.SetEventSink(sink)
+}
+
+// SetBallotSecret keys anonymous voter IDs with secret instead of the key
+// stored in the database, so they stay the same across databases
+func (a *App) SetBallotSecret(secret string) {
+	pseudo := services.NewPseudonymizer([]byte(secret))
+	a.voting.SetPseudonymizer(pseudo)
+	a.voters.SetPseudonymizer(pseudo)
+	a.results.SetPseudonymizer(pseudo)
+}
+
 // Close performs graceful shutdown of app resources
 func (a *App) Close() {
 	if a.cancelCountdown != nil {
 		a.cancelCountdown()
 	}
+	if a.listener != nil {
+		a.listener.Close()
+	}
 }
 
-// Run starts the HTTP server
+// Run binds addr and serves HTTP until the server fails
 func (a *App) Run(addr string) error {
+	if _, err := a.Listen(addr); err != nil {
+		return err
+	}
+	return a.Serve()
+}
+
+// Listen binds addr without serving yet and returns the bound address. Port 0
+// lets the OS pick a free port; the chosen one is used for the default base URL
+// and logged.
+func (a *App) Listen(addr string) (net.Addr, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	a.listener = ln
+
 	// Set default base URL if not configured, using detected LAN IP
 	ip := getPreferredIP(realNetworkProvider{})
-	baseURL := fmt.Sprintf("http://%s%s", ip, addr)
-	a.setDefaultBaseURL(baseURL)
+	baseURL := fmt.Sprintf("http://%s:%d", ip, a.Port())
+	if a.readOnly {
+		a.log.Info("Read-only mode: database changes are disabled")
+	} else {
+		a.setDefaultBaseURL(baseURL)
+	}
 
-	a.log.Info("Server starting", "url", baseURL)
+	a.log.Info("Server listening", "addr", ln.Addr().String(), "url", baseURL)
 	a.log.Info("Admin URL", "url", baseURL+"/admin")
-	return http.ListenAndServe(addr, a.Router())
+	return ln.Addr(), nil
+}
+
+// Serve serves HTTP on the address bound by Listen
+func (a *App) Serve() error {
+	if a.listener == nil {
+		return fmt.Errorf("app: Serve called before Listen")
+	}
+	return http.Serve(a.listener, a.Router())
+}
+
+// Addr returns the address the server is bound to, or nil before Listen
+func (a *App) Addr() net.Addr {
+	if a.listener == nil {
+		return nil
+	}
+	return a.listener.Addr()
+}
+
+// Port returns the TCP port the server is bound to, or 0 before Listen
+func (a *App) Port() int {
+	if tcpAddr, ok := a.Addr().(*net.TCPAddr); ok {
+		return tcpAddr.Port
+	}
+	return 0
 }
 
 // setDefaultBaseURL sets the base URL setting if not already configured
@@ -2,15 +2,20 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
 	"testing/fstest"
 	"time"
 
 	"github.com/abrezinsky/derbyvote/internal/auth"
 	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/repository"
+	"github.com/abrezinsky/derbyvote/internal/services"
 	"github.com/abrezinsky/derbyvote/pkg/derbynet"
 )
 
@@ -55,6 +60,66 @@ func TestNew_FailsWithBadDBPath(t *testing.T) {
 	}
 }
 
+func TestNewReadOnly_RejectsWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "derby.db")
+	repo, err := repository.New(path)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	// The main instance stores the ballot key on its first start
+	if _, err := services.NewSettingsService(logger.New(), repo).BallotKey(context.Background()); err != nil {
+		t.Fatalf("failed to create ballot key: %v", err)
+	}
+	repo.Close()
+
+	app, err := NewReadOnly(logger.New(), path, derbynet.NewMockClient(), createTestTemplatesFS(), fstest.MapFS{}, auth.New("test-password"))
+	if err != nil {
+		t.Fatalf("NewReadOnly failed: %v", err)
+	}
+	defer app.Close()
+
+	rec := httptest.NewRecorder()
+	app.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/vote", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	app.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/categories", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestNew_FailsWithUnreadableBallotKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "derby.db")
+	repo, err := repository.New(path)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	repo.SetSetting(context.Background(), "ballot_anonymization_key", "not-hex")
+	repo.Close()
+
+	_, err = New(logger.New(), path, derbynet.NewMockClient(), createTestTemplatesFS(), fstest.MapFS{}, auth.New("test-password"))
+	if err == nil || !strings.Contains(err.Error(), "ballot anonymization key") {
+		t.Errorf("expected startup to fail on an unreadable ballot key, got %v", err)
+	}
+}
+
+func TestNewReadOnly_FailsWithoutBallotKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "derby.db")
+	repo, err := repository.New(path)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	repo.Close()
+
+	// A read-only instance cannot generate and store the key itself
+	if _, err := NewReadOnly(logger.New(), path, derbynet.NewMockClient(), createTestTemplatesFS(), fstest.MapFS{}, auth.New("test-password")); err == nil {
+		t.Error("expected read-only startup to fail without a stored ballot key")
+	}
+}
+
 func TestNew_FailsWithMissingTemplates(t *testing.T) {
 	// Empty templates FS
 	templatesFS := fstest.MapFS{}
@@ -98,6 +163,25 @@ func TestApp_Router_ServesRequests(t *testing.T) {
 	}
 }
 
+func TestApp_ListCars(t *testing.T) {
+	app := createTestApp(t)
+	ctx := context.Background()
+
+	cars, err := app.ListCars(ctx)
+	if err != nil {
+		t.Fatalf("ListCars failed: %v", err)
+	}
+	if len(cars) != 0 {
+		t.Errorf("expected no cars in a new database, got %d", len(cars))
+	}
+
+	app.repo.CreateCar(ctx, "42", "Racer", "Lightning", "")
+	cars, _ = app.ListCars(ctx)
+	if len(cars) != 1 || cars[0].CarName != "Lightning" {
+		t.Errorf("expected the created car, got %+v", cars)
+	}
+}
+
 func TestGetPreferredIP_ReturnsValidIP(t *testing.T) {
 	ip := getPreferredIP(realNetworkProvider{})
 
@@ -473,6 +557,52 @@ func TestApp_Run_Integration(t *testing.T) {
 	}
 }
 
+func TestApp_Listen_EphemeralPort(t *testing.T) {
+	app := createTestApp(t)
+	defer app.Close()
+
+	if app.Addr() != nil || app.Port() != 0 {
+		t.Fatalf("expected no address before Listen, got %v", app.Addr())
+	}
+	if err := app.Serve(); err == nil {
+		t.Error("expected Serve to fail before Listen")
+	}
+
+	addr, err := app.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	if app.Port() == 0 || addr.String() != app.Addr().String() {
+		t.Fatalf("expected a resolved port, got %v", addr)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Serve()
+	}()
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/api/clock", addr))
+	if err != nil {
+		t.Fatalf("request to resolved address failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	baseURL, _ := app.repo.GetSetting(context.Background(), "base_url")
+	if !strings.HasSuffix(baseURL, fmt.Sprintf(":%d", app.Port())) {
+		t.Errorf("expected base_url to use the resolved port %d, got %q", app.Port(), baseURL)
+	}
+
+	app.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("expected Serve to return after Close")
+	}
+}
+
 // Helper functions
 
 func createTestTemplatesFS() fstest.MapFS {
@@ -1,12 +1,18 @@
 package auth
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/abrezinsky/derbyvote/internal/actor"
 )
 
 const (
@@ -24,9 +30,10 @@ var derbyWords = []string{
 
 // Auth handles admin authentication
 type Auth struct {
-	password string
-	sessions map[string]time.Time
-	mu       sync.RWMutex
+	password       string
+	sessions       map[string]time.Time
+	mu             sync.RWMutex
+	trustLocalhost bool
 }
 
 // New creates a new Auth instance with the given password
@@ -97,11 +104,92 @@ func (a *Auth) GetSessionFromRequest(r *http.Request) bool {
 	return a.ValidateSession(cookie.Value)
 }
 
+// SetTrustLocalhost makes requests from this machine count as authenticated,
+// for kiosks that open the admin page without typing the password. Off by
+// default, since anything running locally then gets admin access.
+func (a *Auth) SetTrustLocalhost(trust bool) {
+	a.trustLocalhost = trust
+}
+
+// IsAuthenticated reports whether a request has a valid session, or comes
+// from this machine while localhost is trusted
+func (a *Auth) IsAuthenticated(r *http.Request) bool {
+	return a.GetSessionFromRequest(r) || (a.trustLocalhost && isLocalRequest(r))
+}
+
+// Actor identifies the admin behind a request. There is a single shared
+// password, so sessions stand in for users: the actor is a short hash of the
+// session token, never the token itself. Trusted local requests without a
+// session are "localhost". Returns "" for unauthenticated requests.
+func (a *Auth) Actor(r *http.Request) string {
+	if cookie, err := r.Cookie(CookieName); err == nil && a.ValidateSession(cookie.Value) {
+		sum := sha256.Sum256([]byte(cookie.Value))
+		return "session-" + hex.EncodeToString(sum[:6])
+	}
+	if a.trustLocalhost && isLocalRequest(r) {
+		return "localhost"
+	}
+	return ""
+}
+
+// withActor returns r with its actor recorded in the context
+func (a *Auth) withActor(r *http.Request) *http.Request {
+	return r.WithContext(actor.With(r.Context(), a.Actor(r)))
+}
+
+// peerAddrKey is the context key for the connection's own remote address
+type peerAddrKey struct{}
+
+// RecordPeerAddr keeps the connection's remote address before proxy headers
+// rewrite r.RemoteAddr, so a forged X-Forwarded-For cannot pass as local.
+// It must run before middleware.RealIP.
+func RecordPeerAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), peerAddrKey{}, r.RemoteAddr)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isLocalRequest reports whether r arrived over loopback, addressed to a
+// loopback host, and was not sent cross-site by another origin. The host and
+// origin checks keep web pages open in the kiosk's browser, including ones
+// using DNS rebinding, from borrowing the trust.
+func isLocalRequest(r *http.Request) bool {
+	peer, ok := r.Context().Value(peerAddrKey{}).(string)
+	if !ok {
+		peer = r.RemoteAddr
+	}
+	if !isLoopback(peer) || !isLoopback(r.Host) {
+		return false
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || u.Host != r.Host {
+			return false
+		}
+	}
+	return true
+}
+
+// isLoopback reports whether a host or host:port names the loopback interface
+func isLoopback(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 // RequireAuth middleware for admin pages (redirects to login)
 func (a *Auth) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if a.GetSessionFromRequest(r) {
-			next.ServeHTTP(w, r)
+		if a.IsAuthenticated(r) {
+			next.ServeHTTP(w, a.withActor(r))
 			return
 		}
 		http.Redirect(w, r, "/admin/login", http.StatusFound)
@@ -111,13 +199,13 @@ func (a *Auth) RequireAuth(next http.Handler) http.Handler {
 // RequireAuthAPI middleware for API endpoints (returns 401)
 func (a *Auth) RequireAuthAPI(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if a.GetSessionFromRequest(r) {
-			next.ServeHTTP(w, r)
+		if a.IsAuthenticated(r) {
+			next.ServeHTTP(w, a.withActor(r))
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusUnauthorized)
-		w.Write([]byte(`{"code":"UNAUTHORIZED","error":"Unauthorized - please log in"}`))
+		w.Write([]byte(`{"error":{"code":"UNAUTHORIZED","message":"Unauthorized - please log in"}}`))
 	})
 }
 
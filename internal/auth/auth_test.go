@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	actorpkg "github.com/abrezinsky/derbyvote/internal/actor"
 )
 
 func TestNew(t *testing.T) {
@@ -257,6 +259,107 @@ func TestRequireAuthAPI_Returns401WithoutSession(t *testing.T) {
 	}
 }
 
+func TestRequireAuthAPI_TrustLocalhost(t *testing.T) {
+	tests := []struct {
+		name       string
+		trust      bool
+		remoteAddr string
+		host       string
+		origin     string
+		want       int
+	}{
+		{"off by default", false, "127.0.0.1:50000", "localhost:8081", "", http.StatusUnauthorized},
+		{"IPv4 loopback", true, "127.0.0.1:50000", "localhost:8081", "", http.StatusOK},
+		{"IPv6 loopback", true, "[::1]:50000", "[::1]:8081", "", http.StatusOK},
+		{"same origin", true, "127.0.0.1:50000", "127.0.0.1:8081", "http://127.0.0.1:8081", http.StatusOK},
+		{"remote client", true, "192.168.1.20:50000", "192.168.1.5:8081", "", http.StatusUnauthorized},
+		{"non-loopback host", true, "127.0.0.1:50000", "evil.example:8081", "", http.StatusUnauthorized},
+		{"cross-site origin", true, "127.0.0.1:50000", "localhost:8081", "http://evil.example", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New("password")
+			a.SetTrustLocalhost(tt.trust)
+			handler := RecordPeerAddr(a.RequireAuthAPI(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})))
+
+			req := httptest.NewRequest("GET", "/api/admin/settings", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Host = tt.host
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestRequireAuthAPI_TrustLocalhostIgnoresRewrittenRemoteAddr(t *testing.T) {
+	a := New("password")
+	a.SetTrustLocalhost(true)
+
+	// A proxy header rewrite, as middleware.RealIP does, must not make a remote client local
+	rewrite := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.RemoteAddr = "127.0.0.1"
+			next.ServeHTTP(w, r)
+		})
+	}
+	handler := RecordPeerAddr(rewrite(a.RequireAuthAPI(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))))
+
+	req := httptest.NewRequest("GET", "/api/admin/settings", nil)
+	req.RemoteAddr = "192.168.1.20:50000"
+	req.Host = "localhost:8081"
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rr.Code)
+	}
+}
+
+func TestRequireAuthAPI_RecordsActor(t *testing.T) {
+	a := New("password")
+	a.SetTrustLocalhost(true)
+	token, _ := a.Login("password")
+
+	var actor string
+	handler := RecordPeerAddr(a.RequireAuthAPI(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = actorpkg.FromContext(r.Context())
+	})))
+
+	req := httptest.NewRequest("GET", "/api/admin/settings", nil)
+	req.AddCookie(&http.Cookie{Name: CookieName, Value: token})
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if !strings.HasPrefix(actor, "session-") || strings.Contains(actor, token) {
+		t.Errorf("expected a hashed session actor, got %q", actor)
+	}
+	sessionActor := actor
+
+	// The same session is always the same actor
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if actor != sessionActor {
+		t.Errorf("expected stable actor %q, got %q", sessionActor, actor)
+	}
+
+	req = httptest.NewRequest("GET", "/api/admin/settings", nil)
+	req.RemoteAddr = "127.0.0.1:50000"
+	req.Host = "localhost:8081"
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if actor != "localhost" {
+		t.Errorf("expected localhost actor, got %q", actor)
+	}
+}
+
 func TestSetSessionCookie(t *testing.T) {
 	rr := httptest.NewRecorder()
 
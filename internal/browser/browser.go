@@ -48,3 +48,15 @@ func OpenWithCommander(url string, commander Commander, goos string) error {
 
 	return commander.Start(name, args...)
 }
+
+// Headless reports whether a browser window would likely go unseen: the
+// session is over SSH, or on Linux there is no X11 or Wayland display
+func Headless(goos string, getenv func(string) string) bool {
+	if getenv("SSH_CONNECTION") != "" || getenv("SSH_TTY") != "" {
+		return true
+	}
+	if goos == "linux" {
+		return getenv("DISPLAY") == "" && getenv("WAYLAND_DISPLAY") == ""
+	}
+	return false
+}
@@ -202,3 +202,29 @@ func TestRealCommander_Start(t *testing.T) {
 		t.Error("expected error for nonexistent command, got nil")
 	}
 }
+
+func TestHeadless(t *testing.T) {
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		want bool
+	}{
+		{"linux with X11", "linux", map[string]string{"DISPLAY": ":0"}, false},
+		{"linux with Wayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, false},
+		{"linux without display", "linux", map[string]string{}, true},
+		{"linux over SSH with forwarded X11", "linux", map[string]string{"DISPLAY": "localhost:10.0", "SSH_CONNECTION": "10.0.0.2 50000 10.0.0.1 22"}, true},
+		{"darwin", "darwin", map[string]string{}, false},
+		{"darwin over SSH", "darwin", map[string]string{"SSH_TTY": "/dev/ttys001"}, true},
+		{"windows", "windows", map[string]string{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := Headless(tt.goos, getenv); got != tt.want {
+				t.Errorf("Headless() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
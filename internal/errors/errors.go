@@ -1,6 +1,10 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // Kind represents the type of error
 type Kind int
@@ -11,13 +15,16 @@ const (
 	ErrValidation
 	ErrConflict
 	ErrInvalidInput
+	ErrForbidden
+	ErrInvalidFields
 )
 
 // Error is an application-level error with a kind for classification
 type Error struct {
 	Kind    Kind
 	Message string
-	Err     error // underlying error
+	Err     error             // underlying error
+	Fields  map[string]string // per-field messages for ErrInvalidFields
 }
 
 func (e *Error) Error() string {
@@ -65,6 +72,25 @@ func InvalidInputf(format string, args ...interface{}) *Error {
 	return &Error{Kind: ErrInvalidInput, Message: fmt.Sprintf(format, args...)}
 }
 
+func Forbidden(msg string) *Error {
+	return &Error{Kind: ErrForbidden, Message: msg}
+}
+
+// InvalidFields creates an error carrying a message per offending field
+func InvalidFields(fields map[string]string) *Error {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+": "+fields[k])
+	}
+	return &Error{Kind: ErrInvalidFields, Message: "invalid fields: " + strings.Join(parts, "; "), Fields: fields}
+}
+
 func Internal(err error) *Error {
 	return &Error{Kind: ErrInternal, Message: "internal error", Err: err}
 }
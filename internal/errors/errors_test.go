@@ -125,6 +125,34 @@ func TestInvalidInputf(t *testing.T) {
 	}
 }
 
+func TestForbidden(t *testing.T) {
+	err := Forbidden("access denied")
+
+	if err.Kind != ErrForbidden {
+		t.Errorf("expected Kind to be ErrForbidden (%d), got %d", ErrForbidden, err.Kind)
+	}
+	if err.Message != "access denied" {
+		t.Errorf("expected Message to be 'access denied', got '%s'", err.Message)
+	}
+	if err.Err != nil {
+		t.Errorf("expected Err to be nil, got %v", err.Err)
+	}
+}
+
+func TestInvalidFields(t *testing.T) {
+	err := InvalidFields(map[string]string{"base_url": "must be a valid URL", "alpha": "unknown field"})
+
+	if err.Kind != ErrInvalidFields {
+		t.Errorf("expected Kind to be ErrInvalidFields (%d), got %d", ErrInvalidFields, err.Kind)
+	}
+	if err.Message != "invalid fields: alpha: unknown field; base_url: must be a valid URL" {
+		t.Errorf("unexpected Message: '%s'", err.Message)
+	}
+	if len(err.Fields) != 2 || err.Fields["base_url"] != "must be a valid URL" {
+		t.Errorf("expected Fields to be preserved, got %v", err.Fields)
+	}
+}
+
 func TestInternal(t *testing.T) {
 	underlyingErr := fmt.Errorf("database connection failed")
 	err := Internal(underlyingErr)
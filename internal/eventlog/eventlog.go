@@ -0,0 +1,49 @@
+// Package eventlog writes the events recorded by the services to a file,
+// one JSON object per line, for analysis after the event.
+package eventlog
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/abrezinsky/derbyvote/internal/services"
+)
+
+// File appends events to a JSON lines file
+type File struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+	err error
+}
+
+// Open opens path for appending, creating it if needed
+func Open(path string) (*File, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &File{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Record appends event as one line. After a failed write the remaining
+// events are dropped and Close reports the error.
+func (l *File) Record(event services.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return
+	}
+	l.err = l.enc.Encode(event)
+}
+
+// Close closes the file, returning the first write error if there was one
+func (l *File) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.f.Close(); err != nil && l.err == nil {
+		l.err = err
+	}
+	return l.err
+}
@@ -0,0 +1,101 @@
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abrezinsky/derbyvote/internal/services"
+)
+
+func readEvents(t *testing.T, path string) []services.Event {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+
+	var events []services.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event services.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestFile_AppendsOneLinePerEvent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	now := time.Date(2026, 5, 2, 18, 30, 0, 0, time.UTC)
+
+	l, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	l.Record(services.Event{Type: services.EventVotingOpened, Time: now})
+	l.Record(services.Event{Type: services.EventVoteCast, Time: now, VoterID: "abc", CategoryID: 2, CarID: 7})
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Reopening appends rather than truncating
+	l, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	l.Record(services.Event{Type: services.EventVotingClosed, Time: now})
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	events := readEvents(t, path)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	if events[1].VoterID != "abc" || events[1].CategoryID != 2 || events[1].CarID != 7 {
+		t.Errorf("unexpected vote event: %+v", events[1])
+	}
+	if !events[1].Time.Equal(now) {
+		t.Errorf("expected time %v, got %v", now, events[1].Time)
+	}
+	if events[2].Type != services.EventVotingClosed {
+		t.Errorf("expected last event %q, got %q", services.EventVotingClosed, events[2].Type)
+	}
+}
+
+func TestFile_OmitsEmptyFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	l.Record(services.Event{Type: services.EventVotingOpened})
+	l.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, key := range []string{"voter_id", "category_id", "car_id"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("expected %s to be omitted, got %s", key, data)
+		}
+	}
+}
+
+func TestOpen_MissingDirectory(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "missing", "events.jsonl")); err == nil {
+		t.Fatal("expected error opening a file in a missing directory")
+	}
+}
@@ -1,11 +1,23 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/models"
+	"github.com/abrezinsky/derbyvote/internal/pdf"
 	"github.com/abrezinsky/derbyvote/internal/services"
 	"github.com/abrezinsky/derbyvote/pkg/derbynet"
 )
@@ -13,7 +25,7 @@ import (
 // ==================== Public Pages ====================
 
 func (h *Handlers) handleIndex(w http.ResponseWriter, r *http.Request) {
-	h.templates.Index.Execute(w, nil)
+	h.templates.Index.Execute(w, PublicPageData{Branding: h.branding(r)})
 }
 
 // ==================== Admin Pages ====================
@@ -66,9 +78,15 @@ func (h *Handlers) handleAdminSettings(w http.ResponseWriter, r *http.Request) {
 // ==================== Categories ====================
 
 func (h *Handlers) handleGetCategories(w http.ResponseWriter, r *http.Request) {
-	categories, err := h.Category.ListAllCategories(r.Context())
+	var categories []map[string]interface{}
+	var err error
+	if tag := r.URL.Query().Get("tag"); tag != "" {
+		categories, err = h.Category.ListAllCategoriesByTag(r.Context(), tag)
+	} else {
+		categories, err = h.Category.ListAllCategories(r.Context())
+	}
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 	respondOK(w, categories)
@@ -77,7 +95,7 @@ func (h *Handlers) handleGetCategories(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) handleCreateCategory(w http.ResponseWriter, r *http.Request) {
 	var req CategoryCreateRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -88,10 +106,14 @@ func (h *Handlers) handleCreateCategory(w http.ResponseWriter, r *http.Request)
 		Active:            req.Active,
 		AllowedVoterTypes: req.AllowedVoterTypes,
 		AllowedRanks:      req.AllowedRanks,
+		Tags:              services.NormalizeTags(req.Tags),
+		AllowWriteIn:      req.AllowWriteIn,
+		ShowLiveCounts:    req.ShowLiveCounts,
+		BannerURL:         strings.TrimSpace(req.BannerURL),
 	}
 	id, err := h.Category.CreateCategory(r.Context(), cat)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -103,19 +125,23 @@ func (h *Handlers) handleCreateCategory(w http.ResponseWriter, r *http.Request)
 		Active:            true,
 		AllowedVoterTypes: cat.AllowedVoterTypes,
 		AllowedRanks:      cat.AllowedRanks,
+		Tags:              cat.Tags,
+		AllowWriteIn:      cat.AllowWriteIn,
+		ShowLiveCounts:    cat.ShowLiveCounts,
+		BannerURL:         cat.BannerURL,
 	})
 }
 
 func (h *Handlers) handleUpdateCategory(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIntParam(r, "id")
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	var req CategoryUpdateRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -126,9 +152,13 @@ func (h *Handlers) handleUpdateCategory(w http.ResponseWriter, r *http.Request)
 		Active:            req.Active,
 		AllowedVoterTypes: req.AllowedVoterTypes,
 		AllowedRanks:      req.AllowedRanks,
+		Tags:              services.NormalizeTags(req.Tags),
+		AllowWriteIn:      req.AllowWriteIn,
+		ShowLiveCounts:    req.ShowLiveCounts,
+		BannerURL:         strings.TrimSpace(req.BannerURL),
 	}
 	if err := h.Category.UpdateCategory(r.Context(), id, cat); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -140,13 +170,170 @@ func (h *Handlers) handleUpdateCategory(w http.ResponseWriter, r *http.Request)
 		Active:            cat.Active,
 		AllowedVoterTypes: cat.AllowedVoterTypes,
 		AllowedRanks:      cat.AllowedRanks,
+		Tags:              cat.Tags,
+		AllowWriteIn:      cat.AllowWriteIn,
+		ShowLiveCounts:    cat.ShowLiveCounts,
+		BannerURL:         cat.BannerURL,
 	})
 }
 
+// handleSetCategoryDerbyNetAward maps a category to a DerbyNet award (null clears it)
+func (h *Handlers) handleSetCategoryDerbyNetAward(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req CategoryDerbyNetAwardRequest
+	if err := decodeJSONFields(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := h.Category.SetDerbyNetAward(r.Context(), id, req.AwardID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, CategoryDerbyNetAwardResponse{ID: id, DerbyNetAwardID: req.AwardID})
+}
+
+// handleSetCategoryCars limits a category to a subset of cars
+func (h *Handlers) handleSetCategoryCars(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req CategoryCarsRequest
+	if err := decodeJSONFields(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.CarIDs == nil {
+		req.CarIDs = []int{}
+	}
+
+	if err := h.Category.SetCategoryCars(r.Context(), id, req.CarIDs); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, CategoryCarsResponse{ID: id, CarIDs: req.CarIDs})
+}
+
+// handleSetCategoryGroup moves a category to another group (null removes it from
+// its group) and reports conflicts the move creates
+func (h *Handlers) handleSetCategoryGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req CategoryGroupAssignRequest
+	if err := decodeJSONFields(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+	move, err := h.Category.SetCategoryGroup(ctx, id, req.GroupID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	multiWins, err := h.Results.DetectMultipleWins(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	involved := []services.MultiWinConflict{}
+	for _, conflict := range multiWins {
+		for _, categoryID := range conflict.CategoryIDs {
+			if categoryID == id {
+				involved = append(involved, conflict)
+				break
+			}
+		}
+	}
+
+	respondOK(w, CategoryGroupAssignResponse{
+		Category:             move.Category,
+		ExclusivityConflicts: move.ExclusivityConflicts,
+		MultiWins:            involved,
+	})
+}
+
+// categoryBannerFileName is the name of a category's uploaded banner inside the upload directory
+func categoryBannerFileName(id int) string {
+	return fmt.Sprintf("category-banner-%d", id)
+}
+
+// handleUploadCategoryBanner stores an uploaded banner image and points the category at it
+func (h *Handlers) handleUploadCategoryBanner(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if h.uploadDir == "" {
+		writeError(w, InternalError(fmt.Errorf("banner upload directory not configured")))
+		return
+	}
+
+	data, err := readImageUpload(w, r, "banner", "Banner")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := os.MkdirAll(h.uploadDir, 0o755); err != nil {
+		writeError(w, InternalError(err))
+		return
+	}
+	path := filepath.Join(h.uploadDir, categoryBannerFileName(id))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		writeError(w, InternalError(err))
+		return
+	}
+
+	bannerURL := fmt.Sprintf("/categories/%d/banner?v=%d", id, time.Now().Unix())
+	if err := h.Category.SetCategoryBannerURL(r.Context(), id, bannerURL); err != nil {
+		os.Remove(path)
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, CategoryBannerResponse{ID: id, BannerURL: bannerURL})
+}
+
+// handleCategoryBanner serves a category's uploaded banner
+func (h *Handlers) handleCategoryBanner(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil || h.uploadDir == "" {
+		writeError(w, NotFound("No banner uploaded"))
+		return
+	}
+
+	data, err := os.ReadFile(filepath.Join(h.uploadDir, categoryBannerFileName(id)))
+	if err != nil {
+		writeError(w, NotFound("No banner uploaded"))
+		return
+	}
+
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Write(data)
+}
+
 func (h *Handlers) handleDeleteCategory(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIntParam(r, "id")
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -157,32 +344,134 @@ func (h *Handlers) handleDeleteCategory(w http.ResponseWriter, r *http.Request)
 	if !force {
 		voteCount, err := h.Category.CountVotesForCategory(r.Context(), id)
 		if err != nil {
-			respondError(w, err)
+			writeError(w, err)
 			return
 		}
 		if voteCount > 0 {
-			// Return confirmation needed response
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusConflict)
-			w.Write([]byte(fmt.Sprintf(`{"error":"This category has received %d vote(s). Are you sure you want to delete it?","confirmation_required":true,"vote_count":%d}`, voteCount, voteCount)))
+			writeConfirmationRequired(w, fmt.Sprintf("This category has received %d vote(s). Are you sure you want to delete it?", voteCount), voteCount)
 			return
 		}
 	}
 
 	if err := h.Category.DeleteCategory(r.Context(), id); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	respondDeleted(w)
 }
 
+// handleGetCategoryVotes lists a category's votes with their timestamps, earliest first
+func (h *Handlers) handleGetCategoryVotes(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	votes, err := h.Results.ListVotesForCategory(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	loc := h.location(r)
+	for i := range votes {
+		votes[i].CreatedAt = formatTimestamp(votes[i].CreatedAt, loc)
+		votes[i].UpdatedAt = formatTimestamp(votes[i].UpdatedAt, loc)
+	}
+	respondOK(w, votes)
+}
+
+// handleExportCategoryBallots downloads one row per vote cast in a category for a
+// manual recount. Voter identity is hashed when anonymize_ballots is set.
+func (h *Handlers) handleExportCategoryBallots(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ballots, err := h.Results.GetCategoryBallots(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="category-%d-ballots.csv"`, id))
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"voter_id", "voter_name", "voter_type", "car_id", "car_number", "car_name", "racer_name", "write_in", "voted_at"})
+	for _, b := range ballots {
+		cw.Write([]string{
+			b.VoterID,
+			csvSafe(b.VoterName),
+			csvSafe(b.VoterType),
+			strconv.Itoa(b.CarID),
+			csvSafe(b.CarNumber),
+			csvSafe(b.CarName),
+			csvSafe(b.RacerName),
+			strconv.FormatBool(b.WriteIn),
+			b.VotedAt,
+		})
+	}
+	cw.Flush()
+}
+
+// csvSafe stops spreadsheet apps from treating a cell as a formula. Write-in
+// car names come straight from voters, so they can't be trusted.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// maxCategoryCSVSize limits the size of an uploaded category CSV
+const maxCategoryCSVSize = 1 << 20
+
+// handleImportCategories creates or updates categories from a CSV sent either
+// as the raw request body or as the "file" field of a multipart form
+func (h *Handlers) handleImportCategories(w http.ResponseWriter, r *http.Request) {
+	setBodyLimit(w, r, maxCategoryCSVSize+1024)
+
+	var src io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			writeError(w, BadRequest("Missing or oversized CSV file"))
+			return
+		}
+		defer file.Close()
+		src = file
+	}
+
+	data, err := io.ReadAll(io.LimitReader(src, maxCategoryCSVSize+1))
+	if _, tooLarge := bodyTooLarge(err); tooLarge || len(data) > maxCategoryCSVSize {
+		writeError(w, PayloadTooLarge("CSV must be 1MB or smaller"))
+		return
+	}
+	if err != nil {
+		writeError(w, BadRequest("Failed to read CSV"))
+		return
+	}
+
+	result, err := h.Category.ImportCategoriesCSV(r.Context(), bytes.NewReader(data))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, result)
+}
+
 // ==================== Category Groups ====================
 
 func (h *Handlers) handleGetCategoryGroups(w http.ResponseWriter, r *http.Request) {
 	groups, err := h.Category.ListGroups(r.Context())
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 	respondOK(w, groups)
@@ -191,20 +480,22 @@ func (h *Handlers) handleGetCategoryGroups(w http.ResponseWriter, r *http.Reques
 func (h *Handlers) handleCreateCategoryGroup(w http.ResponseWriter, r *http.Request) {
 	var req CategoryGroupCreateRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	group := services.CategoryGroup{
-		Name:              req.Name,
-		Description:       req.Description,
-		ExclusivityPoolID: req.ExclusivityPoolID,
-		MaxWinsPerCar:     req.MaxWinsPerCar,
-		DisplayOrder:      req.DisplayOrder,
+		Name:                req.Name,
+		Description:         req.Description,
+		ExclusivityPoolID:   req.ExclusivityPoolID,
+		ExclusivityPoolName: req.ExclusivityPoolName,
+		MaxWinsPerCar:       req.MaxWinsPerCar,
+		MultiWinStrategy:    req.MultiWinStrategy,
+		DisplayOrder:        req.DisplayOrder,
 	}
 	id, err := h.Category.CreateGroup(r.Context(), group)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -214,13 +505,13 @@ func (h *Handlers) handleCreateCategoryGroup(w http.ResponseWriter, r *http.Requ
 func (h *Handlers) handleGetCategoryGroup(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		respondError(w, BadRequest("Invalid category group ID"))
+		writeError(w, BadRequest("Invalid category group ID"))
 		return
 	}
 
 	group, err := h.Category.GetGroup(r.Context(), id)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -230,32 +521,34 @@ func (h *Handlers) handleGetCategoryGroup(w http.ResponseWriter, r *http.Request
 func (h *Handlers) handleUpdateCategoryGroup(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		respondError(w, BadRequest("Invalid category group ID"))
+		writeError(w, BadRequest("Invalid category group ID"))
 		return
 	}
 
 	// Check if group exists first
 	_, err := h.Category.GetGroup(r.Context(), id)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	var req CategoryGroupUpdateRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	group := services.CategoryGroup{
-		Name:              req.Name,
-		Description:       req.Description,
-		ExclusivityPoolID: req.ExclusivityPoolID,
-		MaxWinsPerCar:     req.MaxWinsPerCar,
-		DisplayOrder:      req.DisplayOrder,
+		Name:                req.Name,
+		Description:         req.Description,
+		ExclusivityPoolID:   req.ExclusivityPoolID,
+		ExclusivityPoolName: req.ExclusivityPoolName,
+		MaxWinsPerCar:       req.MaxWinsPerCar,
+		MultiWinStrategy:    req.MultiWinStrategy,
+		DisplayOrder:        req.DisplayOrder,
 	}
 	if err := h.Category.UpdateGroup(r.Context(), id, group); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -265,19 +558,83 @@ func (h *Handlers) handleUpdateCategoryGroup(w http.ResponseWriter, r *http.Requ
 func (h *Handlers) handleDeleteCategoryGroup(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		respondError(w, BadRequest("Invalid category group ID"))
+		writeError(w, BadRequest("Invalid category group ID"))
 		return
 	}
 
 	// Check if group exists first
 	_, err := h.Category.GetGroup(r.Context(), id)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	if err := h.Category.DeleteGroup(r.Context(), id); err != nil {
-		respondError(w, err)
+		writeError(w, err)
+		return
+	}
+
+	respondDeleted(w)
+}
+
+// ==================== Exclusivity Pools ====================
+
+func (h *Handlers) handleGetExclusivityPools(w http.ResponseWriter, r *http.Request) {
+	pools, err := h.Category.ListPools(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respondOK(w, pools)
+}
+
+func (h *Handlers) handleCreateExclusivityPool(w http.ResponseWriter, r *http.Request) {
+	var req ExclusivityPoolRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	id, err := h.Category.CreatePool(r.Context(), req.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondCreated(w, ExclusivityPoolResponse{ID: id, Name: strings.TrimSpace(req.Name)})
+}
+
+func (h *Handlers) handleUpdateExclusivityPool(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req ExclusivityPoolRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := h.Category.UpdatePool(r.Context(), id, req.Name); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondSuccess(w, "Exclusivity pool updated")
+}
+
+// handleDeleteExclusivityPool deletes a pool; its groups remain, without a pool
+func (h *Handlers) handleDeleteExclusivityPool(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := h.Category.DeletePool(r.Context(), id); err != nil {
+		writeError(w, err)
 		return
 	}
 
@@ -289,7 +646,7 @@ func (h *Handlers) handleDeleteCategoryGroup(w http.ResponseWriter, r *http.Requ
 func (h *Handlers) handleSetVotingStatus(w http.ResponseWriter, r *http.Request) {
 	var req VotingStatusRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -301,52 +658,317 @@ func (h *Handlers) handleSetVotingStatus(w http.ResponseWriter, r *http.Request)
 		err = h.Settings.CloseVoting(ctx)
 	}
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	respondOK(w, VotingStatusResponse{Open: req.Open})
 }
 
+// handleFinalizeVoting closes voting and freezes the results unless conflicts
+// remain, optionally embargoing them until the ceremony
+func (h *Handlers) handleFinalizeVoting(w http.ResponseWriter, r *http.Request) {
+	var req FinalizeVotingRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSON(r, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	if req.Embargo {
+		if err := services.ValidateRevealCode(req.RevealCode); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	ctx := r.Context()
+	result, err := h.Results.FinalizeVoting(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if result.Snapshot == nil {
+		respondJSON(w, http.StatusConflict, FinalizeConflictResponse{
+			Error:             Conflict("Voting is closed, but results were not frozen: resolve all conflicts (ties or multiple wins) and finalize again."),
+			ConflictsResponse: newConflictsResponse(result.Ties, result.MultiWins),
+		})
+		return
+	}
+
+	if req.Embargo {
+		if err := h.Results.EmbargoResults(ctx, req.RevealCode); err != nil {
+			writeError(w, err)
+			return
+		}
+		respondOK(w, FinalizeVotingResponse{VotingOpen: false, Embargoed: true})
+		return
+	}
+
+	respondOK(w, FinalizeVotingResponse{VotingOpen: false, Snapshot: result.Snapshot})
+}
+
+// handleVerifyResults recounts the raw votes and reports any category whose
+// tallies disagree with the primary results
+func (h *Handlers) handleVerifyResults(w http.ResponseWriter, r *http.Request) {
+	verification, err := h.Results.VerifyTallies(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respondOK(w, verification)
+}
+
+// handleRevealResults lifts the results embargo. The reveal code comes from
+// the X-Reveal header or, failing that, the body.
+func (h *Handlers) handleRevealResults(w http.ResponseWriter, r *http.Request) {
+	code := r.Header.Get(RevealHeader)
+	if code == "" && r.ContentLength != 0 {
+		var req RevealResultsRequest
+		if err := decodeJSON(r, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+		code = req.RevealCode
+	}
+
+	if err := h.Results.RevealResults(r.Context(), code); err != nil {
+		writeError(w, err)
+		return
+	}
+	respondOK(w, RevealResultsResponse{Embargoed: false})
+}
+
 func (h *Handlers) handleSetVotingTimer(w http.ResponseWriter, r *http.Request) {
 	var req VotingTimerRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	closeTimeStr, err := h.Settings.StartVotingTimer(r.Context(), req.Minutes)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	respondOK(w, VotingTimerResponse{
-		CloseTime: closeTimeStr,
+		CloseTime: formatTimestamp(closeTimeStr, h.location(r)),
 		Minutes:   req.Minutes,
 	})
 }
 
+// handleGetVotingTimerPresets returns the configured quick-pick timer durations
+func (h *Handlers) handleGetVotingTimerPresets(w http.ResponseWriter, r *http.Request) {
+	presets, err := h.Settings.GetVotingTimerPresets(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	maxMinutes, err := h.Settings.GetMaxVotingMinutes(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, VotingTimerPresetsResponse{Presets: presets, MaxMinutes: maxMinutes})
+}
+
 // ==================== Stats & Results ====================
 
 func (h *Handlers) handleGetStats(w http.ResponseWriter, r *http.Request) {
 	stats, err := h.Results.GetStats(r.Context())
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	respondOK(w, stats)
 }
 
+// handleGetVoterGroupStats reports turnout for each voter group
+func (h *Handlers) handleGetVoterGroupStats(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.Voter.VoterGroupTurnout(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respondOK(w, groups)
+}
+
+// DefaultTopCars is how many cars the overall leaderboard lists without ?limit=
+const DefaultTopCars = 10
+
+// handleGetTopCars ranks cars by their votes across all categories. Pass
+// ?breakdown=true for each car's per-category votes.
+func (h *Handlers) handleGetTopCars(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseIntQuery(r, "limit")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if limit == 0 {
+		limit = DefaultTopCars
+	}
+	breakdown := r.URL.Query().Get("breakdown") == "true"
+
+	cars, err := h.Results.TopCars(r.Context(), limit, breakdown)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respondOK(w, cars)
+}
+
+// refreshResultsIfRequested drops cached results when the request asks for ?refresh=true
+func (h *Handlers) refreshResultsIfRequested(r *http.Request) {
+	if r.URL.Query().Get("refresh") == "true" {
+		h.Results.InvalidateCache()
+	}
+}
+
+// resultsETag derives an entity tag from the results version and the
+// representation served. The modified time keeps tags unique across restarts,
+// which reset the version counter.
+func resultsETag(v services.ResultsVersion, contentType string) string {
+	var suffix string
+	switch contentType {
+	case contentTypeCSV:
+		suffix = "-csv"
+	case contentTypePDF:
+		suffix = "-pdf"
+	}
+	return fmt.Sprintf(`"results-%d-%d%s"`, v.ModifiedAt.UnixNano(), v.Version, suffix)
+}
+
+// contentTypeCSV is the media type clients send in Accept to get results as CSV
+const contentTypeCSV = "text/csv"
+
+// handleGetResults returns the results as JSON, or as CSV or PDF when the
+// Accept header prefers them, with each category's cars in the ?sort= order
 func (h *Handlers) handleGetResults(w http.ResponseWriter, r *http.Request) {
+	order := r.URL.Query().Get("sort")
+	if err := services.ValidateResultSort(order); err != nil {
+		writeError(w, err)
+		return
+	}
+	h.refreshResultsIfRequested(r)
+	contentType := negotiateContentType(r, "application/json", contentTypeCSV, contentTypePDF)
+	w.Header().Set("Vary", "Accept")
+
+	// Test voters are a debugging view; it shares the version with normal
+	// results, so it is never served conditionally
+	if r.URL.Query().Get("include_test") == "true" {
+		results, err := h.Results.GetResultsIncludingTest(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		respondResults(w, contentType, results, order)
+		return
+	}
+
+	// A forced refresh is for data changed outside the app, which the version
+	// cannot see, so it skips the conditional check and returns full results
+	if r.URL.Query().Get("refresh") != "true" {
+		version := h.Results.Version()
+		if respondNotModified(w, r, resultsETag(version, contentType), version.ModifiedAt) {
+			return
+		}
+	}
+
 	results, err := h.Results.GetResults(r.Context())
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
+	respondResults(w, contentType, results, order)
+}
+
+// respondResults writes the results in the negotiated representation, with
+// each category's cars in the given display order
+func respondResults(w http.ResponseWriter, contentType string, results *services.FullResults, order string) {
+	for _, cat := range results.Categories {
+		services.SortCarResults(cat.Votes, order)
+	}
+	switch contentType {
+	case contentTypeCSV:
+		writeResultsCSV(w, results)
+	case contentTypePDF:
+		writeResultsPDF(w, results)
+	default:
+		respondResultCategories(w, results)
+	}
+}
+
+// writeResultsCSV writes one row per ranked car in each category
+func writeResultsCSV(w http.ResponseWriter, results *services.FullResults) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="results.csv"`)
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"category_id", "category_name", "group_name", "total_votes", "rank", "car_id", "car_number", "car_name", "racer_name", "vote_count", "write_in"})
+	for _, cat := range results.Categories {
+		for _, car := range cat.Votes {
+			cw.Write([]string{
+				strconv.Itoa(cat.CategoryID),
+				csvSafe(cat.CategoryName),
+				csvSafe(cat.GroupName),
+				strconv.Itoa(cat.TotalVotes),
+				strconv.Itoa(car.Rank),
+				strconv.Itoa(car.CarID),
+				csvSafe(car.CarNumber),
+				csvSafe(car.CarName),
+				csvSafe(car.RacerName),
+				strconv.Itoa(car.VoteCount),
+				strconv.FormatBool(car.WriteIn),
+			})
+		}
+	}
+	cw.Flush()
+}
+
+// writeResultsPDF writes each category's ranked cars as a printable PDF
+func writeResultsPDF(w http.ResponseWriter, results *services.FullResults) {
+	doc := pdf.New("Voting Results")
+	doc.Title("Voting Results")
+	if len(results.Categories) == 0 {
+		doc.Text("No active categories.")
+	}
+	for _, cat := range results.Categories {
+		heading := cat.CategoryName
+		if cat.GroupName != "" {
+			heading += " (" + cat.GroupName + ")"
+		}
+		doc.Heading(heading)
+		doc.Text(fmt.Sprintf("%d votes", cat.TotalVotes))
+		for _, car := range cat.Votes {
+			line := fmt.Sprintf("%d. #%s %s", car.Rank, car.CarNumber, car.RacerName)
+			if car.CarName != "" {
+				line += fmt.Sprintf(" (%s)", car.CarName)
+			}
+			line += fmt.Sprintf(" - %d votes", car.VoteCount)
+			if car.WriteIn {
+				line += " [write-in]"
+			}
+			if cat.OverrideCarID != nil && *cat.OverrideCarID == car.CarID {
+				line += " [override]"
+			}
+			doc.Text(line)
+		}
+		if cat.HasOverride && cat.OverrideReason != "" {
+			doc.Text("Override reason: " + cat.OverrideReason)
+		}
+	}
 
-	// Ensure we return an empty array, not null
+	w.Header().Set("Content-Type", contentTypePDF)
+	w.Header().Set("Content-Disposition", `attachment; filename="results.pdf"`)
+	doc.WriteTo(w)
+}
+
+// respondResultCategories writes the category results, as an empty array rather than null
+func respondResultCategories(w http.ResponseWriter, results *services.FullResults) {
 	categories := results.Categories
 	if categories == nil {
 		categories = []services.CategoryResult{}
@@ -354,108 +976,313 @@ func (h *Handlers) handleGetResults(w http.ResponseWriter, r *http.Request) {
 	respondOK(w, categories)
 }
 
+// handleGetCategoryResults returns one active category's ranked cars, override
+// and conflict state
+func (h *Handlers) handleGetCategoryResults(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := parseIntParam(r, "categoryID")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	order := r.URL.Query().Get("sort")
+	if err := services.ValidateResultSort(order); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+	h.refreshResultsIfRequested(r)
+
+	result, err := h.Results.GetCategoryResults(ctx, categoryID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if result == nil {
+		writeError(w, NotFound("Category not found"))
+		return
+	}
+	if result.Votes == nil {
+		result.Votes = []services.CarResult{}
+	}
+	services.SortCarResults(result.Votes, order)
+
+	ties, err := h.Results.DetectTies(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	multiWins, err := h.Results.DetectMultipleWins(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Keep only the conflicts that involve this category
+	var categoryTies []services.TieConflict
+	for _, tie := range ties {
+		if tie.CategoryID == categoryID {
+			categoryTies = append(categoryTies, tie)
+		}
+	}
+	var categoryMultiWins []services.MultiWinConflict
+	for _, mw := range multiWins {
+		for _, id := range mw.CategoryIDs {
+			if id == categoryID {
+				categoryMultiWins = append(categoryMultiWins, mw)
+				break
+			}
+		}
+	}
+
+	respondOK(w, CategoryResultsResponse{
+		CategoryResult: *result,
+		HasConflicts:   len(services.ExactTies(categoryTies)) > 0 || len(categoryMultiWins) > 0,
+		Conflicts:      newConflictsResponse(categoryTies, categoryMultiWins),
+	})
+}
+
 // ==================== DerbyNet Sync ====================
 
 func (h *Handlers) handleSyncDerbyNet(w http.ResponseWriter, r *http.Request) {
 	var req DerbyNetSyncRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
+		return
+	}
+
+	if req.DerbyNetURL == "" {
+		writeError(w, BadRequest("derbynet_url is required"))
+		return
+	}
+
+	result, err := h.Car.SyncFromDerbyNet(r.Context(), req.DerbyNetURL)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, result)
+}
+
+// handleSyncVotersDerbyNet creates a voter for each DerbyNet racer whose car
+// has no voter yet
+func (h *Handlers) handleSyncVotersDerbyNet(w http.ResponseWriter, r *http.Request) {
+	var req DerbyNetSyncRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if req.DerbyNetURL == "" {
+		writeError(w, BadRequest("derbynet_url is required"))
+		return
+	}
+
+	result, err := h.Car.SyncVotersFromDerbyNet(r.Context(), req.DerbyNetURL)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, result)
+}
+
+func (h *Handlers) handleSyncCategoriesDerbyNet(w http.ResponseWriter, r *http.Request) {
+	var req DerbyNetSyncRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if req.DerbyNetURL == "" {
+		writeError(w, BadRequest("derbynet_url is required"))
+		return
+	}
+
+	result, err := h.Category.SyncFromDerbyNet(r.Context(), req.DerbyNetURL)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, result)
+}
+
+// handleTestDerbyNet checks connectivity to a DerbyNet server, timing each
+// call so slow or flaky WiFi can be spotted. It only reads from DerbyNet and
+// uses a throwaway client, so it is safe to retry. An unreachable URL falls
+// back to the fallback URL, and active_url reports which one answered.
+func (h *Handlers) handleTestDerbyNet(w http.ResponseWriter, r *http.Request) {
+	var req DerbyNetTestRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
 		return
 	}
 
 	if req.DerbyNetURL == "" {
-		respondError(w, BadRequest("derbynet_url is required"))
+		writeError(w, BadRequest("derbynet_url is required"))
+		return
+	}
+
+	// Create a temporary DerbyNet client that records each response
+	transport := &recordingTransport{base: derbynet.NewTransport()}
+	jar, _ := cookiejar.New(nil)
+	client := derbynet.NewHTTPClientWithHTTPClient(req.DerbyNetURL, &http.Client{
+		Timeout:   30 * time.Second,
+		Jar:       jar,
+		Transport: transport,
+	}, logger.New())
+	fallback := req.DerbyNetURLFallback
+	if fallback == "" {
+		fallback, _ = h.Settings.GetSetting(r.Context(), "derbynet_url_fallback")
+	}
+	client.SetFallbackURL(fallback)
+
+	// Try to fetch racers to test basic connectivity
+	var racers []derbynet.Racer
+	racerCall := transport.timeCall("racer.list", func() (err error) {
+		racers, err = client.FetchRacers(r.Context())
+		return err
+	})
+	if racerCall.Error != "" {
+		writeError(w, BadRequest("Failed to connect to DerbyNet: "+racerCall.Error))
 		return
 	}
 
-	result, err := h.Car.SyncFromDerbyNet(r.Context(), req.DerbyNetURL)
-	if err != nil {
-		respondError(w, err)
-		return
+	// Try to fetch awards; they might fail if not authenticated, but the connection works
+	var awards []derbynet.Award
+	awardCall := transport.timeCall("award.list", func() (err error) {
+		awards, err = client.FetchAwards(r.Context())
+		return err
+	})
+
+	// Check if we have credentials to test authentication
+	role, _ := h.Settings.GetSetting(r.Context(), "derbynet_role")
+	password, _ := h.Settings.GetSetting(r.Context(), "derbynet_password")
+	authenticated := false
+	authError := ""
+	if role != "" && password != "" {
+		if err := client.Login(r.Context(), role, password); err != nil {
+			authError = derbynet.Explain(err)
+		} else {
+			authenticated = true
+		}
+	}
+
+	sessionCookie := false
+	if u, err := url.Parse(client.BaseURL()); err == nil {
+		sessionCookie = len(jar.Cookies(u)) > 0
 	}
 
-	respondOK(w, result)
+	respondOK(w, DerbyNetTestResponse{
+		Status:        "success",
+		TotalRacers:   len(racers),
+		TotalAwards:   len(awards),
+		Authenticated: authenticated,
+		Role:          role,
+		ActiveURL:     client.BaseURL(),
+		AuthError:     authError,
+		RacerList:     racerCall,
+		AwardList:     awardCall,
+		SessionCookie: sessionCookie,
+		Server:        transport.server,
+	})
 }
 
-func (h *Handlers) handleSyncCategoriesDerbyNet(w http.ResponseWriter, r *http.Request) {
-	var req DerbyNetSyncRequest
-	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+// handleGetDerbyNetStatus returns the cached result of the background DerbyNet
+// health check, or runs a check now with ?refresh=true
+func (h *Handlers) handleGetDerbyNetStatus(w http.ResponseWriter, r *http.Request) {
+	if h.DerbyNetHealth == nil {
+		writeError(w, InternalError(fmt.Errorf("DerbyNet health polling not configured")))
 		return
 	}
-
-	if req.DerbyNetURL == "" {
-		respondError(w, BadRequest("derbynet_url is required"))
+	if r.URL.Query().Get("refresh") == "true" {
+		respondOK(w, h.DerbyNetHealth.Check(r.Context()))
 		return
 	}
+	respondOK(w, h.DerbyNetHealth.Status(r.Context()))
+}
 
-	result, err := h.Category.SyncFromDerbyNet(r.Context(), req.DerbyNetURL)
+// recordingTransport remembers the status and Server header of the last
+// DerbyNet response so connection tests can report them
+type recordingTransport struct {
+	base       http.RoundTripper
+	lastStatus int
+	server     string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
 	if err != nil {
-		respondError(w, err)
-		return
+		t.lastStatus = 0
+		return nil, err
 	}
-
-	respondOK(w, result)
+	t.lastStatus = resp.StatusCode
+	if server := resp.Header.Get("Server"); server != "" {
+		t.server = server
+	}
+	return resp, nil
 }
 
-func (h *Handlers) handleTestDerbyNet(w http.ResponseWriter, r *http.Request) {
-	var req DerbyNetSyncRequest
-	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
-		return
+// timeCall runs one DerbyNet call and reports its latency, HTTP status and error
+func (t *recordingTransport) timeCall(query string, call func() error) DerbyNetCallResult {
+	t.lastStatus = 0
+	start := time.Now()
+	err := call()
+	result := DerbyNetCallResult{
+		Query:      query,
+		LatencyMS:  time.Since(start).Milliseconds(),
+		HTTPStatus: t.lastStatus,
+	}
+	if err != nil {
+		result.Error = err.Error()
 	}
+	return result
+}
 
-	if req.DerbyNetURL == "" {
-		respondError(w, BadRequest("derbynet_url is required"))
+// handleGetDerbyNetAwards lists the awards available in DerbyNet for category mapping
+func (h *Handlers) handleGetDerbyNetAwards(w http.ResponseWriter, r *http.Request) {
+	awards, err := h.Category.ListDerbyNetAwards(r.Context())
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	// Create a temporary DerbyNet client
-	client := derbynet.NewHTTPClient(req.DerbyNetURL, logger.New())
+	respondOK(w, awards)
+}
 
-	// Try to fetch racers to test basic connectivity
-	racers, err := client.FetchRacers(r.Context())
+// handleGetDerbyNetCategoryDiff previews a category sync without changing anything
+func (h *Handlers) handleGetDerbyNetCategoryDiff(w http.ResponseWriter, r *http.Request) {
+	diff, err := h.Category.DerbyNetCategoryDiff(r.Context())
 	if err != nil {
-		respondError(w, BadRequest("Failed to connect to DerbyNet: "+err.Error()))
+		writeError(w, err)
 		return
 	}
 
-	// Try to fetch awards
-	awards, err := client.FetchAwards(r.Context())
-	if err != nil {
-		// Awards might fail if not authenticated, but connection works
-		awards = []derbynet.Award{}
-	}
+	respondOK(w, diff)
+}
 
-	// Check if we have credentials to test authentication
-	role, _ := h.Settings.GetSetting(r.Context(), "derbynet_role")
-	password, _ := h.Settings.GetSetting(r.Context(), "derbynet_password")
-	authenticated := false
-	if role != "" && password != "" {
-		client.SetCredentials(role, password)
-		// Try to fetch award types which requires some level of access
-		_, authErr := client.FetchAwardTypes(r.Context())
-		authenticated = authErr == nil
+// handleGetDerbyNetRacers lists the racers in DerbyNet for car mapping
+func (h *Handlers) handleGetDerbyNetRacers(w http.ResponseWriter, r *http.Request) {
+	racers, err := h.Car.ListDerbyNetRacers(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
 	}
 
-	respondOK(w, map[string]interface{}{
-		"status":        "success",
-		"total_racers":  len(racers),
-		"total_awards":  len(awards),
-		"authenticated": authenticated,
-		"role":          role,
-	})
+	respondOK(w, racers)
 }
 
 func (h *Handlers) handlePushResultsDerbyNet(w http.ResponseWriter, r *http.Request) {
-	var req DerbyNetSyncRequest
+	var req ResultsPushRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	if req.DerbyNetURL == "" {
-		respondError(w, BadRequest("derbynet_url is required"))
+		writeError(w, BadRequest("derbynet_url is required"))
 		return
 	}
 
@@ -464,50 +1291,93 @@ func (h *Handlers) handlePushResultsDerbyNet(w http.ResponseWriter, r *http.Requ
 	// Check for conflicts before pushing
 	ties, err := h.Results.DetectTies(ctx)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	multiWins, err := h.Results.DetectMultipleWins(ctx)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
-	if len(ties) > 0 || len(multiWins) > 0 {
-		respondError(w, Conflict("Cannot push results: conflicts exist (ties or multiple wins). Please resolve all conflicts first."))
+	if len(services.ExactTies(ties)) > 0 || len(multiWins) > 0 {
+		writeError(w, Conflict("Cannot push results: conflicts exist (ties or multiple wins). Please resolve all conflicts first."))
 		return
 	}
 
-	result, err := h.Results.PushResultsToDerbyNet(ctx, req.DerbyNetURL)
+	result, err := h.Results.PushResultsToDerbyNet(ctx, req.DerbyNetURL, req.DryRun)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	respondOK(w, result)
 }
 
+// handleGetPushHistory lists past pushes of results to DerbyNet, newest first
+func (h *Handlers) handleGetPushHistory(w http.ResponseWriter, r *http.Request) {
+	records, err := h.Results.ListPushHistory(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	loc := h.location(r)
+	for i := range records {
+		records[i].PushedAt = formatTimestamp(records[i].PushedAt, loc)
+	}
+	respondOK(w, records)
+}
+
+// handleGetPushReadiness reports winners that could not be pushed to DerbyNet
+func (h *Handlers) handleGetPushReadiness(w http.ResponseWriter, r *http.Request) {
+	readiness, err := h.Results.PushReadiness(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respondOK(w, readiness)
+}
+
 // handleGetConflicts returns all detected ties and multiple-win conflicts
 func (h *Handlers) handleGetConflicts(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	h.refreshResultsIfRequested(r)
 
 	// Detect ties
 	ties, err := h.Results.DetectTies(ctx)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	// Detect multiple wins
 	multiWins, err := h.Results.DetectMultipleWins(ctx)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, newConflictsResponse(ties, multiWins))
+}
+
+// handleGetResolutionStatus returns each category's conflict-resolution state
+func (h *Handlers) handleGetResolutionStatus(w http.ResponseWriter, r *http.Request) {
+	h.refreshResultsIfRequested(r)
+
+	status, err := h.Results.ResolutionStatus(r.Context())
+	if err != nil {
+		writeError(w, err)
 		return
 	}
+	respondOK(w, status)
+}
 
-	// Build response
+// newConflictsResponse converts detected conflicts to their API representation
+func newConflictsResponse(ties []services.TieConflict, multiWins []services.MultiWinConflict) ConflictsResponse {
 	tieResponses := []TieConflictResponse{}
+	nearTieResponses := []TieConflictResponse{}
 	for _, tie := range ties {
 		var tiedCars []TiedCarResponse
 		for _, car := range tie.TiedCars {
@@ -519,11 +1389,17 @@ func (h *Handlers) handleGetConflicts(w http.ResponseWriter, r *http.Request) {
 				VoteCount: car.VoteCount,
 			})
 		}
-		tieResponses = append(tieResponses, TieConflictResponse{
+		tieResponse := TieConflictResponse{
 			CategoryID:   tie.CategoryID,
 			CategoryName: tie.CategoryName,
 			TiedCars:     tiedCars,
-		})
+			Margin:       tie.Margin,
+		}
+		if tie.NearTie {
+			nearTieResponses = append(nearTieResponses, tieResponse)
+		} else {
+			tieResponses = append(tieResponses, tieResponse)
+		}
 	}
 
 	multiWinResponses := []MultiWinConflictResponse{}
@@ -540,47 +1416,62 @@ func (h *Handlers) handleGetConflicts(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	respondOK(w, ConflictsResponse{
+	return ConflictsResponse{
 		Ties:      tieResponses,
+		NearTies:  nearTieResponses,
 		MultiWins: multiWinResponses,
-	})
+	}
+}
+
+// handleGetResultsSnapshot returns the results frozen when voting was finalized
+func (h *Handlers) handleGetResultsSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.Results.GetResultsSnapshot(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if snapshot == nil {
+		writeError(w, NotFound("Results have not been frozen"))
+		return
+	}
+	respondOK(w, snapshot)
 }
 
 // handleOverrideWinner sets a manual winner for a category
 func (h *Handlers) handleOverrideWinner(w http.ResponseWriter, r *http.Request) {
 	var req OverrideWinnerRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	if req.CategoryID == 0 {
-		respondError(w, BadRequest("category_id is required"))
+		writeError(w, BadRequest("category_id is required"))
 		return
 	}
 	if req.CarID == 0 {
-		respondError(w, BadRequest("car_id is required"))
+		writeError(w, BadRequest("car_id is required"))
 		return
 	}
 	if req.Reason == "" {
-		respondError(w, BadRequest("reason is required"))
+		writeError(w, BadRequest("reason is required"))
 		return
 	}
 
 	// Check if voting is still open
 	votingOpen, err := h.Settings.IsVotingOpen(r.Context())
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 	if votingOpen {
-		respondError(w, BadRequest("Cannot resolve conflicts while voting is still open"))
+		writeError(w, BadRequest("Cannot resolve conflicts while voting is still open"))
 		return
 	}
 
 	err = h.Results.SetManualWinner(r.Context(), req.CategoryID, req.CarID, req.Reason)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -589,28 +1480,62 @@ func (h *Handlers) handleOverrideWinner(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// handleRandomTieBreak breaks a category's tie by random draw, recorded as a
+// manual override
+func (h *Handlers) handleRandomTieBreak(w http.ResponseWriter, r *http.Request) {
+	var req RandomTieBreakRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if req.CategoryID == 0 {
+		writeError(w, BadRequest("category_id is required"))
+		return
+	}
+
+	// Check if voting is still open
+	votingOpen, err := h.Settings.IsVotingOpen(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if votingOpen {
+		writeError(w, BadRequest("Cannot resolve conflicts while voting is still open"))
+		return
+	}
+
+	result, err := h.Results.RandomTieBreak(r.Context(), req.CategoryID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, result)
+}
+
 // handleClearOverride clears the manual winner override for a category
 func (h *Handlers) handleClearOverride(w http.ResponseWriter, r *http.Request) {
 	categoryID, err := parseIntParam(r, "categoryID")
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	// Check if voting is still open
 	votingOpen, err := h.Settings.IsVotingOpen(r.Context())
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 	if votingOpen {
-		respondError(w, BadRequest("Cannot clear conflict resolution while voting is still open"))
+		writeError(w, BadRequest("Cannot clear conflict resolution while voting is still open"))
 		return
 	}
 
 	err = h.Results.ClearManualWinner(r.Context(), categoryID)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -621,15 +1546,18 @@ func (h *Handlers) handleClearOverride(w http.ResponseWriter, r *http.Request) {
 
 // handleGetOverrides returns all categories with manual overrides
 func (h *Handlers) handleGetOverrides(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	// Get results which includes override info
-	results, err := h.Results.GetResults(ctx)
+	results, err := h.Results.GetResults(r.Context())
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
+	respondOK(w, overridesFromResults(results))
+}
+
+// overridesFromResults lists the categories in results whose winner was set manually
+func overridesFromResults(results *services.FullResults) []OverrideResponse {
 	overrides := []OverrideResponse{}
 	for _, cat := range results.Categories {
 		if cat.HasOverride && cat.OverrideCarID != nil {
@@ -654,8 +1582,150 @@ func (h *Handlers) handleGetOverrides(w http.ResponseWriter, r *http.Request) {
 			})
 		}
 	}
+	return overrides
+}
+
+// ==================== Event Report ====================
+
+// contentTypePDF is the media type clients send in Accept to get results or
+// the event report as a PDF
+const contentTypePDF = "application/pdf"
+
+// handleGetEventReport returns the summary handed to pack leadership after
+// the event, as JSON or as a printable PDF when the Accept header prefers
+// application/pdf
+func (h *Handlers) handleGetEventReport(w http.ResponseWriter, r *http.Request) {
+	report, err := h.eventReport(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept")
+	if negotiateContentType(r, "application/json", contentTypePDF) == contentTypePDF {
+		writeEventReportPDF(w, report, h.location(r))
+		return
+	}
+	respondOK(w, report)
+}
+
+// eventReport assembles the report from the stats, turnout, results, final
+// winners and overrides the other admin endpoints serve
+func (h *Handlers) eventReport(r *http.Request) (*EventReportResponse, error) {
+	ctx := r.Context()
+
+	branding, err := h.Settings.GetBranding(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := h.Results.GetStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	turnout, err := h.Voter.VoterGroupTurnout(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results, err := h.Results.GetResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	winners, err := h.Results.GetFinalWinners(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	winnerByCategory := make(map[int]map[string]interface{})
+	for _, w := range winners {
+		if id, ok := w["category_id"].(int); ok {
+			winnerByCategory[id], _ = w["winner"].(map[string]interface{})
+		}
+	}
+	categories := make([]EventReportCategory, 0, len(results.Categories))
+	for _, cat := range results.Categories {
+		categories = append(categories, EventReportCategory{
+			CategoryID:   cat.CategoryID,
+			CategoryName: cat.CategoryName,
+			GroupName:    cat.GroupName,
+			TotalVotes:   cat.TotalVotes,
+			Winner:       winnerByCategory[cat.CategoryID],
+		})
+	}
+
+	return &EventReportResponse{
+		EventName:   branding.EventName,
+		GeneratedAt: time.Now().In(h.location(r)).Format(time.RFC3339),
+		Stats:       stats,
+		Turnout:     turnout,
+		Categories:  categories,
+		Overrides:   overridesFromResults(results),
+	}, nil
+}
+
+// writeEventReportPDF renders the report as a PDF download
+func writeEventReportPDF(w http.ResponseWriter, report *EventReportResponse, loc *time.Location) {
+	doc := pdf.New(report.EventName + " Event Summary")
+	doc.Title(report.EventName + " Event Summary")
+	if generated, err := time.Parse(time.RFC3339, report.GeneratedAt); err == nil {
+		doc.Text("Generated " + generated.Format("January 2, 2006 3:04 PM MST"))
+	}
+
+	doc.Heading("Turnout")
+	doc.Text(fmt.Sprintf("Registered voters: %v", report.Stats["total_voters"]))
+	voted := fmt.Sprintf("Voters who voted: %v", report.Stats["voters_who_voted"])
+	if rate, ok := report.Stats["participation_rate"].(float64); ok {
+		voted += fmt.Sprintf(" (%.0f%%)", rate*100)
+	}
+	doc.Text(voted)
+	doc.Text(fmt.Sprintf("Total votes: %v", report.Stats["total_votes"]))
+	if len(report.Turnout) > 1 {
+		for _, group := range report.Turnout {
+			name := group.VoterGroup
+			if name == "" {
+				name = "No group"
+			}
+			doc.Text(fmt.Sprintf("%s: %d of %d voted (%.0f%%)", name, group.Voted, group.Voters, group.TurnoutRate*100))
+		}
+	}
+
+	doc.Heading("Categories")
+	if len(report.Categories) == 0 {
+		doc.Text("No active categories.")
+	}
+	for _, cat := range report.Categories {
+		line := fmt.Sprintf("%s: %d votes", cat.CategoryName, cat.TotalVotes)
+		if cat.Winner != nil {
+			line += fmt.Sprintf(" - winner #%v %v", cat.Winner["car_number"], cat.Winner["racer_name"])
+			if carName, _ := cat.Winner["car_name"].(string); carName != "" {
+				line += fmt.Sprintf(" (%s)", carName)
+			}
+			if override, _ := cat.Winner["is_override"].(bool); override {
+				line += " [override]"
+			}
+		} else {
+			line += " - no winner"
+		}
+		doc.Text(line)
+	}
+
+	doc.Heading("Overrides")
+	if len(report.Overrides) == 0 {
+		doc.Text("No winners were set manually.")
+	}
+	for _, o := range report.Overrides {
+		line := fmt.Sprintf("%s: #%s %s", o.CategoryName, o.OverrideCarNumber, o.OverrideRacerName)
+		if o.OverriddenAt != "" {
+			line += ", " + formatTimestamp(o.OverriddenAt, loc)
+		}
+		doc.Text(line)
+		if o.OverrideReason != "" {
+			doc.Text("Reason: " + o.OverrideReason)
+		}
+	}
 
-	respondOK(w, overrides)
+	w.Header().Set("Content-Type", contentTypePDF)
+	w.Header().Set("Content-Disposition", `attachment; filename="event-report.pdf"`)
+	doc.WriteTo(w)
 }
 
 // ==================== QR Codes ====================
@@ -663,13 +1733,13 @@ func (h *Handlers) handleGetOverrides(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) handleGenerateQRCodes(w http.ResponseWriter, r *http.Request) {
 	var req QRCodeGenerateRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	qrCodes, err := h.Voter.GenerateQRCodes(r.Context(), req.Count)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -679,13 +1749,13 @@ func (h *Handlers) handleGenerateQRCodes(w http.ResponseWriter, r *http.Request)
 func (h *Handlers) handleGetQRImage(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIntParam(r, "id")
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	png, err := h.Voter.GenerateQRImage(r.Context(), id)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -697,7 +1767,44 @@ func (h *Handlers) handleGetQRImage(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) handleGetOpenVotingQR(w http.ResponseWriter, r *http.Request) {
 	png, err := h.Voter.GenerateDynamicQRImage(r.Context())
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(png)
+}
+
+// handleGetCategoryQR returns an open voting QR code that opens the ballot on
+// one active category
+func (h *Handlers) handleGetCategoryQR(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	categories, err := h.Category.ListCategories(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	found := false
+	for _, cat := range categories {
+		if cat.ID == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		writeError(w, NotFound("Category not found"))
+		return
+	}
+
+	png, err := h.Voter.GenerateCategoryQRImage(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
@@ -711,50 +1818,246 @@ func (h *Handlers) handleGetOpenVotingQR(w http.ResponseWriter, r *http.Request)
 func (h *Handlers) handleGetSettings(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	derbynetURL, _ := h.Settings.GetDerbyNetURL(ctx)
+	derbynetURLFallback, _ := h.Settings.GetSetting(ctx, "derbynet_url_fallback")
 	baseURL, _ := h.Settings.GetBaseURL(ctx)
 	derbynetRole, _ := h.Settings.GetSetting(ctx, "derbynet_role")
 	requireRegisteredQR, _ := h.Settings.RequireRegisteredQR(ctx)
+	openVotingOnePerDevice, _ := h.Settings.OpenVotingOnePerDevice(ctx)
+	publicResultsEnabled, _ := h.Settings.PublicResultsEnabled(ctx)
+	derbyNetHealthPolling, _ := h.Settings.DerbyNetHealthPolling(ctx)
+	anonymizeBallots, _ := h.Settings.AnonymizeBallots(ctx)
+	requireCompleteBallot, _ := h.Settings.RequireCompleteBallot(ctx)
+	allowVoteChanges, _ := h.Settings.AllowVoteChanges(ctx)
+	maintenanceMessage, _ := h.Settings.MaintenanceMessage(ctx)
+	postVoteRedirectURL, _ := h.Settings.PostVoteRedirectURL(ctx)
+	carNumberFormat, _ := h.Settings.CarNumberFormat(ctx)
 	votingInstructions, _ := h.Settings.GetSetting(ctx, "voting_instructions")
+	votingInstructionsByType, _ := h.Settings.GetVotingInstructionsByType(ctx)
 	voterTypes, _ := h.Settings.GetVoterTypes(ctx)
+	eventName, _ := h.Settings.GetSetting(ctx, "event_name")
+	logoURL, _ := h.Settings.GetSetting(ctx, "logo_url")
+	themeColor, _ := h.Settings.GetSetting(ctx, "theme_color")
+	timezone, _ := h.Settings.GetSetting(ctx, "timezone")
+	maxVotingMinutes, _ := h.Settings.GetMaxVotingMinutes(ctx)
+	votingTimerPresets, _ := h.Settings.GetVotingTimerPresets(ctx)
+	tieMargin, _ := h.Settings.GetTieMargin(ctx)
+	autoCloseIdleMinutes, _ := h.Settings.GetAutoCloseIdleMinutes(ctx)
 
 	respondOK(w, SettingsResponse{
-		DerbyNetURL:         derbynetURL,
-		BaseURL:             baseURL,
-		DerbyNetRole:        derbynetRole,
-		RequireRegisteredQR: requireRegisteredQR,
-		VotingInstructions:  votingInstructions,
-		VoterTypes:          voterTypes,
+		DerbyNetURL:              derbynetURL,
+		DerbyNetURLFallback:      derbynetURLFallback,
+		BaseURL:                  baseURL,
+		DerbyNetRole:             derbynetRole,
+		RequireRegisteredQR:      requireRegisteredQR,
+		OpenVotingOnePerDevice:   openVotingOnePerDevice,
+		PublicResultsEnabled:     publicResultsEnabled,
+		DerbyNetHealthPolling:    derbyNetHealthPolling,
+		AnonymizeBallots:         anonymizeBallots,
+		RequireCompleteBallot:    requireCompleteBallot,
+		AllowVoteChanges:         allowVoteChanges,
+		MaintenanceMessage:       maintenanceMessage,
+		PostVoteRedirectURL:      postVoteRedirectURL,
+		CarNumberFormat:          carNumberFormat,
+		VotingInstructions:       votingInstructions,
+		VotingInstructionsByType: votingInstructionsByType,
+		VoterTypes:               voterTypes,
+		EventName:                eventName,
+		LogoURL:                  logoURL,
+		ThemeColor:               themeColor,
+		Timezone:                 timezone,
+		MaxVotingMinutes:         maxVotingMinutes,
+		VotingTimerPresets:       votingTimerPresets,
+		TieMargin:                tieMargin,
+		AutoCloseIdleMinutes:     autoCloseIdleMinutes,
 	})
 }
 
-func (h *Handlers) handleUpdateSettings(w http.ResponseWriter, r *http.Request) {
-	var req SettingsUpdateRequest
-	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+func (h *Handlers) handleUpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var req SettingsUpdateRequest
+	if err := decodeJSONFields(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	settings := services.Settings{
+		DerbyNetURL:              req.DerbyNetURL,
+		DerbyNetURLFallback:      req.DerbyNetURLFallback,
+		BaseURL:                  req.BaseURL,
+		DerbyNetRole:             req.DerbyNetRole,
+		DerbyNetPassword:         req.DerbyNetPassword,
+		RequireRegisteredQR:      req.RequireRegisteredQR,
+		OpenVotingOnePerDevice:   req.OpenVotingOnePerDevice,
+		PublicResultsEnabled:     req.PublicResultsEnabled,
+		DerbyNetHealthPolling:    req.DerbyNetHealthPolling,
+		AnonymizeBallots:         req.AnonymizeBallots,
+		RequireCompleteBallot:    req.RequireCompleteBallot,
+		AllowVoteChanges:         req.AllowVoteChanges,
+		MaintenanceMessage:       req.MaintenanceMessage,
+		PostVoteRedirectURL:      req.PostVoteRedirectURL,
+		CarNumberFormat:          req.CarNumberFormat,
+		VotingInstructions:       req.VotingInstructions,
+		VotingInstructionsByType: req.VotingInstructionsByType,
+		VoterTypes:               req.VoterTypes,
+		EventName:                req.EventName,
+		LogoURL:                  req.LogoURL,
+		ThemeColor:               req.ThemeColor,
+		Timezone:                 req.Timezone,
+		MaxVotingMinutes:         req.MaxVotingMinutes,
+		VotingTimerPresets:       req.VotingTimerPresets,
+		TieMargin:                req.TieMargin,
+		AutoCloseIdleMinutes:     req.AutoCloseIdleMinutes,
+	}
+	if err := h.Settings.UpdateSettings(r.Context(), settings); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondSuccess(w, "Settings updated")
+}
+
+func (h *Handlers) handleExportSettings(w http.ResponseWriter, r *http.Request) {
+	includeSensitive := r.URL.Query().Get("include_sensitive") == "true"
+
+	settings, err := h.Settings.ExportSettings(r.Context(), includeSensitive)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="derbyvote-settings.json"`)
+	respondOK(w, settings)
+}
+
+func (h *Handlers) handleImportSettings(w http.ResponseWriter, r *http.Request) {
+	var req SettingsImportRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(req.Settings) == 0 {
+		writeError(w, BadRequest("No settings to import"))
+		return
+	}
+
+	result, err := h.Settings.ImportSettings(r.Context(), req.Settings, req.IncludeSensitive)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, result)
+}
+
+// ==================== Branding ====================
+
+// maxImageSize limits the size of an uploaded branding logo or category banner
+const maxImageSize = 2 << 20
+
+// logoFileName is the name of the uploaded logo inside the upload directory
+const logoFileName = "logo"
+
+// imageContentTypes lists the image types accepted for uploaded logos and banners
+var imageContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// branding returns the configured branding, falling back to defaults on error
+func (h *Handlers) branding(r *http.Request) *services.Branding {
+	branding, err := h.Settings.GetBranding(r.Context())
+	if err != nil {
+		return &services.Branding{EventName: services.DefaultEventName, ThemeColor: services.DefaultThemeColor}
+	}
+	return branding
+}
+
+func (h *Handlers) handleGetBranding(w http.ResponseWriter, r *http.Request) {
+	branding, err := h.Settings.GetBranding(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, branding)
+}
+
+func (h *Handlers) handleUploadLogo(w http.ResponseWriter, r *http.Request) {
+	if h.uploadDir == "" {
+		writeError(w, InternalError(fmt.Errorf("logo upload directory not configured")))
+		return
+	}
+
+	data, err := readImageUpload(w, r, "logo", "Logo")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := os.MkdirAll(h.uploadDir, 0o755); err != nil {
+		writeError(w, InternalError(err))
+		return
+	}
+	if err := os.WriteFile(filepath.Join(h.uploadDir, logoFileName), data, 0o644); err != nil {
+		writeError(w, InternalError(err))
+		return
+	}
+
+	logoURL := fmt.Sprintf("/branding/logo?v=%d", time.Now().Unix())
+	if err := h.Settings.SetSetting(r.Context(), "logo_url", logoURL); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, map[string]string{"logo_url": logoURL})
+}
+
+// readImageUpload reads the image uploaded in the multipart form field, checking
+// its size and sniffed content type. label names the image in error messages.
+func readImageUpload(w http.ResponseWriter, r *http.Request, field, label string) ([]byte, error) {
+	setBodyLimit(w, r, maxImageSize+1024)
+	file, _, err := r.FormFile(field)
+	if err != nil {
+		return nil, BadRequest(fmt.Sprintf("Missing or oversized %s file", field))
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxImageSize+1))
+	if err != nil {
+		return nil, BadRequest(fmt.Sprintf("Failed to read %s file", field))
+	}
+	if len(data) > maxImageSize {
+		return nil, PayloadTooLarge(label + " must be 2MB or smaller")
+	}
+	if !imageContentTypes[http.DetectContentType(data)] {
+		return nil, BadRequest(label + " must be a PNG, JPEG, GIF or WebP image")
+	}
+	return data, nil
+}
+
+// handleBrandingLogo serves the uploaded branding logo
+func (h *Handlers) handleBrandingLogo(w http.ResponseWriter, r *http.Request) {
+	if h.uploadDir == "" {
+		writeError(w, NotFound("No logo uploaded"))
 		return
 	}
 
-	settings := services.Settings{
-		DerbyNetURL:         req.DerbyNetURL,
-		BaseURL:             req.BaseURL,
-		DerbyNetRole:        req.DerbyNetRole,
-		DerbyNetPassword:    req.DerbyNetPassword,
-		RequireRegisteredQR: req.RequireRegisteredQR,
-		VotingInstructions:  req.VotingInstructions,
-		VoterTypes:          req.VoterTypes,
-	}
-	if err := h.Settings.UpdateSettings(r.Context(), settings); err != nil {
-		respondError(w, err)
+	data, err := os.ReadFile(filepath.Join(h.uploadDir, logoFileName))
+	if err != nil {
+		writeError(w, NotFound("No logo uploaded"))
 		return
 	}
 
-	respondSuccess(w, "Settings updated")
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Write(data)
 }
 
 func (h *Handlers) handleGetVoterTypes(w http.ResponseWriter, r *http.Request) {
 	voterTypes, err := h.Settings.GetVoterTypes(r.Context())
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -768,126 +2071,260 @@ func (h *Handlers) handleGetVoterTypes(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) handleResetDatabase(w http.ResponseWriter, r *http.Request) {
 	var req DatabaseResetRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	result, err := h.Settings.ResetTables(r.Context(), req.Tables)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	respondSuccess(w, result.Message)
 }
 
+// handleNewEvent clears votes, voters and overrides while keeping the
+// event configuration, leaving voting closed
+func (h *Handlers) handleNewEvent(w http.ResponseWriter, r *http.Request) {
+	var req NewEventRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if !req.Confirm {
+		writeError(w, BadRequest("confirm must be true to start a new event"))
+		return
+	}
+
+	result, err := h.Settings.StartNewEvent(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, NewEventResponse{Cleared: result.Cleared, VotingOpen: false})
+}
+
+// handleExportVotes downloads every voter and vote as JSON that another
+// instance can merge with POST /api/admin/merge-votes
+func (h *Handlers) handleExportVotes(w http.ResponseWriter, r *http.Request) {
+	export, err := h.Voting.ExportVotes(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="derbyvote-votes.json"`)
+	respondOK(w, export)
+}
+
+// handleMergeVotes merges the voters and votes exported by another instance,
+// reporting votes that conflict with ones recorded here
+func (h *Handlers) handleMergeVotes(w http.ResponseWriter, r *http.Request) {
+	var export services.VotesExport
+	if err := decodeJSON(r, &export); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result, err := h.Voting.MergeVotes(r.Context(), export)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, result)
+}
+
+// seedClearTables maps each seed type to the table wiped by ?clear=true
+var seedClearTables = map[string]string{
+	"categories": "categories",
+	"cars":       "cars",
+	"voters":     "voters",
+	"votes":      "votes",
+}
+
 func (h *Handlers) handleSeedMockData(w http.ResponseWriter, r *http.Request) {
 	var req SeedMockDataRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
-	ctx := r.Context()
+	table, ok := seedClearTables[req.SeedType]
+	if !ok {
+		writeError(w, BadRequest("Invalid seed type"))
+		return
+	}
 
-	var message string
-	var addedCount int
+	ctx := r.Context()
+	response := SeedMockDataResponse{}
 
-	switch req.SeedType {
-	case "categories":
-		count, err := h.Category.SeedMockCategories(ctx)
+	if r.URL.Query().Get("clear") == "true" {
+		// Clearing always wipes votes, which closes voting and clears the timer
+		votingOpen, err := h.Settings.IsVotingOpen(ctx)
 		if err != nil {
-			respondError(w, err)
+			writeError(w, err)
 			return
 		}
-		addedCount = count
-		if addedCount == 0 {
-			message = "All default categories already exist"
-		} else {
-			message = fmt.Sprintf("Added %d new categories", addedCount)
+		result, err := h.Settings.ResetTables(ctx, []string{table})
+		if err != nil {
+			writeError(w, err)
+			return
 		}
+		response.Cleared = result.Tables
+		response.VotingClosed = votingOpen
+	}
 
+	var err error
+	var noun, emptyMessage string
+	switch req.SeedType {
+	case "categories":
+		response.Created, err = h.Category.SeedMockCategories(ctx)
+		noun, emptyMessage = "categories", "All default categories already exist"
 	case "cars":
-		count, err := h.Car.SeedMockCars(ctx)
-		if err != nil {
-			respondError(w, err)
+		response.Created, err = h.Car.SeedMockCars(ctx)
+		noun, emptyMessage = "cars", "All mock cars already exist"
+	case "voters":
+		response.Created, err = h.Voter.SeedMockVoters(ctx)
+		noun, emptyMessage = "voters", "All demo voters already exist"
+	case "votes":
+		response.Created, err = h.Voting.SeedMockVotes(ctx)
+		noun, emptyMessage = "votes", "No votes to add - every voter has voted in every category"
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if response.Created == 0 {
+		response.Message = emptyMessage
+	} else {
+		response.Message = fmt.Sprintf("Added %d new %s", response.Created, noun)
+	}
+	if response.VotingClosed {
+		response.Message += "; voting has been closed"
+	}
+	respondOK(w, response)
+}
+
+// handlePreviewMockData returns the demo data seed-mock-data would create
+// without writing anything. An empty seed_type previews every type; votes are
+// random and depend on existing data, so they cannot be previewed.
+func (h *Handlers) handlePreviewMockData(w http.ResponseWriter, r *http.Request) {
+	var req SeedMockDataRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSON(r, &req); err != nil {
+			writeError(w, err)
 			return
 		}
-		addedCount = count
-		if addedCount == 0 {
-			message = "All mock cars already exist"
-		} else {
-			message = fmt.Sprintf("Added %d new cars", addedCount)
-		}
+	}
 
+	var response SeedMockDataPreviewResponse
+	switch req.SeedType {
+	case "":
+		response.Categories = services.MockCategories()
+		response.Cars = services.MockCars()
+		response.Voters = services.MockVoters()
+	case "categories":
+		response.Categories = services.MockCategories()
+	case "cars":
+		response.Cars = services.MockCars()
+	case "voters":
+		response.Voters = services.MockVoters()
+	case "votes":
+		writeError(w, BadRequest("Votes are random and cannot be previewed"))
+		return
 	default:
-		respondError(w, BadRequest("Invalid seed type"))
+		writeError(w, BadRequest("Invalid seed type"))
 		return
 	}
-
-	respondSuccess(w, message)
+	respondOK(w, response)
 }
 
 // ==================== Voters ====================
 
+// handleGetVoters lists voters. Pass ?group= for the voters in one voter group.
 func (h *Handlers) handleGetVoters(w http.ResponseWriter, r *http.Request) {
-	voters, err := h.Voter.ListVoters(r.Context())
+	var voters []map[string]interface{}
+	var err error
+	if group, ok := r.URL.Query()["group"]; ok {
+		voters, err = h.Voter.ListVotersInGroup(r.Context(), group[0])
+	} else {
+		voters, err = h.Voter.ListVoters(r.Context())
+	}
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
+
+	loc := h.location(r)
+	for _, voter := range voters {
+		for _, key := range []string{"created_at", "last_voted_at"} {
+			if value, ok := voter[key].(string); ok && value != "" {
+				voter[key] = formatTimestamp(value, loc)
+			}
+		}
+	}
 	respondOK(w, voters)
 }
 
 func (h *Handlers) handleCreateVoter(w http.ResponseWriter, r *http.Request) {
 	var req VoterCreateRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	voter := services.Voter{
-		CarID:     req.CarID,
-		Name:      req.Name,
-		Email:     req.Email,
-		VoterType: req.VoterType,
-		QRCode:    req.QRCode,
-		Notes:     req.Notes,
+		CarID:      req.CarID,
+		Name:       req.Name,
+		Email:      req.Email,
+		VoterType:  req.VoterType,
+		VoterGroup: req.VoterGroup,
+		QRCode:     req.QRCode,
+		Notes:      req.Notes,
+		IsTest:     req.IsTest,
 	}
 	id, qrCode, err := h.Voter.CreateVoter(r.Context(), voter)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	respondCreated(w, VoterResponse{
-		ID:        id,
-		CarID:     req.CarID,
-		Name:      req.Name,
-		Email:     req.Email,
-		VoterType: req.VoterType,
-		QRCode:    qrCode,
-		Notes:     req.Notes,
+		ID:         id,
+		CarID:      req.CarID,
+		Name:       req.Name,
+		Email:      req.Email,
+		VoterType:  req.VoterType,
+		VoterGroup: strings.TrimSpace(req.VoterGroup),
+		QRCode:     qrCode,
+		Notes:      req.Notes,
+		IsTest:     req.IsTest,
 	})
 }
 
 func (h *Handlers) handleUpdateVoter(w http.ResponseWriter, r *http.Request) {
 	var req VoterUpdateRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	voter := services.Voter{
-		ID:        req.ID,
-		CarID:     req.CarID,
-		Name:      req.Name,
-		Email:     req.Email,
-		VoterType: req.VoterType,
-		Notes:     req.Notes,
+		ID:         req.ID,
+		CarID:      req.CarID,
+		Name:       req.Name,
+		Email:      req.Email,
+		VoterType:  req.VoterType,
+		VoterGroup: req.VoterGroup,
+		Notes:      req.Notes,
+		IsTest:     req.IsTest,
 	}
 	if err := h.Voter.UpdateVoter(r.Context(), voter); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -897,18 +2334,201 @@ func (h *Handlers) handleUpdateVoter(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) handleDeleteVoter(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIntParam(r, "id")
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
+	// Deleting a voter discards their ballot, so ask first unless forced
+	if r.URL.Query().Get("force") != "true" {
+		voteCount, err := h.Voter.CountVotesForVoter(r.Context(), id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if voteCount > 0 {
+			writeConfirmationRequired(w, fmt.Sprintf("This voter has cast %d vote(s). Are you sure you want to delete them?", voteCount), voteCount)
+			return
+		}
+	}
+
 	if err := h.Voter.DeleteVoter(r.Context(), id); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	respondDeleted(w)
 }
 
+// handleClearVoterVotes removes all of a voter's votes so they can vote again.
+// Requires ?force=true while voting is closed.
+func (h *Handlers) handleClearVoterVotes(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	force := r.URL.Query().Get("force") == "true"
+
+	cleared, err := h.Voter.ClearVoterVotes(r.Context(), id, force)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, VoterVotesClearedResponse{VoterID: id, Cleared: cleared})
+}
+
+// handleAssistedVote records a vote on a voter's behalf, even while voting is
+// closed, and notes it in the audit log
+func (h *Handlers) handleAssistedVote(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req AssistedVoteRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	vote := models.Vote{
+		CategoryID: req.CategoryID,
+		CarID:      req.CarID,
+		WriteIn:    req.WriteIn,
+		Replace:    req.Replace,
+	}
+	result, err := h.Voting.SubmitAssistedVote(r.Context(), id, vote, req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, result)
+}
+
+// handleVoidVote removes an invalid vote, recording the reason in the audit
+// log, and returns the category's updated tally. While results are embargoed
+// the tally is left out unless the X-Reveal header carries the reveal code.
+func (h *Handlers) handleVoidVote(w http.ResponseWriter, r *http.Request) {
+	var req VoteVoidRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+	carID, err := h.Voter.VoidVote(ctx, req.VoterID, req.CategoryID, req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var category *services.CategoryResult
+	switch err := h.Results.CheckReveal(ctx, r.Header.Get(RevealHeader)); err {
+	case nil:
+		category, err = h.Results.GetCategoryResults(ctx, req.CategoryID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if category != nil && category.Votes == nil {
+			category.Votes = []services.CarResult{}
+		}
+	case services.ErrResultsEmbargoed:
+		// The void still stands, but the tally would give the results away
+	default:
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, VoteVoidResponse{
+		VoterID:    req.VoterID,
+		CategoryID: req.CategoryID,
+		CarID:      carID,
+		Category:   category,
+	})
+}
+
+// handleGetAuditLog lists accountable admin actions, newest first
+func (h *Handlers) handleGetAuditLog(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.Voter.ListAuditLog(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	loc := h.location(r)
+	for i := range entries {
+		entries[i].CreatedAt = formatTimestamp(entries[i].CreatedAt, loc)
+	}
+	respondOK(w, entries)
+}
+
+// handleGetStaleVoters lists voters who started but did not finish their
+// ballot and have been idle for ?minutes= (default 15)
+func (h *Handlers) handleGetStaleVoters(w http.ResponseWriter, r *http.Request) {
+	minutes := services.DefaultStaleVoterMinutes
+	if value := r.URL.Query().Get("minutes"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			writeError(w, BadRequest("minutes must be a positive whole number"))
+			return
+		}
+		minutes = n
+	}
+
+	voters, err := h.Voter.ListStaleVoters(r.Context(), minutes)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respondOK(w, voters)
+}
+
+func (h *Handlers) handleBulkDeleteVoters(w http.ResponseWriter, r *http.Request) {
+	var req VoterBulkDeleteRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if !req.Confirm {
+		writeError(w, BadRequest("confirm must be true to bulk delete voters"))
+		return
+	}
+
+	filter := services.VoterFilter{
+		VoterType: req.VoterType,
+		HasVoted:  req.HasVoted,
+	}
+	if filter.IsEmpty() && !req.ConfirmAll {
+		writeError(w, BadRequest("Filter matches all voters; set confirm_all to delete every voter"))
+		return
+	}
+
+	deleted, err := h.Voter.BulkDeleteVoters(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, VoterBulkDeleteResponse{Deleted: deleted})
+}
+
+// handleClearTestVoters deletes all test voters and their votes
+func (h *Handlers) handleClearTestVoters(w http.ResponseWriter, r *http.Request) {
+	deleted, err := h.Voter.ClearTestVoters(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, VoterBulkDeleteResponse{Deleted: deleted})
+}
+
 // ==================== Cars ====================
 
 func (h *Handlers) handleAdminCars(w http.ResponseWriter, r *http.Request) {
@@ -921,49 +2541,105 @@ func (h *Handlers) handleAdminCars(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handlers) handleGetCars(w http.ResponseWriter, r *http.Request) {
-	cars, err := h.Car.ListCars(r.Context())
+	filter, err := parseCarFilter(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	cars, total, err := h.Car.ListCarsPaged(r.Context(), filter)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
+	if cars == nil {
+		cars = []models.Car{}
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
 	respondOK(w, cars)
 }
 
+// parseCarFilter reads the ?q=, ?eligible=, ?write_ins=, ?limit= and ?offset= car list parameters
+func parseCarFilter(r *http.Request) (services.CarFilter, error) {
+	filter := services.CarFilter{
+		Query:    r.URL.Query().Get("q"),
+		WriteIns: r.URL.Query().Get("write_ins") == "true",
+	}
+
+	if value := r.URL.Query().Get("eligible"); value != "" {
+		eligible, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, BadRequest("Invalid eligible parameter")
+		}
+		filter.Eligible = &eligible
+	}
+
+	var err error
+	if filter.Limit, err = parseIntQuery(r, "limit"); err != nil {
+		return filter, err
+	}
+	if filter.Offset, err = parseIntQuery(r, "offset"); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
 func (h *Handlers) handleGetCar(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIntParam(r, "id")
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	car, err := h.Car.GetCar(r.Context(), id)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	respondOK(w, car)
 }
 
+func (h *Handlers) handleGetCarResults(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	results, err := h.Results.GetCarResults(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, results)
+}
+
 func (h *Handlers) handleCreateCar(w http.ResponseWriter, r *http.Request) {
 	var req CarCreateRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	if req.CarNumber == "" {
-		respondError(w, BadRequest("car_number is required"))
+		writeError(w, BadRequest("car_number is required"))
 		return
 	}
 
-	if err := h.Car.CreateCar(r.Context(), req.CarNumber, req.RacerName, req.CarName, req.PhotoURL); err != nil {
-		respondError(w, err)
+	carNumber, err := h.Car.NormalizeCarNumber(r.Context(), req.CarNumber)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := h.Car.CreateCar(r.Context(), carNumber, req.RacerName, req.CarName, req.PhotoURL); err != nil {
+		writeCarError(w, err)
 		return
 	}
 
 	respondCreated(w, CarResponse{
-		CarNumber: req.CarNumber,
+		CarNumber: carNumber,
 		RacerName: req.RacerName,
 		CarName:   req.CarName,
 		PhotoURL:  req.PhotoURL,
@@ -974,31 +2650,36 @@ func (h *Handlers) handleCreateCar(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) handleUpdateCar(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIntParam(r, "id")
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	// Check if car exists first
 	_, err = h.Car.GetCar(r.Context(), id)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	var req CarUpdateRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
-	if err := h.Car.UpdateCar(r.Context(), id, req.CarNumber, req.RacerName, req.CarName, req.PhotoURL, req.Rank); err != nil {
-		respondError(w, err)
+	carNumber, err := h.Car.NormalizeCarNumber(r.Context(), req.CarNumber)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := h.Car.UpdateCar(r.Context(), id, carNumber, req.RacerName, req.CarName, req.PhotoURL, req.Rank); err != nil {
+		writeCarError(w, err)
 		return
 	}
 
 	respondOK(w, CarResponse{
 		ID:        id,
-		CarNumber: req.CarNumber,
+		CarNumber: carNumber,
 		RacerName: req.RacerName,
 		CarName:   req.CarName,
 		PhotoURL:  req.PhotoURL,
@@ -1006,17 +2687,60 @@ func (h *Handlers) handleUpdateCar(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// writeCarError writes a car create or update error, naming the existing car
+// when the car number is already taken
+func writeCarError(w http.ResponseWriter, err error) {
+	dupErr, ok := err.(*services.DuplicateCarNumberError)
+	if !ok {
+		writeError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusConflict, CarNumberConflictResponse{
+		Error: Conflict(dupErr.Error()),
+		ConflictingCar: CarResponse{
+			ID:        dupErr.Car.ID,
+			CarNumber: dupErr.Car.CarNumber,
+			RacerName: dupErr.Car.RacerName,
+			CarName:   dupErr.Car.CarName,
+			PhotoURL:  dupErr.Car.PhotoURL,
+			Rank:      dupErr.Car.Rank,
+		},
+	})
+}
+
+// handleSetCarDerbyNetRacer links a car to a DerbyNet racer (null clears it)
+func (h *Handlers) handleSetCarDerbyNetRacer(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIntParam(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req CarDerbyNetRacerRequest
+	if err := decodeJSONFields(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := h.Car.SetDerbyNetRacer(r.Context(), id, req.RacerID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, CarDerbyNetRacerResponse{ID: id, DerbyNetRacerID: req.RacerID})
+}
+
 func (h *Handlers) handleDeleteCar(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIntParam(r, "id")
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	// Check if car exists first
 	_, err = h.Car.GetCar(r.Context(), id)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -1027,20 +2751,17 @@ func (h *Handlers) handleDeleteCar(w http.ResponseWriter, r *http.Request) {
 	if !force {
 		voteCount, err := h.Car.CountVotesForCar(r.Context(), id)
 		if err != nil {
-			respondError(w, err)
+			writeError(w, err)
 			return
 		}
 		if voteCount > 0 {
-			// Return confirmation needed response
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusConflict)
-			w.Write([]byte(fmt.Sprintf(`{"error":"This car has received %d vote(s). Are you sure you want to delete it?","confirmation_required":true,"vote_count":%d}`, voteCount, voteCount)))
+			writeConfirmationRequired(w, fmt.Sprintf("This car has received %d vote(s). Are you sure you want to delete it?", voteCount), voteCount)
 			return
 		}
 	}
 
 	if err := h.Car.DeleteCar(r.Context(), id); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -1050,20 +2771,20 @@ func (h *Handlers) handleDeleteCar(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) handleSetCarEligibility(w http.ResponseWriter, r *http.Request) {
 	id, err := parseIntParam(r, "id")
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	// Check if car exists first
 	car, err := h.Car.GetCar(r.Context(), id)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	var req CarEligibilityRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -1071,20 +2792,17 @@ func (h *Handlers) handleSetCarEligibility(w http.ResponseWriter, r *http.Reques
 	if !req.Eligible && car.Eligible && !req.Force {
 		voteCount, err := h.Car.CountVotesForCar(r.Context(), id)
 		if err != nil {
-			respondError(w, err)
+			writeError(w, err)
 			return
 		}
 		if voteCount > 0 {
-			// Return confirmation needed response
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusConflict)
-			w.Write([]byte(fmt.Sprintf(`{"error":"This car has received %d vote(s). Are you sure you want to mark it as ineligible?","confirmation_required":true,"vote_count":%d}`, voteCount, voteCount)))
+			writeConfirmationRequired(w, fmt.Sprintf("This car has received %d vote(s). Are you sure you want to mark it as ineligible?", voteCount), voteCount)
 			return
 		}
 	}
 
 	if err := h.Car.SetCarEligibility(r.Context(), id, req.Eligible); err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
@@ -1094,3 +2812,58 @@ func (h *Handlers) handleSetCarEligibility(w http.ResponseWriter, r *http.Reques
 	})
 }
 
+// handleBulkSetCarEligibility sets the eligibility of every car of a rank, or
+// of a list of cars, asking for confirmation first when cars that would become
+// ineligible have received votes
+func (h *Handlers) handleBulkSetCarEligibility(w http.ResponseWriter, r *http.Request) {
+	var req CarBulkEligibilityRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.Eligible == nil {
+		writeError(w, BadRequest("eligible is required"))
+		return
+	}
+
+	cars, err := h.Car.MatchCarsForEligibility(r.Context(), services.CarEligibilityFilter{Rank: req.Rank, CarIDs: req.CarIDs})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if !*req.Eligible && !req.Force {
+		carsWithVotes, voteCount := 0, 0
+		for _, car := range cars {
+			if !car.Eligible {
+				continue
+			}
+			count, err := h.Car.CountVotesForCar(r.Context(), car.ID)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			if count > 0 {
+				carsWithVotes++
+				voteCount += count
+			}
+		}
+		if voteCount > 0 {
+			writeConfirmationRequired(w, fmt.Sprintf("%d of these cars have received %d vote(s) in total. Are you sure you want to mark them as ineligible?", carsWithVotes, voteCount), voteCount)
+			return
+		}
+	}
+
+	ids := make([]int, len(cars))
+	for i, car := range cars {
+		ids[i] = car.ID
+	}
+	updated, err := h.Car.BulkSetCarEligibility(r.Context(), ids, *req.Eligible)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, CarBulkEligibilityResponse{Eligible: *req.Eligible, Matched: len(cars), Updated: updated})
+}
+
@@ -3,18 +3,22 @@ package handlers_test
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/abrezinsky/derbyvote/internal/auth"
 	"github.com/abrezinsky/derbyvote/internal/handlers"
 	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/models"
 	"github.com/abrezinsky/derbyvote/internal/repository"
 	"github.com/abrezinsky/derbyvote/internal/repository/mock"
 	"github.com/abrezinsky/derbyvote/internal/services"
@@ -30,6 +34,16 @@ type testSetup struct {
 	log         *logger.SlogLogger
 }
 
+// errorMessage returns error.message from a decoded error response envelope
+func errorMessage(body map[string]interface{}) (string, bool) {
+	errObj, ok := body["error"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	message, ok := errObj["message"].(string)
+	return message, ok
+}
+
 // newTestSetup creates a new test setup with in-memory repository
 func newTestSetup(t *testing.T) *testSetup {
 	t.Helper()
@@ -131,7 +145,7 @@ func TestHandleGetCategories_Success(t *testing.T) {
 
 	// Create a category first
 	ctx := context.Background()
-	_, err := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	_, err := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -180,6 +194,146 @@ func TestHandleGetCategories_Empty(t *testing.T) {
 	}
 }
 
+func TestHandleGetCategories_FilterByTag(t *testing.T) {
+	setup := newTestSetup(t)
+
+	ctx := context.Background()
+	if _, err := setup.repo.CreateCategory(ctx, "Best Paint", 1, nil, nil, nil, []string{"Design", "Den"}); err != nil {
+		t.Fatalf("failed to create tagged category: %v", err)
+	}
+	_, _ = setup.repo.CreateCategory(ctx, "Funniest", 2, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/categories?tag=den", nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var response []map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 || response[0]["name"] != "Best Paint" {
+		t.Fatalf("expected only Best Paint, got %v", response)
+	}
+	if tags, _ := response[0]["tags"].([]interface{}); len(tags) != 2 {
+		t.Errorf("expected 2 tags, got %v", response[0]["tags"])
+	}
+}
+
+func TestHandleCreateCategory_WithTags(t *testing.T) {
+	setup := newTestSetup(t)
+
+	body := []byte(`{"name":"Best Paint","display_order":1,"tags":[" Design ","Den","den"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/categories", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Tags) != 2 || response.Tags[0] != "Design" || response.Tags[1] != "Den" {
+		t.Errorf("expected tags [Design Den], got %v", response.Tags)
+	}
+
+	categories, _ := setup.repo.ListCategories(context.Background())
+	if len(categories) != 1 || len(categories[0].Tags) != 2 {
+		t.Errorf("expected stored tags, got %v", categories)
+	}
+}
+
+func TestHandleCreateCategory_AllowWriteIn(t *testing.T) {
+	setup := newTestSetup(t)
+
+	body := []byte(`{"name":"Crowd Favorite","display_order":1,"allow_write_in":true}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/categories", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		AllowWriteIn bool `json:"allow_write_in"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !response.AllowWriteIn {
+		t.Error("expected allow_write_in in response")
+	}
+
+	categories, _ := setup.repo.ListCategories(context.Background())
+	if len(categories) != 1 || !categories[0].AllowWriteIn {
+		t.Errorf("expected stored write-in flag, got %v", categories)
+	}
+}
+
+func TestHandleCreate_RecordsCreatedBy(t *testing.T) {
+	setup := newTestSetup(t)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodPost, "/api/admin/categories", `{"name":"Best Design","display_order":1}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create category failed: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodPost, "/api/admin/cars", `{"car_number":"101","racer_name":"Racer","car_name":"Car"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create car failed: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodPost, "/api/admin/voters", `{"name":"Judge","qr_code":"JUDGE-QR"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create voter failed: %d %s", rec.Code, rec.Body.String())
+	}
+
+	var categories []map[string]interface{}
+	json.NewDecoder(do(http.MethodGet, "/api/admin/categories", "").Body).Decode(&categories)
+	creator, _ := categories[0]["created_by"].(string)
+	if !strings.HasPrefix(creator, "session-") || strings.Contains(creator, setup.authCookie.Value) {
+		t.Fatalf("expected a hashed session creator, got %q", creator)
+	}
+
+	var cars []models.Car
+	json.NewDecoder(do(http.MethodGet, "/api/admin/cars", "").Body).Decode(&cars)
+	if len(cars) != 1 || cars[0].CreatedBy != creator {
+		t.Errorf("expected car created by %q, got %+v", creator, cars)
+	}
+	var car models.Car
+	json.NewDecoder(do(http.MethodGet, fmt.Sprintf("/api/admin/cars/%d", cars[0].ID), "").Body).Decode(&car)
+	if car.CreatedBy != creator {
+		t.Errorf("expected GET car created by %q, got %q", creator, car.CreatedBy)
+	}
+
+	var voters []map[string]interface{}
+	json.NewDecoder(do(http.MethodGet, "/api/admin/voters", "").Body).Decode(&voters)
+	if len(voters) != 1 || voters[0]["created_by"] != creator {
+		t.Errorf("expected voter created by %q, got %v", creator, voters)
+	}
+}
+
 func TestHandleCreateCategory_Success(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -274,7 +428,7 @@ func TestHandleUpdateCategory_Success(t *testing.T) {
 
 	// Create a category first
 	ctx := context.Background()
-	id, err := setup.repo.CreateCategory(ctx, "Original", 1, nil, nil, nil)
+	id, err := setup.repo.CreateCategory(ctx, "Original", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -329,12 +483,262 @@ func TestHandleUpdateCategory_InvalidID(t *testing.T) {
 	}
 }
 
+func TestHandleSetCategoryDerbyNetAward(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+	setup.repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+	id, _ := setup.repo.CreateCategory(ctx, "Original", 1, nil, nil, nil, nil)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/admin/categories/%d/derbynet-award", id), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := put(`{"award_id": 2}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response handlers.CategoryDerbyNetAwardResponse
+	json.NewDecoder(rec.Body).Decode(&response)
+	if response.DerbyNetAwardID == nil || *response.DerbyNetAwardID != 2 {
+		t.Errorf("expected derbynet_award_id 2, got %v", response.DerbyNetAwardID)
+	}
+
+	if rec := put(`{"award_id": 9999}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown award: expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if rec := put(`{"award": 2}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("unknown field: expected status %d, got %d", http.StatusUnprocessableEntity, rec.Code)
+	}
+
+	rec = put(`{"award_id": null}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("clear: expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	categories, _ := setup.repo.ListCategories(ctx)
+	if categories[0].DerbyNetAwardID != nil {
+		t.Errorf("expected mapping cleared, got %d", *categories[0].DerbyNetAwardID)
+	}
+}
+
+func TestHandleSetCategoryCars(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+	id, _ := setup.repo.CreateCategory(ctx, "Best Sibling Car", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Sibling", "Car 1", "")
+	cars, _ := setup.repo.ListCars(ctx)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/admin/categories/%d/cars", id), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := put(fmt.Sprintf(`{"car_ids": [%d]}`, cars[0].ID))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response handlers.CategoryCarsResponse
+	json.NewDecoder(rec.Body).Decode(&response)
+	if len(response.CarIDs) != 1 || response.CarIDs[0] != cars[0].ID {
+		t.Errorf("expected car_ids [%d], got %v", cars[0].ID, response.CarIDs)
+	}
+
+	if rec := put(`{"car_ids": [9999]}`); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown car: expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+	if rec := put(`{"cars": []}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("unknown field: expected status %d, got %d", http.StatusUnprocessableEntity, rec.Code)
+	}
+
+	rec = put(`{"car_ids": null}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("clear: expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	categories, _ := setup.repo.ListCategories(ctx)
+	if len(categories[0].CarIDs) != 0 {
+		t.Errorf("expected subset cleared, got %v", categories[0].CarIDs)
+	}
+}
+
+func TestHandleSetCategoryGroup(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	poolID, maxWins := 1, 1
+	groupID64, _ := setup.repo.CreateCategoryGroup(ctx, "Design Awards", "", &poolID, &maxWins, "", 1)
+	groupID := int(groupID64)
+	inPool, _ := setup.repo.CreateCategory(ctx, "Best Paint", 1, &groupID, nil, nil, nil)
+	moved, _ := setup.repo.CreateCategory(ctx, "Best Theme", 2, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	voterID, _ := setup.repo.CreateVoter(ctx, "V1")
+	_ = setup.repo.SaveVote(ctx, voterID, int(inPool), 1)
+	_ = setup.repo.SaveVote(ctx, voterID, int(moved), 1)
+
+	put := func(id int64, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/admin/categories/%d/group", id), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := put(moved, fmt.Sprintf(`{"group_id": %d}`, groupID))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response handlers.CategoryGroupAssignResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Category["group_id"] != float64(groupID) || response.Category["name"] != "Best Theme" {
+		t.Errorf("expected the moved category in group %d, got %v", groupID, response.Category)
+	}
+	if response.ExclusivityConflicts != 1 {
+		t.Errorf("expected 1 exclusivity conflict, got %d", response.ExclusivityConflicts)
+	}
+	if len(response.MultiWins) != 1 {
+		t.Errorf("expected the car to be over max_wins_per_car, got %+v", response.MultiWins)
+	}
+
+	rec = put(moved, `{"group_id": null}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("remove: expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	response = handlers.CategoryGroupAssignResponse{}
+	json.NewDecoder(rec.Body).Decode(&response)
+	if _, ok := response.Category["group_id"]; ok || response.ExclusivityConflicts != 0 || len(response.MultiWins) != 0 {
+		t.Errorf("expected the category out of any group with no conflicts, got %+v", response)
+	}
+
+	if rec := put(moved, `{"group_id": 999}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("unknown group: expected status %d, got %d", http.StatusUnprocessableEntity, rec.Code)
+	}
+	if rec := put(999, `{"group_id": null}`); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown category: expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestHandleSetCategoryDerbyNetAward_NotFound(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/categories/42/derbynet-award", strings.NewReader(`{"award_id": null}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestHandleGetDerbyNetAwards(t *testing.T) {
+	setup := newTestSetup(t)
+
+	get := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/derbynet/awards", nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := get(); rec.Code != http.StatusBadRequest {
+		t.Errorf("unconfigured: expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	setup.repo.SetSetting(context.Background(), "derbynet_url", "http://derbynet.local")
+	rec := get()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var awards []derbynet.Award
+	if err := json.NewDecoder(rec.Body).Decode(&awards); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(awards) == 0 || awards[0].AwardName == "" {
+		t.Errorf("expected mock awards, got %+v", awards)
+	}
+}
+
+func TestHandleGetDerbyNetCategoryDiff(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	get := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/derbynet/categories-diff", nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := get(); rec.Code != http.StatusBadRequest {
+		t.Errorf("unconfigured: expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	setup.repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+	setup.repo.CreateCategory(ctx, "Scout Spirit", 1, nil, nil, nil, nil)
+	rec := get()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var diff services.CategoryDiff
+	if err := json.NewDecoder(rec.Body).Decode(&diff); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(diff.AwardsToImport) != len(derbynet.DefaultMockAwards()) {
+		t.Errorf("expected every mock award to import, got %+v", diff.AwardsToImport)
+	}
+	if len(diff.LocalOnly) != 1 || diff.LocalOnly[0].Name != "Scout Spirit" {
+		t.Errorf("expected Scout Spirit to be local only, got %+v", diff.LocalOnly)
+	}
+}
+
+func TestHandleGetDerbyNetRacers(t *testing.T) {
+	setup := newTestSetup(t)
+
+	get := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/derbynet/racers", nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := get(); rec.Code != http.StatusBadRequest {
+		t.Errorf("unconfigured: expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	setup.repo.SetSetting(context.Background(), "derbynet_url", "http://derbynet.local")
+	rec := get()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var racers []services.DerbyNetRacer
+	if err := json.NewDecoder(rec.Body).Decode(&racers); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(racers) != len(derbynet.DefaultMockRacers()) || racers[0].Name == "" || racers[0].CarNumber == "" {
+		t.Errorf("expected mock racers, got %+v", racers)
+	}
+}
+
 func TestHandleUpdateCategory_ServiceError(t *testing.T) {
 	setup := newTestSetup(t)
 	ctx := context.Background()
 
 	// Create a category first
-	id, err := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	id, err := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -381,7 +785,7 @@ func TestHandleDeleteCategory_Success(t *testing.T) {
 
 	// Create a category first
 	ctx := context.Background()
-	id, err := setup.repo.CreateCategory(ctx, "To Delete", 1, nil, nil, nil)
+	id, err := setup.repo.CreateCategory(ctx, "To Delete", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -434,7 +838,7 @@ func TestHandleDeleteCategory_WithVotes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category
-	catID, err := setup.repo.CreateCategory(ctx, "Popular Category", 1, nil, nil, nil)
+	catID, err := setup.repo.CreateCategory(ctx, "Popular Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -474,6 +878,9 @@ func TestHandleDeleteCategory_WithVotes(t *testing.T) {
 	if !response["confirmation_required"].(bool) {
 		t.Error("expected confirmation_required to be true")
 	}
+	if errObj, _ := response["error"].(map[string]interface{}); errObj["code"] != handlers.ErrCodeConfirmRequired {
+		t.Errorf("expected code %s, got %v", handlers.ErrCodeConfirmRequired, response["error"])
+	}
 }
 
 func TestHandleDeleteCategory_WithVotesAndForce(t *testing.T) {
@@ -481,7 +888,7 @@ func TestHandleDeleteCategory_WithVotesAndForce(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category
-	catID, err := setup.repo.CreateCategory(ctx, "To Force Delete", 1, nil, nil, nil)
+	catID, err := setup.repo.CreateCategory(ctx, "To Force Delete", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -566,7 +973,7 @@ func TestHandleUpdateCategory_WithAllowedRanks(t *testing.T) {
 
 	// Create a category first
 	ctx := context.Background()
-	id, err := setup.repo.CreateCategory(ctx, "Original", 1, nil, nil, nil)
+	id, err := setup.repo.CreateCategory(ctx, "Original", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -617,12 +1024,12 @@ func TestHandleGetCategories_ReturnsAllowedRanks(t *testing.T) {
 
 	// Create categories with different allowed_ranks
 	ctx := context.Background()
-	_, err := setup.repo.CreateCategory(ctx, "Tiger Category", 1, nil, nil, []string{"Tiger"})
+	_, err := setup.repo.CreateCategory(ctx, "Tiger Category", 1, nil, nil, []string{"Tiger"}, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
 
-	_, err = setup.repo.CreateCategory(ctx, "All Ranks", 2, nil, nil, nil)
+	_, err = setup.repo.CreateCategory(ctx, "All Ranks", 2, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -667,6 +1074,76 @@ func TestHandleGetCategories_ReturnsAllowedRanks(t *testing.T) {
 
 // ==================== Voters Tests ====================
 
+func TestHandleImportCategories(t *testing.T) {
+	setup := newTestSetup(t)
+	csv := "name,display_order,group_name,allowed_ranks\nBest Paint,1,Design,Tiger|Wolf\nFunniest,2,,\n"
+
+	t.Run("RawBody", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/categories/import", strings.NewReader(csv))
+		req.Header.Set("Content-Type", "text/csv")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response map[string]interface{}
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response["committed"] != true || response["created"] != float64(2) || response["groups_created"] != float64(1) {
+			t.Errorf("unexpected response: %v", response)
+		}
+	})
+
+	t.Run("MultipartFile", func(t *testing.T) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, _ := writer.CreateFormFile("file", "categories.csv")
+		part.Write([]byte(csv))
+		writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/categories/import", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response map[string]interface{}
+		json.NewDecoder(rec.Body).Decode(&response)
+		if response["updated"] != float64(2) {
+			t.Errorf("expected both categories updated on re-import, got %v", response)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/categories/import", strings.NewReader(""))
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		big := strings.Repeat("Category,1\n", (1<<20)/10)
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/categories/import", strings.NewReader(big))
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected status %d, got %d", http.StatusRequestEntityTooLarge, rec.Code)
+		}
+	})
+}
+
 func TestHandleGetVoters_Success(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -697,6 +1174,39 @@ func TestHandleGetVoters_Success(t *testing.T) {
 	}
 }
 
+func TestHandleGetVoters_FormatsTimestampsInTimezone(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+	_ = setup.repo.SetSetting(ctx, "timezone", "Asia/Tokyo")
+
+	voterID, err := setup.repo.CreateVoterFull(ctx, nil, "Test Voter", "", "general", "TEST-QR1", "")
+	if err != nil {
+		t.Fatalf("failed to create test voter: %v", err)
+	}
+	if _, err := setup.repo.DB().Exec(`UPDATE voters SET last_voted_at = '2024-05-01 12:00:00' WHERE id = ?`, voterID); err != nil {
+		t.Fatalf("failed to set last_voted_at: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/voters", nil)
+	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	var response []map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 {
+		t.Fatalf("expected 1 voter, got %d", len(response))
+	}
+	if response[0]["last_voted_at"] != "2024-05-01T21:00:00+09:00" {
+		t.Errorf("expected last_voted_at in Asia/Tokyo, got %v", response[0]["last_voted_at"])
+	}
+	if createdAt, _ := response[0]["created_at"].(string); !strings.HasSuffix(createdAt, "+09:00") {
+		t.Errorf("expected created_at in Asia/Tokyo, got %q", createdAt)
+	}
+}
+
 func TestHandleGetVoters_Empty(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -728,29 +1238,151 @@ func TestHandleGetVoters_ServiceError(t *testing.T) {
 	}
 }
 
-func TestHandleCreateVoter_Success(t *testing.T) {
+func TestHandleGetStaleVoters_Success(t *testing.T) {
 	setup := newTestSetup(t)
+	ctx := context.Background()
 
-	payload := map[string]interface{}{
-		"name":       "New Voter",
-		"email":      "new@example.com",
-		"voter_type": "general",
-		"notes":      "test notes",
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_, _ = setup.repo.CreateCategory(ctx, "Most Creative", 2, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterID, _ := setup.repo.CreateVoter(ctx, "STALE-QR1")
+	_ = setup.repo.SaveVote(ctx, voterID, int(catID), cars[0].ID)
+	if _, err := setup.repo.DB().Exec(`UPDATE voters SET last_activity_at = '2024-05-01 12:00:00' WHERE id = ?`, voterID); err != nil {
+		t.Fatalf("failed to set last_activity_at: %v", err)
 	}
-	body, _ := json.Marshal(payload)
 
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/voters", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/voters/stale?minutes=30", nil)
 	rec := httptest.NewRecorder()
-
 	req.AddCookie(setup.authCookie)
 	setup.router.ServeHTTP(rec, req)
 
-	if rec.Code != http.StatusCreated {
-		t.Errorf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
 	}
-
-	var response map[string]interface{}
+	var response []map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 {
+		t.Fatalf("expected 1 stale voter, got %v", response)
+	}
+	if response[0]["qr_code"] != "STALE-QR1" || response[0]["votes_cast"] != float64(1) || response[0]["categories_available"] != float64(2) {
+		t.Errorf("unexpected stale voter: %v", response[0])
+	}
+}
+
+func TestHandleGetStaleVoters_Empty(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/voters/stale", nil)
+	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != "[]" {
+		t.Errorf("expected empty list, got %s", body)
+	}
+}
+
+func TestHandleGetStaleVoters_InvalidMinutes(t *testing.T) {
+	setup := newTestSetup(t)
+
+	for _, minutes := range []string{"abc", "0", "-5"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/voters/stale?minutes="+minutes, nil)
+		rec := httptest.NewRecorder()
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("minutes=%s: expected status %d, got %d", minutes, http.StatusBadRequest, rec.Code)
+		}
+	}
+}
+
+func TestHandleVoterGroups(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	do := func(method, path string, payload interface{}) *httptest.ResponseRecorder {
+		var body []byte
+		if payload != nil {
+			body, _ = json.Marshal(payload)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/api/admin/voters", map[string]interface{}{"name": "Alex", "voter_group": "Wolf Den"})
+	if rec.Code != http.StatusCreated || !strings.Contains(rec.Body.String(), `"voter_group":"Wolf Den"`) {
+		t.Fatalf("expected the voter created in Wolf Den, got %d: %s", rec.Code, rec.Body.String())
+	}
+	_ = do(http.MethodPost, "/api/admin/voters", map[string]interface{}{"name": "Sam"})
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	voters, _ := setup.repo.ListVoters(ctx)
+	for _, voter := range voters {
+		if voter["name"] == "Alex" {
+			_ = setup.repo.SaveVote(ctx, int(voter["id"].(int64)), int(catID), 1)
+		}
+	}
+
+	var listed []map[string]interface{}
+	rec = do(http.MethodGet, "/api/admin/voters?group=Wolf+Den", nil)
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(listed) != 1 || listed[0]["name"] != "Alex" {
+		t.Errorf("expected only Alex in Wolf Den, got %v", listed)
+	}
+	rec = do(http.MethodGet, "/api/admin/voters", nil)
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil || len(listed) != 2 {
+		t.Errorf("expected both voters without a group filter, got %v", listed)
+	}
+
+	var turnout []models.VoterGroupTurnout
+	rec = do(http.MethodGet, "/api/admin/stats/voter-groups", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&turnout); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(turnout) != 2 || turnout[0].VoterGroup != "Wolf Den" || turnout[0].Voted != 1 || turnout[0].TurnoutRate != 1 {
+		t.Errorf("unexpected turnout: %+v", turnout)
+	}
+}
+
+func TestHandleCreateVoter_Success(t *testing.T) {
+	setup := newTestSetup(t)
+
+	payload := map[string]interface{}{
+		"name":       "New Voter",
+		"email":      "new@example.com",
+		"voter_type": "general",
+		"notes":      "test notes",
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voters", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	var response map[string]interface{}
 	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
@@ -902,99 +1534,272 @@ func TestHandleDeleteVoter_ServiceError(t *testing.T) {
 	}
 }
 
-// ==================== Stats Tests ====================
-
-func TestHandleGetStats_Success(t *testing.T) {
+func TestHandleDeleteVoter_WithVotes(t *testing.T) {
 	setup := newTestSetup(t)
+	ctx := context.Background()
 
-	req := httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterID, _ := setup.repo.CreateVoter(ctx, "JUDGE-QR")
+	if err := setup.repo.SaveVote(ctx, voterID, int(catID), cars[0].ID); err != nil {
+		t.Fatalf("failed to save vote: %v", err)
+	}
+
+	// Without force the voter and their ballot are kept
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/admin/voters/%d", voterID), nil)
 	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
 
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+	var response map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if response["confirmation_required"] != true {
+		t.Error("expected confirmation_required to be true")
+	}
+	if response["vote_count"] != float64(1) {
+		t.Errorf("expected vote_count to be 1, got %v", response["vote_count"])
+	}
+	if votes, _ := setup.repo.GetVoterVotes(ctx, voterID); len(votes) != 1 {
+		t.Errorf("expected the vote to be kept, got %v", votes)
+	}
+
+	// With force the voter is deleted
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/admin/voters/%d?force=true", voterID), nil)
+	rec = httptest.NewRecorder()
 	req.AddCookie(setup.authCookie)
 	setup.router.ServeHTTP(rec, req)
 
-	if rec.Code != http.StatusOK {
-		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+	}
+	if _, found, _ := setup.repo.GetVoterByQRCode(ctx, "JUDGE-QR"); found {
+		t.Error("expected voter to be deleted")
+	}
+}
+
+func TestHandleClearVoterVotes(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterID, _ := setup.repo.CreateVoter(ctx, "REDO-QR")
+	setup.repo.SaveVote(ctx, voterID, int(catID), cars[0].ID)
+	setup.repo.SetSetting(ctx, "voting_open", "false")
+
+	clear := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/admin/voters/%d/votes%s", voterID, query), nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
 	}
 
-	var response map[string]interface{}
+	if rec := clear(""); rec.Code != http.StatusForbidden {
+		t.Errorf("closed voting: expected status %d, got %d: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+
+	rec := clear("?force=true")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response handlers.VoterVotesClearedResponse
 	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
+	if response.VoterID != voterID || response.Cleared != 1 {
+		t.Errorf("expected 1 vote cleared for voter %d, got %+v", voterID, response)
+	}
 
-	// Check expected stat fields exist
-	expectedFields := []string{"total_voters", "total_votes", "total_categories", "total_cars", "voting_open"}
-	for _, field := range expectedFields {
-		if _, ok := response[field]; !ok {
-			t.Errorf("expected field %q in stats response", field)
-		}
+	voters, _ := setup.repo.ListVoters(ctx)
+	if len(voters) != 1 {
+		t.Errorf("expected voter record to remain, got %d voters", len(voters))
 	}
 }
 
-func TestHandleGetStats_ServiceError(t *testing.T) {
+func TestHandleClearVoterVotes_NotFound(t *testing.T) {
 	setup := newTestSetup(t)
 
-	// Close the database to cause a service error
-	setup.repo.DB().Close()
-
-	req := httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil)
-	rec := httptest.NewRecorder()
-
+	req := httptest.NewRequest(http.MethodDelete, "/api/admin/voters/9999/votes?force=true", nil)
 	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
 	setup.router.ServeHTTP(rec, req)
 
-	if rec.Code != http.StatusInternalServerError {
-		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d: %s", http.StatusNotFound, rec.Code, rec.Body.String())
 	}
 }
 
-func TestHandleGetStats_WithData(t *testing.T) {
+func TestHandleAssistedVote(t *testing.T) {
 	setup := newTestSetup(t)
 	ctx := context.Background()
 
-	// Add some data
-	_, _ = setup.repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil)
-	_, _ = setup.repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil)
-	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
-	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Voter 1", "", "general", "VOTER-1", "")
-
-	req := httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil)
-	rec := httptest.NewRecorder()
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterID, _ := setup.repo.CreateVoter(ctx, "ASSIST-QR1")
+	setup.repo.SetSetting(ctx, "voting_open", "false")
 
+	body := fmt.Sprintf(`{"category_id":%d,"car_id":%d,"reason":"Kiosk helper"}`, catID, cars[0].ID)
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/admin/voters/%d/vote", voterID), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
 	setup.router.ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusOK {
-		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if votes, _ := setup.repo.GetVoterVotes(ctx, voterID); votes[int(catID)] != cars[0].ID {
+		t.Errorf("expected the vote to be saved while voting is closed, got %v", votes)
+	}
+	entries, _ := setup.repo.ListAuditLog(ctx)
+	if len(entries) != 1 || entries[0].Action != models.AuditActionVoteAssisted || entries[0].Reason != "Kiosk helper" {
+		t.Errorf("unexpected audit log: %+v", entries)
 	}
 
-	var response map[string]interface{}
+	// Admin only
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/admin/voters/%d/vote", voterID), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("unauthenticated: expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestHandleVoidVote(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterID, _ := setup.repo.CreateVoter(ctx, "VOID-QR1")
+	otherID, _ := setup.repo.CreateVoter(ctx, "VOID-QR2")
+	setup.repo.SaveVote(ctx, voterID, int(catID), cars[0].ID)
+	setup.repo.SaveVote(ctx, otherID, int(catID), cars[0].ID)
+
+	void := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/votes/void", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := void(fmt.Sprintf(`{"voter_id":%d,"category_id":%d}`, voterID, catID)); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("missing reason: expected status %d, got %d: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+
+	rec := void(fmt.Sprintf(`{"voter_id":%d,"category_id":%d,"reason":"Judge double-scanned"}`, voterID, catID))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response handlers.VoteVoidResponse
 	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
+	if response.CarID != cars[0].ID || response.Category == nil {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+	if response.Category.TotalVotes != 1 {
+		t.Errorf("expected updated tally of 1 vote, got %d", response.Category.TotalVotes)
+	}
 
-	if response["total_categories"].(float64) != 2 {
-		t.Errorf("expected 2 categories, got %v", response["total_categories"])
+	if rec := void(fmt.Sprintf(`{"voter_id":%d,"category_id":%d,"reason":"Again"}`, voterID, catID)); rec.Code != http.StatusNotFound {
+		t.Errorf("already voided: expected status %d, got %d: %s", http.StatusNotFound, rec.Code, rec.Body.String())
 	}
-	if response["total_cars"].(float64) != 1 {
-		t.Errorf("expected 1 car, got %v", response["total_cars"])
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/audit-log", nil)
+	req.AddCookie(setup.authCookie)
+	rec = httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("audit log: expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
 	}
-	if response["total_voters"].(float64) != 1 {
-		t.Errorf("expected 1 voter, got %v", response["total_voters"])
+	var entries []models.AuditEntry
+	if err := json.NewDecoder(rec.Body).Decode(&entries); err != nil {
+		t.Fatalf("failed to decode audit log: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Reason != "Judge double-scanned" || entries[0].Action != models.AuditActionVoteVoided {
+		t.Errorf("unexpected audit log: %+v", entries)
+	}
+	if _, err := time.Parse(time.RFC3339, entries[0].CreatedAt); err != nil {
+		t.Errorf("expected RFC3339 created_at, got %q", entries[0].CreatedAt)
 	}
 }
 
-// ==================== Voting Control Tests ====================
-
-func TestHandleSetVotingStatus_Open(t *testing.T) {
+func TestHandleVoidVote_Embargoed(t *testing.T) {
 	setup := newTestSetup(t)
+	ctx := context.Background()
 
-	payload := map[string]interface{}{
-		"open": true,
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterIDs := make([]int, 3)
+	for i := range voterIDs {
+		voterIDs[i], _ = setup.repo.CreateVoter(ctx, fmt.Sprintf("EMBARGO-QR%d", i))
+		setup.repo.SaveVote(ctx, voterIDs[i], int(catID), cars[0].ID)
 	}
-	body, _ := json.Marshal(payload)
 
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-control", bytes.NewReader(body))
+	do := func(path, revealCode, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if revealCode != "" {
+			req.Header.Set(handlers.RevealHeader, revealCode)
+		}
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+	void := func(voterID int, revealCode string) handlers.VoteVoidResponse {
+		t.Helper()
+		body := fmt.Sprintf(`{"voter_id":%d,"category_id":%d,"reason":"Double-scanned"}`, voterID, catID)
+		rec := do("/api/admin/votes/void", revealCode, body)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response handlers.VoteVoidResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	if rec := do("/api/admin/voting/finalize", "", `{"embargo":true,"reveal_code":"curtain"}`); rec.Code != http.StatusOK {
+		t.Fatalf("finalize: expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// Voiding still works while embargoed, without giving the tally away
+	if response := void(voterIDs[0], ""); response.CarID != cars[0].ID || response.Category != nil {
+		t.Errorf("expected the void without a tally, got %+v", response)
+	}
+	if response := void(voterIDs[1], "wrong"); response.Category != nil {
+		t.Errorf("expected no tally with a wrong reveal code, got %+v", response.Category)
+	}
+	if response := void(voterIDs[2], "curtain"); response.Category == nil || response.Category.TotalVotes != 0 {
+		t.Errorf("expected the updated tally with the reveal code, got %+v", response.Category)
+	}
+}
+
+func TestHandleBulkDeleteVoters_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Walk-up", "", "general", "BULK-GEN-1", "")
+	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Walk-up 2", "", "general", "BULK-GEN-2", "")
+	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Judge", "", "judge", "BULK-JUDGE", "")
+
+	body := `{"voter_type":"general","confirm":true}`
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voters/bulk-delete", strings.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
@@ -1002,254 +1807,1159 @@ func TestHandleSetVotingStatus_Open(t *testing.T) {
 	setup.router.ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusOK {
-		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
 	}
 
-	var response map[string]interface{}
+	var response handlers.VoterBulkDeleteResponse
 	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
+	if response.Deleted != 2 {
+		t.Errorf("expected 2 voters deleted, got %d", response.Deleted)
+	}
 
-	if response["open"] != true {
-		t.Errorf("expected open=true, got %v", response["open"])
+	voters, _ := setup.repo.ListVoters(ctx)
+	if len(voters) != 1 {
+		t.Errorf("expected 1 voter remaining, got %d", len(voters))
 	}
 }
 
-func TestHandleSetVotingStatus_Close(t *testing.T) {
+func TestHandleClearTestVoters(t *testing.T) {
 	setup := newTestSetup(t)
+	ctx := context.Background()
 
-	payload := map[string]interface{}{
-		"open": false,
-	}
-	body, _ := json.Marshal(payload)
-
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-control", bytes.NewReader(body))
+	body := `{"name":"Setup","qr_code":"PRACTICE-1","is_test":true}`
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voters", strings.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(setup.authCookie)
 	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Parent", "", "general", "REAL-1", "")
 
+	req = httptest.NewRequest(http.MethodPost, "/api/admin/voters/clear-test", nil)
 	req.AddCookie(setup.authCookie)
+	rec = httptest.NewRecorder()
 	setup.router.ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusOK {
-		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
 	}
-
-	var response map[string]interface{}
+	var response handlers.VoterBulkDeleteResponse
 	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
+	if response.Deleted != 1 {
+		t.Errorf("expected 1 test voter deleted, got %d", response.Deleted)
+	}
+	voters, _ := setup.repo.ListVoters(ctx)
+	if len(voters) != 1 || voters[0]["qr_code"] != "REAL-1" {
+		t.Errorf("expected only REAL-1 to remain, got %v", voters)
+	}
+}
 
-	if response["open"] != false {
-		t.Errorf("expected open=false, got %v", response["open"])
+func TestHandleBulkDeleteVoters_RequiresConfirm(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Walk-up", "", "general", "BULK-GEN-1", "")
+
+	body := `{"voter_type":"general"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voters/bulk-delete", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	voters, _ := setup.repo.ListVoters(ctx)
+	if len(voters) != 1 {
+		t.Errorf("expected voter to remain without confirm, got %d voters", len(voters))
 	}
 }
 
-func TestHandleSetVotingStatus_InvalidJSON(t *testing.T) {
+func TestHandleBulkDeleteVoters_MatchAllRequiresConfirmAll(t *testing.T) {
 	setup := newTestSetup(t)
+	ctx := context.Background()
 
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-control", bytes.NewReader([]byte("invalid")))
-	req.Header.Set("Content-Type", "application/json")
+	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Walk-up", "", "general", "BULK-GEN-1", "")
+	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Judge", "", "judge", "BULK-JUDGE", "")
+
+	// Empty filter without confirm_all is rejected
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voters/bulk-delete", strings.NewReader(`{"confirm":true}`))
 	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
 
+	// Empty filter with confirm_all deletes everyone
+	req = httptest.NewRequest(http.MethodPost, "/api/admin/voters/bulk-delete", strings.NewReader(`{"confirm":true,"confirm_all":true}`))
+	rec = httptest.NewRecorder()
 	req.AddCookie(setup.authCookie)
 	setup.router.ServeHTTP(rec, req)
 
-	if rec.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	voters, _ := setup.repo.ListVoters(ctx)
+	if len(voters) != 0 {
+		t.Errorf("expected 0 voters remaining, got %d", len(voters))
+	}
+}
+
+func TestHandleBulkDeleteVoters_InvalidJSON(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voters/bulk-delete", strings.NewReader("invalid"))
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestHandleBulkDeleteVoters_ServiceError(t *testing.T) {
+	setup := newTestSetup(t)
+
+	setup.repo.DB().Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voters/bulk-delete", strings.NewReader(`{"voter_type":"general","confirm":true}`))
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+// ==================== Stats Tests ====================
+
+func TestHandleGetStats_Success(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// Check expected stat fields exist
+	expectedFields := []string{"total_voters", "total_votes", "total_categories", "total_cars", "voting_open"}
+	for _, field := range expectedFields {
+		if _, ok := response[field]; !ok {
+			t.Errorf("expected field %q in stats response", field)
+		}
+	}
+}
+
+func TestHandleGetTopCars(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterID, _ := setup.repo.CreateVoter(ctx, "TOP-QR1")
+	setup.repo.SaveVote(ctx, voterID, int(catID), cars[0].ID)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/stats/top-cars"+query, nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("?breakdown=true")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var top []services.TopCar
+	if err := json.NewDecoder(rec.Body).Decode(&top); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(top) != 1 || top[0].CarNumber != "101" || top[0].TotalVotes != 1 || len(top[0].Categories) != 1 {
+		t.Errorf("unexpected leaderboard: %+v", top)
+	}
+
+	if rec := get("?limit=abc"); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid limit: expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if rec := get("?limit=1000"); rec.Code != http.StatusBadRequest {
+		t.Errorf("limit too large: expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestHandleGetStats_ServiceError(t *testing.T) {
+	setup := newTestSetup(t)
+
+	// Close the database to cause a service error
+	setup.repo.DB().Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+func TestHandleGetStats_WithData(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	// Add some data
+	_, _ = setup.repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil, nil)
+	_, _ = setup.repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Voter 1", "", "general", "VOTER-1", "")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response["total_categories"].(float64) != 2 {
+		t.Errorf("expected 2 categories, got %v", response["total_categories"])
+	}
+	if response["total_cars"].(float64) != 1 {
+		t.Errorf("expected 1 car, got %v", response["total_cars"])
+	}
+	if response["total_voters"].(float64) != 1 {
+		t.Errorf("expected 1 voter, got %v", response["total_voters"])
+	}
+}
+
+// ==================== Voting Control Tests ====================
+
+func TestHandleSetVotingStatus_Open(t *testing.T) {
+	setup := newTestSetup(t)
+
+	payload := map[string]interface{}{
+		"open": true,
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-control", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response["open"] != true {
+		t.Errorf("expected open=true, got %v", response["open"])
+	}
+}
+
+func TestHandleSetVotingStatus_Close(t *testing.T) {
+	setup := newTestSetup(t)
+
+	payload := map[string]interface{}{
+		"open": false,
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-control", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response["open"] != false {
+		t.Errorf("expected open=false, got %v", response["open"])
+	}
+}
+
+func TestHandleSetVotingStatus_InvalidJSON(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-control", bytes.NewReader([]byte("invalid")))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestHandleSetVotingStatus_EmptyBody(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-control", nil)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestHandleSetVotingTimer_Success(t *testing.T) {
+	setup := newTestSetup(t)
+
+	payload := map[string]interface{}{
+		"minutes": 10,
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response["minutes"].(float64) != 10 {
+		t.Errorf("expected minutes=10, got %v", response["minutes"])
+	}
+	if response["close_time"] == nil || response["close_time"] == "" {
+		t.Error("expected close_time to be set")
+	}
+}
+
+func TestHandleSetVotingTimer_FormatsCloseTimeInTimezone(t *testing.T) {
+	setup := newTestSetup(t)
+	_ = setup.repo.SetSetting(context.Background(), "timezone", "Asia/Kolkata")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", strings.NewReader(`{"minutes": 5}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if closeTime, _ := response["close_time"].(string); !strings.HasSuffix(closeTime, "+05:30") {
+		t.Errorf("expected close_time in Asia/Kolkata, got %q", closeTime)
+	}
+
+	// Stored value stays in UTC
+	stored, _ := setup.repo.GetSetting(context.Background(), "voting_close_time")
+	if !strings.HasSuffix(stored, "Z") {
+		t.Errorf("expected stored close time in UTC, got %q", stored)
+	}
+}
+
+func TestHandleSetVotingTimer_InvalidMinutes_Zero(t *testing.T) {
+	setup := newTestSetup(t)
+
+	payload := map[string]interface{}{
+		"minutes": 0,
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestHandleSetVotingTimer_InvalidMinutes_TooHigh(t *testing.T) {
+	setup := newTestSetup(t)
+
+	payload := map[string]interface{}{
+		"minutes": 61,
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestHandleSetVotingTimer_InvalidJSON(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader([]byte("invalid")))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestHandleSetVotingTimer_ServiceError(t *testing.T) {
+	setup := newTestSetup(t)
+
+	// Close database to trigger service error
+	setup.repo.DB().Close()
+
+	payload := map[string]interface{}{
+		"minutes": 10,
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	// Should return 500 for internal service error
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+func TestHandleSetVotingTimer_ConfiguredMax(t *testing.T) {
+	setup := newTestSetup(t)
+	_ = setup.repo.SetSetting(context.Background(), "max_voting_minutes", "240")
+
+	body, _ := json.Marshal(map[string]interface{}{"minutes": 180})
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleGetVotingTimerPresets(t *testing.T) {
+	setup := newTestSetup(t)
+
+	get := func() handlers.VotingTimerPresetsResponse {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/voting-timer/presets", nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response handlers.VotingTimerPresetsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	response := get()
+	if response.MaxMinutes != services.DefaultMaxVotingMinutes || len(response.Presets) != len(services.DefaultVotingTimerPresets) {
+		t.Errorf("expected defaults, got %+v", response)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/settings", strings.NewReader(`{"max_voting_minutes": 240, "voting_timer_presets": [30, 60, 120]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update settings: expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	response = get()
+	if response.MaxMinutes != 240 || len(response.Presets) != 3 || response.Presets[2] != 120 {
+		t.Errorf("expected configured presets, got %+v", response)
+	}
+}
+
+// ==================== Settings Tests ====================
+
+func TestHandleGetSettings_Success(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/settings", nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// Check that expected fields exist (may be empty strings)
+	if _, ok := response["derbynet_url"]; !ok {
+		t.Error("expected derbynet_url in response")
+	}
+	if _, ok := response["base_url"]; !ok {
+		t.Error("expected base_url in response")
+	}
+}
+
+func TestHandleUpdateSettings_Success(t *testing.T) {
+	setup := newTestSetup(t)
+
+	payload := map[string]interface{}{
+		"derbynet_url": "http://derbynet.local",
+		"base_url":     "http://voting.local",
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// Verify settings were updated
+	getReq := httptest.NewRequest(http.MethodGet, "/api/admin/settings", nil)
+	getRec := httptest.NewRecorder()
+	getReq.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(getRec, getReq)
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(getRec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response["derbynet_url"] != "http://derbynet.local" {
+		t.Errorf("expected derbynet_url 'http://derbynet.local', got %v", response["derbynet_url"])
+	}
+	if response["base_url"] != "http://voting.local" {
+		t.Errorf("expected base_url 'http://voting.local', got %v", response["base_url"])
+	}
+}
+
+func TestHandleUpdateSettings_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		field   string
+	}{
+		{"InvalidURL", `{"derbynet_url": "not a url"}`, "derbynet_url"},
+		{"UnknownKey", `{"base_url": "http://voting.local", "colour": "red"}`, "colour"},
+		{"WrongType", `{"require_registered_qr": "yes"}`, "require_registered_qr"},
+		{"InstructionsByTypeNotObject", `{"voting_instructions_by_type": ["judge"]}`, "voting_instructions_by_type"},
+		{"InstructionsByTypeBlankType", `{"voting_instructions_by_type": {"": "Hello"}}`, "voting_instructions_by_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newTestSetup(t)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", strings.NewReader(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			req.AddCookie(setup.authCookie)
+			setup.router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+			}
+
+			var response struct {
+				Error struct {
+					Code   string            `json:"code"`
+					Field  string            `json:"field"`
+					Fields map[string]string `json:"fields"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Error.Code != "VALIDATION_ERROR" {
+				t.Errorf("expected code VALIDATION_ERROR, got %q", response.Error.Code)
+			}
+			if response.Error.Field != tt.field {
+				t.Errorf("expected field %s, got %q", tt.field, response.Error.Field)
+			}
+			if response.Error.Fields[tt.field] == "" {
+				t.Errorf("expected field error for %s, got %v", tt.field, response.Error.Fields)
+			}
+		})
+	}
+}
+
+func TestHandleNewEvent_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	voterID, _ := setup.repo.CreateVoter(ctx, "EVENT-QR")
+	_ = setup.repo.SaveVote(ctx, voterID, int(catID), 1)
+	_ = setup.repo.SetManualWinner(ctx, int(catID), 1, "Judges' call")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/new-event", strings.NewReader(`{"confirm": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response handlers.NewEventResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Cleared["votes"] != 1 || response.Cleared["voters"] != 1 || response.Cleared["overrides"] != 1 {
+		t.Errorf("unexpected cleared counts: %v", response.Cleared)
+	}
+	if response.VotingOpen {
+		t.Error("expected voting_open false")
+	}
+	if cars, _ := setup.repo.ListCars(ctx); len(cars) != 1 {
+		t.Errorf("expected cars to be kept, got %d", len(cars))
+	}
+}
+
+func TestHandleNewEvent_RequiresConfirm(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+	_, _ = setup.repo.CreateVoter(ctx, "KEEP-QR")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/new-event", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if voters, _ := setup.repo.ListVoters(ctx); len(voters) != 1 {
+		t.Errorf("expected voters to be kept without confirm, got %d", len(voters))
+	}
+}
+
+func TestHandleExportImportSettings_RoundTrip(t *testing.T) {
+	source := newTestSetup(t)
+	ctx := context.Background()
+	_ = source.repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+	_ = source.repo.SetSetting(ctx, "derbynet_password", "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/settings/export", nil)
+	rec := httptest.NewRecorder()
+	req.AddCookie(source.authCookie)
+	source.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var exported map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&exported); err != nil {
+		t.Fatalf("failed to decode export: %v", err)
+	}
+	if _, ok := exported["derbynet_password"]; ok {
+		t.Error("expected derbynet_password to be excluded from export")
+	}
+
+	target := newTestSetup(t)
+	body, _ := json.Marshal(map[string]interface{}{"settings": exported})
+	req = httptest.NewRequest(http.MethodPost, "/api/admin/settings/import", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	req.AddCookie(target.authCookie)
+	target.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if url, _ := target.repo.GetSetting(ctx, "derbynet_url"); url != "http://derbynet.local" {
+		t.Errorf("expected derbynet_url to be imported, got %q", url)
+	}
+}
+
+func TestHandleImportSettings_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		expected int
+	}{
+		{"InvalidJSON", `invalid`, http.StatusBadRequest},
+		{"Empty", `{"settings": {}}`, http.StatusBadRequest},
+		{"InvalidValue", `{"settings": {"base_url": "nope"}}`, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newTestSetup(t)
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/settings/import", strings.NewReader(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			req.AddCookie(setup.authCookie)
+			setup.router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("expected status %d, got %d: %s", tt.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleExportSettings_ServiceError(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.repo.DB().Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/settings/export", nil)
+	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 for service error, got %d", rec.Code)
+	}
+}
+
+func TestHandleExportCategoryBallots(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterID, _ := setup.repo.CreateVoterFull(ctx, nil, "=Pat", "", "judge", "BALLOT-QR", "")
+	setup.repo.SaveVote(ctx, int(voterID), int(catID), cars[0].ID)
+
+	export := func() [][]string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/categories/%d/ballots.csv", catID), nil)
+		rec := httptest.NewRecorder()
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+			t.Errorf("expected text/csv, got %q", ct)
+		}
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("invalid CSV: %v", err)
+		}
+		if len(records) != 2 {
+			t.Fatalf("expected header and 1 ballot, got %v", records)
+		}
+		return records
+	}
+
+	row := export()[1]
+	if row[0] != fmt.Sprint(voterID) || row[1] != "'=Pat" || row[2] != "judge" || row[4] != "101" {
+		t.Errorf("unexpected ballot row: %v", row)
+	}
+
+	setup.repo.SetSetting(ctx, "anonymize_ballots", "true")
+	row = export()[1]
+	if !strings.HasPrefix(row[0], "anon-") || row[1] != "" {
+		t.Errorf("expected anonymized voter, got %v", row)
+	}
+	if again := export()[1]; again[0] != row[0] {
+		t.Errorf("expected a stable anonymized ID, got %q and %q", row[0], again[0])
 	}
 }
 
-func TestHandleSetVotingStatus_EmptyBody(t *testing.T) {
+func TestHandleExportCategoryBallots_NotFound(t *testing.T) {
 	setup := newTestSetup(t)
 
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-control", nil)
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/categories/9999/ballots.csv", nil)
 	rec := httptest.NewRecorder()
-
 	req.AddCookie(setup.authCookie)
 	setup.router.ServeHTTP(rec, req)
 
-	if rec.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
 	}
 }
 
-func TestHandleSetVotingTimer_Success(t *testing.T) {
+func TestHandleGetCategoryVotes(t *testing.T) {
 	setup := newTestSetup(t)
+	ctx := context.Background()
+	categoryID, _ := setup.repo.CreateCategory(ctx, "Best in Show", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "42", "Racer", "Car", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterID, _ := setup.repo.CreateVoter(ctx, "TIMING-QR")
+	_ = setup.repo.SaveVote(ctx, voterID, int(categoryID), cars[0].ID)
 
-	payload := map[string]interface{}{
-		"minutes": 10,
+	get := func(id int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/categories/%d/votes", id), nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
 	}
-	body, _ := json.Marshal(payload)
-
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
-	rec := httptest.NewRecorder()
-
-	req.AddCookie(setup.authCookie)
-	setup.router.ServeHTTP(rec, req)
 
+	rec := get(categoryID)
 	if rec.Code != http.StatusOK {
-		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
 	}
-
-	var response map[string]interface{}
-	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	var votes []models.CategoryVote
+	json.NewDecoder(rec.Body).Decode(&votes)
+	if len(votes) != 1 || votes[0].VoterID != voterID || votes[0].CarID != cars[0].ID {
+		t.Fatalf("expected the one vote, got %+v", votes)
 	}
-
-	if response["minutes"].(float64) != 10 {
-		t.Errorf("expected minutes=10, got %v", response["minutes"])
+	if _, err := time.Parse(time.RFC3339, votes[0].CreatedAt); err != nil {
+		t.Errorf("expected an RFC 3339 created_at, got %q", votes[0].CreatedAt)
 	}
-	if response["close_time"] == nil || response["close_time"] == "" {
-		t.Error("expected close_time to be set")
+
+	if rec := get(9999); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown category: expected status %d, got %d", http.StatusNotFound, rec.Code)
 	}
 }
 
-func TestHandleSetVotingTimer_InvalidMinutes_Zero(t *testing.T) {
-	setup := newTestSetup(t)
+func TestHandleMergeVotes(t *testing.T) {
+	remote := newTestSetup(t)
+	local := newTestSetup(t)
+	ctx := context.Background()
 
-	payload := map[string]interface{}{
-		"minutes": 0,
+	for _, setup := range []*testSetup{remote, local} {
+		setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+		setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
 	}
-	body, _ := json.Marshal(payload)
+	cars, _ := remote.repo.ListCars(ctx)
+	categories, _ := remote.repo.ListCategories(ctx)
+	voterID, _ := remote.repo.CreateVoter(ctx, "REMOTE-QR")
+	remote.repo.SaveVote(ctx, voterID, categories[0].ID, cars[0].ID)
 
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/votes/export", nil)
 	rec := httptest.NewRecorder()
+	req.AddCookie(remote.authCookie)
+	remote.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("export: expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.Contains(cd, "derbyvote-votes.json") {
+		t.Errorf("expected attachment filename, got %q", cd)
+	}
 
-	req.AddCookie(setup.authCookie)
-	setup.router.ServeHTTP(rec, req)
+	req = httptest.NewRequest(http.MethodPost, "/api/admin/merge-votes", bytes.NewReader(rec.Body.Bytes()))
+	rec = httptest.NewRecorder()
+	req.AddCookie(local.authCookie)
+	local.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("merge: expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
 
-	if rec.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	var result services.MergeResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if result.VotersAdded != 1 || result.VotesAdded != 1 {
+		t.Errorf("expected 1 voter and 1 vote added, got %+v", result)
+	}
+	if _, err := local.repo.GetVoterByQR(ctx, "REMOTE-QR"); err != nil {
+		t.Errorf("expected merged voter to exist: %v", err)
 	}
 }
 
-func TestHandleSetVotingTimer_InvalidMinutes_TooHigh(t *testing.T) {
+func TestHandleMergeVotes_InvalidBody(t *testing.T) {
 	setup := newTestSetup(t)
 
-	payload := map[string]interface{}{
-		"minutes": 61,
-	}
-	body, _ := json.Marshal(payload)
-
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
-	rec := httptest.NewRecorder()
-
-	req.AddCookie(setup.authCookie)
-	setup.router.ServeHTTP(rec, req)
+	for _, body := range []string{"not json", `{"voters":[],"votes":[]}`} {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/merge-votes", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
 
-	if rec.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("body %q: expected status %d, got %d", body, http.StatusBadRequest, rec.Code)
+		}
 	}
 }
 
-func TestHandleSetVotingTimer_InvalidJSON(t *testing.T) {
+func TestHandleGetBranding(t *testing.T) {
 	setup := newTestSetup(t)
+	_ = setup.repo.SetSetting(context.Background(), "event_name", "Pack 42 Derby")
 
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader([]byte("invalid")))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/branding", nil)
 	rec := httptest.NewRecorder()
+	setup.handlers.Router().ServeHTTP(rec, req)
 
-	req.AddCookie(setup.authCookie)
-	setup.router.ServeHTTP(rec, req)
-
-	if rec.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var branding services.Branding
+	if err := json.NewDecoder(rec.Body).Decode(&branding); err != nil {
+		t.Fatalf("failed to decode branding: %v", err)
+	}
+	if branding.EventName != "Pack 42 Derby" || branding.ThemeColor != services.DefaultThemeColor {
+		t.Errorf("unexpected branding: %+v", branding)
 	}
 }
 
-func TestHandleSetVotingTimer_ServiceError(t *testing.T) {
+func TestHandleGetBranding_ServiceError(t *testing.T) {
 	setup := newTestSetup(t)
-
-	// Close database to trigger service error
 	setup.repo.DB().Close()
 
-	payload := map[string]interface{}{
-		"minutes": 10,
-	}
-	body, _ := json.Marshal(payload)
-
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/branding", nil)
 	rec := httptest.NewRecorder()
+	setup.handlers.Router().ServeHTTP(rec, req)
 
-	req.AddCookie(setup.authCookie)
-	setup.router.ServeHTTP(rec, req)
-
-	// Should return 500 for internal service error
 	if rec.Code != http.StatusInternalServerError {
-		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+		t.Errorf("expected 500 for service error, got %d", rec.Code)
 	}
 }
 
-// ==================== Settings Tests ====================
+// newLogoUploadRequest builds a multipart logo upload request
+func newLogoUploadRequest(t *testing.T, data []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("logo", "logo.png")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	part.Write(data)
+	writer.Close()
 
-func TestHandleGetSettings_Success(t *testing.T) {
-	setup := newTestSetup(t)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/branding/logo", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
 
-	req := httptest.NewRequest(http.MethodGet, "/api/admin/settings", nil)
-	rec := httptest.NewRecorder()
+func TestHandleUploadLogo_SavesAndServes(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.handlers.SetUploadDir(t.TempDir())
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
 
+	req := newLogoUploadRequest(t, png)
 	req.AddCookie(setup.authCookie)
-	setup.router.ServeHTTP(rec, req)
+	rec := httptest.NewRecorder()
+	setup.handlers.Router().ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusOK {
-		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
 	}
-
-	var response map[string]interface{}
-	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	logoURL, _ := setup.repo.GetSetting(context.Background(), "logo_url")
+	if !strings.HasPrefix(logoURL, "/branding/logo") {
+		t.Errorf("expected logo_url to point at uploaded logo, got %q", logoURL)
 	}
 
-	// Check that expected fields exist (may be empty strings)
-	if _, ok := response["derbynet_url"]; !ok {
-		t.Error("expected derbynet_url in response")
+	req = httptest.NewRequest(http.MethodGet, "/branding/logo", nil)
+	rec = httptest.NewRecorder()
+	setup.handlers.Router().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
 	}
-	if _, ok := response["base_url"]; !ok {
-		t.Error("expected base_url in response")
+	if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+		t.Errorf("expected image/png, got %q", ct)
+	}
+	if !bytes.Equal(rec.Body.Bytes(), png) {
+		t.Error("expected served logo to match upload")
 	}
 }
 
-func TestHandleUpdateSettings_Success(t *testing.T) {
-	setup := newTestSetup(t)
+func TestHandleUploadLogo_Errors(t *testing.T) {
+	t.Run("NotConfigured", func(t *testing.T) {
+		setup := newTestSetup(t)
+		req := newLogoUploadRequest(t, []byte("\x89PNG\r\n\x1a\n"))
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.handlers.Router().ServeHTTP(rec, req)
 
-	payload := map[string]interface{}{
-		"derbynet_url": "http://derbynet.local",
-		"base_url":     "http://voting.local",
-	}
-	body, _ := json.Marshal(payload)
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("expected 500, got %d", rec.Code)
+		}
+	})
+
+	t.Run("NotAnImage", func(t *testing.T) {
+		setup := newTestSetup(t)
+		setup.handlers.SetUploadDir(t.TempDir())
+		req := newLogoUploadRequest(t, []byte("<svg onload=alert(1)></svg>"))
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.handlers.Router().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		setup := newTestSetup(t)
+		setup.handlers.SetUploadDir(t.TempDir())
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/branding/logo", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.handlers.Router().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
 
-	req := httptest.NewRequest(http.MethodPut, "/api/admin/settings", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
-	rec := httptest.NewRecorder()
+	t.Run("Unauthenticated", func(t *testing.T) {
+		setup := newTestSetup(t)
+		setup.handlers.SetUploadDir(t.TempDir())
+		req := newLogoUploadRequest(t, []byte("\x89PNG\r\n\x1a\n"))
+		rec := httptest.NewRecorder()
+		setup.handlers.Router().ServeHTTP(rec, req)
 
-	req.AddCookie(setup.authCookie)
-	setup.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", rec.Code)
+		}
+	})
+}
 
+func TestHandleUploadCategoryBanner(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.handlers.SetUploadDir(t.TempDir())
+	ctx := context.Background()
+	id, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	upload := func(categoryID int64, data []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, _ := writer.CreateFormFile("banner", "banner.png")
+		part.Write(data)
+		writer.Close()
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/admin/categories/%d/banner", categoryID), &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := upload(id, png)
 	if rec.Code != http.StatusOK {
-		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response handlers.CategoryBannerResponse
+	json.NewDecoder(rec.Body).Decode(&response)
+	if !strings.HasPrefix(response.BannerURL, fmt.Sprintf("/categories/%d/banner", id)) {
+		t.Errorf("expected banner_url to point at the upload, got %q", response.BannerURL)
+	}
+	categories, _ := setup.repo.ListCategories(ctx)
+	if categories[0].BannerURL != response.BannerURL {
+		t.Errorf("expected category banner_url %q, got %q", response.BannerURL, categories[0].BannerURL)
 	}
 
-	// Verify settings were updated
-	getReq := httptest.NewRequest(http.MethodGet, "/api/admin/settings", nil)
-	getRec := httptest.NewRecorder()
-	getReq.AddCookie(setup.authCookie)
-	setup.router.ServeHTTP(getRec, getReq)
+	req := httptest.NewRequest(http.MethodGet, response.BannerURL, nil)
+	rec = httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !bytes.Equal(rec.Body.Bytes(), png) {
+		t.Fatalf("expected the uploaded banner to be served, got %d", rec.Code)
+	}
+	if cc := rec.Header().Get("Cache-Control"); !strings.Contains(cc, "max-age") {
+		t.Errorf("expected a cacheable banner, got Cache-Control %q", cc)
+	}
 
-	var response map[string]interface{}
-	if err := json.NewDecoder(getRec.Body).Decode(&response); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	if rec := upload(id, []byte("<svg onload=alert(1)></svg>")); rec.Code != http.StatusBadRequest {
+		t.Errorf("not an image: expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if rec := upload(999, png); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown category: expected status %d, got %d", http.StatusNotFound, rec.Code)
 	}
 
-	if response["derbynet_url"] != "http://derbynet.local" {
-		t.Errorf("expected derbynet_url 'http://derbynet.local', got %v", response["derbynet_url"])
+	req = httptest.NewRequest(http.MethodGet, "/categories/999/banner", nil)
+	rec = httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a category without a banner, got %d", rec.Code)
 	}
-	if response["base_url"] != "http://voting.local" {
-		t.Errorf("expected base_url 'http://voting.local', got %v", response["base_url"])
+}
+
+func TestHandleBrandingLogo_NotUploaded(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.handlers.SetUploadDir(t.TempDir())
+
+	req := httptest.NewRequest(http.MethodGet, "/branding/logo", nil)
+	rec := httptest.NewRecorder()
+	setup.handlers.Router().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
 	}
 }
 
@@ -1402,7 +3112,7 @@ func TestHandleGetCategoryGroups_Success(t *testing.T) {
 
 	// Create a group first
 	ctx := context.Background()
-	_, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, 1)
+	_, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -1449,6 +3159,28 @@ func TestHandleCreateCategoryGroup_Success(t *testing.T) {
 	}
 }
 
+func TestHandleCreateCategoryGroup_InvalidMultiWinStrategy(t *testing.T) {
+	setup := newTestSetup(t)
+
+	payload := map[string]interface{}{
+		"name":               "New Group",
+		"multi_win_strategy": "coin_flip",
+		"display_order":      1,
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/category-groups", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+}
+
 func TestHandleCreateCategoryGroup_InvalidJSON(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -1494,7 +3226,7 @@ func TestHandleGetCategoryGroup_Success(t *testing.T) {
 
 	// Create a group first
 	ctx := context.Background()
-	id, err := setup.repo.CreateCategoryGroup(ctx, "Get Group", "Description", nil, nil, 1)
+	id, err := setup.repo.CreateCategoryGroup(ctx, "Get Group", "Description", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -1529,7 +3261,7 @@ func TestHandleUpdateCategoryGroup_Success(t *testing.T) {
 
 	// Create a group first
 	ctx := context.Background()
-	id, err := setup.repo.CreateCategoryGroup(ctx, "Original Group", "Original Desc", nil, nil, 1)
+	id, err := setup.repo.CreateCategoryGroup(ctx, "Original Group", "Original Desc", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -1558,7 +3290,7 @@ func TestHandleDeleteCategoryGroup_Success(t *testing.T) {
 
 	// Create a group first
 	ctx := context.Background()
-	id, err := setup.repo.CreateCategoryGroup(ctx, "Delete Group", "Description", nil, nil, 1)
+	id, err := setup.repo.CreateCategoryGroup(ctx, "Delete Group", "Description", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -1593,7 +3325,7 @@ func TestHandleDeleteCategoryGroup_ServiceErrorOnGet(t *testing.T) {
 
 	// Create a group first
 	ctx := context.Background()
-	id, err := setup.repo.CreateCategoryGroup(ctx, "Delete Group", "Description", nil, nil, 1)
+	id, err := setup.repo.CreateCategoryGroup(ctx, "Delete Group", "Description", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -1640,7 +3372,7 @@ func TestHandleUpdateCategoryGroup_ServiceErrorOnGet(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a group first
-	id, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, 1)
+	id, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -1673,7 +3405,7 @@ func TestHandleUpdateCategoryGroup_InvalidJSON(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a group first
-	id, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, 1)
+	id, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -1875,6 +3607,53 @@ func TestHandleSeedMockData_Categories(t *testing.T) {
 	}
 }
 
+func TestHandleSeedMockDataPreview(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/seed-mock-data/preview", nil)
+	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var preview handlers.SeedMockDataPreviewResponse
+	if err := json.NewDecoder(rec.Body).Decode(&preview); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(preview.Categories) != len(services.MockCategories()) || len(preview.Cars) != len(services.MockCars()) || len(preview.Voters) != len(services.MockVoters()) {
+		t.Errorf("expected every mock type, got %d categories, %d cars, %d voters", len(preview.Categories), len(preview.Cars), len(preview.Voters))
+	}
+	if preview.Voters[0].QRCode == "" {
+		t.Error("expected voter QR codes in preview")
+	}
+
+	// Nothing is written
+	categories, _ := setup.repo.ListCategories(ctx)
+	cars, _ := setup.repo.ListCars(ctx)
+	voters, _ := setup.repo.ListVoters(ctx)
+	if len(categories) != 0 || len(cars) != 0 || len(voters) != 0 {
+		t.Errorf("expected preview not to persist, got %d categories, %d cars, %d voters", len(categories), len(cars), len(voters))
+	}
+
+	for seedType, wantStatus := range map[string]int{"cars": http.StatusOK, "votes": http.StatusBadRequest, "bogus": http.StatusBadRequest} {
+		body, _ := json.Marshal(map[string]string{"seed_type": seedType})
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/seed-mock-data/preview", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		if rec.Code != wantStatus {
+			t.Errorf("seed_type %q: expected status %d, got %d", seedType, wantStatus, rec.Code)
+		}
+		if seedType == "cars" && strings.Contains(rec.Body.String(), `"voters"`) {
+			t.Errorf("expected only cars in preview, got %s", rec.Body.String())
+		}
+	}
+}
+
 func TestHandleSeedMockData_CategoriesAlreadyExist(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -2047,27 +3826,129 @@ func TestHandleSeedMockData_CategoriesServiceError(t *testing.T) {
 	}
 }
 
-func TestHandleSeedMockData_CarsServiceError(t *testing.T) {
+func TestHandleSeedMockData_CarsServiceError(t *testing.T) {
+	setup := newTestSetup(t)
+
+	// Close database to trigger service error
+	setup.repo.DB().Close()
+
+	payload := map[string]interface{}{
+		"seed_type": "cars",
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/seed-mock-data", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	// Should return 500 for internal service error
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+// postSeed sends a seed-mock-data request and decodes the response
+func postSeed(t *testing.T, setup *testSetup, query, seedType string) (int, handlers.SeedMockDataResponse) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"seed_type": seedType})
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/seed-mock-data"+query, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	var response handlers.SeedMockDataResponse
+	json.NewDecoder(rec.Body).Decode(&response)
+	return rec.Code, response
+}
+
+func TestHandleSeedMockData_ReturnsCreatedCount(t *testing.T) {
+	setup := newTestSetup(t)
+
+	code, response := postSeed(t, setup, "", "cars")
+	if code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if response.Created != 20 {
+		t.Errorf("expected 20 cars created, got %d", response.Created)
+	}
+
+	_, response = postSeed(t, setup, "", "cars")
+	if response.Created != 0 {
+		t.Errorf("expected 0 cars created on reseed, got %d", response.Created)
+	}
+}
+
+func TestHandleSeedMockData_ClearReseeds(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+	setup.repo.CreateCategory(ctx, "Custom Award", 1, nil, nil, nil, nil)
+	postSeed(t, setup, "", "categories")
+
+	code, response := postSeed(t, setup, "?clear=true", "categories")
+	if code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if response.Created != 6 {
+		t.Errorf("expected 6 categories created after clear, got %d", response.Created)
+	}
+	if len(response.Cleared) != 2 {
+		t.Errorf("expected votes and categories cleared, got %v", response.Cleared)
+	}
+	if exists, _ := setup.repo.CategoryExists(ctx, "Custom Award"); exists {
+		t.Error("expected existing categories to be cleared")
+	}
+}
+
+func TestHandleSeedMockData_ClearReportsVotingClosed(t *testing.T) {
 	setup := newTestSetup(t)
+	ctx := context.Background()
+	setup.repo.SetSetting(ctx, "voting_open", "true")
 
-	// Close database to trigger service error
-	setup.repo.DB().Close()
+	code, response := postSeed(t, setup, "?clear=true", "cars")
+	if code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if !response.VotingClosed {
+		t.Error("expected voting_closed when clearing closed open voting")
+	}
+	if !strings.Contains(response.Message, "voting has been closed") {
+		t.Errorf("expected message to mention voting was closed, got %q", response.Message)
+	}
+	if open, _ := setup.repo.GetSetting(ctx, "voting_open"); open != "false" {
+		t.Errorf("expected voting to be closed, got voting_open=%q", open)
+	}
 
-	payload := map[string]interface{}{
-		"seed_type": "cars",
+	_, response = postSeed(t, setup, "?clear=true", "cars")
+	if response.VotingClosed {
+		t.Error("expected no voting_closed when voting was already closed")
 	}
-	body, _ := json.Marshal(payload)
+}
 
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/seed-mock-data", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
-	rec := httptest.NewRecorder()
+func TestHandleSeedMockData_VotersAndVotes(t *testing.T) {
+	setup := newTestSetup(t)
 
-	req.AddCookie(setup.authCookie)
-	setup.router.ServeHTTP(rec, req)
+	code, response := postSeed(t, setup, "", "votes")
+	if code != http.StatusBadRequest {
+		t.Errorf("expected 400 for votes without categories and cars, got %d", code)
+	}
 
-	// Should return 500 for internal service error
-	if rec.Code != http.StatusInternalServerError {
-		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	postSeed(t, setup, "", "categories")
+	postSeed(t, setup, "", "cars")
+	code, response = postSeed(t, setup, "", "voters")
+	if code != http.StatusOK || response.Created != 12 {
+		t.Fatalf("expected 12 voters created, got %d (status %d)", response.Created, code)
+	}
+
+	code, response = postSeed(t, setup, "", "votes")
+	if code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if response.Created != 12*6 {
+		t.Errorf("expected %d votes created, got %d", 12*6, response.Created)
 	}
 }
 
@@ -2182,7 +4063,7 @@ func TestHandleGetResults_WithVotes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category
-	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 
 	// Create a car
 	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
@@ -2211,6 +4092,278 @@ func TestHandleGetResults_WithVotes(t *testing.T) {
 	}
 }
 
+func TestHandleGetResults_AcceptNegotiation(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "=Car 1", "")
+	voterID, _ := setup.repo.CreateVoter(ctx, "TEST-VOTER")
+	_ = setup.repo.SaveVote(ctx, voterID, int(catID), 1)
+
+	get := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/results", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", "application/json"},
+		{"application/json", "application/json"},
+		{"text/csv", "text/csv"},
+		{"text/*", "text/csv"},
+		{"application/json;q=0.5, text/csv", "text/csv"},
+		{"text/csv;q=0.2, application/json;q=0.9", "application/json"},
+		{"application/pdf", "application/pdf"},
+		{"image/png", "application/json"},
+	}
+	for _, tt := range tests {
+		rec := get(tt.accept)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Accept %q: expected status %d, got %d", tt.accept, http.StatusOK, rec.Code)
+		}
+		if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("Accept %q: expected %s, got %s", tt.accept, tt.want, got)
+		}
+		if rec.Header().Get("Vary") != "Accept" {
+			t.Errorf("Accept %q: expected Vary: Accept", tt.accept)
+		}
+	}
+
+	rec := get("text/csv")
+	rows, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(rows) != 2 || rows[0][0] != "category_id" {
+		t.Fatalf("expected header and one row, got %v", rows)
+	}
+	if rows[1][1] != "Test Category" || rows[1][7] != "'=Car 1" || rows[1][9] != "1" {
+		t.Errorf("unexpected CSV row %v", rows[1])
+	}
+
+	pdfRec := get("application/pdf")
+	if body := pdfRec.Body.String(); !strings.HasPrefix(body, "%PDF-") || !strings.Contains(body, "(Test Category) Tj") {
+		t.Errorf("expected a PDF listing the category, got %q", body)
+	}
+
+	// Each representation has its own entity tag
+	if csvTag, jsonTag := rec.Header().Get("ETag"), get("").Header().Get("ETag"); csvTag == jsonTag {
+		t.Errorf("expected CSV and JSON ETags to differ, both %s", csvTag)
+	}
+	if pdfTag, jsonTag := pdfRec.Header().Get("ETag"), get("").Header().Get("ETag"); pdfTag == jsonTag {
+		t.Errorf("expected PDF and JSON ETags to differ, both %s", pdfTag)
+	}
+}
+
+func TestHandleGetResults_Sort(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "20", "Alice", "Car A", "")
+	_ = setup.repo.CreateCar(ctx, "3", "Zed", "Car Z", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	carIDs := map[string]int{}
+	for _, car := range cars {
+		carIDs[car.CarNumber] = car.ID
+	}
+	// Car 20 wins with two votes to one
+	for i, carNumber := range []string{"20", "20", "3"} {
+		voterID, _ := setup.repo.CreateVoter(ctx, fmt.Sprintf("SORT-%d", i))
+		_ = setup.repo.SaveVote(ctx, voterID, int(catID), carIDs[carNumber])
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		sort  string
+		first string
+	}{
+		{"", "20"},
+		{"votes_desc", "20"},
+		{"car_number", "3"},
+		{"racer_name", "20"},
+	}
+	for _, tt := range tests {
+		var results []services.CategoryResult
+		json.NewDecoder(get("/api/admin/results?sort=" + tt.sort).Body).Decode(&results)
+		if len(results) != 1 || len(results[0].Votes) != 2 || results[0].Votes[0].CarNumber != tt.first {
+			t.Errorf("sort %q: expected car %s first, got %+v", tt.sort, tt.first, results)
+			continue
+		}
+		for _, v := range results[0].Votes {
+			if v.CarNumber == "20" && v.Rank != 1 {
+				t.Errorf("sort %q: expected the winner to keep rank 1, got %d", tt.sort, v.Rank)
+			}
+		}
+	}
+
+	var category services.CategoryResult
+	json.NewDecoder(get(fmt.Sprintf("/api/admin/results/%d?sort=car_number", catID)).Body).Decode(&category)
+	if len(category.Votes) != 2 || category.Votes[0].CarNumber != "3" || category.Votes[0].Rank != 2 {
+		t.Errorf("expected category results sorted by car number, got %+v", category.Votes)
+	}
+
+	for _, path := range []string{"/api/admin/results?sort=bogus", fmt.Sprintf("/api/admin/results/%d?sort=bogus", catID)} {
+		if rec := get(path); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", path, http.StatusBadRequest, rec.Code)
+		}
+	}
+}
+
+func TestHandleGetResults_IncludeTest(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	voterID, _ := setup.repo.CreateVoter(ctx, "PRACTICE-1")
+	_ = setup.repo.SetVoterTest(ctx, voterID, true)
+	_ = setup.repo.SaveVote(ctx, voterID, int(catID), 1)
+
+	get := func(url string) []services.CategoryResult {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d: %s", url, http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response []services.CategoryResult
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	if results := get("/api/admin/results"); results[0].TotalVotes != 0 {
+		t.Errorf("expected test vote left out, got %d votes", results[0].TotalVotes)
+	}
+	if results := get("/api/admin/results?include_test=true"); results[0].TotalVotes != 1 {
+		t.Errorf("expected test vote counted, got %d votes", results[0].TotalVotes)
+	}
+}
+
+func TestHandleGetResults_RefreshBypassesCache(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	voterID, _ := setup.repo.CreateVoter(ctx, "TEST-VOTER")
+	otherVoter, _ := setup.repo.CreateVoter(ctx, "OTHER-VOTER")
+	_ = setup.repo.SaveVote(ctx, voterID, int(catID), 1)
+
+	getTotal := func(query string) float64 {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/results"+query, nil)
+		rec := httptest.NewRecorder()
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
+
+		var response []map[string]interface{}
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response[0]["total_votes"].(float64)
+	}
+
+	if total := getTotal(""); total != 1 {
+		t.Fatalf("expected 1 vote, got %v", total)
+	}
+
+	// A write outside the repository API is not tracked by the cache
+	setup.repo.DB().Exec(`INSERT INTO votes (voter_id, category_id, car_id) VALUES (?, ?, 1)`, otherVoter, catID)
+
+	if total := getTotal(""); total != 1 {
+		t.Errorf("expected cached total of 1, got %v", total)
+	}
+	if total := getTotal("?refresh=true"); total != 2 {
+		t.Errorf("expected refreshed total of 2, got %v", total)
+	}
+}
+
+func TestHandleGetResults_NotModified(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+
+	get := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/results", nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected ETag header")
+	}
+	if first.Header().Get("Last-Modified") == "" {
+		t.Error("expected Last-Modified header")
+	}
+
+	rec := get(etag)
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("expected status %d, got %d", http.StatusNotModified, rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %q", rec.Body.String())
+	}
+
+	// A vote changes the results, so the old tag no longer matches
+	voterID, _ := setup.repo.CreateVoter(ctx, "TEST-VOTER")
+	_ = setup.repo.SaveVote(ctx, voterID, int(catID), 1)
+
+	rec = get(etag)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if rec.Header().Get("ETag") == etag {
+		t.Error("expected ETag to change after a vote")
+	}
+}
+
+func TestHandleGetResults_RefreshIgnoresIfNoneMatch(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/results", nil)
+	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/admin/results?refresh=true", nil)
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	rec = httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
 func TestHandleGetResults_EmptyReturnsArray(t *testing.T) {
 	// This test verifies the bug fix: results API must return a JSON array
 	// even when there are no categories, not null or an object
@@ -2260,6 +4413,108 @@ func TestHandleGetResults_ServiceError(t *testing.T) {
 	}
 }
 
+func TestHandleGetCategoryResults_WithTie(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	otherID, _ := setup.repo.CreateCategory(ctx, "Best Paint", 2, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	setup.repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	cars, _ := setup.repo.ListCars(ctx)
+
+	v1, _ := setup.repo.CreateVoter(ctx, "V1")
+	v2, _ := setup.repo.CreateVoter(ctx, "V2")
+	setup.repo.SaveVote(ctx, v1, int(catID), cars[0].ID)
+	setup.repo.SaveVote(ctx, v2, int(catID), cars[1].ID)
+	setup.repo.SaveVote(ctx, v1, int(otherID), cars[0].ID)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/results/%d", catID), nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response handlers.CategoryResultsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.CategoryName != "Best Design" || response.TotalVotes != 2 || len(response.Votes) != 2 {
+		t.Errorf("expected Best Design with 2 ranked cars, got %+v", response.CategoryResult)
+	}
+	if !response.HasConflicts || len(response.Conflicts.Ties) != 1 {
+		t.Errorf("expected the tie to be reported, got %+v", response.Conflicts)
+	}
+
+	// The other category has a clear winner and no conflicts
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/results/%d", otherID), nil)
+	rec = httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	response = handlers.CategoryResultsResponse{}
+	json.NewDecoder(rec.Body).Decode(&response)
+	if response.HasConflicts || len(response.Conflicts.Ties) != 0 {
+		t.Errorf("expected no conflicts for Best Paint, got %+v", response.Conflicts)
+	}
+}
+
+func TestHandleGetCategoryResults_NoVotesReturnsEmptyList(t *testing.T) {
+	setup := newTestSetup(t)
+
+	catID, _ := setup.repo.CreateCategory(context.Background(), "Best Design", 1, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/results/%d", catID), nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"votes":[]`) {
+		t.Errorf("expected empty votes array, got %s", rec.Body.String())
+	}
+}
+
+func TestHandleGetCategoryResults_NotFound(t *testing.T) {
+	setup := newTestSetup(t)
+
+	inactiveID, _ := setup.repo.CreateCategory(context.Background(), "Retired", 1, nil, nil, nil, nil)
+	setup.repo.DeleteCategory(context.Background(), int(inactiveID))
+
+	for _, path := range []string{"/api/admin/results/9999", fmt.Sprintf("/api/admin/results/%d", inactiveID)} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("%s: expected status %d, got %d", path, http.StatusNotFound, rec.Code)
+		}
+	}
+}
+
+func TestHandleGetCategoryResults_InvalidID(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/results/abc", nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
 // ==================== Edge Cases and Integration Tests ====================
 
 func TestCategoryLifecycle(t *testing.T) {
@@ -2454,43 +4709,106 @@ func TestHandleSyncCategoriesDerbyNet_InvalidJSON(t *testing.T) {
 
 	setup.router.ServeHTTP(rec, req)
 
-	if rec.Code != http.StatusBadRequest {
-		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+// ==================== Push Results DerbyNet Tests ====================
+
+func TestHandlePushResultsDerbyNet_Success(t *testing.T) {
+	setup := newTestSetup(t)
+
+	// First, create a category for testing
+	ctx := context.Background()
+	_, _ = setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
+
+	payload := map[string]interface{}{
+		"derbynet_url": "http://derbynet.local",
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/push-results-derbynet", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
+
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resp["status"] == nil {
+		t.Error("expected status field in response")
+	}
+}
+
+func TestHandlePushResultsDerbyNet_DryRunRecordedInHistory(t *testing.T) {
+	setup := newTestSetup(t)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"derbynet_url": "http://derbynet.local",
+		"dry_run":      true,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/push-results-derbynet", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/admin/derbynet/push-history", nil)
+	req.AddCookie(setup.authCookie)
+	rec = httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var history []models.PushRecord
+	if err := json.NewDecoder(rec.Body).Decode(&history); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(history) != 1 || !history[0].DryRun || history[0].DerbyNetURL != "http://derbynet.local" {
+		t.Errorf("expected one recorded dry run, got %+v", history)
+	}
+	if history[0].PushedAt == "" {
+		t.Error("expected pushed_at to be set")
 	}
 }
 
-// ==================== Push Results DerbyNet Tests ====================
-
-func TestHandlePushResultsDerbyNet_Success(t *testing.T) {
+func TestHandleGetPushReadiness(t *testing.T) {
 	setup := newTestSetup(t)
-
-	// First, create a category for testing
 	ctx := context.Background()
-	_, _ = setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
-
-	payload := map[string]interface{}{
-		"derbynet_url": "http://derbynet.local",
-	}
-	body, _ := json.Marshal(payload)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterID, _ := setup.repo.CreateVoter(ctx, "READY-QR")
+	_ = setup.repo.SaveVote(ctx, voterID, int(catID), cars[0].ID)
 
-	req := httptest.NewRequest(http.MethodPost, "/api/admin/push-results-derbynet", bytes.NewReader(body))
-	req.Header.Set("Content-Type", "application/json")
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/derbynet/push-readiness", nil)
 	req.AddCookie(setup.authCookie)
 	rec := httptest.NewRecorder()
-
 	setup.router.ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusOK {
-		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
 	}
-
-	var resp map[string]interface{}
-	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+	var readiness services.PushReadiness
+	if err := json.NewDecoder(rec.Body).Decode(&readiness); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
-
-	if resp["status"] == nil {
-		t.Error("expected status field in response")
+	if readiness.Ready || len(readiness.UnlinkedCategories) != 1 || len(readiness.UnlinkedWinners) != 1 {
+		t.Errorf("expected the unlinked category and car to be reported, got %+v", readiness)
 	}
 }
 
@@ -2796,7 +5114,7 @@ func TestHandlePushResultsDerbyNet_WithTieConflict(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and cars
-	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	setup.repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
 	cars, _ := setup.repo.ListCars(ctx)
@@ -2829,7 +5147,7 @@ func TestHandlePushResultsDerbyNet_WithTieConflict(t *testing.T) {
 	// Check error message
 	var resp map[string]interface{}
 	json.NewDecoder(rec.Body).Decode(&resp)
-	errorMsg, ok := resp["error"].(string)
+	errorMsg, ok := errorMessage(resp)
 	if !ok || !strings.Contains(errorMsg, "conflicts exist") {
 		t.Errorf("expected error message about conflicts, got: %v", resp)
 	}
@@ -2841,12 +5159,12 @@ func TestHandlePushResultsDerbyNet_WithMultiWinConflict(t *testing.T) {
 
 	// Create a category group with max_wins_per_car = 1
 	maxWins := 1
-	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Main Awards", "Description", nil, &maxWins, 1)
+	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Main Awards", "Description", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create two categories in the same group
-	cat1ID, _ := setup.repo.CreateCategory(ctx, "Fastest", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := setup.repo.CreateCategory(ctx, "Best Design", 2, &groupIDInt, nil, nil)
+	cat1ID, _ := setup.repo.CreateCategory(ctx, "Fastest", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := setup.repo.CreateCategory(ctx, "Best Design", 2, &groupIDInt, nil, nil, nil)
 
 	// Create two cars
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -2893,7 +5211,7 @@ func TestHandlePushResultsDerbyNet_WithMultiWinConflict(t *testing.T) {
 	// Check error message
 	var resp map[string]interface{}
 	json.NewDecoder(rec.Body).Decode(&resp)
-	errorMsg, ok := resp["error"].(string)
+	errorMsg, ok := errorMessage(resp)
 	if !ok || !strings.Contains(errorMsg, "conflicts exist") {
 		t.Errorf("expected error message about conflicts, got: %v", resp)
 	}
@@ -3000,7 +5318,7 @@ func TestHandleDeleteCategoryGroup_DeleteServiceError(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category group first
-	groupID, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Desc", nil, nil, 1)
+	groupID, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Desc", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -3100,7 +5418,7 @@ func TestHandleUpdateCategoryGroup_UpdateServiceError(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category group first
-	groupID, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Desc", nil, nil, 1)
+	groupID, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Desc", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -3291,7 +5609,7 @@ func TestHandleDeleteCategoryGroup_DeleteError(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category group first
-	groupID, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Desc", nil, nil, 1)
+	groupID, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Desc", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -3316,7 +5634,7 @@ func TestHandleUpdateCategoryGroup_UpdateError(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category group first
-	groupID, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Desc", nil, nil, 1)
+	groupID, err := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Desc", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("failed to create test group: %v", err)
 	}
@@ -3422,6 +5740,38 @@ func TestHandleGetOpenVotingQR_BaseURLNotConfigured(t *testing.T) {
 	}
 }
 
+func TestHandleGetCategoryQR(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	setup.repo.SetSetting(ctx, "require_registered_qr", "false")
+	setup.repo.SetSetting(ctx, "base_url", "http://localhost:8080")
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Paint", 1, nil, nil, nil, nil)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get(fmt.Sprintf("/api/admin/categories/%d/qr.png", catID))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+		t.Errorf("expected Content-Type image/png, got %s", ct)
+	}
+	if data := rec.Body.Bytes(); len(data) < 4 || string(data[1:4]) != "PNG" {
+		t.Error("expected valid PNG data")
+	}
+
+	if rec = get("/api/admin/categories/9999/qr.png"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for an unknown category, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
 // ==================== Manual Winner Override Tests ====================
 
 func TestHandleGetConflicts_NoConflicts(t *testing.T) {
@@ -3429,7 +5779,7 @@ func TestHandleGetConflicts_NoConflicts(t *testing.T) {
 	ctx := context.Background()
 
 	// Create categories and cars but no votes
-	setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 
 	req := httptest.NewRequest(http.MethodGet, "/api/admin/results/conflicts", nil)
@@ -3458,12 +5808,45 @@ func TestHandleGetConflicts_NoConflicts(t *testing.T) {
 	}
 }
 
+func TestHandleGetResolutionStatus(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	setup.repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	v1, _ := setup.repo.CreateVoter(ctx, "V1")
+	v2, _ := setup.repo.CreateVoter(ctx, "V2")
+	setup.repo.SaveVote(ctx, v1, int(catID), cars[0].ID)
+	setup.repo.SaveVote(ctx, v2, int(catID), cars[1].ID)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/results/resolution-status", nil)
+	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var status services.ResolutionStatus
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if status.Ready || len(status.Categories) != 1 {
+		t.Fatalf("expected one blocking category, got %+v", status)
+	}
+	if got := status.Categories[0]; got.Status != services.ResolutionTieUnresolved || !got.Blocking {
+		t.Errorf("expected an unresolved tie, got %+v", got)
+	}
+}
+
 func TestHandleGetConflicts_WithTie(t *testing.T) {
 	setup := newTestSetup(t)
 	ctx := context.Background()
 
 	// Create category and cars
-	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	setup.repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
 	cars, _ := setup.repo.ListCars(ctx)
@@ -3504,18 +5887,59 @@ func TestHandleGetConflicts_WithTie(t *testing.T) {
 	}
 }
 
+func TestHandleGetConflicts_WithNearTie(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	setup.repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	cars, _ := setup.repo.ListCars(ctx)
+
+	// Car A leads Car B by one vote
+	for i, carIdx := range []int{0, 0, 1} {
+		voterID, _ := setup.repo.CreateVoter(ctx, fmt.Sprintf("V%d", i))
+		setup.repo.SaveVote(ctx, voterID, int(catID), cars[carIdx].ID)
+	}
+	setup.repo.SetSetting(ctx, "tie_margin", "1")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/results/conflicts", nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response handlers.ConflictsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Ties) != 0 {
+		t.Errorf("expected no exact ties, got %d", len(response.Ties))
+	}
+	if len(response.NearTies) != 1 {
+		t.Fatalf("expected 1 near tie, got %d", len(response.NearTies))
+	}
+	if response.NearTies[0].Margin != 1 || len(response.NearTies[0].TiedCars) != 2 {
+		t.Errorf("expected margin 1 with 2 cars, got %+v", response.NearTies[0])
+	}
+}
+
 func TestHandleGetConflicts_WithMultipleWins(t *testing.T) {
 	setup := newTestSetup(t)
 	ctx := context.Background()
 
 	// Create a category group with max_wins_per_car = 1
 	maxWins := 1
-	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Design Awards", "Design related categories", nil, &maxWins, 1)
+	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Design Awards", "Design related categories", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create categories in the group
-	cat1ID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := setup.repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil)
+	cat1ID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := setup.repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil, nil)
 
 	// Create cars
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Super Car", "")
@@ -3563,7 +5987,7 @@ func TestHandleOverrideWinner_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := setup.repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -3608,6 +6032,83 @@ func TestHandleOverrideWinner_Success(t *testing.T) {
 	}
 }
 
+func TestHandleRandomTieBreak(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	setup.repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	v1, _ := setup.repo.CreateVoter(ctx, "V1")
+	v2, _ := setup.repo.CreateVoter(ctx, "V2")
+	setup.repo.SaveVote(ctx, v1, int(catID), cars[0].ID)
+	setup.repo.SaveVote(ctx, v2, int(catID), cars[1].ID)
+
+	send := func() *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{"category_id": catID})
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/results/random-tiebreak", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Refused while voting is open
+	setup.repo.SetSetting(ctx, "voting_open", "true")
+	if rec := send(); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d while voting is open, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	setup.repo.SetSetting(ctx, "voting_open", "false")
+	rec := send()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var result services.RandomTieBreakResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if result.Winner.CarID != cars[0].ID && result.Winner.CarID != cars[1].ID {
+		t.Errorf("expected a tied car to win, got %d", result.Winner.CarID)
+	}
+	if !strings.HasPrefix(result.Reason, "random draw (seed ") {
+		t.Errorf("unexpected reason %q", result.Reason)
+	}
+
+	// The tie is now resolved by the override
+	if rec := send(); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d with no tie left, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestHandleOverrideWinner_CarNotFound(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.SetSetting(ctx, "voting_open", "false")
+
+	payload := map[string]interface{}{
+		"category_id": catID,
+		"car_id":      9999,
+		"reason":      "Resolved tie",
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/results/override-winner", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
+
 func TestHandleOverrideWinner_MissingCategoryID(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -3676,7 +6177,7 @@ func TestHandleClearOverride_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := setup.repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -3727,7 +6228,7 @@ func TestHandleGetOverrides_NoOverrides(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category without override
-	setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/admin/results/overrides", nil)
 	rec := httptest.NewRecorder()
@@ -3754,7 +6255,7 @@ func TestHandleGetOverrides_WithOverrides(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := setup.repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -3794,12 +6295,98 @@ func TestHandleGetOverrides_WithOverrides(t *testing.T) {
 	}
 }
 
+func TestHandleGetEventReport(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	designID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	speedID, _ := setup.repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	setup.repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	v1, _ := setup.repo.CreateVoter(ctx, "V1")
+	v2, _ := setup.repo.CreateVoter(ctx, "V2")
+	setup.repo.CreateVoter(ctx, "V3")
+	setup.repo.SaveVote(ctx, v1, int(designID), cars[0].ID)
+	setup.repo.SaveVote(ctx, v2, int(designID), cars[0].ID)
+	setup.repo.SaveVote(ctx, v1, int(speedID), cars[0].ID)
+	setup.repo.SaveVote(ctx, v2, int(speedID), cars[1].ID)
+	// A test voter's vote is left out of the report's stats and winners
+	practice, _ := setup.repo.CreateVoter(ctx, "PRACTICE")
+	setup.repo.SetVoterTest(ctx, practice, true)
+	setup.repo.SaveVote(ctx, practice, int(designID), cars[1].ID)
+	setup.repo.SetManualWinner(ctx, int(speedID), cars[1].ID, "Judges' choice")
+	setup.repo.SetSetting(ctx, "event_name", "Pack 42 Derby")
+
+	get := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/report", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Vary") != "Accept" {
+		t.Error("expected Vary: Accept")
+	}
+	var report handlers.EventReportResponse
+	if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if report.EventName != "Pack 42 Derby" || report.GeneratedAt == "" {
+		t.Errorf("unexpected report header %q, %q", report.EventName, report.GeneratedAt)
+	}
+	if report.Stats["total_voters"] != float64(3) || report.Stats["voters_who_voted"] != float64(2) {
+		t.Errorf("unexpected stats %v", report.Stats)
+	}
+	if len(report.Turnout) == 0 {
+		t.Error("expected voter group turnout")
+	}
+	if len(report.Categories) != 2 {
+		t.Fatalf("expected 2 categories, got %+v", report.Categories)
+	}
+	design, speed := report.Categories[0], report.Categories[1]
+	if design.TotalVotes != 2 || design.Winner["car_number"] != "101" || design.Winner["is_override"] != false {
+		t.Errorf("unexpected Best Design entry %+v", design)
+	}
+	if speed.TotalVotes != 2 || speed.Winner["car_number"] != "102" || speed.Winner["is_override"] != true {
+		t.Errorf("expected the override to be the Fastest Looking winner, got %+v", speed)
+	}
+	if len(report.Overrides) != 1 || report.Overrides[0].OverrideReason != "Judges' choice" {
+		t.Errorf("expected one override with its reason, got %+v", report.Overrides)
+	}
+
+	rec = get("application/pdf")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/pdf" {
+		t.Errorf("expected application/pdf, got %s", got)
+	}
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "%PDF-") {
+		t.Fatalf("expected a PDF, got %q", body[:min(len(body), 20)])
+	}
+	for _, want := range []string{"Pack 42 Derby Event Summary", "Voters who voted: 2 \\(67%\\)", "Best Design: 2 votes - winner #101 Racer One \\(Car A\\)", "[override]", "Reason: Judges' choice"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected PDF to contain %q", want)
+		}
+	}
+}
+
 func TestPushResultsToDerbyNet_WithOverride(t *testing.T) {
 	setup := newTestSetup(t)
 	ctx := context.Background()
 
 	// Create category with DerbyNet award ID
-	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	awardID := 100
 	setup.repo.UpsertCategory(ctx, "Best Design", 1, &awardID)
 
@@ -3858,7 +6445,7 @@ func TestPushResultsToDerbyNet_OverrideWithNoVotes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category
-	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	awardID := 100
 	setup.repo.UpsertCategory(ctx, "Best Design", 1, &awardID)
 
@@ -3945,7 +6532,7 @@ func TestHandleGetCategoryGroup_ReturnsMaxWinsPerCar(t *testing.T) {
 	ctx := context.Background()
 
 	maxWins := 2
-	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, &maxWins, 1)
+	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, &maxWins, "", 1)
 
 	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/category-groups/%d", groupID), nil)
 	rec := httptest.NewRecorder()
@@ -3972,7 +6559,7 @@ func TestHandleUpdateCategoryGroup_UpdatesMaxWinsPerCar(t *testing.T) {
 	ctx := context.Background()
 
 	// Create group without max_wins_per_car
-	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, 1)
+	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, "", 1)
 
 	// Update to set max_wins_per_car
 	newMaxWins := 1
@@ -4010,7 +6597,7 @@ func TestHandleUpdateCategoryGroup_ClearsMaxWinsPerCar(t *testing.T) {
 
 	// Create group with max_wins_per_car
 	maxWins := 1
-	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, &maxWins, 1)
+	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, &maxWins, "", 1)
 
 	// Update to clear max_wins_per_car (send null)
 	payload := map[string]interface{}{
@@ -4045,8 +6632,8 @@ func TestHandleGetCategoryGroups_ReturnsMaxWinsPerCar(t *testing.T) {
 
 	// Create groups with and without max_wins_per_car
 	maxWins := 2
-	setup.repo.CreateCategoryGroup(ctx, "Group With Max", "Has limit", nil, &maxWins, 1)
-	setup.repo.CreateCategoryGroup(ctx, "Group Without Max", "No limit", nil, nil, 2)
+	setup.repo.CreateCategoryGroup(ctx, "Group With Max", "Has limit", nil, &maxWins, "", 1)
+	setup.repo.CreateCategoryGroup(ctx, "Group Without Max", "No limit", nil, nil, "", 2)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/admin/category-groups", nil)
 	rec := httptest.NewRecorder()
@@ -4096,13 +6683,13 @@ func TestHandleGetConflicts_WithTiesAndMultiWins(t *testing.T) {
 
 	// Create a group with max_wins_per_car = 1
 	maxWins := 1
-	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Design Awards", "Design categories", nil, &maxWins, 1)
+	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Design Awards", "Design categories", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create categories in the group
-	cat1ID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := setup.repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil)
-	cat3ID, _ := setup.repo.CreateCategory(ctx, "Best Paint", 3, nil, nil, nil) // Not in group
+	cat1ID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := setup.repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil, nil)
+	cat3ID, _ := setup.repo.CreateCategory(ctx, "Best Paint", 3, nil, nil, nil, nil) // Not in group
 
 	// Create cars
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -4208,7 +6795,7 @@ func TestHandleGetConflicts_DetectMultipleWinsError(t *testing.T) {
 	}
 
 	// Create a category so DetectTies succeeds
-	realRepo.CreateCategory(context.Background(), "Test", 1, nil, nil, nil)
+	realRepo.CreateCategory(context.Background(), "Test", 1, nil, nil, nil, nil)
 
 	// Create mock repo with error for ListCategoryGroups
 	mockRepo := mock.NewRepository(realRepo)
@@ -4380,7 +6967,7 @@ func TestHandleOverrideWinner_VotingStillOpen(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := setup.repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -4411,7 +6998,7 @@ func TestHandleOverrideWinner_VotingStillOpen(t *testing.T) {
 	// Verify error message
 	var response map[string]interface{}
 	json.Unmarshal(rec.Body.Bytes(), &response)
-	if errorMsg, ok := response["error"].(string); ok {
+	if errorMsg, ok := errorMessage(response); ok {
 		if errorMsg != "Cannot resolve conflicts while voting is still open" {
 			t.Errorf("expected error about voting being open, got: %s", errorMsg)
 		}
@@ -4436,7 +7023,7 @@ func TestHandleClearOverride_VotingStillOpen(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := setup.repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -4471,7 +7058,7 @@ func TestHandleClearOverride_VotingStillOpen(t *testing.T) {
 	// Verify error message
 	var response map[string]interface{}
 	json.Unmarshal(rec.Body.Bytes(), &response)
-	if errorMsg, ok := response["error"].(string); ok {
+	if errorMsg, ok := errorMessage(response); ok {
 		if errorMsg != "Cannot clear conflict resolution while voting is still open" {
 			t.Errorf("expected error about voting being open, got: %s", errorMsg)
 		}
@@ -4616,6 +7203,55 @@ func TestHandleClearOverride_IsVotingOpenError(t *testing.T) {
 
 // ==================== Test DerbyNet Tests ====================
 
+func TestHandleGetDerbyNetStatus(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/derbynet/status"+query, nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.handlers.Router().ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := get(""); rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d without a health service, got %d", http.StatusInternalServerError, rec.Code)
+	}
+
+	settings := services.NewSettingsService(logger.New(), setup.repo)
+	health := services.NewDerbyNetHealthService(logger.New(), settings, derbynet.NewMockClient())
+	setup.handlers.SetDerbyNetHealth(health)
+	_ = setup.repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+	_ = setup.repo.SetSetting(ctx, "derbynet_health_polling", "true")
+
+	// Without refresh, nothing has been checked yet
+	var response map[string]interface{}
+	json.NewDecoder(get("").Body).Decode(&response)
+	if response["checked_at"] != nil {
+		t.Errorf("expected no check before refresh, got %v", response)
+	}
+
+	rec := get("?refresh=true")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	response = nil
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["enabled"] != true || response["connected"] != true || response["checked_at"] == nil {
+		t.Errorf("unexpected status: %v", response)
+	}
+
+	// The refreshed result is cached
+	response = nil
+	json.NewDecoder(get("").Body).Decode(&response)
+	if response["checked_at"] == nil {
+		t.Errorf("expected the refreshed check to be cached, got %v", response)
+	}
+}
+
 func TestHandleTestDerbyNet_Success(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -4764,7 +7400,7 @@ func TestHandleTestDerbyNet_MissingURL(t *testing.T) {
 	var result map[string]interface{}
 	json.NewDecoder(rec.Body).Decode(&result)
 
-	errorMsg, ok := result["error"].(string)
+	errorMsg, ok := errorMessage(result)
 	if !ok || !strings.Contains(strings.ToLower(errorMsg), "derbynet_url") {
 		t.Errorf("expected error about derbynet_url, got %v", result["error"])
 	}
@@ -4793,7 +7429,7 @@ func TestHandleTestDerbyNet_ConnectionFailure(t *testing.T) {
 	var result map[string]interface{}
 	json.NewDecoder(rec.Body).Decode(&result)
 
-	errorMsg, ok := result["error"].(string)
+	errorMsg, ok := errorMessage(result)
 	if !ok || !strings.Contains(strings.ToLower(errorMsg), "failed to connect") {
 		t.Errorf("expected error about connection failure, got %v", result["error"])
 	}
@@ -4826,27 +7462,121 @@ func TestHandleTestDerbyNet_AwardsFetchFailure(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodPost, "/api/admin/test-derbynet", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
-	rec := httptest.NewRecorder()
-
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var result map[string]interface{}
+	json.NewDecoder(rec.Body).Decode(&result)
+
+	if result["status"] != "success" {
+		t.Errorf("expected status 'success', got %v", result["status"])
+	}
+	if result["total_racers"].(float64) != 1 {
+		t.Errorf("expected 1 racer, got %v", result["total_racers"])
+	}
+	// Awards should be 0 since fetch failed
+	if result["total_awards"].(float64) != 0 {
+		t.Errorf("expected 0 awards (fetch failed), got %v", result["total_awards"])
+	}
+
+	// The failing call is reported with its HTTP status
+	awardList := result["award_list"].(map[string]interface{})
+	if awardList["http_status"].(float64) != http.StatusInternalServerError || awardList["error"] == nil {
+		t.Errorf("expected award.list to report status 500 and an error, got %v", awardList)
+	}
+	racerList := result["racer_list"].(map[string]interface{})
+	if racerList["http_status"].(float64) != http.StatusOK || racerList["error"] != nil {
+		t.Errorf("expected racer.list to succeed, got %v", racerList)
+	}
+}
+
+func TestHandleTestDerbyNet_ReportsTimingAndSession(t *testing.T) {
+	setup := newTestSetup(t)
+
+	derbynetServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "PHPSESSID", Value: "abc123", Path: "/"})
+		w.Header().Set("Server", "Apache/2.4.57")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("query") == "racer.list" {
+			time.Sleep(5 * time.Millisecond)
+			w.Write([]byte(`{"racers":[]}`))
+			return
+		}
+		w.Write([]byte(`{"awards":[],"award-types":[]}`))
+	}))
+	defer derbynetServer.Close()
+
+	body, _ := json.Marshal(map[string]string{"derbynet_url": derbynetServer.URL})
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/test-derbynet", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var result handlers.DerbyNetTestResponse
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if result.RacerList.Query != "racer.list" || result.RacerList.LatencyMS < 5 {
+		t.Errorf("expected racer.list latency of at least 5ms, got %+v", result.RacerList)
+	}
+	if result.AwardList.Query != "award.list" || result.AwardList.HTTPStatus != http.StatusOK {
+		t.Errorf("expected award.list to succeed, got %+v", result.AwardList)
+	}
+	if !result.SessionCookie {
+		t.Error("expected session cookie to be reported")
+	}
+	if result.Server != "Apache/2.4.57" {
+		t.Errorf("expected server header, got %q", result.Server)
+	}
+}
+
+func TestHandleTestDerbyNet_FallbackURL(t *testing.T) {
+	setup := newTestSetup(t)
+
+	derbynetServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("query") == "racer.list" {
+			w.Write([]byte(`{"racers":[{"racerid":1,"firstname":"Racer","lastname":"One","carnumber":1}]}`))
+			return
+		}
+		w.Write([]byte(`{"awards":[],"award-types":[]}`))
+	}))
+	defer derbynetServer.Close()
+	gone := httptest.NewServer(http.NotFoundHandler())
+	gone.Close()
+
+	setup.repo.SetSetting(context.Background(), "derbynet_url_fallback", derbynetServer.URL)
+
+	body, _ := json.Marshal(map[string]string{"derbynet_url": gone.URL})
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/test-derbynet", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
 	setup.router.ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusOK {
-		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
 	}
-
-	var result map[string]interface{}
-	json.NewDecoder(rec.Body).Decode(&result)
-
-	if result["status"] != "success" {
-		t.Errorf("expected status 'success', got %v", result["status"])
+	var result handlers.DerbyNetTestResponse
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
 	}
-	if result["total_racers"].(float64) != 1 {
-		t.Errorf("expected 1 racer, got %v", result["total_racers"])
+	if result.ActiveURL != derbynetServer.URL {
+		t.Errorf("expected active_url %q, got %q", derbynetServer.URL, result.ActiveURL)
 	}
-	// Awards should be 0 since fetch failed
-	if result["total_awards"].(float64) != 0 {
-		t.Errorf("expected 0 awards (fetch failed), got %v", result["total_awards"])
+	if result.TotalRacers != 1 {
+		t.Errorf("expected 1 racer from fallback, got %d", result.TotalRacers)
 	}
 }
 
@@ -4916,6 +7646,9 @@ func TestHandleTestDerbyNet_AuthenticationFailure(t *testing.T) {
 	if result["authenticated"] != false {
 		t.Errorf("expected authenticated to be false (auth failed), got %v", result["authenticated"])
 	}
+	if result["auth_error"] != "Wrong DerbyNet password" {
+		t.Errorf("expected auth_error 'Wrong DerbyNet password', got %v", result["auth_error"])
+	}
 }
 
 func TestHandleDeleteCar_WithVotes(t *testing.T) {
@@ -4923,7 +7656,7 @@ func TestHandleDeleteCar_WithVotes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category
-	catID, err := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -4973,7 +7706,7 @@ func TestHandleDeleteCar_WithVotesAndForce(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category
-	catID, err := setup.repo.CreateCategory(ctx, "Test Category 2", 1, nil, nil, nil)
+	catID, err := setup.repo.CreateCategory(ctx, "Test Category 2", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -5014,7 +7747,7 @@ func TestHandleSetCarEligibility_MarkIneligibleWithVotes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category
-	catID, err := setup.repo.CreateCategory(ctx, "Test Category 3", 1, nil, nil, nil)
+	catID, err := setup.repo.CreateCategory(ctx, "Test Category 3", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -5066,7 +7799,7 @@ func TestHandleSetCarEligibility_MarkIneligibleWithVotesAndForce(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category
-	catID, err := setup.repo.CreateCategory(ctx, "Test Category 4", 1, nil, nil, nil)
+	catID, err := setup.repo.CreateCategory(ctx, "Test Category 4", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create test category: %v", err)
 	}
@@ -5108,6 +7841,63 @@ func TestHandleSetCarEligibility_MarkIneligibleWithVotesAndForce(t *testing.T) {
 	}
 }
 
+func TestHandleBulkSetCarEligibility(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = setup.repo.UpsertCar(ctx, 1, "101", "Tiger 1", "", "", "Tiger")
+	_ = setup.repo.UpsertCar(ctx, 2, "102", "Tiger 2", "", "", "Tiger")
+	_ = setup.repo.UpsertCar(ctx, 3, "201", "Wolf 1", "", "", "Wolf")
+	cars, _ := setup.repo.ListCars(ctx)
+	voter1, _ := setup.repo.CreateVoter(ctx, "bulk-eligibility-1")
+	voter2, _ := setup.repo.CreateVoter(ctx, "bulk-eligibility-2")
+	_ = setup.repo.SaveVote(ctx, voter1, int(catID), cars[0].ID)
+	_ = setup.repo.SaveVote(ctx, voter2, int(catID), cars[0].ID)
+
+	post := func(payload map[string]interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/cars/eligibility", bytes.NewReader(body))
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post(map[string]interface{}{"rank": "Tiger"}); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without eligible, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := post(map[string]interface{}{"eligible": false}); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a filter, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec := post(map[string]interface{}{"rank": "Tiger", "eligible": false})
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+	var confirm map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &confirm)
+	if confirm["confirmation_required"] != true || confirm["vote_count"] != float64(2) {
+		t.Errorf("expected confirmation for 2 votes, got %v", confirm)
+	}
+
+	rec = post(map[string]interface{}{"rank": "Tiger", "eligible": false, "force": true})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp handlers.CarBulkEligibilityResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Eligible || resp.Matched != 2 || resp.Updated != 2 {
+		t.Errorf("expected 2 Tiger cars marked ineligible, got %+v", resp)
+	}
+
+	rec = post(map[string]interface{}{"car_ids": []int{cars[0].ID, cars[2].ID}, "eligible": true})
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if rec.Code != http.StatusOK || resp.Matched != 2 || resp.Updated != 1 {
+		t.Errorf("expected 1 of 2 listed cars to change, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
 func TestHandleDeleteCar_CountVotesError(t *testing.T) {
 	setup, mockRepo := newTestSetupWithMockRepo(t)
 	ctx := context.Background()
@@ -5139,12 +7929,34 @@ func TestHandleDeleteCar_CountVotesError(t *testing.T) {
 	}
 }
 
+func TestHandleDeleteVoter_CountVotesError(t *testing.T) {
+	setup, mockRepo := newTestSetupWithMockRepo(t)
+	ctx := context.Background()
+
+	voterID, err := setup.repo.CreateVoter(ctx, "COUNT-ERR-QR")
+	if err != nil {
+		t.Fatalf("failed to create voter: %v", err)
+	}
+
+	mockRepo.CountVoterVotesError = fmt.Errorf("database error")
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/admin/voters/%d", voterID), nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
 func TestHandleDeleteCategory_CountVotesError(t *testing.T) {
 	setup, mockRepo := newTestSetupWithMockRepo(t)
 	ctx := context.Background()
 
 	// Create a category
-	catID, err := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create category: %v", err)
 	}
@@ -5196,6 +8008,130 @@ func TestHandleSetCarEligibility_CountVotesError(t *testing.T) {
 	}
 }
 
+func TestRequestID(t *testing.T) {
+	setup := newTestSetup(t)
+	router := setup.handlers.Router()
+
+	get := func(incoming string) string {
+		req := httptest.NewRequest(http.MethodGet, "/api/branding", nil)
+		if incoming != "" {
+			req.Header.Set("X-Request-Id", incoming)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Header().Get("X-Request-Id")
+	}
+
+	generated := get("")
+	if len(generated) != 16 {
+		t.Errorf("expected a generated 16-character request ID, got %q", generated)
+	}
+	if other := get(""); other == generated {
+		t.Errorf("expected a new request ID per request, got %q twice", other)
+	}
+	if got := get("client-trace.42"); got != "client-trace.42" {
+		t.Errorf("expected incoming request ID to be echoed, got %q", got)
+	}
+	if got := get("bad id\nwith newline"); got == "" || strings.ContainsAny(got, " \n") {
+		t.Errorf("expected malformed request ID to be replaced, got %q", got)
+	}
+}
+
+func TestMaxBodySize(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.handlers.SetMaxBodySize(1024)
+	router := setup.handlers.Router()
+
+	post := func(body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/categories", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	oversized := []byte(`{"name": "` + strings.Repeat("x", 2048) + `", "display_order": 1}`)
+
+	rec := post(oversized)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusRequestEntityTooLarge, rec.Code, rec.Body.String())
+	}
+	var response map[string]interface{}
+	json.NewDecoder(rec.Body).Decode(&response)
+	errObj, _ := response["error"].(map[string]interface{})
+	if errObj["code"] != "PAYLOAD_TOO_LARGE" || errObj["message"] != "Request body must be 1KB or smaller" {
+		t.Errorf("unexpected error: %v", response)
+	}
+
+	if rec := post([]byte(`{"name": "Small", "display_order": 1}`)); rec.Code != http.StatusCreated {
+		t.Errorf("expected small body to be accepted, got %d", rec.Code)
+	}
+
+	// Zero disables the limit
+	setup.handlers.SetMaxBodySize(0)
+	if rec := post(oversized); rec.Code != http.StatusCreated {
+		t.Errorf("expected status %d with no limit, got %d", http.StatusCreated, rec.Code)
+	}
+}
+
+func TestMaxBodySize_UploadUsesOwnLimit(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.handlers.SetUploadDir(t.TempDir())
+	setup.handlers.SetMaxBodySize(1024)
+
+	png := append([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), make([]byte, 4096)...)
+	req := newLogoUploadRequest(t, png)
+	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
+	setup.handlers.Router().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected logo larger than the global limit to upload, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestReadOnlyMode_RejectsWrites(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.handlers.SetReadOnly(true)
+	router := setup.handlers.Router()
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/api/admin/categories", strings.NewReader(`{"name": "Blocked", "display_order": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s: expected status %d, got %d", method, http.StatusMethodNotAllowed, rec.Code)
+		}
+		if allow := rec.Header().Get("Allow"); allow != "GET, HEAD, OPTIONS" {
+			t.Errorf("%s: expected Allow header, got %q", method, allow)
+		}
+	}
+	if categories, _ := setup.repo.ListCategories(context.Background()); len(categories) != 0 {
+		t.Errorf("expected no category to be created, got %d", len(categories))
+	}
+
+	// Reads still work
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/results", nil)
+	req.AddCookie(setup.authCookie)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected results to load in read-only mode, got %d", rec.Code)
+	}
+
+	// Logging in only touches in-memory sessions, so it stays allowed
+	req = httptest.NewRequest(http.MethodPost, "/admin/login", strings.NewReader("password=test-password"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusFound {
+		t.Errorf("expected login to succeed in read-only mode, got %d", rec.Code)
+	}
+}
+
 func TestConditionalHTTPLogger_WithLoggingEnabled(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -5256,12 +8192,76 @@ func TestConditionalHTTPLogger_WithNilLogger(t *testing.T) {
 	}
 }
 
+// recordingHTTPLogger captures HTTP request log lines
+type recordingHTTPLogger struct {
+	debug []map[string]any
+	info  []map[string]any
+}
+
+func (l *recordingHTTPLogger) IsHTTPLoggingEnabled() bool { return true }
+
+func (l *recordingHTTPLogger) DebugContext(ctx context.Context, msg string, args ...any) {
+	l.debug = append(l.debug, logArgs(args))
+}
+
+func (l *recordingHTTPLogger) InfoContext(ctx context.Context, msg string, args ...any) {
+	l.info = append(l.info, logArgs(args))
+}
+
+func logArgs(args []any) map[string]any {
+	fields := make(map[string]any)
+	for i := 0; i+1 < len(args); i += 2 {
+		fields[args[i].(string)] = args[i+1]
+	}
+	return fields
+}
+
+func TestConditionalHTTPLogger_LogsStatusSizeAndDuration(t *testing.T) {
+	setup := newTestSetupWithTemplatesForVote(t)
+	log := &recordingHTTPLogger{}
+	setup.handlers.Log = log
+	router := setup.handlers.Router()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/categories", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if len(log.info) != 1 {
+		t.Fatalf("expected 1 info log line, got %d", len(log.info))
+	}
+	line := log.info[0]
+	if line["method"] != http.MethodGet || line["path"] != "/api/categories" {
+		t.Errorf("unexpected method/path: %v", line)
+	}
+	if line["status"] != rec.Code {
+		t.Errorf("expected status %d, got %v", rec.Code, line["status"])
+	}
+	if line["bytes"] != rec.Body.Len() {
+		t.Errorf("expected bytes %d, got %v", rec.Body.Len(), line["bytes"])
+	}
+	if _, ok := line["duration"].(time.Duration); !ok {
+		t.Errorf("expected a duration, got %v", line["duration"])
+	}
+
+	// Static assets are logged at debug level
+	req = httptest.NewRequest(http.MethodGet, "/static/missing.css", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if len(log.info) != 1 || len(log.debug) != 1 {
+		t.Fatalf("expected static request at debug level, got %d info and %d debug", len(log.info), len(log.debug))
+	}
+	if log.debug[0]["status"] != http.StatusNotFound {
+		t.Errorf("expected status 404, got %v", log.debug[0]["status"])
+	}
+}
+
 func TestHandleDeleteCategory_WithForceDeleteError(t *testing.T) {
 	setup, mockRepo := newTestSetupWithMockRepo(t)
 	ctx := context.Background()
 
 	// Create a category
-	catID, err := setup.repo.CreateCategory(ctx, "Category To Delete With Error", 1, nil, nil, nil)
+	catID, err := setup.repo.CreateCategory(ctx, "Category To Delete With Error", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create category: %v", err)
 	}
@@ -5298,3 +8298,271 @@ func TestHandleDeleteCategory_WithForceDeleteError(t *testing.T) {
 		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
 	}
 }
+
+func TestHandleFinalizeVoting(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = setup.repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	v1, _ := setup.repo.CreateVoter(ctx, "V1")
+	v2, _ := setup.repo.CreateVoter(ctx, "V2")
+	_ = setup.repo.SaveVote(ctx, v1, int(catID), 1)
+	_ = setup.repo.SaveVote(ctx, v2, int(catID), 2)
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		rec := httptest.NewRecorder()
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodGet, "/api/admin/results/snapshot"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d before freezing, got %d", http.StatusNotFound, rec.Code)
+	}
+
+	// A tie blocks the freeze
+	rec := do(http.MethodPost, "/api/admin/voting/finalize")
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+	var conflict handlers.ConflictsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&conflict); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(conflict.Ties) != 1 {
+		t.Errorf("expected 1 tie, got %d", len(conflict.Ties))
+	}
+
+	// Breaking the tie lets finalize freeze the results
+	if err := setup.repo.SetManualWinner(ctx, int(catID), 1, "judges"); err != nil {
+		t.Fatalf("SetManualWinner failed: %v", err)
+	}
+	rec = do(http.MethodPost, "/api/admin/voting/finalize")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	rec = do(http.MethodGet, "/api/admin/results/snapshot")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var snapshot services.ResultsSnapshot
+	if err := json.NewDecoder(rec.Body).Decode(&snapshot); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if snapshot.FrozenAt == "" || len(snapshot.Winners) != 1 {
+		t.Errorf("expected a frozen snapshot with 1 winner, got %+v", snapshot)
+	}
+}
+
+func TestHandleResultsEmbargo(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = setup.repo.SetCategoryShowLiveCounts(ctx, int(catID), true)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	v1, _ := setup.repo.CreateVoter(ctx, "V1")
+	_ = setup.repo.SaveVote(ctx, v1, int(catID), 1)
+
+	do := func(method, path, revealCode string, payload interface{}) *httptest.ResponseRecorder {
+		var body []byte
+		if payload != nil {
+			body, _ = json.Marshal(payload)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if revealCode != "" {
+			req.Header.Set(handlers.RevealHeader, revealCode)
+		}
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// A bad reveal code is refused before voting is closed
+	rec := do(http.MethodPost, "/api/admin/voting/finalize", "", map[string]interface{}{"embargo": true, "reveal_code": "ab"})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+	if open, _ := setup.handlers.Settings.IsVotingOpen(ctx); !open {
+		t.Error("expected voting to stay open after a rejected finalize")
+	}
+
+	rec = do(http.MethodPost, "/api/admin/voting/finalize", "", map[string]interface{}{"embargo": true, "reveal_code": "curtain"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var finalized handlers.FinalizeVotingResponse
+	if err := json.NewDecoder(rec.Body).Decode(&finalized); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !finalized.Embargoed || finalized.Snapshot != nil {
+		t.Errorf("expected an embargoed finalize without the snapshot, got %+v", finalized)
+	}
+
+	for _, path := range []string{"/api/admin/results", "/api/admin/results/snapshot", "/api/admin/stats/top-cars", "/api/admin/derbynet/push-readiness",
+		"/api/admin/results/conflicts", "/api/admin/results/resolution-status", "/api/admin/results/overrides"} {
+		rec = do(http.MethodGet, path, "", nil)
+		if rec.Code != http.StatusLocked || !strings.Contains(rec.Body.String(), "RESULTS_EMBARGOED") {
+			t.Errorf("%s: expected status %d, got %d: %s", path, http.StatusLocked, rec.Code, rec.Body.String())
+		}
+		if rec = do(http.MethodGet, path, "wrong", nil); rec.Code != http.StatusLocked {
+			t.Errorf("%s: expected status %d with a wrong code, got %d", path, http.StatusLocked, rec.Code)
+		}
+		if rec = do(http.MethodGet, path, "curtain", nil); rec.Code != http.StatusOK {
+			t.Errorf("%s: expected status %d with the reveal code, got %d: %s", path, http.StatusOK, rec.Code, rec.Body.String())
+		}
+	}
+
+	// Live counts on the voter category listing would give the results away
+	rec = do(http.MethodGet, "/api/categories", "", nil)
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "vote_count") {
+		t.Errorf("expected categories without live counts while embargoed, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// Lifting the embargo takes the reveal code, in the header or the body
+	if rec = do(http.MethodPost, "/api/admin/results/reveal", "", nil); rec.Code != http.StatusLocked {
+		t.Errorf("expected status %d revealing without the code, got %d: %s", http.StatusLocked, rec.Code, rec.Body.String())
+	}
+	if rec = do(http.MethodPost, "/api/admin/results/reveal", "", map[string]string{"reveal_code": "wrong"}); rec.Code != http.StatusLocked {
+		t.Errorf("expected status %d revealing with a wrong code, got %d: %s", http.StatusLocked, rec.Code, rec.Body.String())
+	}
+	if rec = do(http.MethodPost, "/api/admin/results/reveal", "", map[string]string{"reveal_code": "curtain"}); rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if rec = do(http.MethodGet, "/api/admin/results", "", nil); rec.Code != http.StatusOK {
+		t.Errorf("expected results once revealed, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec = do(http.MethodGet, "/api/categories", "", nil); !strings.Contains(rec.Body.String(), `"vote_count":1`) {
+		t.Errorf("expected live counts once revealed, got %s", rec.Body.String())
+	}
+}
+
+func TestHandleVerifyResults(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = setup.repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	v1, _ := setup.repo.CreateVoter(ctx, "V1")
+	v2, _ := setup.repo.CreateVoter(ctx, "V2")
+	_ = setup.repo.SaveVote(ctx, v1, int(catID), 1)
+	_ = setup.repo.SaveVote(ctx, v2, int(catID), 2)
+
+	verify := func() services.TallyVerification {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/results/verify", nil)
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var verification services.TallyVerification
+		if err := json.NewDecoder(rec.Body).Decode(&verification); err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		return verification
+	}
+
+	if verification := verify(); !verification.Consistent || verification.VotesCounted != 2 {
+		t.Errorf("expected 2 consistent votes, got %+v", verification)
+	}
+
+	// Change a vote without invalidating the cached results
+	if _, err := setup.repo.DB().ExecContext(ctx, `UPDATE votes SET car_id = 1 WHERE voter_id = ?`, v2); err != nil {
+		t.Fatalf("update vote failed: %v", err)
+	}
+	verification := verify()
+	if verification.Consistent || len(verification.Mismatches) != 1 {
+		t.Fatalf("expected one mismatched category, got %+v", verification)
+	}
+	mismatch := verification.Mismatches[0]
+	if len(mismatch.Cars) != 2 || len(mismatch.PrimaryWinners) != 2 || len(mismatch.RecountedWinners) != 1 || mismatch.RecountedWinners[0] != 1 {
+		t.Errorf("unexpected mismatch: %+v", mismatch)
+	}
+}
+
+func TestHandleExclusivityPools_CRUD(t *testing.T) {
+	setup := newTestSetup(t)
+
+	send := func(method, path string, payload interface{}) *httptest.ResponseRecorder {
+		var body []byte
+		if payload != nil {
+			body, _ = json.Marshal(payload)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := send(http.MethodPost, "/api/admin/exclusivity-pools", map[string]string{"name": "Design Awards"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var created handlers.ExclusivityPoolResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ID <= 0 || created.Name != "Design Awards" {
+		t.Errorf("unexpected create response: %+v", created)
+	}
+
+	rec = send(http.MethodPost, "/api/admin/exclusivity-pools", map[string]string{"name": "design awards"})
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected status %d for duplicate name, got %d", http.StatusConflict, rec.Code)
+	}
+	rec = send(http.MethodPost, "/api/admin/exclusivity-pools", map[string]string{"name": ""})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d for blank name, got %d", http.StatusUnprocessableEntity, rec.Code)
+	}
+
+	// Assign a group to the pool by name
+	rec = send(http.MethodPost, "/api/admin/category-groups", map[string]interface{}{"name": "Looks", "exclusivity_pool_name": "Design Awards"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	rec = send(http.MethodPost, "/api/admin/category-groups", map[string]interface{}{"name": "Speed", "exclusivity_pool_name": "Unknown"})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d for unknown pool, got %d", http.StatusUnprocessableEntity, rec.Code)
+	}
+
+	rec = send(http.MethodPut, fmt.Sprintf("/api/admin/exclusivity-pools/%d", created.ID), map[string]string{"name": "Looks Awards"})
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	rec = send(http.MethodPut, "/api/admin/exclusivity-pools/99999", map[string]string{"name": "Other"})
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+
+	rec = send(http.MethodGet, "/api/admin/exclusivity-pools", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	var pools []models.ExclusivityPool
+	if err := json.NewDecoder(rec.Body).Decode(&pools); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(pools) != 1 || pools[0].Name != "Looks Awards" || pools[0].GroupCount != 1 {
+		t.Errorf("unexpected pools: %+v", pools)
+	}
+
+	rec = send(http.MethodDelete, fmt.Sprintf("/api/admin/exclusivity-pools/%d", created.ID), nil)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+	}
+	rec = send(http.MethodDelete, fmt.Sprintf("/api/admin/exclusivity-pools/%d", created.ID), nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
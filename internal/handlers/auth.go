@@ -14,7 +14,7 @@ type LoginPageData struct {
 // handleLoginPage renders the login form
 func (h *Handlers) handleLoginPage(w http.ResponseWriter, r *http.Request) {
 	// If already logged in, redirect to admin
-	if h.Auth.GetSessionFromRequest(r) {
+	if h.Auth.IsAuthenticated(r) {
 		http.Redirect(w, r, "/admin", http.StatusFound)
 		return
 	}
@@ -7,7 +7,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/abrezinsky/derbyvote/internal/handlers"
+	"github.com/abrezinsky/derbyvote/internal/models"
 )
 
 // ==================== Car Tests ====================
@@ -65,6 +69,96 @@ func TestHandleGetCars_Empty(t *testing.T) {
 	}
 }
 
+func TestHandleGetCars_Paged(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	for i := 1; i <= 5; i++ {
+		_ = setup.repo.CreateCar(ctx, fmt.Sprint(i), fmt.Sprintf("Racer %d", i), "", "")
+	}
+	_ = setup.repo.SetCarEligibility(ctx, 5, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/cars?limit=2&offset=2&eligible=true&q=racer", nil)
+	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("X-Total-Count"); got != "4" {
+		t.Errorf("expected X-Total-Count 4, got %q", got)
+	}
+
+	var response []map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 2 || response[0]["car_number"] != "3" || response[1]["car_number"] != "4" {
+		t.Errorf("expected cars 3 and 4, got %v", response)
+	}
+}
+
+func TestHandleGetCars_WriteIns(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	_ = setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	writeInID, _ := setup.repo.GetOrCreateWriteInCar(ctx, "Mystery Car")
+
+	get := func(query string) []models.Car {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/cars"+query, nil)
+		rec := httptest.NewRecorder()
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var cars []models.Car
+		if err := json.NewDecoder(rec.Body).Decode(&cars); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return cars
+	}
+
+	if cars := get(""); len(cars) != 1 || cars[0].WriteIn {
+		t.Errorf("expected only the registered car by default, got %+v", cars)
+	}
+	cars := get("?write_ins=true")
+	if len(cars) != 1 || cars[0].ID != writeInID || !cars[0].WriteIn || cars[0].Eligible {
+		t.Fatalf("expected the write-in car awaiting approval, got %+v", cars)
+	}
+
+	// Admins approve a write-in by marking it eligible like any other car
+	body := strings.NewReader(`{"eligible": true}`)
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/admin/cars/%d/eligibility", writeInID), body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if cars := get("?write_ins=true&eligible=true"); len(cars) != 1 || !cars[0].Eligible {
+		t.Errorf("expected the write-in car to be eligible, got %+v", cars)
+	}
+}
+
+func TestHandleGetCars_InvalidParams(t *testing.T) {
+	setup := newTestSetup(t)
+
+	for _, query := range []string{"limit=abc", "offset=-1", "eligible=maybe", "limit=100000"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/cars?"+query, nil)
+		rec := httptest.NewRecorder()
+		req.AddCookie(setup.authCookie)
+		setup.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, rec.Code)
+		}
+	}
+}
+
 func TestHandleGetCars_ServiceError(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -163,6 +257,137 @@ func TestHandleGetCar_ServiceError(t *testing.T) {
 	}
 }
 
+func TestHandleGetCarResults_WithVotes(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	cat1ID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	cat2ID, _ := setup.repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil, nil)
+	setup.repo.CreateCategory(ctx, "Most Creative", 3, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	setup.repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	car1ID, car2ID := cars[0].ID, cars[1].ID
+
+	voter1, _ := setup.repo.CreateVoter(ctx, "QR-1")
+	voter2, _ := setup.repo.CreateVoter(ctx, "QR-2")
+	setup.repo.SaveVote(ctx, voter1, int(cat1ID), car1ID)
+	setup.repo.SaveVote(ctx, voter2, int(cat1ID), car1ID)
+	setup.repo.SaveVote(ctx, voter1, int(cat2ID), car2ID)
+	setup.repo.SaveVote(ctx, voter2, int(cat2ID), car2ID)
+	// Give car 1 one vote in category 2 so it places second there
+	voter3, _ := setup.repo.CreateVoter(ctx, "QR-3")
+	setup.repo.SaveVote(ctx, voter3, int(cat2ID), car1ID)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/cars/%d/results", car1ID), nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response []map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(response) != 2 {
+		t.Fatalf("expected results in 2 categories, got %d: %v", len(response), response)
+	}
+	if response[0]["category_name"] != "Best Design" || response[0]["vote_count"] != float64(2) || response[0]["rank"] != float64(1) {
+		t.Errorf("unexpected Best Design result: %v", response[0])
+	}
+	if response[1]["category_name"] != "Fastest Looking" || response[1]["vote_count"] != float64(1) || response[1]["rank"] != float64(2) {
+		t.Errorf("unexpected Fastest Looking result: %v", response[1])
+	}
+}
+
+func TestHandleGetCarResults_NoVotes(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	cars, _ := setup.repo.ListCars(ctx)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/cars/%d/results", cars[0].ID), nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != "[]" {
+		t.Errorf("expected empty array, got %s", body)
+	}
+}
+
+func TestHandleGetCarResults_NotFound(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/cars/99999/results", nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleSetCarDerbyNetRacer(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+	setup.repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+	setup.repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	carID := cars[0].ID
+
+	put := func(id int, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/admin/cars/%d/derbynet-racer", id), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := put(carID, `{"racer_id": 3}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response handlers.CarDerbyNetRacerResponse
+	json.NewDecoder(rec.Body).Decode(&response)
+	if response.DerbyNetRacerID == nil || *response.DerbyNetRacerID != 3 {
+		t.Errorf("expected derbynet_racer_id 3, got %v", response.DerbyNetRacerID)
+	}
+
+	if rec := put(carID, `{"racer_id": 9999}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown racer: expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if rec := put(carID, `{"racer": 3}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("unknown field: expected status %d, got %d", http.StatusUnprocessableEntity, rec.Code)
+	}
+	if rec := put(99999, `{"racer_id": null}`); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown car: expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+
+	rec = put(carID, `{"racer_id": null}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("clear: expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	racerID, _ := setup.repo.GetCarDerbyNetRacerID(ctx, carID)
+	if racerID != nil {
+		t.Errorf("expected mapping cleared, got %d", *racerID)
+	}
+}
+
 func TestHandleGetCar_InvalidID(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -270,6 +495,63 @@ func TestHandleCreateCar_ServiceError(t *testing.T) {
 	}
 }
 
+func TestHandleCreateCar_DuplicateNumericCarNumber(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	setup.repo.SetSetting(ctx, "car_number_format", "numeric")
+	if err := setup.repo.CreateCar(ctx, "1", "First Racer", "First Car", ""); err != nil {
+		t.Fatalf("failed to create test car: %v", err)
+	}
+	cars, _ := setup.repo.ListCars(ctx)
+
+	payload := map[string]interface{}{
+		"car_number": " 01 ",
+		"racer_name": "Second Racer",
+	}
+	body, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/cars", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+
+	var response handlers.CarNumberConflictResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Error == nil || response.Error.Code != handlers.ErrCodeConflict {
+		t.Errorf("expected CONFLICT error, got %+v", response.Error)
+	}
+	if response.ConflictingCar.ID != cars[0].ID || response.ConflictingCar.RacerName != "First Racer" {
+		t.Errorf("expected conflicting car %d, got %+v", cars[0].ID, response.ConflictingCar)
+	}
+}
+
+func TestHandleCreateCar_NonNumericCarNumber(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.repo.SetSetting(context.Background(), "car_number_format", "numeric")
+
+	body, _ := json.Marshal(map[string]interface{}{"car_number": "12A"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/cars", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+}
+
 func TestHandleUpdateCar_Success(t *testing.T) {
 	setup := newTestSetup(t)
 	ctx := context.Background()
@@ -2,13 +2,19 @@ package handlers
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+
+	"github.com/abrezinsky/derbyvote/internal/testutil"
 )
 
 // This file contains tests for edge cases that can't be reached through the router
@@ -144,3 +150,144 @@ func TestParseIntParam_EmptyParam(t *testing.T) {
 		t.Error("expected error for empty parameter, got nil")
 	}
 }
+
+func TestTimeoutRequests_CancelsBlockedQuery(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	h := &Handlers{requestTimeout: 50 * time.Millisecond}
+
+	// A recursive query that never finishes on its own
+	blocked := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		err := repo.DB().QueryRowContext(r.Context(), `
+			WITH RECURSIVE forever(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM forever)
+			SELECT COUNT(*) FROM forever
+		`).Scan(&n)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		respondOK(w, n)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/slow", nil)
+	rec := httptest.NewRecorder()
+	start := time.Now()
+	h.timeoutRequests(blocked).ServeHTTP(rec, req)
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the query to be cancelled, took %v", elapsed)
+	}
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusServiceUnavailable, rec.Code, rec.Body.String())
+	}
+	var body errorEnvelope
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Error == nil || body.Error.Code != ErrCodeTimeout {
+		t.Errorf("expected TIMEOUT error, got %+v", body.Error)
+	}
+
+	// The connection is free again once the request is cancelled
+	var one int
+	if err := repo.DB().QueryRowContext(context.Background(), `SELECT 1`).Scan(&one); err != nil {
+		t.Errorf("expected database to be usable after timeout: %v", err)
+	}
+}
+
+func TestTimeoutRequests_HandlerGivesUpWithoutResponding(t *testing.T) {
+	h := &Handlers{requestTimeout: 10 * time.Millisecond}
+	waits := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	rec := httptest.NewRecorder()
+	h.timeoutRequests(waits).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+}
+
+func TestTimeoutRequests_Disabled(t *testing.T) {
+	h := &Handlers{}
+	var hasDeadline bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = r.Context().Deadline()
+	})
+
+	h.timeoutRequests(next).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if hasDeadline {
+		t.Error("expected no deadline when the timeout is zero")
+	}
+}
+
+func TestCompressResponses(t *testing.T) {
+	large := `{"data":"` + strings.Repeat("x", 2*DefaultGzipMinSize) + `"}`
+	serve := func(h *Handlers, acceptEncoding, contentType, body string) *httptest.ResponseRecorder {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.Write([]byte(body))
+		})
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/results", nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		h.compressResponses(next).ServeHTTP(rec, req)
+		return rec
+	}
+	h := &Handlers{gzip: true}
+
+	rec := serve(h, "br, gzip", "application/json", large)
+	if rec.Header().Get("Content-Encoding") != "gzip" || rec.Header().Get("Vary") != "Accept-Encoding" {
+		t.Fatalf("expected a gzipped response, got headers %v", rec.Header())
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("failed to open gzip body: %v", err)
+	}
+	if got, _ := io.ReadAll(zr); string(got) != large {
+		t.Errorf("expected the body to round-trip, got %d bytes", len(got))
+	}
+
+	for name, rec := range map[string]*httptest.ResponseRecorder{
+		"below threshold": serve(h, "gzip", "application/json", `{"ok":true}`),
+		"not accepted":    serve(h, "", "application/json", large),
+		"refused by q=0":  serve(h, "gzip;q=0", "application/json", large),
+		"image":           serve(h, "gzip", "image/png", large),
+		"disabled":        serve(&Handlers{}, "gzip", "application/json", large),
+	} {
+		if rec.Header().Get("Content-Encoding") != "" {
+			t.Errorf("%s: expected no compression, got %v", name, rec.Header())
+		}
+		if rec.Body.Len() == 0 || rec.Code != http.StatusOK {
+			t.Errorf("%s: expected the plain body with 200, got %d and %d bytes", name, rec.Code, rec.Body.Len())
+		}
+	}
+}
+
+func TestCompressResponses_FlushSendsEarly(t *testing.T) {
+	h := &Handlers{gzip: true}
+	var flushedBytes int
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("first chunk\n"))
+		w.(http.Flusher).Flush()
+		flushedBytes = w.(*gzipResponseWriter).ResponseWriter.(*httptest.ResponseRecorder).Body.Len()
+		w.Write([]byte("second chunk\n"))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.compressResponses(next).ServeHTTP(rec, req)
+
+	if flushedBytes == 0 || !rec.Flushed {
+		t.Error("expected the first chunk to be sent on flush")
+	}
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "first chunk\nsecond chunk\n" {
+		t.Errorf("expected the uncompressed stream with status 202, got %d %q", rec.Code, rec.Body.String())
+	}
+}
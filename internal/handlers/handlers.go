@@ -1,19 +1,65 @@
 package handlers
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"html/template"
 	"io/fs"
 	"net/http"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/abrezinsky/derbyvote/internal/auth"
 	"github.com/abrezinsky/derbyvote/internal/services"
 	"github.com/abrezinsky/derbyvote/internal/websocket"
 )
 
-// NewStaticServer creates a static file server from an fs.FS
+// staticCacheControl lets browsers reuse static assets for a few minutes
+// before revalidating them, which a matching ETag answers with 304
+const staticCacheControl = "public, max-age=300"
+
+// NewStaticServer creates a static file server from an fs.FS. Files carry an
+// ETag from their content so phones reloading the voter page on slow WiFi get
+// 304 Not Modified instead of downloading CSS and JS again. HEAD requests and
+// directory index.html files are served as by http.FileServer.
 func NewStaticServer(staticFS fs.FS) http.Handler {
-	return http.FileServer(http.FS(staticFS))
+	etags := staticETags(staticFS)
+	files := http.FileServer(http.FS(staticFS))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+		if etag, ok := etags[name]; ok {
+			// http.FileServer checks If-None-Match against this header
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Cache-Control", staticCacheControl)
+		}
+		files.ServeHTTP(w, r)
+	})
+}
+
+// staticETags hashes every file in staticFS, keyed by path. A directory shares
+// the ETag of its index.html, which is what http.FileServer serves for it.
+func staticETags(staticFS fs.FS) map[string]string {
+	etags := make(map[string]string)
+	fs.WalkDir(staticFS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		data, err := fs.ReadFile(staticFS, name)
+		if err != nil {
+			return nil
+		}
+		sum := sha256.Sum256(data)
+		etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+		etags[name] = etag
+		if path.Base(name) == "index.html" {
+			etags[strings.TrimPrefix(path.Dir(name), ".")] = etag
+		}
+		return nil
+	})
+	return etags
 }
 
 // AdminPageData holds the data passed to admin templates
@@ -23,6 +69,12 @@ type AdminPageData struct {
 	ActiveNav string
 }
 
+// PublicPageData holds the data passed to voter-facing templates
+type PublicPageData struct {
+	Branding *services.Branding
+	QRCode   string
+}
+
 // Templates holds all parsed HTML templates
 type Templates struct {
 	Index           *template.Template
@@ -38,22 +90,30 @@ type Templates struct {
 
 // Handlers holds all HTTP handler dependencies
 type Handlers struct {
-	Voting       services.VotingServicer
-	Category     services.CategoryServicer
-	Voter        services.VoterServicer
-	Car          services.CarServicer
-	Settings     services.SettingsServicer
-	Results      services.ResultsServicer
-	Auth         *auth.Auth
-	Hub          *websocket.Hub
-	Log          HTTPLogger
-	templates    *Templates
-	staticServer http.Handler
+	Voting         services.VotingServicer
+	Category       services.CategoryServicer
+	Voter          services.VoterServicer
+	Car            services.CarServicer
+	Settings       services.SettingsServicer
+	Results        services.ResultsServicer
+	DerbyNetHealth services.DerbyNetHealthServicer
+	Auth           *auth.Auth
+	Hub            *websocket.Hub
+	Log            HTTPLogger
+	templates      *Templates
+	staticServer   http.Handler
+	uploadDir      string
+	maxBodySize    int64
+	requestTimeout time.Duration
+	gzip           bool
+	readOnly       bool
 }
 
 // HTTPLogger is an interface for loggers that support HTTP logging control
 type HTTPLogger interface {
 	IsHTTPLoggingEnabled() bool
+	DebugContext(ctx context.Context, msg string, args ...any)
+	InfoContext(ctx context.Context, msg string, args ...any)
 }
 
 // New creates a new Handlers instance with all dependencies
@@ -76,24 +136,62 @@ func New(
 	}
 
 	return &Handlers{
-		Voting:       voting,
-		Category:     category,
-		Voter:        voter,
-		Car:          car,
-		Settings:     settings,
-		Results:      results,
-		Auth:         adminAuth,
-		Hub:          hub,
-		Log:          log,
-		templates:    templates,
-		staticServer: staticServer,
+		Voting:         voting,
+		Category:       category,
+		Voter:          voter,
+		Car:            car,
+		Settings:       settings,
+		Results:        results,
+		Auth:           adminAuth,
+		Hub:            hub,
+		Log:            log,
+		templates:      templates,
+		staticServer:   staticServer,
+		maxBodySize:    DefaultMaxBodySize,
+		requestTimeout: DefaultRequestTimeout,
+		gzip:           true,
 	}, nil
 }
 
+// SetUploadDir sets the directory where uploaded files such as the branding logo are stored
+func (h *Handlers) SetUploadDir(dir string) {
+	h.uploadDir = dir
+}
+
+// SetMaxBodySize sets the request body limit applied to every request; routes
+// such as uploads may apply their own limit instead. Zero disables the limit.
+func (h *Handlers) SetMaxBodySize(n int64) {
+	h.maxBodySize = n
+}
+
+// SetRequestTimeout sets how long a request may run before its context is
+// cancelled and it is answered with 503. Zero disables the timeout.
+func (h *Handlers) SetRequestTimeout(d time.Duration) {
+	h.requestTimeout = d
+}
+
+// SetGzip turns gzip compression of responses on or off; it is on by default
+func (h *Handlers) SetGzip(enabled bool) {
+	h.gzip = enabled
+}
+
+// SetReadOnly makes the router reject POST, PUT, PATCH and DELETE requests
+// with 405, for instances that only display results
+func (h *Handlers) SetReadOnly(readOnly bool) {
+	h.readOnly = readOnly
+}
+
+// SetDerbyNetHealth sets the service that reports background DerbyNet health checks
+func (h *Handlers) SetDerbyNetHealth(health services.DerbyNetHealthServicer) {
+	h.DerbyNetHealth = health
+}
+
 // NoopHTTPLogger is a test logger that always returns false for HTTP logging
 type NoopHTTPLogger struct{}
 
-func (NoopHTTPLogger) IsHTTPLoggingEnabled() bool { return false }
+func (NoopHTTPLogger) IsHTTPLoggingEnabled() bool                                { return false }
+func (NoopHTTPLogger) DebugContext(ctx context.Context, msg string, args ...any) {}
+func (NoopHTTPLogger) InfoContext(ctx context.Context, msg string, args ...any)  {}
 
 // NewForTesting creates a Handlers instance without loading templates (for testing API endpoints)
 
@@ -108,14 +206,17 @@ func NewForTesting(
 	// Create a test auth with a known password
 	testAuth := auth.New("test-password")
 	return &Handlers{
-		Voting:   voting,
-		Category: category,
-		Voter:    voter,
-		Car:      car,
-		Settings: settings,
-		Results:  results,
-		Auth:     testAuth,
-		Log:      NoopHTTPLogger{},
+		Voting:         voting,
+		Category:       category,
+		Voter:          voter,
+		Car:            car,
+		Settings:       settings,
+		Results:        results,
+		Auth:           testAuth,
+		Log:            NoopHTTPLogger{},
+		maxBodySize:    DefaultMaxBodySize,
+		requestTimeout: DefaultRequestTimeout,
+		gzip:           true,
 		// templates left nil - API endpoints don't use templates
 	}
 }
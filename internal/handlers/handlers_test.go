@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"testing/fstest"
@@ -828,3 +829,72 @@ func TestNewForTesting_NoTemplatesNeeded(t *testing.T) {
 		t.Error("expected test auth to be created")
 	}
 }
+
+func TestNewStaticServer_CachingAndConditionalRequests(t *testing.T) {
+	staticFS := fstest.MapFS{
+		"css/app.css":     &fstest.MapFile{Data: []byte(`body { color: black; }`)},
+		"docs/index.html": &fstest.MapFile{Data: []byte(`<html>Docs</html>`)},
+	}
+	server := handlers.NewStaticServer(staticFS)
+
+	serve := func(method, target, etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(http.MethodGet, "/css/app.css", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+	if cc := rec.Header().Get("Cache-Control"); !strings.Contains(cc, "max-age=") {
+		t.Errorf("expected Cache-Control with max-age, got %q", cc)
+	}
+
+	// A matching ETag is answered without the body
+	rec = serve(http.MethodGet, "/css/app.css", etag)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected status %d, got %d", http.StatusNotModified, rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected empty body for 304, got %q", rec.Body.String())
+	}
+	rec = serve(http.MethodGet, "/css/app.css", `"stale"`)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d for a stale ETag, got %d", http.StatusOK, rec.Code)
+	}
+
+	// HEAD gets the headers without the body
+	rec = serve(http.MethodHead, "/css/app.css", "")
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("expected 200 with no body for HEAD, got %d with %d bytes", rec.Code, rec.Body.Len())
+	}
+	if rec.Header().Get("ETag") != etag {
+		t.Errorf("expected HEAD to carry ETag %s, got %q", etag, rec.Header().Get("ETag"))
+	}
+
+	// A directory still serves its index.html, with the same ETag
+	rec = serve(http.MethodGet, "/docs/", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Docs") {
+		t.Fatalf("expected directory index, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := serve(http.MethodGet, "/docs/", rec.Header().Get("ETag")); rec.Code != http.StatusNotModified {
+		t.Errorf("expected status %d for directory index, got %d", http.StatusNotModified, rec.Code)
+	}
+
+	rec = serve(http.MethodGet, "/css/missing.css", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+	if rec.Header().Get("ETag") != "" {
+		t.Error("expected no ETag for a missing file")
+	}
+}
@@ -1,15 +1,20 @@
 package handlers
 
 import (
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	stderrors "errors"
 	"io"
 	"log"
 	"net/http"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/services"
 )
@@ -18,6 +23,7 @@ import (
 const (
 	ErrCodeBadRequest       = "BAD_REQUEST"
 	ErrCodeUnauthorized     = "UNAUTHORIZED"
+	ErrCodeForbidden        = "FORBIDDEN"
 	ErrCodeNotFound         = "NOT_FOUND"
 	ErrCodeConflict         = "CONFLICT"
 	ErrCodeValidation       = "VALIDATION_ERROR"
@@ -25,13 +31,28 @@ const (
 	ErrCodeVotingClosed     = "VOTING_CLOSED"
 	ErrCodeAlreadyVoted     = "ALREADY_VOTED"
 	ErrCodeInvalidQRCode    = "INVALID_QR_CODE"
+	ErrCodeConfirmRequired  = "CONFIRMATION_REQUIRED"
+	ErrCodePayloadTooLarge  = "PAYLOAD_TOO_LARGE"
+	ErrCodeReadOnly         = "READ_ONLY"
+	ErrCodeMaintenance      = "MAINTENANCE"
+	ErrCodeTimeout          = "TIMEOUT"
+	ErrCodeResultsEmbargoed = "RESULTS_EMBARGOED"
 )
 
-// APIError represents an error with an HTTP status code and error code
+// APIError represents an error with an HTTP status code and error code.
+// Field names the offending input when there is one; Fields carries a
+// message per field when several failed validation together.
 type APIError struct {
-	Status  int    `json:"-"`
-	Code    string `json:"code"`
-	Message string `json:"error"`
+	Status  int               `json:"-"`
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Field   string            `json:"field,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// errorEnvelope is the body of every error response: {"error": {...}}
+type errorEnvelope struct {
+	Error *APIError `json:"error"`
 }
 
 func (e *APIError) Error() string {
@@ -70,6 +91,11 @@ func Unauthorized(message string) *APIError {
 	return &APIError{Status: http.StatusUnauthorized, Code: ErrCodeUnauthorized, Message: message}
 }
 
+// Forbidden creates a 403 error with custom message
+func Forbidden(message string) *APIError {
+	return &APIError{Status: http.StatusForbidden, Code: ErrCodeForbidden, Message: message}
+}
+
 // NotFound creates a 404 error with custom message
 func NotFound(message string) *APIError {
 	return &APIError{Status: http.StatusNotFound, Code: ErrCodeNotFound, Message: message}
@@ -80,6 +106,26 @@ func Conflict(message string) *APIError {
 	return &APIError{Status: http.StatusConflict, Code: ErrCodeConflict, Message: message}
 }
 
+// PayloadTooLarge creates a 413 error with custom message
+func PayloadTooLarge(message string) *APIError {
+	return &APIError{Status: http.StatusRequestEntityTooLarge, Code: ErrCodePayloadTooLarge, Message: message}
+}
+
+// ReadOnly creates a 405 error for writes to a read-only instance
+func ReadOnly(message string) *APIError {
+	return &APIError{Status: http.StatusMethodNotAllowed, Code: ErrCodeReadOnly, Message: message}
+}
+
+// Maintenance creates a 503 error carrying the maintenance message shown to voters
+func Maintenance(message string) *APIError {
+	return &APIError{Status: http.StatusServiceUnavailable, Code: ErrCodeMaintenance, Message: message}
+}
+
+// Timeout creates a 503 error for a request that ran past its deadline
+func Timeout() *APIError {
+	return &APIError{Status: http.StatusServiceUnavailable, Code: ErrCodeTimeout, Message: "The request took too long to complete; please try again"}
+}
+
 // InternalError creates a 500 error, logs the original error
 func InternalError(err error) *APIError {
 	log.Printf("Internal error: %v", err)
@@ -115,15 +161,28 @@ func respondDeleted(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// respondError writes an error response
-func respondError(w http.ResponseWriter, err error) {
-	if apiErr, ok := err.(*APIError); ok {
-		respondJSON(w, apiErr.Status, apiErr)
-		return
+// writeError writes an error response in the standard error envelope
+func writeError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		// Convert service errors to appropriate API errors
+		apiErr = ToAPIError(err)
 	}
-	// Convert service errors to appropriate API errors
-	apiErr := ToAPIError(err)
-	respondJSON(w, apiErr.Status, apiErr)
+	respondJSON(w, apiErr.Status, errorEnvelope{Error: apiErr})
+}
+
+// writeConfirmationRequired writes a 409 asking the client to repeat the
+// request with force set, reporting how many votes the action would affect
+func writeConfirmationRequired(w http.ResponseWriter, message string, voteCount int) {
+	respondJSON(w, http.StatusConflict, struct {
+		errorEnvelope
+		ConfirmationRequired bool `json:"confirmation_required"`
+		VoteCount            int  `json:"vote_count"`
+	}{
+		errorEnvelope:        errorEnvelope{Error: &APIError{Status: http.StatusConflict, Code: ErrCodeConfirmRequired, Message: message}},
+		ConfirmationRequired: true,
+		VoteCount:            voteCount,
+	})
 }
 
 // decodeJSON decodes JSON from request body into the target
@@ -132,11 +191,377 @@ func decodeJSON(r *http.Request, target interface{}) error {
 		if err == io.EOF {
 			return BadRequest("Request body is empty")
 		}
+		if tooLarge, ok := bodyTooLarge(err); ok {
+			return tooLarge
+		}
+		return BadRequest("Invalid JSON: " + err.Error())
+	}
+	return nil
+}
+
+// decodeJSONFields decodes a JSON object into target, rejecting keys the target
+// does not declare and reporting type mismatches per field (422)
+func decodeJSONFields(r *http.Request, target interface{}) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		if tooLarge, ok := bodyTooLarge(err); ok {
+			return tooLarge
+		}
+		return BadRequest("Failed to read request body")
+	}
+	if len(body) == 0 {
+		return BadRequest("Request body is empty")
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return BadRequest("Invalid JSON: " + err.Error())
+	}
+
+	known := jsonFieldNames(target)
+	fields := make(map[string]string)
+	for key := range raw {
+		if !known[key] {
+			fields[key] = "unknown field"
+		}
+	}
+	if len(fields) > 0 {
+		return errors.InvalidFields(fields)
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if stderrors.As(err, &typeErr) && typeErr.Field != "" {
+			return errors.InvalidFields(map[string]string{typeErr.Field: "must be " + describeJSONKind(typeErr.Type.Kind())})
+		}
 		return BadRequest("Invalid JSON: " + err.Error())
 	}
 	return nil
 }
 
+// DefaultMaxBodySize is the request body limit used unless SetMaxBodySize changes it
+const DefaultMaxBodySize = 10 << 20
+
+// limitedBody is a request body capped by setBodyLimit. It keeps the
+// original body so a route can replace the limit with its own.
+type limitedBody struct {
+	io.ReadCloser
+	original io.ReadCloser
+}
+
+// setBodyLimit caps the request body at limit bytes, replacing any limit
+// already applied. Reads past the limit fail with *http.MaxBytesError.
+func setBodyLimit(w http.ResponseWriter, r *http.Request, limit int64) {
+	body := r.Body
+	if limited, ok := body.(*limitedBody); ok {
+		body = limited.original
+	}
+	r.Body = &limitedBody{ReadCloser: http.MaxBytesReader(w, body, limit), original: body}
+}
+
+// limitBody applies the configured request body limit to every request
+func (h *Handlers) limitBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.maxBodySize > 0 && r.Body != nil && r.Body != http.NoBody {
+			setBodyLimit(w, r, h.maxBodySize)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readOnlyWritablePaths still accept POST in read-only mode; admin sessions
+// are kept in memory, so logging in and out never writes to the database
+var readOnlyWritablePaths = map[string]bool{
+	"/admin/login":  true,
+	"/admin/logout": true,
+}
+
+// rejectWrites answers mutating requests with 405 when the instance is read-only
+func (h *Handlers) rejectWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.readOnly && !readOnlyWritablePaths[r.URL.Path] {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+			default:
+				w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+				writeError(w, ReadOnly("This instance is read-only"))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// DefaultRequestTimeout is the per-request deadline used unless SetRequestTimeout changes it
+const DefaultRequestTimeout = 15 * time.Second
+
+// timeoutRequests gives each request a context deadline, so a slow query is
+// cancelled rather than holding a database connection. A handler that gives
+// up without responding is answered with a 503. WebSocket upgrades outlive
+// any request deadline and are left alone.
+func (h *Handlers) timeoutRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.requestTimeout <= 0 || strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), h.requestTimeout)
+		defer cancel()
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+		if ww.Status() == 0 && stderrors.Is(ctx.Err(), context.DeadlineExceeded) {
+			writeError(ww, Timeout())
+		}
+	})
+}
+
+// DefaultGzipMinSize is the smallest response body worth compressing; below it
+// the gzip framing costs more than it saves
+const DefaultGzipMinSize = 1024
+
+// compressResponses gzips responses for clients that accept it once the body
+// reaches DefaultGzipMinSize. Only text-like content types are compressed, so
+// PNG QR codes and uploaded images are sent as they are. WebSocket upgrades
+// are left alone, and a handler that flushes early gets what it has written
+// so far sent immediately.
+func (h *Handlers) compressResponses(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !h.gzip || strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w, accepted: acceptsGzip(r)}
+		next.ServeHTTP(gw, r)
+		// Not deferred: after a panic the buffered response is dropped so
+		// the recoverer can still send its 500
+		gw.close()
+	})
+}
+
+// acceptsGzip reports whether the Accept-Encoding header allows gzip
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		if key, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(key) == "q" {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// compressibleType reports whether a Content-Type is worth compressing
+func compressibleType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	switch mediaType {
+	case "application/json", "application/javascript", "application/xml", "image/svg+xml":
+		return true
+	}
+	return strings.HasPrefix(mediaType, "text/")
+}
+
+// gzipResponseWriter holds back the start of a response until it knows
+// whether the response is big enough, and of the right type, to compress
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	accepted bool
+	status   int
+	buf      []byte
+	decided  bool
+	gz       *gzip.Writer
+}
+
+func (g *gzipResponseWriter) WriteHeader(status int) {
+	if g.decided {
+		g.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if g.status == 0 {
+		g.status = status
+	}
+}
+
+func (g *gzipResponseWriter) Write(p []byte) (int, error) {
+	if !g.decided {
+		g.buf = append(g.buf, p...)
+		if len(g.buf) < DefaultGzipMinSize {
+			return len(p), nil
+		}
+		if err := g.decide(); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if g.gz != nil {
+		return g.gz.Write(p)
+	}
+	return g.ResponseWriter.Write(p)
+}
+
+// Flush sends what has been written so far, deciding on compression early
+func (g *gzipResponseWriter) Flush() {
+	if !g.decided {
+		if err := g.decide(); err != nil {
+			return
+		}
+	}
+	if g.gz != nil {
+		g.gz.Flush()
+	}
+	if f, ok := g.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// decide picks compressed or plain output and writes the buffered start of the response
+func (g *gzipResponseWriter) decide() error {
+	g.decided = true
+	header := g.Header()
+	if header.Get("Content-Type") == "" && len(g.buf) > 0 {
+		// Sniff before compressing, as net/http would on the plain bytes
+		header.Set("Content-Type", http.DetectContentType(g.buf))
+	}
+	status := g.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	if compressibleType(header.Get("Content-Type")) {
+		header.Add("Vary", "Accept-Encoding")
+		if g.accepted && len(g.buf) >= DefaultGzipMinSize && status != http.StatusPartialContent && header.Get("Content-Encoding") == "" {
+			header.Del("Content-Length")
+			header.Set("Content-Encoding", "gzip")
+			g.gz = gzip.NewWriter(g.ResponseWriter)
+		}
+	}
+
+	g.ResponseWriter.WriteHeader(status)
+	buf := g.buf
+	g.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if g.gz != nil {
+		_, err = g.gz.Write(buf)
+	} else {
+		_, err = g.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// close sends a response that never reached the threshold and ends the gzip stream
+func (g *gzipResponseWriter) close() {
+	if !g.decided && (g.status != 0 || len(g.buf) > 0) {
+		if err := g.decide(); err != nil {
+			return
+		}
+	}
+	if g.gz != nil {
+		g.gz.Close()
+	}
+}
+
+// pauseForMaintenance answers voter requests with 503 and the maintenance
+// message while maintenance mode is on. Pages get the message as plain text
+// so a voter's phone can show it; API requests get the standard error body.
+func (h *Handlers) pauseForMaintenance(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		message, err := h.Settings.MaintenanceMessage(r.Context())
+		if err != nil || message == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			writeError(w, Maintenance(message))
+			return
+		}
+		http.Error(w, message, http.StatusServiceUnavailable)
+	})
+}
+
+// RevealHeader carries the reveal code that shows embargoed results
+const RevealHeader = "X-Reveal"
+
+// requireReveal answers results requests with 423 while results are embargoed,
+// unless the X-Reveal header carries the reveal code
+func (h *Handlers) requireReveal(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := h.Results.CheckReveal(r.Context(), r.Header.Get(RevealHeader)); err != nil {
+			w.Header().Set("Cache-Control", "no-store")
+			writeError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bodyTooLarge returns a 413 error if err came from reading past the body limit
+func bodyTooLarge(err error) (*APIError, bool) {
+	var maxErr *http.MaxBytesError
+	if !stderrors.As(err, &maxErr) {
+		return nil, false
+	}
+	return PayloadTooLarge("Request body must be " + formatByteSize(maxErr.Limit) + " or smaller"), true
+}
+
+// formatByteSize renders a byte count for error messages, e.g. "10MB"
+func formatByteSize(n int64) string {
+	switch {
+	case n >= 1<<20 && n%(1<<20) == 0:
+		return strconv.FormatInt(n>>20, 10) + "MB"
+	case n >= 1<<10 && n%(1<<10) == 0:
+		return strconv.FormatInt(n>>10, 10) + "KB"
+	default:
+		return strconv.FormatInt(n, 10) + " bytes"
+	}
+}
+
+// jsonFieldNames returns the JSON keys declared on a struct (or pointer to struct)
+func jsonFieldNames(v interface{}) map[string]bool {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// describeJSONKind returns a human-readable name for an expected JSON type
+func describeJSONKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	default:
+		return "a valid value"
+	}
+}
+
 // parseIntParam extracts and parses an integer URL parameter
 func parseIntParam(r *http.Request, name string) (int, error) {
 	param := chi.URLParam(r, name)
@@ -150,8 +575,135 @@ func parseIntParam(r *http.Request, name string) (int, error) {
 	return id, nil
 }
 
+// parseIntQuery parses an optional integer query parameter, returning 0 when it is absent
+func parseIntQuery(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, BadRequest("Invalid " + name + " parameter")
+	}
+	return n, nil
+}
+
+// respondNotModified sets the validators for a response and, when the request
+// already holds the current representation (If-None-Match), writes 304 Not
+// Modified and returns true. Cache-Control: no-cache makes clients revalidate
+// on every request rather than guessing freshness from Last-Modified.
+func respondNotModified(w http.ResponseWriter, r *http.Request, etag string, modifiedAt time.Time) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", modifiedAt.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag,
+// using the weak comparison RFC 9110 specifies for that header
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiateContentType picks the offered media type the Accept header prefers,
+// honoring q-values and wildcards. The first offer is the default for a missing
+// header or one that accepts none of the offers.
+func negotiateContentType(r *http.Request, offers ...string) string {
+	best, bestQ := offers[0], 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaRange, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		mediaRange = strings.ToLower(strings.TrimSpace(mediaRange))
+		if mediaRange == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if key, value, ok := strings.Cut(strings.TrimSpace(param), "="); ok && strings.TrimSpace(key) == "q" {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+		for _, offer := range offers {
+			if mediaTypeMatches(mediaRange, offer) {
+				best, bestQ = offer, q
+				break
+			}
+		}
+	}
+	return best
+}
+
+// mediaTypeMatches reports whether an Accept media range such as text/* covers mediaType
+func mediaTypeMatches(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(mediaRange, "/*")
+	return ok && strings.HasPrefix(mediaType, prefix+"/")
+}
+
+// timestampLayouts lists the formats timestamps may be stored in. Values without
+// a zone (such as SQLite's CURRENT_TIMESTAMP) are UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05",
+}
+
+// formatTimestamp renders a stored timestamp as RFC3339 in loc.
+// Values that cannot be parsed are returned unchanged.
+func formatTimestamp(value string, loc *time.Location) string {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.In(loc).Format(time.RFC3339)
+		}
+	}
+	return value
+}
+
+// location returns the configured display time zone, falling back to the server's zone
+func (h *Handlers) location(r *http.Request) *time.Location {
+	loc, err := h.Settings.GetLocation(r.Context())
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// firstField returns the alphabetically first field name, so responses name
+// the same offending field every time
+func firstField(fields map[string]string) string {
+	first := ""
+	for name := range fields {
+		if first == "" || name < first {
+			first = name
+		}
+	}
+	return first
+}
+
 // ToAPIError converts service errors to appropriate API errors
 func ToAPIError(err error) *APIError {
+	// Queries cancelled by the request deadline surface as the context error
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return Timeout()
+	}
+
 	// Check for application errors first
 	var appErr *errors.Error
 	if stderrors.As(err, &appErr) {
@@ -162,6 +714,10 @@ func ToAPIError(err error) *APIError {
 			return &APIError{Status: http.StatusBadRequest, Code: ErrCodeValidation, Message: appErr.Message}
 		case errors.ErrConflict:
 			return Conflict(appErr.Message)
+		case errors.ErrForbidden:
+			return Forbidden(appErr.Message)
+		case errors.ErrInvalidFields:
+			return &APIError{Status: http.StatusUnprocessableEntity, Code: ErrCodeValidation, Message: appErr.Message, Field: firstField(appErr.Fields), Fields: appErr.Fields}
 		default:
 			return InternalError(err)
 		}
@@ -170,17 +726,26 @@ func ToAPIError(err error) *APIError {
 	// Legacy service errors (can migrate these over time)
 	if svcErr, ok := err.(*services.ServiceError); ok {
 		// Map specific service error types to error codes
-		if svcErr.Message == "Voting is closed" {
-			return &APIError{Status: http.StatusBadRequest, Code: ErrCodeVotingClosed, Message: svcErr.Message}
+		if svcErr == services.ErrVotingClosed {
+			return &APIError{Status: http.StatusForbidden, Code: ErrCodeVotingClosed, Message: svcErr.Message}
+		}
+		if svcErr == services.ErrResultsEmbargoed {
+			return &APIError{Status: http.StatusLocked, Code: ErrCodeResultsEmbargoed, Message: svcErr.Message}
 		}
 		if svcErr.Message == "You have already voted in this category" {
 			return &APIError{Status: http.StatusBadRequest, Code: ErrCodeAlreadyVoted, Message: svcErr.Message}
 		}
 		return BadRequest(svcErr.Message)
 	}
+	if dupErr, ok := err.(*services.DuplicateCarNumberError); ok {
+		return Conflict(dupErr.Error())
+	}
 	if tableErr, ok := err.(*services.InvalidTableError); ok {
 		return BadRequest(tableErr.Error())
 	}
+	if timerErr, ok := err.(*services.InvalidTimerMinutesError); ok {
+		return BadRequest(timerErr.Error())
+	}
 
 	return InternalError(err)
 }
@@ -111,12 +111,10 @@ func TestPredefinedErrors(t *testing.T) {
 	}
 }
 
-// Test error conversion from service errors
-func TestToAPIError_ServiceError(t *testing.T) {
+// Test error conversion from the invalid timer minutes error
+func TestToAPIError_InvalidTimerMinutesError(t *testing.T) {
 	setup := newTestSetup(t)
 
-	// Test by triggering a service error through the handlers
-	// Using invalid timer minutes should trigger ErrInvalidTimerMinutes
 	req := httptest.NewRequest(http.MethodPost, "/api/admin/voting-timer", strings.NewReader(`{"minutes":0}`))
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
@@ -125,7 +123,10 @@ func TestToAPIError_ServiceError(t *testing.T) {
 	setup.router.ServeHTTP(rec, req)
 
 	if rec.Code != http.StatusBadRequest {
-		t.Errorf("expected 400 for service error, got %d", rec.Code)
+		t.Errorf("expected 400 for invalid timer minutes, got %d", rec.Code)
+	}
+	if want := fmt.Sprintf("between 1 and %d", services.DefaultMaxVotingMinutes); !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected the error to state the max (%q), got %s", want, rec.Body.String())
 	}
 }
 
@@ -160,6 +161,36 @@ func TestToAPIError_ApplicationError_NotFound(t *testing.T) {
 	}
 }
 
+func TestWriteError_Envelope(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/category-groups/99999", nil)
+	rec := httptest.NewRecorder()
+
+	req.AddCookie(setup.authCookie)
+	setup.router.ServeHTTP(rec, req)
+
+	var response struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Field   string `json:"field"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Error.Code != handlers.ErrCodeNotFound {
+		t.Errorf("expected code %s, got %q", handlers.ErrCodeNotFound, response.Error.Code)
+	}
+	if response.Error.Message == "" {
+		t.Error("expected error message")
+	}
+	if response.Error.Field != "" {
+		t.Errorf("expected no field, got %q", response.Error.Field)
+	}
+}
+
 func TestDecodeJSON_EmptyBody(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -355,6 +386,13 @@ func TestToAPIError_DirectTests(t *testing.T) {
 			expectedMsg:    "resource conflict",
 			expectedCode:   "CONFLICT",
 		},
+		{
+			name:           "ForbiddenError",
+			inputErr:       &errors.Error{Kind: errors.ErrForbidden, Message: "access denied"},
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "access denied",
+			expectedCode:   "FORBIDDEN",
+		},
 		{
 			name:           "InternalError_DefaultCase",
 			inputErr:       &errors.Error{Kind: errors.ErrInternal, Message: "internal error"},
@@ -371,9 +409,9 @@ func TestToAPIError_DirectTests(t *testing.T) {
 		},
 		{
 			name:           "ServiceError_VotingClosed",
-			inputErr:       &services.ServiceError{Message: "Voting is closed"},
-			expectedStatus: http.StatusBadRequest,
-			expectedMsg:    "Voting is closed",
+			inputErr:       services.ErrVotingClosed,
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "voting is currently closed",
 			expectedCode:   "VOTING_CLOSED",
 		},
 		{
@@ -8,6 +8,10 @@ type CategoryCreateRequest struct {
 	Active             bool     `json:"active"`
 	AllowedVoterTypes  []string `json:"allowed_voter_types,omitempty"`
 	AllowedRanks       []string `json:"allowed_ranks,omitempty"`
+	Tags               []string `json:"tags,omitempty"`
+	AllowWriteIn       bool     `json:"allow_write_in"`
+	ShowLiveCounts     bool     `json:"show_live_counts"`
+	BannerURL          string   `json:"banner_url,omitempty"`
 }
 
 // CategoryUpdateRequest represents a request to update a category
@@ -18,24 +22,61 @@ type CategoryUpdateRequest struct {
 	Active             bool     `json:"active"`
 	AllowedVoterTypes  []string `json:"allowed_voter_types,omitempty"`
 	AllowedRanks       []string `json:"allowed_ranks,omitempty"`
+	Tags               []string `json:"tags,omitempty"`
+	AllowWriteIn       bool     `json:"allow_write_in"`
+	ShowLiveCounts     bool     `json:"show_live_counts"`
+	BannerURL          string   `json:"banner_url,omitempty"`
+}
+
+// CategoryDerbyNetAwardRequest represents a request to map a category to a
+// DerbyNet award; a null award_id clears the mapping
+type CategoryDerbyNetAwardRequest struct {
+	AwardID *int `json:"award_id"`
+}
+
+// CategoryCarsRequest represents a request to limit a category to a subset of
+// cars; an empty car_ids list lets every eligible car compete
+type CategoryCarsRequest struct {
+	CarIDs []int `json:"car_ids"`
+}
+
+// CategoryGroupAssignRequest represents a request to move a category to
+// another group; a null group_id takes it out of any group
+type CategoryGroupAssignRequest struct {
+	GroupID *int `json:"group_id"`
+}
+
+// CarDerbyNetRacerRequest represents a request to link a car to a DerbyNet
+// racer; a null racer_id clears the link
+type CarDerbyNetRacerRequest struct {
+	RacerID *int `json:"racer_id"`
 }
 
 // CategoryGroupCreateRequest represents a request to create a category group
 type CategoryGroupCreateRequest struct {
-	Name              string `json:"name"`
-	Description       string `json:"description"`
-	ExclusivityPoolID *int   `json:"exclusivity_pool_id"`
-	MaxWinsPerCar     *int   `json:"max_wins_per_car"`
-	DisplayOrder      int    `json:"display_order"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	ExclusivityPoolID   *int   `json:"exclusivity_pool_id"`
+	ExclusivityPoolName string `json:"exclusivity_pool_name"` // Assigns the pool by name instead of ID
+	MaxWinsPerCar       *int   `json:"max_wins_per_car"`
+	MultiWinStrategy    string `json:"multi_win_strategy"`
+	DisplayOrder        int    `json:"display_order"`
 }
 
 // CategoryGroupUpdateRequest represents a request to update a category group
 type CategoryGroupUpdateRequest struct {
-	Name              string `json:"name"`
-	Description       string `json:"description"`
-	ExclusivityPoolID *int   `json:"exclusivity_pool_id"`
-	MaxWinsPerCar     *int   `json:"max_wins_per_car"`
-	DisplayOrder      int    `json:"display_order"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	ExclusivityPoolID   *int   `json:"exclusivity_pool_id"`
+	ExclusivityPoolName string `json:"exclusivity_pool_name"` // Assigns the pool by name instead of ID
+	MaxWinsPerCar       *int   `json:"max_wins_per_car"`
+	MultiWinStrategy    string `json:"multi_win_strategy"`
+	DisplayOrder        int    `json:"display_order"`
+}
+
+// ExclusivityPoolRequest represents a request to create or rename an exclusivity pool
+type ExclusivityPoolRequest struct {
+	Name string `json:"name"`
 }
 
 // VotingStatusRequest represents a request to set voting open/closed
@@ -43,6 +84,19 @@ type VotingStatusRequest struct {
 	Open bool `json:"open"`
 }
 
+// FinalizeVotingRequest is the optional body for finalizing voting. Embargo
+// hides the frozen results until revealed with RevealCode.
+type FinalizeVotingRequest struct {
+	Embargo    bool   `json:"embargo"`
+	RevealCode string `json:"reveal_code"`
+}
+
+// RevealResultsRequest is the optional body for lifting the results embargo,
+// for clients that do not send the reveal code in the X-Reveal header
+type RevealResultsRequest struct {
+	RevealCode string `json:"reveal_code"`
+}
+
 // VotingTimerRequest represents a request to start a voting timer
 type VotingTimerRequest struct {
 	Minutes int `json:"minutes"`
@@ -53,6 +107,19 @@ type DerbyNetSyncRequest struct {
 	DerbyNetURL string `json:"derbynet_url"`
 }
 
+// DerbyNetTestRequest represents a request to test a DerbyNet connection.
+// An empty fallback URL uses the derbynet_url_fallback setting.
+type DerbyNetTestRequest struct {
+	DerbyNetURL         string `json:"derbynet_url"`
+	DerbyNetURLFallback string `json:"derbynet_url_fallback"`
+}
+
+// ResultsPushRequest represents a request to push results to DerbyNet
+type ResultsPushRequest struct {
+	DerbyNetURL string `json:"derbynet_url"`
+	DryRun      bool   `json:"dry_run"` // Check what would be pushed without sending anything
+}
+
 // QRCodeGenerateRequest represents a request to generate QR codes
 type QRCodeGenerateRequest struct {
 	Count int `json:"count"`
@@ -60,13 +127,38 @@ type QRCodeGenerateRequest struct {
 
 // SettingsUpdateRequest represents a request to update settings
 type SettingsUpdateRequest struct {
-	DerbyNetURL         string   `json:"derbynet_url"`
-	BaseURL             string   `json:"base_url"`
-	DerbyNetRole        string   `json:"derbynet_role"`
-	DerbyNetPassword    string   `json:"derbynet_password"`
-	RequireRegisteredQR *bool    `json:"require_registered_qr"`
-	VotingInstructions  string   `json:"voting_instructions"`
-	VoterTypes          []string `json:"voter_types"`
+	DerbyNetURL              string            `json:"derbynet_url"`
+	DerbyNetURLFallback      *string           `json:"derbynet_url_fallback"`
+	BaseURL                  string            `json:"base_url"`
+	DerbyNetRole             string            `json:"derbynet_role"`
+	DerbyNetPassword         string            `json:"derbynet_password"`
+	RequireRegisteredQR      *bool             `json:"require_registered_qr"`
+	OpenVotingOnePerDevice   *bool             `json:"open_voting_one_per_device"`
+	PublicResultsEnabled     *bool             `json:"public_results_enabled"`
+	DerbyNetHealthPolling    *bool             `json:"derbynet_health_polling"`
+	AnonymizeBallots         *bool             `json:"anonymize_ballots"`
+	RequireCompleteBallot    *bool             `json:"require_complete_ballot"`
+	AllowVoteChanges         *bool             `json:"allow_vote_changes"`
+	MaintenanceMessage       *string           `json:"maintenance_message"`
+	PostVoteRedirectURL      *string           `json:"post_vote_redirect_url"`
+	CarNumberFormat          string            `json:"car_number_format"`
+	VotingInstructions       string            `json:"voting_instructions"`
+	VotingInstructionsByType map[string]string `json:"voting_instructions_by_type"`
+	VoterTypes               []string          `json:"voter_types"`
+	EventName                string            `json:"event_name"`
+	LogoURL                  *string           `json:"logo_url"`
+	ThemeColor               string            `json:"theme_color"`
+	Timezone                 string            `json:"timezone"`
+	MaxVotingMinutes         int               `json:"max_voting_minutes"`
+	VotingTimerPresets       []int             `json:"voting_timer_presets"`
+	TieMargin                *int              `json:"tie_margin"`
+	AutoCloseIdleMinutes     *int              `json:"auto_close_idle_minutes"`
+}
+
+// SettingsImportRequest represents a request to import exported settings
+type SettingsImportRequest struct {
+	Settings         map[string]string `json:"settings"`
+	IncludeSensitive bool              `json:"include_sensitive"`
 }
 
 // DatabaseResetRequest represents a request to reset database tables
@@ -74,24 +166,48 @@ type DatabaseResetRequest struct {
 	Tables []string `json:"tables"`
 }
 
+// NewEventRequest represents a request to clear event data for a new event
+type NewEventRequest struct {
+	Confirm bool `json:"confirm"`
+}
+
 // VoterCreateRequest represents a request to create a voter
 type VoterCreateRequest struct {
-	CarID     *int   `json:"car_id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	VoterType string `json:"voter_type"`
-	QRCode    string `json:"qr_code"`
-	Notes     string `json:"notes"`
+	CarID      *int   `json:"car_id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	VoterType  string `json:"voter_type"`
+	VoterGroup string `json:"voter_group"`
+	QRCode     string `json:"qr_code"`
+	Notes      string `json:"notes"`
+	IsTest     bool   `json:"is_test"`
 }
 
 // VoterUpdateRequest represents a request to update a voter
 type VoterUpdateRequest struct {
-	ID        int    `json:"id"`
-	CarID     *int   `json:"car_id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	VoterType string `json:"voter_type"`
-	Notes     string `json:"notes"`
+	ID         int    `json:"id"`
+	CarID      *int   `json:"car_id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	VoterType  string `json:"voter_type"`
+	VoterGroup string `json:"voter_group"`
+	Notes      string `json:"notes"`
+	IsTest     bool   `json:"is_test"`
+}
+
+// VoterBulkDeleteRequest represents a request to delete all voters matching a filter
+type VoterBulkDeleteRequest struct {
+	VoterType  string `json:"voter_type"`
+	HasVoted   *bool  `json:"has_voted"`
+	Confirm    bool   `json:"confirm"`
+	ConfirmAll bool   `json:"confirm_all"`
+}
+
+// VoteVoidRequest represents a request to void a vote as invalid
+type VoteVoidRequest struct {
+	VoterID    int    `json:"voter_id"`
+	CategoryID int    `json:"category_id"`
+	Reason     string `json:"reason"`
 }
 
 // VoteSubmitRequest represents a request to submit a vote
@@ -99,6 +215,25 @@ type VoteSubmitRequest struct {
 	VoterQR    string `json:"voter_qr"`
 	CategoryID int    `json:"category_id"`
 	CarID      int    `json:"car_id"`
+	WriteIn    string `json:"write_in,omitempty"` // Free-text car name when car_id is 0
+	Replace    bool   `json:"replace"`
+}
+
+// AssistedVoteRequest represents a vote an admin records on a voter's behalf
+type AssistedVoteRequest struct {
+	CategoryID int    `json:"category_id"`
+	CarID      int    `json:"car_id"`
+	WriteIn    string `json:"write_in,omitempty"` // Free-text car name when car_id is 0
+	Replace    bool   `json:"replace"`
+	Reason     string `json:"reason,omitempty"` // Note for the audit log, such as who helped
+}
+
+// BallotSubmitRequest represents a request to submit several votes at once.
+// Votes maps category ID to car ID.
+type BallotSubmitRequest struct {
+	Votes        map[int]int `json:"votes"`
+	Replace      bool        `json:"replace"`
+	AllOrNothing bool        `json:"all_or_nothing"`
 }
 
 // SeedMockDataRequest represents a request to seed mock data
@@ -129,3 +264,12 @@ type CarEligibilityRequest struct {
 	Eligible bool `json:"eligible"`
 	Force    bool `json:"force"`
 }
+
+// CarBulkEligibilityRequest represents a request to set the eligibility of
+// every car of a rank, or of a list of cars
+type CarBulkEligibilityRequest struct {
+	Rank     string `json:"rank"`
+	CarIDs   []int  `json:"car_ids"`
+	Eligible *bool  `json:"eligible"`
+	Force    bool   `json:"force"`
+}
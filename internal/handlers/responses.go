@@ -1,5 +1,10 @@
 package handlers
 
+import (
+	"github.com/abrezinsky/derbyvote/internal/models"
+	"github.com/abrezinsky/derbyvote/internal/services"
+)
+
 // CategoryResponse is the JSON response for category operations
 type CategoryResponse struct {
 	ID                int64    `json:"id"`
@@ -9,6 +14,42 @@ type CategoryResponse struct {
 	Active            bool     `json:"active"`
 	AllowedVoterTypes []string `json:"allowed_voter_types,omitempty"`
 	AllowedRanks      []string `json:"allowed_ranks,omitempty"`
+	Tags              []string `json:"tags,omitempty"`
+	AllowWriteIn      bool     `json:"allow_write_in"`
+	ShowLiveCounts    bool     `json:"show_live_counts"`
+	BannerURL         string   `json:"banner_url,omitempty"`
+}
+
+// CarDerbyNetRacerResponse is the response for linking a car to a DerbyNet racer
+type CarDerbyNetRacerResponse struct {
+	ID              int  `json:"id"`
+	DerbyNetRacerID *int `json:"derbynet_racer_id"`
+}
+
+// CategoryDerbyNetAwardResponse is the response for mapping a category to a DerbyNet award
+type CategoryDerbyNetAwardResponse struct {
+	ID              int  `json:"id"`
+	DerbyNetAwardID *int `json:"derbynet_award_id"`
+}
+
+// CategoryBannerResponse is the response for uploading a category banner
+type CategoryBannerResponse struct {
+	ID        int    `json:"id"`
+	BannerURL string `json:"banner_url"`
+}
+
+// CategoryCarsResponse is the response for setting a category's car subset
+type CategoryCarsResponse struct {
+	ID     int   `json:"id"`
+	CarIDs []int `json:"car_ids"`
+}
+
+// CategoryGroupAssignResponse is the response for moving a category to another
+// group, with what the move means for existing votes and winners
+type CategoryGroupAssignResponse struct {
+	Category             map[string]interface{}      `json:"category"`
+	ExclusivityConflicts int                         `json:"exclusivity_conflicts"` // Voters now holding the same car in two pool categories
+	MultiWins            []services.MultiWinConflict `json:"multi_wins"`            // Cars over max_wins_per_car that involve this category
 }
 
 // CategoryGroupResponse is the response for category group operations
@@ -21,12 +62,82 @@ type VotingStatusResponse struct {
 	Open bool `json:"open"`
 }
 
+// MaintenanceResponse reports whether voter pages are paused for maintenance
+type MaintenanceResponse struct {
+	Active  bool   `json:"active"`
+	Message string `json:"message,omitempty"`
+}
+
+// FinalizeVotingResponse is the response when voting is closed and results
+// frozen. Snapshot is null when the results were embargoed.
+type FinalizeVotingResponse struct {
+	VotingOpen bool                      `json:"voting_open"`
+	Embargoed  bool                      `json:"embargoed"`
+	Snapshot   *services.ResultsSnapshot `json:"snapshot"`
+}
+
+// RevealResultsResponse is the response when the results embargo is lifted
+type RevealResultsResponse struct {
+	Embargoed bool `json:"embargoed"`
+}
+
+// FinalizeConflictResponse is the 409 body when conflicts keep results from being frozen
+type FinalizeConflictResponse struct {
+	Error *APIError `json:"error"`
+	ConflictsResponse
+}
+
+// CategoryResultsResponse is the response for a single category's results,
+// with the conflicts that involve that category
+type CategoryResultsResponse struct {
+	services.CategoryResult
+	HasConflicts bool              `json:"has_conflicts"`
+	Conflicts    ConflictsResponse `json:"conflicts"`
+}
+
 // VotingTimerResponse is the response for setting a voting timer
 type VotingTimerResponse struct {
 	CloseTime string `json:"close_time"`
 	Minutes   int    `json:"minutes"`
 }
 
+// ClockResponse is the voting countdown polled by projector pages
+type ClockResponse struct {
+	Open             bool   `json:"open"`
+	SecondsRemaining int    `json:"seconds_remaining"`
+	ServerTime       string `json:"server_time"`
+}
+
+// VotingTimerPresetsResponse lists the quick-pick timer durations and the longest timer allowed
+type VotingTimerPresetsResponse struct {
+	Presets    []int `json:"presets"`
+	MaxMinutes int   `json:"max_minutes"`
+}
+
+// DerbyNetCallResult reports one DerbyNet call made during a connection test.
+// HTTPStatus is 0 when no response was received.
+type DerbyNetCallResult struct {
+	Query      string `json:"query"`
+	LatencyMS  int64  `json:"latency_ms"`
+	HTTPStatus int    `json:"http_status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// DerbyNetTestResponse is the response for a DerbyNet connection test
+type DerbyNetTestResponse struct {
+	Status        string             `json:"status"`
+	TotalRacers   int                `json:"total_racers"`
+	TotalAwards   int                `json:"total_awards"`
+	Authenticated bool               `json:"authenticated"`
+	Role          string             `json:"role"`
+	ActiveURL     string             `json:"active_url"`           // the URL that answered, which may be the fallback
+	AuthError     string             `json:"auth_error,omitempty"` // why the configured credentials were refused
+	RacerList     DerbyNetCallResult `json:"racer_list"`
+	AwardList     DerbyNetCallResult `json:"award_list"`
+	SessionCookie bool               `json:"session_cookie"`
+	Server        string             `json:"server,omitempty"` // Server header reported by DerbyNet's web server
+}
+
 // QRCodesResponse is the response for QR code generation
 type QRCodesResponse struct {
 	QRCodes []string `json:"qr_codes"`
@@ -34,23 +145,78 @@ type QRCodesResponse struct {
 
 // SettingsResponse is the response for settings
 type SettingsResponse struct {
-	DerbyNetURL         string   `json:"derbynet_url"`
-	BaseURL             string   `json:"base_url"`
-	DerbyNetRole        string   `json:"derbynet_role,omitempty"`
-	RequireRegisteredQR bool     `json:"require_registered_qr"`
-	VotingInstructions  string   `json:"voting_instructions,omitempty"`
-	VoterTypes          []string `json:"voter_types,omitempty"`
+	DerbyNetURL              string            `json:"derbynet_url"`
+	DerbyNetURLFallback      string            `json:"derbynet_url_fallback,omitempty"`
+	BaseURL                  string            `json:"base_url"`
+	DerbyNetRole             string            `json:"derbynet_role,omitempty"`
+	RequireRegisteredQR      bool              `json:"require_registered_qr"`
+	OpenVotingOnePerDevice   bool              `json:"open_voting_one_per_device"`
+	PublicResultsEnabled     bool              `json:"public_results_enabled"`
+	DerbyNetHealthPolling    bool              `json:"derbynet_health_polling"`
+	AnonymizeBallots         bool              `json:"anonymize_ballots"`
+	RequireCompleteBallot    bool              `json:"require_complete_ballot"`
+	AllowVoteChanges         bool              `json:"allow_vote_changes"`
+	MaintenanceMessage       string            `json:"maintenance_message"`
+	PostVoteRedirectURL      string            `json:"post_vote_redirect_url,omitempty"`
+	CarNumberFormat          string            `json:"car_number_format"`
+	VotingInstructions       string            `json:"voting_instructions,omitempty"`
+	VotingInstructionsByType map[string]string `json:"voting_instructions_by_type"`
+	VoterTypes               []string          `json:"voter_types,omitempty"`
+	EventName                string            `json:"event_name,omitempty"`
+	LogoURL                  string            `json:"logo_url,omitempty"`
+	ThemeColor               string            `json:"theme_color,omitempty"`
+	Timezone                 string            `json:"timezone,omitempty"`
+	MaxVotingMinutes         int               `json:"max_voting_minutes"`
+	VotingTimerPresets       []int             `json:"voting_timer_presets"`
+	TieMargin                int               `json:"tie_margin"`
+	AutoCloseIdleMinutes     int               `json:"auto_close_idle_minutes"`
 }
 
 // VoterResponse is the response for voter operations
 type VoterResponse struct {
-	ID        int64  `json:"id"`
-	CarID     *int   `json:"car_id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	VoterType string `json:"voter_type"`
-	QRCode    string `json:"qr_code"`
-	Notes     string `json:"notes"`
+	ID         int64  `json:"id"`
+	CarID      *int   `json:"car_id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	VoterType  string `json:"voter_type"`
+	VoterGroup string `json:"voter_group,omitempty"`
+	QRCode     string `json:"qr_code"`
+	Notes      string `json:"notes"`
+	IsTest     bool   `json:"is_test"`
+}
+
+// NewEventResponse is the response for starting a new event
+type NewEventResponse struct {
+	Cleared    map[string]int64 `json:"cleared"`
+	VotingOpen bool             `json:"voting_open"`
+}
+
+// CarBulkEligibilityResponse is the response for a bulk car eligibility change
+type CarBulkEligibilityResponse struct {
+	Eligible bool  `json:"eligible"`
+	Matched  int   `json:"matched"`
+	Updated  int64 `json:"updated"`
+}
+
+// VoterBulkDeleteResponse is the response for bulk voter deletion
+type VoterBulkDeleteResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// VoterVotesClearedResponse is the response for clearing a voter's votes
+type VoterVotesClearedResponse struct {
+	VoterID int   `json:"voter_id"`
+	Cleared int64 `json:"cleared"`
+}
+
+// VoteVoidResponse is the response for voiding a vote, with the category's
+// updated tally. Category is nil if the category is no longer active or
+// results are embargoed.
+type VoteVoidResponse struct {
+	VoterID    int                      `json:"voter_id"`
+	CategoryID int                      `json:"category_id"`
+	CarID      int                      `json:"car_id"`
+	Category   *services.CategoryResult `json:"category"`
 }
 
 // CarResponse is the response for car operations
@@ -63,17 +229,27 @@ type CarResponse struct {
 	Rank      string `json:"rank"`
 }
 
-// ConflictsResponse is the response for the conflicts detection endpoint
+// CarNumberConflictResponse is the 409 body when a car number matches an existing car
+type CarNumberConflictResponse struct {
+	Error          *APIError   `json:"error"`
+	ConflictingCar CarResponse `json:"conflicting_car"`
+}
+
+// ConflictsResponse is the response for the conflicts detection endpoint.
+// Near ties are warnings and do not block finalizing or pushing results.
 type ConflictsResponse struct {
 	Ties      []TieConflictResponse      `json:"ties"`
+	NearTies  []TieConflictResponse      `json:"near_ties"`
 	MultiWins []MultiWinConflictResponse `json:"multi_wins"`
 }
 
-// TieConflictResponse represents a category with tied vote counts
+// TieConflictResponse represents a category with tied vote counts; for a near
+// tie, Margin is the leader's lead over the runner-up
 type TieConflictResponse struct {
 	CategoryID   int              `json:"category_id"`
 	CategoryName string           `json:"category_name"`
 	TiedCars     []TiedCarResponse `json:"tied_cars"`
+	Margin       int              `json:"margin,omitempty"`
 }
 
 // TiedCarResponse represents a car in a tie
@@ -97,6 +273,17 @@ type MultiWinConflictResponse struct {
 	MaxWinsPerCar int      `json:"max_wins_per_car"`
 }
 
+// ExclusivityPoolResponse is the response for creating an exclusivity pool
+type ExclusivityPoolResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// RandomTieBreakRequest is the request body for breaking a tie by random draw
+type RandomTieBreakRequest struct {
+	CategoryID int `json:"category_id"`
+}
+
 // OverrideWinnerRequest is the request body for setting a manual winner
 type OverrideWinnerRequest struct {
 	CategoryID int    `json:"category_id"`
@@ -114,3 +301,37 @@ type OverrideResponse struct {
 	OverrideReason      string `json:"override_reason,omitempty"`
 	OverriddenAt        string `json:"overridden_at,omitempty"`
 }
+
+// EventReportResponse is the end-of-event summary from GET /api/admin/report
+type EventReportResponse struct {
+	EventName   string                     `json:"event_name"`
+	GeneratedAt string                     `json:"generated_at"`
+	Stats       map[string]interface{}     `json:"stats"`
+	Turnout     []models.VoterGroupTurnout `json:"turnout"`
+	Categories  []EventReportCategory      `json:"categories"`
+	Overrides   []OverrideResponse         `json:"overrides"`
+}
+
+// EventReportCategory is one category's vote total and final winner in the event report
+type EventReportCategory struct {
+	CategoryID   int                    `json:"category_id"`
+	CategoryName string                 `json:"category_name"`
+	GroupName    string                 `json:"group_name,omitempty"`
+	TotalVotes   int                    `json:"total_votes"`
+	Winner       map[string]interface{} `json:"winner,omitempty"` // Nil when no car received a vote
+}
+
+// SeedMockDataResponse is the response for seeding mock data
+type SeedMockDataResponse struct {
+	Message      string   `json:"message"`
+	Created      int      `json:"created"`
+	Cleared      []string `json:"cleared,omitempty"`
+	VotingClosed bool     `json:"voting_closed,omitempty"` // Voting was open and clearing closed it
+}
+
+// SeedMockDataPreviewResponse lists the demo data a seed would create
+type SeedMockDataPreviewResponse struct {
+	Categories []services.MockCategory `json:"categories,omitempty"`
+	Cars       []services.MockCar      `json:"cars,omitempty"`
+	Voters     []services.MockVoter    `json:"voters,omitempty"`
+}
@@ -1,22 +1,83 @@
 package handlers
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/abrezinsky/derbyvote/internal/auth"
+	"github.com/abrezinsky/derbyvote/internal/logger"
 )
 
-// conditionalHTTPLogger only logs HTTP requests when HTTP logging is enabled
+// requestIDHeader carries the request ID in both directions
+const requestIDHeader = "X-Request-Id"
+
+// validRequestID limits which incoming request IDs are trusted, so they are safe to log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// requestID tags each request with an ID, reusing a well-formed incoming
+// X-Request-Id or generating one. The ID is echoed in the response header
+// and stored in the context so that log lines and the HTTP request log
+// for the request include it.
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+
+		ctx := logger.WithRequestID(r.Context(), id)
+		ctx = context.WithValue(ctx, middleware.RequestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// newRequestID returns a random 16-character hex ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b) // crypto/rand.Read never returns an error
+	return hex.EncodeToString(b)
+}
+
+// conditionalHTTPLogger only logs HTTP requests when HTTP logging is enabled.
+// Each request is logged in one line with its status, response size and
+// duration; static asset requests are logged at debug level.
 func (h *Handlers) conditionalHTTPLogger(next http.Handler) http.Handler {
-	logger := middleware.Logger(next)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if h.Log != nil && h.Log.IsHTTPLoggingEnabled() {
-			logger.ServeHTTP(w, r)
-		} else {
+		if h.Log == nil || !h.Log.IsHTTPLoggingEnabled() {
 			next.ServeHTTP(w, r)
+			return
 		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+		defer func() {
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK // Nothing written, net/http sends 200
+			}
+			args := []any{
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"duration", time.Since(start),
+			}
+			if strings.HasPrefix(r.URL.Path, "/static/") {
+				h.Log.DebugContext(r.Context(), "HTTP request", args...)
+			} else {
+				h.Log.InfoContext(r.Context(), "HTTP request", args...)
+			}
+		}()
+		next.ServeHTTP(ww, r)
 	})
 }
 
@@ -24,13 +85,18 @@ func (h *Handlers) conditionalHTTPLogger(next http.Handler) http.Handler {
 func (h *Handlers) Router() chi.Router {
 	r := chi.NewRouter()
 
-	// Middleware
-	r.Use(middleware.RequestID)
+	// Middleware. Routes that show vote counts or winners also use
+	// requireReveal, which hides them while results are embargoed.
+	r.Use(requestID)
+	r.Use(auth.RecordPeerAddr) // Before RealIP rewrites RemoteAddr from proxy headers
 	r.Use(middleware.RealIP)
 	r.Use(h.conditionalHTTPLogger) // Custom conditional HTTP logger
 	r.Use(middleware.Recoverer)
+	r.Use(h.compressResponses)
+	r.Use(h.limitBody)
+	r.Use(h.rejectWrites)
 	r.Use(middleware.RedirectSlashes)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(h.timeoutRequests)
 
 	// Static files (served from embedded filesystem)
 	r.Handle("/static/*", http.StripPrefix("/static/", h.staticServer))
@@ -41,17 +107,39 @@ func (h *Handlers) Router() chi.Router {
 	// WebSocket
 	r.Get("/ws", h.Hub.ServeWs)
 
-	// Voting pages (public)
-	r.Get("/vote/new", h.handleGenerateVoteCode) // Must come before /vote/{qrCode}
-	r.Get("/vote/{qrCode}", h.handleVotePage)
+	// Voter pages and API (public, paused in maintenance mode)
+	r.Group(func(r chi.Router) {
+		r.Use(h.pauseForMaintenance)
+		r.Get("/vote/new", h.handleGenerateVoteCode) // Must come before /vote/{qrCode}
+		r.Get("/vote/{qrCode}", h.handleVotePage)
+		r.Get("/v/{token}", h.handleShortLink)
+		r.Get("/api/vote-data/{qrCode}", h.handleGetVoteData)
+		r.Get("/api/voter/validate", h.handleValidateVoter)
+		r.Get("/api/voter/{qrCode}/votes", h.handleGetVoterVotes)
+		r.Get("/api/voter/{qrCode}/instructions", h.handleGetVoterInstructions)
+		r.Post("/api/vote", h.handleSubmitVote)
+		r.Post("/api/voter/{qrCode}/ballot", h.handleSubmitBallot)
+	})
 
-	// Voting API (public)
-	r.Get("/api/vote-data/{qrCode}", h.handleGetVoteData)
-	r.Post("/api/vote", h.handleSubmitVote)
+	// Public API
+	r.Get("/api/categories", h.handleGetPublicCategories)
+	r.With(h.requireReveal).Get("/api/results/public", h.handleGetPublicResults)
+	r.Get("/api/branding", h.handleGetBranding)
+	r.Get("/api/maintenance", h.handleGetMaintenance)
+	r.Get("/api/clock", h.handleGetClock)
+
+	// API description (public)
+	r.Get("/api/openapi.json", h.handleOpenAPISpec)
 
 	// Car photo proxy (public)
 	r.Get("/cars/{id}/photo", h.handleCarPhoto)
 
+	// Branding logo (public)
+	r.Get("/branding/logo", h.handleBrandingLogo)
+
+	// Category banners (public)
+	r.Get("/categories/{id}/banner", h.handleCategoryBanner)
+
 	// Auth routes (public)
 	r.Get("/admin/login", h.handleLoginPage)
 	r.Post("/admin/login", h.handleLogin)
@@ -75,8 +163,15 @@ func (h *Handlers) Router() chi.Router {
 		// Categories
 		r.Get("/api/admin/categories", h.handleGetCategories)
 		r.Post("/api/admin/categories", h.handleCreateCategory)
+		r.Post("/api/admin/categories/import", h.handleImportCategories)
 		r.Put("/api/admin/categories/{id}", h.handleUpdateCategory)
+		r.Put("/api/admin/categories/{id}/derbynet-award", h.handleSetCategoryDerbyNetAward)
+		r.Put("/api/admin/categories/{id}/cars", h.handleSetCategoryCars)
+		r.Put("/api/admin/categories/{id}/group", h.handleSetCategoryGroup)
+		r.Post("/api/admin/categories/{id}/banner", h.handleUploadCategoryBanner)
 		r.Delete("/api/admin/categories/{id}", h.handleDeleteCategory)
+		r.With(h.requireReveal).Get("/api/admin/categories/{id}/ballots.csv", h.handleExportCategoryBallots)
+		r.With(h.requireReveal).Get("/api/admin/categories/{id}/votes", h.handleGetCategoryVotes)
 
 		// Category Groups
 		r.Get("/api/admin/category-groups", h.handleGetCategoryGroups)
@@ -85,51 +180,93 @@ func (h *Handlers) Router() chi.Router {
 		r.Put("/api/admin/category-groups/{id}", h.handleUpdateCategoryGroup)
 		r.Delete("/api/admin/category-groups/{id}", h.handleDeleteCategoryGroup)
 
+		// Exclusivity Pools
+		r.Get("/api/admin/exclusivity-pools", h.handleGetExclusivityPools)
+		r.Post("/api/admin/exclusivity-pools", h.handleCreateExclusivityPool)
+		r.Put("/api/admin/exclusivity-pools/{id}", h.handleUpdateExclusivityPool)
+		r.Delete("/api/admin/exclusivity-pools/{id}", h.handleDeleteExclusivityPool)
+
 		// Voting Control
 		r.Post("/api/admin/voting-control", h.handleSetVotingStatus)
 		r.Post("/api/admin/voting-timer", h.handleSetVotingTimer)
+		r.Get("/api/admin/voting-timer/presets", h.handleGetVotingTimerPresets)
+		r.Post("/api/admin/voting/finalize", h.handleFinalizeVoting)
 
 		// Stats & Results
 		r.Get("/api/admin/stats", h.handleGetStats)
-		r.Get("/api/admin/results", h.handleGetResults)
-		r.Get("/api/admin/results/conflicts", h.handleGetConflicts)
-		r.Get("/api/admin/results/overrides", h.handleGetOverrides)
+		r.Get("/api/admin/stats/voter-groups", h.handleGetVoterGroupStats)
+		r.With(h.requireReveal).Get("/api/admin/stats/top-cars", h.handleGetTopCars)
+		r.With(h.requireReveal).Get("/api/admin/results", h.handleGetResults)
+		r.With(h.requireReveal).Get("/api/admin/results/conflicts", h.handleGetConflicts)
+		r.With(h.requireReveal).Get("/api/admin/results/resolution-status", h.handleGetResolutionStatus)
+		r.With(h.requireReveal).Get("/api/admin/results/snapshot", h.handleGetResultsSnapshot)
+		r.With(h.requireReveal).Get("/api/admin/results/verify", h.handleVerifyResults)
+		r.With(h.requireReveal).Get("/api/admin/results/overrides", h.handleGetOverrides)
+		r.With(h.requireReveal).Get("/api/admin/results/{categoryID}", h.handleGetCategoryResults)
 		r.Post("/api/admin/results/override-winner", h.handleOverrideWinner)
+		r.With(h.requireReveal).Post("/api/admin/results/random-tiebreak", h.handleRandomTieBreak)
 		r.Delete("/api/admin/results/override-winner/{categoryID}", h.handleClearOverride)
+		r.Post("/api/admin/results/reveal", h.handleRevealResults)
+		r.With(h.requireReveal).Get("/api/admin/report", h.handleGetEventReport)
 
 		// DerbyNet
 		r.Post("/api/admin/sync-derbynet", h.handleSyncDerbyNet)
+		r.Post("/api/admin/sync-voters-derbynet", h.handleSyncVotersDerbyNet)
 		r.Post("/api/admin/sync-categories-derbynet", h.handleSyncCategoriesDerbyNet)
-		r.Post("/api/admin/push-results-derbynet", h.handlePushResultsDerbyNet)
+		r.With(h.requireReveal).Post("/api/admin/push-results-derbynet", h.handlePushResultsDerbyNet)
 		r.Post("/api/admin/test-derbynet", h.handleTestDerbyNet)
+		r.Get("/api/admin/derbynet/status", h.handleGetDerbyNetStatus)
+		r.Get("/api/admin/derbynet/awards", h.handleGetDerbyNetAwards)
+		r.Get("/api/admin/derbynet/categories-diff", h.handleGetDerbyNetCategoryDiff)
+		r.Get("/api/admin/derbynet/racers", h.handleGetDerbyNetRacers)
+		r.Get("/api/admin/derbynet/push-history", h.handleGetPushHistory)
+		r.With(h.requireReveal).Get("/api/admin/derbynet/push-readiness", h.handleGetPushReadiness)
 
 		// QR Codes
 		r.Post("/api/admin/generate-qr", h.handleGenerateQRCodes)
 		r.Get("/api/admin/voters/{id}/qr", h.handleGetQRImage)
 		r.Get("/api/admin/open-voting-qr", h.handleGetOpenVotingQR)
+		r.Get("/api/admin/categories/{id}/qr.png", h.handleGetCategoryQR)
 
 		// Settings
 		r.Get("/api/admin/settings", h.handleGetSettings)
 		r.Post("/api/admin/settings", h.handleUpdateSettings)
 		r.Put("/api/admin/settings", h.handleUpdateSettings)
+		r.Get("/api/admin/settings/export", h.handleExportSettings)
+		r.Post("/api/admin/settings/import", h.handleImportSettings)
 		r.Get("/api/admin/voter-types", h.handleGetVoterTypes)
+		r.Post("/api/admin/branding/logo", h.handleUploadLogo)
 
 		// Database Management
 		r.Post("/api/admin/reset-database", h.handleResetDatabase)
+		r.Post("/api/admin/new-event", h.handleNewEvent)
+		r.With(h.requireReveal).Get("/api/admin/votes/export", h.handleExportVotes)
+		r.Post("/api/admin/votes/void", h.handleVoidVote)
+		r.Get("/api/admin/audit-log", h.handleGetAuditLog)
+		r.Post("/api/admin/merge-votes", h.handleMergeVotes)
 		r.Post("/api/admin/seed-mock-data", h.handleSeedMockData)
+		r.Post("/api/admin/seed-mock-data/preview", h.handlePreviewMockData)
 
 		// Voters
 		r.Get("/api/admin/voters", h.handleGetVoters)
+		r.Get("/api/admin/voters/stale", h.handleGetStaleVoters)
 		r.Post("/api/admin/voters", h.handleCreateVoter)
 		r.Put("/api/admin/voters", h.handleUpdateVoter)
+		r.Post("/api/admin/voters/bulk-delete", h.handleBulkDeleteVoters)
+		r.Post("/api/admin/voters/clear-test", h.handleClearTestVoters)
 		r.Delete("/api/admin/voters/{id}", h.handleDeleteVoter)
+		r.Delete("/api/admin/voters/{id}/votes", h.handleClearVoterVotes)
+		r.Post("/api/admin/voters/{id}/vote", h.handleAssistedVote)
 
 		// Cars
 		r.Get("/api/admin/cars", h.handleGetCars)
 		r.Get("/api/admin/cars/{id}", h.handleGetCar)
+		r.With(h.requireReveal).Get("/api/admin/cars/{id}/results", h.handleGetCarResults)
 		r.Post("/api/admin/cars", h.handleCreateCar)
 		r.Put("/api/admin/cars/{id}", h.handleUpdateCar)
+		r.Post("/api/admin/cars/eligibility", h.handleBulkSetCarEligibility)
 		r.Put("/api/admin/cars/{id}/eligibility", h.handleSetCarEligibility)
+		r.Put("/api/admin/cars/{id}/derbynet-racer", h.handleSetCarDerbyNetRacer)
 		r.Delete("/api/admin/cars/{id}", h.handleDeleteCar)
 	})
 
@@ -24,7 +24,7 @@ func setupHandlersWithTemplates(t *testing.T) (*handlers.Handlers, *http.Cookie)
 	// Create test templates
 	templatesFS := fstest.MapFS{
 		"index.html":             &fstest.MapFile{Data: []byte(`<html><body><h1>Index Page</h1></body></html>`)},
-		"voter/vote.html":        &fstest.MapFile{Data: []byte(`<html><body><h1>Vote Page</h1></body></html>`)},
+		"voter/vote.html":        &fstest.MapFile{Data: []byte(`<html><body><h1>Vote Page</h1><p>{{.Branding.EventName}} {{.QRCode}}</p></body></html>`)},
 		"admin/login.html":       &fstest.MapFile{Data: []byte(`<html><body><h1>Login Page</h1></body></html>`)},
 		"admin/layout.html":      &fstest.MapFile{Data: []byte(`{{define "admin"}}<html><body><h1>{{.PageTitle}}</h1>{{template "content" .}}</body></html>{{end}}`),
 		},
@@ -247,4 +247,7 @@ func TestHandleVotePage(t *testing.T) {
 	if !strings.Contains(body, "Vote Page") {
 		t.Error("expected vote page content")
 	}
+	if !strings.Contains(body, services.DefaultEventName+" TEST-QR") {
+		t.Errorf("expected default branding and QR code, got %q", body)
+	}
 }
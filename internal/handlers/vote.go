@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/abrezinsky/derbyvote/internal/models"
+	"github.com/abrezinsky/derbyvote/internal/services"
+	"github.com/abrezinsky/derbyvote/web"
 )
 
 // Stock placeholder image (simple gray SVG)
@@ -19,51 +24,251 @@ var stockPhotoSVG = []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="200"
 func (h *Handlers) handleVotePage(w http.ResponseWriter, r *http.Request) {
 	qrCode := chi.URLParam(r, "qrCode")
 	if qrCode == "" {
-		respondError(w, BadRequest("Invalid QR code"))
+		writeError(w, BadRequest("Invalid QR code"))
 		return
 	}
 
-	data := map[string]string{
-		"QRCode": qrCode,
+	data := PublicPageData{
+		Branding: h.branding(r),
+		QRCode:   qrCode,
 	}
 	h.templates.Vote.Execute(w, data)
 }
 
-// handleGenerateVoteCode generates a unique random code and redirects to the voting page
+// handleShortLink redirects a voter's short link, as printed in QR codes, to
+// their voting page
+func (h *Handlers) handleShortLink(w http.ResponseWriter, r *http.Request) {
+	qrCode, err := h.Voter.ResolveShortToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	http.Redirect(w, r, "/vote/"+url.PathEscape(qrCode), http.StatusFound)
+}
+
+// handleGenerateVoteCode generates a unique random code and redirects to the
+// voting page. A ?category= from a category QR code is passed along so the
+// ballot opens on that category.
 func (h *Handlers) handleGenerateVoteCode(w http.ResponseWriter, r *http.Request) {
+	var focus string
+	if id, err := strconv.Atoi(r.URL.Query().Get("category")); err == nil && id > 0 {
+		focus = "?category=" + strconv.Itoa(id)
+	}
+
+	// A device limited to one ballot goes back to the code it already has
+	if code, ok := h.Voter.DeviceVoterCode(r.Context(), deviceToken(r)); ok {
+		http.Redirect(w, r, "/vote/"+code+focus, http.StatusFound)
+		return
+	}
+
 	// Generate a unique code using the voter service
 	code, err := h.Voter.GenerateUniqueCode(r.Context())
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
+		return
+	}
+	if !h.bindDevice(w, r, code) {
 		return
 	}
 
 	// Redirect to the voting page with the generated code
-	http.Redirect(w, r, "/vote/"+code, http.StatusFound)
+	http.Redirect(w, r, "/vote/"+code+focus, http.StatusFound)
+}
+
+// deviceCookieName holds the signed open-voting code a browser is tied to
+const deviceCookieName = "derbyvote_device"
+
+// deviceCookieMaxAge keeps a device tied to its code well past a single event day
+const deviceCookieMaxAge = 7 * 24 * time.Hour
+
+// deviceToken returns the request's device cookie value, or "" if there is none
+func deviceToken(r *http.Request) string {
+	cookie, err := r.Cookie(deviceCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// bindDevice applies one-per-device open voting to a code before it can
+// create a voter, storing the device cookie when the browser is tied to it.
+// It writes the error and returns false when the device is refused.
+func (h *Handlers) bindDevice(w http.ResponseWriter, r *http.Request, qrCode string) bool {
+	token, err := h.Voter.BindDevice(r.Context(), deviceToken(r), qrCode)
+	if err != nil {
+		writeError(w, err)
+		return false
+	}
+	if token != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     deviceCookieName,
+			Value:    token,
+			Path:     "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+			MaxAge:   int(deviceCookieMaxAge.Seconds()),
+		})
+	}
+	return true
 }
 
 // handleGetVoteData returns vote data for a voter
 func (h *Handlers) handleGetVoteData(w http.ResponseWriter, r *http.Request) {
 	qrCode := chi.URLParam(r, "qrCode")
 	if qrCode == "" {
-		respondError(w, BadRequest("Invalid QR code"))
+		writeError(w, BadRequest("Invalid QR code"))
+		return
+	}
+
+	if !h.bindDevice(w, r, qrCode) {
 		return
 	}
 
 	voteData, err := h.Voting.GetVoteData(r.Context(), qrCode)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
 		return
 	}
 
 	respondOK(w, voteData)
 }
 
+// handleGetVoterVotes returns a voter's current selections and remaining categories
+func (h *Handlers) handleGetVoterVotes(w http.ResponseWriter, r *http.Request) {
+	qrCode := chi.URLParam(r, "qrCode")
+	if qrCode == "" {
+		writeError(w, BadRequest("Invalid QR code"))
+		return
+	}
+
+	summary, err := h.Voting.GetVoterVoteSummary(r.Context(), qrCode)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, summary)
+}
+
+// handleGetVoterInstructions returns the voting instructions for a voter's type
+func (h *Handlers) handleGetVoterInstructions(w http.ResponseWriter, r *http.Request) {
+	qrCode := chi.URLParam(r, "qrCode")
+	if qrCode == "" {
+		writeError(w, BadRequest("Invalid QR code"))
+		return
+	}
+
+	instructions, err := h.Voting.GetVoterInstructions(r.Context(), qrCode)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, instructions)
+}
+
+// handleValidateVoter checks a scanned QR code before the ballot is shown,
+// without creating the voter or casting a vote
+func (h *Handlers) handleValidateVoter(w http.ResponseWriter, r *http.Request) {
+	qrCode := r.URL.Query().Get("qr")
+	if qrCode == "" {
+		writeError(w, BadRequest("Missing qr parameter"))
+		return
+	}
+
+	validation, err := h.Voting.ValidateVoterQR(r.Context(), qrCode)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, validation)
+}
+
+// handleGetPublicCategories returns active categories with the cars eligible in each
+func (h *Handlers) handleGetPublicCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.Voting.ListPublicCategories(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, categories)
+}
+
+// publicResultsETag is resultsETag for the public leaderboard, which differs
+// from the admin results at the same version
+func publicResultsETag(v services.ResultsVersion) string {
+	return fmt.Sprintf(`"public-results-%d-%d"`, v.ModifiedAt.UnixNano(), v.Version)
+}
+
+// handleGetPublicResults returns the public leaderboard of category winners
+func (h *Handlers) handleGetPublicResults(w http.ResponseWriter, r *http.Request) {
+	// Availability is checked first so a client holding results from before
+	// voting reopened gets the error rather than 304
+	if err := h.Results.CheckPublicResults(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+	version := h.Results.Version()
+	if respondNotModified(w, r, publicResultsETag(version), version.ModifiedAt) {
+		return
+	}
+
+	results, err := h.Results.GetPublicResults(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	respondOK(w, results)
+}
+
+// handleGetMaintenance reports whether voter pages are paused, so a waiting
+// device can poll for voting to resume
+func (h *Handlers) handleGetMaintenance(w http.ResponseWriter, r *http.Request) {
+	message, err := h.Settings.MaintenanceMessage(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	respondOK(w, MaintenanceResponse{Active: message != "", Message: message})
+}
+
+// handleGetClock returns the voting countdown. Projectors poll it every
+// second, so it is kept small and must never be served from a cache.
+func (h *Handlers) handleGetClock(w http.ResponseWriter, r *http.Request) {
+	clock, err := h.Settings.GetVotingClock(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	respondOK(w, ClockResponse{
+		Open:             clock.Open,
+		SecondsRemaining: clock.SecondsRemaining,
+		ServerTime:       clock.ServerTime.In(h.location(r)).Format(time.RFC3339),
+	})
+}
+
+// handleOpenAPISpec serves the OpenAPI document for the API
+func (h *Handlers) handleOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(web.GetOpenAPISpec())
+}
+
 // handleSubmitVote handles vote submissions
 func (h *Handlers) handleSubmitVote(w http.ResponseWriter, r *http.Request) {
 	var req VoteSubmitRequest
 	if err := decodeJSON(r, &req); err != nil {
-		respondError(w, err)
+		writeError(w, err)
+		return
+	}
+
+	if !h.bindDevice(w, r, req.VoterQR) {
 		return
 	}
 
@@ -71,10 +276,52 @@ func (h *Handlers) handleSubmitVote(w http.ResponseWriter, r *http.Request) {
 		VoterQR:    req.VoterQR,
 		CategoryID: req.CategoryID,
 		CarID:      req.CarID,
+		WriteIn:    req.WriteIn,
+		Replace:    req.Replace,
 	}
 	result, err := h.Voting.SubmitVote(r.Context(), vote)
 	if err != nil {
-		respondError(w, err)
+		writeError(w, err)
+		return
+	}
+
+	// ?return_tally=true saves a live-count page re-fetching the category
+	if r.URL.Query().Get("return_tally") == "true" {
+		if result.Tally, err = h.Voting.LiveTally(r.Context(), vote.CategoryID); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	respondOK(w, result)
+}
+
+// handleSubmitBallot handles submission of a whole ballot in one request
+func (h *Handlers) handleSubmitBallot(w http.ResponseWriter, r *http.Request) {
+	qrCode := chi.URLParam(r, "qrCode")
+	if qrCode == "" {
+		writeError(w, BadRequest("Invalid QR code"))
+		return
+	}
+
+	var req BallotSubmitRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if !h.bindDevice(w, r, qrCode) {
+		return
+	}
+
+	result, err := h.Voting.SubmitBallot(r.Context(), services.Ballot{
+		VoterQR:      qrCode,
+		Votes:        req.Votes,
+		Replace:      req.Replace,
+		AllOrNothing: req.AllOrNothing,
+	})
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
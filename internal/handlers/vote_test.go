@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -27,7 +29,7 @@ func TestHandleGetVoteData_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test data
-	_, _ = setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	_, _ = setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
 	voterID, _ := setup.repo.CreateVoter(ctx, "TEST-VOTER-QR")
 
@@ -111,12 +113,341 @@ func TestHandleGetVoteData_ServiceError(t *testing.T) {
 	}
 }
 
+func TestHandleGetVoterVotes_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
+	_, _ = setup.repo.CreateCategory(ctx, "Other Category", 2, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	voterID, _ := setup.repo.CreateVoter(ctx, "RESUME-QR")
+	_ = setup.repo.SaveVote(ctx, voterID, int(catID), 1)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/voter/RESUME-QR/votes", nil)
+	rec := httptest.NewRecorder()
+
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response services.VoterVoteSummary
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Selections) != 1 || len(response.Remaining) != 1 {
+		t.Errorf("expected 1 selection and 1 remaining, got %+v", response)
+	}
+}
+
+func TestHandleGetVoterVotes_UnknownQR(t *testing.T) {
+	setup := newTestSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/voter/UNKNOWN-QR/votes", nil)
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+
+	_ = setup.repo.SetSetting(context.Background(), "require_registered_qr", "true")
+
+	req = httptest.NewRequest(http.MethodGet, "/api/voter/UNKNOWN-QR/votes", nil)
+	rec = httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, rec.Code)
+	}
+}
+
+func TestHandleGetVoterVotes_ServiceError(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.repo.DB().Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/voter/ANY-QR/votes", nil)
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 for service error, got %d", rec.Code)
+	}
+}
+
+func TestHandleGetVoterInstructions_ByVoterType(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	_ = setup.repo.SetSetting(ctx, "voting_instructions", "Vote for your favorites")
+	_ = setup.repo.SetSetting(ctx, "voting_instructions_by_type", `{"judge": "Score every category"}`)
+	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Judge", "", "judge", "JUDGE-QR", "")
+
+	tests := []struct {
+		qrCode    string
+		voterType string
+		want      string
+	}{
+		{"JUDGE-QR", "judge", "Score every category"},
+		{"NEW-QR", "general", "Vote for your favorites"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/voter/"+tt.qrCode+"/instructions", nil)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d: %s", tt.qrCode, http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response services.VoterInstructions
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.VoterType != tt.voterType || response.Instructions != tt.want {
+			t.Errorf("%s: expected %s instructions %q, got %+v", tt.qrCode, tt.voterType, tt.want, response)
+		}
+	}
+
+	// Looking up instructions does not register the voter
+	if _, err := setup.repo.GetVoterByQR(ctx, "NEW-QR"); err == nil {
+		t.Error("expected unknown QR code to stay unregistered")
+	}
+}
+
+func TestHandleGetVoterInstructions_UnregisteredQR(t *testing.T) {
+	setup := newTestSetup(t)
+	_ = setup.repo.SetSetting(context.Background(), "require_registered_qr", "true")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/voter/UNKNOWN-QR/instructions", nil)
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, rec.Code)
+	}
+}
+
+func TestHandleValidateVoter(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+	_ = setup.repo.SetSetting(ctx, "require_registered_qr", "true")
+	_, _ = setup.repo.CreateVoterFull(ctx, nil, "Alex Smith", "", "general", "REG-QR", "")
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/api/voter/validate?qr=REG-QR")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var validation services.VoterValidation
+	json.NewDecoder(rec.Body).Decode(&validation)
+	if !validation.Valid || validation.VoterName != "Alex Smith" {
+		t.Errorf("expected valid named voter, got %+v", validation)
+	}
+
+	if rec := get("/api/voter/validate?qr=UNKNOWN-QR"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for unknown QR, got %d", http.StatusNotFound, rec.Code)
+	}
+	if rec := get("/api/voter/validate"); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d without qr, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestHandleGetPublicCategories_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	_, _ = setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/categories", nil)
+	rec := httptest.NewRecorder()
+
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response []map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 {
+		t.Fatalf("expected 1 category, got %d", len(response))
+	}
+	cars, ok := response[0]["cars"].([]interface{})
+	if !ok || len(cars) != 1 {
+		t.Errorf("expected 1 car in category, got %v", response[0]["cars"])
+	}
+}
+
+func TestHandleGetPublicCategories_LiveCounts(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	hiddenID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	liveID, _ := setup.repo.CreateCategory(ctx, "Crowd Favorite", 2, nil, nil, nil, nil)
+	_ = setup.repo.SetCategoryShowLiveCounts(ctx, int(liveID), true)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	_ = setup.repo.CreateCar(ctx, "102", "Racer 2", "Car 2", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	voterID, _ := setup.repo.CreateVoter(ctx, "V1")
+	_ = setup.repo.SaveVote(ctx, voterID, int(hiddenID), cars[0].ID)
+	_ = setup.repo.SaveVote(ctx, voterID, int(liveID), cars[0].ID)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/categories", nil)
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response []struct {
+		ShowLiveCounts bool                     `json:"show_live_counts"`
+		Cars           []map[string]interface{} `json:"cars"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 2 {
+		t.Fatalf("expected 2 categories, got %d", len(response))
+	}
+
+	// Counts are left out entirely when the flag is off
+	for _, car := range response[0].Cars {
+		if _, ok := car["vote_count"]; ok {
+			t.Errorf("expected no vote_count in hidden category, got %v", car)
+		}
+	}
+
+	// Every car gets a count when it is on, including cars without votes
+	if !response[1].ShowLiveCounts || len(response[1].Cars) != 2 {
+		t.Fatalf("expected live category with 2 cars, got %+v", response[1])
+	}
+	if response[1].Cars[0]["vote_count"] != float64(1) || response[1].Cars[1]["vote_count"] != float64(0) {
+		t.Errorf("expected vote counts 1 and 0, got %v and %v", response[1].Cars[0]["vote_count"], response[1].Cars[1]["vote_count"])
+	}
+}
+
+func TestHandleGetPublicResults(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	voterID, _ := setup.repo.CreateVoter(ctx, "V1")
+	_ = setup.repo.SaveVote(ctx, voterID, int(catID), 1)
+
+	get := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/results/public", nil)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := get(); rec.Code != http.StatusForbidden {
+		t.Errorf("expected status %d while disabled, got %d", http.StatusForbidden, rec.Code)
+	}
+
+	_ = setup.repo.SetSetting(ctx, "public_results_enabled", "true")
+	_ = setup.repo.SetSetting(ctx, "voting_open", "false")
+
+	rec := get()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response []map[string]interface{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 || response[0]["winner"] == nil {
+		t.Errorf("expected one category with a winner, got %v", response)
+	}
+}
+
+func TestHandleGetPublicResults_NotModified(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	_ = setup.repo.SetSetting(ctx, "public_results_enabled", "true")
+	_ = setup.repo.SetSetting(ctx, "voting_open", "false")
+
+	get := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/results/public", nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if etag == "" || first.Header().Get("Last-Modified") == "" {
+		t.Fatalf("expected ETag and Last-Modified headers, got %v", first.Header())
+	}
+
+	rec := get(etag)
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("expected status %d, got %d", http.StatusNotModified, rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %q", rec.Body.String())
+	}
+
+	// A vote changes the results, so the old tag no longer matches
+	voterID, _ := setup.repo.CreateVoter(ctx, "V1")
+	_ = setup.repo.SaveVote(ctx, voterID, int(catID), 1)
+	rec = get(etag)
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Fatalf("expected new results after a vote, got %d with ETag %s", rec.Code, rec.Header().Get("ETag"))
+	}
+	etag = rec.Header().Get("ETag")
+
+	// Freezing or discarding a snapshot changes the tag too
+	_ = setup.repo.SetSetting(ctx, "results_snapshot", "")
+	if rec := get(etag); rec.Code != http.StatusOK {
+		t.Errorf("expected new results after a snapshot change, got %d", rec.Code)
+	}
+
+	// Reopening voting hides results even from a client holding the current tag
+	etag = get("").Header().Get("ETag")
+	_ = setup.repo.SetSetting(ctx, "voting_open", "true")
+	if rec := get(etag); rec.Code != http.StatusForbidden {
+		t.Errorf("expected status %d while voting is open, got %d", http.StatusForbidden, rec.Code)
+	}
+}
+
+func TestHandleGetPublicCategories_ServiceError(t *testing.T) {
+	setup := newTestSetup(t)
+	setup.repo.DB().Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/categories", nil)
+	rec := httptest.NewRecorder()
+
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 for service error, got %d", rec.Code)
+	}
+}
+
 func TestHandleSubmitVote_Success(t *testing.T) {
 	setup := newTestSetup(t)
 	ctx := context.Background()
 
 	// Create test data
-	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
 	setup.repo.CreateVoter(ctx, "VOTER-SUBMIT")
 
@@ -145,6 +476,87 @@ func TestHandleSubmitVote_Success(t *testing.T) {
 	}
 }
 
+func TestHandleSubmitVote_ReturnTally(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Crowd Favorite", 1, nil, nil, nil, nil)
+	_ = setup.repo.SetCategoryShowLiveCounts(ctx, int(catID), true)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	cars, _ := setup.repo.ListCars(ctx)
+
+	submit := func(query string) map[string]interface{} {
+		body, _ := json.Marshal(map[string]interface{}{"voter_qr": "VOTER-TALLY", "category_id": catID, "car_id": cars[0].ID})
+		req := httptest.NewRequest(http.MethodPost, "/api/vote"+query, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var result map[string]interface{}
+		json.NewDecoder(rec.Body).Decode(&result)
+		return result
+	}
+
+	if result := submit(""); result["tally"] != nil {
+		t.Errorf("expected no tally by default, got %v", result["tally"])
+	}
+	result := submit("?return_tally=true")
+	tally, _ := result["tally"].(map[string]interface{})
+	if tally[strconv.Itoa(cars[0].ID)] != float64(1) {
+		t.Errorf("expected tally of 1 for car %d, got %v", cars[0].ID, result["tally"])
+	}
+
+	// Categories that hide their counts never return a tally
+	_ = setup.repo.SetCategoryShowLiveCounts(ctx, int(catID), false)
+	if result := submit("?return_tally=true"); result["tally"] != nil {
+		t.Errorf("expected no tally for hidden category, got %v", result["tally"])
+	}
+}
+
+func TestHandleSubmitVote_WriteIn(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Crowd Favorite", 1, nil, nil, nil, nil)
+	otherID, _ := setup.repo.CreateCategory(ctx, "Best Design", 2, nil, nil, nil, nil)
+	_ = setup.repo.SetCategoryAllowWriteIn(ctx, int(catID), true)
+
+	submit := func(categoryID int64) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"voter_qr":    "VOTER-WRITEIN",
+			"category_id": categoryID,
+			"car_id":      0,
+			"write_in":    "Mystery Car",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/vote", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := submit(catID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response struct {
+		WriteInCarID int `json:"write_in_car_id"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	car, err := setup.repo.GetCar(ctx, response.WriteInCarID)
+	if err != nil || !car.WriteIn || car.CarName != "Mystery Car" {
+		t.Errorf("expected write-in car Mystery Car, got %+v (err %v)", car, err)
+	}
+
+	if rec := submit(otherID); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for category without write-ins, got %d: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+}
+
 func TestHandleSubmitVote_InvalidJSON(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -181,7 +593,7 @@ func TestHandleSubmitVote_VotingClosed(t *testing.T) {
 	setup.repo.SetSetting(ctx, "voting_open", "false")
 
 	// Create test data
-	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
 	setup.repo.CreateVoter(ctx, "VOTER-CLOSED")
 
@@ -203,9 +615,217 @@ func TestHandleSubmitVote_VotingClosed(t *testing.T) {
 
 	setup.router.ServeHTTP(rec, req)
 
-	// Should fail with 400 because voting is closed
-	if rec.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400 (voting closed), got %d: %s", rec.Code, rec.Body.String())
+	// Should fail with 403 because voting is closed
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 (voting closed), got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "VOTING_CLOSED") {
+		t.Errorf("expected VOTING_CLOSED error code, got %s", rec.Body.String())
+	}
+
+	// Nothing was saved
+	voterID, _ := setup.repo.GetVoterByQR(ctx, "VOTER-CLOSED")
+	votes, _ := setup.repo.GetVoterVotes(ctx, voterID)
+	if len(votes) != 0 {
+		t.Errorf("expected no votes saved while closed, got %v", votes)
+	}
+}
+
+func TestHandleSubmitVote_VoteChangesDisallowed(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	setup.repo.SetSetting(ctx, "allow_vote_changes", "false")
+	catID, _ := setup.repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	_ = setup.repo.CreateCar(ctx, "102", "Racer 2", "Car 2", "")
+	cars, _ := setup.repo.ListCars(ctx)
+
+	submit := func(carID int) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"voter_qr":    "VOTER-FINAL",
+			"category_id": catID,
+			"car_id":      carID,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/vote", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := submit(cars[0].ID); rec.Code != http.StatusOK {
+		t.Fatalf("expected first vote to succeed, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := submit(cars[1].ID); rec.Code != http.StatusConflict {
+		t.Errorf("expected status 409 changing a final vote, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleSubmitVote_ExclusivityConflict(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	poolID := 1
+	groupID, _ := setup.repo.CreateCategoryGroup(ctx, "Design Awards", "", &poolID, nil, "", 1)
+	groupIDInt := int(groupID)
+	cat1ID, _ := setup.repo.CreateCategory(ctx, "Best Paint", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := setup.repo.CreateCategory(ctx, "Best Theme", 2, &groupIDInt, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	setup.repo.CreateVoter(ctx, "VOTER-EXCL")
+
+	cars, _ := setup.repo.ListCars(ctx)
+	carID := cars[0].ID
+
+	submit := func(categoryID int64, replace bool) *httptest.ResponseRecorder {
+		payload := map[string]interface{}{
+			"voter_qr":    "VOTER-EXCL",
+			"category_id": categoryID,
+			"car_id":      carID,
+			"replace":     replace,
+		}
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/vote", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := submit(cat1ID, false); rec.Code != http.StatusOK {
+		t.Fatalf("expected first vote to succeed, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// Same car in the same pool without replace is rejected
+	rec := submit(cat2ID, false)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "Best Paint") {
+		t.Errorf("expected error to name the conflicting category, got %s", rec.Body.String())
+	}
+
+	// With replace the old vote is cleared
+	rec = submit(cat2ID, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var result services.VoteResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !result.ConflictCleared || result.ConflictCategoryID != int(cat1ID) {
+		t.Errorf("expected conflict in category %d to be cleared, got %+v", cat1ID, result)
+	}
+}
+
+func TestHandleSubmitBallot_Success(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	cat1ID, _ := setup.repo.CreateCategory(ctx, "Best Paint", 1, nil, nil, nil, nil)
+	cat2ID, _ := setup.repo.CreateCategory(ctx, "Best Theme", 2, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	cars, _ := setup.repo.ListCars(ctx)
+
+	body := fmt.Sprintf(`{"votes": {"%d": %d, "%d": 999}}`, cat1ID, cars[0].ID, cat2ID)
+	req := httptest.NewRequest(http.MethodPost, "/api/voter/VOTER-BALLOT/ballot", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var result services.BallotResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !result.Committed || result.Accepted != 1 || len(result.Results) != 2 {
+		t.Fatalf("expected one of two entries committed, got %+v", result)
+	}
+	if result.Results[1].Status != services.BallotEntryRejected || result.Results[1].Error != "car not found" {
+		t.Errorf("expected unknown car to be rejected, got %+v", result.Results[1])
+	}
+}
+
+func TestHandleSubmitBallot_AllOrNothing(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	cat1ID, _ := setup.repo.CreateCategory(ctx, "Best Paint", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	cars, _ := setup.repo.ListCars(ctx)
+
+	body := fmt.Sprintf(`{"votes": {"%d": %d, "999": %d}, "all_or_nothing": true}`, cat1ID, cars[0].ID, cars[0].ID)
+	req := httptest.NewRequest(http.MethodPost, "/api/voter/VOTER-BALLOT/ballot", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var result services.BallotResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result.Committed || result.Results[0].Status != services.BallotEntryRolledBack {
+		t.Errorf("expected ballot to be rolled back, got %+v", result)
+	}
+
+	voterID, _ := setup.repo.GetVoterByQR(ctx, "VOTER-BALLOT")
+	votes, _ := setup.repo.GetVoterVotes(ctx, voterID)
+	if len(votes) != 0 {
+		t.Errorf("expected no votes saved, got %v", votes)
+	}
+}
+
+func TestHandleSubmitBallot_RedirectURL(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	catID, _ := setup.repo.CreateCategory(ctx, "Best Paint", 1, nil, nil, nil, nil)
+	_ = setup.repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	cars, _ := setup.repo.ListCars(ctx)
+	_ = setup.repo.SetSetting(ctx, "post_vote_redirect_url", "https://pack123.example.org/thanks")
+
+	body := fmt.Sprintf(`{"votes": {"%d": %d}}`, catID, cars[0].ID)
+	req := httptest.NewRequest(http.MethodPost, "/api/voter/VOTER-BALLOT/ballot", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	setup.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var result services.BallotResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result.RedirectURL != "https://pack123.example.org/thanks" {
+		t.Errorf("expected redirect_url for a complete ballot, got %q", result.RedirectURL)
+	}
+}
+
+func TestHandleSubmitBallot_Errors(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/voter/VOTER-BALLOT/ballot", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post("invalid"); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid JSON: expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if rec := post(`{"votes": {}}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("empty ballot: expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	setup.repo.SetSetting(ctx, "voting_open", "false")
+	if rec := post(`{"votes": {"1": 1}}`); rec.Code != http.StatusForbidden {
+		t.Errorf("voting closed: expected status %d, got %d", http.StatusForbidden, rec.Code)
 	}
 }
 
@@ -400,6 +1020,37 @@ func TestHandleSyncDerbyNet_MissingURL(t *testing.T) {
 	}
 }
 
+func TestHandleSyncVotersDerbyNet(t *testing.T) {
+	setup := newTestSetup(t)
+
+	send := func(payload map[string]interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/sync-voters-derbynet", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(setup.authCookie)
+		rec := httptest.NewRecorder()
+		setup.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := send(map[string]interface{}{}); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d without a URL, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	// No cars have been synced, so every racer is reported as missing a car
+	rec := send(map[string]interface{}{"derbynet_url": "http://mock.derbynet.local"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var result services.VoterSyncResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if result.VotersCreated != 0 || result.MissingCars != result.TotalRacers {
+		t.Errorf("expected all racers to be missing cars, got %+v", result)
+	}
+}
+
 func TestHandleSyncDerbyNet_InvalidJSON(t *testing.T) {
 	setup := newTestSetup(t)
 
@@ -704,6 +1355,55 @@ func TestHandleGenerateVoteCode_Success(t *testing.T) {
 	}
 }
 
+func TestHandleGenerateVoteCode_KeepsCategory(t *testing.T) {
+	setup := newTestSetupWithTemplatesForVote(t)
+	setup.repo.SetSetting(context.Background(), "require_registered_qr", "false")
+
+	for query, suffix := range map[string]string{"?category=3": "?category=3", "?category=abc": "", "?category=-1": ""} {
+		req := httptest.NewRequest(http.MethodGet, "/vote/new"+query, nil)
+		w := httptest.NewRecorder()
+		setup.router.ServeHTTP(w, req)
+
+		location := w.Header().Get("Location")
+		if w.Code != http.StatusFound || !strings.HasPrefix(location, "/vote/") || len(strings.TrimPrefix(location, "/vote/")) != 8+len(suffix) || !strings.HasSuffix(location, suffix) {
+			t.Errorf("%s: expected a redirect to /vote/{code}%s, got %d %s", query, suffix, w.Code, location)
+		}
+	}
+}
+
+func TestHandleShortLink(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+
+	voterID, err := setup.repo.CreateVoter(ctx, "SHORT-QR")
+	if err != nil {
+		t.Fatalf("CreateVoter failed: %v", err)
+	}
+	if err := setup.repo.SetVoterShortToken(ctx, voterID, "K7MQ2P"); err != nil {
+		t.Fatalf("SetVoterShortToken failed: %v", err)
+	}
+
+	// Tokens are matched case-insensitively
+	req := httptest.NewRequest(http.MethodGet, "/v/k7mq2p", nil)
+	w := httptest.NewRecorder()
+	setup.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected status 302, got %d: %s", w.Code, w.Body.String())
+	}
+	if location := w.Header().Get("Location"); location != "/vote/SHORT-QR" {
+		t.Errorf("expected redirect to /vote/SHORT-QR, got: %s", location)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v/NOPE22", nil)
+	w = httptest.NewRecorder()
+	setup.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown token, got %d", w.Code)
+	}
+}
+
 func TestHandleGenerateVoteCode_OpenVotingDisabled(t *testing.T) {
 	setup := newTestSetupWithTemplatesForVote(t)
 
@@ -720,3 +1420,190 @@ func TestHandleGenerateVoteCode_OpenVotingDisabled(t *testing.T) {
 		t.Error("expected error response, got redirect")
 	}
 }
+
+func TestHandleGenerateVoteCode_OnePerDevice(t *testing.T) {
+	setup := newTestSetupWithTemplatesForVote(t)
+	ctx := context.Background()
+	setup.repo.SetSetting(ctx, "require_registered_qr", "false")
+	setup.repo.SetSetting(ctx, "open_voting_one_per_device", "true")
+
+	req := httptest.NewRequest(http.MethodGet, "/vote/new", nil)
+	w := httptest.NewRecorder()
+	setup.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected status 302, got %d", w.Code)
+	}
+	first := w.Header().Get("Location")
+	var device *http.Cookie
+	for _, c := range w.Result().Cookies() {
+		if c.Name == "derbyvote_device" {
+			device = c
+		}
+	}
+	if device == nil {
+		t.Fatal("expected a device cookie")
+	}
+
+	// Scanning again from the same device returns to the same ballot
+	req = httptest.NewRequest(http.MethodGet, "/vote/new", nil)
+	req.AddCookie(device)
+	w = httptest.NewRecorder()
+	setup.router.ServeHTTP(w, req)
+	if loc := w.Header().Get("Location"); loc != first {
+		t.Errorf("expected redirect to %s, got %s", first, loc)
+	}
+
+	// A different new code is refused for this device
+	req = httptest.NewRequest(http.MethodGet, "/api/vote-data/someone-else", nil)
+	req.AddCookie(device)
+	w = httptest.NewRecorder()
+	setup.router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for another new code, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// The device's own code still loads
+	req = httptest.NewRequest(http.MethodGet, "/api/vote-data/"+strings.TrimPrefix(first, "/vote/"), nil)
+	req.AddCookie(device)
+	w = httptest.NewRecorder()
+	setup.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for the device's code, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	setup := newTestSetupWithTemplatesForVote(t)
+	ctx := context.Background()
+	setup.repo.SetSetting(ctx, "maintenance_message", "Voting opens at 7pm")
+
+	serve := func(method, path, body string, admin bool) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if admin {
+			req.AddCookie(setup.authCookie)
+		}
+		w := httptest.NewRecorder()
+		setup.router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Voter pages and API return 503 with the message
+	if w := serve(http.MethodGet, "/vote/ABC123", "", false); w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "Voting opens at 7pm") {
+		t.Errorf("expected 503 with message for vote page, got %d: %s", w.Code, w.Body.String())
+	}
+	w := serve(http.MethodPost, "/api/vote", `{"voter_qr":"ABC123","category_id":1,"car_id":1}`, false)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 for vote, got %d: %s", w.Code, w.Body.String())
+	}
+	var envelope struct {
+		Error handlers.APIError `json:"error"`
+	}
+	json.NewDecoder(w.Body).Decode(&envelope)
+	if envelope.Error.Code != handlers.ErrCodeMaintenance || envelope.Error.Message != "Voting opens at 7pm" {
+		t.Errorf("unexpected maintenance error: %+v", envelope.Error)
+	}
+
+	// The public flag reports maintenance mode
+	w = serve(http.MethodGet, "/api/maintenance", "", false)
+	var status handlers.MaintenanceResponse
+	json.NewDecoder(w.Body).Decode(&status)
+	if w.Code != http.StatusOK || !status.Active || status.Message != "Voting opens at 7pm" {
+		t.Errorf("expected active maintenance status, got %d: %+v", w.Code, status)
+	}
+
+	// Admin routes keep working, including clearing the message
+	if w := serve(http.MethodGet, "/api/admin/settings", "", true); w.Code != http.StatusOK {
+		t.Errorf("expected admin settings to load, got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/api/admin/settings", `{"maintenance_message":""}`, true); w.Code != http.StatusOK {
+		t.Fatalf("expected clearing the message to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := serve(http.MethodGet, "/vote/ABC123", "", false); w.Code != http.StatusOK {
+		t.Errorf("expected vote page after clearing, got %d", w.Code)
+	}
+	w = serve(http.MethodGet, "/api/maintenance", "", false)
+	status = handlers.MaintenanceResponse{}
+	json.NewDecoder(w.Body).Decode(&status)
+	if status.Active {
+		t.Errorf("expected maintenance mode off, got %+v", status)
+	}
+}
+
+func TestHandleGetClock(t *testing.T) {
+	setup := newTestSetup(t)
+	ctx := context.Background()
+	setup.handlers.Settings.StartVotingTimer(ctx, 2)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/clock", nil)
+	w := httptest.NewRecorder()
+	setup.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if cc := w.Header().Get("Cache-Control"); !strings.Contains(cc, "no-store") {
+		t.Errorf("expected no-store Cache-Control, got %q", cc)
+	}
+	var clock handlers.ClockResponse
+	json.NewDecoder(w.Body).Decode(&clock)
+	if !clock.Open || clock.SecondsRemaining <= 0 || clock.SecondsRemaining > 120 {
+		t.Errorf("expected an open countdown, got %+v", clock)
+	}
+	if _, err := time.Parse(time.RFC3339, clock.ServerTime); err != nil {
+		t.Errorf("expected RFC 3339 server_time, got %q", clock.ServerTime)
+	}
+}
+
+func TestHandleOpenAPISpec(t *testing.T) {
+	setup := newTestSetup(t)
+	router := setup.handlers.Router()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected application/json, got %q", ct)
+	}
+
+	var spec struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&spec); err != nil {
+		t.Fatalf("failed to decode spec: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("expected an OpenAPI 3 document, got %q", spec.OpenAPI)
+	}
+
+	// Every registered /api route must be described, and nothing else
+	registered := map[string]bool{}
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if !strings.HasPrefix(route, "/api/") {
+			return nil
+		}
+		key := strings.ToLower(method) + " " + route
+		registered[key] = true
+		if _, ok := spec.Paths[route][strings.ToLower(method)]; !ok {
+			t.Errorf("route %s %s is missing from the OpenAPI spec", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			if !registered[method+" "+path] {
+				t.Errorf("OpenAPI spec describes %s %s, which is not a registered route", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
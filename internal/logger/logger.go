@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"strings"
@@ -13,6 +14,10 @@ type Logger interface {
 	Info(msg string, args ...any)
 	Warn(msg string, args ...any)
 	Error(msg string, args ...any)
+	DebugContext(ctx context.Context, msg string, args ...any)
+	InfoContext(ctx context.Context, msg string, args ...any)
+	WarnContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
 	SetLevel(level slog.Level)
 	GetLevel() slog.Level
 	EnableHTTPLogging()
@@ -38,9 +43,9 @@ func NewWithLevel(level slog.Level) *SlogLogger {
 	levelVar.Set(level)
 
 	sl := &SlogLogger{
-		logger: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		logger: slog.New(contextHandler{slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 			Level: levelVar,
-		})),
+		})}),
 		level: levelVar,
 	}
 	sl.httpLogging.Store(false)
@@ -81,6 +86,26 @@ func (l *SlogLogger) Error(msg string, args ...any) {
 	l.logger.Error(msg, args...)
 }
 
+// DebugContext logs at debug level, tagged with the context's request ID if any
+func (l *SlogLogger) DebugContext(ctx context.Context, msg string, args ...any) {
+	l.logger.DebugContext(ctx, msg, args...)
+}
+
+// InfoContext logs at info level, tagged with the context's request ID if any
+func (l *SlogLogger) InfoContext(ctx context.Context, msg string, args ...any) {
+	l.logger.InfoContext(ctx, msg, args...)
+}
+
+// WarnContext logs at warn level, tagged with the context's request ID if any
+func (l *SlogLogger) WarnContext(ctx context.Context, msg string, args ...any) {
+	l.logger.WarnContext(ctx, msg, args...)
+}
+
+// ErrorContext logs at error level, tagged with the context's request ID if any
+func (l *SlogLogger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	l.logger.ErrorContext(ctx, msg, args...)
+}
+
 // SetLevel changes the logging level dynamically
 func (l *SlogLogger) SetLevel(level slog.Level) {
 	l.level.Set(level)
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"log/slog"
 	"strings"
 	"testing"
@@ -110,6 +111,51 @@ func TestSlogLogger_LogMethods(t *testing.T) {
 	}
 }
 
+func TestSlogLogger_ContextMethods_IncludeRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	handler := contextHandler{slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})}
+	log := &SlogLogger{logger: slog.New(handler)}
+	ctx := WithRequestID(context.Background(), "abc123")
+
+	tests := []struct {
+		name string
+		fn   func(context.Context, string, ...any)
+	}{
+		{"DebugContext", log.DebugContext},
+		{"InfoContext", log.InfoContext},
+		{"WarnContext", log.WarnContext},
+		{"ErrorContext", log.ErrorContext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.fn(ctx, "test message", "key", "value")
+
+			output := buf.String()
+			if !strings.Contains(output, "request_id=abc123") || !strings.Contains(output, "key=value") {
+				t.Errorf("expected request ID and attributes in output, got: %s", output)
+			}
+		})
+	}
+
+	// Without a request ID, nothing is added
+	buf.Reset()
+	log.InfoContext(context.Background(), "test message")
+	if strings.Contains(buf.String(), "request_id") {
+		t.Errorf("expected no request_id without one in the context, got: %s", buf.String())
+	}
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	if id := RequestIDFromContext(context.Background()); id != "" {
+		t.Errorf("expected empty request ID, got %q", id)
+	}
+	if id := RequestIDFromContext(WithRequestID(context.Background(), "abc123")); id != "abc123" {
+		t.Errorf("expected abc123, got %q", id)
+	}
+}
+
 func TestSlogLogger_LevelFiltering(t *testing.T) {
 	// Create logger at WARN level
 	var buf bytes.Buffer
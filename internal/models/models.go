@@ -1,14 +1,32 @@
 package models
 
+import "encoding/json"
+
+// Multi-win strategies control how a group handles a car exceeding max_wins_per_car
+const (
+	MultiWinStrategyManual       = "manual"
+	MultiWinStrategyAutoRunnerUp = "auto_runner_up"
+)
+
+// ExclusivityPool is a named set of category groups in which a voter may pick
+// a car only once
+type ExclusivityPool struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	GroupCount int    `json:"group_count"` // Active category groups assigned to the pool
+}
+
 // CategoryGroup represents a group of categories with optional exclusivity
 type CategoryGroup struct {
-	ID                int    `json:"id"`
-	Name              string `json:"name"`
-	Description       string `json:"description"`
-	ExclusivityPoolID *int   `json:"exclusivity_pool_id"`
-	MaxWinsPerCar     *int   `json:"max_wins_per_car,omitempty"`
-	DisplayOrder      int    `json:"display_order"`
-	Active            bool   `json:"active"`
+	ID                  int    `json:"id"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	ExclusivityPoolID   *int   `json:"exclusivity_pool_id"`
+	ExclusivityPoolName string `json:"exclusivity_pool_name,omitempty"`
+	MaxWinsPerCar       *int   `json:"max_wins_per_car,omitempty"`
+	MultiWinStrategy    string `json:"multi_win_strategy"`
+	DisplayOrder        int    `json:"display_order"`
+	Active              bool   `json:"active"`
 }
 
 // Category represents a voting category
@@ -25,6 +43,11 @@ type Category struct {
 	OverriddenAt         string   `json:"overridden_at,omitempty"`
 	AllowedVoterTypes    []string `json:"allowed_voter_types,omitempty"` // Empty/nil means all types allowed
 	AllowedRanks         []string `json:"allowed_ranks,omitempty"`       // Empty/nil means all ranks allowed
+	Tags                 []string `json:"tags,omitempty"`                // Reporting tags, independent of group
+	AllowWriteIn         bool     `json:"allow_write_in,omitempty"`      // Voters may type in a car not on the list
+	ShowLiveCounts       bool     `json:"show_live_counts,omitempty"`    // Public listing shows each car's current vote count
+	CarIDs               []int    `json:"car_ids,omitempty"`             // Cars competing in the category; empty means all eligible cars
+	BannerURL            string   `json:"banner_url,omitempty"`          // Image shown above the category on the ballot
 }
 
 // Car represents a pinewood derby car
@@ -36,6 +59,75 @@ type Car struct {
 	PhotoURL  string `json:"photo_url"`
 	Rank      string `json:"rank"`
 	Eligible  bool   `json:"eligible"`
+	WriteIn   bool   `json:"write_in,omitempty"`   // Created from a voter's free-text write-in
+	CreatedBy string `json:"created_by,omitempty"` // Admin who added the car; set by admin reads only
+}
+
+// StaleVoter is a voter who has voted in some but not all of their categories
+// and has been idle since LastActivityAt
+type StaleVoter struct {
+	ID                  int    `json:"id"`
+	QRCode              string `json:"qr_code"`
+	Name                string `json:"name,omitempty"`
+	VoterType           string `json:"voter_type"`
+	VotesCast           int    `json:"votes_cast"`
+	CategoriesAvailable int    `json:"categories_available"`
+	LastActivityAt      string `json:"last_activity_at"`
+}
+
+// VoterGroupTurnout is how many voters in a group, such as a den, have voted
+type VoterGroupTurnout struct {
+	VoterGroup      string  `json:"voter_group"` // Empty for ungrouped voters
+	Voters          int     `json:"voters"`
+	Voted           int     `json:"voted"`
+	CompleteBallots int     `json:"complete_ballots"`
+	TurnoutRate     float64 `json:"turnout_rate"` // Voted / Voters
+}
+
+// AuditActionVoteVoided is the audit log action for an admin voiding a vote
+const AuditActionVoteVoided = "vote_voided"
+
+// AuditActionVoteAssisted is the audit log action for a vote an admin recorded
+// on a voter's behalf
+const AuditActionVoteAssisted = "vote_assisted"
+
+// AuditActionVotingAutoClosed is the audit log action for voting closing
+// itself after the auto_close_idle_minutes setting passed without a vote
+const AuditActionVotingAutoClosed = "voting_auto_closed"
+
+// AuditEntry is one accountable admin action recorded in the audit log
+type AuditEntry struct {
+	ID         int    `json:"id"`
+	Action     string `json:"action"`
+	VoterID    *int   `json:"voter_id,omitempty"`
+	CategoryID *int   `json:"category_id,omitempty"`
+	CarID      *int   `json:"car_id,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// PushRecord is one push of results to DerbyNet, kept to reconcile awards
+// with DerbyNet afterwards. Details holds the per-award outcomes as JSON.
+type PushRecord struct {
+	ID            int             `json:"id"`
+	DerbyNetURL   string          `json:"derbynet_url"`
+	DryRun        bool            `json:"dry_run"`
+	Status        string          `json:"status"`
+	Message       string          `json:"message,omitempty"`
+	WinnersPushed int             `json:"winners_pushed"`
+	Skipped       int             `json:"skipped"`
+	Errors        int             `json:"errors"`
+	Details       json.RawMessage `json:"details,omitempty"`
+	PushedAt      string          `json:"pushed_at"`
+}
+
+// CategoryVote is a vote in a category with when it was cast. UpdatedAt is
+// when the voter last changed their pick; it equals CreatedAt otherwise.
+type CategoryVote struct {
+	VoterID   int    `json:"voter_id"`
+	CarID     int    `json:"car_id"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
 }
 
 // Vote represents a vote submission
@@ -43,6 +135,8 @@ type Vote struct {
 	VoterQR    string `json:"voter_qr"`
 	CategoryID int    `json:"category_id"`
 	CarID      int    `json:"car_id"`
+	Replace    bool   `json:"replace,omitempty"`  // Clear a conflicting vote in the same exclusivity pool
+	WriteIn    string `json:"write_in,omitempty"` // Free-text car name, used when CarID is 0 and the category allows write-ins
 }
 
 // VoteData represents the data sent to voters
@@ -0,0 +1,171 @@
+// Package pdf writes plain text PDF documents, enough for printable reports
+// without depending on a PDF library. Text uses the standard Helvetica fonts,
+// so nothing is embedded.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Page layout in points, for US Letter
+const (
+	pageWidth  = 612
+	pageHeight = 792
+	margin     = 54
+)
+
+// Font sizes for each kind of line
+const (
+	titleSize   = 18
+	headingSize = 13
+	textSize    = 10
+)
+
+// Document is a text document laid out top to bottom over as many pages as needed
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+	y     float64 // Baseline of the next line on the current page
+}
+
+// New creates an empty document with the given title in its metadata
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// Title adds a line of large bold text
+func (d *Document) Title(text string) {
+	d.line("F2", titleSize, text)
+}
+
+// Heading adds a line of bold text with a little space above it
+func (d *Document) Heading(text string) {
+	d.Gap()
+	d.line("F2", headingSize, text)
+}
+
+// Text adds regular text, wrapped to the page width
+func (d *Document) Text(text string) {
+	for _, line := range wrap(text, textSize) {
+		d.line("F1", textSize, line)
+	}
+}
+
+// Gap adds a blank line
+func (d *Document) Gap() {
+	if d.y != 0 {
+		d.y -= textSize
+	}
+}
+
+// line writes one line of text, starting a new page when this one is full
+func (d *Document) line(font string, size float64, text string) {
+	leading := size * 1.4
+	if len(d.pages) == 0 || d.y-leading < margin {
+		d.pages = append(d.pages, &bytes.Buffer{})
+		d.y = pageHeight - margin
+	}
+	d.y -= leading
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %g Tf %d %.2f Td (%s) Tj ET\n", font, size, margin, d.y, encode(text))
+}
+
+// wrap splits text into lines that fit the page width at size. Helvetica has
+// no fixed width, so this estimates from an average character width.
+func wrap(text string, size float64) []string {
+	limit := int((pageWidth - 2*margin) / (size * 0.5))
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) <= limit:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	return append(lines, line)
+}
+
+// winAnsi maps the punctuation people commonly type outside Latin-1 to its
+// WinAnsiEncoding byte
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// encode converts text to a WinAnsi PDF string body, escaping the characters
+// the string syntax reserves. Characters the fonts cannot show become '?'.
+func encode(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			b.WriteByte(byte(r))
+		case winAnsi[r] != 0:
+			b.WriteByte(winAnsi[r])
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// WriteTo writes the document as a PDF file
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*bytes.Buffer{{}}
+	}
+
+	// Objects are numbered from 1: catalog, page tree, info, the two fonts,
+	// then a page and its content stream for each page
+	const firstPage = 6
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		fmt.Sprintf("<< /Title (%s) /Producer (DerbyVote) >>", encode(d.title)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	)
+	for i, page := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents %d 0 R >>",
+				pageWidth, pageHeight, firstPage+2*i+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.WriteTo(w)
+}
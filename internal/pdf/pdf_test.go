@@ -0,0 +1,120 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, d *Document) string {
+	t.Helper()
+	var buf bytes.Buffer
+	n, err := d.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo reported %d bytes, wrote %d", n, buf.Len())
+	}
+	return buf.String()
+}
+
+func TestDocument_WriteTo_Structure(t *testing.T) {
+	d := New("Spring Derby")
+	d.Title("Spring Derby")
+	d.Heading("Winners")
+	d.Text("Best Design: #101 Pat")
+	out := render(t, d)
+
+	if !strings.HasPrefix(out, "%PDF-1.4\n") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatalf("missing PDF header or trailer:\n%s", out)
+	}
+	if !strings.Contains(out, "/Title (Spring Derby)") || !strings.Contains(out, "(Best Design: #101 Pat) Tj") {
+		t.Errorf("expected title and text in output:\n%s", out)
+	}
+
+	// Every xref entry must point at the start of its object
+	start, err := strconv.Atoi(regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(out)[1])
+	if err != nil || !strings.HasPrefix(out[start:], "xref\n") {
+		t.Fatalf("startxref does not point at the xref table")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(out[start:], -1)
+	if len(entries) != 7 {
+		t.Fatalf("expected 7 objects for a one page document, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(entry[1])
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !strings.HasPrefix(out[offset:], want) {
+			t.Errorf("xref entry %d points at %q", i+1, out[offset:offset+10])
+		}
+	}
+
+	// Stream lengths must match their contents
+	for _, m := range regexp.MustCompile(`(?s)/Length (\d+) >>\nstream\n(.*?)endstream`).FindAllStringSubmatch(out, -1) {
+		if length, _ := strconv.Atoi(m[1]); length != len(m[2]) {
+			t.Errorf("stream /Length %d, actual %d", length, len(m[2]))
+		}
+	}
+}
+
+func TestDocument_PaginatesLongContent(t *testing.T) {
+	d := New("Long")
+	for i := 0; i < 200; i++ {
+		d.Text(fmt.Sprintf("Line %d", i))
+	}
+	out := render(t, d)
+
+	if len(d.pages) < 2 {
+		t.Fatalf("expected several pages, got %d", len(d.pages))
+	}
+	if !strings.Contains(out, fmt.Sprintf("/Count %d", len(d.pages))) {
+		t.Errorf("expected page tree to count %d pages", len(d.pages))
+	}
+	if !strings.Contains(out, "(Line 199) Tj") {
+		t.Error("expected the last line to be written")
+	}
+}
+
+func TestDocument_EmptyHasOnePage(t *testing.T) {
+	if out := render(t, New("")); !strings.Contains(out, "/Count 1") {
+		t.Errorf("expected an empty document to have one blank page:\n%s", out)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{`a (b) \c`, `a \(b\) \\c`},
+		{"tab\there", "tab here"},
+		{"café", "caf\xe9"},
+		{"it’s — ok", "it\x92s \x97 ok"},
+		{"🏎️ car", "?? car"},
+	}
+	for _, tt := range tests {
+		if got := encode(tt.in); got != tt.want {
+			t.Errorf("encode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	long := strings.Repeat("word ", 60)
+	lines := wrap(long, textSize)
+	if len(lines) < 2 {
+		t.Fatalf("expected long text to wrap, got %d line", len(lines))
+	}
+	for _, line := range lines {
+		if len(line) > 100 { // About 504pt of 10pt Helvetica
+			t.Errorf("line too long: %q", line)
+		}
+	}
+	if got := wrap("", textSize); len(got) != 1 || got[0] != "" {
+		t.Errorf("expected empty text to give one empty line, got %q", got)
+	}
+}
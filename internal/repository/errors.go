@@ -10,3 +10,7 @@ var ErrNotFound = errors.New("record not found")
 // ErrInvalidTable is returned when attempting to clear a table that is not whitelisted.
 // This prevents SQL injection attacks.
 var ErrInvalidTable = errors.New("invalid table name")
+
+// ErrReadOnly is returned by writes a read-only repository refuses up front,
+// so callers can fall back instead of failing.
+var ErrReadOnly = errors.New("database is read-only")
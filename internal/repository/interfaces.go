@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/abrezinsky/derbyvote/internal/models"
 )
@@ -10,18 +11,32 @@ import (
 type CategoryRepository interface {
 	ListCategories(ctx context.Context) ([]models.Category, error)
 	ListAllCategories(ctx context.Context) ([]map[string]interface{}, error)
-	CreateCategory(ctx context.Context, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string) (int64, error)
-	UpdateCategory(ctx context.Context, id int, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string, active bool) error
+	CreateCategory(ctx context.Context, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string, tags []string) (int64, error)
+	UpdateCategory(ctx context.Context, id int, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string, active bool, tags []string) error
 	DeleteCategory(ctx context.Context, id int) error
 	CategoryExists(ctx context.Context, name string) (bool, error)
 	UpsertCategory(ctx context.Context, name string, displayOrder int, derbynetAwardID *int) (created bool, err error)
+	ImportCategories(ctx context.Context, rows []CategoryImportRow) (created []bool, groupsCreated int, err error)
+	SetCategoryDerbyNetAwardID(ctx context.Context, id int, awardID *int) error
+	SetCategoryGroup(ctx context.Context, id int, groupID *int) error
+	CountPoolConflicts(ctx context.Context, categoryID, poolID int) (int, error)
+	SetCategoryAllowWriteIn(ctx context.Context, id int, allow bool) error
+	SetCategoryShowLiveCounts(ctx context.Context, id int, show bool) error
+	SetCategoryBannerURL(ctx context.Context, id int, bannerURL string) error
+	SetCategoryCars(ctx context.Context, id int, carIDs []int) error
+	CategoryAllowsCar(ctx context.Context, categoryID, carID int) (bool, error)
 	SetManualWinner(ctx context.Context, categoryID, carID int, reason string) error
 	ClearManualWinner(ctx context.Context, categoryID int) error
 	ListCategoryGroups(ctx context.Context) ([]models.CategoryGroup, error)
 	GetCategoryGroup(ctx context.Context, id string) (*models.CategoryGroup, error)
-	CreateCategoryGroup(ctx context.Context, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, displayOrder int) (int64, error)
-	UpdateCategoryGroup(ctx context.Context, id string, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, displayOrder int) error
+	CreateCategoryGroup(ctx context.Context, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, multiWinStrategy string, displayOrder int) (int64, error)
+	UpdateCategoryGroup(ctx context.Context, id string, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, multiWinStrategy string, displayOrder int) error
 	DeleteCategoryGroup(ctx context.Context, id string) error
+	ListExclusivityPools(ctx context.Context) ([]models.ExclusivityPool, error)
+	GetExclusivityPoolByName(ctx context.Context, name string) (*models.ExclusivityPool, error)
+	CreateExclusivityPool(ctx context.Context, name string) (int64, error)
+	UpdateExclusivityPool(ctx context.Context, id int, name string) error
+	DeleteExclusivityPool(ctx context.Context, id int) error
 }
 
 // VoterRepository defines voter data operations
@@ -30,49 +45,88 @@ type VoterRepository interface {
 	GetVoterByQR(ctx context.Context, qrCode string) (int, error)
 	GetVoterByQRCode(ctx context.Context, qrCode string) (int64, bool, error)
 	GetVoterQRCode(ctx context.Context, id int) (string, error)
+	GetVoterShortToken(ctx context.Context, voterID int) (string, error)
+	GetQRCodeByShortToken(ctx context.Context, token string) (string, error)
+	SetVoterShortToken(ctx context.Context, voterID int, token string) error
 	GetVoterType(ctx context.Context, voterID int) (string, error)
+	GetVoterName(ctx context.Context, voterID int) (string, error)
 	CreateVoter(ctx context.Context, qrCode string) (int, error)
 	CreateVoterFull(ctx context.Context, carID *int, name, email, voterType, qrCode, notes string) (int64, error)
 	UpdateVoter(ctx context.Context, id int, carID *int, name, email, voterType, notes string) error
 	DeleteVoter(ctx context.Context, id int) error
+	DeleteVotersByFilter(ctx context.Context, voterType string, hasVoted *bool) (int64, error)
+	SetVoterTest(ctx context.Context, id int, isTest bool) error
+	SetVoterGroup(ctx context.Context, id int, group string) error
+	ListVoterGroupTurnout(ctx context.Context) ([]models.VoterGroupTurnout, error)
+	DeleteTestVoters(ctx context.Context) (int64, error)
+	ClearVoterVotes(ctx context.Context, voterID int) (int64, error)
+	VoidVote(ctx context.Context, voterID, categoryID int, reason string) (int, error)
+	AddAuditEntry(ctx context.Context, entry models.AuditEntry) error
+	ListAuditLog(ctx context.Context) ([]models.AuditEntry, error)
+	CountVotesForVoter(ctx context.Context, voterID int) (int, error)
+	TouchVoterActivity(ctx context.Context, voterID int) error
+	ListStaleVoters(ctx context.Context, idleSince time.Time) ([]models.StaleVoter, error)
 	InsertVoterIgnore(ctx context.Context, qrCode string) error
 	UpsertVoterForCar(ctx context.Context, carID int64, name, qrCode string) error
+	CarHasVoter(ctx context.Context, carID int64) (bool, error)
 }
 
 // CarRepository defines car data operations
 type CarRepository interface {
 	ListCars(ctx context.Context) ([]models.Car, error)
 	ListEligibleCars(ctx context.Context) ([]models.Car, error)
+	ListCarsPaged(ctx context.Context, query string, eligible *bool, writeIns bool, limit, offset int) ([]models.Car, int, error)
 	GetCar(ctx context.Context, id int) (*models.Car, error)
 	GetCarByDerbyNetID(ctx context.Context, racerID int) (int64, bool, error)
+	GetCarDerbyNetRacerID(ctx context.Context, carID int) (*int, error)
+	SetCarDerbyNetRacerID(ctx context.Context, id int, racerID *int) error
 	UpsertCar(ctx context.Context, derbynetRacerID int, carNumber, racerName, carName, photoURL, rank string) error
 	CarExists(ctx context.Context, carNumber string) (bool, error)
 	CreateCar(ctx context.Context, carNumber, racerName, carName, photoURL string) error
+	GetOrCreateWriteInCar(ctx context.Context, name string) (int, error)
+	FindWriteInCar(ctx context.Context, name string) (int, error)
 	UpdateCar(ctx context.Context, id int, carNumber, racerName, carName, photoURL, rank string) error
 	SetCarEligibility(ctx context.Context, id int, eligible bool) error
+	SetCarsEligibility(ctx context.Context, ids []int, eligible bool) (int64, error)
 	DeleteCar(ctx context.Context, id int) error
 	CountVotesForCar(ctx context.Context, carID int) (int, error)
 }
 
 // VoteRepository defines vote data operations
 type VoteRepository interface {
+	AnyVoteSince(ctx context.Context, since time.Time) (bool, error)
 	GetVoterVotes(ctx context.Context, voterID int) (map[int]int, error)
 	SaveVote(ctx context.Context, voterID, categoryID, carID int) error
+	SaveBallot(ctx context.Context, voterID int, votes map[int]int) error
 	GetExclusivityPoolID(ctx context.Context, categoryID int) (int64, bool, error)
 	FindConflictingVote(ctx context.Context, voterID, carID, categoryID int, poolID int64) (int, string, bool, error)
 	ClearConflictingVote(ctx context.Context, voterID, categoryID, carID int) error
 	GetVoteResults(ctx context.Context) (map[int]map[int]int, error)
 	GetVoteResultsWithCars(ctx context.Context) ([]VoteResultRow, error)
+	GetAllVoteResultsWithCars(ctx context.Context) ([]VoteResultRow, error)
+	ListRawVotes(ctx context.Context) ([]RawVoteRow, error)
+	ListTopCars(ctx context.Context, limit int) ([]TopCarRow, error)
+	ListCategoryBallots(ctx context.Context, categoryID int) ([]BallotRow, error)
+	ListVotesForCategory(ctx context.Context, categoryID int) ([]models.CategoryVote, error)
+	ListVotersForExport(ctx context.Context) ([]VoterExportRow, error)
+	ListVotesForExport(ctx context.Context) ([]VoteExportRow, error)
+	MergeVotes(ctx context.Context, voters []VoterMergeRow, votes []VoteMergeRow) (int, int, error)
 	GetWinnersForDerbyNet(ctx context.Context) ([]WinnerForDerbyNet, error)
+	SavePushRecord(ctx context.Context, record models.PushRecord) (int64, error)
+	ListPushHistory(ctx context.Context) ([]models.PushRecord, error)
 	CountVotesForCategory(ctx context.Context, categoryID int) (int, error)
+	ResultsVersion() uint64
+	ResultsModifiedAt() time.Time
 }
 
 // SettingsRepository defines settings data operations
 type SettingsRepository interface {
 	GetSetting(ctx context.Context, key string) (string, error)
 	SetSetting(ctx context.Context, key, value string) error
+	ListSettings(ctx context.Context) (map[string]string, error)
 	GetVotingStats(ctx context.Context) (map[string]interface{}, error)
 	ClearTable(ctx context.Context, table string) error
+	ClearEventData(ctx context.Context) (map[string]int64, error)
 }
 
 // FullRepository combines all repository interfaces
@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migration is one versioned schema change. Applied versions are recorded in
+// schema_migrations so each migration runs once, in version order.
+type migration struct {
+	version int
+	name    string
+	steps   []migrationStep
+}
+
+// migrationStep is a single change applied inside a migration's transaction
+type migrationStep func(tx *sql.Tx) error
+
+// migrations lists every schema change in the order it must be applied.
+// Append new migrations with the next version; never edit or reorder
+// released ones.
+var migrations = []migration{
+	{1, "create base tables", []migrationStep{
+		execStep(`CREATE TABLE IF NOT EXISTS voters (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			car_id INTEGER,
+			name TEXT,
+			email TEXT,
+			voter_type TEXT DEFAULT 'general',
+			qr_code TEXT UNIQUE NOT NULL,
+			notes TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			last_voted_at DATETIME,
+			FOREIGN KEY (car_id) REFERENCES cars(id) ON DELETE SET NULL
+		)`),
+		execStep(`CREATE TABLE IF NOT EXISTS cars (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			derbynet_racer_id INTEGER UNIQUE,
+			car_number TEXT NOT NULL,
+			racer_name TEXT,
+			car_name TEXT,
+			photo_url TEXT,
+			rank TEXT,
+			synced_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			active BOOLEAN DEFAULT 1
+		)`),
+		execStep(`CREATE TABLE IF NOT EXISTS category_groups (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			description TEXT,
+			exclusivity_pool_id INTEGER,
+			display_order INTEGER NOT NULL,
+			active BOOLEAN DEFAULT 1
+		)`),
+		execStep(`CREATE TABLE IF NOT EXISTS categories (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			display_order INTEGER NOT NULL,
+			group_id INTEGER,
+			derbynet_award_id INTEGER,
+			active BOOLEAN DEFAULT 1,
+			override_winner_car_id INTEGER,
+			override_reason TEXT,
+			overridden_at DATETIME,
+			FOREIGN KEY (group_id) REFERENCES category_groups(id) ON DELETE SET NULL,
+			FOREIGN KEY (override_winner_car_id) REFERENCES cars(id) ON DELETE SET NULL
+		)`),
+		execStep(`CREATE TABLE IF NOT EXISTS votes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			voter_id INTEGER NOT NULL,
+			car_id INTEGER NOT NULL,
+			category_id INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (voter_id) REFERENCES voters(id),
+			FOREIGN KEY (car_id) REFERENCES cars(id),
+			FOREIGN KEY (category_id) REFERENCES categories(id),
+			UNIQUE(voter_id, category_id)
+		)`),
+		execStep(`CREATE TABLE IF NOT EXISTS settings (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		)`),
+	}},
+	{2, "add voter details", []migrationStep{
+		addColumnStep("voters", "car_id", "INTEGER"),
+		addColumnStep("voters", "name", "TEXT"),
+		addColumnStep("voters", "email", "TEXT"),
+		addColumnStep("voters", "voter_type", "TEXT DEFAULT 'general'"),
+		addColumnStep("voters", "notes", "TEXT"),
+	}},
+	{3, "add vote and voter indexes", []migrationStep{
+		execStep(`CREATE INDEX IF NOT EXISTS idx_votes_voter ON votes(voter_id)`),
+		execStep(`CREATE INDEX IF NOT EXISTS idx_votes_category ON votes(category_id)`),
+		execStep(`CREATE INDEX IF NOT EXISTS idx_votes_car ON votes(car_id)`),
+		execStep(`CREATE INDEX IF NOT EXISTS idx_voters_qr ON voters(qr_code)`),
+		execStep(`CREATE INDEX IF NOT EXISTS idx_voters_car ON voters(car_id)`),
+	}},
+	{4, "add category groups and DerbyNet awards", []migrationStep{
+		addColumnStep("categories", "group_id", "INTEGER"),
+		addColumnStep("categories", "derbynet_award_id", "INTEGER"),
+	}},
+	{5, "add car eligibility", []migrationStep{
+		addColumnStep("cars", "eligible", "BOOLEAN DEFAULT 1"),
+	}},
+	{6, "add manual winner overrides", []migrationStep{
+		addColumnStep("categories", "override_winner_car_id", "INTEGER"),
+		addColumnStep("categories", "override_reason", "TEXT"),
+		addColumnStep("categories", "overridden_at", "DATETIME"),
+	}},
+	{7, "add group win limits", []migrationStep{
+		addColumnStep("category_groups", "max_wins_per_car", "INTEGER"),
+		addColumnStep("category_groups", "multi_win_strategy", "TEXT DEFAULT 'manual'"),
+	}},
+	{8, "add category voter types", []migrationStep{
+		addColumnStep("categories", "allowed_voter_types", "TEXT"), // JSON array of voter types, NULL means all types allowed
+	}},
+	{9, "add car ranks", []migrationStep{
+		addColumnStep("cars", "rank", "TEXT"),
+		addColumnStep("categories", "allowed_ranks", "TEXT"), // JSON array of ranks, NULL means all ranks allowed
+	}},
+	{10, "add category tags", []migrationStep{
+		addColumnStep("categories", "tags", "TEXT"), // JSON array of reporting tags, NULL means untagged
+	}},
+	{11, "add voter activity", []migrationStep{
+		addColumnStep("voters", "last_activity_at", "DATETIME"),
+	}},
+	{12, "add write-in votes", []migrationStep{
+		addColumnStep("categories", "allow_write_in", "BOOLEAN DEFAULT 0"),
+		addColumnStep("cars", "write_in", "BOOLEAN DEFAULT 0"),
+	}},
+	{13, "add category live counts", []migrationStep{
+		addColumnStep("categories", "show_live_counts", "BOOLEAN DEFAULT 0"),
+	}},
+	{14, "add test voters", []migrationStep{
+		addColumnStep("voters", "is_test", "BOOLEAN DEFAULT 0"),
+	}},
+	{15, "add audit log", []migrationStep{
+		// No foreign keys: entries must outlive the voters, categories and cars they name
+		execStep(`CREATE TABLE IF NOT EXISTS audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			action TEXT NOT NULL,
+			voter_id INTEGER,
+			category_id INTEGER,
+			car_id INTEGER,
+			reason TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`),
+	}},
+	{16, "add exclusivity pools", []migrationStep{
+		execStep(`CREATE TABLE IF NOT EXISTS exclusivity_pools (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL
+		)`),
+		// Pools were bare integers on category groups; register each one in use
+		execStep(`INSERT OR IGNORE INTO exclusivity_pools (id, name)
+			SELECT DISTINCT exclusivity_pool_id, 'Pool ' || exclusivity_pool_id
+			FROM category_groups WHERE exclusivity_pool_id IS NOT NULL`),
+	}},
+	{17, "add voter short links", []migrationStep{
+		execStep(`CREATE TABLE IF NOT EXISTS voter_short_links (
+			token TEXT PRIMARY KEY,
+			voter_id INTEGER NOT NULL UNIQUE,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (voter_id) REFERENCES voters(id) ON DELETE CASCADE
+		)`),
+	}},
+	{18, "add push history", []migrationStep{
+		execStep(`CREATE TABLE IF NOT EXISTS push_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			derbynet_url TEXT NOT NULL,
+			dry_run BOOLEAN NOT NULL DEFAULT 0,
+			status TEXT NOT NULL,
+			message TEXT,
+			winners_pushed INTEGER NOT NULL DEFAULT 0,
+			skipped INTEGER NOT NULL DEFAULT 0,
+			errors INTEGER NOT NULL DEFAULT 0,
+			details TEXT,
+			pushed_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`),
+	}},
+	// A frozen copy of ballotCompleteSQL as of this version, so later
+	// changes to the live query cannot change what this migration does
+	{19, "add voter ballot completion", []migrationStep{
+		addColumnStep("voters", "ballot_complete", "BOOLEAN DEFAULT 0"),
+		execStep(`UPDATE voters SET ballot_complete = NOT EXISTS (
+			SELECT 1 FROM categories c
+			WHERE c.active = 1
+			  AND (c.allowed_voter_types IS NULL OR c.allowed_voter_types = '' OR c.allowed_voter_types = '[]'
+			       OR EXISTS (SELECT 1 FROM json_each(c.allowed_voter_types) t
+			                  WHERE t.value = COALESCE(voters.voter_type, 'general')))
+			  AND NOT EXISTS (SELECT 1 FROM votes vo WHERE vo.voter_id = voters.id AND vo.category_id = c.id))`),
+	}},
+	{20, "add category car subsets", []migrationStep{
+		execStep(`CREATE TABLE IF NOT EXISTS category_cars (
+			category_id INTEGER NOT NULL,
+			car_id INTEGER NOT NULL,
+			PRIMARY KEY (category_id, car_id),
+			FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE,
+			FOREIGN KEY (car_id) REFERENCES cars(id) ON DELETE CASCADE
+		)`),
+	}},
+	{21, "add category banners", []migrationStep{
+		addColumnStep("categories", "banner_url", "TEXT"),
+	}},
+	// Existing rows keep a NULL creator
+	{22, "add record creators", []migrationStep{
+		addColumnStep("categories", "created_by", "TEXT"),
+		addColumnStep("voters", "created_by", "TEXT"),
+		addColumnStep("cars", "created_by", "TEXT"),
+	}},
+	{23, "add voter groups", []migrationStep{
+		addColumnStep("voters", "voter_group", "TEXT"), // Den or other grouping, independent of voter_type
+	}},
+}
+
+// execStep runs a single statement
+func execStep(query string) migrationStep {
+	return func(tx *sql.Tx) error {
+		_, err := tx.Exec(query)
+		return err
+	}
+}
+
+// addColumnStep adds a column unless it already exists. Databases created
+// before migrations were versioned may already have some of these columns.
+func addColumnStep(table, column, definition string) migrationStep {
+	return func(tx *sql.Tx) error {
+		var count int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		_, err := tx.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + definition)
+		return err
+	}
+}
+
+// migrate applies pending migrations and inserts default settings
+func (r *Repository) migrate() error {
+	if _, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return err
+	}
+
+	applied, err := r.appliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		if err := r.applyMigration(m); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+	}
+
+	// Insert default settings if not exists
+	// Note: base_url is intentionally not set here - it's set by app.go
+	// with the detected LAN IP address on startup
+	defaultSettings := map[string]string{
+		"voting_open":  "true",
+		"derbynet_url": "",
+	}
+
+	for key, value := range defaultSettings {
+		_, err := r.db.Exec(`INSERT OR IGNORE INTO settings (key, value) VALUES (?, ?)`, key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appliedMigrations returns the versions recorded in schema_migrations
+func (r *Repository) appliedMigrations() (map[int]bool, error) {
+	rows, err := r.db.Query(`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration runs a migration's steps and records its version in one transaction
+func (r *Repository) applyMigration(m migration) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, step := range m.steps {
+		if err := step(tx); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version, name) VALUES (?, ?)`, m.version, m.name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SchemaVersion returns the highest applied migration version
+func (r *Repository) SchemaVersion() (int, error) {
+	var version sql.NullInt64
+	if err := r.db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
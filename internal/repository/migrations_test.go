@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// openLegacyDB writes the legacy schema fixture to a fresh database file and returns its path
+func openLegacyDB(t *testing.T) string {
+	t.Helper()
+	fixture, err := os.ReadFile(filepath.Join("testdata", "legacy_schema.sql"))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "legacy.db")
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("failed to open legacy database: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(string(fixture)); err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+	return path
+}
+
+func TestMigrate_FreshDatabaseRecordsAllVersions(t *testing.T) {
+	repo := newTestRepo(t)
+
+	version, err := repo.SchemaVersion()
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if want := migrations[len(migrations)-1].version; version != want {
+		t.Errorf("expected schema version %d, got %d", want, version)
+	}
+}
+
+func TestMigrate_VersionsAreOrdered(t *testing.T) {
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Errorf("migration %q has version %d, expected %d", m.name, m.version, i+1)
+		}
+	}
+}
+
+func TestMigrate_UpgradesLegacyDatabaseWithoutDataLoss(t *testing.T) {
+	path := openLegacyDB(t)
+	ctx := context.Background()
+
+	repo, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed on legacy database: %v", err)
+	}
+
+	version, _ := repo.SchemaVersion()
+	if want := migrations[len(migrations)-1].version; version != want {
+		t.Errorf("expected schema version %d, got %d", want, version)
+	}
+
+	// Existing rows survive
+	voters, err := repo.ListVoters(ctx)
+	if err != nil {
+		t.Fatalf("ListVoters failed: %v", err)
+	}
+	if len(voters) != 2 {
+		t.Errorf("expected 2 voters, got %d", len(voters))
+	}
+	if voterType, _ := repo.GetVoterType(ctx, 1); voterType != "general" {
+		t.Errorf("expected default voter type general, got %q", voterType)
+	}
+	cars, err := repo.ListCars(ctx)
+	if err != nil {
+		t.Fatalf("ListCars failed: %v", err)
+	}
+	if len(cars) != 2 || !cars[0].Eligible || cars[1].Eligible {
+		t.Errorf("expected 2 cars with eligibility kept, got %+v", cars)
+	}
+	categories, err := repo.ListCategories(ctx)
+	if err != nil {
+		t.Fatalf("ListCategories failed: %v", err)
+	}
+	if len(categories) != 2 {
+		t.Errorf("expected 2 categories, got %d", len(categories))
+	}
+	votes, _ := repo.GetVoterVotes(ctx, 1)
+	if votes[1] != 1 {
+		t.Errorf("expected vote for car 1 in category 1, got %v", votes)
+	}
+	if open, _ := repo.GetSetting(ctx, "voting_open"); open != "false" {
+		t.Errorf("expected voting_open to keep its value, got %q", open)
+	}
+	if name, _ := repo.GetSetting(ctx, "event_name"); name != "Spring Derby" {
+		t.Errorf("expected event_name to be kept, got %q", name)
+	}
+
+	// New columns are usable
+	if err := repo.SetManualWinner(ctx, 1, 1, "Judges' call"); err != nil {
+		t.Errorf("SetManualWinner failed after upgrade: %v", err)
+	}
+	if _, err := repo.CreateCategory(ctx, "Pack Award", 9, nil, nil, nil, []string{"pack"}); err != nil {
+		t.Errorf("CreateCategory with tags failed after upgrade: %v", err)
+	}
+	repo.Close()
+
+	// Reopening applies nothing new
+	repo, err = New(path)
+	if err != nil {
+		t.Fatalf("New failed when reopening upgraded database: %v", err)
+	}
+	defer repo.Close()
+	var applied int
+	if err := repo.DB().QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&applied); err != nil {
+		t.Fatalf("failed to count applied migrations: %v", err)
+	}
+	if applied != len(migrations) {
+		t.Errorf("expected %d applied migrations, got %d", len(migrations), applied)
+	}
+}
+
+func TestMigrate_AppliesOnlyPendingVersions(t *testing.T) {
+	path := openLegacyDB(t)
+
+	repo, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	// Forget the last migration and drop what it added, as if it were new in this release
+	last := migrations[len(migrations)-1]
+	if _, err := repo.DB().Exec(`DELETE FROM schema_migrations WHERE version = ?`, last.version); err != nil {
+		t.Fatalf("failed to delete migration record: %v", err)
+	}
+	if _, err := repo.DB().Exec(`ALTER TABLE voters DROP COLUMN voter_group`); err != nil {
+		t.Fatalf("failed to drop column: %v", err)
+	}
+	repo.Close()
+
+	repo, err = New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer repo.Close()
+
+	if version, _ := repo.SchemaVersion(); version != last.version {
+		t.Errorf("expected schema version %d, got %d", last.version, version)
+	}
+	if _, err := repo.ListVoters(context.Background()); err != nil {
+		t.Fatalf("expected voters.voter_group to be re-added, got %v", err)
+	}
+}
@@ -23,6 +23,10 @@ type Repository struct {
 
 	// ===== Category Errors =====
 	UpsertCategoryError      error
+	ImportCategoriesError    error
+	SetCategoryAwardError    error
+	SetCategoryWriteInError  error
+	SetLiveCountsError       error
 	ListCategoriesError      error
 	CategoryExistsError      error
 	CreateCategoryError      error
@@ -39,6 +43,8 @@ type Repository struct {
 	UpsertCarError          error
 	DeleteCarError          error
 	SetCarEligibilityError  error
+	SetCarRacerIDError      error
+	WriteInCarError         error
 
 	// ===== Voter Errors =====
 	GetVoterByQRCodeError   error
@@ -47,16 +53,22 @@ type Repository struct {
 	InsertVoterIgnoreError  error
 	GetVoterQRCodeError     error
 	GetVoterTypeError       error
+	DeleteVotersByFilterError error
+	ClearVoterVotesError      error
+	CountVoterVotesError      error
 
 	// ===== Settings Errors =====
 	GetSettingError error
 	SetSettingError error
+	ListSettingsError error
 	ClearTableError error
+	ClearEventDataError error
 
 	// ===== Vote Errors =====
 	ListEligibleCarsError       error
 	GetVoterVotesError          error
 	SaveVoteError               error
+	SaveBallotError             error
 	GetVoteResultsError         error
 	GetExclusivityPoolIDError   error
 	ClearConflictingVoteError   error
@@ -67,10 +79,15 @@ type Repository struct {
 
 	// ===== Results Errors =====
 	ListCarsError               error
+	ListCarsPagedError          error
 	UpdateCarError              error
 	GetVoteResultsWithCarsError error
+	ListCategoryBallotsError    error
+	ListVotesForExportError     error
+	MergeVotesError             error
 	GetVotingStatsError         error
 	GetWinnersForDerbyNetError  error
+	GetCarDerbyNetRacerIDError  error
 	ClearManualWinnerError      error
 }
 
@@ -90,6 +107,34 @@ func (m *Repository) UpsertCategory(ctx context.Context, name string, displayOrd
 	return m.FullRepository.UpsertCategory(ctx, name, displayOrder, derbynetAwardID)
 }
 
+func (m *Repository) ImportCategories(ctx context.Context, rows []repository.CategoryImportRow) ([]bool, int, error) {
+	if m.ImportCategoriesError != nil {
+		return nil, 0, m.ImportCategoriesError
+	}
+	return m.FullRepository.ImportCategories(ctx, rows)
+}
+
+func (m *Repository) SetCategoryDerbyNetAwardID(ctx context.Context, id int, awardID *int) error {
+	if m.SetCategoryAwardError != nil {
+		return m.SetCategoryAwardError
+	}
+	return m.FullRepository.SetCategoryDerbyNetAwardID(ctx, id, awardID)
+}
+
+func (m *Repository) SetCategoryAllowWriteIn(ctx context.Context, id int, allow bool) error {
+	if m.SetCategoryWriteInError != nil {
+		return m.SetCategoryWriteInError
+	}
+	return m.FullRepository.SetCategoryAllowWriteIn(ctx, id, allow)
+}
+
+func (m *Repository) SetCategoryShowLiveCounts(ctx context.Context, id int, show bool) error {
+	if m.SetLiveCountsError != nil {
+		return m.SetLiveCountsError
+	}
+	return m.FullRepository.SetCategoryShowLiveCounts(ctx, id, show)
+}
+
 func (m *Repository) ListCategories(ctx context.Context) ([]models.Category, error) {
 	if m.ListCategoriesError != nil {
 		return nil, m.ListCategoriesError
@@ -104,11 +149,11 @@ func (m *Repository) CategoryExists(ctx context.Context, name string) (bool, err
 	return m.FullRepository.CategoryExists(ctx, name)
 }
 
-func (m *Repository) CreateCategory(ctx context.Context, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string) (int64, error) {
+func (m *Repository) CreateCategory(ctx context.Context, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string, tags []string) (int64, error) {
 	if m.CreateCategoryError != nil {
 		return 0, m.CreateCategoryError
 	}
-	return m.FullRepository.CreateCategory(ctx, name, displayOrder, groupID, allowedVoterTypes, allowedRanks)
+	return m.FullRepository.CreateCategory(ctx, name, displayOrder, groupID, allowedVoterTypes, allowedRanks, tags)
 }
 
 func (m *Repository) DeleteCategory(ctx context.Context, id int) error {
@@ -141,6 +186,13 @@ func (m *Repository) CreateCar(ctx context.Context, carNumber, racerName, carNam
 	return m.FullRepository.CreateCar(ctx, carNumber, racerName, carName, photoURL)
 }
 
+func (m *Repository) GetOrCreateWriteInCar(ctx context.Context, name string) (int, error) {
+	if m.WriteInCarError != nil {
+		return 0, m.WriteInCarError
+	}
+	return m.FullRepository.GetOrCreateWriteInCar(ctx, name)
+}
+
 func (m *Repository) GetCarByDerbyNetID(ctx context.Context, derbyNetID int) (int64, bool, error) {
 	if m.GetCarByDerbyNetIDError != nil {
 		return 0, false, m.GetCarByDerbyNetIDError
@@ -155,6 +207,13 @@ func (m *Repository) UpsertCar(ctx context.Context, derbyNetID int, carNumber, r
 	return m.FullRepository.UpsertCar(ctx, derbyNetID, carNumber, racerName, carName, photoURL, rank)
 }
 
+func (m *Repository) SetCarDerbyNetRacerID(ctx context.Context, id int, racerID *int) error {
+	if m.SetCarRacerIDError != nil {
+		return m.SetCarRacerIDError
+	}
+	return m.FullRepository.SetCarDerbyNetRacerID(ctx, id, racerID)
+}
+
 // ===== Voter Methods =====
 
 func (m *Repository) GetVoterByQRCode(ctx context.Context, qrCode string) (int64, bool, error) {
@@ -171,6 +230,20 @@ func (m *Repository) UpsertVoterForCar(ctx context.Context, carID int64, name, q
 	return m.FullRepository.UpsertVoterForCar(ctx, carID, name, qrCode)
 }
 
+func (m *Repository) ClearVoterVotes(ctx context.Context, voterID int) (int64, error) {
+	if m.ClearVoterVotesError != nil {
+		return 0, m.ClearVoterVotesError
+	}
+	return m.FullRepository.ClearVoterVotes(ctx, voterID)
+}
+
+func (m *Repository) CountVotesForVoter(ctx context.Context, voterID int) (int, error) {
+	if m.CountVoterVotesError != nil {
+		return 0, m.CountVoterVotesError
+	}
+	return m.FullRepository.CountVotesForVoter(ctx, voterID)
+}
+
 // ===== Settings Methods =====
 
 func (m *Repository) GetSetting(ctx context.Context, key string) (string, error) {
@@ -187,6 +260,13 @@ func (m *Repository) SetSetting(ctx context.Context, key, value string) error {
 	return m.FullRepository.SetSetting(ctx, key, value)
 }
 
+func (m *Repository) ListSettings(ctx context.Context) (map[string]string, error) {
+	if m.ListSettingsError != nil {
+		return nil, m.ListSettingsError
+	}
+	return m.FullRepository.ListSettings(ctx)
+}
+
 // ===== Vote Methods =====
 
 func (m *Repository) ListEligibleCars(ctx context.Context) ([]models.Car, error) {
@@ -210,6 +290,13 @@ func (m *Repository) SaveVote(ctx context.Context, voterID, categoryID, carID in
 	return m.FullRepository.SaveVote(ctx, voterID, categoryID, carID)
 }
 
+func (m *Repository) SaveBallot(ctx context.Context, voterID int, votes map[int]int) error {
+	if m.SaveBallotError != nil {
+		return m.SaveBallotError
+	}
+	return m.FullRepository.SaveBallot(ctx, voterID, votes)
+}
+
 func (m *Repository) GetVoteResults(ctx context.Context) (map[int]map[int]int, error) {
 	if m.GetVoteResultsError != nil {
 		return nil, m.GetVoteResultsError
@@ -226,6 +313,13 @@ func (m *Repository) ListCars(ctx context.Context) ([]models.Car, error) {
 	return m.FullRepository.ListCars(ctx)
 }
 
+func (m *Repository) ListCarsPaged(ctx context.Context, query string, eligible *bool, writeIns bool, limit, offset int) ([]models.Car, int, error) {
+	if m.ListCarsPagedError != nil {
+		return nil, 0, m.ListCarsPagedError
+	}
+	return m.FullRepository.ListCarsPaged(ctx, query, eligible, writeIns, limit, offset)
+}
+
 func (m *Repository) UpdateCar(ctx context.Context, id int, carNumber, racerName, carName, photoURL, rank string) error {
 	if m.UpdateCarError != nil {
 		return m.UpdateCarError
@@ -268,6 +362,13 @@ func (m *Repository) GetVoterByQR(ctx context.Context, qrCode string) (int, erro
 	return m.FullRepository.GetVoterByQR(ctx, qrCode)
 }
 
+func (m *Repository) DeleteVotersByFilter(ctx context.Context, voterType string, hasVoted *bool) (int64, error) {
+	if m.DeleteVotersByFilterError != nil {
+		return 0, m.DeleteVotersByFilterError
+	}
+	return m.FullRepository.DeleteVotersByFilter(ctx, voterType, hasVoted)
+}
+
 func (m *Repository) GetVoterType(ctx context.Context, voterID int) (string, error) {
 	if m.GetVoterTypeError != nil {
 		return "", m.GetVoterTypeError
@@ -282,6 +383,27 @@ func (m *Repository) GetVoteResultsWithCars(ctx context.Context) ([]repository.V
 	return m.FullRepository.GetVoteResultsWithCars(ctx)
 }
 
+func (m *Repository) ListCategoryBallots(ctx context.Context, categoryID int) ([]repository.BallotRow, error) {
+	if m.ListCategoryBallotsError != nil {
+		return nil, m.ListCategoryBallotsError
+	}
+	return m.FullRepository.ListCategoryBallots(ctx, categoryID)
+}
+
+func (m *Repository) ListVotesForExport(ctx context.Context) ([]repository.VoteExportRow, error) {
+	if m.ListVotesForExportError != nil {
+		return nil, m.ListVotesForExportError
+	}
+	return m.FullRepository.ListVotesForExport(ctx)
+}
+
+func (m *Repository) MergeVotes(ctx context.Context, voters []repository.VoterMergeRow, votes []repository.VoteMergeRow) (int, int, error) {
+	if m.MergeVotesError != nil {
+		return 0, 0, m.MergeVotesError
+	}
+	return m.FullRepository.MergeVotes(ctx, voters, votes)
+}
+
 func (m *Repository) GetVotingStats(ctx context.Context) (map[string]interface{}, error) {
 	if m.GetVotingStatsError != nil {
 		return nil, m.GetVotingStatsError
@@ -289,6 +411,13 @@ func (m *Repository) GetVotingStats(ctx context.Context) (map[string]interface{}
 	return m.FullRepository.GetVotingStats(ctx)
 }
 
+func (m *Repository) GetCarDerbyNetRacerID(ctx context.Context, carID int) (*int, error) {
+	if m.GetCarDerbyNetRacerIDError != nil {
+		return nil, m.GetCarDerbyNetRacerIDError
+	}
+	return m.FullRepository.GetCarDerbyNetRacerID(ctx, carID)
+}
+
 func (m *Repository) GetWinnersForDerbyNet(ctx context.Context) ([]repository.WinnerForDerbyNet, error) {
 	if m.GetWinnersForDerbyNetError != nil {
 		return nil, m.GetWinnersForDerbyNetError
@@ -331,11 +460,11 @@ func (m *Repository) GetCategoryGroup(ctx context.Context, id string) (*models.C
 	return m.FullRepository.GetCategoryGroup(ctx, id)
 }
 
-func (m *Repository) UpdateCategoryGroup(ctx context.Context, id, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, displayOrder int) error {
+func (m *Repository) UpdateCategoryGroup(ctx context.Context, id, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, multiWinStrategy string, displayOrder int) error {
 	if m.UpdateCategoryGroupError != nil {
 		return m.UpdateCategoryGroupError
 	}
-	return m.FullRepository.UpdateCategoryGroup(ctx, id, name, description, exclusivityPoolID, maxWinsPerCar, displayOrder)
+	return m.FullRepository.UpdateCategoryGroup(ctx, id, name, description, exclusivityPoolID, maxWinsPerCar, multiWinStrategy, displayOrder)
 }
 
 func (m *Repository) DeleteCategoryGroup(ctx context.Context, id string) error {
@@ -352,6 +481,13 @@ func (m *Repository) ClearTable(ctx context.Context, table string) error {
 	return m.FullRepository.ClearTable(ctx, table)
 }
 
+func (m *Repository) ClearEventData(ctx context.Context) (map[string]int64, error) {
+	if m.ClearEventDataError != nil {
+		return nil, m.ClearEventDataError
+	}
+	return m.FullRepository.ClearEventData(ctx)
+}
+
 func (m *Repository) ClearManualWinner(ctx context.Context, categoryID int) error {
 	if m.ClearManualWinnerError != nil {
 		return m.ClearManualWinnerError
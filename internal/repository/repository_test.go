@@ -6,9 +6,15 @@ import (
 	stderrors "errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/abrezinsky/derbyvote/internal/actor"
 	"github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/models"
 )
@@ -269,6 +275,466 @@ func TestDeleteVoter_NonExistent(t *testing.T) {
 	}
 }
 
+func TestDeleteVotersByFilter_ByTypeAndVotingStatus(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+
+	votedGeneral, _ := repo.CreateVoterFull(ctx, nil, "Walk-up 1", "", "general", "GEN-1", "")
+	_, _ = repo.CreateVoterFull(ctx, nil, "Walk-up 2", "", "general", "GEN-2", "")
+	judge, _ := repo.CreateVoterFull(ctx, nil, "Judge", "", "judge", "JUDGE-1", "")
+
+	if err := repo.SaveVote(ctx, int(votedGeneral), int(catID), cars[0].ID); err != nil {
+		t.Fatalf("SaveVote failed: %v", err)
+	}
+	if err := repo.SaveVote(ctx, int(judge), int(catID), cars[0].ID); err != nil {
+		t.Fatalf("SaveVote failed: %v", err)
+	}
+
+	// Only general voters who have voted
+	hasVoted := true
+	deleted, err := repo.DeleteVotersByFilter(ctx, "general", &hasVoted)
+	if err != nil {
+		t.Fatalf("DeleteVotersByFilter failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 voter deleted, got %d", deleted)
+	}
+
+	// The deleted voter's vote should be gone, the judge's vote should remain
+	count, _ := repo.CountVotesForCategory(ctx, int(catID))
+	if count != 1 {
+		t.Errorf("expected 1 remaining vote, got %d", count)
+	}
+
+	// All remaining general voters
+	deleted, err = repo.DeleteVotersByFilter(ctx, "general", nil)
+	if err != nil {
+		t.Fatalf("DeleteVotersByFilter failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 voter deleted, got %d", deleted)
+	}
+
+	voters, _ := repo.ListVoters(ctx)
+	if len(voters) != 1 || voters[0]["voter_type"] != "judge" {
+		t.Errorf("expected only the judge to remain, got %v", voters)
+	}
+}
+
+func TestTestVoters_ExcludedFromResults(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+
+	real, _ := repo.CreateVoterFull(ctx, nil, "Parent", "", "general", "REAL-1", "")
+	practice, _ := repo.CreateVoterFull(ctx, nil, "Setup", "", "general", "TEST-1", "")
+	if err := repo.SetVoterTest(ctx, int(practice), true); err != nil {
+		t.Fatalf("SetVoterTest failed: %v", err)
+	}
+	_ = repo.SaveVote(ctx, int(real), int(catID), cars[0].ID)
+	_ = repo.SaveVote(ctx, int(practice), int(catID), cars[0].ID)
+
+	results, err := repo.GetVoteResults(ctx)
+	if err != nil {
+		t.Fatalf("GetVoteResults failed: %v", err)
+	}
+	if got := results[int(catID)][cars[0].ID]; got != 1 {
+		t.Errorf("expected 1 counted vote, got %d", got)
+	}
+
+	rows, _ := repo.GetVoteResultsWithCars(ctx)
+	if len(rows) != 1 || rows[0].VoteCount != 1 {
+		t.Errorf("expected 1 counted vote with cars, got %+v", rows)
+	}
+	rows, _ = repo.GetAllVoteResultsWithCars(ctx)
+	if len(rows) != 1 || rows[0].VoteCount != 2 {
+		t.Errorf("expected 2 votes including test voters, got %+v", rows)
+	}
+	winners, _ := repo.GetWinnersForDerbyNet(ctx)
+	if len(winners) != 1 || winners[0].VoteCount != 1 {
+		t.Errorf("expected the DerbyNet winner to count 1 vote, got %+v", winners)
+	}
+
+	voters, _ := repo.ListVoters(ctx)
+	for _, v := range voters {
+		if v["is_test"] != (v["qr_code"] == "TEST-1") {
+			t.Errorf("unexpected is_test for %v: %v", v["qr_code"], v["is_test"])
+		}
+	}
+
+	deleted, err := repo.DeleteTestVoters(ctx)
+	if err != nil {
+		t.Fatalf("DeleteTestVoters failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 test voter deleted, got %d", deleted)
+	}
+	if count, _ := repo.CountVotesForCategory(ctx, int(catID)); count != 1 {
+		t.Errorf("expected the real vote to remain, got %d votes", count)
+	}
+	if _, err := repo.GetVoterByQR(ctx, "REAL-1"); err != nil {
+		t.Errorf("expected real voter to remain: %v", err)
+	}
+}
+
+func TestRequireCompleteBallot_ExcludesIncompleteBallots(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	carID := cars[0].ID
+	openCat, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	racerCat, _ := repo.CreateCategory(ctx, "Racers' Choice", 2, nil, []string{"racer"}, nil, nil)
+
+	parent, _ := repo.CreateVoterFull(ctx, nil, "Parent", "", "general", "PARENT-1", "")
+	racer, _ := repo.CreateVoterFull(ctx, nil, "Scout", "", "racer", "RACER-1", "")
+	_ = repo.SaveVote(ctx, int(parent), int(openCat), carID)
+	_ = repo.SaveVote(ctx, int(racer), int(openCat), carID)
+
+	counted := func() int {
+		t.Helper()
+		results, err := repo.GetVoteResults(ctx)
+		if err != nil {
+			t.Fatalf("GetVoteResults failed: %v", err)
+		}
+		return results[int(openCat)][carID]
+	}
+
+	if got := counted(); got != 2 {
+		t.Errorf("expected both votes counted with the setting off, got %d", got)
+	}
+
+	if err := repo.SetSetting(ctx, "require_complete_ballot", "true"); err != nil {
+		t.Fatalf("SetSetting failed: %v", err)
+	}
+	if got := counted(); got != 1 {
+		t.Errorf("expected only the complete ballot counted, got %d", got)
+	}
+
+	_ = repo.SaveVote(ctx, int(racer), int(racerCat), carID)
+	if got := counted(); got != 2 {
+		t.Errorf("expected the racer's ballot to count once complete, got %d", got)
+	}
+	winners, _ := repo.GetWinnersForDerbyNet(ctx)
+	if len(winners) == 0 || winners[0].VoteCount != 2 {
+		t.Errorf("expected DerbyNet winners to count complete ballots, got %+v", winners)
+	}
+
+	// A new category leaves every ballot incomplete again
+	_, _ = repo.CreateCategory(ctx, "Most Colorful", 3, nil, nil, nil, nil)
+	if got := counted(); got != 0 {
+		t.Errorf("expected no complete ballots after adding a category, got %d", got)
+	}
+}
+
+func TestSetCategoryCars_LimitsResults(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Sibling Car", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Scout", "Car 1", "")
+	_ = repo.CreateCar(ctx, "102", "Sibling", "Car 2", "")
+	cars, _ := repo.ListCars(ctx)
+	voter1, _ := repo.CreateVoter(ctx, "SUBSET-1")
+	voter2, _ := repo.CreateVoter(ctx, "SUBSET-2")
+	_ = repo.SaveVote(ctx, voter1, int(catID), cars[0].ID)
+	_ = repo.SaveVote(ctx, voter2, int(catID), cars[1].ID)
+
+	if allowed, _ := repo.CategoryAllowsCar(ctx, int(catID), cars[0].ID); !allowed {
+		t.Error("expected every car allowed before a subset is set")
+	}
+
+	if err := repo.SetCategoryCars(ctx, int(catID), []int{cars[1].ID, cars[1].ID}); err != nil {
+		t.Fatalf("SetCategoryCars failed: %v", err)
+	}
+	categories, _ := repo.ListCategories(ctx)
+	if len(categories[0].CarIDs) != 1 || categories[0].CarIDs[0] != cars[1].ID {
+		t.Errorf("expected car_ids [%d], got %v", cars[1].ID, categories[0].CarIDs)
+	}
+	if allowed, _ := repo.CategoryAllowsCar(ctx, int(catID), cars[0].ID); allowed {
+		t.Error("expected a car outside the subset not to be allowed")
+	}
+
+	results, err := repo.GetVoteResults(ctx)
+	if err != nil {
+		t.Fatalf("GetVoteResults failed: %v", err)
+	}
+	if results[int(catID)][cars[0].ID] != 0 || results[int(catID)][cars[1].ID] != 1 {
+		t.Errorf("expected only the subset car counted, got %v", results[int(catID)])
+	}
+
+	var appErr *errors.Error
+	err = repo.SetCategoryCars(ctx, int(catID), []int{9999})
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrNotFound {
+		t.Errorf("expected not found for an unknown car, got %v", err)
+	}
+	err = repo.SetCategoryCars(ctx, 9999, nil)
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrNotFound {
+		t.Errorf("expected not found for an unknown category, got %v", err)
+	}
+	// A failed update leaves the subset as it was
+	if categories, _ := repo.ListCategories(ctx); len(categories[0].CarIDs) != 1 {
+		t.Errorf("expected the subset unchanged, got %v", categories[0].CarIDs)
+	}
+}
+
+func TestDeleteVotersByFilter_NotVoted(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	_, _ = repo.CreateVoter(ctx, "NOT-VOTED-1")
+	_, _ = repo.CreateVoter(ctx, "NOT-VOTED-2")
+
+	hasVoted := false
+	deleted, err := repo.DeleteVotersByFilter(ctx, "", &hasVoted)
+	if err != nil {
+		t.Fatalf("DeleteVotersByFilter failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 voters deleted, got %d", deleted)
+	}
+}
+
+func TestDeleteVotersByFilter_DBError(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.db.Close()
+
+	_, err := repo.DeleteVotersByFilter(context.Background(), "general", nil)
+	if err == nil {
+		t.Error("expected error with closed database")
+	}
+}
+
+func TestClearVoterVotes(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	cat1, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	cat2, _ := repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoter(ctx, "CLEAR-QR1")
+	otherID, _ := repo.CreateVoter(ctx, "CLEAR-QR2")
+	_ = repo.SaveVote(ctx, voterID, int(cat1), cars[0].ID)
+	_ = repo.SaveVote(ctx, voterID, int(cat2), cars[0].ID)
+	_ = repo.SaveVote(ctx, otherID, int(cat1), cars[0].ID)
+
+	cleared, err := repo.ClearVoterVotes(ctx, voterID)
+	if err != nil {
+		t.Fatalf("ClearVoterVotes failed: %v", err)
+	}
+	if cleared != 2 {
+		t.Errorf("expected 2 votes cleared, got %d", cleared)
+	}
+
+	votes, _ := repo.GetVoterVotes(ctx, voterID)
+	if len(votes) != 0 {
+		t.Errorf("expected no votes left, got %v", votes)
+	}
+	votes, _ = repo.GetVoterVotes(ctx, otherID)
+	if len(votes) != 1 {
+		t.Errorf("expected other voter's vote to remain, got %v", votes)
+	}
+	if _, err := repo.GetVoterByQR(ctx, "CLEAR-QR1"); err != nil {
+		t.Errorf("expected voter record to remain, got %v", err)
+	}
+}
+
+func TestVoidVote(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	cat1, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	cat2, _ := repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoter(ctx, "VOID-QR1")
+	_ = repo.SaveVote(ctx, voterID, int(cat1), cars[0].ID)
+	_ = repo.SaveVote(ctx, voterID, int(cat2), cars[0].ID)
+	before := repo.ResultsVersion()
+
+	carID, err := repo.VoidVote(ctx, voterID, int(cat1), "Judge double-scanned")
+	if err != nil {
+		t.Fatalf("VoidVote failed: %v", err)
+	}
+	if carID != cars[0].ID {
+		t.Errorf("expected voided car %d, got %d", cars[0].ID, carID)
+	}
+	if repo.ResultsVersion() == before {
+		t.Error("expected VoidVote to invalidate results")
+	}
+
+	votes, _ := repo.GetVoterVotes(ctx, voterID)
+	if _, ok := votes[int(cat1)]; ok || len(votes) != 1 {
+		t.Errorf("expected only the category %d vote to remain, got %v", cat2, votes)
+	}
+
+	entries, err := repo.ListAuditLog(ctx)
+	if err != nil {
+		t.Fatalf("ListAuditLog failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Action != models.AuditActionVoteVoided || entry.Reason != "Judge double-scanned" {
+		t.Errorf("unexpected audit entry: %+v", entry)
+	}
+	if entry.VoterID == nil || *entry.VoterID != voterID ||
+		entry.CategoryID == nil || *entry.CategoryID != int(cat1) ||
+		entry.CarID == nil || *entry.CarID != cars[0].ID {
+		t.Errorf("unexpected audit entry IDs: %+v", entry)
+	}
+	if entry.CreatedAt == "" {
+		t.Error("expected audit entry to be timestamped")
+	}
+
+	// The entry outlives the voter it names
+	if err := repo.DeleteVoter(ctx, voterID); err != nil {
+		t.Fatalf("DeleteVoter failed: %v", err)
+	}
+	if entries, _ := repo.ListAuditLog(ctx); len(entries) != 1 {
+		t.Errorf("expected audit entry to remain after deleting the voter, got %d", len(entries))
+	}
+}
+
+func TestVoidVote_NotFound(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	_, err := repo.VoidVote(ctx, 9999, 1, "No such vote")
+	var appErr *errors.Error
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrNotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if entries, _ := repo.ListAuditLog(ctx); len(entries) != 0 {
+		t.Errorf("expected nothing recorded for a missing vote, got %d entries", len(entries))
+	}
+}
+
+func TestListStaleVoters(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	cat1, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	cat2, _ := repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Judges' Pick", 3, nil, []string{"judge"}, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+
+	partialID, _ := repo.CreateVoter(ctx, "STALE-PARTIAL")
+	completeID, _ := repo.CreateVoter(ctx, "STALE-COMPLETE")
+	judgeID, _ := repo.CreateVoterFull(ctx, nil, "Judge", "", "judge", "STALE-JUDGE", "")
+	readerID, _ := repo.CreateVoter(ctx, "STALE-READER")
+	_ = repo.SaveVote(ctx, partialID, int(cat1), cars[0].ID)
+	_ = repo.SaveVote(ctx, completeID, int(cat1), cars[0].ID)
+	_ = repo.SaveVote(ctx, completeID, int(cat2), cars[0].ID)
+	_ = repo.SaveVote(ctx, int(judgeID), int(cat1), cars[0].ID)
+	_ = repo.SaveVote(ctx, int(judgeID), int(cat2), cars[0].ID)
+	if err := repo.TouchVoterActivity(ctx, readerID); err != nil {
+		t.Fatalf("TouchVoterActivity failed: %v", err)
+	}
+
+	// Everyone is idle relative to a cutoff in the future
+	stale, err := repo.ListStaleVoters(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ListStaleVoters failed: %v", err)
+	}
+	if len(stale) != 2 {
+		t.Fatalf("expected 2 stale voters, got %+v", stale)
+	}
+	byQR := map[string]models.StaleVoter{}
+	for _, v := range stale {
+		byQR[v.QRCode] = v
+	}
+	if v := byQR["STALE-PARTIAL"]; v.VotesCast != 1 || v.CategoriesAvailable != 2 || v.LastActivityAt == "" {
+		t.Errorf("unexpected partial voter: %+v", v)
+	}
+	if v := byQR["STALE-JUDGE"]; v.VotesCast != 2 || v.CategoriesAvailable != 3 || v.Name != "Judge" {
+		t.Errorf("unexpected judge voter: %+v", v)
+	}
+
+	// Nobody has been idle for a minute yet
+	stale, err = repo.ListStaleVoters(ctx, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("ListStaleVoters failed: %v", err)
+	}
+	if len(stale) != 0 {
+		t.Errorf("expected no stale voters, got %+v", stale)
+	}
+}
+
+func TestVoterGroupTurnout(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+
+	wolf1, _ := repo.CreateVoter(ctx, "WOLF-1")
+	wolf2, _ := repo.CreateVoter(ctx, "WOLF-2")
+	bear, _ := repo.CreateVoter(ctx, "BEAR-1")
+	loner, _ := repo.CreateVoter(ctx, "LONER-1")
+	tester, _ := repo.CreateVoter(ctx, "TEST-1")
+	for id, group := range map[int]string{wolf1: "Wolf Den", wolf2: "Wolf Den", bear: "Bear Den", tester: "Wolf Den"} {
+		if err := repo.SetVoterGroup(ctx, id, group); err != nil {
+			t.Fatalf("SetVoterGroup failed: %v", err)
+		}
+	}
+	_ = repo.SetVoterTest(ctx, tester, true)
+	_ = repo.SaveVote(ctx, wolf1, int(catID), cars[0].ID)
+	_ = repo.SaveVote(ctx, tester, int(catID), cars[0].ID)
+	_ = repo.SaveVote(ctx, loner, int(catID), cars[0].ID)
+
+	groups, err := repo.ListVoterGroupTurnout(ctx)
+	if err != nil {
+		t.Fatalf("ListVoterGroupTurnout failed: %v", err)
+	}
+	want := []models.VoterGroupTurnout{
+		{VoterGroup: "Bear Den", Voters: 1},
+		{VoterGroup: "Wolf Den", Voters: 2, Voted: 1, CompleteBallots: 1, TurnoutRate: 0.5},
+		{VoterGroup: "", Voters: 1, Voted: 1, CompleteBallots: 1, TurnoutRate: 1},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("expected %+v, got %+v", want, groups)
+	}
+
+	// Clearing the group moves the voter to the ungrouped bucket
+	if err := repo.SetVoterGroup(ctx, bear, ""); err != nil {
+		t.Fatalf("SetVoterGroup failed: %v", err)
+	}
+	voters, _ := repo.ListVoters(ctx)
+	for _, voter := range voters {
+		if voter["qr_code"] == "BEAR-1" {
+			if _, ok := voter["voter_group"]; ok {
+				t.Errorf("expected no voter_group after clearing, got %v", voter)
+			}
+		}
+		if voter["qr_code"] == "WOLF-1" && voter["voter_group"] != "Wolf Den" {
+			t.Errorf("expected Wolf Den, got %v", voter)
+		}
+	}
+}
+
+func TestClearVoterVotes_NotFound(t *testing.T) {
+	repo := newTestRepo(t)
+
+	_, err := repo.ClearVoterVotes(context.Background(), 9999)
+	var appErr *errors.Error
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrNotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
 func TestListVoters_Empty(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -326,7 +792,7 @@ func TestListVoters_AllFieldCombinations(t *testing.T) {
 	voterID2, _ := repo.CreateVoterFull(ctx, &carID, "Full Name", "email@test.com", "general", "FULL-QR", "Some notes")
 
 	// Create category and make one voter vote (to set last_voted_at)
-	catID, _ := repo.CreateCategory(ctx, "TestCat", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "TestCat", 1, nil, nil, nil, nil)
 	_ = repo.SaveVote(ctx, int(voterID2), int(catID), carID)
 
 	// List voters and verify all field combinations are handled
@@ -418,6 +884,51 @@ func TestGetVoterQRCode_NonExistent(t *testing.T) {
 	}
 }
 
+func TestVoterShortToken(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := repo.CreateVoter(ctx, "SHORT-TEST")
+	if err != nil {
+		t.Fatalf("CreateVoter failed: %v", err)
+	}
+	if _, err := repo.GetVoterShortToken(ctx, id); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound before a token is set, got %v", err)
+	}
+
+	if err := repo.SetVoterShortToken(ctx, id, "K7MQ2P"); err != nil {
+		t.Fatalf("SetVoterShortToken failed: %v", err)
+	}
+	token, err := repo.GetVoterShortToken(ctx, id)
+	if err != nil || token != "K7MQ2P" {
+		t.Errorf("expected token K7MQ2P, got %q (%v)", token, err)
+	}
+	qrCode, err := repo.GetQRCodeByShortToken(ctx, "K7MQ2P")
+	if err != nil || qrCode != "SHORT-TEST" {
+		t.Errorf("expected qr_code SHORT-TEST, got %q (%v)", qrCode, err)
+	}
+	if _, err := repo.GetQRCodeByShortToken(ctx, "NOPE22"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound for unknown token, got %v", err)
+	}
+
+	// A token belongs to one voter
+	other, err := repo.CreateVoter(ctx, "SHORT-OTHER")
+	if err != nil {
+		t.Fatalf("CreateVoter failed: %v", err)
+	}
+	if err := repo.SetVoterShortToken(ctx, other, "K7MQ2P"); err == nil {
+		t.Error("expected error reusing a token")
+	}
+
+	// Deleting the voter removes the link
+	if err := repo.DeleteVoter(ctx, id); err != nil {
+		t.Fatalf("DeleteVoter failed: %v", err)
+	}
+	if _, err := repo.GetQRCodeByShortToken(ctx, "K7MQ2P"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound after deleting the voter, got %v", err)
+	}
+}
+
 func TestGetVoterByQRCode_Existing(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -471,7 +982,7 @@ func TestCreateCategory_Basic(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	id, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	id, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -500,13 +1011,13 @@ func TestCreateCategory_WithGroup(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a group first
-	groupID, err := repo.CreateCategoryGroup(ctx, "Main Awards", "Primary awards", nil, nil, 1)
+	groupID, err := repo.CreateCategoryGroup(ctx, "Main Awards", "Primary awards", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
 
 	gID := int(groupID)
-	id, err := repo.CreateCategory(ctx, "Best Speed", 1, &gID, nil, nil)
+	id, err := repo.CreateCategory(ctx, "Best Speed", 1, &gID, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -537,7 +1048,7 @@ func TestCreateCategory_DBError(t *testing.T) {
 	// Close the database to force an error
 	repo.db.Close()
 
-	_, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	_, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err == nil {
 		t.Error("expected error when database is closed")
 	}
@@ -547,12 +1058,12 @@ func TestUpdateCategory_Basic(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	id, err := repo.CreateCategory(ctx, "Original Name", 1, nil, nil, nil)
+	id, err := repo.CreateCategory(ctx, "Original Name", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
 
-	err = repo.UpdateCategory(ctx, int(id), "Updated Name", 2, nil, nil, nil, true)
+	err = repo.UpdateCategory(ctx, int(id), "Updated Name", 2, nil, nil, nil, true, nil)
 	if err != nil {
 		t.Fatalf("UpdateCategory failed: %v", err)
 	}
@@ -576,7 +1087,7 @@ func TestDeleteCategory_SoftDelete(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	id, err := repo.CreateCategory(ctx, "To Delete", 1, nil, nil, nil)
+	id, err := repo.CreateCategory(ctx, "To Delete", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -622,7 +1133,7 @@ func TestCategoryExists_True(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	_, err := repo.CreateCategory(ctx, "Existing Category", 1, nil, nil, nil)
+	_, err := repo.CreateCategory(ctx, "Existing Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -654,8 +1165,8 @@ func TestListAllCategories_IncludesInactive(t *testing.T) {
 	ctx := context.Background()
 
 	// Create two categories
-	id1, _ := repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil)
-	_, _ = repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil)
+	id1, _ := repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil, nil)
 
 	// Soft delete one
 	_ = repo.DeleteCategory(ctx, int(id1))
@@ -689,7 +1200,7 @@ func TestCreateCategoryGroup_Basic(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	id, err := repo.CreateCategoryGroup(ctx, "Design Awards", "Categories for design", nil, nil, 1)
+	id, err := repo.CreateCategoryGroup(ctx, "Design Awards", "Categories for design", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
@@ -717,7 +1228,7 @@ func TestCreateCategoryGroup_WithExclusivityPool(t *testing.T) {
 	ctx := context.Background()
 
 	poolID := 42
-	id, err := repo.CreateCategoryGroup(ctx, "Exclusive Group", "", &poolID, nil, 1)
+	id, err := repo.CreateCategoryGroup(ctx, "Exclusive Group", "", &poolID, nil, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
@@ -732,6 +1243,10 @@ func TestCreateCategoryGroup_WithExclusivityPool(t *testing.T) {
 	if group.ExclusivityPoolID == nil || *group.ExclusivityPoolID != poolID {
 		t.Errorf("expected exclusivity_pool_id %d, got %v", poolID, group.ExclusivityPoolID)
 	}
+	// A bare pool number is registered as a managed pool
+	if group.ExclusivityPoolName != "Pool 42" {
+		t.Errorf("expected exclusivity_pool_name %q, got %q", "Pool 42", group.ExclusivityPoolName)
+	}
 }
 
 func TestCreateCategoryGroup_DBError(t *testing.T) {
@@ -741,7 +1256,7 @@ func TestCreateCategoryGroup_DBError(t *testing.T) {
 	// Close the database to force an error
 	repo.db.Close()
 
-	_, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, 1)
+	_, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, "", 1)
 	if err == nil {
 		t.Error("expected error when database is closed")
 	}
@@ -751,7 +1266,7 @@ func TestGetCategoryGroup_Existing(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	id, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, 1)
+	id, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
@@ -786,12 +1301,12 @@ func TestUpdateCategoryGroup_Basic(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	_, err := repo.CreateCategoryGroup(ctx, "Original", "Original desc", nil, nil, 1)
+	_, err := repo.CreateCategoryGroup(ctx, "Original", "Original desc", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
 
-	err = repo.UpdateCategoryGroup(ctx, "1", "Updated", "Updated desc", nil, nil, 2)
+	err = repo.UpdateCategoryGroup(ctx, "1", "Updated", "Updated desc", nil, nil, "", 2)
 	if err != nil {
 		t.Fatalf("UpdateCategoryGroup failed: %v", err)
 	}
@@ -812,7 +1327,7 @@ func TestDeleteCategoryGroup_Basic(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	_, err := repo.CreateCategoryGroup(ctx, "To Delete", "", nil, nil, 1)
+	_, err := repo.CreateCategoryGroup(ctx, "To Delete", "", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
@@ -832,6 +1347,99 @@ func TestDeleteCategoryGroup_Basic(t *testing.T) {
 	}
 }
 
+// ==================== Exclusivity Pool Tests ====================
+
+func TestExclusivityPools_CRUD(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := repo.CreateExclusivityPool(ctx, "One award per car")
+	if err != nil {
+		t.Fatalf("CreateExclusivityPool failed: %v", err)
+	}
+	poolID := int(id)
+	if _, err := repo.CreateCategoryGroup(ctx, "Speed", "", &poolID, nil, "", 1); err != nil {
+		t.Fatalf("CreateCategoryGroup failed: %v", err)
+	}
+
+	// Names are unique ignoring case
+	_, err = repo.CreateExclusivityPool(ctx, "ONE AWARD PER CAR")
+	var appErr *errors.Error
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrConflict {
+		t.Errorf("expected conflict for duplicate name, got %v", err)
+	}
+
+	pool, err := repo.GetExclusivityPoolByName(ctx, "one award per car")
+	if err != nil {
+		t.Fatalf("GetExclusivityPoolByName failed: %v", err)
+	}
+	if pool.ID != poolID || pool.GroupCount != 1 {
+		t.Errorf("unexpected pool: %+v", pool)
+	}
+
+	if err := repo.UpdateExclusivityPool(ctx, poolID, "Design awards"); err != nil {
+		t.Fatalf("UpdateExclusivityPool failed: %v", err)
+	}
+	// Renaming to its own name in a different case is allowed
+	if err := repo.UpdateExclusivityPool(ctx, poolID, "Design Awards"); err != nil {
+		t.Errorf("expected rename to same name to succeed, got %v", err)
+	}
+	if err := repo.UpdateExclusivityPool(ctx, 9999, "Missing"); !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrNotFound {
+		t.Errorf("expected not found renaming a missing pool, got %v", err)
+	}
+
+	pools, err := repo.ListExclusivityPools(ctx)
+	if err != nil {
+		t.Fatalf("ListExclusivityPools failed: %v", err)
+	}
+	if len(pools) != 1 || pools[0].Name != "Design Awards" || pools[0].GroupCount != 1 {
+		t.Errorf("unexpected pools: %+v", pools)
+	}
+
+	// Deleting a pool takes its groups out of it
+	if err := repo.DeleteExclusivityPool(ctx, poolID); err != nil {
+		t.Fatalf("DeleteExclusivityPool failed: %v", err)
+	}
+	group, _ := repo.GetCategoryGroup(ctx, "1")
+	if group.ExclusivityPoolID != nil {
+		t.Errorf("expected group to leave the deleted pool, got %v", *group.ExclusivityPoolID)
+	}
+	if err := repo.DeleteExclusivityPool(ctx, poolID); !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrNotFound {
+		t.Errorf("expected not found deleting a missing pool, got %v", err)
+	}
+}
+
+func TestFindConflictingVote_UsesManagedPools(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	id, _ := repo.CreateExclusivityPool(ctx, "Speed awards")
+	poolID := int(id)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Speed", "", &poolID, nil, "", 1)
+	groupIDInt := int(groupID)
+	cat1, _ := repo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil, nil)
+	cat2, _ := repo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoter(ctx, "POOL-QR")
+	_ = repo.SaveVote(ctx, voterID, int(cat1), cars[0].ID)
+
+	if _, _, found, _ := repo.FindConflictingVote(ctx, voterID, cars[0].ID, int(cat2), int64(poolID)); !found {
+		t.Fatal("expected conflict within the pool")
+	}
+
+	// Once the pool is deleted its categories are no longer exclusive
+	if err := repo.DeleteExclusivityPool(ctx, poolID); err != nil {
+		t.Fatalf("DeleteExclusivityPool failed: %v", err)
+	}
+	if _, hasPool, _ := repo.GetExclusivityPoolID(ctx, int(cat2)); hasPool {
+		t.Error("expected category to have no pool after the pool is deleted")
+	}
+	if _, _, found, _ := repo.FindConflictingVote(ctx, voterID, cars[0].ID, int(cat2), int64(poolID)); found {
+		t.Error("expected no conflict after the pool is deleted")
+	}
+}
+
 // ==================== Car Tests ====================
 
 func TestListCars_Empty(t *testing.T) {
@@ -982,24 +1590,305 @@ func TestGetCarByDerbyNetID_NonExistent(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	_, exists, err := repo.GetCarByDerbyNetID(ctx, 99999)
-	if err != nil {
-		t.Fatalf("GetCarByDerbyNetID failed: %v", err)
+	_, exists, err := repo.GetCarByDerbyNetID(ctx, 99999)
+	if err != nil {
+		t.Fatalf("GetCarByDerbyNetID failed: %v", err)
+	}
+	if exists {
+		t.Error("expected car to not exist")
+	}
+}
+
+// ==================== Vote Tests ====================
+
+func TestSetCategoryDerbyNetAwardID(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	id, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	awardID := 7
+	if err := repo.SetCategoryDerbyNetAwardID(ctx, int(id), &awardID); err != nil {
+		t.Fatalf("SetCategoryDerbyNetAwardID failed: %v", err)
+	}
+	categories, _ := repo.ListCategories(ctx)
+	if categories[0].DerbyNetAwardID == nil || *categories[0].DerbyNetAwardID != awardID {
+		t.Errorf("expected award %d, got %v", awardID, categories[0].DerbyNetAwardID)
+	}
+
+	if err := repo.SetCategoryDerbyNetAwardID(ctx, int(id), nil); err != nil {
+		t.Fatalf("clearing award failed: %v", err)
+	}
+	categories, _ = repo.ListCategories(ctx)
+	if categories[0].DerbyNetAwardID != nil {
+		t.Errorf("expected award cleared, got %d", *categories[0].DerbyNetAwardID)
+	}
+
+	if err := repo.SetCategoryDerbyNetAwardID(ctx, 999, nil); err == nil {
+		t.Error("expected error for missing category")
+	}
+}
+
+func TestSetCategoryGroup(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	poolID := 1
+	groupID64, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "", &poolID, nil, "", 1)
+	groupID := int(groupID64)
+	inPool, _ := repo.CreateCategory(ctx, "Best Paint", 1, &groupID, nil, nil, nil)
+	moved, _ := repo.CreateCategory(ctx, "Best Theme", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	voterID, _ := repo.CreateVoter(ctx, "V1")
+	_ = repo.SaveVote(ctx, voterID, int(inPool), 1)
+	_ = repo.SaveVote(ctx, voterID, int(moved), 1)
+
+	if count, err := repo.CountPoolConflicts(ctx, int(moved), poolID+1); err != nil || count != 0 {
+		t.Fatalf("expected no conflicts with another pool, got %d, %v", count, err)
+	}
+
+	if err := repo.SetCategoryGroup(ctx, int(moved), &groupID); err != nil {
+		t.Fatalf("SetCategoryGroup failed: %v", err)
+	}
+	categories, _ := repo.ListCategories(ctx)
+	if categories[1].GroupID == nil || *categories[1].GroupID != groupID {
+		t.Errorf("expected group %d, got %v", groupID, categories[1].GroupID)
+	}
+	if count, err := repo.CountPoolConflicts(ctx, int(moved), poolID); err != nil || count != 1 {
+		t.Errorf("expected 1 voter in conflict, got %d, %v", count, err)
+	}
+
+	if err := repo.SetCategoryGroup(ctx, int(moved), nil); err != nil {
+		t.Fatalf("removing group failed: %v", err)
+	}
+	categories, _ = repo.ListCategories(ctx)
+	if categories[1].GroupID != nil {
+		t.Errorf("expected no group, got %d", *categories[1].GroupID)
+	}
+
+	var appErr *errors.Error
+	if err := repo.SetCategoryGroup(ctx, 999, nil); !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrNotFound {
+		t.Errorf("expected not found for missing category, got %v", err)
+	}
+}
+
+func TestCategoryTags(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := repo.CreateCategory(ctx, "Best Paint", 1, nil, nil, nil, []string{"Design", "Den"})
+	if err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	categories, _ := repo.ListCategories(ctx)
+	if len(categories[0].Tags) != 2 || categories[0].Tags[0] != "Design" || categories[0].Tags[1] != "Den" {
+		t.Errorf("expected tags [Design Den], got %v", categories[0].Tags)
+	}
+	all, _ := repo.ListAllCategories(ctx)
+	if tags, ok := all[0]["tags"].([]string); !ok || len(tags) != 2 {
+		t.Errorf("expected 2 tags in ListAllCategories, got %v", all[0]["tags"])
+	}
+
+	if err := repo.UpdateCategory(ctx, int(id), "Best Paint", 1, nil, nil, nil, true, nil); err != nil {
+		t.Fatalf("clearing tags failed: %v", err)
+	}
+	categories, _ = repo.ListCategories(ctx)
+	if categories[0].Tags != nil {
+		t.Errorf("expected tags cleared, got %v", categories[0].Tags)
+	}
+	all, _ = repo.ListAllCategories(ctx)
+	if _, ok := all[0]["tags"]; ok {
+		t.Errorf("expected no tags key, got %v", all[0]["tags"])
+	}
+
+	if err := repo.UpdateCategory(ctx, int(id), "Best Paint", 1, nil, nil, nil, true, []string{"Pack"}); err != nil {
+		t.Fatalf("UpdateCategory failed: %v", err)
+	}
+	if categories, _ = repo.ListCategories(ctx); len(categories[0].Tags) != 1 || categories[0].Tags[0] != "Pack" {
+		t.Errorf("expected tags replaced by update, got %v", categories[0].Tags)
+	}
+}
+
+func TestSetCategoryAllowWriteIn(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	id, _ := repo.CreateCategory(ctx, "Best Paint", 1, nil, nil, nil, nil)
+	categories, _ := repo.ListCategories(ctx)
+	if categories[0].AllowWriteIn {
+		t.Error("expected write-ins to be off by default")
+	}
+
+	if err := repo.SetCategoryAllowWriteIn(ctx, int(id), true); err != nil {
+		t.Fatalf("SetCategoryAllowWriteIn failed: %v", err)
+	}
+	categories, _ = repo.ListCategories(ctx)
+	if !categories[0].AllowWriteIn {
+		t.Error("expected write-ins to be allowed")
+	}
+	all, _ := repo.ListAllCategories(ctx)
+	if all[0]["allow_write_in"] != true {
+		t.Errorf("expected allow_write_in in ListAllCategories, got %v", all[0]["allow_write_in"])
+	}
+
+	if err := repo.SetCategoryAllowWriteIn(ctx, 999, true); err == nil {
+		t.Error("expected error for missing category")
+	}
+}
+
+func TestSetCategoryShowLiveCounts(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	id, _ := repo.CreateCategory(ctx, "Crowd Favorite", 1, nil, nil, nil, nil)
+	categories, _ := repo.ListCategories(ctx)
+	if categories[0].ShowLiveCounts {
+		t.Error("expected live counts to be hidden by default")
+	}
+
+	if err := repo.SetCategoryShowLiveCounts(ctx, int(id), true); err != nil {
+		t.Fatalf("SetCategoryShowLiveCounts failed: %v", err)
+	}
+	categories, _ = repo.ListCategories(ctx)
+	if !categories[0].ShowLiveCounts {
+		t.Error("expected live counts to be shown")
+	}
+	all, _ := repo.ListAllCategories(ctx)
+	if all[0]["show_live_counts"] != true {
+		t.Errorf("expected show_live_counts in ListAllCategories, got %v", all[0]["show_live_counts"])
+	}
+
+	if err := repo.SetCategoryShowLiveCounts(ctx, 999, true); err == nil {
+		t.Error("expected error for missing category")
+	}
+}
+
+func TestSetCategoryBannerURL(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	id, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	if err := repo.SetCategoryBannerURL(ctx, int(id), "/categories/1/banner?v=1"); err != nil {
+		t.Fatalf("SetCategoryBannerURL failed: %v", err)
+	}
+	categories, _ := repo.ListCategories(ctx)
+	if categories[0].BannerURL != "/categories/1/banner?v=1" {
+		t.Errorf("expected banner_url to be set, got %q", categories[0].BannerURL)
+	}
+	all, _ := repo.ListAllCategories(ctx)
+	if all[0]["banner_url"] != "/categories/1/banner?v=1" {
+		t.Errorf("expected banner_url in ListAllCategories, got %v", all[0]["banner_url"])
+	}
+
+	if err := repo.SetCategoryBannerURL(ctx, int(id), ""); err != nil {
+		t.Fatalf("SetCategoryBannerURL failed: %v", err)
+	}
+	all, _ = repo.ListAllCategories(ctx)
+	if _, ok := all[0]["banner_url"]; ok {
+		t.Errorf("expected a cleared banner to be omitted, got %v", all[0]["banner_url"])
+	}
+
+	if err := repo.SetCategoryBannerURL(ctx, 999, "/banner.png"); err == nil {
+		t.Error("expected error for missing category")
+	}
+}
+
+func TestCreatedBy_RecordsActor(t *testing.T) {
+	repo := newTestRepo(t)
+	adminCtx := actor.With(context.Background(), "session-abc123")
+	ctx := context.Background()
+
+	// Records created outside an admin request, like pre-existing rows, have no creator
+	_, _ = repo.CreateCategory(ctx, "Fastest", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "100", "Racer", "Old Car", "")
+	_, _ = repo.CreateVoter(ctx, "SELF-QR")
+
+	_, _ = repo.CreateCategory(adminCtx, "Best Design", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(adminCtx, "101", "Racer", "New Car", "")
+	_, _ = repo.CreateVoterFull(adminCtx, nil, "Judge", "", "judge", "ADMIN-QR", "")
+
+	categories, _ := repo.ListAllCategories(ctx)
+	if _, ok := categories[0]["created_by"]; ok {
+		t.Errorf("expected no creator for Fastest, got %v", categories[0]["created_by"])
+	}
+	if categories[1]["created_by"] != "session-abc123" {
+		t.Errorf("expected creator on Best Design, got %v", categories[1]["created_by"])
+	}
+
+	cars, _, _ := repo.ListCarsPaged(ctx, "", nil, false, 0, 0)
+	if len(cars) != 2 || cars[0].CreatedBy != "" || cars[1].CreatedBy != "session-abc123" {
+		t.Errorf("expected only the second car to have a creator, got %+v", cars)
+	}
+	car, _ := repo.GetCar(ctx, cars[1].ID)
+	if car.CreatedBy != "session-abc123" {
+		t.Errorf("expected GetCar to return the creator, got %q", car.CreatedBy)
+	}
+
+	voters, _ := repo.ListVoters(ctx)
+	creators := map[string]interface{}{}
+	for _, v := range voters {
+		creators[v["qr_code"].(string)] = v["created_by"]
+	}
+	if creators["SELF-QR"] != nil || creators["ADMIN-QR"] != "session-abc123" {
+		t.Errorf("expected only ADMIN-QR to have a creator, got %v", creators)
+	}
+}
+
+func TestGetOrCreateWriteInCar(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	_ = repo.CreateCar(ctx, "1", "Racer", "Speedy", "")
+
+	id, err := repo.GetOrCreateWriteInCar(ctx, "Thunderbolt")
+	if err != nil {
+		t.Fatalf("GetOrCreateWriteInCar failed: %v", err)
+	}
+	again, err := repo.GetOrCreateWriteInCar(ctx, "thunderbolt")
+	if err != nil {
+		t.Fatalf("GetOrCreateWriteInCar failed: %v", err)
+	}
+	if again != id {
+		t.Errorf("expected write-in to be reused case-insensitively, got %d and %d", id, again)
+	}
+	// A registered car with the same name is not reused
+	if other, _ := repo.GetOrCreateWriteInCar(ctx, "Speedy"); other == id {
+		t.Error("expected a separate write-in car")
+	}
+
+	car, err := repo.GetCar(ctx, id)
+	if err != nil {
+		t.Fatalf("GetCar failed: %v", err)
+	}
+	if !car.WriteIn || car.Eligible || car.CarName != "Thunderbolt" {
+		t.Errorf("expected write-in car named Thunderbolt awaiting approval, got %+v", car)
+	}
+
+	// Write-ins stay out of car lists and stats
+	cars, _ := repo.ListCars(ctx)
+	if len(cars) != 1 {
+		t.Errorf("expected 1 listed car, got %d", len(cars))
 	}
-	if exists {
-		t.Error("expected car to not exist")
+	eligible, _ := repo.ListEligibleCars(ctx)
+	if len(eligible) != 1 {
+		t.Errorf("expected 1 eligible car, got %d", len(eligible))
+	}
+	paged, total, _ := repo.ListCarsPaged(ctx, "", nil, false, 0, 0)
+	if len(paged) != 1 || total != 1 {
+		t.Errorf("expected 1 paged car, got %d (total %d)", len(paged), total)
+	}
+	stats, _ := repo.GetVotingStats(ctx)
+	if stats["total_cars"] != 1 {
+		t.Errorf("expected 1 car in stats, got %v", stats["total_cars"])
 	}
 }
 
-// ==================== Vote Tests ====================
-
 func TestSaveVote_NewVote(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
 	// Create dependencies
 	voterID, _ := repo.CreateVoter(ctx, "VOTE-QR1")
-	categoryID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	categoryID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "1", "Racer", "Car", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1023,13 +1912,90 @@ func TestSaveVote_NewVote(t *testing.T) {
 	}
 }
 
+func TestResultsVersion_BumpsOnResultWrites(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	voterID, _ := repo.CreateVoter(ctx, "VOTE-QR1")
+	categoryID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "1", "Racer", "Car", "")
+
+	before := repo.ResultsVersion()
+	if err := repo.SaveVote(ctx, voterID, int(categoryID), 1); err != nil {
+		t.Fatalf("SaveVote failed: %v", err)
+	}
+	afterVote := repo.ResultsVersion()
+	if afterVote == before {
+		t.Error("expected SaveVote to bump results version")
+	}
+
+	if err := repo.SetManualWinner(ctx, int(categoryID), 1, "tie"); err != nil {
+		t.Fatalf("SetManualWinner failed: %v", err)
+	}
+	if repo.ResultsVersion() == afterVote {
+		t.Error("expected SetManualWinner to bump results version")
+	}
+
+	// Reads leave the version alone
+	version := repo.ResultsVersion()
+	repo.GetVoteResultsWithCars(ctx)
+	if repo.ResultsVersion() != version {
+		t.Error("expected reads not to bump results version")
+	}
+}
+
+func TestResultsVersion_BumpsOnStatsWrites(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	// Voter and car counts feed the stats, so adding either changes the version
+	writes := []struct {
+		name  string
+		write func() error
+	}{
+		{"CreateVoter", func() error { _, err := repo.CreateVoter(ctx, "STATS-QR1"); return err }},
+		{"CreateVoterFull", func() error {
+			_, err := repo.CreateVoterFull(ctx, nil, "Parent", "", "general", "STATS-QR2", "")
+			return err
+		}},
+		{"CreateCar", func() error { return repo.CreateCar(ctx, "1", "Racer", "Car", "") }},
+	}
+	for _, w := range writes {
+		before := repo.ResultsVersion()
+		if err := w.write(); err != nil {
+			t.Fatalf("%s failed: %v", w.name, err)
+		}
+		if repo.ResultsVersion() == before {
+			t.Errorf("expected %s to bump results version", w.name)
+		}
+	}
+}
+
+func TestResultsModifiedAt_AdvancesOnResultWrites(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	opened := repo.ResultsModifiedAt()
+	if opened.IsZero() {
+		t.Fatal("expected ResultsModifiedAt to be set when the repository opens")
+	}
+
+	time.Sleep(time.Millisecond)
+	if _, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil); err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	if !repo.ResultsModifiedAt().After(opened) {
+		t.Errorf("expected ResultsModifiedAt to advance past %v, got %v", opened, repo.ResultsModifiedAt())
+	}
+}
+
 func TestSaveVote_UpdateVote(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
 	// Create dependencies
 	voterID, _ := repo.CreateVoter(ctx, "VOTE-QR2")
-	categoryID, _ := repo.CreateCategory(ctx, "Best Speed", 1, nil, nil, nil)
+	categoryID, _ := repo.CreateCategory(ctx, "Best Speed", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "1", "Racer 1", "Car 1", "")
 	_ = repo.CreateCar(ctx, "2", "Racer 2", "Car 2", "")
 	cars, _ := repo.ListCars(ctx)
@@ -1067,7 +2033,7 @@ func TestSaveVote_DeleteVote(t *testing.T) {
 
 	// Create dependencies
 	voterID, _ := repo.CreateVoter(ctx, "VOTE-QR3")
-	categoryID, _ := repo.CreateCategory(ctx, "Best Color", 1, nil, nil, nil)
+	categoryID, _ := repo.CreateCategory(ctx, "Best Color", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "1", "Racer", "Car", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1112,9 +2078,9 @@ func TestGetVoterVotes_MultipleCategories(t *testing.T) {
 
 	// Create dependencies
 	voterID, _ := repo.CreateVoter(ctx, "MULTI-QR")
-	cat1ID, _ := repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil)
-	cat3ID, _ := repo.CreateCategory(ctx, "Category 3", 3, nil, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil, nil)
+	cat3ID, _ := repo.CreateCategory(ctx, "Category 3", 3, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "1", "Racer", "Car", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1151,7 +2117,7 @@ func TestGetVoteResults_WithVotes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create dependencies
-	categoryID, _ := repo.CreateCategory(ctx, "Popular Vote", 1, nil, nil, nil)
+	categoryID, _ := repo.CreateCategory(ctx, "Popular Vote", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "1", "Racer 1", "Car 1", "")
 	_ = repo.CreateCar(ctx, "2", "Racer 2", "Car 2", "")
 	cars, _ := repo.ListCars(ctx)
@@ -1203,7 +2169,7 @@ func TestGetVoteResultsWithCars_WithVotes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create dependencies
-	categoryID, _ := repo.CreateCategory(ctx, "Best in Show", 1, nil, nil, nil)
+	categoryID, _ := repo.CreateCategory(ctx, "Best in Show", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "42", "Winner Racer", "Champion Car", "http://photo.jpg")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1234,6 +2200,51 @@ func TestGetVoteResultsWithCars_WithVotes(t *testing.T) {
 	}
 }
 
+func TestListTopCars(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	cat1, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	cat2, _ := repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil, nil)
+	retired, _ := repo.CreateCategory(ctx, "Retired", 3, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	_ = repo.CreateCar(ctx, "103", "Racer Three", "Car C", "")
+	cars, _ := repo.ListCars(ctx)
+	v1, _ := repo.CreateVoter(ctx, "TOP-QR1")
+	v2, _ := repo.CreateVoter(ctx, "TOP-QR2")
+	tester, _ := repo.CreateVoter(ctx, "TOP-TEST")
+	_ = repo.SetVoterTest(ctx, tester, true)
+
+	// Car B leads with two votes across categories; Car A has one
+	_ = repo.SaveVote(ctx, v1, int(cat1), cars[1].ID)
+	_ = repo.SaveVote(ctx, v2, int(cat2), cars[1].ID)
+	_ = repo.SaveVote(ctx, v1, int(cat2), cars[0].ID)
+	// Neither test votes nor votes in inactive categories count
+	_ = repo.SaveVote(ctx, tester, int(cat1), cars[2].ID)
+	_ = repo.SaveVote(ctx, tester, int(cat2), cars[2].ID)
+	_ = repo.SaveVote(ctx, v2, int(retired), cars[2].ID)
+	_ = repo.UpdateCategory(ctx, int(retired), "Retired", 3, nil, nil, nil, false, nil)
+
+	top, err := repo.ListTopCars(ctx, 0)
+	if err != nil {
+		t.Fatalf("ListTopCars failed: %v", err)
+	}
+	if len(top) != 2 {
+		t.Fatalf("expected 2 cars with counted votes, got %+v", top)
+	}
+	if top[0].CarNumber != "102" || top[0].TotalVotes != 2 || top[0].RacerName != "Racer Two" {
+		t.Errorf("expected car 102 first with 2 votes, got %+v", top[0])
+	}
+	if top[1].CarNumber != "101" || top[1].TotalVotes != 1 {
+		t.Errorf("expected car 101 second with 1 vote, got %+v", top[1])
+	}
+
+	if top, _ := repo.ListTopCars(ctx, 1); len(top) != 1 || top[0].CarNumber != "102" {
+		t.Errorf("expected limit to keep only the leader, got %+v", top)
+	}
+}
+
 // ==================== Settings Tests ====================
 
 func TestGetSetting_DefaultValues(t *testing.T) {
@@ -1294,6 +2305,24 @@ func TestSetSetting_UpdateExisting(t *testing.T) {
 	}
 }
 
+func TestListSettings(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	_ = repo.SetSetting(ctx, "base_url", "http://voting.local")
+
+	settings, err := repo.ListSettings(ctx)
+	if err != nil {
+		t.Fatalf("ListSettings failed: %v", err)
+	}
+	if settings["base_url"] != "http://voting.local" {
+		t.Errorf("expected base_url to be listed, got %v", settings)
+	}
+	if _, ok := settings["voting_open"]; !ok {
+		t.Error("expected default voting_open setting to be listed")
+	}
+}
+
 func TestGetSetting_NonExistent(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -1310,7 +2339,7 @@ func TestGetExclusivityPoolID_NoPool(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	categoryID, _ := repo.CreateCategory(ctx, "No Pool Category", 1, nil, nil, nil)
+	categoryID, _ := repo.CreateCategory(ctx, "No Pool Category", 1, nil, nil, nil, nil)
 
 	_, hasPool, err := repo.GetExclusivityPoolID(ctx, int(categoryID))
 	if err != nil {
@@ -1326,9 +2355,9 @@ func TestGetExclusivityPoolID_WithPool(t *testing.T) {
 	ctx := context.Background()
 
 	poolID := 1
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Exclusive Group", "", &poolID, nil, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Exclusive Group", "", &poolID, nil, "", 1)
 	gID := int(groupID)
-	categoryID, _ := repo.CreateCategory(ctx, "Pooled Category", 1, &gID, nil, nil)
+	categoryID, _ := repo.CreateCategory(ctx, "Pooled Category", 1, &gID, nil, nil, nil)
 
 	pool, hasPool, err := repo.GetExclusivityPoolID(ctx, int(categoryID))
 	if err != nil {
@@ -1348,9 +2377,9 @@ func TestFindConflictingVote_NoConflict(t *testing.T) {
 
 	voterID, _ := repo.CreateVoter(ctx, "CONFLICT-QR1")
 	poolID := 1
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Exclusive", "", &poolID, nil, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Exclusive", "", &poolID, nil, "", 1)
 	gID := int(groupID)
-	categoryID, _ := repo.CreateCategory(ctx, "Cat 1", 1, &gID, nil, nil)
+	categoryID, _ := repo.CreateCategory(ctx, "Cat 1", 1, &gID, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "1", "Racer", "Car", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1370,10 +2399,10 @@ func TestFindConflictingVote_WithConflict(t *testing.T) {
 
 	voterID, _ := repo.CreateVoter(ctx, "CONFLICT-QR2")
 	poolID := 1
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Exclusive", "", &poolID, nil, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Exclusive", "", &poolID, nil, "", 1)
 	gID := int(groupID)
-	cat1ID, _ := repo.CreateCategory(ctx, "Cat 1", 1, &gID, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Cat 2", 2, &gID, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Cat 1", 1, &gID, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Cat 2", 2, &gID, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "1", "Racer", "Car", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1402,7 +2431,7 @@ func TestClearConflictingVote_Basic(t *testing.T) {
 	ctx := context.Background()
 
 	voterID, _ := repo.CreateVoter(ctx, "CLEAR-QR")
-	categoryID, _ := repo.CreateCategory(ctx, "Clear Test", 1, nil, nil, nil)
+	categoryID, _ := repo.CreateCategory(ctx, "Clear Test", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "1", "Racer", "Car", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1442,6 +2471,9 @@ func TestGetVotingStats_Empty(t *testing.T) {
 	if stats["total_votes"] != 0 {
 		t.Errorf("expected 0 total_votes, got %v", stats["total_votes"])
 	}
+	if stats["participation_rate"] != 0.0 {
+		t.Errorf("expected 0 participation_rate with no voters, got %v", stats["participation_rate"])
+	}
 }
 
 func TestGetVotingStats_WithData(t *testing.T) {
@@ -1449,7 +2481,7 @@ func TestGetVotingStats_WithData(t *testing.T) {
 	ctx := context.Background()
 
 	// Create data
-	categoryID, _ := repo.CreateCategory(ctx, "Stats Category", 1, nil, nil, nil)
+	categoryID, _ := repo.CreateCategory(ctx, "Stats Category", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "1", "Racer", "Car", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1482,6 +2514,39 @@ func TestGetVotingStats_WithData(t *testing.T) {
 	if stats["total_cars"] != 1 {
 		t.Errorf("expected 1 total_cars, got %v", stats["total_cars"])
 	}
+	if stats["unique_voters"] != 2 {
+		t.Errorf("expected 2 unique_voters, got %v", stats["unique_voters"])
+	}
+	if stats["participation_rate"] != 2.0/3.0 {
+		t.Errorf("expected participation_rate 2/3, got %v", stats["participation_rate"])
+	}
+}
+
+func TestGetVotingStats_LeavesOutTestVoters(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	categoryID, _ := repo.CreateCategory(ctx, "Stats Category", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "1", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+
+	realID, _ := repo.CreateVoter(ctx, "STATS-REAL")
+	_, _ = repo.CreateVoter(ctx, "STATS-IDLE")
+	practiceID, _ := repo.CreateVoter(ctx, "STATS-TEST")
+	_ = repo.SetVoterTest(ctx, practiceID, true)
+	_ = repo.SaveVote(ctx, realID, int(categoryID), cars[0].ID)
+	_ = repo.SaveVote(ctx, practiceID, int(categoryID), cars[0].ID)
+
+	stats, err := repo.GetVotingStats(ctx)
+	if err != nil {
+		t.Fatalf("GetVotingStats failed: %v", err)
+	}
+	if stats["total_voters"] != 2 || stats["unique_voters"] != 1 || stats["total_votes"] != 1 {
+		t.Errorf("expected test voter left out, got %v", stats)
+	}
+	if stats["participation_rate"] != 0.5 {
+		t.Errorf("expected participation_rate 1/2, got %v", stats["participation_rate"])
+	}
 }
 
 // ==================== Database Management Tests ====================
@@ -1511,6 +2576,48 @@ func TestClearTable_Voters(t *testing.T) {
 	}
 }
 
+func TestClearEventData(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	voter1, _ := repo.CreateVoter(ctx, "EVENT-1")
+	_, _ = repo.CreateVoter(ctx, "EVENT-2")
+	_ = repo.SaveVote(ctx, voter1, int(catID), cars[0].ID)
+	_ = repo.SetManualWinner(ctx, int(catID), cars[0].ID, "Resolved tie")
+	_ = repo.SetSetting(ctx, "event_name", "Spring Derby")
+
+	cleared, err := repo.ClearEventData(ctx)
+	if err != nil {
+		t.Fatalf("ClearEventData failed: %v", err)
+	}
+	if cleared["votes"] != 1 || cleared["voters"] != 2 || cleared["overrides"] != 1 {
+		t.Errorf("expected 1 vote, 2 voters and 1 override cleared, got %v", cleared)
+	}
+
+	if voters, _ := repo.ListVoters(ctx); len(voters) != 0 {
+		t.Errorf("expected no voters, got %d", len(voters))
+	}
+	categories, _ := repo.ListCategories(ctx)
+	if len(categories) != 2 {
+		t.Fatalf("expected categories to be kept, got %d", len(categories))
+	}
+	for _, cat := range categories {
+		if cat.OverrideWinnerCarID != nil {
+			t.Errorf("expected override cleared for %s", cat.Name)
+		}
+	}
+	if cars, _ := repo.ListCars(ctx); len(cars) != 1 {
+		t.Errorf("expected cars to be kept, got %d", len(cars))
+	}
+	if name, _ := repo.GetSetting(ctx, "event_name"); name != "Spring Derby" {
+		t.Errorf("expected settings to be kept, got event_name %q", name)
+	}
+}
+
 func TestInsertVoterIgnore_New(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -1625,9 +2732,9 @@ func TestListCategoryGroups_Multiple(t *testing.T) {
 
 	// Create multiple groups with different properties
 	poolID := 1
-	_, _ = repo.CreateCategoryGroup(ctx, "Group 1", "Description 1", nil, nil, 1)
-	_, _ = repo.CreateCategoryGroup(ctx, "Group 2", "Description 2", &poolID, nil, 2)
-	_, _ = repo.CreateCategoryGroup(ctx, "Group 3", "", nil, nil, 3)
+	_, _ = repo.CreateCategoryGroup(ctx, "Group 1", "Description 1", nil, nil, "", 1)
+	_, _ = repo.CreateCategoryGroup(ctx, "Group 2", "Description 2", &poolID, nil, "", 2)
+	_, _ = repo.CreateCategoryGroup(ctx, "Group 3", "", nil, nil, "", 3)
 
 	groups, err := repo.ListCategoryGroups(ctx)
 	if err != nil {
@@ -1662,9 +2769,9 @@ func TestListCategories_OrderedByDisplayOrder(t *testing.T) {
 	ctx := context.Background()
 
 	// Create categories out of order
-	_, _ = repo.CreateCategory(ctx, "Category C", 3, nil, nil, nil)
-	_, _ = repo.CreateCategory(ctx, "Category A", 1, nil, nil, nil)
-	_, _ = repo.CreateCategory(ctx, "Category B", 2, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Category C", 3, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Category A", 1, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Category B", 2, nil, nil, nil, nil)
 
 	categories, err := repo.ListCategories(ctx)
 	if err != nil {
@@ -1692,15 +2799,15 @@ func TestListCategories_AllOptionalFields(t *testing.T) {
 
 	// Create category group with exclusivity pool
 	poolID := 1
-	groupID, _ := repo.CreateCategoryGroup(ctx, "TestGroup", "Test Description", &poolID, nil, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "TestGroup", "Test Description", &poolID, nil, "", 1)
 
 	// Create category without optional fields
-	_, _ = repo.CreateCategory(ctx, "MinimalCat", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "MinimalCat", 1, nil, nil, nil, nil)
 
 	// Create category with all optional fields
 	derbynetAwardID := 100
 	groupIDInt := int(groupID)
-	catID2, _ := repo.CreateCategory(ctx, "FullCat", 2, &groupIDInt, nil, nil)
+	catID2, _ := repo.CreateCategory(ctx, "FullCat", 2, &groupIDInt, nil, nil, nil)
 
 	// Set derbynet_award_id via direct DB update
 	_, _ = repo.db.ExecContext(ctx, "UPDATE categories SET derbynet_award_id = ? WHERE id = ?", derbynetAwardID, catID2)
@@ -1758,10 +2865,10 @@ func TestListAllCategories_WithGroupInfo(t *testing.T) {
 	ctx := context.Background()
 
 	// Create group and category with group
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Test Group", "Group Description", nil, nil, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Test Group", "Group Description", nil, nil, "", 1)
 	gID := int(groupID)
-	_, _ = repo.CreateCategory(ctx, "Grouped Category", 1, &gID, nil, nil)
-	_, _ = repo.CreateCategory(ctx, "Ungrouped Category", 2, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Grouped Category", 1, &gID, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Ungrouped Category", 2, nil, nil, nil, nil)
 
 	categories, err := repo.ListAllCategories(ctx)
 	if err != nil {
@@ -1786,15 +2893,15 @@ func TestListAllCategories_AllOptionalFields(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category without optional fields
-	_, _ = repo.CreateCategory(ctx, "MinimalCat", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "MinimalCat", 1, nil, nil, nil, nil)
 
 	// Create category group
-	groupID, _ := repo.CreateCategoryGroup(ctx, "TestGroup", "Test Description", nil, nil, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "TestGroup", "Test Description", nil, nil, "", 1)
 
 	// Create category with all optional fields
 	derbynetAwardID := 200
 	gID := int(groupID)
-	catID2, _ := repo.CreateCategory(ctx, "FullCat", 2, &gID, nil, nil)
+	catID2, _ := repo.CreateCategory(ctx, "FullCat", 2, &gID, nil, nil, nil)
 
 	// Set derbynet_award_id via update
 	_, _ = repo.db.ExecContext(ctx, "UPDATE categories SET derbynet_award_id = ? WHERE id = ?", derbynetAwardID, catID2)
@@ -1906,13 +3013,234 @@ func TestListCars_Multiple(t *testing.T) {
 	}
 }
 
+func TestListCarsPaged(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	_ = repo.CreateCar(ctx, "1", "Alice Smith", "Rocket", "")
+	_ = repo.CreateCar(ctx, "2", "Bob Jones", "Blue 100%", "")
+	_ = repo.CreateCar(ctx, "10", "Carol Smith", "Comet", "")
+	_ = repo.SetCarEligibility(ctx, 3, false)
+
+	cars, total, err := repo.ListCarsPaged(ctx, "", nil, false, 2, 0)
+	if err != nil {
+		t.Fatalf("ListCarsPaged failed: %v", err)
+	}
+	if total != 3 || len(cars) != 2 || cars[0].CarNumber != "1" || cars[1].CarNumber != "2" {
+		t.Errorf("expected first page [1 2] of 3, got %+v (total %d)", cars, total)
+	}
+
+	cars, _, _ = repo.ListCarsPaged(ctx, "", nil, false, 2, 2)
+	if len(cars) != 1 || cars[0].CarNumber != "10" {
+		t.Errorf("expected second page [10], got %+v", cars)
+	}
+
+	// Search matches racer name case-insensitively
+	cars, total, _ = repo.ListCarsPaged(ctx, "smith", nil, false, 0, 0)
+	if total != 2 || len(cars) != 2 {
+		t.Errorf("expected 2 cars matching smith, got %d (total %d)", len(cars), total)
+	}
+
+	// LIKE wildcards in the search are matched literally
+	cars, _, _ = repo.ListCarsPaged(ctx, "%", nil, false, 0, 0)
+	if len(cars) != 1 || cars[0].CarName != "Blue 100%" {
+		t.Errorf("expected only the car named with %%, got %+v", cars)
+	}
+
+	eligible := false
+	cars, total, _ = repo.ListCarsPaged(ctx, "smith", &eligible, false, 0, 0)
+	if total != 1 || len(cars) != 1 || cars[0].CarNumber != "10" {
+		t.Errorf("expected ineligible car 10, got %+v (total %d)", cars, total)
+	}
+}
+
+func TestListCategoryBallots(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	categoryID, _ := repo.CreateCategory(ctx, "Best in Show", 1, nil, nil, nil, nil)
+	otherID, _ := repo.CreateCategory(ctx, "Fastest", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "42", "Winner Racer", "Champion Car", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoterFull(ctx, nil, "Pat", "", "judge", "BALLOT-QR", "")
+	_ = repo.SaveVote(ctx, int(voterID), int(categoryID), cars[0].ID)
+	_ = repo.SaveVote(ctx, int(voterID), int(otherID), cars[0].ID)
+
+	ballots, err := repo.ListCategoryBallots(ctx, int(categoryID))
+	if err != nil {
+		t.Fatalf("ListCategoryBallots failed: %v", err)
+	}
+	if len(ballots) != 1 {
+		t.Fatalf("expected 1 ballot, got %d", len(ballots))
+	}
+	b := ballots[0]
+	if b.VoterID != int(voterID) || b.VoterQR != "BALLOT-QR" || b.VoterName != "Pat" || b.VoterType != "judge" {
+		t.Errorf("unexpected voter details: %+v", b)
+	}
+	if b.CarID != cars[0].ID || b.CarNumber != "42" || b.RacerName != "Winner Racer" {
+		t.Errorf("unexpected car details: %+v", b)
+	}
+	if b.VotedAt == "" {
+		t.Error("expected voted_at to be set")
+	}
+
+	_, err = repo.ListCategoryBallots(ctx, 9999)
+	var appErr *errors.Error
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrNotFound {
+		t.Errorf("expected ErrNotFound for unknown category, got %v", err)
+	}
+}
+
+func TestListVotesForCategory(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	categoryID, _ := repo.CreateCategory(ctx, "Best in Show", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "42", "Winner Racer", "Champion Car", "")
+	cars, _ := repo.ListCars(ctx)
+
+	votes, err := repo.ListVotesForCategory(ctx, int(categoryID))
+	if err != nil {
+		t.Fatalf("ListVotesForCategory failed: %v", err)
+	}
+	if votes == nil || len(votes) != 0 {
+		t.Errorf("expected an empty slice without votes, got %v", votes)
+	}
+
+	late, _ := repo.CreateVoter(ctx, "LATE-QR")
+	early, _ := repo.CreateVoter(ctx, "EARLY-QR")
+	_ = repo.SaveVote(ctx, late, int(categoryID), cars[0].ID)
+	_ = repo.SaveVote(ctx, early, int(categoryID), cars[0].ID)
+	repo.db.Exec(`UPDATE votes SET created_at = '2026-05-02 18:00:00' WHERE voter_id = ?`, early)
+	repo.db.Exec(`UPDATE votes SET created_at = '2026-05-02 18:05:00' WHERE voter_id = ?`, late)
+
+	votes, err = repo.ListVotesForCategory(ctx, int(categoryID))
+	if err != nil {
+		t.Fatalf("ListVotesForCategory failed: %v", err)
+	}
+	if len(votes) != 2 || votes[0].VoterID != early || votes[1].VoterID != late {
+		t.Fatalf("expected the earliest vote first, got %+v", votes)
+	}
+	if votes[0].CarID != cars[0].ID || votes[0].CreatedAt == "" || votes[0].UpdatedAt == "" {
+		t.Errorf("unexpected vote details: %+v", votes[0])
+	}
+
+	_, err = repo.ListVotesForCategory(ctx, 9999)
+	var appErr *errors.Error
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrNotFound {
+		t.Errorf("expected ErrNotFound for unknown category, got %v", err)
+	}
+}
+
+func TestMergeVotes(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	categoryID, _ := repo.CreateCategory(ctx, "Best in Show", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "1", "Racer 1", "Car 1", "")
+	_ = repo.CreateCar(ctx, "2", "Racer 2", "Car 2", "")
+	cars, _ := repo.ListCars(ctx)
+	existing, _ := repo.CreateVoter(ctx, "MERGE-EXISTING")
+	_ = repo.SaveVote(ctx, existing, int(categoryID), cars[0].ID)
+
+	voters := []VoterMergeRow{
+		{QRCode: "MERGE-EXISTING", Name: "Renamed"},
+		{QRCode: "MERGE-NEW", Name: "Jamie", VoterType: "judge"},
+	}
+	votes := []VoteMergeRow{
+		{VoterQR: "MERGE-EXISTING", CategoryID: int(categoryID), CarID: cars[1].ID},
+		{VoterQR: "MERGE-NEW", CategoryID: int(categoryID), CarID: cars[1].ID},
+	}
+	votersAdded, votesAdded, err := repo.MergeVotes(ctx, voters, votes)
+	if err != nil {
+		t.Fatalf("MergeVotes failed: %v", err)
+	}
+	if votersAdded != 1 || votesAdded != 1 {
+		t.Errorf("expected 1 voter and 1 vote added, got %d and %d", votersAdded, votesAdded)
+	}
+
+	// Existing votes are never overwritten
+	if got, _ := repo.GetVoterVotes(ctx, existing); got[int(categoryID)] != cars[0].ID {
+		t.Errorf("expected existing vote to be kept, got %v", got)
+	}
+
+	exported, err := repo.ListVotesForExport(ctx)
+	if err != nil {
+		t.Fatalf("ListVotesForExport failed: %v", err)
+	}
+	if len(exported) != 2 {
+		t.Fatalf("expected 2 exported votes, got %d", len(exported))
+	}
+	for _, v := range exported {
+		if v.CategoryName != "Best in Show" || v.VotedAt == "" {
+			t.Errorf("unexpected exported vote: %+v", v)
+		}
+		if v.VoterQR == "MERGE-NEW" && v.CarNumber != "2" {
+			t.Errorf("expected merged vote for car 2, got %+v", v)
+		}
+	}
+
+	exportedVoters, err := repo.ListVotersForExport(ctx)
+	if err != nil {
+		t.Fatalf("ListVotersForExport failed: %v", err)
+	}
+	if len(exportedVoters) != 2 {
+		t.Errorf("expected 2 exported voters, got %d", len(exportedVoters))
+	}
+
+	// Test voters and their votes stay behind
+	testVoter, _ := repo.CreateVoter(ctx, "MERGE-TEST")
+	_ = repo.SaveVote(ctx, testVoter, int(categoryID), cars[1].ID)
+	_ = repo.SetVoterTest(ctx, testVoter, true)
+	if exported, _ := repo.ListVotesForExport(ctx); len(exported) != 2 {
+		t.Errorf("expected test votes left out of the export, got %d votes", len(exported))
+	}
+	if exportedVoters, _ := repo.ListVotersForExport(ctx); len(exportedVoters) != 2 {
+		t.Errorf("expected test voters left out of the export, got %d voters", len(exportedVoters))
+	}
+}
+
+func TestMergeVotes_WriteInAndVotedAt(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	categoryID, _ := repo.CreateCategory(ctx, "Best in Show", 1, nil, nil, nil, nil)
+	votedAt := time.Date(2026, 3, 14, 18, 30, 0, 0, time.UTC)
+	votes := []VoteMergeRow{
+		{VoterQR: "MERGE-WRITEIN", CategoryID: int(categoryID), WriteIn: "Rocket", VotedAt: votedAt},
+	}
+	_, votesAdded, err := repo.MergeVotes(ctx, []VoterMergeRow{{QRCode: "MERGE-WRITEIN", VoterType: "general"}}, votes)
+	if err != nil {
+		t.Fatalf("MergeVotes failed: %v", err)
+	}
+	if votesAdded != 1 {
+		t.Fatalf("expected 1 vote added, got %d", votesAdded)
+	}
+
+	carID, err := repo.FindWriteInCar(ctx, "rocket")
+	if err != nil {
+		t.Fatalf("expected the write-in car to be created: %v", err)
+	}
+	exported, _ := repo.ListVotesForExport(ctx)
+	if len(exported) != 1 || exported[0].CarID != carID {
+		t.Fatalf("expected the vote for the write-in car, got %+v", exported)
+	}
+	if got, err := time.Parse("2006-01-02 15:04:05-07:00", exported[0].VotedAt); err != nil || !got.Equal(votedAt) {
+		t.Errorf("expected voted_at %v to be kept, got %q", votedAt, exported[0].VotedAt)
+	}
+
+	if _, err := repo.FindWriteInCar(ctx, "Nobody Wrote This"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound for an unknown write-in, got %v", err)
+	}
+}
+
 func TestGetVoteResultsWithCars_MultipleCategories(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
 	// Create multiple categories and cars
-	cat1ID, _ := repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "1", "Racer 1", "Car 1", "http://1.jpg")
 	_ = repo.CreateCar(ctx, "2", "Racer 2", "Car 2", "")
 	cars, _ := repo.ListCars(ctx)
@@ -2041,7 +3369,7 @@ func TestUpsertCategory_UpdateExistingWithAwardID(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category without award ID
-	_, err := repo.CreateCategory(ctx, "Existing Category", 1, nil, nil, nil)
+	_, err := repo.CreateCategory(ctx, "Existing Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -2061,34 +3389,92 @@ func TestUpsertCategory_UpdateExistingWithAwardID(t *testing.T) {
 	if len(categories) != 1 {
 		t.Fatalf("expected 1 category, got %d", len(categories))
 	}
-	if categories[0].DisplayOrder != 2 {
-		t.Errorf("expected display_order 2, got %d", categories[0].DisplayOrder)
+	if categories[0].DisplayOrder != 2 {
+		t.Errorf("expected display_order 2, got %d", categories[0].DisplayOrder)
+	}
+	if categories[0].DerbyNetAwardID == nil || *categories[0].DerbyNetAwardID != awardID {
+		t.Errorf("expected derbynet_award_id %d, got %v", awardID, categories[0].DerbyNetAwardID)
+	}
+}
+
+func TestUpsertCategory_PreservesExistingAwardID(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	// Create with award ID
+	awardID := 123
+	_, _ = repo.UpsertCategory(ctx, "Award Category", 1, &awardID)
+
+	// Upsert without award ID (should preserve existing)
+	_, err := repo.UpsertCategory(ctx, "Award Category", 2, nil)
+	if err != nil {
+		t.Fatalf("UpsertCategory failed: %v", err)
+	}
+
+	categories, _ := repo.ListCategories(ctx)
+	if len(categories) != 1 {
+		t.Fatalf("expected 1 category, got %d", len(categories))
+	}
+	if categories[0].DerbyNetAwardID == nil || *categories[0].DerbyNetAwardID != awardID {
+		t.Errorf("expected preserved derbynet_award_id %d, got %v", awardID, categories[0].DerbyNetAwardID)
+	}
+}
+
+func TestImportCategories(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	existingGroup, _ := repo.CreateCategoryGroup(ctx, "Design", "", nil, nil, "", 1)
+	_, _ = repo.CreateCategory(ctx, "Best Paint", 9, nil, []string{"adult"}, nil, nil)
+
+	created, groupsCreated, err := repo.ImportCategories(ctx, []CategoryImportRow{
+		{Name: "Best Paint", DisplayOrder: 1, GroupName: "Design"},
+		{Name: "Most Creative", DisplayOrder: 2, GroupName: "Design", AllowedRanks: []string{"Tiger", "Wolf"}},
+		{Name: "Fastest Looking", DisplayOrder: 3, GroupName: "Speed"},
+		{Name: "Funniest", DisplayOrder: 4},
+	})
+	if err != nil {
+		t.Fatalf("ImportCategories failed: %v", err)
+	}
+	if want := []bool{false, true, true, true}; fmt.Sprint(created) != fmt.Sprint(want) {
+		t.Errorf("expected created %v, got %v", want, created)
+	}
+	if groupsCreated != 1 {
+		t.Errorf("expected 1 group created, got %d", groupsCreated)
+	}
+
+	categories, _ := repo.ListCategories(ctx)
+	if len(categories) != 4 {
+		t.Fatalf("expected 4 categories, got %d", len(categories))
+	}
+	paint := categories[0]
+	if paint.Name != "Best Paint" || paint.GroupID == nil || *paint.GroupID != int(existingGroup) {
+		t.Errorf("expected Best Paint moved into the existing group, got %+v", paint)
+	}
+	if len(paint.AllowedVoterTypes) != 1 {
+		t.Errorf("expected allowed voter types to be kept, got %v", paint.AllowedVoterTypes)
+	}
+	if len(categories[1].AllowedRanks) != 2 {
+		t.Errorf("expected 2 allowed ranks, got %v", categories[1].AllowedRanks)
+	}
+	if categories[2].GroupName != "Speed" {
+		t.Errorf("expected new group Speed, got %q", categories[2].GroupName)
 	}
-	if categories[0].DerbyNetAwardID == nil || *categories[0].DerbyNetAwardID != awardID {
-		t.Errorf("expected derbynet_award_id %d, got %v", awardID, categories[0].DerbyNetAwardID)
+	if categories[3].GroupID != nil {
+		t.Errorf("expected ungrouped category, got group %v", *categories[3].GroupID)
 	}
 }
 
-func TestUpsertCategory_PreservesExistingAwardID(t *testing.T) {
+func TestImportCategories_DBError(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	// Create with award ID
-	awardID := 123
-	_, _ = repo.UpsertCategory(ctx, "Award Category", 1, &awardID)
-
-	// Upsert without award ID (should preserve existing)
-	_, err := repo.UpsertCategory(ctx, "Award Category", 2, nil)
-	if err != nil {
-		t.Fatalf("UpsertCategory failed: %v", err)
-	}
+	// Close the database to force an error
+	repo.db.Close()
 
-	categories, _ := repo.ListCategories(ctx)
-	if len(categories) != 1 {
-		t.Fatalf("expected 1 category, got %d", len(categories))
-	}
-	if categories[0].DerbyNetAwardID == nil || *categories[0].DerbyNetAwardID != awardID {
-		t.Errorf("expected preserved derbynet_award_id %d, got %v", awardID, categories[0].DerbyNetAwardID)
+	_, _, err := repo.ImportCategories(ctx, []CategoryImportRow{{Name: "Best Paint", DisplayOrder: 1}})
+	if err == nil {
+		t.Error("expected error when database is closed")
 	}
 }
 
@@ -2097,7 +3483,7 @@ func TestUpsertCategory_PreservesInactiveState(t *testing.T) {
 	ctx := context.Background()
 
 	// Create and delete category
-	id, _ := repo.CreateCategory(ctx, "Inactive Category", 1, nil, nil, nil)
+	id, _ := repo.CreateCategory(ctx, "Inactive Category", 1, nil, nil, nil, nil)
 	_ = repo.DeleteCategory(ctx, int(id))
 
 	// Verify inactive
@@ -2186,6 +3572,36 @@ func TestGetWinnersForDerbyNet_WithVotes(t *testing.T) {
 	}
 }
 
+func TestGetWinnersForDerbyNet_PendingWriteInCannotWin(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	categoryID, _ := repo.CreateCategory(ctx, "Crowd Favorite", 1, nil, nil, nil, nil)
+	_ = repo.UpsertCar(ctx, 100, "101", "Racer", "Registered", "", "")
+	cars, _ := repo.ListCars(ctx)
+	writeInID, _ := repo.GetOrCreateWriteInCar(ctx, "Mystery Car")
+
+	voter1, _ := repo.CreateVoter(ctx, "PENDING-QR1")
+	voter2, _ := repo.CreateVoter(ctx, "PENDING-QR2")
+	voter3, _ := repo.CreateVoter(ctx, "PENDING-QR3")
+	_ = repo.SaveVote(ctx, voter1, int(categoryID), writeInID)
+	_ = repo.SaveVote(ctx, voter2, int(categoryID), writeInID)
+	_ = repo.SaveVote(ctx, voter3, int(categoryID), cars[0].ID)
+
+	winners, err := repo.GetWinnersForDerbyNet(ctx)
+	if err != nil {
+		t.Fatalf("GetWinnersForDerbyNet failed: %v", err)
+	}
+	if len(winners) != 1 || winners[0].CarID != cars[0].ID {
+		t.Errorf("expected the registered car to win over the unapproved write-in, got %+v", winners)
+	}
+
+	_ = repo.SetCarEligibility(ctx, writeInID, true)
+	if winners, _ := repo.GetWinnersForDerbyNet(ctx); len(winners) != 1 || winners[0].CarID != writeInID {
+		t.Errorf("expected the approved write-in to win, got %+v", winners)
+	}
+}
+
 func TestGetWinnersForDerbyNet_MultipleCategories(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -2227,7 +3643,7 @@ func TestGetWinnersForDerbyNet_CategoryWithoutAwardID(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category WITHOUT DerbyNet award ID
-	_, _ = repo.CreateCategory(ctx, "Local Only", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Only", 1, nil, nil, nil, nil)
 	categories, _ := repo.ListCategories(ctx)
 	categoryID := categories[0].ID
 
@@ -2730,6 +4146,64 @@ func TestNew_InvalidPath(t *testing.T) {
 	}
 }
 
+func TestNew_MemoryConcurrentQueries(t *testing.T) {
+	repo := newTestRepo(t)
+	defer repo.Close()
+	ctx := context.Background()
+
+	if err := repo.CreateCar(ctx, "101", "Racer", "Car", ""); err != nil {
+		t.Fatalf("CreateCar failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cars, err := repo.ListCars(ctx)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if len(cars) != 1 {
+				errs <- fmt.Errorf("expected 1 car, got %d", len(cars))
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent query failed: %v", err)
+	}
+
+	// Tables must still exist after the pool has been exercised
+	if _, err := repo.GetVotingStats(ctx); err != nil {
+		t.Fatalf("schema lost after concurrent queries: %v", err)
+	}
+}
+
+func TestNew_MemoryReposAreIsolated(t *testing.T) {
+	repo1 := newTestRepo(t)
+	defer repo1.Close()
+	repo2 := newTestRepo(t)
+	defer repo2.Close()
+	ctx := context.Background()
+
+	if err := repo1.CreateCar(ctx, "101", "Racer", "Car", ""); err != nil {
+		t.Fatalf("CreateCar failed: %v", err)
+	}
+
+	cars, err := repo2.ListCars(ctx)
+	if err != nil {
+		t.Fatalf("ListCars failed: %v", err)
+	}
+	if len(cars) != 0 {
+		t.Errorf("expected separate :memory: repos to be isolated, got %d cars", len(cars))
+	}
+}
+
 func TestGetVotingStats_Errors(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -2873,6 +4347,68 @@ func TestGetWinnersForDerbyNet_Errors(t *testing.T) {
 	}
 }
 
+func TestSaveBallot(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	voterID, _ := repo.CreateVoter(ctx, "BALLOT-QR")
+	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Best Speed", 2, nil, nil, nil, nil)
+	cat3ID, _ := repo.CreateCategory(ctx, "Best Color", 3, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "1", "Racer 1", "Car 1", "")
+	_ = repo.CreateCar(ctx, "2", "Racer 2", "Car 2", "")
+	cars, _ := repo.ListCars(ctx)
+
+	_ = repo.SaveVote(ctx, voterID, int(cat3ID), cars[0].ID)
+
+	before := repo.ResultsVersion()
+	err := repo.SaveBallot(ctx, voterID, map[int]int{
+		int(cat1ID): cars[0].ID,
+		int(cat2ID): cars[1].ID,
+		int(cat3ID): 0,
+	})
+	if err != nil {
+		t.Fatalf("SaveBallot failed: %v", err)
+	}
+	if repo.ResultsVersion() == before {
+		t.Error("expected SaveBallot to bump results version")
+	}
+
+	votes, err := repo.GetVoterVotes(ctx, voterID)
+	if err != nil {
+		t.Fatalf("GetVoterVotes failed: %v", err)
+	}
+	if len(votes) != 2 || votes[int(cat1ID)] != cars[0].ID || votes[int(cat2ID)] != cars[1].ID {
+		t.Errorf("expected ballot votes to be saved and category %d cleared, got %v", cat3ID, votes)
+	}
+}
+
+func TestSaveBallot_RollsBackOnError(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	voterID, _ := repo.CreateVoter(ctx, "BALLOT-QR")
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "1", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+
+	// Without the voters table the last_voted_at update fails after the
+	// vote has been written, so the whole ballot must be rolled back
+	if _, err := repo.DB().Exec(`DROP TABLE voters`); err != nil {
+		t.Fatalf("failed to drop voters table: %v", err)
+	}
+
+	if err := repo.SaveBallot(ctx, voterID, map[int]int{int(catID): cars[0].ID}); err == nil {
+		t.Fatal("expected SaveBallot to fail")
+	}
+
+	var count int
+	repo.DB().QueryRow(`SELECT COUNT(*) FROM votes`).Scan(&count)
+	if count != 0 {
+		t.Errorf("expected no votes after rollback, got %d", count)
+	}
+}
+
 func TestSaveVote_UpdateLastVotedError(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -2880,7 +4416,7 @@ func TestSaveVote_UpdateLastVotedError(t *testing.T) {
 	// Create voter, car and category
 	voterID, _ := repo.CreateVoter(ctx, "VOTE-TEST")
 	_ = repo.CreateCar(ctx, "100", "Racer", "Car", "")
-	catID, _ := repo.CreateCategory(ctx, "Test", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Test", 1, nil, nil, nil, nil)
 
 	// Use UpsertCar with derbynet ID so we can look it up
 	_ = repo.UpsertCar(ctx, 100, "100", "Racer", "Car", "", "")
@@ -2904,7 +4440,7 @@ func TestUpsertCategory_UpdateExistingError(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category first
-	catID, err := repo.CreateCategory(ctx, "Test Cat", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Cat", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -2937,7 +4473,7 @@ func TestFindConflictingVote_NoConflictFound(t *testing.T) {
 	voterID, _ := repo.CreateVoter(ctx, "CONFLICT-TEST")
 	_ = repo.UpsertCar(ctx, 100, "100", "Racer", "Car", "", "")
 	carID, _, _ := repo.GetCarByDerbyNetID(ctx, 100)
-	catID, _ := repo.CreateCategory(ctx, "Test", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Test", 1, nil, nil, nil, nil)
 
 	// Find conflict when none exists
 	conflictCatID, conflictName, hasConflict, err := repo.FindConflictingVote(ctx, voterID, int(carID), int(catID), 1)
@@ -3031,7 +4567,7 @@ func TestSetManualWinner_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "42", "John Smith", "Speed Demon", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -3068,7 +4604,7 @@ func TestSetManualWinner_UpdateExisting(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and two cars
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "42", "John", "Car A", "")
 	_ = repo.CreateCar(ctx, "7", "Sarah", "Car B", "")
 	cars, _ := repo.ListCars(ctx)
@@ -3104,7 +4640,7 @@ func TestClearManualWinner_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "42", "John", "Speed Demon", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -3138,7 +4674,7 @@ func TestClearManualWinner_NoOverrideExists(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category without override
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	// Clear non-existent override (should not error)
 	err := repo.ClearManualWinner(ctx, int(catID))
@@ -3160,7 +4696,7 @@ func TestGetWinnersForDerbyNet_WithOverride(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category with derbynet_award_id
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	awardID := 100
 	repo.UpsertCategory(ctx, "Best Design", 1, &awardID)
 
@@ -3217,7 +4753,7 @@ func TestGetWinnersForDerbyNet_WithoutOverride(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	awardID := 100
 	repo.UpsertCategory(ctx, "Best Design", 1, &awardID)
 
@@ -3265,7 +4801,7 @@ func TestGetWinnersForDerbyNet_OverrideWithNoVotes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	awardID := 100
 	repo.UpsertCategory(ctx, "Best Design", 1, &awardID)
 
@@ -3303,7 +4839,7 @@ func TestSetManualWinner_WithInactiveCar(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "42", "John", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -3341,7 +4877,7 @@ func TestListCategories_IncludesOverrideFields(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "42", "John", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -3381,7 +4917,7 @@ func TestListAllCategories_IncludesOverrideFields(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "42", "John", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -3433,7 +4969,7 @@ func TestCategoryGroup_CreateWithMaxWins_AndReadBack(t *testing.T) {
 	ctx := context.Background()
 
 	maxWins := 2
-	id, err := repo.CreateCategoryGroup(ctx, "Design Awards", "Design categories", nil, &maxWins, 1)
+	id, err := repo.CreateCategoryGroup(ctx, "Design Awards", "Design categories", nil, &maxWins, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
@@ -3456,14 +4992,14 @@ func TestCategoryGroup_UpdateMaxWins_FromNullToValue(t *testing.T) {
 	ctx := context.Background()
 
 	// Create without max_wins_per_car
-	id, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, 1)
+	id, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
 
 	// Update to set max_wins_per_car
 	newMaxWins := 1
-	err = repo.UpdateCategoryGroup(ctx, fmt.Sprintf("%d", id), "Updated Group", "Updated desc", nil, &newMaxWins, 2)
+	err = repo.UpdateCategoryGroup(ctx, fmt.Sprintf("%d", id), "Updated Group", "Updated desc", nil, &newMaxWins, "", 2)
 	if err != nil {
 		t.Fatalf("UpdateCategoryGroup failed: %v", err)
 	}
@@ -3484,13 +5020,13 @@ func TestCategoryGroup_UpdateMaxWins_FromValueToNull(t *testing.T) {
 
 	// Create with max_wins_per_car
 	maxWins := 2
-	id, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, &maxWins, 1)
+	id, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, &maxWins, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
 
 	// Update to clear max_wins_per_car
-	err = repo.UpdateCategoryGroup(ctx, fmt.Sprintf("%d", id), "Updated Group", "Updated desc", nil, nil, 2)
+	err = repo.UpdateCategoryGroup(ctx, fmt.Sprintf("%d", id), "Updated Group", "Updated desc", nil, nil, "", 2)
 	if err != nil {
 		t.Fatalf("UpdateCategoryGroup failed: %v", err)
 	}
@@ -3508,14 +5044,14 @@ func TestCategoryGroup_UpdateMaxWins_FromValueToValue(t *testing.T) {
 
 	// Create with max_wins_per_car = 1
 	oldMaxWins := 1
-	id, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, &oldMaxWins, 1)
+	id, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, &oldMaxWins, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
 
 	// Update to max_wins_per_car = 3
 	newMaxWins := 3
-	err = repo.UpdateCategoryGroup(ctx, fmt.Sprintf("%d", id), "Updated Group", "Updated desc", nil, &newMaxWins, 2)
+	err = repo.UpdateCategoryGroup(ctx, fmt.Sprintf("%d", id), "Updated Group", "Updated desc", nil, &newMaxWins, "", 2)
 	if err != nil {
 		t.Fatalf("UpdateCategoryGroup failed: %v", err)
 	}
@@ -3530,6 +5066,90 @@ func TestCategoryGroup_UpdateMaxWins_FromValueToValue(t *testing.T) {
 	}
 }
 
+func TestCategoryGroup_MultiWinStrategy_DefaultAndUpdate(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := repo.CreateCategoryGroup(ctx, "Test Group", "Description", nil, nil, "", 1)
+	if err != nil {
+		t.Fatalf("CreateCategoryGroup failed: %v", err)
+	}
+
+	group, _ := repo.GetCategoryGroup(ctx, fmt.Sprintf("%d", id))
+	if group.MultiWinStrategy != "manual" {
+		t.Errorf("expected default strategy 'manual', got %q", group.MultiWinStrategy)
+	}
+
+	err = repo.UpdateCategoryGroup(ctx, fmt.Sprintf("%d", id), "Test Group", "Description", nil, nil, "auto_runner_up", 1)
+	if err != nil {
+		t.Fatalf("UpdateCategoryGroup failed: %v", err)
+	}
+
+	groups, _ := repo.ListCategoryGroups(ctx)
+	if len(groups) != 1 || groups[0].MultiWinStrategy != "auto_runner_up" {
+		t.Errorf("expected strategy 'auto_runner_up' in list, got %+v", groups)
+	}
+}
+
+func TestGetCarDerbyNetRacerID(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	_ = repo.UpsertCar(ctx, 42, "101", "Racer", "Car", "", "")
+	_ = repo.CreateCar(ctx, "102", "Local Racer", "Local Car", "")
+	cars, _ := repo.ListCars(ctx)
+
+	racerID, err := repo.GetCarDerbyNetRacerID(ctx, cars[0].ID)
+	if err != nil {
+		t.Fatalf("GetCarDerbyNetRacerID failed: %v", err)
+	}
+	if racerID == nil || *racerID != 42 {
+		t.Errorf("expected racer ID 42, got %v", racerID)
+	}
+
+	racerID, err = repo.GetCarDerbyNetRacerID(ctx, cars[1].ID)
+	if err != nil {
+		t.Fatalf("GetCarDerbyNetRacerID failed: %v", err)
+	}
+	if racerID != nil {
+		t.Errorf("expected nil racer ID for unlinked car, got %d", *racerID)
+	}
+
+	if _, err := repo.GetCarDerbyNetRacerID(ctx, 9999); err == nil {
+		t.Error("expected error for missing car, got nil")
+	}
+}
+
+func TestSetCarDerbyNetRacerID(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	carID := cars[0].ID
+
+	racerID := 42
+	if err := repo.SetCarDerbyNetRacerID(ctx, carID, &racerID); err != nil {
+		t.Fatalf("SetCarDerbyNetRacerID failed: %v", err)
+	}
+	got, _ := repo.GetCarDerbyNetRacerID(ctx, carID)
+	if got == nil || *got != racerID {
+		t.Errorf("expected racer %d, got %v", racerID, got)
+	}
+
+	if err := repo.SetCarDerbyNetRacerID(ctx, carID, nil); err != nil {
+		t.Fatalf("clearing racer failed: %v", err)
+	}
+	got, _ = repo.GetCarDerbyNetRacerID(ctx, carID)
+	if got != nil {
+		t.Errorf("expected racer cleared, got %d", *got)
+	}
+
+	if err := repo.SetCarDerbyNetRacerID(ctx, 999, nil); err == nil {
+		t.Error("expected error for missing car")
+	}
+}
+
 func TestCategoryGroup_ListIncludesMaxWinsPerCar(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -3537,9 +5157,9 @@ func TestCategoryGroup_ListIncludesMaxWinsPerCar(t *testing.T) {
 	// Create groups with different max_wins_per_car values
 	maxWins1 := 1
 	maxWins2 := 2
-	repo.CreateCategoryGroup(ctx, "Group 1", "Has max=1", nil, &maxWins1, 1)
-	repo.CreateCategoryGroup(ctx, "Group 2", "Has max=2", nil, &maxWins2, 2)
-	repo.CreateCategoryGroup(ctx, "Group 3", "No max", nil, nil, 3)
+	repo.CreateCategoryGroup(ctx, "Group 1", "Has max=1", nil, &maxWins1, "", 1)
+	repo.CreateCategoryGroup(ctx, "Group 2", "Has max=2", nil, &maxWins2, "", 2)
+	repo.CreateCategoryGroup(ctx, "Group 3", "No max", nil, nil, "", 3)
 
 	groups, err := repo.ListCategoryGroups(ctx)
 	if err != nil {
@@ -3585,7 +5205,7 @@ func TestSetManualWinner_Basic(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 
@@ -3621,7 +5241,7 @@ func TestClearManualWinner_Basic(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 
@@ -3651,7 +5271,7 @@ func TestClearManualWinner_WhenNoOverride(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category without override
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	// Try to clear (should not error)
 	err := repo.ClearManualWinner(ctx, int(catID))
@@ -3665,7 +5285,7 @@ func TestManualWinner_ForeignKeyConstraint_DeleteCar(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -3696,8 +5316,8 @@ func TestListCategories_ReturnsOverrideFields(t *testing.T) {
 	ctx := context.Background()
 
 	// Create categories
-	cat1ID, _ := repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil, nil)
 
 	// Create cars
 	repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -3744,29 +5364,32 @@ func TestListCategories_ReturnsOverrideFields(t *testing.T) {
 // ==================== Error Path Tests with sqlmock ====================
 
 func TestMigrate_ExecutionFailure(t *testing.T) {
-	// Create a mock database that will fail on a specific migration
+	// Create a mock database that will fail on the first migration
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
 	if err != nil {
 		t.Fatalf("failed to create sqlmock: %v", err)
 	}
 	defer db.Close()
 
-	// Expect first migration to succeed
-	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(0, 0))
+	// Expect the migrations table to be created and found empty
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version FROM schema_migrations").WillReturnRows(sqlmock.NewRows([]string{"version"}))
 
-	// Expect second migration to fail
+	// Expect the first migration to fail and roll back
+	mock.ExpectBegin()
 	mock.ExpectExec(".*").WillReturnError(fmt.Errorf("migration failed"))
+	mock.ExpectRollback()
 
 	// Create repository with mock db
 	repo := &Repository{db: db}
 	err = repo.migrate()
 
 	if err == nil {
-		t.Error("expected migrate to fail, but it succeeded")
+		t.Fatal("expected migrate to fail, but it succeeded")
 	}
 
-	if err.Error() != "migration failed" {
-		t.Errorf("expected error 'migration failed', got '%v'", err)
+	if err.Error() != "migration 1 (create base tables): migration failed" {
+		t.Errorf("expected error naming the failed migration, got '%v'", err)
 	}
 
 	// Verify all expectations were met
@@ -3808,6 +5431,79 @@ func TestNew_InvalidDatabasePath(t *testing.T) {
 	}
 }
 
+func TestNewReadOnly(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "derby.db")
+
+	repo, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	_, _ = repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	repo.Close()
+
+	ro, err := NewReadOnly(path)
+	if err != nil {
+		t.Fatalf("NewReadOnly failed: %v", err)
+	}
+	defer ro.Close()
+
+	categories, err := ro.ListCategories(ctx)
+	if err != nil || len(categories) != 1 {
+		t.Errorf("expected to read 1 category, got %d, %v", len(categories), err)
+	}
+	if _, err := ro.CreateCategory(ctx, "Blocked", 2, nil, nil, nil, nil); err == nil {
+		t.Error("expected write to fail on a read-only database")
+	}
+}
+
+func TestNewReadOnly_ResultsVersionFollowsOtherWriters(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "derby.db")
+
+	repo, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer repo.Close()
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoter(ctx, "QR-ONE")
+
+	ro, err := NewReadOnly(path)
+	if err != nil {
+		t.Fatalf("NewReadOnly failed: %v", err)
+	}
+	defer ro.Close()
+
+	before := ro.ResultsVersion()
+	if ro.ResultsVersion() != before {
+		t.Fatal("expected the version to hold while nothing is written")
+	}
+
+	if err := repo.SaveVote(ctx, voterID, int(catID), cars[0].ID); err != nil {
+		t.Fatalf("SaveVote failed: %v", err)
+	}
+	if ro.ResultsVersion() == before {
+		t.Error("expected a write by another connection to change the read-only results version")
+	}
+}
+
+func TestNewReadOnly_RequiresMigratedDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.db")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("failed to create empty database: %v", err)
+	}
+	if _, err := NewReadOnly(path); err == nil {
+		t.Error("expected NewReadOnly to fail on an unmigrated database")
+	}
+
+	if _, err := NewReadOnly(filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Error("expected NewReadOnly to fail on a missing database")
+	}
+}
+
 func TestNew_ForeignKeyPragmaSuccess(t *testing.T) {
 	// Create a database to verify foreign keys are enabled
 	repo := newTestRepo(t)
@@ -3819,6 +5515,187 @@ func TestNew_ForeignKeyPragmaSuccess(t *testing.T) {
 	}
 }
 
+func TestNew_AppliesDefaultPragmas(t *testing.T) {
+	repo, err := New(filepath.Join(t.TempDir(), "derby.db"))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer repo.Close()
+
+	var journalMode string
+	var busyTimeout, synchronous int
+	repo.db.QueryRow("PRAGMA journal_mode").Scan(&journalMode)
+	repo.db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout)
+	repo.db.QueryRow("PRAGMA synchronous").Scan(&synchronous)
+
+	if journalMode != "wal" {
+		t.Errorf("expected journal_mode wal, got %q", journalMode)
+	}
+	if busyTimeout != 5000 {
+		t.Errorf("expected busy_timeout 5000, got %d", busyTimeout)
+	}
+	if synchronous != 1 { // NORMAL
+		t.Errorf("expected synchronous NORMAL (1), got %d", synchronous)
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	repo, err := NewWithOptions(filepath.Join(t.TempDir(), "derby.db"), Options{JournalMode: "delete", Synchronous: "full"})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer repo.Close()
+
+	var journalMode string
+	var synchronous int
+	repo.db.QueryRow("PRAGMA journal_mode").Scan(&journalMode)
+	repo.db.QueryRow("PRAGMA synchronous").Scan(&synchronous)
+	if journalMode != "delete" {
+		t.Errorf("expected journal_mode delete, got %q", journalMode)
+	}
+	if synchronous != 2 { // FULL
+		t.Errorf("expected synchronous FULL (2), got %d", synchronous)
+	}
+}
+
+func TestNewWithOptions_PragmasOnEveryConnection(t *testing.T) {
+	repo, err := NewWithOptions(filepath.Join(t.TempDir(), "derby.db"), Options{BusyTimeout: 2 * time.Second, Synchronous: "full"})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer repo.Close()
+
+	// Hold one connection so the next one is freshly opened
+	ctx := context.Background()
+	repo.db.SetMaxOpenConns(2)
+	first, err := repo.db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Conn failed: %v", err)
+	}
+	defer first.Close()
+	second, err := repo.db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Conn failed: %v", err)
+	}
+	defer second.Close()
+
+	var foreignKeys, busyTimeout, synchronous int
+	second.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys)
+	second.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busyTimeout)
+	second.QueryRowContext(ctx, "PRAGMA synchronous").Scan(&synchronous)
+	if foreignKeys != 1 {
+		t.Errorf("expected foreign_keys on, got %d", foreignKeys)
+	}
+	if busyTimeout != 2000 {
+		t.Errorf("expected busy_timeout 2000, got %d", busyTimeout)
+	}
+	if synchronous != 2 { // FULL
+		t.Errorf("expected synchronous FULL (2), got %d", synchronous)
+	}
+}
+
+func TestNewWithOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"journal mode", Options{JournalMode: "WAL; DROP TABLE votes"}},
+		{"synchronous", Options{Synchronous: "SOMETIMES"}},
+		{"busy timeout", Options{BusyTimeout: -time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewWithOptions(":memory:", tt.opts); err == nil {
+				t.Error("expected error for invalid options")
+			}
+		})
+	}
+}
+
+func TestNewReadOnly_ReadsWhileWriteInProgress(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "derby.db")
+
+	repo, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer repo.Close()
+	voterID, _ := repo.CreateVoter(ctx, "WAL-001")
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+
+	ro, err := NewReadOnly(path)
+	if err != nil {
+		t.Fatalf("NewReadOnly failed: %v", err)
+	}
+	defer ro.Close()
+
+	// Hold a write transaction open, as a slow vote write would
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx failed: %v", err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec("INSERT INTO votes (voter_id, category_id, car_id) VALUES (?, ?, 1)", voterID, catID); err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+
+	// The reader sees the last committed state without waiting on the lock
+	start := time.Now()
+	results, err := ro.GetVoteResults(ctx)
+	if err != nil {
+		t.Fatalf("read during write failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected uncommitted vote to be invisible, got %d results", len(results))
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected read not to block on the writer, took %v", elapsed)
+	}
+}
+
+// benchmarkConcurrentSaveVote writes votes from parallel goroutines to a
+// file database opened with opts
+func benchmarkConcurrentSaveVote(b *testing.B, opts Options) {
+	ctx := context.Background()
+	repo, err := NewWithOptions(filepath.Join(b.TempDir(), "bench.db"), opts)
+	if err != nil {
+		b.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer repo.Close()
+
+	for i := 1; i <= 10; i++ {
+		repo.CreateCategory(ctx, fmt.Sprintf("Category %d", i), i, nil, nil, nil, nil)
+		repo.CreateCar(ctx, fmt.Sprintf("%d", 100+i), fmt.Sprintf("Racer %d", i), "", "")
+	}
+	var voterIDs []int
+	for v := 1; v <= 100; v++ {
+		voterID, _ := repo.CreateVoter(ctx, fmt.Sprintf("BENCH-%03d", v))
+		voterIDs = append(voterIDs, voterID)
+	}
+
+	var n atomic.Int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			i := int(n.Add(1))
+			if err := repo.SaveVote(ctx, voterIDs[i%len(voterIDs)], i%10+1, i%7+1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSaveVote_Concurrent(b *testing.B) {
+	b.Run("rollback-journal", func(b *testing.B) {
+		benchmarkConcurrentSaveVote(b, Options{JournalMode: "DELETE", Synchronous: "FULL"})
+	})
+	b.Run("wal", func(b *testing.B) {
+		benchmarkConcurrentSaveVote(b, DefaultOptions())
+	})
+}
+
 func TestNew_MigrateFailsReadOnlyDB(t *testing.T) {
 	// Create a temporary read-only database file
 	tmpfile, err := os.CreateTemp("", "readonly-*.db")
@@ -3847,7 +5724,7 @@ func TestCreateCategoryWithVoterTypes_Basic(t *testing.T) {
 	ctx := context.Background()
 
 	voterTypes := []string{"general", "racer", "Race Committee"}
-	catID, err := repo.CreateCategory(ctx, "Special Award", 1, nil, voterTypes, nil)
+	catID, err := repo.CreateCategory(ctx, "Special Award", 1, nil, voterTypes, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategoryWithVoterTypes failed: %v", err)
 	}
@@ -3881,7 +5758,7 @@ func TestCreateCategoryWithVoterTypes_EmptyTypes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create with empty voter types array (should allow all voters)
-	_, err := repo.CreateCategory(ctx, "Open Award", 1, nil, []string{}, nil)
+	_, err := repo.CreateCategory(ctx, "Open Award", 1, nil, []string{}, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategoryWithVoterTypes failed: %v", err)
 	}
@@ -3903,11 +5780,11 @@ func TestUpdateCategoryWithVoterTypes_Basic(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category without voter types
-	catID, _ := repo.CreateCategory(ctx, "Award", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Award", 1, nil, nil, nil, nil)
 
 	// Update with voter types
 	voterTypes := []string{"racer", "Cubmaster"}
-	err := repo.UpdateCategory(ctx, int(catID), "Updated Award", 2, nil, voterTypes, nil, true)
+	err := repo.UpdateCategory(ctx, int(catID), "Updated Award", 2, nil, voterTypes, nil, true, nil)
 	if err != nil {
 		t.Fatalf("UpdateCategoryWithVoterTypes failed: %v", err)
 	}
@@ -3941,10 +5818,10 @@ func TestUpdateCategoryWithVoterTypes_ClearTypes(t *testing.T) {
 
 	// Create category with voter types
 	voterTypes := []string{"general", "racer"}
-	catID, _ := repo.CreateCategory(ctx, "Award", 1, nil, voterTypes, nil)
+	catID, _ := repo.CreateCategory(ctx, "Award", 1, nil, voterTypes, nil, nil)
 
 	// Update to clear voter types
-	err := repo.UpdateCategory(ctx, int(catID), "Award", 1, nil, []string{}, nil, true)
+	err := repo.UpdateCategory(ctx, int(catID), "Award", 1, nil, []string{}, nil, true, nil)
 	if err != nil {
 		t.Fatalf("UpdateCategoryWithVoterTypes failed: %v", err)
 	}
@@ -4028,9 +5905,9 @@ func TestListAllCategories_WithVoterTypes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create categories with different voter type configurations
-	repo.CreateCategory(ctx, "Racer Only", 1, nil, []string{"racer"}, nil)
-	repo.CreateCategory(ctx, "All Voters", 2, nil, nil, nil)
-	repo.CreateCategory(ctx, "Multiple Types", 3, nil, []string{"general", "racer", "Cubmaster"}, nil)
+	repo.CreateCategory(ctx, "Racer Only", 1, nil, []string{"racer"}, nil, nil)
+	repo.CreateCategory(ctx, "All Voters", 2, nil, nil, nil, nil)
+	repo.CreateCategory(ctx, "Multiple Types", 3, nil, []string{"general", "racer", "Cubmaster"}, nil, nil)
 
 	categories, err := repo.ListAllCategories(ctx)
 	if err != nil {
@@ -4145,7 +6022,7 @@ func TestCreateCategoryWithRanks_Basic(t *testing.T) {
 	ctx := context.Background()
 
 	ranks := []string{"Tiger", "Lion", "Bear"}
-	catID, err := repo.CreateCategory(ctx, "Rank-Specific Award", 1, nil, nil, ranks)
+	catID, err := repo.CreateCategory(ctx, "Rank-Specific Award", 1, nil, nil, ranks, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory with ranks failed: %v", err)
 	}
@@ -4175,7 +6052,7 @@ func TestCreateCategoryWithRanks_InListAllCategories(t *testing.T) {
 	ctx := context.Background()
 
 	ranks := []string{"Wolf", "Webelos"}
-	catID, err := repo.CreateCategory(ctx, "Older Scouts Award", 1, nil, nil, ranks)
+	catID, err := repo.CreateCategory(ctx, "Older Scouts Award", 1, nil, nil, ranks, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory with ranks failed: %v", err)
 	}
@@ -4209,7 +6086,7 @@ func TestCreateCategoryWithRanks_EmptyRanks(t *testing.T) {
 	ctx := context.Background()
 
 	// Create with empty ranks array (should allow all ranks)
-	_, err := repo.CreateCategory(ctx, "Open Award", 1, nil, nil, []string{})
+	_, err := repo.CreateCategory(ctx, "Open Award", 1, nil, nil, []string{}, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory with empty ranks failed: %v", err)
 	}
@@ -4231,14 +6108,14 @@ func TestUpdateCategoryWithRanks_Basic(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category without ranks
-	catID, err := repo.CreateCategory(ctx, "Test Award", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Award", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
 
 	// Update it with ranks
 	ranks := []string{"Tiger", "Wolf"}
-	err = repo.UpdateCategory(ctx, int(catID), "Test Award", 1, nil, nil, ranks, true)
+	err = repo.UpdateCategory(ctx, int(catID), "Test Award", 1, nil, nil, ranks, true, nil)
 	if err != nil {
 		t.Fatalf("UpdateCategory with ranks failed: %v", err)
 	}
@@ -4267,13 +6144,13 @@ func TestUpdateCategoryWithRanks_ClearRanks(t *testing.T) {
 
 	// Create a category with ranks
 	ranks := []string{"Tiger", "Lion"}
-	catID, err := repo.CreateCategory(ctx, "Test Award", 1, nil, nil, ranks)
+	catID, err := repo.CreateCategory(ctx, "Test Award", 1, nil, nil, ranks, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
 
 	// Update it to clear ranks (empty array)
-	err = repo.UpdateCategory(ctx, int(catID), "Test Award", 1, nil, nil, []string{}, true)
+	err = repo.UpdateCategory(ctx, int(catID), "Test Award", 1, nil, nil, []string{}, true, nil)
 	if err != nil {
 		t.Fatalf("UpdateCategory to clear ranks failed: %v", err)
 	}
@@ -4296,7 +6173,7 @@ func TestCategoryWithBothVoterTypesAndRanks(t *testing.T) {
 
 	voterTypes := []string{"racer", "general"}
 	ranks := []string{"Tiger", "Bear"}
-	catID, err := repo.CreateCategory(ctx, "Combined Award", 1, nil, voterTypes, ranks)
+	catID, err := repo.CreateCategory(ctx, "Combined Award", 1, nil, voterTypes, ranks, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory with both failed: %v", err)
 	}
@@ -4376,7 +6253,7 @@ func TestDeleteVoter_WithVotes(t *testing.T) {
 	carID := cars[0].ID
 
 	// Create category
-	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -4441,11 +6318,11 @@ func TestCountVotesForCar(t *testing.T) {
 	carID := cars[0].ID
 
 	// Create categories
-	catID1, err := repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil)
+	catID1, err := repo.CreateCategory(ctx, "Category 1", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
-	catID2, err := repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil)
+	catID2, err := repo.CreateCategory(ctx, "Category 2", 2, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -4515,7 +6392,7 @@ func TestCountVotesForCategory(t *testing.T) {
 	carID2 := cars[1].ID
 
 	// Create category
-	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
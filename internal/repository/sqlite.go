@@ -4,9 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/abrezinsky/derbyvote/internal/actor"
 	"github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/models"
 )
@@ -14,25 +20,130 @@ import (
 // Repository provides data access methods
 type Repository struct {
 	db *sql.DB
+
+	// resultsVersion is bumped on every write that can change vote results,
+	// letting callers cache results until it moves
+	resultsVersion atomic.Uint64
+	// resultsModifiedAt holds the UnixNano time of the last results version bump
+	resultsModifiedAt atomic.Int64
+
+	// readOnly is set by NewReadOnly. Another process writes the database, so
+	// the results version follows SQLite's data_version instead of local writes.
+	readOnly bool
+	// dataVersion is the last PRAGMA data_version a read-only repository saw
+	dataVersion atomic.Int64
+}
+
+// memoryDBCounter gives each in-memory repository its own named database
+var memoryDBCounter atomic.Uint64
+
+// dataSourceName maps dbPath to a SQLite DSN carrying the given driver
+// parameters. A plain ":memory:" path is
+// rewritten to a uniquely named shared-cache in-memory database so every
+// pooled connection sees the same schema instead of a fresh empty one.
+func dataSourceName(dbPath string, params url.Values) string {
+	dsn := dbPath
+	if dbPath == ":memory:" {
+		dsn = fmt.Sprintf("file:derbyvote_mem_%d?mode=memory&cache=shared", memoryDBCounter.Add(1))
+	}
+	if len(params) == 0 {
+		return dsn
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&" + params.Encode()
+	}
+	return dsn + "?" + params.Encode()
+}
+
+// Options tunes the SQLite pragmas applied when a Repository is opened.
+// Empty fields leave SQLite's own default in place.
+type Options struct {
+	// JournalMode is the journal_mode pragma. WAL lets readers, such as a
+	// read-only results projector, proceed while votes are being written.
+	JournalMode string
+	// BusyTimeout is how long a statement waits for a lock held by another
+	// connection before failing with "database is locked"
+	BusyTimeout time.Duration
+	// Synchronous is the synchronous pragma. NORMAL is safe in WAL mode and
+	// skips the fsync on every commit.
+	Synchronous string
+}
+
+// DefaultOptions returns the options used by New
+func DefaultOptions() Options {
+	return Options{
+		JournalMode: "WAL",
+		BusyTimeout: 5 * time.Second,
+		Synchronous: "NORMAL",
+	}
+}
+
+var (
+	journalModes     = map[string]bool{"DELETE": true, "TRUNCATE": true, "PERSIST": true, "MEMORY": true, "WAL": true, "OFF": true}
+	synchronousModes = map[string]bool{"OFF": true, "NORMAL": true, "FULL": true, "EXTRA": true}
+)
+
+// pragmas validates the options. Per-connection pragmas are returned as DSN
+// parameters so the driver applies them to every connection it opens, not
+// just the first; the journal mode is stored in the database file, so it is
+// returned as a statement to run once.
+func (o Options) pragmas() (url.Values, []string, error) {
+	params := url.Values{"_foreign_keys": {"on"}}
+	var stmts []string
+	if o.JournalMode != "" {
+		mode := strings.ToUpper(o.JournalMode)
+		if !journalModes[mode] {
+			return nil, nil, fmt.Errorf("invalid journal mode %q", o.JournalMode)
+		}
+		stmts = append(stmts, "PRAGMA journal_mode = "+mode)
+	}
+	if o.BusyTimeout < 0 {
+		return nil, nil, fmt.Errorf("invalid busy timeout %v", o.BusyTimeout)
+	}
+	if o.BusyTimeout > 0 {
+		params.Set("_busy_timeout", strconv.FormatInt(o.BusyTimeout.Milliseconds(), 10))
+	}
+	if o.Synchronous != "" {
+		mode := strings.ToUpper(o.Synchronous)
+		if !synchronousModes[mode] {
+			return nil, nil, fmt.Errorf("invalid synchronous mode %q", o.Synchronous)
+		}
+		params.Set("_synchronous", mode)
+	}
+	return params, stmts, nil
 }
 
-// New creates a new Repository
+// New creates a new Repository using DefaultOptions
 func New(dbPath string) (*Repository, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	return NewWithOptions(dbPath, DefaultOptions())
+}
+
+// NewWithOptions creates a new Repository with the given SQLite pragmas
+func NewWithOptions(dbPath string, opts Options) (*Repository, error) {
+	params, pragmas, err := opts.pragmas()
 	if err != nil {
 		return nil, err
 	}
 
-	// Enable foreign key constraints
-	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+	db, err := sql.Open("sqlite3", dataSourceName(dbPath, params))
+	if err != nil {
 		return nil, err
 	}
 
+	for _, pragma := range pragmas {
+		if _, err := db.Exec(pragma); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("%s: %w", pragma, err)
+		}
+	}
+
 	// Set connection pool settings
 	db.SetMaxOpenConns(1) // SQLite works best with single connection
 	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0) // never recycle the connection; an in-memory DB dies with its last one
 
 	repo := &Repository{db: db}
+	repo.resultsModifiedAt.Store(time.Now().UnixNano())
 
 	// Run migrations
 	if err := repo.migrate(); err != nil {
@@ -42,11 +153,79 @@ func New(dbPath string) (*Repository, error) {
 	return repo, nil
 }
 
+// NewReadOnly opens an existing database without write access, for instances
+// that only display results. Migrations are not run, so the database must
+// already be at the current schema version.
+func NewReadOnly(dbPath string) (*Repository, error) {
+	// Wait out the writing instance's locks rather than failing
+	busyTimeout := strconv.FormatInt(DefaultOptions().BusyTimeout.Milliseconds(), 10)
+	db, err := sql.Open("sqlite3", dataSourceName("file:"+dbPath+"?mode=ro", url.Values{"_busy_timeout": {busyTimeout}}))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+
+	repo := &Repository{db: db, readOnly: true}
+	repo.resultsModifiedAt.Store(time.Now().UnixNano())
+
+	version, err := repo.SchemaVersion()
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open read-only database: %w", err)
+	}
+	if latest := migrations[len(migrations)-1].version; version < latest {
+		db.Close()
+		return nil, fmt.Errorf("read-only database is at schema version %d, expected %d; start a writable instance on it first", version, latest)
+	}
+
+	return repo, nil
+}
+
 // DB returns the underlying database connection (for transactions)
 func (r *Repository) DB() *sql.DB {
 	return r.db
 }
 
+// ResultsVersion returns a counter that changes whenever votes, overrides,
+// or the cars, categories, and category groups they reference are modified
+func (r *Repository) ResultsVersion() uint64 {
+	r.syncDataVersion()
+	return r.resultsVersion.Load()
+}
+
+// ResultsModifiedAt returns when the results version last changed, or when
+// the repository was opened if it has not changed since
+func (r *Repository) ResultsModifiedAt() time.Time {
+	r.syncDataVersion()
+	return time.Unix(0, r.resultsModifiedAt.Load())
+}
+
+// syncDataVersion bumps the results version of a read-only repository when
+// another process has committed to the database since the last check. The
+// pool keeps a single connection, which data_version requires.
+func (r *Repository) syncDataVersion() {
+	if !r.readOnly {
+		return
+	}
+	var version int64
+	if err := r.db.QueryRow("PRAGMA data_version").Scan(&version); err != nil {
+		r.invalidateResults() // Cannot tell whether anything changed, so never serve a stale copy
+		return
+	}
+	if r.dataVersion.Swap(version) != version {
+		r.invalidateResults()
+	}
+}
+
+// invalidateResults bumps the results version; call it after writes that affect
+// results or stats, including new voters and cars
+func (r *Repository) invalidateResults() {
+	r.resultsModifiedAt.Store(time.Now().UnixNano())
+	r.resultsVersion.Add(1)
+}
+
 // Close closes the database connection
 func (r *Repository) Close() error {
 	if r.db != nil {
@@ -60,122 +239,6 @@ func (r *Repository) Ping(ctx context.Context) error {
 	return r.db.PingContext(ctx)
 }
 
-// migrate runs database migrations
-func (r *Repository) migrate() error {
-	migrations := []string{
-		`CREATE TABLE IF NOT EXISTS voters (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			car_id INTEGER,
-			name TEXT,
-			email TEXT,
-			voter_type TEXT DEFAULT 'general',
-			qr_code TEXT UNIQUE NOT NULL,
-			notes TEXT,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			last_voted_at DATETIME,
-			FOREIGN KEY (car_id) REFERENCES cars(id) ON DELETE SET NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS cars (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			derbynet_racer_id INTEGER UNIQUE,
-			car_number TEXT NOT NULL,
-			racer_name TEXT,
-			car_name TEXT,
-			photo_url TEXT,
-			rank TEXT,
-			synced_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			active BOOLEAN DEFAULT 1
-		)`,
-		`CREATE TABLE IF NOT EXISTS category_groups (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			name TEXT NOT NULL,
-			description TEXT,
-			exclusivity_pool_id INTEGER,
-			display_order INTEGER NOT NULL,
-			active BOOLEAN DEFAULT 1
-		)`,
-		`CREATE TABLE IF NOT EXISTS categories (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			name TEXT NOT NULL,
-			display_order INTEGER NOT NULL,
-			group_id INTEGER,
-			derbynet_award_id INTEGER,
-			active BOOLEAN DEFAULT 1,
-			override_winner_car_id INTEGER,
-			override_reason TEXT,
-			overridden_at DATETIME,
-			FOREIGN KEY (group_id) REFERENCES category_groups(id) ON DELETE SET NULL,
-			FOREIGN KEY (override_winner_car_id) REFERENCES cars(id) ON DELETE SET NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS votes (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			voter_id INTEGER NOT NULL,
-			car_id INTEGER NOT NULL,
-			category_id INTEGER NOT NULL,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			FOREIGN KEY (voter_id) REFERENCES voters(id),
-			FOREIGN KEY (car_id) REFERENCES cars(id),
-			FOREIGN KEY (category_id) REFERENCES categories(id),
-			UNIQUE(voter_id, category_id)
-		)`,
-		`CREATE TABLE IF NOT EXISTS settings (
-			key TEXT PRIMARY KEY,
-			value TEXT NOT NULL
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_votes_voter ON votes(voter_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_votes_category ON votes(category_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_votes_car ON votes(car_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_voters_qr ON voters(qr_code)`,
-		`CREATE INDEX IF NOT EXISTS idx_voters_car ON voters(car_id)`,
-	}
-
-	additionalMigrations := []string{
-		`ALTER TABLE voters ADD COLUMN car_id INTEGER`,
-		`ALTER TABLE voters ADD COLUMN name TEXT`,
-		`ALTER TABLE voters ADD COLUMN email TEXT`,
-		`ALTER TABLE voters ADD COLUMN voter_type TEXT DEFAULT 'general'`,
-		`ALTER TABLE voters ADD COLUMN notes TEXT`,
-		`ALTER TABLE categories ADD COLUMN group_id INTEGER`,
-		`ALTER TABLE categories ADD COLUMN derbynet_award_id INTEGER`,
-		`ALTER TABLE cars ADD COLUMN eligible BOOLEAN DEFAULT 1`,
-		`ALTER TABLE categories ADD COLUMN override_winner_car_id INTEGER`,
-		`ALTER TABLE categories ADD COLUMN override_reason TEXT`,
-		`ALTER TABLE categories ADD COLUMN overridden_at DATETIME`,
-		`ALTER TABLE category_groups ADD COLUMN max_wins_per_car INTEGER`,
-		`ALTER TABLE categories ADD COLUMN allowed_voter_types TEXT`, // JSON array of voter types, NULL means all types allowed
-		`ALTER TABLE cars ADD COLUMN rank TEXT`,
-		`ALTER TABLE categories ADD COLUMN allowed_ranks TEXT`, // JSON array of ranks, NULL means all ranks allowed
-	}
-
-	for _, migration := range migrations {
-		if _, err := r.db.Exec(migration); err != nil {
-			return err
-		}
-	}
-
-	for _, migration := range additionalMigrations {
-		r.db.Exec(migration) // Ignore errors - columns may already exist
-	}
-
-	// Insert default settings if not exists
-	// Note: base_url is intentionally not set here - it's set by app.go
-	// with the detected LAN IP address on startup
-	defaultSettings := map[string]string{
-		"voting_open":  "true",
-		"derbynet_url": "",
-	}
-
-	for key, value := range defaultSettings {
-		_, err := r.db.Exec(`INSERT OR IGNORE INTO settings (key, value) VALUES (?, ?)`, key, value)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 // ==================== Voter Methods ====================
 
 // GetVoterByQR retrieves a voter by QR code
@@ -204,9 +267,21 @@ func (r *Repository) GetVoterType(ctx context.Context, voterID int) (string, err
 	return "general", nil // Default to general if NULL
 }
 
+// GetVoterName returns a voter's name, or "" if they have none
+func (r *Repository) GetVoterName(ctx context.Context, voterID int) (string, error) {
+	var name sql.NullString
+	err := r.db.QueryRowContext(ctx, `SELECT name FROM voters WHERE id = ?`, voterID).Scan(&name)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	return name.String, err
+}
+
 // CreateVoter creates a new voter
 func (r *Repository) CreateVoter(ctx context.Context, qrCode string) (int, error) {
-	result, err := r.db.ExecContext(ctx, `INSERT INTO voters (qr_code) VALUES (?)`, qrCode)
+	defer r.invalidateResults()
+
+	result, err := r.db.ExecContext(ctx, `INSERT INTO voters (qr_code, created_by) VALUES (?, ?)`, qrCode, createdBy(ctx))
 	if err != nil {
 		return 0, err
 	}
@@ -216,10 +291,12 @@ func (r *Repository) CreateVoter(ctx context.Context, qrCode string) (int, error
 
 // CreateVoterFull creates a voter with all fields
 func (r *Repository) CreateVoterFull(ctx context.Context, carID *int, name, email, voterType, qrCode, notes string) (int64, error) {
+	defer r.invalidateResults()
+
 	result, err := r.db.ExecContext(ctx, `
-		INSERT INTO voters (car_id, name, email, voter_type, qr_code, notes)
-		VALUES (?, ?, ?, ?, ?, ?)
-	`, carID, name, email, voterType, qrCode, notes)
+		INSERT INTO voters (car_id, name, email, voter_type, qr_code, notes, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, carID, name, email, voterType, qrCode, notes, createdBy(ctx))
 	if err != nil {
 		return 0, err
 	}
@@ -228,15 +305,23 @@ func (r *Repository) CreateVoterFull(ctx context.Context, carID *int, name, emai
 
 // UpdateVoter updates a voter
 func (r *Repository) UpdateVoter(ctx context.Context, id int, carID *int, name, email, voterType, notes string) error {
+	defer r.invalidateResults()
+
 	_, err := r.db.ExecContext(ctx, `
 		UPDATE voters SET car_id = ?, name = ?, email = ?, voter_type = ?, notes = ?
 		WHERE id = ?
 	`, carID, name, email, voterType, notes, id)
-	return err
+	if err != nil {
+		return err
+	}
+	// The voter type decides which categories a complete ballot needs
+	return refreshBallotComplete(ctx, r.db, id)
 }
 
 // DeleteVoter deletes a voter
 func (r *Repository) DeleteVoter(ctx context.Context, id int) error {
+	defer r.invalidateResults()
+
 	// Delete voter's votes first (foreign key constraint)
 	_, err := r.db.ExecContext(ctx, `DELETE FROM votes WHERE voter_id = ?`, id)
 	if err != nil {
@@ -248,6 +333,372 @@ func (r *Repository) DeleteVoter(ctx context.Context, id int) error {
 	return err
 }
 
+// SetVoterTest marks or unmarks a voter as a test voter. Test voters' votes
+// are kept but left out of results.
+func (r *Repository) SetVoterTest(ctx context.Context, id int, isTest bool) error {
+	defer r.invalidateResults()
+
+	_, err := r.db.ExecContext(ctx, `UPDATE voters SET is_test = ? WHERE id = ?`, isTest, id)
+	return err
+}
+
+// SetVoterGroup sets the group, such as a den, a voter belongs to. An empty
+// group leaves the voter ungrouped.
+func (r *Repository) SetVoterGroup(ctx context.Context, id int, group string) error {
+	defer r.invalidateResults()
+
+	_, err := r.db.ExecContext(ctx, `UPDATE voters SET voter_group = NULLIF(?, '') WHERE id = ?`, group, id)
+	return err
+}
+
+// ListVoterGroupTurnout returns turnout for each voter group, leaving out test
+// voters. Ungrouped voters are reported under an empty group.
+func (r *Repository) ListVoterGroupTurnout(ctx context.Context) ([]models.VoterGroupTurnout, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT COALESCE(voter_group, ''), COUNT(*),
+		       COUNT(last_voted_at),
+		       COALESCE(SUM(CASE WHEN ballot_complete = 1 THEN 1 ELSE 0 END), 0)
+		FROM voters
+		WHERE COALESCE(is_test, 0) = 0
+		GROUP BY COALESCE(voter_group, '')
+		ORDER BY COALESCE(voter_group, '') = '', COALESCE(voter_group, '')
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []models.VoterGroupTurnout{}
+	for rows.Next() {
+		var group models.VoterGroupTurnout
+		if err := rows.Scan(&group.VoterGroup, &group.Voters, &group.Voted, &group.CompleteBallots); err != nil {
+			return nil, err
+		}
+		if group.Voters > 0 {
+			group.TurnoutRate = float64(group.Voted) / float64(group.Voters)
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// DeleteTestVoters deletes all test voters and their votes in a transaction
+func (r *Repository) DeleteTestVoters(ctx context.Context) (int64, error) {
+	defer r.invalidateResults()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM votes WHERE voter_id IN (SELECT id FROM voters WHERE is_test = 1)`); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM voters WHERE is_test = 1`)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// TouchVoterActivity records that a voter just read or changed their ballot.
+// A read-only repository skips it; the instance that writes the database
+// tracks activity.
+func (r *Repository) TouchVoterActivity(ctx context.Context, voterID int) error {
+	if r.readOnly {
+		return nil
+	}
+	_, err := r.db.ExecContext(ctx, `UPDATE voters SET last_activity_at = ? WHERE id = ?`, time.Now().UTC(), voterID)
+	return err
+}
+
+// ballotCompleteSQL is true when the voter in the enclosing UPDATE of voters
+// has voted in every active category open to their voter type
+const ballotCompleteSQL = `NOT EXISTS (
+	SELECT 1 FROM categories c
+	WHERE c.active = 1
+	  AND (c.allowed_voter_types IS NULL OR c.allowed_voter_types = '' OR c.allowed_voter_types = '[]'
+	       OR EXISTS (SELECT 1 FROM json_each(c.allowed_voter_types) t
+	                  WHERE t.value = COALESCE(voters.voter_type, 'general')))
+	  AND NOT EXISTS (SELECT 1 FROM votes vo WHERE vo.voter_id = voters.id AND vo.category_id = c.id))`
+
+// completeBallotFilterSQL leaves out the votes of voters whose ballot is
+// incomplete while the require_complete_ballot setting is on
+const completeBallotFilterSQL = `(COALESCE((SELECT value FROM settings WHERE key = 'require_complete_ballot'), 'false') != 'true'
+	OR COALESCE(vr.ballot_complete, 0) = 1)`
+
+// categoryCarSQL matches votes, as v, for cars competing in their category:
+// the category has no car subset, the car is in it, or the car is a write-in
+const categoryCarSQL = `(NOT EXISTS (SELECT 1 FROM category_cars cc WHERE cc.category_id = v.category_id)
+	OR EXISTS (SELECT 1 FROM category_cars cc WHERE cc.category_id = v.category_id AND cc.car_id = v.car_id)
+	OR EXISTS (SELECT 1 FROM cars wc WHERE wc.id = v.car_id AND COALESCE(wc.write_in, 0) = 1))`
+
+// countedVoteSQL matches the votes that count toward results, for votes as v
+// joined to their voter as vr
+const countedVoteSQL = `COALESCE(vr.is_test, 0) = 0 AND ` + completeBallotFilterSQL + ` AND ` + categoryCarSQL
+
+// sqlExecer is implemented by both *sql.DB and *sql.Tx
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// sqlQueryExecer is implemented by both *sql.DB and *sql.Tx
+type sqlQueryExecer interface {
+	sqlExecer
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// createdBy returns the admin named in ctx for a created_by column, or NULL
+// when there is none, as for voters registering themselves
+func createdBy(ctx context.Context) sql.NullString {
+	name := actor.FromContext(ctx)
+	return sql.NullString{String: name, Valid: name != ""}
+}
+
+// refreshBallotComplete recomputes one voter's ballot_complete flag
+func refreshBallotComplete(ctx context.Context, db sqlExecer, voterID int) error {
+	_, err := db.ExecContext(ctx, `UPDATE voters SET ballot_complete = `+ballotCompleteSQL+` WHERE id = ?`, voterID)
+	return err
+}
+
+// refreshAllBallotComplete recomputes every voter's ballot_complete flag, for
+// changes to the categories that decide what a complete ballot is
+func refreshAllBallotComplete(ctx context.Context, db sqlExecer) error {
+	_, err := db.ExecContext(ctx, `UPDATE voters SET ballot_complete = `+ballotCompleteSQL)
+	return err
+}
+
+// ListStaleVoters returns voters who have voted in at least one but not all of
+// the active categories open to their voter type, and whose last activity was
+// before idleSince
+func (r *Repository) ListStaleVoters(ctx context.Context, idleSince time.Time) ([]models.StaleVoter, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, qr_code, name, voter_type, votes_cast, categories_available, last_activity_at
+		FROM (
+			SELECT v.id, v.qr_code, v.name, COALESCE(v.voter_type, 'general') AS voter_type,
+			       v.last_activity_at,
+			       (SELECT COUNT(*) FROM votes vo
+			        JOIN categories c ON c.id = vo.category_id AND c.active = 1
+			        WHERE vo.voter_id = v.id) AS votes_cast,
+			       (SELECT COUNT(*) FROM categories c
+			        WHERE c.active = 1
+			          AND (c.allowed_voter_types IS NULL OR c.allowed_voter_types = '' OR c.allowed_voter_types = '[]'
+			               OR EXISTS (SELECT 1 FROM json_each(c.allowed_voter_types) t
+			                          WHERE t.value = COALESCE(v.voter_type, 'general')))) AS categories_available
+			FROM voters v
+		)
+		WHERE last_activity_at IS NOT NULL AND last_activity_at < ?
+		  AND votes_cast > 0 AND votes_cast < categories_available
+		ORDER BY last_activity_at
+	`, idleSince.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	voters := []models.StaleVoter{}
+	for rows.Next() {
+		var voter models.StaleVoter
+		var name sql.NullString
+		if err := rows.Scan(&voter.ID, &voter.QRCode, &name, &voter.VoterType,
+			&voter.VotesCast, &voter.CategoriesAvailable, &voter.LastActivityAt); err != nil {
+			return nil, err
+		}
+		voter.Name = name.String
+		voters = append(voters, voter)
+	}
+	return voters, rows.Err()
+}
+
+// AnyVoteSince reports whether any voter has voted at or after since
+func (r *Repository) AnyVoteSince(ctx context.Context, since time.Time) (bool, error) {
+	var voted bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM voters WHERE last_voted_at >= ?)`, since.UTC()).Scan(&voted)
+	return voted, err
+}
+
+// ClearVoterVotes deletes all of a voter's votes in a transaction and resets
+// their last-voted time, keeping the voter record. Returns how many votes were removed.
+func (r *Repository) ClearVoterVotes(ctx context.Context, voterID int) (int64, error) {
+	defer r.invalidateResults()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE voters SET last_voted_at = NULL WHERE id = ?`, voterID)
+	if err != nil {
+		return 0, err
+	}
+	found, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if found == 0 {
+		return 0, errors.NotFound("voter not found")
+	}
+
+	result, err = tx.ExecContext(ctx, `DELETE FROM votes WHERE voter_id = ?`, voterID)
+	if err != nil {
+		return 0, err
+	}
+	cleared, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if err := refreshBallotComplete(ctx, tx, voterID); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return cleared, nil
+}
+
+// VoidVote deletes a voter's vote in a category and records the reason in the
+// audit log, in a transaction. Returns the car the vote was for.
+func (r *Repository) VoidVote(ctx context.Context, voterID, categoryID int, reason string) (int, error) {
+	defer r.invalidateResults()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var carID int
+	err = tx.QueryRowContext(ctx, `SELECT car_id FROM votes WHERE voter_id = ? AND category_id = ?`, voterID, categoryID).Scan(&carID)
+	if err == sql.ErrNoRows {
+		return 0, errors.NotFound("vote not found")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM votes WHERE voter_id = ? AND category_id = ?`, voterID, categoryID); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO audit_log (action, voter_id, category_id, car_id, reason, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, models.AuditActionVoteVoided, voterID, categoryID, carID, reason, time.Now().UTC()); err != nil {
+		return 0, err
+	}
+	if err := refreshBallotComplete(ctx, tx, voterID); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return carID, nil
+}
+
+// AddAuditEntry records an admin action in the audit log; CreatedAt is ignored
+func (r *Repository) AddAuditEntry(ctx context.Context, entry models.AuditEntry) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO audit_log (action, voter_id, category_id, car_id, reason, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, entry.Action, entry.VoterID, entry.CategoryID, entry.CarID, entry.Reason, time.Now().UTC())
+	return err
+}
+
+// ListAuditLog returns every audit log entry, newest first
+func (r *Repository) ListAuditLog(ctx context.Context) ([]models.AuditEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, action, voter_id, category_id, car_id, reason, created_at
+		FROM audit_log
+		ORDER BY id DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		var entry models.AuditEntry
+		var voterID, categoryID, carID sql.NullInt64
+		var reason sql.NullString
+		if err := rows.Scan(&entry.ID, &entry.Action, &voterID, &categoryID, &carID, &reason, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		if voterID.Valid {
+			id := int(voterID.Int64)
+			entry.VoterID = &id
+		}
+		if categoryID.Valid {
+			id := int(categoryID.Int64)
+			entry.CategoryID = &id
+		}
+		if carID.Valid {
+			id := int(carID.Int64)
+			entry.CarID = &id
+		}
+		entry.Reason = reason.String
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// DeleteVotersByFilter deletes all voters matching the filter (and their votes) in a transaction.
+// An empty voterType matches all types; a nil hasVoted matches voters regardless of voting status.
+func (r *Repository) DeleteVotersByFilter(ctx context.Context, voterType string, hasVoted *bool) (int64, error) {
+	defer r.invalidateResults()
+
+	where := `1 = 1`
+	var args []interface{}
+	if voterType != "" {
+		where += ` AND COALESCE(voter_type, 'general') = ?`
+		args = append(args, voterType)
+	}
+	if hasVoted != nil {
+		if *hasVoted {
+			where += ` AND last_voted_at IS NOT NULL`
+		} else {
+			where += ` AND last_voted_at IS NULL`
+		}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Delete matching voters' votes first (foreign key constraint)
+	if _, err := tx.ExecContext(ctx, `DELETE FROM votes WHERE voter_id IN (SELECT id FROM voters WHERE `+where+`)`, args...); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM voters WHERE `+where, args...)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
 // GetVoterQRCode returns the QR code for a voter by ID
 func (r *Repository) GetVoterQRCode(ctx context.Context, id int) (string, error) {
 	var qrCode string
@@ -255,11 +706,48 @@ func (r *Repository) GetVoterQRCode(ctx context.Context, id int) (string, error)
 	return qrCode, err
 }
 
+// GetVoterShortToken returns the short link token for a voter, or ErrNotFound
+// if the voter has none yet
+func (r *Repository) GetVoterShortToken(ctx context.Context, voterID int) (string, error) {
+	var token string
+	err := r.db.QueryRowContext(ctx, `SELECT token FROM voter_short_links WHERE voter_id = ?`, voterID).Scan(&token)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	return token, err
+}
+
+// GetQRCodeByShortToken returns the QR code of the voter a short link token
+// belongs to, or ErrNotFound
+func (r *Repository) GetQRCodeByShortToken(ctx context.Context, token string) (string, error) {
+	var qrCode string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT v.qr_code FROM voter_short_links l
+		JOIN voters v ON v.id = l.voter_id
+		WHERE l.token = ?
+	`, token).Scan(&qrCode)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	return qrCode, err
+}
+
+// SetVoterShortToken stores the short link token for a voter, or returns
+// ErrReadOnly on a read-only repository
+func (r *Repository) SetVoterShortToken(ctx context.Context, voterID int, token string) error {
+	if r.readOnly {
+		return ErrReadOnly
+	}
+	_, err := r.db.ExecContext(ctx, `INSERT INTO voter_short_links (token, voter_id) VALUES (?, ?)`, token, voterID)
+	return err
+}
+
 // ListVoters returns all voters with car info
 func (r *Repository) ListVoters(ctx context.Context) ([]map[string]interface{}, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT v.id, v.car_id, v.name, v.email, v.voter_type, v.qr_code, v.notes,
-		       v.created_at, v.last_voted_at, c.car_number, c.racer_name
+		SELECT v.id, v.car_id, v.name, v.email, v.voter_type, v.voter_group, v.qr_code, v.notes,
+		       v.created_at, v.last_voted_at, v.last_activity_at, c.car_number, c.racer_name,
+		       COALESCE(v.is_test, 0), v.created_by
 		FROM voters v
 		LEFT JOIN cars c ON v.car_id = c.id
 		ORDER BY v.created_at DESC
@@ -272,11 +760,12 @@ func (r *Repository) ListVoters(ctx context.Context) ([]map[string]interface{},
 	var voters []map[string]interface{}
 	for rows.Next() {
 		var id, carID sql.NullInt64
-		var name, email, voterType, qrCode, notes, createdAt, lastVotedAt sql.NullString
-		var carNumber, racerName sql.NullString
+		var name, email, voterType, voterGroup, qrCode, notes, createdAt, lastVotedAt, lastActivityAt sql.NullString
+		var carNumber, racerName, createdByActor sql.NullString
+		var isTest bool
 
-		if err := rows.Scan(&id, &carID, &name, &email, &voterType, &qrCode, &notes,
-			&createdAt, &lastVotedAt, &carNumber, &racerName); err != nil {
+		if err := rows.Scan(&id, &carID, &name, &email, &voterType, &voterGroup, &qrCode, &notes,
+			&createdAt, &lastVotedAt, &lastActivityAt, &carNumber, &racerName, &isTest, &createdByActor); err != nil {
 			continue
 		}
 
@@ -285,6 +774,7 @@ func (r *Repository) ListVoters(ctx context.Context) ([]map[string]interface{},
 			"qr_code":    qrCode.String,
 			"voter_type": voterType.String,
 			"created_at": createdAt.String,
+			"is_test":    isTest,
 		}
 
 		if carID.Valid {
@@ -298,6 +788,9 @@ func (r *Repository) ListVoters(ctx context.Context) ([]map[string]interface{},
 		if email.Valid {
 			voter["email"] = email.String
 		}
+		if voterGroup.Valid {
+			voter["voter_group"] = voterGroup.String
+		}
 		if notes.Valid {
 			voter["notes"] = notes.String
 		}
@@ -307,6 +800,12 @@ func (r *Repository) ListVoters(ctx context.Context) ([]map[string]interface{},
 		} else {
 			voter["has_voted"] = false
 		}
+		if lastActivityAt.Valid {
+			voter["last_activity_at"] = lastActivityAt.String
+		}
+		if createdByActor.Valid {
+			voter["created_by"] = createdByActor.String
+		}
 
 		voters = append(voters, voter)
 	}
@@ -318,10 +817,12 @@ func (r *Repository) ListVoters(ctx context.Context) ([]map[string]interface{},
 // ListCategories returns all active categories with group info
 func (r *Repository) ListCategories(ctx context.Context) ([]models.Category, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT c.id, c.name, c.display_order, c.group_id, c.derbynet_award_id, cg.name, cg.exclusivity_pool_id,
-		       c.override_winner_car_id, c.override_reason, c.overridden_at, c.allowed_voter_types, c.allowed_ranks
+		SELECT c.id, c.name, c.display_order, c.group_id, c.derbynet_award_id, cg.name, p.id,
+		       c.override_winner_car_id, c.override_reason, c.overridden_at, c.allowed_voter_types, c.allowed_ranks, c.tags,
+		       COALESCE(c.allow_write_in, 0), COALESCE(c.show_live_counts, 0), COALESCE(c.banner_url, '')
 		FROM categories c
 		LEFT JOIN category_groups cg ON c.group_id = cg.id
+		LEFT JOIN exclusivity_pools p ON cg.exclusivity_pool_id = p.id
 		WHERE c.active = 1
 		ORDER BY c.display_order
 	`)
@@ -334,9 +835,10 @@ func (r *Repository) ListCategories(ctx context.Context) ([]models.Category, err
 	for rows.Next() {
 		var cat models.Category
 		var groupID, derbynetAwardID, exclusivityPoolID, overrideWinnerCarID sql.NullInt64
-		var groupName, overrideReason, overriddenAt, allowedVoterTypesJSON, allowedRanksJSON sql.NullString
+		var groupName, overrideReason, overriddenAt, allowedVoterTypesJSON, allowedRanksJSON, tagsJSON sql.NullString
 		if err := rows.Scan(&cat.ID, &cat.Name, &cat.DisplayOrder, &groupID, &derbynetAwardID, &groupName, &exclusivityPoolID,
-			&overrideWinnerCarID, &overrideReason, &overriddenAt, &allowedVoterTypesJSON, &allowedRanksJSON); err != nil {
+			&overrideWinnerCarID, &overrideReason, &overriddenAt, &allowedVoterTypesJSON, &allowedRanksJSON, &tagsJSON,
+			&cat.AllowWriteIn, &cat.ShowLiveCounts, &cat.BannerURL); err != nil {
 			return nil, err
 		}
 		if groupID.Valid {
@@ -372,8 +874,24 @@ func (r *Repository) ListCategories(ctx context.Context) ([]models.Category, err
 				return nil, err
 			}
 		}
+		if tagsJSON.Valid && tagsJSON.String != "" {
+			if err := json.Unmarshal([]byte(tagsJSON.String), &cat.Tags); err != nil {
+				return nil, err
+			}
+		}
 		categories = append(categories, cat)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	carIDs, err := r.listCategoryCarIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range categories {
+		categories[i].CarIDs = carIDs[categories[i].ID]
+	}
 	return categories, nil
 }
 
@@ -381,7 +899,8 @@ func (r *Repository) ListCategories(ctx context.Context) ([]models.Category, err
 func (r *Repository) ListAllCategories(ctx context.Context) ([]map[string]interface{}, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT c.id, c.name, c.display_order, c.group_id, c.derbynet_award_id, c.active, cg.name as group_name,
-		       c.override_winner_car_id, c.override_reason, c.overridden_at, c.allowed_voter_types, c.allowed_ranks
+		       c.override_winner_car_id, c.override_reason, c.overridden_at, c.allowed_voter_types, c.allowed_ranks, c.tags,
+		       COALESCE(c.allow_write_in, 0), COALESCE(c.show_live_counts, 0), COALESCE(c.banner_url, ''), c.created_by
 		FROM categories c
 		LEFT JOIN category_groups cg ON c.group_id = cg.id
 		ORDER BY c.display_order
@@ -395,18 +914,21 @@ func (r *Repository) ListAllCategories(ctx context.Context) ([]map[string]interf
 	for rows.Next() {
 		var id, displayOrder int
 		var groupID, derbynetAwardID, overrideWinnerCarID sql.NullInt64
-		var name string
-		var groupName, overrideReason, overriddenAt, allowedVoterTypesJSON, allowedRanksJSON sql.NullString
-		var active bool
+		var name, bannerURL string
+		var groupName, overrideReason, overriddenAt, allowedVoterTypesJSON, allowedRanksJSON, tagsJSON, createdByActor sql.NullString
+		var active, allowWriteIn, showLiveCounts bool
 		if err := rows.Scan(&id, &name, &displayOrder, &groupID, &derbynetAwardID, &active, &groupName,
-			&overrideWinnerCarID, &overrideReason, &overriddenAt, &allowedVoterTypesJSON, &allowedRanksJSON); err != nil {
+			&overrideWinnerCarID, &overrideReason, &overriddenAt, &allowedVoterTypesJSON, &allowedRanksJSON, &tagsJSON,
+			&allowWriteIn, &showLiveCounts, &bannerURL, &createdByActor); err != nil {
 			return nil, err
 		}
 		cat := map[string]interface{}{
-			"id":            id,
-			"name":          name,
-			"display_order": displayOrder,
-			"active":        active,
+			"id":               id,
+			"name":             name,
+			"display_order":    displayOrder,
+			"active":           active,
+			"allow_write_in":   allowWriteIn,
+			"show_live_counts": showLiveCounts,
 		}
 		if groupID.Valid {
 			cat["group_id"] = int(groupID.Int64)
@@ -424,6 +946,12 @@ func (r *Repository) ListAllCategories(ctx context.Context) ([]map[string]interf
 		if overriddenAt.Valid {
 			cat["overridden_at"] = overriddenAt.String
 		}
+		if bannerURL != "" {
+			cat["banner_url"] = bannerURL
+		}
+		if createdByActor.Valid {
+			cat["created_by"] = createdByActor.String
+		}
 		// Parse allowed_voter_types JSON
 		if allowedVoterTypesJSON.Valid && allowedVoterTypesJSON.String != "" {
 			var allowedTypes []string
@@ -438,54 +966,243 @@ func (r *Repository) ListAllCategories(ctx context.Context) ([]map[string]interf
 				cat["allowed_ranks"] = allowedRanks
 			}
 		}
+		// Parse tags JSON
+		if tagsJSON.Valid && tagsJSON.String != "" {
+			var tags []string
+			if err := json.Unmarshal([]byte(tagsJSON.String), &tags); err == nil {
+				cat["tags"] = tags
+			}
+		}
 		categories = append(categories, cat)
 	}
-	return categories, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	carIDs, err := r.listCategoryCarIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, cat := range categories {
+		if ids := carIDs[cat["id"].(int)]; len(ids) > 0 {
+			cat["car_ids"] = ids
+		}
+	}
+	return categories, nil
+}
+
+// listCategoryCarIDs returns the car subset of each category that has one
+func (r *Repository) listCategoryCarIDs(ctx context.Context) (map[int][]int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT category_id, car_id FROM category_cars ORDER BY category_id, car_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	carIDs := make(map[int][]int)
+	for rows.Next() {
+		var categoryID, carID int
+		if err := rows.Scan(&categoryID, &carID); err != nil {
+			return nil, err
+		}
+		carIDs[categoryID] = append(carIDs[categoryID], carID)
+	}
+	return carIDs, rows.Err()
+}
+
+// SetCategoryCars replaces the cars competing in a category in a transaction;
+// an empty list lets every eligible car compete again
+func (r *Repository) SetCategoryCars(ctx context.Context, id int, carIDs []int) error {
+	defer r.invalidateResults()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM categories WHERE id = ?)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return errors.NotFound("category not found")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM category_cars WHERE category_id = ?`, id); err != nil {
+		return err
+	}
+	for _, carID := range carIDs {
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM cars WHERE id = ? AND active = 1)`, carID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return errors.NotFoundf("car %d not found", carID)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO category_cars (category_id, car_id) VALUES (?, ?)`, id, carID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// CategoryAllowsCar reports whether a car competes in a category: it is in the
+// category's car subset, or the category has none. Write-in cars always compete.
+func (r *Repository) CategoryAllowsCar(ctx context.Context, categoryID, carID int) (bool, error) {
+	var allowed bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT `+categoryCarSQL+`
+		FROM (SELECT ? AS category_id, ? AS car_id) v
+	`, categoryID, carID).Scan(&allowed)
+	return allowed, err
+}
+
+// jsonList encodes a string list column as a JSON array, or NULL when empty
+func jsonList(values []string) sql.NullString {
+	if len(values) == 0 {
+		return sql.NullString{}
+	}
+	jsonData, _ := json.Marshal(values) // Marshal on []string never fails
+	return sql.NullString{String: string(jsonData), Valid: true}
+}
+
+// CreateCategory creates a new category with its allowed voter types, allowed ranks and tags
+func (r *Repository) CreateCategory(ctx context.Context, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string, tags []string) (int64, error) {
+	defer r.invalidateResults()
+
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO categories (name, display_order, group_id, allowed_voter_types, allowed_ranks, tags, active, created_by) VALUES (?, ?, ?, ?, ?, ?, 1, ?)`,
+		name, displayOrder, groupID, jsonList(allowedVoterTypes), jsonList(allowedRanks), jsonList(tags), createdBy(ctx))
+	if err != nil {
+		return 0, err
+	}
+	if err := refreshAllBallotComplete(ctx, r.db); err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// UpdateCategory updates a category including allowed voter types, allowed
+// ranks and tags. Tags are replaced, so an empty list clears them.
+func (r *Repository) UpdateCategory(ctx context.Context, id int, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string, active bool, tags []string) error {
+	defer r.invalidateResults()
+
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE categories SET name = ?, display_order = ?, group_id = ?, allowed_voter_types = ?, allowed_ranks = ?, tags = ?, active = ? WHERE id = ?`,
+		name, displayOrder, groupID, jsonList(allowedVoterTypes), jsonList(allowedRanks), jsonList(tags), active, id)
+	if err != nil {
+		return err
+	}
+	return refreshAllBallotComplete(ctx, r.db)
 }
 
-// CreateCategory creates a new category
-func (r *Repository) CreateCategory(ctx context.Context, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string) (int64, error) {
-	var voterTypesJSON, ranksJSON sql.NullString
-	if len(allowedVoterTypes) > 0 {
-		jsonData, _ := json.Marshal(allowedVoterTypes) // Marshal on []string never fails
-		voterTypesJSON = sql.NullString{String: string(jsonData), Valid: true}
+// SetCategoryDerbyNetAwardID links a category to a DerbyNet award, or unlinks it when awardID is nil
+func (r *Repository) SetCategoryDerbyNetAwardID(ctx context.Context, id int, awardID *int) error {
+	defer r.invalidateResults()
+
+	result, err := r.db.ExecContext(ctx, `UPDATE categories SET derbynet_award_id = ? WHERE id = ?`, awardID, id)
+	if err != nil {
+		return err
 	}
-	if len(allowedRanks) > 0 {
-		jsonData, _ := json.Marshal(allowedRanks) // Marshal on []string never fails
-		ranksJSON = sql.NullString{String: string(jsonData), Valid: true}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
 	}
+	if updated == 0 {
+		return errors.NotFound("category not found")
+	}
+	return nil
+}
 
-	result, err := r.db.ExecContext(ctx,
-		`INSERT INTO categories (name, display_order, group_id, allowed_voter_types, allowed_ranks, active) VALUES (?, ?, ?, ?, ?, 1)`,
-		name, displayOrder, groupID, voterTypesJSON, ranksJSON)
+// SetCategoryGroup moves a category into a group, or out of any group when groupID is nil
+func (r *Repository) SetCategoryGroup(ctx context.Context, id int, groupID *int) error {
+	defer r.invalidateResults()
+
+	result, err := r.db.ExecContext(ctx, `UPDATE categories SET group_id = ? WHERE id = ?`, groupID, id)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	return result.LastInsertId()
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errors.NotFound("category not found")
+	}
+	return nil
+}
+
+// CountPoolConflicts counts voters who picked the same car in a category and
+// in another active category of an exclusivity pool
+func (r *Repository) CountPoolConflicts(ctx context.Context, categoryID, poolID int) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT v.voter_id)
+		FROM votes v
+		JOIN votes o ON o.voter_id = v.voter_id AND o.car_id = v.car_id AND o.category_id != v.category_id
+		JOIN categories c ON o.category_id = c.id
+		JOIN category_groups cg ON c.group_id = cg.id
+		WHERE v.category_id = ? AND cg.exclusivity_pool_id = ? AND c.active = 1
+	`, categoryID, poolID).Scan(&count)
+	return count, err
+}
+
+// SetCategoryAllowWriteIn sets whether voters may write in a car for a category
+func (r *Repository) SetCategoryAllowWriteIn(ctx context.Context, id int, allow bool) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE categories SET allow_write_in = ? WHERE id = ?`, allow, id)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errors.NotFound("category not found")
+	}
+	return nil
 }
 
-// UpdateCategory updates a category including allowed voter types and allowed ranks
-func (r *Repository) UpdateCategory(ctx context.Context, id int, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string, active bool) error {
-	var voterTypesJSON, ranksJSON sql.NullString
-	if len(allowedVoterTypes) > 0 {
-		jsonData, _ := json.Marshal(allowedVoterTypes) // Marshal on []string never fails
-		voterTypesJSON = sql.NullString{String: string(jsonData), Valid: true}
+// SetCategoryBannerURL sets the image shown above a category on the ballot; empty clears it
+func (r *Repository) SetCategoryBannerURL(ctx context.Context, id int, bannerURL string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE categories SET banner_url = ? WHERE id = ?`, bannerURL, id)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
 	}
-	if len(allowedRanks) > 0 {
-		jsonData, _ := json.Marshal(allowedRanks) // Marshal on []string never fails
-		ranksJSON = sql.NullString{String: string(jsonData), Valid: true}
+	if updated == 0 {
+		return errors.NotFound("category not found")
 	}
+	return nil
+}
 
-	_, err := r.db.ExecContext(ctx,
-		`UPDATE categories SET name = ?, display_order = ?, group_id = ?, allowed_voter_types = ?, allowed_ranks = ?, active = ? WHERE id = ?`,
-		name, displayOrder, groupID, voterTypesJSON, ranksJSON, active, id)
-	return err
+// SetCategoryShowLiveCounts sets whether the public category listing shows a category's vote counts
+func (r *Repository) SetCategoryShowLiveCounts(ctx context.Context, id int, show bool) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE categories SET show_live_counts = ? WHERE id = ?`, show, id)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errors.NotFound("category not found")
+	}
+	return nil
 }
 
 // DeleteCategory soft-deletes a category
 func (r *Repository) DeleteCategory(ctx context.Context, id int) error {
-	_, err := r.db.ExecContext(ctx, `UPDATE categories SET active = 0 WHERE id = ?`, id)
-	return err
+	defer r.invalidateResults()
+
+	if _, err := r.db.ExecContext(ctx, `UPDATE categories SET active = 0 WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return refreshAllBallotComplete(ctx, r.db)
 }
 
 // CategoryExists checks if a category with the given name exists
@@ -498,6 +1215,8 @@ func (r *Repository) CategoryExists(ctx context.Context, name string) (bool, err
 // UpsertCategory creates or updates a category by name, returns whether it was created
 // Also links to DerbyNet award ID if provided (for bi-directional sync)
 func (r *Repository) UpsertCategory(ctx context.Context, name string, displayOrder int, derbynetAwardID *int) (bool, error) {
+	defer r.invalidateResults()
+
 	// Check if category exists (by name)
 	exists, err := r.CategoryExists(ctx, name)
 	if err != nil {
@@ -515,13 +1234,105 @@ func (r *Repository) UpsertCategory(ctx context.Context, name string, displayOrd
 
 	// Create new category
 	_, err = r.db.ExecContext(ctx,
-		`INSERT INTO categories (name, display_order, derbynet_award_id) VALUES (?, ?, ?)`,
-		name, displayOrder, derbynetAwardID)
+		`INSERT INTO categories (name, display_order, derbynet_award_id, created_by) VALUES (?, ?, ?, ?)`,
+		name, displayOrder, derbynetAwardID, createdBy(ctx))
 	return true, err
 }
 
+// CategoryImportRow is one category definition in a bulk import
+type CategoryImportRow struct {
+	Name         string
+	DisplayOrder int
+	GroupName    string // empty leaves the category ungrouped
+	AllowedRanks []string
+}
+
+// ImportCategories creates or updates categories by name in a single transaction,
+// creating any named groups that don't exist yet. Existing categories keep their
+// active state and allowed voter types. Returns whether each row created a new
+// category, and how many groups were created.
+func (r *Repository) ImportCategories(ctx context.Context, rows []CategoryImportRow) ([]bool, int, error) {
+	defer r.invalidateResults()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	groupIDs := make(map[string]int64)
+	groupsCreated := 0
+	created := make([]bool, len(rows))
+
+	for i, row := range rows {
+		var groupID sql.NullInt64
+		if row.GroupName != "" {
+			id, ok := groupIDs[row.GroupName]
+			if !ok {
+				err := tx.QueryRowContext(ctx,
+					`SELECT id FROM category_groups WHERE name = ? AND active = 1 ORDER BY id LIMIT 1`,
+					row.GroupName).Scan(&id)
+				if err == sql.ErrNoRows {
+					result, err := tx.ExecContext(ctx, `
+						INSERT INTO category_groups (name, multi_win_strategy, display_order, active)
+						SELECT ?, ?, COALESCE(MAX(display_order), 0) + 1, 1 FROM category_groups
+					`, row.GroupName, models.MultiWinStrategyManual)
+					if err != nil {
+						return nil, 0, err
+					}
+					if id, err = result.LastInsertId(); err != nil {
+						return nil, 0, err
+					}
+					groupsCreated++
+				} else if err != nil {
+					return nil, 0, err
+				}
+				groupIDs[row.GroupName] = id
+			}
+			groupID = sql.NullInt64{Int64: id, Valid: true}
+		}
+
+		var ranksJSON sql.NullString
+		if len(row.AllowedRanks) > 0 {
+			jsonData, _ := json.Marshal(row.AllowedRanks) // Marshal on []string never fails
+			ranksJSON = sql.NullString{String: string(jsonData), Valid: true}
+		}
+
+		result, err := tx.ExecContext(ctx,
+			`UPDATE categories SET display_order = ?, group_id = ?, allowed_ranks = ? WHERE name = ?`,
+			row.DisplayOrder, groupID, ranksJSON, row.Name)
+		if err != nil {
+			return nil, 0, err
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return nil, 0, err
+		}
+		if updated > 0 {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO categories (name, display_order, group_id, allowed_ranks, active, created_by) VALUES (?, ?, ?, ?, 1, ?)`,
+			row.Name, row.DisplayOrder, groupID, ranksJSON, createdBy(ctx)); err != nil {
+			return nil, 0, err
+		}
+		created[i] = true
+	}
+	if err := refreshAllBallotComplete(ctx, tx); err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, err
+	}
+	return created, groupsCreated, nil
+}
+
 // SetManualWinner sets the manual winner override for a category
 func (r *Repository) SetManualWinner(ctx context.Context, categoryID, carID int, reason string) error {
+	defer r.invalidateResults()
+
 	_, err := r.db.ExecContext(ctx,
 		`UPDATE categories
 		 SET override_winner_car_id = ?, override_reason = ?, overridden_at = CURRENT_TIMESTAMP
@@ -532,6 +1343,8 @@ func (r *Repository) SetManualWinner(ctx context.Context, categoryID, carID int,
 
 // ClearManualWinner clears the manual winner override for a category
 func (r *Repository) ClearManualWinner(ctx context.Context, categoryID int) error {
+	defer r.invalidateResults()
+
 	_, err := r.db.ExecContext(ctx,
 		`UPDATE categories
 		 SET override_winner_car_id = NULL, override_reason = NULL, overridden_at = NULL
@@ -545,8 +1358,11 @@ func (r *Repository) ClearManualWinner(ctx context.Context, categoryID int) erro
 // ListCategoryGroups returns all active category groups
 func (r *Repository) ListCategoryGroups(ctx context.Context) ([]models.CategoryGroup, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, name, description, exclusivity_pool_id, max_wins_per_car, display_order, active
-		FROM category_groups WHERE active = 1 ORDER BY display_order
+		SELECT cg.id, cg.name, cg.description, cg.exclusivity_pool_id, p.name, cg.max_wins_per_car,
+		       COALESCE(cg.multi_win_strategy, 'manual'), cg.display_order, cg.active
+		FROM category_groups cg
+		LEFT JOIN exclusivity_pools p ON cg.exclusivity_pool_id = p.id
+		WHERE cg.active = 1 ORDER BY cg.display_order
 	`)
 	if err != nil {
 		return nil, err
@@ -556,13 +1372,14 @@ func (r *Repository) ListCategoryGroups(ctx context.Context) ([]models.CategoryG
 	var groups []models.CategoryGroup
 	for rows.Next() {
 		var group models.CategoryGroup
-		var description sql.NullString
+		var description, poolName sql.NullString
 		var exclusivityPoolID sql.NullInt64
 		var maxWinsPerCar sql.NullInt64
-		if err := rows.Scan(&group.ID, &group.Name, &description, &exclusivityPoolID, &maxWinsPerCar, &group.DisplayOrder, &group.Active); err != nil {
+		if err := rows.Scan(&group.ID, &group.Name, &description, &exclusivityPoolID, &poolName, &maxWinsPerCar, &group.MultiWinStrategy, &group.DisplayOrder, &group.Active); err != nil {
 			return nil, err
 		}
 		group.Description = description.String
+		group.ExclusivityPoolName = poolName.String
 		if exclusivityPoolID.Valid {
 			poolID := int(exclusivityPoolID.Int64)
 			group.ExclusivityPoolID = &poolID
@@ -579,12 +1396,16 @@ func (r *Repository) ListCategoryGroups(ctx context.Context) ([]models.CategoryG
 // GetCategoryGroup retrieves a category group by ID
 func (r *Repository) GetCategoryGroup(ctx context.Context, id string) (*models.CategoryGroup, error) {
 	var group models.CategoryGroup
-	var description sql.NullString
+	var description, poolName sql.NullString
 	var exclusivityPoolID sql.NullInt64
 	var maxWinsPerCar sql.NullInt64
-	err := r.db.QueryRowContext(ctx,
-		`SELECT id, name, description, exclusivity_pool_id, max_wins_per_car, display_order, active FROM category_groups WHERE id = ?`,
-		id).Scan(&group.ID, &group.Name, &description, &exclusivityPoolID, &maxWinsPerCar, &group.DisplayOrder, &group.Active)
+	err := r.db.QueryRowContext(ctx, `
+		SELECT cg.id, cg.name, cg.description, cg.exclusivity_pool_id, p.name, cg.max_wins_per_car,
+		       COALESCE(cg.multi_win_strategy, 'manual'), cg.display_order, cg.active
+		FROM category_groups cg
+		LEFT JOIN exclusivity_pools p ON cg.exclusivity_pool_id = p.id
+		WHERE cg.id = ?`,
+		id).Scan(&group.ID, &group.Name, &description, &exclusivityPoolID, &poolName, &maxWinsPerCar, &group.MultiWinStrategy, &group.DisplayOrder, &group.Active)
 
 	if err == sql.ErrNoRows {
 		return nil, errors.NotFound("category group not found")
@@ -594,6 +1415,7 @@ func (r *Repository) GetCategoryGroup(ctx context.Context, id string) (*models.C
 	}
 
 	group.Description = description.String
+	group.ExclusivityPoolName = poolName.String
 	if exclusivityPoolID.Valid {
 		poolID := int(exclusivityPoolID.Int64)
 		group.ExclusivityPoolID = &poolID
@@ -606,10 +1428,18 @@ func (r *Repository) GetCategoryGroup(ctx context.Context, id string) (*models.C
 }
 
 // CreateCategoryGroup creates a new category group
-func (r *Repository) CreateCategoryGroup(ctx context.Context, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, displayOrder int) (int64, error) {
+func (r *Repository) CreateCategoryGroup(ctx context.Context, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, multiWinStrategy string, displayOrder int) (int64, error) {
+	defer r.invalidateResults()
+
+	if multiWinStrategy == "" {
+		multiWinStrategy = models.MultiWinStrategyManual
+	}
+	if err := r.registerExclusivityPool(ctx, exclusivityPoolID); err != nil {
+		return 0, err
+	}
 	result, err := r.db.ExecContext(ctx,
-		`INSERT INTO category_groups (name, description, exclusivity_pool_id, max_wins_per_car, display_order, active) VALUES (?, ?, ?, ?, ?, 1)`,
-		name, description, exclusivityPoolID, maxWinsPerCar, displayOrder)
+		`INSERT INTO category_groups (name, description, exclusivity_pool_id, max_wins_per_car, multi_win_strategy, display_order, active) VALUES (?, ?, ?, ?, ?, ?, 1)`,
+		name, description, exclusivityPoolID, maxWinsPerCar, multiWinStrategy, displayOrder)
 	if err != nil {
 		return 0, err
 	}
@@ -617,26 +1447,167 @@ func (r *Repository) CreateCategoryGroup(ctx context.Context, name, description
 }
 
 // UpdateCategoryGroup updates a category group
-func (r *Repository) UpdateCategoryGroup(ctx context.Context, id string, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, displayOrder int) error {
+func (r *Repository) UpdateCategoryGroup(ctx context.Context, id string, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, multiWinStrategy string, displayOrder int) error {
+	defer r.invalidateResults()
+
+	if multiWinStrategy == "" {
+		multiWinStrategy = models.MultiWinStrategyManual
+	}
+	if err := r.registerExclusivityPool(ctx, exclusivityPoolID); err != nil {
+		return err
+	}
 	_, err := r.db.ExecContext(ctx,
-		`UPDATE category_groups SET name = ?, description = ?, exclusivity_pool_id = ?, max_wins_per_car = ?, display_order = ? WHERE id = ?`,
-		name, description, exclusivityPoolID, maxWinsPerCar, displayOrder, id)
+		`UPDATE category_groups SET name = ?, description = ?, exclusivity_pool_id = ?, max_wins_per_car = ?, multi_win_strategy = ?, display_order = ? WHERE id = ?`,
+		name, description, exclusivityPoolID, maxWinsPerCar, multiWinStrategy, displayOrder, id)
 	return err
 }
 
 // DeleteCategoryGroup deletes a category group
 func (r *Repository) DeleteCategoryGroup(ctx context.Context, id string) error {
+	defer r.invalidateResults()
+
 	_, err := r.db.ExecContext(ctx, `DELETE FROM category_groups WHERE id = ?`, id)
 	return err
 }
 
+// ==================== Exclusivity Pool Methods ====================
+
+// registerExclusivityPool makes sure a pool referenced by ID exists, naming
+// it "Pool N" if not, so groups given a bare pool number keep working
+func (r *Repository) registerExclusivityPool(ctx context.Context, poolID *int) error {
+	if poolID == nil {
+		return nil
+	}
+	_, err := r.db.ExecContext(ctx, `INSERT OR IGNORE INTO exclusivity_pools (id, name) VALUES (?, ?)`, *poolID, fmt.Sprintf("Pool %d", *poolID))
+	return err
+}
+
+// ListExclusivityPools returns all exclusivity pools by name, with how many
+// active groups each has
+func (r *Repository) ListExclusivityPools(ctx context.Context) ([]models.ExclusivityPool, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT p.id, p.name, COUNT(cg.id)
+		FROM exclusivity_pools p
+		LEFT JOIN category_groups cg ON cg.exclusivity_pool_id = p.id AND cg.active = 1
+		GROUP BY p.id
+		ORDER BY p.name COLLATE NOCASE
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pools := []models.ExclusivityPool{}
+	for rows.Next() {
+		var pool models.ExclusivityPool
+		if err := rows.Scan(&pool.ID, &pool.Name, &pool.GroupCount); err != nil {
+			return nil, err
+		}
+		pools = append(pools, pool)
+	}
+	return pools, rows.Err()
+}
+
+// GetExclusivityPoolByName retrieves a pool by name, ignoring case
+func (r *Repository) GetExclusivityPoolByName(ctx context.Context, name string) (*models.ExclusivityPool, error) {
+	var pool models.ExclusivityPool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT p.id, p.name, (SELECT COUNT(*) FROM category_groups cg WHERE cg.exclusivity_pool_id = p.id AND cg.active = 1)
+		FROM exclusivity_pools p WHERE p.name = ? COLLATE NOCASE
+	`, name).Scan(&pool.ID, &pool.Name, &pool.GroupCount)
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFound("exclusivity pool not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
+// exclusivityPoolNameTaken reports whether another pool already has the name, ignoring case
+func (r *Repository) exclusivityPoolNameTaken(ctx context.Context, name string, exceptID int) (bool, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM exclusivity_pools WHERE name = ? COLLATE NOCASE AND id != ?`, name, exceptID).Scan(&count)
+	return count > 0, err
+}
+
+// CreateExclusivityPool creates a pool. Names must be unique, ignoring case.
+func (r *Repository) CreateExclusivityPool(ctx context.Context, name string) (int64, error) {
+	taken, err := r.exclusivityPoolNameTaken(ctx, name, 0)
+	if err != nil {
+		return 0, err
+	}
+	if taken {
+		return 0, errors.Conflictf("an exclusivity pool named %q already exists", name)
+	}
+
+	result, err := r.db.ExecContext(ctx, `INSERT INTO exclusivity_pools (name) VALUES (?)`, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// UpdateExclusivityPool renames a pool
+func (r *Repository) UpdateExclusivityPool(ctx context.Context, id int, name string) error {
+	taken, err := r.exclusivityPoolNameTaken(ctx, name, id)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errors.Conflictf("an exclusivity pool named %q already exists", name)
+	}
+
+	result, err := r.db.ExecContext(ctx, `UPDATE exclusivity_pools SET name = ? WHERE id = ?`, name, id)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errors.NotFound("exclusivity pool not found")
+	}
+	return nil
+}
+
+// DeleteExclusivityPool deletes a pool, removing its groups from it, in a transaction
+func (r *Repository) DeleteExclusivityPool(ctx context.Context, id int) error {
+	defer r.invalidateResults()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE category_groups SET exclusivity_pool_id = NULL WHERE exclusivity_pool_id = ?`, id); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM exclusivity_pools WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errors.NotFound("exclusivity pool not found")
+	}
+
+	return tx.Commit()
+}
+
 // ==================== Car Methods ====================
 
-// ListCars returns all active cars (including ineligible ones, for admin views)
+// ListCars returns all active cars (including ineligible ones, for admin views).
+// Write-in cars are left out; they only appear in results.
 func (r *Repository) ListCars(ctx context.Context) ([]models.Car, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, car_number, racer_name, car_name, photo_url, rank, COALESCE(eligible, 1) as eligible
-		FROM cars WHERE active = 1
+		FROM cars WHERE active = 1 AND COALESCE(write_in, 0) = 0
 		ORDER BY CAST(car_number AS INTEGER)
 	`)
 	if err != nil {
@@ -664,7 +1635,7 @@ func (r *Repository) ListCars(ctx context.Context) ([]models.Car, error) {
 func (r *Repository) ListEligibleCars(ctx context.Context) ([]models.Car, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, car_number, racer_name, car_name, photo_url, rank, COALESCE(eligible, 1) as eligible
-		FROM cars WHERE active = 1 AND COALESCE(eligible, 1) = 1
+		FROM cars WHERE active = 1 AND COALESCE(eligible, 1) = 1 AND COALESCE(write_in, 0) = 0
 		ORDER BY CAST(car_number AS INTEGER)
 	`)
 	if err != nil {
@@ -688,6 +1659,80 @@ func (r *Repository) ListEligibleCars(ctx context.Context) ([]models.Car, error)
 	return cars, nil
 }
 
+// ListCarsPaged returns one page of active cars along with the total number of
+// matching cars. query is matched case-insensitively against car number, racer
+// name and car name; a nil eligible matches both eligible and ineligible cars.
+// A limit of 0 returns every car from offset onward. writeIns selects the cars
+// voters wrote in instead of the registered ones.
+func (r *Repository) ListCarsPaged(ctx context.Context, query string, eligible *bool, writeIns bool, limit, offset int) ([]models.Car, int, error) {
+	where := "active = 1 AND COALESCE(write_in, 0) = ?"
+	args := []interface{}{writeIns}
+	if query != "" {
+		pattern := "%" + likeEscaper.Replace(query) + "%"
+		where += ` AND (car_number LIKE ? ESCAPE '\' OR racer_name LIKE ? ESCAPE '\' OR car_name LIKE ? ESCAPE '\')`
+		args = append(args, pattern, pattern, pattern)
+	}
+	if eligible != nil {
+		where += " AND COALESCE(eligible, 1) = ?"
+		args = append(args, *eligible)
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM cars WHERE "+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// SQLite treats a negative LIMIT as no limit
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, car_number, racer_name, car_name, photo_url, rank, COALESCE(eligible, 1) as eligible, COALESCE(write_in, 0), COALESCE(created_by, '')
+		FROM cars WHERE `+where+`
+		ORDER BY CAST(car_number AS INTEGER), id
+		LIMIT ? OFFSET ?
+	`, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var cars []models.Car
+	for rows.Next() {
+		var car models.Car
+		var racerName, carName, photoURL, rank sql.NullString
+		if err := rows.Scan(&car.ID, &car.CarNumber, &racerName, &carName, &photoURL, &rank, &car.Eligible, &car.WriteIn, &car.CreatedBy); err != nil {
+			return nil, 0, err
+		}
+		car.RacerName = racerName.String
+		car.CarName = carName.String
+		car.PhotoURL = photoURL.String
+		car.Rank = rank.String
+		cars = append(cars, car)
+	}
+	return cars, total, rows.Err()
+}
+
+// likeEscaper escapes LIKE wildcards so search text matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// GetCarDerbyNetRacerID returns the DerbyNet racer ID linked to a car, or nil if it is not linked
+func (r *Repository) GetCarDerbyNetRacerID(ctx context.Context, carID int) (*int, error) {
+	var racerID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT derbynet_racer_id FROM cars WHERE id = ?`, carID).Scan(&racerID)
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFound("car not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !racerID.Valid {
+		return nil, nil
+	}
+	id := int(racerID.Int64)
+	return &id, nil
+}
+
 // GetCarByDerbyNetID checks if a car exists by DerbyNet racer ID
 func (r *Repository) GetCarByDerbyNetID(ctx context.Context, racerID int) (int64, bool, error) {
 	var id sql.NullInt64
@@ -703,9 +1748,11 @@ func (r *Repository) GetCarByDerbyNetID(ctx context.Context, racerID int) (int64
 
 // UpsertCar creates or updates a car
 func (r *Repository) UpsertCar(ctx context.Context, derbynetRacerID int, carNumber, racerName, carName, photoURL, rank string) error {
+	defer r.invalidateResults()
+
 	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO cars (derbynet_racer_id, car_number, racer_name, car_name, photo_url, rank, active)
-		VALUES (?, ?, ?, ?, ?, ?, 1)
+		INSERT INTO cars (derbynet_racer_id, car_number, racer_name, car_name, photo_url, rank, active, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, 1, ?)
 		ON CONFLICT(derbynet_racer_id) DO UPDATE SET
 			car_number = excluded.car_number,
 			racer_name = excluded.racer_name,
@@ -713,22 +1760,31 @@ func (r *Repository) UpsertCar(ctx context.Context, derbynetRacerID int, carNumb
 			photo_url = excluded.photo_url,
 			rank = excluded.rank,
 			synced_at = CURRENT_TIMESTAMP
-	`, derbynetRacerID, carNumber, racerName, carName, photoURL, rank)
+	`, derbynetRacerID, carNumber, racerName, carName, photoURL, rank, createdBy(ctx))
 	return err
 }
 
 // UpsertVoterForCar creates or updates a voter for a car
 func (r *Repository) UpsertVoterForCar(ctx context.Context, carID int64, name, qrCode string) error {
+	defer r.invalidateResults()
+
 	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO voters (car_id, name, voter_type, qr_code)
-		VALUES (?, ?, 'racer', ?)
+		INSERT INTO voters (car_id, name, voter_type, qr_code, created_by)
+		VALUES (?, ?, 'racer', ?, ?)
 		ON CONFLICT(qr_code) DO UPDATE SET
 			car_id = excluded.car_id,
 			name = excluded.name
-	`, carID, name, qrCode)
+	`, carID, name, qrCode, createdBy(ctx))
 	return err
 }
 
+// CarHasVoter reports whether any voter is linked to the car
+func (r *Repository) CarHasVoter(ctx context.Context, carID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM voters WHERE car_id = ?)`, carID).Scan(&exists)
+	return exists, err
+}
+
 // GetVoterByQRCode checks if a voter exists by QR code
 func (r *Repository) GetVoterByQRCode(ctx context.Context, qrCode string) (int64, bool, error) {
 	var id sql.NullInt64
@@ -751,20 +1807,74 @@ func (r *Repository) CarExists(ctx context.Context, carNumber string) (bool, err
 
 // CreateCar creates a new car
 func (r *Repository) CreateCar(ctx context.Context, carNumber, racerName, carName, photoURL string) error {
+	defer r.invalidateResults()
+
 	_, err := r.db.ExecContext(ctx,
-		`INSERT INTO cars (car_number, racer_name, car_name, photo_url, rank, active) VALUES (?, ?, ?, ?, '', 1)`,
-		carNumber, racerName, carName, photoURL)
+		`INSERT INTO cars (car_number, racer_name, car_name, photo_url, rank, active, created_by) VALUES (?, ?, ?, ?, '', 1, ?)`,
+		carNumber, racerName, carName, photoURL, createdBy(ctx))
 	return err
 }
 
+// GetOrCreateWriteInCar returns the write-in car with the given name, creating
+// it if needed. Names match case-insensitively so repeated write-ins share a car.
+// New write-ins are ineligible until an admin approves them, which only keeps
+// them from winning; they still take votes.
+func (r *Repository) GetOrCreateWriteInCar(ctx context.Context, name string) (int, error) {
+	defer r.invalidateResults()
+	return getOrCreateWriteInCar(ctx, r.db, name)
+}
+
+// FindWriteInCar returns the write-in car with the given name without
+// creating one, or ErrNotFound
+func (r *Repository) FindWriteInCar(ctx context.Context, name string) (int, error) {
+	id, err := findWriteInCar(ctx, r.db, name)
+	if err == sql.ErrNoRows {
+		return 0, ErrNotFound
+	}
+	return id, err
+}
+
+func findWriteInCar(ctx context.Context, db sqlQueryExecer, name string) (int, error) {
+	var id int
+	err := db.QueryRowContext(ctx, `
+		SELECT id FROM cars
+		WHERE write_in = 1 AND active = 1 AND car_name = ? COLLATE NOCASE
+		ORDER BY id LIMIT 1
+	`, name).Scan(&id)
+	return id, err
+}
+
+func getOrCreateWriteInCar(ctx context.Context, db sqlQueryExecer, name string) (int, error) {
+	id, err := findWriteInCar(ctx, db, name)
+	if err == nil {
+		return id, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	result, err := db.ExecContext(ctx,
+		`INSERT INTO cars (car_number, racer_name, car_name, photo_url, rank, active, eligible, write_in, created_by) VALUES ('', '', ?, '', '', 1, 0, 1, ?)`,
+		name, createdBy(ctx))
+	if err != nil {
+		return 0, err
+	}
+	newID, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(newID), nil
+}
+
 // GetCar returns a car by ID
 func (r *Repository) GetCar(ctx context.Context, id int) (*models.Car, error) {
 	var car models.Car
 	var racerName, carName, photoURL, rank sql.NullString
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, car_number, racer_name, car_name, photo_url, rank, COALESCE(eligible, 1) as eligible
+		SELECT id, car_number, racer_name, car_name, photo_url, rank, COALESCE(eligible, 1) as eligible, COALESCE(write_in, 0),
+		       COALESCE(created_by, '')
 		FROM cars WHERE id = ? AND active = 1
-	`, id).Scan(&car.ID, &car.CarNumber, &racerName, &carName, &photoURL, &rank, &car.Eligible)
+	`, id).Scan(&car.ID, &car.CarNumber, &racerName, &carName, &photoURL, &rank, &car.Eligible, &car.WriteIn, &car.CreatedBy)
 	if err == sql.ErrNoRows {
 		return nil, errors.NotFound("car not found")
 	}
@@ -780,20 +1890,74 @@ func (r *Repository) GetCar(ctx context.Context, id int) (*models.Car, error) {
 
 // UpdateCar updates a car
 func (r *Repository) UpdateCar(ctx context.Context, id int, carNumber, racerName, carName, photoURL, rank string) error {
+	defer r.invalidateResults()
+
 	_, err := r.db.ExecContext(ctx,
 		`UPDATE cars SET car_number = ?, racer_name = ?, car_name = ?, photo_url = ?, rank = ? WHERE id = ?`,
 		carNumber, racerName, carName, photoURL, rank, id)
 	return err
 }
 
+// SetCarDerbyNetRacerID links a car to a DerbyNet racer, or unlinks it when racerID is nil
+func (r *Repository) SetCarDerbyNetRacerID(ctx context.Context, id int, racerID *int) error {
+	defer r.invalidateResults()
+
+	result, err := r.db.ExecContext(ctx, `UPDATE cars SET derbynet_racer_id = ? WHERE id = ? AND active = 1`, racerID, id)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errors.NotFound("car not found")
+	}
+	return nil
+}
+
 // SetCarEligibility updates a car's eligibility for voting
 func (r *Repository) SetCarEligibility(ctx context.Context, id int, eligible bool) error {
+	defer r.invalidateResults()
+
 	_, err := r.db.ExecContext(ctx, `UPDATE cars SET eligible = ? WHERE id = ?`, eligible, id)
 	return err
 }
 
+// SetCarsEligibility updates the eligibility of several cars in a transaction.
+// Returns how many cars changed; cars already in that state are not counted.
+func (r *Repository) SetCarsEligibility(ctx context.Context, ids []int, eligible bool) (int64, error) {
+	defer r.invalidateResults()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var updated int64
+	for _, id := range ids {
+		result, err := tx.ExecContext(ctx, `UPDATE cars SET eligible = ? WHERE id = ? AND COALESCE(eligible, 1) != ?`, eligible, id, eligible)
+		if err != nil {
+			return 0, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		updated += n
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
+
 // DeleteCar soft deletes a car
 func (r *Repository) DeleteCar(ctx context.Context, id int) error {
+	defer r.invalidateResults()
+
 	_, err := r.db.ExecContext(ctx, `UPDATE cars SET active = 0 WHERE id = ?`, id)
 	return err
 }
@@ -826,6 +1990,13 @@ func (r *Repository) CountVotesForCar(ctx context.Context, carID int) (int, erro
 	return count, err
 }
 
+// CountVotesForVoter returns the number of votes a voter has cast
+func (r *Repository) CountVotesForVoter(ctx context.Context, voterID int) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM votes WHERE voter_id = ?`, voterID).Scan(&count)
+	return count, err
+}
+
 // CountVotesForCategory returns the number of votes in a category
 func (r *Repository) CountVotesForCategory(ctx context.Context, categoryID int) (int, error) {
 	var count int
@@ -835,11 +2006,15 @@ func (r *Repository) CountVotesForCategory(ctx context.Context, categoryID int)
 
 // SaveVote saves or updates a vote
 func (r *Repository) SaveVote(ctx context.Context, voterID, categoryID, carID int) error {
-	now := time.Now()
+	defer r.invalidateResults()
+
+	now := time.Now().UTC()
 
 	if carID == 0 {
-		_, err := r.db.ExecContext(ctx, `DELETE FROM votes WHERE voter_id = ? AND category_id = ?`, voterID, categoryID)
-		return err
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM votes WHERE voter_id = ? AND category_id = ?`, voterID, categoryID); err != nil {
+			return err
+		}
+		return refreshBallotComplete(ctx, r.db, voterID)
 	}
 
 	_, err := r.db.ExecContext(ctx, `
@@ -854,17 +2029,61 @@ func (r *Repository) SaveVote(ctx context.Context, voterID, categoryID, carID in
 		return err
 	}
 
-	_, err = r.db.ExecContext(ctx, `UPDATE voters SET last_voted_at = ? WHERE id = ?`, now, voterID)
-	return err
+	_, err = r.db.ExecContext(ctx, `UPDATE voters SET last_voted_at = ?, last_activity_at = ? WHERE id = ?`, now, now, voterID)
+	if err != nil {
+		return err
+	}
+	return refreshBallotComplete(ctx, r.db, voterID)
+}
+
+// SaveBallot saves several of a voter's selections in a single transaction.
+// votes maps category ID to car ID; a car ID of 0 removes the vote.
+func (r *Repository) SaveBallot(ctx context.Context, voterID int, votes map[int]int) error {
+	defer r.invalidateResults()
+
+	now := time.Now().UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for categoryID, carID := range votes {
+		if carID == 0 {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM votes WHERE voter_id = ? AND category_id = ?`, voterID, categoryID); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO votes (voter_id, category_id, car_id, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(voter_id, category_id) DO UPDATE SET
+				car_id = excluded.car_id,
+				updated_at = excluded.updated_at
+		`, voterID, categoryID, carID, now, now); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE voters SET last_voted_at = ?, last_activity_at = ? WHERE id = ?`, now, now, voterID); err != nil {
+		return err
+	}
+	if err := refreshBallotComplete(ctx, tx, voterID); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // GetExclusivityPoolID returns the exclusivity pool ID for a category
 func (r *Repository) GetExclusivityPoolID(ctx context.Context, categoryID int) (int64, bool, error) {
 	var exclusivityPoolID sql.NullInt64
 	err := r.db.QueryRowContext(ctx, `
-		SELECT cg.exclusivity_pool_id
+		SELECT p.id
 		FROM categories c
 		LEFT JOIN category_groups cg ON c.group_id = cg.id
+		LEFT JOIN exclusivity_pools p ON cg.exclusivity_pool_id = p.id
 		WHERE c.id = ?
 	`, categoryID).Scan(&exclusivityPoolID)
 	if err != nil {
@@ -882,7 +2101,8 @@ func (r *Repository) FindConflictingVote(ctx context.Context, voterID, carID, ca
 		FROM votes v
 		JOIN categories c ON v.category_id = c.id
 		JOIN category_groups cg ON c.group_id = cg.id
-		WHERE v.voter_id = ? AND v.car_id = ? AND v.category_id != ? AND cg.exclusivity_pool_id = ?
+		JOIN exclusivity_pools p ON cg.exclusivity_pool_id = p.id
+		WHERE v.voter_id = ? AND v.car_id = ? AND v.category_id != ? AND p.id = ?
 		LIMIT 1
 	`, voterID, carID, categoryID, poolID).Scan(&conflictCategoryID, &conflictCategoryName)
 
@@ -897,15 +2117,23 @@ func (r *Repository) FindConflictingVote(ctx context.Context, voterID, carID, ca
 
 // ClearConflictingVote removes a vote
 func (r *Repository) ClearConflictingVote(ctx context.Context, voterID, categoryID, carID int) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM votes WHERE voter_id = ? AND category_id = ? AND car_id = ?`, voterID, categoryID, carID)
-	return err
+	defer r.invalidateResults()
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM votes WHERE voter_id = ? AND category_id = ? AND car_id = ?`, voterID, categoryID, carID); err != nil {
+		return err
+	}
+	return refreshBallotComplete(ctx, r.db, voterID)
 }
 
-// GetVoteResults returns vote counts per category and car
+// GetVoteResults returns vote counts per category and car, leaving out test
+// voters and, while complete ballots are required, incomplete ballots
 func (r *Repository) GetVoteResults(ctx context.Context) (map[int]map[int]int, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT category_id, car_id, COUNT(*) as vote_count
-		FROM votes GROUP BY category_id, car_id ORDER BY category_id, vote_count DESC
+		SELECT v.category_id, v.car_id, COUNT(*) as vote_count
+		FROM votes v
+		JOIN voters vr ON v.voter_id = vr.id
+		WHERE `+countedVoteSQL+`
+		GROUP BY v.category_id, v.car_id ORDER BY v.category_id, vote_count DESC
 	`)
 	if err != nil {
 		return nil, err
@@ -935,14 +2163,35 @@ type VoteResultRow struct {
 	RacerName  string
 	PhotoURL   string
 	VoteCount  int
+	WriteIn    bool
+	Eligible   bool
 }
 
-// GetVoteResultsWithCars returns vote results with car details (only cars with votes)
+// GetVoteResultsWithCars returns vote results with car details (only cars with
+// votes), leaving out test voters and, while complete ballots are required,
+// incomplete ballots
 func (r *Repository) GetVoteResultsWithCars(ctx context.Context) ([]VoteResultRow, error) {
+	return r.voteResultsWithCars(ctx, false)
+}
+
+// GetAllVoteResultsWithCars is GetVoteResultsWithCars including test voters
+func (r *Repository) GetAllVoteResultsWithCars(ctx context.Context) ([]VoteResultRow, error) {
+	return r.voteResultsWithCars(ctx, true)
+}
+
+func (r *Repository) voteResultsWithCars(ctx context.Context, includeTest bool) ([]VoteResultRow, error) {
+	where := completeBallotFilterSQL + ` AND ` + categoryCarSQL
+	if !includeTest {
+		where = countedVoteSQL
+	}
+
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT v.category_id, v.car_id, c.car_number, c.car_name, c.racer_name, c.photo_url, COUNT(*) as vote_count
+		SELECT v.category_id, v.car_id, c.car_number, c.car_name, c.racer_name, c.photo_url, COUNT(*) as vote_count,
+		       COALESCE(c.write_in, 0), COALESCE(c.eligible, 1)
 		FROM votes v
 		JOIN cars c ON v.car_id = c.id
+		JOIN voters vr ON v.voter_id = vr.id
+		WHERE `+where+`
 		GROUP BY v.category_id, v.car_id
 		ORDER BY v.category_id, vote_count DESC
 	`)
@@ -955,7 +2204,7 @@ func (r *Repository) GetVoteResultsWithCars(ctx context.Context) ([]VoteResultRo
 	for rows.Next() {
 		var row VoteResultRow
 		var carName, racerName, photoURL sql.NullString
-		if err := rows.Scan(&row.CategoryID, &row.CarID, &row.CarNumber, &carName, &racerName, &photoURL, &row.VoteCount); err != nil {
+		if err := rows.Scan(&row.CategoryID, &row.CarID, &row.CarNumber, &carName, &racerName, &photoURL, &row.VoteCount, &row.WriteIn, &row.Eligible); err != nil {
 			return nil, err
 		}
 		row.CarName = carName.String
@@ -966,6 +2215,364 @@ func (r *Repository) GetVoteResultsWithCars(ctx context.Context) ([]VoteResultRo
 	return results, nil
 }
 
+// RawVoteRow is one stored vote with the voter and car facts that decide
+// whether it counts, for recounting results outside of SQL
+type RawVoteRow struct {
+	VoterID    int
+	VoterType  string
+	IsTest     bool
+	CategoryID int
+	CarID      int
+	WriteIn    bool
+}
+
+// ListRawVotes returns every vote for an existing car, unfiltered and
+// uncounted, so results can be recounted independently of GetVoteResultsWithCars
+func (r *Repository) ListRawVotes(ctx context.Context) ([]RawVoteRow, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT v.voter_id, COALESCE(vr.voter_type, 'general'), COALESCE(vr.is_test, 0),
+		       v.category_id, v.car_id, COALESCE(c.write_in, 0)
+		FROM votes v
+		JOIN voters vr ON v.voter_id = vr.id
+		JOIN cars c ON v.car_id = c.id
+		ORDER BY v.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := []RawVoteRow{}
+	for rows.Next() {
+		var row RawVoteRow
+		if err := rows.Scan(&row.VoterID, &row.VoterType, &row.IsTest, &row.CategoryID, &row.CarID, &row.WriteIn); err != nil {
+			return nil, err
+		}
+		votes = append(votes, row)
+	}
+	return votes, rows.Err()
+}
+
+// TopCarRow is a car's counted votes summed across every active category
+type TopCarRow struct {
+	CarID      int
+	CarNumber  string
+	CarName    string
+	RacerName  string
+	TotalVotes int
+}
+
+// ListTopCars returns the cars with the most counted votes across all active
+// categories, most votes first. A limit of 0 returns every car with votes.
+func (r *Repository) ListTopCars(ctx context.Context, limit int) ([]TopCarRow, error) {
+	// SQLite treats a negative LIMIT as no limit
+	if limit <= 0 {
+		limit = -1
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT v.car_id, c.car_number, c.car_name, c.racer_name, COUNT(*) as total_votes
+		FROM votes v
+		JOIN cars c ON v.car_id = c.id
+		JOIN voters vr ON v.voter_id = vr.id
+		JOIN categories cat ON v.category_id = cat.id
+		WHERE cat.active = 1 AND `+countedVoteSQL+`
+		GROUP BY v.car_id
+		ORDER BY total_votes DESC, v.car_id
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []TopCarRow{}
+	for rows.Next() {
+		var row TopCarRow
+		var carName, racerName sql.NullString
+		if err := rows.Scan(&row.CarID, &row.CarNumber, &carName, &racerName, &row.TotalVotes); err != nil {
+			return nil, err
+		}
+		row.CarName = carName.String
+		row.RacerName = racerName.String
+		results = append(results, row)
+	}
+	return results, rows.Err()
+}
+
+// BallotRow is a single vote in a category with the voter and the car chosen
+type BallotRow struct {
+	VoterID   int
+	VoterQR   string
+	VoterName string
+	VoterType string
+	CarID     int
+	CarNumber string
+	CarName   string
+	RacerName string
+	WriteIn   bool
+	VotedAt   string
+}
+
+// ListCategoryBallots returns every vote cast in a category, oldest first.
+// Unknown categories return a not found error.
+func (r *Repository) ListCategoryBallots(ctx context.Context, categoryID int) ([]BallotRow, error) {
+	var exists int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM categories WHERE id = ?`, categoryID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if exists == 0 {
+		return nil, errors.NotFound("category not found")
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT vr.id, vr.qr_code, vr.name, COALESCE(vr.voter_type, 'general'),
+		       c.id, c.car_number, c.car_name, c.racer_name, COALESCE(c.write_in, 0),
+		       COALESCE(v.updated_at, v.created_at)
+		FROM votes v
+		JOIN voters vr ON v.voter_id = vr.id
+		JOIN cars c ON v.car_id = c.id
+		WHERE v.category_id = ?
+		ORDER BY COALESCE(v.updated_at, v.created_at), v.id
+	`, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ballots := []BallotRow{}
+	for rows.Next() {
+		var row BallotRow
+		var voterName, carName, racerName sql.NullString
+		if err := rows.Scan(&row.VoterID, &row.VoterQR, &voterName, &row.VoterType,
+			&row.CarID, &row.CarNumber, &carName, &racerName, &row.WriteIn, &row.VotedAt); err != nil {
+			return nil, err
+		}
+		row.VoterName = voterName.String
+		row.CarName = carName.String
+		row.RacerName = racerName.String
+		ballots = append(ballots, row)
+	}
+	return ballots, rows.Err()
+}
+
+// ListVotesForCategory returns every vote in a category, earliest cast first.
+// Categories without votes return an empty slice; unknown categories return
+// a not found error.
+func (r *Repository) ListVotesForCategory(ctx context.Context, categoryID int) ([]models.CategoryVote, error) {
+	var exists int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM categories WHERE id = ?`, categoryID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if exists == 0 {
+		return nil, errors.NotFound("category not found")
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT voter_id, car_id, created_at, COALESCE(updated_at, created_at)
+		FROM votes
+		WHERE category_id = ?
+		ORDER BY created_at, id
+	`, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := []models.CategoryVote{}
+	for rows.Next() {
+		var vote models.CategoryVote
+		if err := rows.Scan(&vote.VoterID, &vote.CarID, &vote.CreatedAt, &vote.UpdatedAt); err != nil {
+			return nil, err
+		}
+		votes = append(votes, vote)
+	}
+	return votes, rows.Err()
+}
+
+// VoterExportRow is a voter in a votes export
+type VoterExportRow struct {
+	QRCode    string
+	Name      string
+	Email     string
+	VoterType string
+	Notes     string
+	CarNumber string // number of the voter's own car, if linked
+}
+
+// VoteExportRow is a vote in a votes export, carrying names and numbers
+// alongside IDs so another instance can match it to its own rows
+type VoteExportRow struct {
+	VoterQR      string
+	CategoryID   int
+	CategoryName string
+	CarID        int
+	CarNumber    string
+	CarName      string
+	WriteIn      bool
+	VotedAt      string
+}
+
+// ListVotersForExport returns every voter except test voters, with the number
+// of their linked car
+func (r *Repository) ListVotersForExport(ctx context.Context) ([]VoterExportRow, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT v.qr_code, v.name, v.email, COALESCE(v.voter_type, 'general'), v.notes, c.car_number
+		FROM voters v
+		LEFT JOIN cars c ON v.car_id = c.id
+		WHERE COALESCE(v.is_test, 0) = 0
+		ORDER BY v.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	voters := []VoterExportRow{}
+	for rows.Next() {
+		var row VoterExportRow
+		var name, email, notes, carNumber sql.NullString
+		if err := rows.Scan(&row.QRCode, &name, &email, &row.VoterType, &notes, &carNumber); err != nil {
+			return nil, err
+		}
+		row.Name = name.String
+		row.Email = email.String
+		row.Notes = notes.String
+		row.CarNumber = carNumber.String
+		voters = append(voters, row)
+	}
+	return voters, rows.Err()
+}
+
+// ListVotesForExport returns every vote except test voters' with its voter's
+// QR code, category name and car number
+func (r *Repository) ListVotesForExport(ctx context.Context) ([]VoteExportRow, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT vr.qr_code, v.category_id, cat.name, v.car_id, c.car_number, c.car_name,
+		       COALESCE(c.write_in, 0), COALESCE(v.updated_at, v.created_at)
+		FROM votes v
+		JOIN voters vr ON v.voter_id = vr.id
+		JOIN categories cat ON v.category_id = cat.id
+		JOIN cars c ON v.car_id = c.id
+		WHERE COALESCE(vr.is_test, 0) = 0
+		ORDER BY v.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := []VoteExportRow{}
+	for rows.Next() {
+		var row VoteExportRow
+		var carName sql.NullString
+		if err := rows.Scan(&row.VoterQR, &row.CategoryID, &row.CategoryName, &row.CarID,
+			&row.CarNumber, &carName, &row.WriteIn, &row.VotedAt); err != nil {
+			return nil, err
+		}
+		row.CarName = carName.String
+		votes = append(votes, row)
+	}
+	return votes, rows.Err()
+}
+
+// VoterMergeRow is a voter to add during a vote merge
+type VoterMergeRow struct {
+	QRCode    string
+	Name      string
+	Email     string
+	VoterType string
+	Notes     string
+	CarID     *int
+}
+
+// VoteMergeRow is a vote to add during a vote merge, already resolved to local
+// IDs. A vote for a write-in with no car here yet has CarID 0 and WriteIn set.
+type VoteMergeRow struct {
+	VoterQR    string
+	CategoryID int
+	CarID      int
+	WriteIn    string    // Write-in car name, created inside the merge if needed
+	VotedAt    time.Time // When the vote was cast on the other instance; zero means now
+}
+
+// MergeVotes adds voters and votes from another instance in a single
+// transaction. Voters whose QR code already exists are left as they are, and
+// a vote is only added if the voter has no vote in that category, so nothing
+// already recorded here is overwritten. Votes keep the time they were cast.
+// Returns how many voters and votes were added.
+func (r *Repository) MergeVotes(ctx context.Context, voters []VoterMergeRow, votes []VoteMergeRow) (int, int, error) {
+	defer r.invalidateResults()
+
+	now := time.Now().UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	votersAdded := 0
+	for _, v := range voters {
+		result, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO voters (car_id, name, email, voter_type, qr_code, notes)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, v.CarID, v.Name, v.Email, v.VoterType, v.QRCode, v.Notes)
+		if err != nil {
+			return 0, 0, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, 0, err
+		}
+		votersAdded += int(n)
+	}
+
+	votesAdded := 0
+	for _, v := range votes {
+		carID := v.CarID
+		if carID == 0 {
+			if carID, err = getOrCreateWriteInCar(ctx, tx, v.WriteIn); err != nil {
+				return 0, 0, err
+			}
+		}
+		votedAt := v.VotedAt
+		if votedAt.IsZero() {
+			votedAt = now
+		}
+		result, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO votes (voter_id, category_id, car_id, created_at, updated_at)
+			SELECT id, ?, ?, ?, ? FROM voters WHERE qr_code = ?
+		`, v.CategoryID, carID, votedAt, votedAt, v.VoterQR)
+		if err != nil {
+			return 0, 0, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, 0, err
+		}
+		if n == 0 {
+			continue
+		}
+		votesAdded++
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE voters SET last_voted_at = ?
+			WHERE qr_code = ? AND (last_voted_at IS NULL OR last_voted_at < ?)
+		`, votedAt, v.VoterQR, votedAt); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if err := refreshAllBallotComplete(ctx, tx); err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return votersAdded, votesAdded, nil
+}
+
 // WinnerForDerbyNet represents a winner with DerbyNet IDs for syncing
 type WinnerForDerbyNet struct {
 	CategoryID      int
@@ -976,7 +2583,9 @@ type WinnerForDerbyNet struct {
 	VoteCount       int
 }
 
-// GetWinnersForDerbyNet returns the winner per category with DerbyNet IDs, respecting manual overrides
+// GetWinnersForDerbyNet returns the winner per category with DerbyNet IDs,
+// respecting manual overrides and counting the same votes as the results.
+// Write-ins an admin has not approved cannot win.
 func (r *Repository) GetWinnersForDerbyNet(ctx context.Context) ([]WinnerForDerbyNet, error) {
 	// Get top vote for each category with DerbyNet IDs, respecting manual overrides
 	rows, err := r.db.QueryContext(ctx, `
@@ -987,6 +2596,10 @@ func (r *Repository) GetWinnersForDerbyNet(ctx context.Context) ([]WinnerForDerb
 				COUNT(*) as vote_count,
 				ROW_NUMBER() OVER (PARTITION BY v.category_id ORDER BY COUNT(*) DESC) as rn
 			FROM votes v
+			JOIN voters vr ON v.voter_id = vr.id
+			JOIN cars wc ON wc.id = v.car_id
+			WHERE `+countedVoteSQL+`
+			  AND NOT (COALESCE(wc.write_in, 0) = 1 AND COALESCE(wc.eligible, 1) = 0)
 			GROUP BY v.category_id, v.car_id
 		)
 		SELECT
@@ -1028,6 +2641,51 @@ func (r *Repository) GetWinnersForDerbyNet(ctx context.Context) ([]WinnerForDerb
 	return winners, nil
 }
 
+// SavePushRecord records a push of results to DerbyNet
+func (r *Repository) SavePushRecord(ctx context.Context, record models.PushRecord) (int64, error) {
+	var details sql.NullString
+	if len(record.Details) > 0 {
+		details = sql.NullString{String: string(record.Details), Valid: true}
+	}
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO push_history (derbynet_url, dry_run, status, message, winners_pushed, skipped, errors, details)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, record.DerbyNetURL, record.DryRun, record.Status, record.Message, record.WinnersPushed, record.Skipped, record.Errors, details)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// ListPushHistory returns every recorded push to DerbyNet, newest first
+func (r *Repository) ListPushHistory(ctx context.Context) ([]models.PushRecord, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, derbynet_url, dry_run, status, message, winners_pushed, skipped, errors, details, pushed_at
+		FROM push_history
+		ORDER BY id DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []models.PushRecord{}
+	for rows.Next() {
+		var record models.PushRecord
+		var message, details sql.NullString
+		if err := rows.Scan(&record.ID, &record.DerbyNetURL, &record.DryRun, &record.Status, &message,
+			&record.WinnersPushed, &record.Skipped, &record.Errors, &details, &record.PushedAt); err != nil {
+			return nil, err
+		}
+		record.Message = message.String
+		if details.Valid {
+			record.Details = json.RawMessage(details.String)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
 // ==================== Settings Methods ====================
 
 // GetSetting retrieves a setting value
@@ -1040,32 +2698,78 @@ func (r *Repository) GetSetting(ctx context.Context, key string) (string, error)
 	return value, err
 }
 
+// resultsSettings are the settings that change what results show, so writing
+// them bumps the results version like a vote does
+var resultsSettings = map[string]bool{
+	"require_complete_ballot": true,
+	"results_snapshot":        true,
+}
+
 // SetSetting updates a setting value
 func (r *Repository) SetSetting(ctx context.Context, key, value string) error {
+	if resultsSettings[key] {
+		defer r.invalidateResults()
+	}
 	_, err := r.db.ExecContext(ctx, `INSERT OR REPLACE INTO settings (key, value) VALUES (?, ?)`, key, value)
 	return err
 }
 
+// ListSettings returns all stored settings keyed by name
+func (r *Repository) ListSettings(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT key, value FROM settings`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		settings[key] = value
+	}
+	return settings, rows.Err()
+}
+
 // ==================== Stats Methods ====================
 
-// GetVotingStats returns overall voting statistics
+// GetVotingStats returns overall voting statistics, leaving out test voters
+// and their votes
 func (r *Repository) GetVotingStats(ctx context.Context) (map[string]interface{}, error) {
 	stats := make(map[string]interface{})
 
 	var totalVoters int
-	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM voters`).Scan(&totalVoters); err != nil {
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM voters WHERE COALESCE(is_test, 0) = 0`).Scan(&totalVoters); err != nil {
 		return nil, err
 	}
 	stats["total_voters"] = totalVoters
 
 	var votersWhoVoted int
-	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT voter_id) FROM votes`).Scan(&votersWhoVoted); err != nil {
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT v.voter_id) FROM votes v
+		JOIN voters vr ON v.voter_id = vr.id
+		WHERE COALESCE(vr.is_test, 0) = 0
+	`).Scan(&votersWhoVoted); err != nil {
 		return nil, err
 	}
 	stats["voters_who_voted"] = votersWhoVoted
+	stats["unique_voters"] = votersWhoVoted
+
+	// Share of registered voters who cast at least one vote
+	participationRate := 0.0
+	if totalVoters > 0 {
+		participationRate = float64(votersWhoVoted) / float64(totalVoters)
+	}
+	stats["participation_rate"] = participationRate
 
 	var totalVotes int
-	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM votes`).Scan(&totalVotes); err != nil {
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM votes v
+		JOIN voters vr ON v.voter_id = vr.id
+		WHERE COALESCE(vr.is_test, 0) = 0
+	`).Scan(&totalVotes); err != nil {
 		return nil, err
 	}
 	stats["total_votes"] = totalVotes
@@ -1077,7 +2781,7 @@ func (r *Repository) GetVotingStats(ctx context.Context) (map[string]interface{}
 	stats["total_categories"] = totalCategories
 
 	var totalCars int
-	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM cars WHERE active = 1`).Scan(&totalCars); err != nil {
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM cars WHERE active = 1 AND COALESCE(write_in, 0) = 0`).Scan(&totalCars); err != nil {
 		return nil, err
 	}
 	stats["total_cars"] = totalCars
@@ -1095,6 +2799,8 @@ var validTables = map[string]bool{
 // ClearTable clears all data from a table
 // Only allows clearing whitelisted tables to prevent SQL injection
 func (r *Repository) ClearTable(ctx context.Context, table string) error {
+	defer r.invalidateResults()
+
 	// Validate table name against whitelist
 	if !validTables[table] {
 		return ErrInvalidTable
@@ -1105,8 +2811,50 @@ func (r *Repository) ClearTable(ctx context.Context, table string) error {
 	return err
 }
 
+// ClearEventData deletes all votes and voters and clears manual winner
+// overrides in a single transaction, keeping categories, groups, cars and
+// settings. Returns the number of rows cleared for votes, voters and overrides.
+func (r *Repository) ClearEventData(ctx context.Context) (map[string]int64, error) {
+	defer r.invalidateResults()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	steps := []struct {
+		name  string
+		query string
+	}{
+		{"votes", `DELETE FROM votes`},
+		{"voters", `DELETE FROM voters`},
+		{"overrides", `UPDATE categories
+			SET override_winner_car_id = NULL, override_reason = NULL, overridden_at = NULL
+			WHERE override_winner_car_id IS NOT NULL`},
+	}
+
+	cleared := make(map[string]int64, len(steps))
+	for _, step := range steps {
+		result, err := tx.ExecContext(ctx, step.query)
+		if err != nil {
+			return nil, err
+		}
+		if cleared[step.name], err = result.RowsAffected(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return cleared, nil
+}
+
 // InsertVoterIgnore inserts a voter, ignoring conflicts
 func (r *Repository) InsertVoterIgnore(ctx context.Context, qrCode string) error {
-	_, err := r.db.ExecContext(ctx, `INSERT OR IGNORE INTO voters (qr_code) VALUES (?)`, qrCode)
+	defer r.invalidateResults()
+
+	_, err := r.db.ExecContext(ctx, `INSERT OR IGNORE INTO voters (qr_code, created_by) VALUES (?, ?)`, qrCode, createdBy(ctx))
 	return err
 }
@@ -586,23 +586,13 @@ func TestMigrate_SettingsInsertError(t *testing.T) {
 	}
 	defer db.Close()
 
-	// Expect all CREATE TABLE statements to succeed
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS voters").WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS cars").WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS category_groups").WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS categories").WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS votes").WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS settings").WillReturnResult(sqlmock.NewResult(0, 0))
-
-	// Expect CREATE INDEX statements
-	for i := 0; i < 5; i++ {
-		mock.ExpectExec("CREATE INDEX IF NOT EXISTS").WillReturnResult(sqlmock.NewResult(0, 0))
-	}
-
-	// Expect ALTER TABLE statements (additionalMigrations - these are allowed to fail)
-	for i := 0; i < 8; i++ {
-		mock.ExpectExec("ALTER TABLE").WillReturnResult(sqlmock.NewResult(0, 0))
+	// Every migration is already applied
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	versions := sqlmock.NewRows([]string{"version"})
+	for _, m := range migrations {
+		versions.AddRow(m.version)
 	}
+	mock.ExpectQuery("SELECT version FROM schema_migrations").WillReturnRows(versions)
 
 	// First settings insert succeeds, second one fails
 	mock.ExpectExec("INSERT OR IGNORE INTO settings").WillReturnResult(sqlmock.NewResult(1, 1))
@@ -0,0 +1,60 @@
+-- Schema and sample data from a database created before migrations were
+-- versioned. cars.eligible was already added by the old ad-hoc upgrade; the
+-- other later columns are missing.
+CREATE TABLE voters (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	qr_code TEXT UNIQUE NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	last_voted_at DATETIME
+);
+CREATE TABLE cars (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	derbynet_racer_id INTEGER UNIQUE,
+	car_number TEXT NOT NULL,
+	racer_name TEXT,
+	car_name TEXT,
+	photo_url TEXT,
+	synced_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	active BOOLEAN DEFAULT 1,
+	eligible BOOLEAN DEFAULT 1
+);
+CREATE TABLE category_groups (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	description TEXT,
+	exclusivity_pool_id INTEGER,
+	display_order INTEGER NOT NULL,
+	active BOOLEAN DEFAULT 1
+);
+CREATE TABLE categories (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	display_order INTEGER NOT NULL,
+	active BOOLEAN DEFAULT 1
+);
+CREATE TABLE votes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	voter_id INTEGER NOT NULL,
+	car_id INTEGER NOT NULL,
+	category_id INTEGER NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (voter_id) REFERENCES voters(id),
+	FOREIGN KEY (car_id) REFERENCES cars(id),
+	FOREIGN KEY (category_id) REFERENCES categories(id),
+	UNIQUE(voter_id, category_id)
+);
+CREATE TABLE settings (
+	key TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+
+INSERT INTO voters (id, qr_code, last_voted_at) VALUES (1, 'LEGACY-1', '2024-05-01 12:00:00');
+INSERT INTO voters (id, qr_code) VALUES (2, 'LEGACY-2');
+INSERT INTO cars (id, car_number, racer_name, car_name, eligible) VALUES (1, '101', 'Alice', 'Lightning', 1);
+INSERT INTO cars (id, car_number, racer_name, car_name, eligible) VALUES (2, '102', 'Bob', 'Thunder', 0);
+INSERT INTO categories (id, name, display_order) VALUES (1, 'Best Design', 1);
+INSERT INTO categories (id, name, display_order) VALUES (2, 'Most Creative', 2);
+INSERT INTO votes (voter_id, car_id, category_id) VALUES (1, 1, 1);
+INSERT INTO settings (key, value) VALUES ('voting_open', 'false');
+INSERT INTO settings (key, value) VALUES ('event_name', 'Spring Derby');
@@ -6,8 +6,10 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
 	"github.com/abrezinsky/derbyvote/internal/models"
 	"github.com/abrezinsky/derbyvote/internal/repository"
@@ -26,6 +28,26 @@ type CarService struct {
 	log    logger.Logger
 	repo   CarServiceRepository
 	client derbynet.Client
+	racers racerCache
+}
+
+// racerCacheTTL is how long a DerbyNet racer list is reused before fetching again
+const racerCacheTTL = 30 * time.Second
+
+// racerCache holds the racer list fetched from a DerbyNet URL
+type racerCache struct {
+	mu        sync.Mutex
+	url       string
+	fetchedAt time.Time
+	racers    []DerbyNetRacer
+}
+
+// DerbyNetRacer is a racer from DerbyNet, trimmed down for mapping cars to racers
+type DerbyNetRacer struct {
+	RacerID   int    `json:"racerid"`
+	Name      string `json:"name"`
+	CarNumber string `json:"car_number"`
+	CarName   string `json:"car_name"`
 }
 
 // NewCarService creates a new CarService
@@ -46,6 +68,16 @@ type SyncResult struct {
 	TotalRacers   int    `json:"total_racers"`
 }
 
+// VoterSyncResult contains the result of creating voters from the DerbyNet roster
+type VoterSyncResult struct {
+	Status        string `json:"status"`
+	Message       string `json:"message,omitempty"`
+	TotalRacers   int    `json:"total_racers"`
+	VotersCreated int    `json:"voters_created"`
+	Skipped       int    `json:"skipped"`      // Racers whose car already has a voter
+	MissingCars   int    `json:"missing_cars"` // Racers with no synced car
+}
+
 // PhotoData contains photo metadata and content
 type PhotoData struct {
 	Data        []byte
@@ -57,21 +89,130 @@ func (s *CarService) ListCars(ctx context.Context) ([]models.Car, error) {
 	return s.repo.ListCars(ctx)
 }
 
+// MaxCarPageSize caps how many cars a single page may request
+const MaxCarPageSize = 500
+
+// CarFilter selects a page of cars for the admin car list
+type CarFilter struct {
+	Query    string // matched against car number, racer name and car name
+	Eligible *bool  // nil matches eligible and ineligible cars
+	WriteIns bool   // list the cars voters wrote in instead of registered cars
+	Limit    int    // 0 returns every matching car
+	Offset   int
+}
+
+// ListCarsPaged returns the page of active cars selected by filter and the
+// total number of cars matching it
+func (s *CarService) ListCarsPaged(ctx context.Context, filter CarFilter) ([]models.Car, int, error) {
+	if filter.Limit < 0 || filter.Limit > MaxCarPageSize {
+		return nil, 0, errors.Validationf("limit must be between 0 and %d", MaxCarPageSize)
+	}
+	if filter.Offset < 0 {
+		return nil, 0, errors.Validation("offset must not be negative")
+	}
+	return s.repo.ListCarsPaged(ctx, strings.TrimSpace(filter.Query), filter.Eligible, filter.WriteIns, filter.Limit, filter.Offset)
+}
+
 // GetCar returns a car by ID
 func (s *CarService) GetCar(ctx context.Context, id int) (*models.Car, error) {
 	return s.repo.GetCar(ctx, id)
 }
 
-// CreateCar creates a new car
+// CreateCar creates a new car. The car number is normalized and must not
+// match another car's once normalized.
 func (s *CarService) CreateCar(ctx context.Context, carNumber, racerName, carName, photoURL string) error {
+	carNumber, err := s.checkCarNumber(ctx, carNumber, 0)
+	if err != nil {
+		return err
+	}
 	return s.repo.CreateCar(ctx, carNumber, racerName, carName, photoURL)
 }
 
-// UpdateCar updates a car
+// UpdateCar updates a car. The car number is normalized and must not match
+// another car's once normalized.
 func (s *CarService) UpdateCar(ctx context.Context, id int, carNumber, racerName, carName, photoURL, rank string) error {
+	carNumber, err := s.checkCarNumber(ctx, carNumber, id)
+	if err != nil {
+		return err
+	}
 	return s.repo.UpdateCar(ctx, id, carNumber, racerName, carName, photoURL, rank)
 }
 
+// NormalizeCarNumber validates a car number against the car_number_format
+// setting and returns it as it will be stored
+func (s *CarService) NormalizeCarNumber(ctx context.Context, carNumber string) (string, error) {
+	format, err := s.carNumberFormat(ctx)
+	if err != nil {
+		return "", err
+	}
+	return normalizeCarNumber(carNumber, format)
+}
+
+// checkCarNumber normalizes a car number and returns a *DuplicateCarNumberError
+// if another active car (other than excludeID) has the same normalized number
+func (s *CarService) checkCarNumber(ctx context.Context, carNumber string, excludeID int) (string, error) {
+	format, err := s.carNumberFormat(ctx)
+	if err != nil {
+		return "", err
+	}
+	carNumber, err = normalizeCarNumber(carNumber, format)
+	if err != nil {
+		return "", err
+	}
+
+	cars, err := s.repo.ListCars(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, car := range cars {
+		if car.ID == excludeID {
+			continue
+		}
+		// Numbers stored before the format was set may not normalize; compare them as entered
+		existing, err := normalizeCarNumber(car.CarNumber, format)
+		if err != nil {
+			existing = strings.TrimSpace(car.CarNumber)
+		}
+		if existing == carNumber {
+			return "", &DuplicateCarNumberError{CarNumber: carNumber, Car: car}
+		}
+	}
+	return carNumber, nil
+}
+
+// carNumberFormat returns the car_number_format setting, defaulting to any
+func (s *CarService) carNumberFormat(ctx context.Context) (string, error) {
+	value, err := s.repo.GetSetting(ctx, "car_number_format")
+	if err != nil && err != repository.ErrNotFound {
+		return "", err
+	}
+	if value == CarNumberFormatNumeric {
+		return value, nil
+	}
+	return CarNumberFormatAny, nil
+}
+
+// normalizeCarNumber trims a car number and, in numeric format, requires
+// digits only and drops leading zeros so that "01" and "1" are the same car
+func normalizeCarNumber(carNumber, format string) (string, error) {
+	carNumber = strings.TrimSpace(carNumber)
+	if carNumber == "" {
+		return "", errors.Validation("car number is required")
+	}
+	if format != CarNumberFormatNumeric {
+		return carNumber, nil
+	}
+	for _, r := range carNumber {
+		if r < '0' || r > '9' {
+			return "", errors.Validationf("car number %q must contain only digits", carNumber)
+		}
+	}
+	if trimmed := strings.TrimLeft(carNumber, "0"); trimmed != "" {
+		return trimmed, nil
+	}
+	return "0", nil
+}
+
 // DeleteCar soft deletes a car
 func (s *CarService) DeleteCar(ctx context.Context, id int) error {
 	return s.repo.DeleteCar(ctx, id)
@@ -87,6 +228,151 @@ func (s *CarService) SetCarEligibility(ctx context.Context, id int, eligible boo
 	return s.repo.SetCarEligibility(ctx, id, eligible)
 }
 
+// CarEligibilityFilter selects cars for a bulk eligibility change. Exactly one
+// of Rank and CarIDs is set.
+type CarEligibilityFilter struct {
+	Rank   string
+	CarIDs []int
+}
+
+// MatchCarsForEligibility returns the active cars a bulk eligibility change
+// would apply to. Every listed car ID must exist.
+func (s *CarService) MatchCarsForEligibility(ctx context.Context, filter CarEligibilityFilter) ([]models.Car, error) {
+	rank := strings.TrimSpace(filter.Rank)
+	if (rank == "") == (len(filter.CarIDs) == 0) {
+		return nil, errors.Validation("set either rank or car_ids")
+	}
+
+	cars, err := s.repo.ListCars(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := []models.Car{}
+	if rank != "" {
+		for _, car := range cars {
+			if car.Rank == rank {
+				matched = append(matched, car)
+			}
+		}
+		return matched, nil
+	}
+
+	byID := make(map[int]models.Car, len(cars))
+	for _, car := range cars {
+		byID[car.ID] = car
+	}
+	seen := make(map[int]bool, len(filter.CarIDs))
+	for _, id := range filter.CarIDs {
+		car, ok := byID[id]
+		if !ok {
+			return nil, errors.NotFoundf("car %d not found", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			matched = append(matched, car)
+		}
+	}
+	return matched, nil
+}
+
+// BulkSetCarEligibility updates the eligibility of several cars at once and
+// returns how many changed
+func (s *CarService) BulkSetCarEligibility(ctx context.Context, ids []int, eligible bool) (int64, error) {
+	updated, err := s.repo.SetCarsEligibility(ctx, ids, eligible)
+	if err != nil {
+		return 0, err
+	}
+	s.log.InfoContext(ctx, "Bulk set car eligibility", "eligible", eligible, "matched", len(ids), "updated", updated)
+	return updated, nil
+}
+
+// SetDerbyNetRacer links a car to a DerbyNet racer so result pushes land on the
+// right racer. When a DerbyNet URL is configured the racer must exist there.
+// A nil racerID clears the link.
+func (s *CarService) SetDerbyNetRacer(ctx context.Context, carID int, racerID *int) error {
+	if racerID != nil {
+		if err := s.checkDerbyNetRacer(ctx, *racerID); err != nil {
+			return err
+		}
+
+		linkedCarID, linked, err := s.repo.GetCarByDerbyNetID(ctx, *racerID)
+		if err != nil {
+			return err
+		}
+		if linked && int(linkedCarID) != carID {
+			return errors.Conflictf("DerbyNet racer %d is already linked to car %d", *racerID, linkedCarID)
+		}
+	}
+
+	if err := s.repo.SetCarDerbyNetRacerID(ctx, carID, racerID); err != nil {
+		return err
+	}
+	if racerID == nil {
+		s.log.InfoContext(ctx, "Cleared car DerbyNet racer", "car_id", carID)
+	} else {
+		s.log.InfoContext(ctx, "Mapped car to DerbyNet racer", "car_id", carID, "racer_id", *racerID)
+	}
+	return nil
+}
+
+// ListDerbyNetRacers returns the racers in the configured DerbyNet instance.
+// Results are cached briefly so opening the mapping dialog repeatedly does not
+// hammer the DerbyNet server.
+func (s *CarService) ListDerbyNetRacers(ctx context.Context) ([]DerbyNetRacer, error) {
+	derbyNetURL, _ := s.repo.GetSetting(ctx, "derbynet_url")
+	if derbyNetURL == "" {
+		return nil, errors.Validation("DerbyNet URL is not configured")
+	}
+
+	s.racers.mu.Lock()
+	defer s.racers.mu.Unlock()
+	if s.racers.url == derbyNetURL && time.Since(s.racers.fetchedAt) < racerCacheTTL {
+		return s.racers.racers, nil
+	}
+
+	useDerbyNetURL(ctx, s.client, s.repo, derbyNetURL)
+	racers, err := s.client.FetchRacers(ctx)
+	if err != nil {
+		return nil, errors.Validationf("failed to fetch racers from DerbyNet: %v", err)
+	}
+
+	list := make([]DerbyNetRacer, 0, len(racers))
+	for _, racer := range racers {
+		list = append(list, DerbyNetRacer{
+			RacerID:   racer.RacerID,
+			Name:      strings.TrimSpace(racer.FirstName + " " + racer.LastName),
+			CarNumber: fmt.Sprintf("%d", racer.CarNumber),
+			CarName:   racer.CarName.String(),
+		})
+	}
+
+	s.racers.url = derbyNetURL
+	s.racers.fetchedAt = time.Now()
+	s.racers.racers = list
+	return list, nil
+}
+
+// checkDerbyNetRacer verifies a racer exists in DerbyNet; it is skipped when no URL is configured
+func (s *CarService) checkDerbyNetRacer(ctx context.Context, racerID int) error {
+	derbyNetURL, _ := s.repo.GetSetting(ctx, "derbynet_url")
+	if derbyNetURL == "" {
+		return nil
+	}
+	useDerbyNetURL(ctx, s.client, s.repo, derbyNetURL)
+
+	racers, err := s.client.FetchRacers(ctx)
+	if err != nil {
+		return errors.Validationf("failed to fetch racers from DerbyNet: %v", err)
+	}
+	for _, racer := range racers {
+		if racer.RacerID == racerID {
+			return nil
+		}
+	}
+	return errors.Validationf("DerbyNet racer %d does not exist", racerID)
+}
+
 // CountVotesForCar returns the number of votes a car has received
 func (s *CarService) CountVotesForCar(ctx context.Context, carID int) (int, error) {
 	return s.repo.CountVotesForCar(ctx, carID)
@@ -133,11 +419,10 @@ func (s *CarService) GetCarPhoto(ctx context.Context, id int) (*PhotoData, error
 // SyncFromDerbyNet syncs cars from DerbyNet using the provided URL
 func (s *CarService) SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (*SyncResult, error) {
 	// Set the URL on the client
-	s.client.SetBaseURL(derbyNetURL)
-	baseURL := derbyNetURL
+	useDerbyNetURL(ctx, s.client, s.repo, derbyNetURL)
 
 	// Save DerbyNet URL to settings
-	if err := s.repo.SetSetting(ctx, "derbynet_url", baseURL); err != nil {
+	if err := s.repo.SetSetting(ctx, "derbynet_url", derbyNetURL); err != nil {
 		return nil, fmt.Errorf("failed to save DerbyNet URL: %w", err)
 	}
 
@@ -150,7 +435,10 @@ func (s *CarService) SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (
 		}, nil
 	}
 
-	s.log.Info("Fetched racers from DerbyNet", "count", len(racers))
+	s.log.InfoContext(ctx, "Fetched racers from DerbyNet", "count", len(racers))
+
+	// Photos are served by whichever URL answered, which may be the fallback
+	baseURL := s.client.BaseURL()
 
 	// Process racers
 	result := &SyncResult{Status: "success", TotalRacers: len(racers)}
@@ -169,7 +457,7 @@ func (s *CarService) SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (
 		// Check if car already exists
 		_, carExisted, err := s.repo.GetCarByDerbyNetID(ctx, racer.RacerID)
 		if err != nil {
-			s.log.Error("Error checking car", "racer_id", racer.RacerID, "error", err)
+			s.log.ErrorContext(ctx, "Error checking car", "racer_id", racer.RacerID, "error", err)
 			if firstError == nil {
 				firstError = fmt.Errorf("failed to check car for racer %d: %w", racer.RacerID, err)
 			}
@@ -181,7 +469,7 @@ func (s *CarService) SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (
 
 		// Upsert car
 		if err := s.repo.UpsertCar(ctx, racer.RacerID, carNumber, racerName, racer.CarName.String(), photoURL, rank); err != nil {
-			s.log.Error("Error syncing racer", "racer_id", racer.RacerID, "name", racerName, "error", err)
+			s.log.ErrorContext(ctx, "Error syncing racer", "racer_id", racer.RacerID, "name", racerName, "error", err)
 			if firstError == nil {
 				firstError = fmt.Errorf("failed to sync racer %d: %w", racer.RacerID, err)
 			}
@@ -197,7 +485,7 @@ func (s *CarService) SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (
 		// Get the car ID
 		carID, _, err := s.repo.GetCarByDerbyNetID(ctx, racer.RacerID)
 		if err != nil {
-			s.log.Error("Error getting car ID for racer", "racer_id", racer.RacerID, "error", err)
+			s.log.ErrorContext(ctx, "Error getting car ID for racer", "racer_id", racer.RacerID, "error", err)
 			if firstError == nil {
 				firstError = fmt.Errorf("failed to get car ID for racer %d: %w", racer.RacerID, err)
 			}
@@ -210,7 +498,7 @@ func (s *CarService) SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (
 		// Check if voter exists
 		_, voterExisted, err := s.repo.GetVoterByQRCode(ctx, qrCode)
 		if err != nil {
-			s.log.Error("Error checking voter for racer", "racer_id", racer.RacerID, "error", err)
+			s.log.ErrorContext(ctx, "Error checking voter for racer", "racer_id", racer.RacerID, "error", err)
 			if firstError == nil {
 				firstError = fmt.Errorf("failed to check voter for racer %d: %w", racer.RacerID, err)
 			}
@@ -219,7 +507,7 @@ func (s *CarService) SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (
 
 		// Upsert voter
 		if err := s.repo.UpsertVoterForCar(ctx, carID, racerName, qrCode); err != nil {
-			s.log.Error("Error creating/updating voter for racer", "racer_id", racer.RacerID, "name", racerName, "error", err)
+			s.log.ErrorContext(ctx, "Error creating/updating voter for racer", "racer_id", racer.RacerID, "name", racerName, "error", err)
 			if firstError == nil {
 				firstError = fmt.Errorf("failed to upsert voter for racer %d: %w", racer.RacerID, err)
 			}
@@ -235,20 +523,95 @@ func (s *CarService) SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (
 	result.TotalCars = result.CarsCreated + result.CarsUpdated
 	result.TotalVoters = result.VotersCreated + result.VotersUpdated
 
-	s.log.Info("Sync complete", "cars_created", result.CarsCreated, "cars_updated", result.CarsUpdated,
+	s.log.InfoContext(ctx, "Sync complete", "cars_created", result.CarsCreated, "cars_updated", result.CarsUpdated,
 		"voters_created", result.VotersCreated, "voters_updated", result.VotersUpdated)
 
 	return result, firstError
 }
 
-// SeedMockCars seeds mock car data
-func (s *CarService) SeedMockCars(ctx context.Context) (int, error) {
-	mockCars := []struct {
-		CarNumber string
-		RacerName string
-		CarName   string
-		PhotoURL  string
-	}{
+// SyncVotersFromDerbyNet creates a "racer" voter, with a QR code, for each
+// DerbyNet racer whose car has been synced. Racers whose car already has a
+// voter are skipped, as are racers not yet synced as cars.
+func (s *CarService) SyncVotersFromDerbyNet(ctx context.Context, derbyNetURL string) (*VoterSyncResult, error) {
+	useDerbyNetURL(ctx, s.client, s.repo, derbyNetURL)
+
+	// Save DerbyNet URL to settings
+	if err := s.repo.SetSetting(ctx, "derbynet_url", derbyNetURL); err != nil {
+		return nil, fmt.Errorf("failed to save DerbyNet URL: %w", err)
+	}
+
+	racers, err := s.client.FetchRacers(ctx)
+	if err != nil {
+		return &VoterSyncResult{
+			Status:  "error",
+			Message: fmt.Sprintf("Failed to fetch from DerbyNet: %v", err),
+		}, nil
+	}
+
+	result := &VoterSyncResult{Status: "success", TotalRacers: len(racers)}
+	var firstError error
+
+	for _, racer := range racers {
+		carID, carExists, err := s.repo.GetCarByDerbyNetID(ctx, racer.RacerID)
+		if err != nil {
+			s.log.ErrorContext(ctx, "Error checking car", "racer_id", racer.RacerID, "error", err)
+			if firstError == nil {
+				firstError = fmt.Errorf("failed to check car for racer %d: %w", racer.RacerID, err)
+			}
+			continue
+		}
+		if !carExists {
+			result.MissingCars++
+			continue
+		}
+
+		hasVoter, err := s.repo.CarHasVoter(ctx, carID)
+		if err != nil {
+			s.log.ErrorContext(ctx, "Error checking voter for racer", "racer_id", racer.RacerID, "error", err)
+			if firstError == nil {
+				firstError = fmt.Errorf("failed to check voter for racer %d: %w", racer.RacerID, err)
+			}
+			continue
+		}
+		if hasVoter {
+			result.Skipped++
+			continue
+		}
+
+		// Same code car sync would give the racer's voter
+		racerName := fmt.Sprintf("%s %s", racer.FirstName, racer.LastName)
+		qrCode := GenerateReadableCode(fmt.Sprintf("car-%d-%d", racer.RacerID, carID))
+		if err := s.repo.UpsertVoterForCar(ctx, carID, racerName, qrCode); err != nil {
+			s.log.ErrorContext(ctx, "Error creating voter for racer", "racer_id", racer.RacerID, "name", racerName, "error", err)
+			if firstError == nil {
+				firstError = fmt.Errorf("failed to create voter for racer %d: %w", racer.RacerID, err)
+			}
+			continue
+		}
+		result.VotersCreated++
+	}
+
+	if result.MissingCars > 0 {
+		result.Message = fmt.Sprintf("%d racers have no car yet (sync cars first)", result.MissingCars)
+	}
+
+	s.log.InfoContext(ctx, "Voter sync complete", "voters_created", result.VotersCreated,
+		"skipped", result.Skipped, "missing_cars", result.MissingCars)
+
+	return result, firstError
+}
+
+// MockCar is a demo car created by SeedMockCars
+type MockCar struct {
+	CarNumber string `json:"car_number"`
+	RacerName string `json:"racer_name"`
+	CarName   string `json:"car_name"`
+	PhotoURL  string `json:"photo_url"`
+}
+
+// MockCars returns the demo cars SeedMockCars creates
+func MockCars() []MockCar {
+	return []MockCar{
 		{"101", "Alex Johnson", "Lightning Bolt", "https://placehold.co/300x300/3b82f6/ffffff?text=101"},
 		{"102", "Sarah Williams", "Red Rocket", "https://placehold.co/300x300/ef4444/ffffff?text=102"},
 		{"103", "Mike Chen", "Blue Thunder", "https://placehold.co/300x300/3b82f6/ffffff?text=103"},
@@ -270,13 +633,16 @@ func (s *CarService) SeedMockCars(ctx context.Context) (int, error) {
 		{"119", "Henry Hall", "Emerald Express", "https://placehold.co/300x300/059669/ffffff?text=119"},
 		{"120", "Harper Allen", "Ruby Racer", "https://placehold.co/300x300/be123c/ffffff?text=120"},
 	}
+}
 
+// SeedMockCars seeds mock car data
+func (s *CarService) SeedMockCars(ctx context.Context) (int, error) {
 	var addedCount int
 	var firstError error
-	for _, car := range mockCars {
+	for _, car := range MockCars() {
 		exists, err := s.repo.CarExists(ctx, car.CarNumber)
 		if err != nil {
-			s.log.Error("Error checking car", "car_number", car.CarNumber, "error", err)
+			s.log.ErrorContext(ctx, "Error checking car", "car_number", car.CarNumber, "error", err)
 			if firstError == nil {
 				firstError = fmt.Errorf("failed to check if car exists: %w", err)
 			}
@@ -284,7 +650,7 @@ func (s *CarService) SeedMockCars(ctx context.Context) (int, error) {
 		}
 		if !exists {
 			if err := s.repo.CreateCar(ctx, car.CarNumber, car.RacerName, car.CarName, car.PhotoURL); err != nil {
-				s.log.Error("Error seeding car", "car_number", car.CarNumber, "error", err)
+				s.log.ErrorContext(ctx, "Error seeding car", "car_number", car.CarNumber, "error", err)
 				if firstError == nil {
 					firstError = fmt.Errorf("failed to create car %q: %w", car.CarNumber, err)
 				}
@@ -6,6 +6,7 @@ import (
 	stderrors "errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/abrezinsky/derbyvote/internal/errors"
@@ -34,6 +35,50 @@ func TestCarService_ListCars_Empty(t *testing.T) {
 	}
 }
 
+func TestCarService_SyncFromDerbyNet_UsesFallbackURL(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	mockClient := derbynet.NewMockClient()
+	svc := services.NewCarService(logger.New(), repo, mockClient)
+	ctx := context.Background()
+
+	_ = repo.SetSetting(ctx, "derbynet_url_fallback", "http://10.0.0.42")
+	if _, err := svc.SyncFromDerbyNet(ctx, "http://derbynet.local"); err != nil {
+		t.Fatalf("SyncFromDerbyNet failed: %v", err)
+	}
+	if mockClient.BaseURL() != "http://derbynet.local" || mockClient.FallbackURL() != "http://10.0.0.42" {
+		t.Errorf("expected client pointed at both URLs, got %q and %q", mockClient.BaseURL(), mockClient.FallbackURL())
+	}
+}
+
+func TestCarService_ListCarsPaged(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCarService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	_ = repo.CreateCar(ctx, "1", "Alice", "Rocket", "")
+	_ = repo.CreateCar(ctx, "2", "Bob", "Comet", "")
+
+	cars, total, err := svc.ListCarsPaged(ctx, services.CarFilter{Query: "  comet ", Limit: 10})
+	if err != nil {
+		t.Fatalf("ListCarsPaged failed: %v", err)
+	}
+	if total != 1 || len(cars) != 1 || cars[0].CarNumber != "2" {
+		t.Errorf("expected car 2 only, got %+v (total %d)", cars, total)
+	}
+
+	for _, filter := range []services.CarFilter{
+		{Limit: -1},
+		{Limit: services.MaxCarPageSize + 1},
+		{Offset: -1},
+	} {
+		_, _, err := svc.ListCarsPaged(ctx, filter)
+		var appErr *errors.Error
+		if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrValidation {
+			t.Errorf("expected validation error for %+v, got %v", filter, err)
+		}
+	}
+}
+
 func TestCarService_ListCars_AfterSeeding(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	mockClient := derbynet.NewMockClient()
@@ -190,6 +235,61 @@ func TestCarService_SyncFromDerbyNet_CreatesVoters(t *testing.T) {
 	}
 }
 
+func TestCarService_SyncVotersFromDerbyNet(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	ctx := context.Background()
+
+	// Sync cars (and their voters), then remove three of the voters
+	carSvc := services.NewCarService(log, repo, derbynet.NewMockClient())
+	if _, err := carSvc.SyncFromDerbyNet(ctx, "http://test-derbynet.local"); err != nil {
+		t.Fatalf("SyncFromDerbyNet failed: %v", err)
+	}
+	voters, err := repo.ListVoters(ctx)
+	if err != nil {
+		t.Fatalf("ListVoters failed: %v", err)
+	}
+	for _, voter := range voters[:3] {
+		if err := repo.DeleteVoter(ctx, int(voter["id"].(int64))); err != nil {
+			t.Fatalf("DeleteVoter failed: %v", err)
+		}
+	}
+
+	// DerbyNet now has a racer whose car has not been synced
+	racers := append(derbynet.DefaultMockRacers(), derbynet.Racer{RacerID: 999, FirstName: "New", LastName: "Racer", CarNumber: 999})
+	svc := services.NewCarService(log, repo, derbynet.NewMockClient(derbynet.WithRacers(racers)))
+
+	result, err := svc.SyncVotersFromDerbyNet(ctx, "http://test-derbynet.local")
+	if err != nil {
+		t.Fatalf("SyncVotersFromDerbyNet failed: %v", err)
+	}
+	if result.TotalRacers != 11 || result.VotersCreated != 3 || result.Skipped != 7 || result.MissingCars != 1 {
+		t.Errorf("expected 11 racers, 3 created, 7 skipped, 1 missing car, got %+v", result)
+	}
+
+	voters, err = repo.ListVoters(ctx)
+	if err != nil {
+		t.Fatalf("ListVoters failed: %v", err)
+	}
+	if len(voters) != 10 {
+		t.Errorf("expected 10 voters, got %d", len(voters))
+	}
+	for _, voter := range voters {
+		if voter["voter_type"] != "racer" || voter["car_id"] == nil {
+			t.Errorf("expected a racer voter linked to a car, got %v", voter)
+		}
+	}
+
+	// Running again creates nothing
+	result, err = svc.SyncVotersFromDerbyNet(ctx, "http://test-derbynet.local")
+	if err != nil {
+		t.Fatalf("SyncVotersFromDerbyNet failed: %v", err)
+	}
+	if result.VotersCreated != 0 || result.Skipped != 10 {
+		t.Errorf("expected all racers skipped on a second run, got %+v", result)
+	}
+}
+
 func TestCarService_SyncFromDerbyNet_UpdatesExisting(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	mockClient := derbynet.NewMockClient()
@@ -484,6 +584,124 @@ func TestCarService_GetCar_NotFound(t *testing.T) {
 	}
 }
 
+func TestCarService_SetDerbyNetRacer(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCarService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	cars, _ := repo.ListCars(ctx)
+
+	// Without a DerbyNet URL any racer ID is accepted
+	unchecked := 9999
+	if err := svc.SetDerbyNetRacer(ctx, cars[0].ID, &unchecked); err != nil {
+		t.Fatalf("SetDerbyNetRacer without URL failed: %v", err)
+	}
+
+	repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+	racerID := derbynet.DefaultMockRacers()[2].RacerID
+	if err := svc.SetDerbyNetRacer(ctx, cars[0].ID, &racerID); err != nil {
+		t.Fatalf("SetDerbyNetRacer failed: %v", err)
+	}
+	got, _ := repo.GetCarDerbyNetRacerID(ctx, cars[0].ID)
+	if got == nil || *got != racerID {
+		t.Fatalf("expected racer %d to be linked, got %v", racerID, got)
+	}
+
+	// Racers that DerbyNet does not know about are rejected
+	var appErr *errors.Error
+	err := svc.SetDerbyNetRacer(ctx, cars[0].ID, &unchecked)
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrValidation {
+		t.Errorf("expected validation error for unknown racer, got %v", err)
+	}
+
+	// A racer can only be linked to one car
+	err = svc.SetDerbyNetRacer(ctx, cars[1].ID, &racerID)
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrConflict {
+		t.Errorf("expected conflict for racer linked to another car, got %v", err)
+	}
+
+	if err := svc.SetDerbyNetRacer(ctx, cars[0].ID, nil); err != nil {
+		t.Fatalf("clearing racer failed: %v", err)
+	}
+	got, _ = repo.GetCarDerbyNetRacerID(ctx, cars[0].ID)
+	if got != nil {
+		t.Errorf("expected racer to be cleared, got %d", *got)
+	}
+}
+
+func TestCarService_SetDerbyNetRacer_FetchError(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	client := derbynet.NewMockClient(derbynet.WithFetchError(stderrors.New("connection refused")))
+	svc := services.NewCarService(logger.New(), repo, client)
+	ctx := context.Background()
+	repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+
+	repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	cars, _ := repo.ListCars(ctx)
+
+	racerID := 1
+	err := svc.SetDerbyNetRacer(ctx, cars[0].ID, &racerID)
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected fetch error, got %v", err)
+	}
+}
+
+func TestCarService_ListDerbyNetRacers(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	client := derbynet.NewMockClient(derbynet.WithRacers([]derbynet.Racer{
+		{RacerID: 7, FirstName: "Alice", LastName: "Smith", CarNumber: 101, CarName: "Lightning"},
+	}))
+	svc := services.NewCarService(logger.New(), repo, client)
+	ctx := context.Background()
+
+	// Without a configured URL there is nothing to ask
+	if _, err := svc.ListDerbyNetRacers(ctx); err == nil {
+		t.Fatal("expected error when DerbyNet URL is not configured")
+	}
+
+	repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+	racers, err := svc.ListDerbyNetRacers(ctx)
+	if err != nil {
+		t.Fatalf("ListDerbyNetRacers failed: %v", err)
+	}
+	want := services.DerbyNetRacer{RacerID: 7, Name: "Alice Smith", CarNumber: "101", CarName: "Lightning"}
+	if len(racers) != 1 || racers[0] != want {
+		t.Fatalf("expected %+v, got %+v", want, racers)
+	}
+
+	// A second call within the cache window reuses the list
+	if _, err := svc.ListDerbyNetRacers(ctx); err != nil {
+		t.Fatalf("cached ListDerbyNetRacers failed: %v", err)
+	}
+	if calls := client.FetchRacersCalls(); calls != 1 {
+		t.Errorf("expected 1 fetch, got %d", calls)
+	}
+
+	// Changing the URL bypasses the cache
+	repo.SetSetting(ctx, "derbynet_url", "http://other-derbynet.local")
+	if _, err := svc.ListDerbyNetRacers(ctx); err != nil {
+		t.Fatalf("ListDerbyNetRacers after URL change failed: %v", err)
+	}
+	if calls := client.FetchRacersCalls(); calls != 2 {
+		t.Errorf("expected 2 fetches after URL change, got %d", calls)
+	}
+}
+
+func TestCarService_ListDerbyNetRacers_FetchError(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	client := derbynet.NewMockClient(derbynet.WithFetchError(stderrors.New("connection refused")))
+	svc := services.NewCarService(logger.New(), repo, client)
+	ctx := context.Background()
+	repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+
+	_, err := svc.ListDerbyNetRacers(ctx)
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected fetch error, got %v", err)
+	}
+}
+
 func TestCarService_CreateCar_Success(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	mockClient := derbynet.NewMockClient()
@@ -827,6 +1045,51 @@ func TestCarService_SetCarEligibility_Enable(t *testing.T) {
 	}
 }
 
+func TestCarService_BulkSetCarEligibility(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCarService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	_ = repo.UpsertCar(ctx, 1, "101", "Tiger 1", "", "", "Tiger")
+	_ = repo.UpsertCar(ctx, 2, "102", "Tiger 2", "", "", "Tiger")
+	_ = repo.UpsertCar(ctx, 3, "201", "Wolf 1", "", "", "Wolf")
+	cars, _ := svc.ListCars(ctx)
+
+	var appErr *errors.Error
+	for _, filter := range []services.CarEligibilityFilter{{}, {Rank: "Tiger", CarIDs: []int{cars[0].ID}}} {
+		_, err := svc.MatchCarsForEligibility(ctx, filter)
+		if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrValidation {
+			t.Errorf("expected validation error for %+v, got %v", filter, err)
+		}
+	}
+	_, err := svc.MatchCarsForEligibility(ctx, services.CarEligibilityFilter{CarIDs: []int{9999}})
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrNotFound {
+		t.Errorf("expected not found error for an unknown car, got %v", err)
+	}
+
+	tigers, err := svc.MatchCarsForEligibility(ctx, services.CarEligibilityFilter{Rank: "Tiger"})
+	if err != nil {
+		t.Fatalf("MatchCarsForEligibility failed: %v", err)
+	}
+	if len(tigers) != 2 {
+		t.Fatalf("expected 2 Tiger cars, got %d", len(tigers))
+	}
+
+	// Wolf 1 is already ineligible, so only the two Tigers change
+	_ = svc.SetCarEligibility(ctx, cars[2].ID, false)
+	ids := []int{tigers[0].ID, tigers[1].ID, cars[2].ID}
+	updated, err := svc.BulkSetCarEligibility(ctx, ids, false)
+	if err != nil {
+		t.Fatalf("BulkSetCarEligibility failed: %v", err)
+	}
+	if updated != 2 {
+		t.Errorf("expected 2 cars updated, got %d", updated)
+	}
+	if eligible, _ := svc.ListEligibleCars(ctx); len(eligible) != 0 {
+		t.Errorf("expected no eligible cars, got %d", len(eligible))
+	}
+}
+
 func TestCarService_SetCarEligibility_MultipleCars(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	mockClient := derbynet.NewMockClient()
@@ -1319,7 +1582,7 @@ func TestCarService_CountVotesForCar(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category
-	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create category: %v", err)
 	}
@@ -1379,3 +1642,87 @@ func TestCarService_CountVotesForCar(t *testing.T) {
 		t.Errorf("expected 2 votes, got %d", count)
 	}
 }
+
+func TestCarService_CreateCar_CarNumberValidation(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCarService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	// Whitespace is trimmed before storing
+	if err := svc.CreateCar(ctx, "  7 ", "Racer", "Car", ""); err != nil {
+		t.Fatalf("CreateCar failed: %v", err)
+	}
+	cars, _ := repo.ListCars(ctx)
+	if len(cars) != 1 || cars[0].CarNumber != "7" {
+		t.Fatalf("expected trimmed car number 7, got %+v", cars)
+	}
+
+	// Blank numbers are rejected
+	err := svc.CreateCar(ctx, "   ", "Racer", "Car", "")
+	var appErr *errors.Error
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrValidation {
+		t.Errorf("expected validation error for blank car number, got %v", err)
+	}
+
+	// Exact duplicates are rejected regardless of format
+	err = svc.CreateCar(ctx, "7", "Other", "Car", "")
+	var dupErr *services.DuplicateCarNumberError
+	if !stderrors.As(err, &dupErr) || dupErr.Car.ID != cars[0].ID {
+		t.Errorf("expected duplicate error naming car %d, got %v", cars[0].ID, err)
+	}
+
+	// In the default format, leading zeros make a different number
+	if err := svc.CreateCar(ctx, "07", "Other", "Car", ""); err != nil {
+		t.Errorf("expected 07 to be allowed alongside 7, got %v", err)
+	}
+}
+
+func TestCarService_CreateCar_NumericCarNumbers(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCarService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+	repo.SetSetting(ctx, "car_number_format", services.CarNumberFormatNumeric)
+
+	if err := svc.CreateCar(ctx, "1", "Racer", "Car", ""); err != nil {
+		t.Fatalf("CreateCar failed: %v", err)
+	}
+	cars, _ := repo.ListCars(ctx)
+
+	// "01" and "1" are the same car number
+	err := svc.CreateCar(ctx, "01", "Other", "Car", "")
+	var dupErr *services.DuplicateCarNumberError
+	if !stderrors.As(err, &dupErr) {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+	if dupErr.Car.ID != cars[0].ID || dupErr.CarNumber != "1" {
+		t.Errorf("expected conflict with car %d on number 1, got %+v", cars[0].ID, dupErr)
+	}
+
+	// Non-digits are rejected
+	err = svc.CreateCar(ctx, "1A", "Other", "Car", "")
+	var appErr *errors.Error
+	if !stderrors.As(err, &appErr) || appErr.Kind != errors.ErrValidation {
+		t.Errorf("expected validation error for 1A, got %v", err)
+	}
+
+	// Leading zeros are dropped when storing
+	if err := svc.CreateCar(ctx, "007", "Bond", "Car", ""); err != nil {
+		t.Fatalf("CreateCar failed: %v", err)
+	}
+	number, err := svc.NormalizeCarNumber(ctx, "007")
+	if err != nil || number != "7" {
+		t.Errorf("expected normalized number 7, got %q (%v)", number, err)
+	}
+	if exists, _ := repo.CarExists(ctx, "7"); !exists {
+		t.Error("expected car stored as 7")
+	}
+
+	// Updating a car without changing its number is not a conflict
+	if err := svc.UpdateCar(ctx, cars[0].ID, "001", "Racer", "Renamed", "", ""); err != nil {
+		t.Errorf("expected update keeping the same number to succeed, got %v", err)
+	}
+	// Moving a car onto another car's number is
+	if err := svc.UpdateCar(ctx, cars[0].ID, "07", "Racer", "Car", "", ""); !stderrors.As(err, &dupErr) {
+		t.Errorf("expected duplicate error on update, got %v", err)
+	}
+}
@@ -2,9 +2,14 @@ package services
 
 import (
 	"context"
+	"encoding/csv"
+	stderrors "errors"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
+	"github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
 	"github.com/abrezinsky/derbyvote/internal/models"
 	"github.com/abrezinsky/derbyvote/internal/repository"
@@ -42,6 +47,24 @@ type CategorySyncResult struct {
 	AuthError         string `json:"auth_error,omitempty"` // DerbyNet authentication error if any
 }
 
+// CategoryDiff previews what a DerbyNet category sync would change
+type CategoryDiff struct {
+	AwardsToImport []derbynet.Award  `json:"awards_to_import"` // awards with no local category yet
+	LocalOnly      []models.Category `json:"local_only"`       // categories that would be pushed to DerbyNet
+	Matched        []CategoryMatch   `json:"matched"`
+}
+
+// CategoryMatch pairs a local category with a DerbyNet award. Linked is true
+// when the category already carries the award ID; otherwise the two match by
+// name and a sync would link them.
+type CategoryMatch struct {
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	AwardID      int    `json:"award_id"`
+	AwardName    string `json:"award_name"`
+	Linked       bool   `json:"linked"`
+}
+
 // Category represents a category for create/update operations
 type Category struct {
 	Name              string
@@ -50,15 +73,22 @@ type Category struct {
 	Active            bool
 	AllowedVoterTypes []string
 	AllowedRanks      []string
+	Tags              []string
+	AllowWriteIn      bool
+	ShowLiveCounts    bool
+	BannerURL         string
 }
 
-// CategoryGroup represents a category group for create/update operations
+// CategoryGroup represents a category group for create/update operations.
+// The pool may be given by ID or by name.
 type CategoryGroup struct {
-	Name              string
-	Description       string
-	ExclusivityPoolID *int
-	MaxWinsPerCar     *int
-	DisplayOrder      int
+	Name                string
+	Description         string
+	ExclusivityPoolID   *int
+	ExclusivityPoolName string
+	MaxWinsPerCar       *int
+	MultiWinStrategy    string
+	DisplayOrder        int
 }
 
 // ListCategories returns all active categories
@@ -71,14 +101,104 @@ func (s *CategoryService) ListAllCategories(ctx context.Context) ([]map[string]i
 	return s.repo.ListAllCategories(ctx)
 }
 
+// ListAllCategoriesByTag returns all categories (including inactive) carrying
+// the given tag, matched case-insensitively
+func (s *CategoryService) ListAllCategoriesByTag(ctx context.Context, tag string) ([]map[string]interface{}, error) {
+	categories, err := s.repo.ListAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tag = strings.TrimSpace(tag)
+	filtered := []map[string]interface{}{}
+	for _, cat := range categories {
+		tags, _ := cat["tags"].([]string)
+		for _, t := range tags {
+			if strings.EqualFold(t, tag) {
+				filtered = append(filtered, cat)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
+
 // CreateCategory creates a new category
 func (s *CategoryService) CreateCategory(ctx context.Context, cat Category) (int64, error) {
-	return s.repo.CreateCategory(ctx, cat.Name, cat.DisplayOrder, cat.GroupID, cat.AllowedVoterTypes, cat.AllowedRanks)
+	if err := validateBannerURL(cat.BannerURL); err != nil {
+		return 0, err
+	}
+	id, err := s.repo.CreateCategory(ctx, cat.Name, cat.DisplayOrder, cat.GroupID, cat.AllowedVoterTypes, cat.AllowedRanks, NormalizeTags(cat.Tags))
+	if err != nil {
+		return 0, err
+	}
+	if cat.AllowWriteIn {
+		if err := s.repo.SetCategoryAllowWriteIn(ctx, int(id), true); err != nil {
+			return 0, err
+		}
+	}
+	if cat.ShowLiveCounts {
+		if err := s.repo.SetCategoryShowLiveCounts(ctx, int(id), true); err != nil {
+			return 0, err
+		}
+	}
+	if cat.BannerURL != "" {
+		if err := s.repo.SetCategoryBannerURL(ctx, int(id), cat.BannerURL); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
 }
 
-// UpdateCategory updates a category
+// UpdateCategory updates a category. Tags and the banner are replaced, so
+// leaving them empty clears them.
 func (s *CategoryService) UpdateCategory(ctx context.Context, id int, cat Category) error {
-	return s.repo.UpdateCategory(ctx, id, cat.Name, cat.DisplayOrder, cat.GroupID, cat.AllowedVoterTypes, cat.AllowedRanks, cat.Active)
+	if err := validateBannerURL(cat.BannerURL); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateCategory(ctx, id, cat.Name, cat.DisplayOrder, cat.GroupID, cat.AllowedVoterTypes, cat.AllowedRanks, cat.Active, NormalizeTags(cat.Tags)); err != nil {
+		return err
+	}
+	if err := s.repo.SetCategoryAllowWriteIn(ctx, id, cat.AllowWriteIn); err != nil {
+		return err
+	}
+	if err := s.repo.SetCategoryShowLiveCounts(ctx, id, cat.ShowLiveCounts); err != nil {
+		return err
+	}
+	return s.repo.SetCategoryBannerURL(ctx, id, cat.BannerURL)
+}
+
+// SetCategoryBannerURL points a category's banner at an uploaded image
+func (s *CategoryService) SetCategoryBannerURL(ctx context.Context, categoryID int, bannerURL string) error {
+	if err := validateBannerURL(bannerURL); err != nil {
+		return err
+	}
+	return s.repo.SetCategoryBannerURL(ctx, categoryID, bannerURL)
+}
+
+// validateBannerURL accepts an empty banner, an http(s) URL or a path on this server
+func validateBannerURL(bannerURL string) error {
+	if bannerURL != "" && !isHTTPURL(bannerURL) && !strings.HasPrefix(bannerURL, "/") {
+		return errors.InvalidFields(map[string]string{"banner_url": "must be an http or https URL or a path starting with /"})
+	}
+	return nil
+}
+
+// NormalizeTags trims category tags and drops blanks and case-insensitive
+// duplicates, keeping the first spelling of each tag
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
 }
 
 // DeleteCategory soft-deletes a category
@@ -86,6 +206,183 @@ func (s *CategoryService) DeleteCategory(ctx context.Context, id int) error {
 	return s.repo.DeleteCategory(ctx, id)
 }
 
+// ListDerbyNetAwards returns the awards defined in the configured DerbyNet instance
+func (s *CategoryService) ListDerbyNetAwards(ctx context.Context) ([]derbynet.Award, error) {
+	derbyNetURL, _ := s.repo.GetSetting(ctx, "derbynet_url")
+	if derbyNetURL == "" {
+		return nil, errors.Validation("DerbyNet URL is not configured")
+	}
+	useDerbyNetURL(ctx, s.client, s.repo, derbyNetURL)
+
+	awards, err := s.client.FetchAwards(ctx)
+	if err != nil {
+		return nil, errors.Validationf("failed to fetch awards from DerbyNet: %v", err)
+	}
+	if awards == nil {
+		awards = []derbynet.Award{}
+	}
+	return awards, nil
+}
+
+// SetDerbyNetAward maps a category to a DerbyNet award, checking that the
+// award exists in DerbyNet. A nil awardID clears the mapping.
+func (s *CategoryService) SetDerbyNetAward(ctx context.Context, categoryID int, awardID *int) error {
+	if awardID != nil {
+		awards, err := s.ListDerbyNetAwards(ctx)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, award := range awards {
+			if award.AwardID == *awardID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Validationf("DerbyNet award %d does not exist", *awardID)
+		}
+	}
+
+	if err := s.repo.SetCategoryDerbyNetAwardID(ctx, categoryID, awardID); err != nil {
+		return err
+	}
+	if awardID == nil {
+		s.log.InfoContext(ctx, "Cleared category DerbyNet award", "category_id", categoryID)
+	} else {
+		s.log.InfoContext(ctx, "Mapped category to DerbyNet award", "category_id", categoryID, "award_id", *awardID)
+	}
+	return nil
+}
+
+// SetCategoryCars limits a category to a subset of cars. An empty list
+// removes the subset so every eligible car competes again.
+func (s *CategoryService) SetCategoryCars(ctx context.Context, categoryID int, carIDs []int) error {
+	if err := s.repo.SetCategoryCars(ctx, categoryID, carIDs); err != nil {
+		return err
+	}
+	s.log.InfoContext(ctx, "Set category cars", "category_id", categoryID, "cars", len(carIDs))
+	return nil
+}
+
+// CategoryGroupMove is a category after moving it to another group, with the
+// number of voters whose existing picks now break the new group's exclusivity pool
+type CategoryGroupMove struct {
+	Category             map[string]interface{}
+	ExclusivityConflicts int
+}
+
+// SetCategoryGroup moves a category into a group, or out of any group when
+// groupID is nil. Existing votes are kept; any that now pick the same car as
+// another category in the group's exclusivity pool are counted for review.
+func (s *CategoryService) SetCategoryGroup(ctx context.Context, categoryID int, groupID *int) (*CategoryGroupMove, error) {
+	var group *models.CategoryGroup
+	if groupID != nil {
+		var err error
+		group, err = s.repo.GetCategoryGroup(ctx, strconv.Itoa(*groupID))
+		var appErr *errors.Error
+		if (stderrors.As(err, &appErr) && appErr.Kind == errors.ErrNotFound) || (err == nil && !group.Active) {
+			return nil, errors.InvalidFields(map[string]string{"group_id": fmt.Sprintf("no category group has ID %d", *groupID)})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.SetCategoryGroup(ctx, categoryID, groupID); err != nil {
+		return nil, err
+	}
+
+	move := &CategoryGroupMove{}
+	if group != nil && group.ExclusivityPoolID != nil {
+		conflicts, err := s.repo.CountPoolConflicts(ctx, categoryID, *group.ExclusivityPoolID)
+		if err != nil {
+			return nil, err
+		}
+		move.ExclusivityConflicts = conflicts
+	}
+
+	categories, err := s.repo.ListAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, cat := range categories {
+		if cat["id"] == categoryID {
+			move.Category = cat
+			break
+		}
+	}
+
+	if groupID == nil {
+		s.log.InfoContext(ctx, "Removed category from its group", "category_id", categoryID)
+	} else {
+		s.log.InfoContext(ctx, "Moved category to group", "category_id", categoryID, "group_id", *groupID, "exclusivity_conflicts", move.ExclusivityConflicts)
+	}
+	return move, nil
+}
+
+// DerbyNetCategoryDiff compares DerbyNet awards with local categories without
+// changing either side, so an admin can review a sync before running it.
+func (s *CategoryService) DerbyNetCategoryDiff(ctx context.Context) (*CategoryDiff, error) {
+	awards, err := s.ListDerbyNetAwards(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &CategoryDiff{
+		AwardsToImport: []derbynet.Award{},
+		LocalOnly:      []models.Category{},
+		Matched:        []CategoryMatch{},
+	}
+
+	awardsByID := make(map[int]derbynet.Award, len(awards))
+	awardsByName := make(map[string]derbynet.Award, len(awards))
+	for _, award := range awards {
+		awardsByID[award.AwardID] = award
+		awardsByName[award.AwardName] = award
+	}
+
+	matchedAwards := make(map[int]bool)
+	for _, cat := range categories {
+		if cat.DerbyNetAwardID != nil {
+			if award, ok := awardsByID[*cat.DerbyNetAwardID]; ok {
+				diff.Matched = append(diff.Matched, CategoryMatch{
+					CategoryID:   cat.ID,
+					CategoryName: cat.Name,
+					AwardID:      award.AwardID,
+					AwardName:    award.AwardName,
+					Linked:       true,
+				})
+				matchedAwards[award.AwardID] = true
+				continue
+			}
+		}
+		if award, ok := awardsByName[cat.Name]; ok {
+			diff.Matched = append(diff.Matched, CategoryMatch{
+				CategoryID:   cat.ID,
+				CategoryName: cat.Name,
+				AwardID:      award.AwardID,
+				AwardName:    award.AwardName,
+			})
+			matchedAwards[award.AwardID] = true
+			continue
+		}
+		diff.LocalOnly = append(diff.LocalOnly, cat)
+	}
+
+	for _, award := range awards {
+		if !matchedAwards[award.AwardID] {
+			diff.AwardsToImport = append(diff.AwardsToImport, award)
+		}
+	}
+
+	return diff, nil
+}
+
 // CountVotesForCategory returns the number of votes in a category
 func (s *CategoryService) CountVotesForCategory(ctx context.Context, categoryID int) (int, error) {
 	return s.repo.CountVotesForCategory(ctx, categoryID)
@@ -103,12 +400,100 @@ func (s *CategoryService) GetGroup(ctx context.Context, id string) (*models.Cate
 
 // CreateGroup creates a new category group
 func (s *CategoryService) CreateGroup(ctx context.Context, group CategoryGroup) (int64, error) {
-	return s.repo.CreateCategoryGroup(ctx, group.Name, group.Description, group.ExclusivityPoolID, group.MaxWinsPerCar, group.DisplayOrder)
+	if err := validateMultiWinStrategy(group.MultiWinStrategy); err != nil {
+		return 0, err
+	}
+	poolID, err := s.resolveGroupPool(ctx, group)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.CreateCategoryGroup(ctx, group.Name, group.Description, poolID, group.MaxWinsPerCar, group.MultiWinStrategy, group.DisplayOrder)
 }
 
 // UpdateGroup updates a category group
 func (s *CategoryService) UpdateGroup(ctx context.Context, id string, group CategoryGroup) error {
-	return s.repo.UpdateCategoryGroup(ctx, id, group.Name, group.Description, group.ExclusivityPoolID, group.MaxWinsPerCar, group.DisplayOrder)
+	if err := validateMultiWinStrategy(group.MultiWinStrategy); err != nil {
+		return err
+	}
+	poolID, err := s.resolveGroupPool(ctx, group)
+	if err != nil {
+		return err
+	}
+	return s.repo.UpdateCategoryGroup(ctx, id, group.Name, group.Description, poolID, group.MaxWinsPerCar, group.MultiWinStrategy, group.DisplayOrder)
+}
+
+// resolveGroupPool returns the ID of the group's exclusivity pool, looking it
+// up when the pool is given by name
+func (s *CategoryService) resolveGroupPool(ctx context.Context, group CategoryGroup) (*int, error) {
+	name := strings.TrimSpace(group.ExclusivityPoolName)
+	if name == "" {
+		return group.ExclusivityPoolID, nil
+	}
+
+	pool, err := s.repo.GetExclusivityPoolByName(ctx, name)
+	var appErr *errors.Error
+	if stderrors.As(err, &appErr) && appErr.Kind == errors.ErrNotFound {
+		return nil, errors.InvalidFields(map[string]string{"exclusivity_pool_name": fmt.Sprintf("no exclusivity pool is named %q", name)})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if group.ExclusivityPoolID != nil && *group.ExclusivityPoolID != pool.ID {
+		return nil, errors.InvalidFields(map[string]string{"exclusivity_pool_name": fmt.Sprintf("pool %q does not have ID %d", pool.Name, *group.ExclusivityPoolID)})
+	}
+	return &pool.ID, nil
+}
+
+// maxPoolNameLength caps the length of an exclusivity pool name
+const maxPoolNameLength = 100
+
+// validatePoolName trims an exclusivity pool name and checks it is usable
+func validatePoolName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.InvalidFields(map[string]string{"name": "name is required"})
+	}
+	if len([]rune(name)) > maxPoolNameLength {
+		return "", errors.InvalidFields(map[string]string{"name": fmt.Sprintf("name must be at most %d characters", maxPoolNameLength)})
+	}
+	return name, nil
+}
+
+// ListPools returns all exclusivity pools by name
+func (s *CategoryService) ListPools(ctx context.Context) ([]models.ExclusivityPool, error) {
+	return s.repo.ListExclusivityPools(ctx)
+}
+
+// CreatePool creates a named exclusivity pool
+func (s *CategoryService) CreatePool(ctx context.Context, name string) (int64, error) {
+	name, err := validatePoolName(name)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.CreateExclusivityPool(ctx, name)
+}
+
+// UpdatePool renames an exclusivity pool
+func (s *CategoryService) UpdatePool(ctx context.Context, id int, name string) error {
+	name, err := validatePoolName(name)
+	if err != nil {
+		return err
+	}
+	return s.repo.UpdateExclusivityPool(ctx, id, name)
+}
+
+// DeletePool deletes an exclusivity pool. Its groups stay, without a pool.
+func (s *CategoryService) DeletePool(ctx context.Context, id int) error {
+	return s.repo.DeleteExclusivityPool(ctx, id)
+}
+
+// validateMultiWinStrategy accepts an empty strategy (defaults to manual) or a known strategy
+func validateMultiWinStrategy(strategy string) error {
+	switch strategy {
+	case "", models.MultiWinStrategyManual, models.MultiWinStrategyAutoRunnerUp:
+		return nil
+	}
+	return errors.Validationf("invalid multi_win_strategy %q: must be %q or %q", strategy, models.MultiWinStrategyManual, models.MultiWinStrategyAutoRunnerUp)
 }
 
 // DeleteGroup deletes a category group
@@ -116,12 +501,155 @@ func (s *CategoryService) DeleteGroup(ctx context.Context, id string) error {
 	return s.repo.DeleteCategoryGroup(ctx, id)
 }
 
-// SeedMockCategories seeds mock category data
-func (s *CategoryService) SeedMockCategories(ctx context.Context) (int, error) {
-	mockCategories := []struct {
-		Name         string
-		DisplayOrder int
-	}{
+// Category import line statuses
+const (
+	CategoryImportCreated    = "created"
+	CategoryImportUpdated    = "updated"
+	CategoryImportRejected   = "rejected"
+	CategoryImportRolledBack = "rolled_back"
+)
+
+// CategoryImportLine is the outcome of one line of a category CSV import
+type CategoryImportLine struct {
+	Line   int    `json:"line"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// CategoryImportResult contains the per-line outcome of a category CSV import
+type CategoryImportResult struct {
+	Results       []CategoryImportLine `json:"results"`
+	Created       int                  `json:"created"`
+	Updated       int                  `json:"updated"`
+	GroupsCreated int                  `json:"groups_created"`
+	Committed     bool                 `json:"committed"`
+}
+
+// ImportCategoriesCSV creates or updates categories from a CSV with the columns
+// name, display_order, group_name and allowed_ranks (pipe-separated). A header
+// row is optional. An empty display_order uses the line's position in the file.
+// The import is all or nothing: if any line is rejected, no changes are saved.
+func (s *CategoryService) ImportCategoriesCSV(ctx context.Context, r io.Reader) (*CategoryImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	result := &CategoryImportResult{Results: []CategoryImportLine{}}
+	var rows []repository.CategoryImportRow
+	var rowLines []int // index into result.Results for each row
+	seen := make(map[string]int)
+	rejected := false
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Validationf("invalid CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(result.Results) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "name") {
+			continue
+		}
+
+		row, err := parseCategoryImportRecord(record, len(result.Results)+1)
+		entry := CategoryImportLine{Line: line, Name: row.Name}
+		if err == nil {
+			if first, dup := seen[row.Name]; dup {
+				err = errors.Validationf("duplicate category name (first seen on line %d)", first)
+			}
+		}
+		if err != nil {
+			entry.Status = CategoryImportRejected
+			entry.Error = err.Error()
+			rejected = true
+		} else {
+			seen[row.Name] = line
+			rows = append(rows, row)
+			rowLines = append(rowLines, len(result.Results))
+		}
+		result.Results = append(result.Results, entry)
+	}
+
+	if len(result.Results) == 0 {
+		return nil, errors.Validation("CSV contains no categories")
+	}
+
+	if rejected {
+		for _, i := range rowLines {
+			result.Results[i].Status = CategoryImportRolledBack
+		}
+		s.log.InfoContext(ctx, "Category import rejected", "lines", len(result.Results))
+		return result, nil
+	}
+
+	created, groupsCreated, err := s.repo.ImportCategories(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	for n, i := range rowLines {
+		if created[n] {
+			result.Results[i].Status = CategoryImportCreated
+			result.Created++
+		} else {
+			result.Results[i].Status = CategoryImportUpdated
+			result.Updated++
+		}
+	}
+	result.GroupsCreated = groupsCreated
+	result.Committed = true
+
+	s.log.InfoContext(ctx, "Imported categories", "created", result.Created, "updated", result.Updated, "groups_created", groupsCreated)
+	return result, nil
+}
+
+// parseCategoryImportRecord converts one CSV record into an import row.
+// position is the record's 1-based position, used when display_order is empty.
+func parseCategoryImportRecord(record []string, position int) (repository.CategoryImportRow, error) {
+	field := func(i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := repository.CategoryImportRow{
+		Name:         field(0),
+		DisplayOrder: position,
+		GroupName:    field(2),
+	}
+	if len(record) > 4 {
+		return row, errors.Validationf("expected at most 4 columns, got %d", len(record))
+	}
+	if row.Name == "" {
+		return row, errors.Validation("name is required")
+	}
+	if order := field(1); order != "" {
+		n, err := strconv.Atoi(order)
+		if err != nil {
+			return row, errors.Validationf("invalid display_order %q", order)
+		}
+		row.DisplayOrder = n
+	}
+	for _, rank := range strings.Split(field(3), "|") {
+		if rank = strings.TrimSpace(rank); rank != "" {
+			row.AllowedRanks = append(row.AllowedRanks, rank)
+		}
+	}
+	return row, nil
+}
+
+// MockCategory is a demo category created by SeedMockCategories
+type MockCategory struct {
+	Name         string `json:"name"`
+	DisplayOrder int    `json:"display_order"`
+}
+
+// MockCategories returns the demo categories SeedMockCategories creates
+func MockCategories() []MockCategory {
+	return []MockCategory{
 		{"Most Creative", 1},
 		{"Best Paint Job", 2},
 		{"Best Design", 3},
@@ -129,10 +657,13 @@ func (s *CategoryService) SeedMockCategories(ctx context.Context) (int, error) {
 		{"Most Unique", 5},
 		{"Best Theme", 6},
 	}
+}
 
+// SeedMockCategories seeds mock category data
+func (s *CategoryService) SeedMockCategories(ctx context.Context) (int, error) {
 	var addedCount int
 	var firstError error
-	for _, cat := range mockCategories {
+	for _, cat := range MockCategories() {
 		exists, err := s.repo.CategoryExists(ctx, cat.Name)
 		if err != nil {
 			if firstError == nil {
@@ -141,7 +672,7 @@ func (s *CategoryService) SeedMockCategories(ctx context.Context) (int, error) {
 			continue
 		}
 		if !exists {
-			_, err := s.repo.CreateCategory(ctx, cat.Name, cat.DisplayOrder, nil, nil, nil)
+			_, err := s.repo.CreateCategory(ctx, cat.Name, cat.DisplayOrder, nil, nil, nil, nil)
 			if err != nil {
 				if firstError == nil {
 					firstError = fmt.Errorf("failed to create category %q: %w", cat.Name, err)
@@ -160,7 +691,7 @@ func (s *CategoryService) SeedMockCategories(ctx context.Context) (int, error) {
 // - Push: Local categories without derbynet_award_id are created as awards in DerbyNet
 func (s *CategoryService) SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (*CategorySyncResult, error) {
 	// Set the URL on the client
-	s.client.SetBaseURL(derbyNetURL)
+	useDerbyNetURL(ctx, s.client, s.repo, derbyNetURL)
 
 	// Save DerbyNet URL to settings
 	if err := s.repo.SetSetting(ctx, "derbynet_url", derbyNetURL); err != nil {
@@ -180,7 +711,7 @@ func (s *CategoryService) SyncFromDerbyNet(ctx context.Context, derbyNetURL stri
 		}, nil
 	}
 
-	s.log.Info("Fetched awards from DerbyNet", "count", len(awards))
+	s.log.InfoContext(ctx, "Fetched awards from DerbyNet", "count", len(awards))
 	result.TotalAwards = len(awards)
 
 	// Build a set of award names for checking existing awards
@@ -199,7 +730,7 @@ func (s *CategoryService) SyncFromDerbyNet(ctx context.Context, derbyNetURL stri
 		awardID := award.AwardID
 		created, err := s.repo.UpsertCategory(ctx, award.AwardName, displayOrder, &awardID)
 		if err != nil {
-			s.log.Error("Error syncing award", "award_id", award.AwardID, "name", award.AwardName, "error", err)
+			s.log.ErrorContext(ctx, "Error syncing award", "award_id", award.AwardID, "name", award.AwardName, "error", err)
 			if firstError == nil {
 				firstError = fmt.Errorf("failed to sync award %q: %w", award.AwardName, err)
 			}
@@ -218,14 +749,14 @@ func (s *CategoryService) SyncFromDerbyNet(ctx context.Context, derbyNetURL stri
 	derbyNetRole, _ := s.repo.GetSetting(ctx, "derbynet_role")
 	derbyNetPassword, _ := s.repo.GetSetting(ctx, "derbynet_password")
 	if derbyNetRole != "" && derbyNetPassword != "" {
-		s.log.Debug("Configuring DerbyNet credentials", "role", derbyNetRole)
+		s.log.DebugContext(ctx, "Configuring DerbyNet credentials", "role", derbyNetRole)
 		s.client.SetCredentials(derbyNetRole, derbyNetPassword)
 	}
 
 	// Get all local categories
 	categories, err := s.repo.ListCategories(ctx)
 	if err != nil {
-		s.log.Error("Failed to list categories for push sync", "error", err)
+		s.log.ErrorContext(ctx, "Failed to list categories for push sync", "error", err)
 		if firstError == nil {
 			firstError = fmt.Errorf("failed to list categories: %w", err)
 		}
@@ -233,7 +764,7 @@ func (s *CategoryService) SyncFromDerbyNet(ctx context.Context, derbyNetURL stri
 		// Get award types from DerbyNet (need to know available types)
 		awardTypes, err := s.client.FetchAwardTypes(ctx)
 		if err != nil {
-			s.log.Warn("Failed to fetch award types, using default", "error", err)
+			s.log.WarnContext(ctx, "Failed to fetch award types, using default", "error", err)
 			// Use default award type ID 1 (typically "Design")
 			awardTypes = []derbynet.AwardType{{AwardTypeID: 1, AwardType: "Design"}}
 		}
@@ -253,10 +784,10 @@ func (s *CategoryService) SyncFromDerbyNet(ctx context.Context, derbyNetURL stri
 			// Check if an award with this name already exists in DerbyNet
 			if existingAwardID, exists := awardNameSet[cat.Name]; exists {
 				// Link to existing award
-				s.log.Info("Linking existing category to DerbyNet award", "category", cat.Name, "award_id", existingAwardID)
+				s.log.InfoContext(ctx, "Linking existing category to DerbyNet award", "category", cat.Name, "award_id", existingAwardID)
 				_, err := s.repo.UpsertCategory(ctx, cat.Name, cat.DisplayOrder, &existingAwardID)
 				if err != nil {
-					s.log.Error("Failed to link category to award", "category", cat.Name, "error", err)
+					s.log.ErrorContext(ctx, "Failed to link category to award", "category", cat.Name, "error", err)
 					if firstError == nil {
 						firstError = fmt.Errorf("failed to link category %q to award: %w", cat.Name, err)
 					}
@@ -267,10 +798,10 @@ func (s *CategoryService) SyncFromDerbyNet(ctx context.Context, derbyNetURL stri
 			}
 
 			// Create new award in DerbyNet
-			s.log.Info("Creating award in DerbyNet", "category", cat.Name)
+			s.log.InfoContext(ctx, "Creating award in DerbyNet", "category", cat.Name)
 			newAwardID, err := s.client.CreateAward(ctx, cat.Name, defaultAwardTypeID)
 			if err != nil {
-				s.log.Error("Failed to create award in DerbyNet", "category", cat.Name, "error", err)
+				s.log.ErrorContext(ctx, "Failed to create award in DerbyNet", "category", cat.Name, "error", err)
 				// Check if it's an authentication error
 				errMsg := err.Error()
 				isAuthError := strings.Contains(errMsg, "failed to authenticate") ||
@@ -294,7 +825,7 @@ func (s *CategoryService) SyncFromDerbyNet(ctx context.Context, derbyNetURL stri
 			// Update local category with new award ID
 			_, err = s.repo.UpsertCategory(ctx, cat.Name, cat.DisplayOrder, &newAwardID)
 			if err != nil {
-				s.log.Error("Failed to update category with award ID", "category", cat.Name, "award_id", newAwardID, "error", err)
+				s.log.ErrorContext(ctx, "Failed to update category with award ID", "category", cat.Name, "award_id", newAwardID, "error", err)
 				if firstError == nil {
 					firstError = fmt.Errorf("failed to update category %q with award ID: %w", cat.Name, err)
 				}
@@ -302,13 +833,13 @@ func (s *CategoryService) SyncFromDerbyNet(ctx context.Context, derbyNetURL stri
 			}
 
 			result.AwardsCreated++
-			s.log.Info("Created award in DerbyNet", "category", cat.Name, "award_id", newAwardID)
+			s.log.InfoContext(ctx, "Created award in DerbyNet", "category", cat.Name, "award_id", newAwardID)
 		}
 	}
 
 	result.TotalCategories = result.CategoriesCreated + result.CategoriesUpdated
 
-	s.log.Info("Category sync complete",
+	s.log.InfoContext(ctx, "Category sync complete",
 		"categories_created", result.CategoriesCreated,
 		"categories_updated", result.CategoriesUpdated,
 		"awards_created", result.AwardsCreated)
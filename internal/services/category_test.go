@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	apperrors "github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
 	"github.com/abrezinsky/derbyvote/internal/repository"
 	"github.com/abrezinsky/derbyvote/internal/repository/mock"
@@ -90,6 +91,39 @@ func TestCategoryService_UpdateCategory(t *testing.T) {
 	}
 }
 
+func TestCategoryService_BannerURL(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewCategoryService(log, repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	id, err := svc.CreateCategory(ctx, services.Category{Name: "Best Design", BannerURL: "https://example.com/design.png"})
+	if err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	categories, _ := svc.ListCategories(ctx)
+	if categories[0].BannerURL != "https://example.com/design.png" {
+		t.Errorf("expected banner_url to be set on create, got %q", categories[0].BannerURL)
+	}
+
+	// Updates replace the banner, so leaving it out clears it
+	if err := svc.UpdateCategory(ctx, int(id), services.Category{Name: "Best Design", Active: true}); err != nil {
+		t.Fatalf("UpdateCategory failed: %v", err)
+	}
+	categories, _ = svc.ListCategories(ctx)
+	if categories[0].BannerURL != "" {
+		t.Errorf("expected banner_url cleared, got %q", categories[0].BannerURL)
+	}
+
+	for _, bad := range []string{"javascript:alert(1)", "banner.png"} {
+		_, err := svc.CreateCategory(ctx, services.Category{Name: "Bad Banner", BannerURL: bad})
+		var appErr *apperrors.Error
+		if !errors.As(err, &appErr) || appErr.Fields["banner_url"] == "" {
+			t.Errorf("expected banner_url field error for %q, got %v", bad, err)
+		}
+	}
+}
+
 func TestCategoryService_DeleteCategory(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -445,7 +479,7 @@ func TestCategoryService_SyncFromDerbyNet_UpdateExisting(t *testing.T) {
 	ctx := context.Background()
 
 	// Pre-create a category with the same name
-	_, _ = repo.CreateCategory(ctx, "Best Design", 10, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Best Design", 10, nil, nil, nil, nil)
 
 	mockAwards := []derbynet.Award{
 		{AwardID: 1, AwardName: "Best Design", Sort: 1},
@@ -558,8 +592,8 @@ func TestCategoryService_SyncFromDerbyNet_PushLocalCategories(t *testing.T) {
 	ctx := context.Background()
 
 	// Create local categories without derbynet_award_id
-	_, _ = repo.CreateCategory(ctx, "Local Award 1", 1, nil, nil, nil)
-	_, _ = repo.CreateCategory(ctx, "Local Award 2", 2, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Award 1", 1, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Award 2", 2, nil, nil, nil, nil)
 
 	// DerbyNet has no awards
 	mockClient := derbynet.NewMockClient(derbynet.WithAwards([]derbynet.Award{}))
@@ -599,7 +633,7 @@ func TestCategoryService_SyncFromDerbyNet_LinkByName(t *testing.T) {
 	ctx := context.Background()
 
 	// Create local category without derbynet_award_id
-	_, _ = repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	// DerbyNet has an award with the same name
 	mockAwards := []derbynet.Award{
@@ -635,7 +669,7 @@ func TestCategoryService_SyncFromDerbyNet_CreateAwardError(t *testing.T) {
 	ctx := context.Background()
 
 	// Create local category without derbynet_award_id
-	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil, nil)
 
 	// Mock client returns error on CreateAward
 	mockClient := derbynet.NewMockClient(
@@ -675,7 +709,7 @@ func TestCategoryService_SyncFromDerbyNet_FetchAwardTypesError(t *testing.T) {
 	ctx := context.Background()
 
 	// Create local category without derbynet_award_id
-	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil, nil)
 
 	// Mock client returns error on FetchAwardTypes but CreateAward works
 	mockClient := derbynet.NewMockClient(
@@ -706,7 +740,7 @@ func TestCategoryService_SyncFromDerbyNet_MixedPullPush(t *testing.T) {
 	ctx := context.Background()
 
 	// Create local category without derbynet_award_id
-	_, _ = repo.CreateCategory(ctx, "Local Only Award", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Only Award", 1, nil, nil, nil, nil)
 
 	// DerbyNet has different awards
 	mockAwards := []derbynet.Award{
@@ -756,7 +790,7 @@ func TestCategoryService_SyncFromDerbyNet_AuthError(t *testing.T) {
 	_ = repo.SetSetting(ctx, "derbynet_password", "secret123")
 
 	// Create a local category that would need to be pushed
-	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil, nil)
 
 	// Mock client returns login error
 	mockClient := derbynet.NewMockClient(
@@ -793,7 +827,7 @@ func TestCategoryService_SyncFromDerbyNet_NoCredentials_NoAuthError(t *testing.T
 	// No DerbyNet credentials configured
 
 	// Create a local category
-	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil, nil)
 
 	// Mock client that would fail login if called
 	mockClient := derbynet.NewMockClient(
@@ -824,7 +858,7 @@ func TestCategoryService_SyncFromDerbyNet_AuthSuccess_NoAuthError(t *testing.T)
 	_ = repo.SetSetting(ctx, "derbynet_password", "correctpassword")
 
 	// Create a local category
-	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil, nil)
 
 	// Mock client with successful login (no error)
 	mockClient := derbynet.NewMockClient(
@@ -940,7 +974,7 @@ func TestCategoryService_SyncFromDerbyNet_EmptyAwardTypes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create local category without DerbyNet link
-	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil, nil)
 
 	// Mock client that returns empty award types
 	mockClient := derbynet.NewMockClient(
@@ -971,7 +1005,7 @@ func TestCategoryService_SyncFromDerbyNet_WithDebugLogging(t *testing.T) {
 	_ = repo.SetSetting(ctx, "derbynet_password", "test123")
 
 	// Create local category
-	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil, nil)
 
 	mockClient := derbynet.NewMockClient(
 		derbynet.WithAwards([]derbynet.Award{}),
@@ -1099,7 +1133,7 @@ func TestCategoryService_SyncFromDerbyNet_LinkExistingByName(t *testing.T) {
 	ctx := context.Background()
 
 	// Create local category without derbynet_award_id
-	_, _ = repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	// DerbyNet has an award with the same name
 	mockAwards := []derbynet.Award{
@@ -1137,7 +1171,7 @@ func TestCategoryService_SyncFromDerbyNet_UpsertCategoryErrorAfterCreateAward(t
 	ctx := context.Background()
 
 	// Create local category without derbynet_award_id
-	_, _ = realRepo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil)
+	_, _ = realRepo.CreateCategory(ctx, "Local Award", 1, nil, nil, nil, nil)
 
 	// DerbyNet has no awards (so CreateAward will be called)
 	mockClient := derbynet.NewMockClient(derbynet.WithAwards([]derbynet.Award{}))
@@ -1219,7 +1253,7 @@ func TestCategoryService_SyncFromDerbyNet_LinkExistingAwardError(t *testing.T) {
 	ctx := context.Background()
 
 	// Create local category without derbynet_award_id
-	_, _ = realRepo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	_, _ = realRepo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	// DerbyNet has an award with the same name
 	mockAwards := []derbynet.Award{
@@ -1284,7 +1318,7 @@ func TestCategoryService_SyncFromDerbyNet_LinkExistingAwardFailureFirstError(t *
 	ctx := context.Background()
 
 	// Create local category without derbynet_award_id
-	_, _ = realRepo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	_, _ = realRepo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	// DerbyNet has awards
 	mockAwards := []derbynet.Award{
@@ -1337,7 +1371,7 @@ func TestCategoryService_SyncFromDerbyNet_LinkExistingAwardSuccess(t *testing.T)
 	ctx := context.Background()
 
 	// Create local category without derbynet_award_id
-	_, _ = realRepo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	_, _ = realRepo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	// DerbyNet has awards, including one with the same name as local category
 	// But we'll use a mock repo to prevent PULL from setting the derbynet_award_id
@@ -1397,7 +1431,7 @@ func TestCategoryService_CountVotesForCategory(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category
-	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("failed to create category: %v", err)
 	}
@@ -1457,3 +1491,453 @@ func TestCategoryService_CountVotesForCategory(t *testing.T) {
 		t.Errorf("expected 2 votes, got %d", count)
 	}
 }
+
+func TestCategoryService_ListDerbyNetAwards(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCategoryService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	// Without a configured URL there is nothing to ask
+	if _, err := svc.ListDerbyNetAwards(ctx); err == nil {
+		t.Fatal("expected error when DerbyNet URL is not configured")
+	}
+
+	repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+	awards, err := svc.ListDerbyNetAwards(ctx)
+	if err != nil {
+		t.Fatalf("ListDerbyNetAwards failed: %v", err)
+	}
+	if len(awards) != len(derbynet.DefaultMockAwards()) {
+		t.Errorf("expected %d awards, got %d", len(derbynet.DefaultMockAwards()), len(awards))
+	}
+}
+
+func TestCategoryService_ListDerbyNetAwards_FetchError(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	client := derbynet.NewMockClient(derbynet.WithAwardsError(errors.New("connection refused")))
+	svc := services.NewCategoryService(logger.New(), repo, client)
+	ctx := context.Background()
+	repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+
+	_, err := svc.ListDerbyNetAwards(ctx)
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected fetch error, got %v", err)
+	}
+}
+
+func TestCategoryService_DerbyNetCategoryDiff(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	client := derbynet.NewMockClient(derbynet.WithAwards([]derbynet.Award{
+		{AwardID: 1, AwardName: "Most Creative"},
+		{AwardID: 2, AwardName: "Best Paint Job"},
+		{AwardID: 3, AwardName: "Fastest Looking"},
+	}))
+	svc := services.NewCategoryService(logger.New(), repo, client)
+	ctx := context.Background()
+
+	if _, err := svc.DerbyNetCategoryDiff(ctx); err == nil {
+		t.Fatal("expected error when DerbyNet URL is not configured")
+	}
+	repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+
+	// Linked by award ID even though the local name differs
+	linkedID, _ := repo.CreateCategory(ctx, "Most Creative Car", 1, nil, nil, nil, nil)
+	awardID := 1
+	repo.SetCategoryDerbyNetAwardID(ctx, int(linkedID), &awardID)
+	// Same name as an award but not linked yet
+	repo.CreateCategory(ctx, "Best Paint Job", 2, nil, nil, nil, nil)
+	// Only exists locally
+	repo.CreateCategory(ctx, "Scout Spirit", 3, nil, nil, nil, nil)
+
+	diff, err := svc.DerbyNetCategoryDiff(ctx)
+	if err != nil {
+		t.Fatalf("DerbyNetCategoryDiff failed: %v", err)
+	}
+
+	if len(diff.AwardsToImport) != 1 || diff.AwardsToImport[0].AwardName != "Fastest Looking" {
+		t.Errorf("expected Fastest Looking to import, got %+v", diff.AwardsToImport)
+	}
+	if len(diff.LocalOnly) != 1 || diff.LocalOnly[0].Name != "Scout Spirit" {
+		t.Errorf("expected Scout Spirit to be local only, got %+v", diff.LocalOnly)
+	}
+	if len(diff.Matched) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", diff.Matched)
+	}
+	for _, m := range diff.Matched {
+		switch m.AwardID {
+		case 1:
+			if !m.Linked || m.CategoryName != "Most Creative Car" {
+				t.Errorf("expected award 1 linked to Most Creative Car, got %+v", m)
+			}
+		case 2:
+			if m.Linked || m.CategoryName != "Best Paint Job" {
+				t.Errorf("expected award 2 matched by name, got %+v", m)
+			}
+		default:
+			t.Errorf("unexpected match %+v", m)
+		}
+	}
+
+	// The preview changes nothing
+	categories, _ := repo.ListCategories(ctx)
+	if len(categories) != 3 {
+		t.Errorf("expected 3 categories after preview, got %d", len(categories))
+	}
+	for _, cat := range categories {
+		if cat.Name == "Best Paint Job" && cat.DerbyNetAwardID != nil {
+			t.Error("expected preview not to link Best Paint Job")
+		}
+	}
+}
+
+func TestCategoryService_SetDerbyNetAward(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCategoryService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+	repo.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+
+	id, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	awardID := derbynet.DefaultMockAwards()[1].AwardID
+
+	if err := svc.SetDerbyNetAward(ctx, int(id), &awardID); err != nil {
+		t.Fatalf("SetDerbyNetAward failed: %v", err)
+	}
+	categories, _ := repo.ListCategories(ctx)
+	if categories[0].DerbyNetAwardID == nil || *categories[0].DerbyNetAwardID != awardID {
+		t.Fatalf("expected award %d to be linked, got %v", awardID, categories[0].DerbyNetAwardID)
+	}
+
+	// Awards that DerbyNet does not know about are rejected
+	missing := 9999
+	if err := svc.SetDerbyNetAward(ctx, int(id), &missing); err == nil {
+		t.Error("expected error for unknown award")
+	}
+
+	// nil clears the mapping without contacting DerbyNet
+	repo.SetSetting(ctx, "derbynet_url", "")
+	if err := svc.SetDerbyNetAward(ctx, int(id), nil); err != nil {
+		t.Fatalf("clearing award failed: %v", err)
+	}
+	categories, _ = repo.ListCategories(ctx)
+	if categories[0].DerbyNetAwardID != nil {
+		t.Errorf("expected award to be cleared, got %d", *categories[0].DerbyNetAwardID)
+	}
+}
+
+func TestCategoryService_SetDerbyNetAward_CategoryNotFound(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCategoryService(logger.New(), repo, derbynet.NewMockClient())
+
+	err := svc.SetDerbyNetAward(context.Background(), 42, nil)
+	if err == nil || !strings.Contains(err.Error(), "category not found") {
+		t.Errorf("expected category not found, got %v", err)
+	}
+}
+
+func TestCategoryService_ImportCategoriesCSV(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCategoryService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	_, _ = repo.CreateCategory(ctx, "Best Paint", 5, nil, nil, nil, nil)
+
+	csv := "name,display_order,group_name,allowed_ranks\n" +
+		"Best Paint,1,Design,\n" +
+		"Most Creative,,Design,Tiger | Wolf\n" +
+		"\"Fastest, Looking\",3,,\n"
+	result, err := svc.ImportCategoriesCSV(ctx, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ImportCategoriesCSV failed: %v", err)
+	}
+	if !result.Committed || result.Created != 2 || result.Updated != 1 || result.GroupsCreated != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	want := []services.CategoryImportLine{
+		{Line: 2, Name: "Best Paint", Status: services.CategoryImportUpdated},
+		{Line: 3, Name: "Most Creative", Status: services.CategoryImportCreated},
+		{Line: 4, Name: "Fastest, Looking", Status: services.CategoryImportCreated},
+	}
+	if fmt.Sprint(result.Results) != fmt.Sprint(want) {
+		t.Errorf("expected results %v, got %v", want, result.Results)
+	}
+
+	categories, _ := svc.ListCategories(ctx)
+	if len(categories) != 3 {
+		t.Fatalf("expected 3 categories, got %d", len(categories))
+	}
+	// An empty display_order falls back to the line's position
+	if categories[1].Name != "Most Creative" || categories[1].DisplayOrder != 2 || len(categories[1].AllowedRanks) != 2 {
+		t.Errorf("unexpected imported category: %+v", categories[1])
+	}
+}
+
+func TestCategoryService_ImportCategoriesCSV_RejectsWholeFile(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCategoryService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	csv := "Best Paint,1\n" +
+		",2\n" +
+		"Most Creative,first\n" +
+		"Best Paint,4\n"
+	result, err := svc.ImportCategoriesCSV(ctx, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ImportCategoriesCSV failed: %v", err)
+	}
+	if result.Committed {
+		t.Error("expected nothing to be committed")
+	}
+	statuses := make([]string, len(result.Results))
+	for i, line := range result.Results {
+		statuses[i] = line.Status
+	}
+	want := []string{services.CategoryImportRolledBack, services.CategoryImportRejected, services.CategoryImportRejected, services.CategoryImportRejected}
+	if fmt.Sprint(statuses) != fmt.Sprint(want) {
+		t.Errorf("expected statuses %v, got %v", want, statuses)
+	}
+	if !strings.Contains(result.Results[3].Error, "line 1") {
+		t.Errorf("expected duplicate error to name line 1, got %q", result.Results[3].Error)
+	}
+
+	categories, _ := svc.ListCategories(ctx)
+	if len(categories) != 0 {
+		t.Errorf("expected no categories, got %d", len(categories))
+	}
+}
+
+func TestCategoryService_ImportCategoriesCSV_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		csv     string
+		repoErr error
+	}{
+		{name: "empty", csv: ""},
+		{name: "header only", csv: "name,display_order,group_name,allowed_ranks\n"},
+		{name: "malformed", csv: "\"Best Paint,1\n"},
+		{name: "repository error", csv: "Best Paint,1\n", repoErr: errors.New("database error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock.NewRepository(testutil.NewTestRepository(t))
+			repo.ImportCategoriesError = tt.repoErr
+			svc := services.NewCategoryService(logger.New(), repo, derbynet.NewMockClient())
+
+			if _, err := svc.ImportCategoriesCSV(ctx, strings.NewReader(tt.csv)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestCategoryService_Tags(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewCategoryService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	paintID, err := svc.CreateCategory(ctx, services.Category{
+		Name:         "Best Paint",
+		DisplayOrder: 1,
+		Tags:         []string{" Design ", "Den", "design", ""},
+	})
+	if err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	if _, err := svc.CreateCategory(ctx, services.Category{Name: "Fastest Looking", DisplayOrder: 2, Tags: []string{"Den"}}); err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	if _, err := svc.CreateCategory(ctx, services.Category{Name: "Funniest", DisplayOrder: 3}); err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+
+	categories, _ := svc.ListCategories(ctx)
+	if got := strings.Join(categories[0].Tags, ","); got != "Design,Den" {
+		t.Errorf("expected normalized tags Design,Den, got %q", got)
+	}
+
+	design, err := svc.ListAllCategoriesByTag(ctx, "DESIGN")
+	if err != nil {
+		t.Fatalf("ListAllCategoriesByTag failed: %v", err)
+	}
+	if len(design) != 1 || design[0]["name"] != "Best Paint" {
+		t.Errorf("expected only Best Paint tagged Design, got %v", design)
+	}
+	den, _ := svc.ListAllCategoriesByTag(ctx, "den")
+	if len(den) != 2 {
+		t.Errorf("expected 2 categories tagged Den, got %d", len(den))
+	}
+	none, _ := svc.ListAllCategoriesByTag(ctx, "Speed")
+	if none == nil || len(none) != 0 {
+		t.Errorf("expected empty list for unknown tag, got %v", none)
+	}
+
+	// Updating without tags clears them
+	if err := svc.UpdateCategory(ctx, int(paintID), services.Category{Name: "Best Paint", DisplayOrder: 1, Active: true}); err != nil {
+		t.Fatalf("UpdateCategory failed: %v", err)
+	}
+	design, _ = svc.ListAllCategoriesByTag(ctx, "Design")
+	if len(design) != 0 {
+		t.Errorf("expected tags cleared by update, got %v", design)
+	}
+}
+
+func TestCategoryService_Tags_SavedWithCategory(t *testing.T) {
+	repo := mock.NewRepository(testutil.NewTestRepository(t))
+	svc := services.NewCategoryService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	// A category that fails to save leaves neither the category nor its tags behind
+	repo.CreateCategoryError = errors.New("database error")
+	if _, err := svc.CreateCategory(ctx, services.Category{Name: "Best Design", DisplayOrder: 1, Tags: []string{"Design"}}); err == nil {
+		t.Fatal("expected error when the category cannot be saved")
+	}
+	repo.CreateCategoryError = nil
+	if tagged, _ := svc.ListAllCategoriesByTag(ctx, "Design"); len(tagged) != 0 {
+		t.Errorf("expected no tagged categories after a failed create, got %v", tagged)
+	}
+
+	id, err := svc.CreateCategory(ctx, services.Category{Name: "Best Design", DisplayOrder: 1, Tags: []string{"Design"}})
+	if err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	if tagged, _ := svc.ListAllCategoriesByTag(ctx, "Design"); len(tagged) != 1 {
+		t.Errorf("expected the category tagged on create, got %v", tagged)
+	}
+	if err := svc.UpdateCategory(ctx, int(id), services.Category{Name: "Best Design", DisplayOrder: 1, Active: true, Tags: []string{"Den"}}); err != nil {
+		t.Fatalf("UpdateCategory failed: %v", err)
+	}
+	if tagged, _ := svc.ListAllCategoriesByTag(ctx, "Den"); len(tagged) != 1 {
+		t.Errorf("expected the category retagged on update, got %v", tagged)
+	}
+}
+
+func TestCategoryService_ShowLiveCounts(t *testing.T) {
+	repo := mock.NewRepository(testutil.NewTestRepository(t))
+	svc := services.NewCategoryService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	id, err := svc.CreateCategory(ctx, services.Category{Name: "Crowd Favorite", DisplayOrder: 1, ShowLiveCounts: true})
+	if err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	categories, _ := svc.ListCategories(ctx)
+	if !categories[0].ShowLiveCounts {
+		t.Error("expected live counts shown after create")
+	}
+
+	if err := svc.UpdateCategory(ctx, int(id), services.Category{Name: "Crowd Favorite", DisplayOrder: 1, Active: true}); err != nil {
+		t.Fatalf("UpdateCategory failed: %v", err)
+	}
+	categories, _ = svc.ListCategories(ctx)
+	if categories[0].ShowLiveCounts {
+		t.Error("expected live counts hidden by update")
+	}
+
+	repo.SetLiveCountsError = errors.New("database error")
+	if _, err := svc.CreateCategory(ctx, services.Category{Name: "Best Design", DisplayOrder: 2, ShowLiveCounts: true}); err == nil {
+		t.Error("expected error when live counts flag cannot be saved on create")
+	}
+	if err := svc.UpdateCategory(ctx, int(id), services.Category{Name: "Crowd Favorite", DisplayOrder: 1, Active: true}); err == nil {
+		t.Error("expected error when live counts flag cannot be saved on update")
+	}
+}
+
+func TestCategoryService_AllowWriteIn(t *testing.T) {
+	repo := mock.NewRepository(testutil.NewTestRepository(t))
+	svc := services.NewCategoryService(logger.New(), repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	id, err := svc.CreateCategory(ctx, services.Category{Name: "Crowd Favorite", DisplayOrder: 1, AllowWriteIn: true})
+	if err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	categories, _ := svc.ListCategories(ctx)
+	if !categories[0].AllowWriteIn {
+		t.Error("expected write-ins allowed after create")
+	}
+
+	if err := svc.UpdateCategory(ctx, int(id), services.Category{Name: "Crowd Favorite", DisplayOrder: 1, Active: true}); err != nil {
+		t.Fatalf("UpdateCategory failed: %v", err)
+	}
+	categories, _ = svc.ListCategories(ctx)
+	if categories[0].AllowWriteIn {
+		t.Error("expected write-ins turned off by update")
+	}
+
+	repo.SetCategoryWriteInError = errors.New("database error")
+	if _, err := svc.CreateCategory(ctx, services.Category{Name: "Best Design", DisplayOrder: 2, AllowWriteIn: true}); err == nil {
+		t.Error("expected error when write-in flag cannot be saved on create")
+	}
+	if err := svc.UpdateCategory(ctx, int(id), services.Category{Name: "Crowd Favorite", DisplayOrder: 1, Active: true}); err == nil {
+		t.Error("expected error when write-in flag cannot be saved on update")
+	}
+}
+
+func TestCategoryService_ExclusivityPools(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewCategoryService(log, repo, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	if _, err := svc.CreatePool(ctx, "   "); err == nil {
+		t.Error("expected error for blank pool name")
+	}
+	if _, err := svc.CreatePool(ctx, strings.Repeat("x", 101)); err == nil {
+		t.Error("expected error for overlong pool name")
+	}
+
+	poolID, err := svc.CreatePool(ctx, "  Design Awards ")
+	if err != nil {
+		t.Fatalf("CreatePool failed: %v", err)
+	}
+	if _, err := svc.CreatePool(ctx, "design awards"); err == nil {
+		t.Error("expected error for duplicate pool name")
+	}
+
+	// Groups can join the pool by name
+	groupID, err := svc.CreateGroup(ctx, services.CategoryGroup{Name: "Looks", ExclusivityPoolName: "DESIGN AWARDS"})
+	if err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	group, err := svc.GetGroup(ctx, fmt.Sprintf("%d", groupID))
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	if group.ExclusivityPoolID == nil || int64(*group.ExclusivityPoolID) != poolID {
+		t.Errorf("expected group in pool %d, got %v", poolID, group.ExclusivityPoolID)
+	}
+	if group.ExclusivityPoolName != "Design Awards" {
+		t.Errorf("expected pool name 'Design Awards', got %q", group.ExclusivityPoolName)
+	}
+
+	if _, err := svc.CreateGroup(ctx, services.CategoryGroup{Name: "Speed", ExclusivityPoolName: "Nope"}); err == nil {
+		t.Error("expected error for unknown pool name")
+	}
+	other := int(poolID) + 1
+	if _, err := svc.CreateGroup(ctx, services.CategoryGroup{Name: "Speed", ExclusivityPoolID: &other, ExclusivityPoolName: "Design Awards"}); err == nil {
+		t.Error("expected error when pool name and ID disagree")
+	}
+
+	if err := svc.UpdatePool(ctx, int(poolID), "Looks Awards"); err != nil {
+		t.Fatalf("UpdatePool failed: %v", err)
+	}
+	pools, err := svc.ListPools(ctx)
+	if err != nil {
+		t.Fatalf("ListPools failed: %v", err)
+	}
+	if len(pools) != 1 || pools[0].Name != "Looks Awards" || pools[0].GroupCount != 1 {
+		t.Errorf("unexpected pools: %+v", pools)
+	}
+
+	if err := svc.DeletePool(ctx, int(poolID)); err != nil {
+		t.Fatalf("DeletePool failed: %v", err)
+	}
+	group, err = svc.GetGroup(ctx, fmt.Sprintf("%d", groupID))
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	if group.ExclusivityPoolID != nil {
+		t.Errorf("expected group to leave the deleted pool, got %v", *group.ExclusivityPoolID)
+	}
+}
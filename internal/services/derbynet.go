@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"github.com/abrezinsky/derbyvote/pkg/derbynet"
+)
+
+// settingGetter reads a single setting; repositories and SettingsService both provide it
+type settingGetter interface {
+	GetSetting(ctx context.Context, key string) (string, error)
+}
+
+// useDerbyNetURL points client at derbyNetURL, with the derbynet_url_fallback
+// setting as the URL it tries when derbyNetURL cannot be reached. A client that
+// has failed over to the fallback stays on it while the settings are unchanged.
+func useDerbyNetURL(ctx context.Context, client derbynet.Client, settings settingGetter, derbyNetURL string) {
+	fallback, _ := settings.GetSetting(ctx, "derbynet_url_fallback")
+	client.UseURLs(derbyNetURL, fallback)
+}
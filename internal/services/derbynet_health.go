@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/pkg/derbynet"
+)
+
+// Default DerbyNet health polling intervals
+const (
+	DefaultDerbyNetHealthInterval   = 30 * time.Second
+	DefaultDerbyNetHealthMaxBackoff = 5 * time.Minute
+	derbyNetHealthCheckTimeout      = 10 * time.Second
+)
+
+// DerbyNetStatus is the cached outcome of the latest background DerbyNet check
+type DerbyNetStatus struct {
+	Enabled             bool       `json:"enabled"`
+	Configured          bool       `json:"configured"`
+	Connected           bool       `json:"connected"`
+	CheckedAt           *time.Time `json:"checked_at,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LatencyMs           int64      `json:"latency_ms"`
+	Error               string     `json:"error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	NextCheckAt         *time.Time `json:"next_check_at,omitempty"`
+}
+
+// DerbyNetHealthService periodically checks DerbyNet connectivity in the
+// background and caches the result, so the admin UI can show a current
+// connection badge without running a manual test
+type DerbyNetHealthService struct {
+	log        logger.Logger
+	settings   SettingsServicer
+	client     derbynet.Client
+	interval   time.Duration
+	maxBackoff time.Duration
+
+	mu     sync.RWMutex
+	status DerbyNetStatus
+}
+
+// NewDerbyNetHealthService creates a new DerbyNetHealthService
+func NewDerbyNetHealthService(log logger.Logger, settings SettingsServicer, client derbynet.Client) *DerbyNetHealthService {
+	return &DerbyNetHealthService{
+		log:        log,
+		settings:   settings,
+		client:     client,
+		interval:   DefaultDerbyNetHealthInterval,
+		maxBackoff: DefaultDerbyNetHealthMaxBackoff,
+	}
+}
+
+// SetIntervals overrides how often DerbyNet is checked and the longest delay
+// between checks while it is unreachable
+func (s *DerbyNetHealthService) SetIntervals(interval, maxBackoff time.Duration) {
+	s.interval = interval
+	s.maxBackoff = maxBackoff
+}
+
+// Status returns the cached status of the latest check. Enabled and Configured
+// reflect the current settings rather than those at the time of the check.
+func (s *DerbyNetHealthService) Status(ctx context.Context) DerbyNetStatus {
+	s.mu.RLock()
+	status := s.status
+	s.mu.RUnlock()
+
+	status.Enabled, _ = s.settings.DerbyNetHealthPolling(ctx)
+	derbyNetURL, _ := s.settings.GetSetting(ctx, "derbynet_url")
+	status.Configured = derbyNetURL != ""
+	return status
+}
+
+// Run polls DerbyNet until ctx is cancelled. Polling only happens while the
+// derbynet_health_polling setting is on and a DerbyNet URL is configured;
+// after a failure the delay doubles up to the maximum backoff.
+func (s *DerbyNetHealthService) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("DerbyNet health polling stopped")
+			return
+		case <-timer.C:
+			timer.Reset(s.poll(ctx))
+		}
+	}
+}
+
+// poll runs one check if polling is enabled and returns the delay until the next one
+func (s *DerbyNetHealthService) poll(ctx context.Context) time.Duration {
+	enabled, _ := s.settings.DerbyNetHealthPolling(ctx)
+	derbyNetURL, _ := s.settings.GetSetting(ctx, "derbynet_url")
+	if !enabled || derbyNetURL == "" {
+		return s.interval
+	}
+	return s.check(ctx, derbyNetURL)
+}
+
+// Check runs a single DerbyNet connectivity check against the configured URL,
+// caches the outcome and returns the updated status
+func (s *DerbyNetHealthService) Check(ctx context.Context) DerbyNetStatus {
+	derbyNetURL, _ := s.settings.GetSetting(ctx, "derbynet_url")
+	if derbyNetURL != "" {
+		s.check(ctx, derbyNetURL)
+	}
+	return s.Status(ctx)
+}
+
+// check fetches the racer list from DerbyNet, records the outcome and returns
+// the delay until the next check
+func (s *DerbyNetHealthService) check(ctx context.Context, derbyNetURL string) time.Duration {
+	checkCtx, cancel := context.WithTimeout(ctx, derbyNetHealthCheckTimeout)
+	defer cancel()
+
+	useDerbyNetURL(ctx, s.client, s.settings, derbyNetURL)
+	start := time.Now()
+	_, err := s.client.FetchRacers(checkCtx)
+	checkedAt := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wasConnected := s.status.Connected
+	s.status.CheckedAt = &checkedAt
+	s.status.LatencyMs = checkedAt.Sub(start).Milliseconds()
+	if err != nil {
+		s.status.Connected = false
+		s.status.Error = err.Error()
+		s.status.ConsecutiveFailures++
+		if wasConnected || s.status.ConsecutiveFailures == 1 {
+			s.log.Warn("DerbyNet unreachable", "url", derbyNetURL, "error", err)
+		}
+	} else {
+		s.status.Connected = true
+		s.status.Error = ""
+		s.status.ConsecutiveFailures = 0
+		s.status.LastSuccessAt = &checkedAt
+		if !wasConnected {
+			s.log.Info("DerbyNet reachable", "url", derbyNetURL)
+		}
+	}
+
+	delay := s.backoff(s.status.ConsecutiveFailures)
+	next := checkedAt.Add(delay)
+	s.status.NextCheckAt = &next
+	return delay
+}
+
+// backoff returns the delay after the given number of consecutive failures
+func (s *DerbyNetHealthService) backoff(failures int) time.Duration {
+	delay := s.interval
+	for i := 0; i < failures && delay < s.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.maxBackoff {
+		delay = s.maxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/services"
+	"github.com/abrezinsky/derbyvote/internal/testutil"
+	"github.com/abrezinsky/derbyvote/pkg/derbynet"
+)
+
+func TestDerbyNetHealthService_Status_Default(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	settings := services.NewSettingsService(logger.New(), repo)
+	svc := services.NewDerbyNetHealthService(logger.New(), settings, derbynet.NewMockClient())
+
+	status := svc.Status(context.Background())
+	if status.Enabled || status.Configured || status.Connected || status.CheckedAt != nil {
+		t.Errorf("expected empty status, got %+v", status)
+	}
+}
+
+func TestDerbyNetHealthService_Check_Success(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	settings := services.NewSettingsService(logger.New(), repo)
+	client := derbynet.NewMockClient()
+	svc := services.NewDerbyNetHealthService(logger.New(), settings, client)
+	ctx := context.Background()
+
+	_ = settings.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+	status := svc.Check(ctx)
+
+	if !status.Configured || !status.Connected || status.Error != "" {
+		t.Errorf("expected connected status, got %+v", status)
+	}
+	if status.LastSuccessAt == nil || status.CheckedAt == nil || !status.LastSuccessAt.Equal(*status.CheckedAt) {
+		t.Errorf("expected last success at the check time, got %+v", status)
+	}
+	if status.NextCheckAt == nil || status.NextCheckAt.Sub(*status.CheckedAt) != services.DefaultDerbyNetHealthInterval {
+		t.Errorf("expected next check after the default interval, got %+v", status)
+	}
+	if client.BaseURL() != "http://derbynet.local" {
+		t.Errorf("expected client pointed at configured URL, got %q", client.BaseURL())
+	}
+}
+
+func TestDerbyNetHealthService_Check_BacksOff(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	settings := services.NewSettingsService(logger.New(), repo)
+	client := derbynet.NewMockClient(derbynet.WithFetchError(errors.New("connection refused")))
+	svc := services.NewDerbyNetHealthService(logger.New(), settings, client)
+	svc.SetIntervals(10*time.Second, 35*time.Second)
+	ctx := context.Background()
+
+	_ = settings.SetSetting(ctx, "derbynet_url", "http://derbynet.local")
+
+	for i, want := range []time.Duration{20 * time.Second, 35 * time.Second, 35 * time.Second} {
+		status := svc.Check(ctx)
+		if status.Connected || status.Error != "connection refused" || status.ConsecutiveFailures != i+1 {
+			t.Fatalf("check %d: expected failure, got %+v", i+1, status)
+		}
+		if got := status.NextCheckAt.Sub(*status.CheckedAt); got != want {
+			t.Errorf("check %d: expected delay %v, got %v", i+1, want, got)
+		}
+	}
+	if status := svc.Status(ctx); status.LastSuccessAt != nil {
+		t.Errorf("expected no successful check, got %v", status.LastSuccessAt)
+	}
+}
+
+func TestDerbyNetHealthService_Run(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	settings := services.NewSettingsService(logger.New(), repo)
+	client := derbynet.NewMockClient()
+	svc := services.NewDerbyNetHealthService(logger.New(), settings, client)
+	svc.SetIntervals(5*time.Millisecond, 20*time.Millisecond)
+
+	_ = settings.SetSetting(context.Background(), "derbynet_url", "http://derbynet.local")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Run(ctx)
+		close(done)
+	}()
+
+	// Polling is opt-in
+	time.Sleep(30 * time.Millisecond)
+	if status := svc.Status(ctx); status.CheckedAt != nil {
+		t.Fatalf("expected no checks while disabled, got %+v", status)
+	}
+
+	_ = settings.SetDerbyNetHealthPolling(ctx, true)
+	var first *time.Time
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		status := svc.Status(ctx)
+		if first == nil {
+			first = status.CheckedAt
+		} else if status.CheckedAt != nil && status.CheckedAt.After(*first) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to stop when its context is cancelled")
+	}
+	if calls := client.FetchRacersCalls(); calls < 2 {
+		t.Errorf("expected repeated checks once enabled, got %d", calls)
+	}
+}
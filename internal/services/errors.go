@@ -1,18 +1,22 @@
 package services
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/abrezinsky/derbyvote/internal/models"
+)
 
 // Service errors
 var (
-	ErrInvalidTimerMinutes = &ServiceError{Message: "minutes must be between 1 and 60"}
-	ErrNoTablesSpecified   = &ServiceError{Message: "no tables specified"}
-	ErrInvalidQRCount      = &ServiceError{Message: "count must be between 1 and 200"}
-	ErrInvalidSeedType     = &ServiceError{Message: "invalid seed type"}
-	ErrVotingClosed        = &ServiceError{Message: "voting is currently closed"}
-	ErrCarNotEligible      = &ServiceError{Message: "car is not eligible for voting"}
-	ErrCarNotFound         = &ServiceError{Message: "car not found"}
-	ErrUnregisteredQR      = &ServiceError{Message: "QR code is not registered"}
-	ErrOpenVotingDisabled  = &ServiceError{Message: "open voting is disabled - only pre-registered QR codes are allowed"}
+	ErrNoTablesSpecified  = &ServiceError{Message: "no tables specified"}
+	ErrInvalidQRCount     = &ServiceError{Message: "count must be between 1 and 200"}
+	ErrInvalidSeedType    = &ServiceError{Message: "invalid seed type"}
+	ErrVotingClosed       = &ServiceError{Message: "voting is currently closed"}
+	ErrCarNotEligible     = &ServiceError{Message: "car is not eligible for voting"}
+	ErrCarNotFound        = &ServiceError{Message: "car not found"}
+	ErrUnregisteredQR     = &ServiceError{Message: "QR code is not registered"}
+	ErrOpenVotingDisabled = &ServiceError{Message: "open voting is disabled - only pre-registered QR codes are allowed"}
+	ErrResultsEmbargoed   = &ServiceError{Message: "results are embargoed until revealed"}
 )
 
 // ServiceError represents a service-level error
@@ -24,6 +28,26 @@ func (e *ServiceError) Error() string {
 	return e.Message
 }
 
+// DuplicateCarNumberError reports a car number that, once normalized, matches another car
+type DuplicateCarNumberError struct {
+	CarNumber string
+	Car       models.Car // The existing car using the number
+}
+
+func (e *DuplicateCarNumberError) Error() string {
+	return fmt.Sprintf("car number %s is already used by car %d (%s)", e.CarNumber, e.Car.ID, e.Car.CarNumber)
+}
+
+// InvalidTimerMinutesError reports a voting timer length outside 1 to the
+// configured max_voting_minutes
+type InvalidTimerMinutesError struct {
+	Max int
+}
+
+func (e *InvalidTimerMinutesError) Error() string {
+	return fmt.Sprintf("minutes must be between 1 and %d", e.Max)
+}
+
 // InvalidTableError represents an invalid table name error
 type InvalidTableError struct {
 	Table string
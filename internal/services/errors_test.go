@@ -30,6 +30,13 @@ func TestInvalidTableError_Error(t *testing.T) {
 	}
 }
 
+func TestInvalidTimerMinutesError_Error(t *testing.T) {
+	err := &services.InvalidTimerMinutesError{Max: 90}
+	if got := err.Error(); got != "minutes must be between 1 and 90" {
+		t.Errorf("expected the max in the message, got %q", got)
+	}
+}
+
 func TestPredefinedErrors(t *testing.T) {
 	// Test that predefined errors return expected messages
 	tests := []struct {
@@ -37,7 +44,6 @@ func TestPredefinedErrors(t *testing.T) {
 		err      error
 		contains string
 	}{
-		{"ErrInvalidTimerMinutes", services.ErrInvalidTimerMinutes, "minutes"},
 		{"ErrNoTablesSpecified", services.ErrNoTablesSpecified, "tables"},
 		{"ErrInvalidQRCount", services.ErrInvalidQRCount, "count"},
 		{"ErrInvalidSeedType", services.ErrInvalidSeedType, "seed"},
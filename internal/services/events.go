@@ -0,0 +1,39 @@
+package services
+
+import "time"
+
+// Event types written to the event log
+const (
+	EventVoterRegistered  = "voter_registered"
+	EventVoteCast         = "vote_cast"
+	EventVoteCleared      = "vote_cleared"
+	EventVoteVoided       = "vote_voided"
+	EventVotingOpened     = "voting_opened"
+	EventVotingClosed     = "voting_closed"
+	EventWinnerOverridden = "winner_overridden"
+)
+
+// Event is one entry in the event log kept for post-event analysis. Voters
+// appear only under the hashed ID used by anonymized ballot exports.
+type Event struct {
+	Type       string    `json:"type"`
+	Time       time.Time `json:"time"`
+	VoterID    string    `json:"voter_id,omitempty"`
+	CategoryID int       `json:"category_id,omitempty"`
+	CarID      int       `json:"car_id,omitempty"`
+}
+
+// EventSink receives events as the services record them
+type EventSink interface {
+	Record(event Event)
+}
+
+// recordEvent stamps an event with the current time and passes it to sink.
+// Without a sink the event is dropped.
+func recordEvent(sink EventSink, event Event) {
+	if sink == nil {
+		return
+	}
+	event.Time = time.Now().UTC()
+	sink.Record(event)
+}
@@ -0,0 +1,156 @@
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/models"
+	"github.com/abrezinsky/derbyvote/internal/services"
+	"github.com/abrezinsky/derbyvote/internal/testutil"
+	"github.com/abrezinsky/derbyvote/pkg/derbynet"
+)
+
+// recordingSink keeps the events it is given for inspection
+type recordingSink struct {
+	mu     sync.Mutex
+	events []services.Event
+}
+
+func (s *recordingSink) Record(event services.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *recordingSink) types() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	types := make([]string, len(s.events))
+	for i, event := range s.events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+func assertEventTypes(t *testing.T, sink *recordingSink, want ...string) {
+	t.Helper()
+	got := sink.types()
+	if len(got) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected events %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSettingsService_RecordsVotingOpenedAndClosed(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	sink := &recordingSink{}
+	svc.SetEventSink(sink)
+	ctx := context.Background()
+
+	// Voting starts open
+	if err := svc.OpenVoting(ctx); err != nil {
+		t.Fatalf("OpenVoting failed: %v", err)
+	}
+	assertEventTypes(t, sink)
+
+	if err := svc.CloseVoting(ctx); err != nil {
+		t.Fatalf("CloseVoting failed: %v", err)
+	}
+	if err := svc.OpenVoting(ctx); err != nil {
+		t.Fatalf("OpenVoting failed: %v", err)
+	}
+	// Opening again is not a change and records nothing
+	if err := svc.OpenVoting(ctx); err != nil {
+		t.Fatalf("OpenVoting failed: %v", err)
+	}
+	if err := svc.CloseVoting(ctx); err != nil {
+		t.Fatalf("CloseVoting failed: %v", err)
+	}
+
+	assertEventTypes(t, sink, services.EventVotingClosed, services.EventVotingOpened, services.EventVotingClosed)
+	if sink.events[0].Time.IsZero() {
+		t.Error("expected event to be timestamped")
+	}
+}
+
+func TestVotingService_RecordsVoterAndVoteEvents(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	sink := &recordingSink{}
+	votingSvc.SetEventSink(sink)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+
+	poolID := 1
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Speed Awards", "", &poolID, nil, "", 1)
+	groupIDInt := int(groupID)
+	catID1, _ := repo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil, nil)
+	catID2, _ := repo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	cars, _ := repo.ListCars(ctx)
+	carID := cars[0].ID
+
+	qrCode := "EV-ENT"
+	if _, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: qrCode, CategoryID: int(catID1), CarID: carID}); err != nil {
+		t.Fatalf("SubmitVote failed: %v", err)
+	}
+	// Moving the car to the other category in the pool clears the first vote
+	if _, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: qrCode, CategoryID: int(catID2), CarID: carID, Replace: true}); err != nil {
+		t.Fatalf("SubmitVote failed: %v", err)
+	}
+
+	assertEventTypes(t, sink,
+		services.EventVoterRegistered,
+		services.EventVoteCast,
+		services.EventVoteCleared,
+		services.EventVoteCast,
+	)
+
+	voterID := sink.events[0].VoterID
+	if voterID == "" || voterID == qrCode {
+		t.Fatalf("expected an anonymized voter ID, got %q", voterID)
+	}
+	for _, event := range sink.events {
+		if event.VoterID != voterID {
+			t.Errorf("expected every event to carry voter ID %q, got %+v", voterID, event)
+		}
+	}
+	if cleared := sink.events[2]; cleared.CategoryID != int(catID1) || cleared.CarID != 0 {
+		t.Errorf("unexpected cleared event: %+v", cleared)
+	}
+	if cast := sink.events[3]; cast.CategoryID != int(catID2) || cast.CarID != carID {
+		t.Errorf("unexpected cast event: %+v", cast)
+	}
+}
+
+func TestResultsService_RecordsWinnerOverridden(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+	sink := &recordingSink{}
+	svc.SetEventSink(sink)
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	cars, _ := repo.ListCars(ctx)
+
+	// A failed override records nothing
+	if err := svc.SetManualWinner(ctx, int(catID), 9999, "Missing car"); err == nil {
+		t.Fatal("expected error for non-existent car")
+	}
+	if err := svc.SetManualWinner(ctx, int(catID), cars[0].ID, "Resolved tie"); err != nil {
+		t.Fatalf("SetManualWinner failed: %v", err)
+	}
+
+	assertEventTypes(t, sink, services.EventWinnerOverridden)
+	if event := sink.events[0]; event.CategoryID != int(catID) || event.CarID != cars[0].ID {
+		t.Errorf("unexpected override event: %+v", event)
+	}
+}
@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -233,7 +234,7 @@ func TestIntegration_SettingsCascade(t *testing.T) {
 	}
 
 	// Setup test data
-	catID, _ := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "100", "Test Racer", "Test Car", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -318,8 +319,9 @@ func TestIntegration_TimerFunctionality(t *testing.T) {
 	invalidMinutes := []int{0, -1, 61, 100}
 	for _, minutes := range invalidMinutes {
 		_, err := settingsSvc.StartVotingTimer(ctx, minutes)
-		if err != services.ErrInvalidTimerMinutes {
-			t.Errorf("StartVotingTimer(%d): expected ErrInvalidTimerMinutes, got %v", minutes, err)
+		var timerErr *services.InvalidTimerMinutesError
+		if !errors.As(err, &timerErr) {
+			t.Errorf("StartVotingTimer(%d): expected InvalidTimerMinutesError, got %v", minutes, err)
 		}
 	}
 
@@ -516,6 +518,7 @@ func TestIntegration_CategoryGroupExclusivity(t *testing.T) {
 		VoterQR:    voterQR,
 		CategoryID: int(cat2ID),
 		CarID:      carID,
+		Replace:    true,
 	}
 	result2, err := votingSvc.SubmitVote(ctx, vote2)
 	if err != nil {
@@ -533,6 +536,7 @@ func TestIntegration_CategoryGroupExclusivity(t *testing.T) {
 		VoterQR:    voterQR,
 		CategoryID: int(cat3ID),
 		CarID:      carID,
+		Replace:    true,
 	}
 	result3, err := votingSvc.SubmitVote(ctx, vote3)
 	if err != nil {
@@ -629,13 +633,13 @@ func TestIntegration_ExclusivityAcrossMultiplePools(t *testing.T) {
 	}
 
 	// Now vote in same pool - should trigger conflict
-	result, _ := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: voterQR, CategoryID: int(cat2ID), CarID: carID})
+	result, _ := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: voterQR, CategoryID: int(cat2ID), CarID: carID, Replace: true})
 	if !result.ConflictCleared {
 		t.Error("expected conflict within pool 1")
 	}
 
 	// Vote in other pool - should also trigger conflict
-	result2, _ := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: voterQR, CategoryID: int(cat4ID), CarID: carID})
+	result2, _ := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: voterQR, CategoryID: int(cat4ID), CarID: carID, Replace: true})
 	if !result2.ConflictCleared {
 		t.Error("expected conflict within pool 2")
 	}
@@ -667,7 +671,7 @@ func TestIntegration_ConcurrentVoting(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Setup test data
-	catID, err := repo.CreateCategory(ctx, "Concurrent Test", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Concurrent Test", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -759,7 +763,7 @@ func TestIntegration_ConcurrentVoteUpdates(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Setup
-	catID, _ := repo.CreateCategory(ctx, "Update Test", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Update Test", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "U1", "Racer 1", "Car 1", "")
 	_ = repo.CreateCar(ctx, "U2", "Racer 2", "Car 2", "")
 	cars, _ := repo.ListCars(ctx)
@@ -896,7 +900,7 @@ func TestIntegration_VoteDeselection(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Setup
-	catID, _ := repo.CreateCategory(ctx, "Deselect Test", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Deselect Test", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "D1", "Deselect Racer", "Deselect Car", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -963,7 +967,7 @@ func TestIntegration_MultipleVotersSameCar(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Setup
-	catID, _ := repo.CreateCategory(ctx, "Popular Vote", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Popular Vote", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "P1", "Popular Racer", "Popular Car", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1257,6 +1261,7 @@ func TestIntegration_CompleteEndToEndWorkflow(t *testing.T) {
 		VoterQR:    qrCodes[0],
 		CategoryID: int(cat2ID),
 		CarID:      cars[2].ID, // Same car as cat1, should trigger conflict
+		Replace:    true,
 	})
 	if err != nil {
 		t.Fatalf("Exclusivity vote failed: %v", err)
@@ -1346,11 +1351,11 @@ func TestIntegration_CompleteEndToEndWorkflow(t *testing.T) {
 	awards := mockClient.GetAwards()
 	if len(awards) > 0 {
 		// Link first category to first award
-		repo.UpdateCategory(ctx, int(cat1ID), "Fastest Looking", 1, &g1Int, nil, nil, true)
+		repo.UpdateCategory(ctx, int(cat1ID), "Fastest Looking", 1, &g1Int, nil, nil, true, nil)
 	}
 
 	// Push results
-	pushResult, err := resultsSvc.PushResultsToDerbyNet(ctx, "http://mock-derbynet.local")
+	pushResult, err := resultsSvc.PushResultsToDerbyNet(ctx, "http://mock-derbynet.local", false)
 	if err != nil {
 		t.Fatalf("Failed to push results to DerbyNet: %v", err)
 	}
@@ -1438,7 +1443,7 @@ func TestIntegration_PushResultsToDerbyNet(t *testing.T) {
 	}
 
 	// Push results to DerbyNet
-	pushResult, err := resultsSvc.PushResultsToDerbyNet(ctx, "http://mock-derbynet.local")
+	pushResult, err := resultsSvc.PushResultsToDerbyNet(ctx, "http://mock-derbynet.local", false)
 	if err != nil {
 		t.Fatalf("Failed to push results: %v", err)
 	}
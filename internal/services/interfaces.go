@@ -2,23 +2,38 @@ package services
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/abrezinsky/derbyvote/internal/models"
+	"github.com/abrezinsky/derbyvote/pkg/derbynet"
 )
 
 // CategoryServicer defines the interface for category operations
 type CategoryServicer interface {
 	ListCategories(ctx context.Context) ([]models.Category, error)
 	ListAllCategories(ctx context.Context) ([]map[string]interface{}, error)
+	ListAllCategoriesByTag(ctx context.Context, tag string) ([]map[string]interface{}, error)
 	CreateCategory(ctx context.Context, cat Category) (int64, error)
 	UpdateCategory(ctx context.Context, id int, cat Category) error
 	DeleteCategory(ctx context.Context, id int) error
+	ImportCategoriesCSV(ctx context.Context, r io.Reader) (*CategoryImportResult, error)
 	CountVotesForCategory(ctx context.Context, categoryID int) (int, error)
+	ListDerbyNetAwards(ctx context.Context) ([]derbynet.Award, error)
+	SetDerbyNetAward(ctx context.Context, categoryID int, awardID *int) error
+	SetCategoryCars(ctx context.Context, categoryID int, carIDs []int) error
+	SetCategoryGroup(ctx context.Context, categoryID int, groupID *int) (*CategoryGroupMove, error)
+	SetCategoryBannerURL(ctx context.Context, categoryID int, bannerURL string) error
+	DerbyNetCategoryDiff(ctx context.Context) (*CategoryDiff, error)
 	ListGroups(ctx context.Context) ([]models.CategoryGroup, error)
 	GetGroup(ctx context.Context, id string) (*models.CategoryGroup, error)
 	CreateGroup(ctx context.Context, group CategoryGroup) (int64, error)
 	UpdateGroup(ctx context.Context, id string, group CategoryGroup) error
 	DeleteGroup(ctx context.Context, id string) error
+	ListPools(ctx context.Context) ([]models.ExclusivityPool, error)
+	CreatePool(ctx context.Context, name string) (int64, error)
+	UpdatePool(ctx context.Context, id int, name string) error
+	DeletePool(ctx context.Context, id int) error
 	SeedMockCategories(ctx context.Context) (int, error)
 	SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (*CategorySyncResult, error)
 }
@@ -26,35 +41,67 @@ type CategoryServicer interface {
 // CarServicer defines the interface for car operations
 type CarServicer interface {
 	ListCars(ctx context.Context) ([]models.Car, error)
+	ListCarsPaged(ctx context.Context, filter CarFilter) ([]models.Car, int, error)
 	ListEligibleCars(ctx context.Context) ([]models.Car, error)
 	GetCar(ctx context.Context, id int) (*models.Car, error)
 	GetCarPhoto(ctx context.Context, id int) (*PhotoData, error)
 	CreateCar(ctx context.Context, carNumber, racerName, carName, photoURL string) error
 	UpdateCar(ctx context.Context, id int, carNumber, racerName, carName, photoURL, rank string) error
+	NormalizeCarNumber(ctx context.Context, carNumber string) (string, error)
 	SetCarEligibility(ctx context.Context, id int, eligible bool) error
+	MatchCarsForEligibility(ctx context.Context, filter CarEligibilityFilter) ([]models.Car, error)
+	BulkSetCarEligibility(ctx context.Context, ids []int, eligible bool) (int64, error)
+	SetDerbyNetRacer(ctx context.Context, carID int, racerID *int) error
+	ListDerbyNetRacers(ctx context.Context) ([]DerbyNetRacer, error)
 	DeleteCar(ctx context.Context, id int) error
 	CountVotesForCar(ctx context.Context, carID int) (int, error)
 	SyncFromDerbyNet(ctx context.Context, derbyNetURL string) (*SyncResult, error)
+	SyncVotersFromDerbyNet(ctx context.Context, derbyNetURL string) (*VoterSyncResult, error)
 	SeedMockCars(ctx context.Context) (int, error)
 }
 
 // VoterServicer defines the interface for voter operations
 type VoterServicer interface {
 	ListVoters(ctx context.Context) ([]map[string]interface{}, error)
+	ListVotersInGroup(ctx context.Context, group string) ([]map[string]interface{}, error)
+	VoterGroupTurnout(ctx context.Context) ([]models.VoterGroupTurnout, error)
 	CreateVoter(ctx context.Context, voter Voter) (int64, string, error)
 	UpdateVoter(ctx context.Context, voter Voter) error
 	DeleteVoter(ctx context.Context, id int) error
+	CountVotesForVoter(ctx context.Context, voterID int) (int, error)
+	BulkDeleteVoters(ctx context.Context, filter VoterFilter) (int64, error)
+	ClearTestVoters(ctx context.Context) (int64, error)
+	ClearVoterVotes(ctx context.Context, voterID int, force bool) (int64, error)
+	VoidVote(ctx context.Context, voterID, categoryID int, reason string) (int, error)
+	ListAuditLog(ctx context.Context) ([]models.AuditEntry, error)
+	ListStaleVoters(ctx context.Context, minutes int) ([]models.StaleVoter, error)
 	GenerateQRCodes(ctx context.Context, count int) ([]string, error)
 	GenerateQRImage(ctx context.Context, voterID int) ([]byte, error)
+	ResolveShortToken(ctx context.Context, token string) (string, error)
 	GenerateUniqueCode(ctx context.Context) (string, error)
+	DeviceVoterCode(ctx context.Context, token string) (string, bool)
+	BindDevice(ctx context.Context, token, qrCode string) (string, error)
 	GenerateDynamicQRImage(ctx context.Context) ([]byte, error)
+	GenerateCategoryQRImage(ctx context.Context, categoryID int) ([]byte, error)
+	SeedMockVoters(ctx context.Context) (int, error)
 }
 
 // VotingServicer defines the interface for voting operations
 type VotingServicer interface {
 	GetVoteData(ctx context.Context, qrCode string) (*VoteData, error)
+	ListPublicCategories(ctx context.Context) ([]PublicCategory, error)
+	GetVoterVoteSummary(ctx context.Context, qrCode string) (*VoterVoteSummary, error)
+	GetVoterInstructions(ctx context.Context, qrCode string) (*VoterInstructions, error)
+	ValidateVoterQR(ctx context.Context, qrCode string) (*VoterValidation, error)
 	GetOrCreateVoter(ctx context.Context, qrCode string) (int, error)
 	SubmitVote(ctx context.Context, vote models.Vote) (*VoteResult, error)
+	LiveTally(ctx context.Context, categoryID int) (map[int]int, error)
+	SubmitAssistedVote(ctx context.Context, voterID int, vote models.Vote, reason string) (*VoteResult, error)
+	SubmitBallot(ctx context.Context, ballot Ballot) (*BallotResult, error)
+	SaveVote(ctx context.Context, voterID, categoryID, carID int, override bool) error
+	SeedMockVotes(ctx context.Context) (int, error)
+	ExportVotes(ctx context.Context) (*VotesExport, error)
+	MergeVotes(ctx context.Context, export VotesExport) (*MergeResult, error)
 }
 
 // SettingsServicer defines the interface for settings operations
@@ -74,34 +121,83 @@ type SettingsServicer interface {
 	OpenVoting(ctx context.Context) error
 	CloseVoting(ctx context.Context) error
 	StartVotingTimer(ctx context.Context, minutes int) (string, error)
+	GetVotingClock(ctx context.Context) (*VotingClock, error)
+	GetMaxVotingMinutes(ctx context.Context) (int, error)
+	GetTieMargin(ctx context.Context) (int, error)
+	GetAutoCloseIdleMinutes(ctx context.Context) (int, error)
+	VotingOpenedAt(ctx context.Context) (time.Time, error)
+	GetVotingInstructions(ctx context.Context, voterType string) (string, error)
+	GetVotingInstructionsByType(ctx context.Context) (map[string]string, error)
+	GetVotingTimerPresets(ctx context.Context) ([]int, error)
 	UpdateSettings(ctx context.Context, settings Settings) error
 	ResetTables(ctx context.Context, tables []string) (*ResetTablesResult, error)
+	StartNewEvent(ctx context.Context) (*NewEventResult, error)
 	SetBroadcaster(b Broadcaster)
 	RequireRegisteredQR(ctx context.Context) (bool, error)
+	OpenVotingOnePerDevice(ctx context.Context) (bool, error)
+	PublicResultsEnabled(ctx context.Context) (bool, error)
+	DerbyNetHealthPolling(ctx context.Context) (bool, error)
+	AnonymizeBallots(ctx context.Context) (bool, error)
+	RequireCompleteBallot(ctx context.Context) (bool, error)
+	AllowVoteChanges(ctx context.Context) (bool, error)
+	MaintenanceMessage(ctx context.Context) (string, error)
+	PostVoteRedirectURL(ctx context.Context) (string, error)
+	CarNumberFormat(ctx context.Context) (string, error)
 	GetVoterTypes(ctx context.Context) ([]string, error)
 	SetVoterTypes(ctx context.Context, types []string) error
+	ExportSettings(ctx context.Context, includeSensitive bool) (map[string]string, error)
+	ImportSettings(ctx context.Context, values map[string]string, includeSensitive bool) (*SettingsImportResult, error)
+	GetBranding(ctx context.Context) (*Branding, error)
+	GetLocation(ctx context.Context) (*time.Location, error)
 }
 
 // ResultsServicer defines the interface for results operations
 type ResultsServicer interface {
 	GetResults(ctx context.Context) (*FullResults, error)
+	GetResultsIncludingTest(ctx context.Context) (*FullResults, error)
 	GetCategoryResults(ctx context.Context, categoryID int) (*CategoryResult, error)
+	GetCarResults(ctx context.Context, carID int) ([]CarCategoryResult, error)
 	GetStats(ctx context.Context) (map[string]interface{}, error)
+	TopCars(ctx context.Context, limit int, breakdown bool) ([]TopCar, error)
 	GetWinners(ctx context.Context) ([]map[string]interface{}, error)
 	GetFinalWinners(ctx context.Context) ([]map[string]interface{}, error)
-	PushResultsToDerbyNet(ctx context.Context, derbyNetURL string) (*ResultsPushResult, error)
+	RandomTieBreak(ctx context.Context, categoryID int) (*RandomTieBreakResult, error)
+	PushResultsToDerbyNet(ctx context.Context, derbyNetURL string, dryRun bool) (*ResultsPushResult, error)
+	ListPushHistory(ctx context.Context) ([]models.PushRecord, error)
+	PushReadiness(ctx context.Context) (*PushReadiness, error)
+	ListVotesForCategory(ctx context.Context, categoryID int) ([]models.CategoryVote, error)
 	DetectTies(ctx context.Context) ([]TieConflict, error)
 	DetectMultipleWins(ctx context.Context) ([]MultiWinConflict, error)
+	ResolutionStatus(ctx context.Context) (*ResolutionStatus, error)
 	SetManualWinner(ctx context.Context, categoryID, carID int, reason string) error
 	ClearManualWinner(ctx context.Context, categoryID int) error
+	FinalizeVoting(ctx context.Context) (*FinalizeResult, error)
+	GetResultsSnapshot(ctx context.Context) (*ResultsSnapshot, error)
+	VerifyTallies(ctx context.Context) (*TallyVerification, error)
+	EmbargoResults(ctx context.Context, revealCode string) error
+	ResultsEmbargoed(ctx context.Context) (bool, error)
+	CheckReveal(ctx context.Context, revealCode string) error
+	RevealResults(ctx context.Context, revealCode string) error
+	CheckPublicResults(ctx context.Context) error
+	GetPublicResults(ctx context.Context) ([]PublicCategoryResult, error)
+	GetCategoryBallots(ctx context.Context, categoryID int) ([]CategoryBallot, error)
+	Version() ResultsVersion
+	InvalidateCache()
+}
+
+// DerbyNetHealthServicer defines the interface for DerbyNet health polling
+type DerbyNetHealthServicer interface {
+	Status(ctx context.Context) DerbyNetStatus
+	Check(ctx context.Context) DerbyNetStatus
 }
 
 // Ensure concrete types implement interfaces
 var (
-	_ CategoryServicer = (*CategoryService)(nil)
-	_ CarServicer      = (*CarService)(nil)
-	_ VoterServicer    = (*VoterService)(nil)
-	_ VotingServicer   = (*VotingService)(nil)
-	_ SettingsServicer = (*SettingsService)(nil)
-	_ ResultsServicer  = (*ResultsService)(nil)
+	_ CategoryServicer       = (*CategoryService)(nil)
+	_ CarServicer            = (*CarService)(nil)
+	_ VoterServicer          = (*VoterService)(nil)
+	_ VotingServicer         = (*VotingService)(nil)
+	_ SettingsServicer       = (*SettingsService)(nil)
+	_ ResultsServicer        = (*ResultsService)(nil)
+	_ DerbyNetHealthServicer = (*DerbyNetHealthService)(nil)
 )
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Pseudonymizer derives the anonymous voter IDs used in anonymized ballot
+// exports and the event log. IDs are an HMAC of the voter's QR code under a
+// server secret, so the same voter keeps the same ID across exports but
+// someone holding the printed QR codes cannot recompute them.
+type Pseudonymizer struct {
+	key []byte
+}
+
+// NewPseudonymizer creates a Pseudonymizer keyed with secret
+func NewPseudonymizer(secret []byte) *Pseudonymizer {
+	return &Pseudonymizer{key: secret}
+}
+
+// VoterID returns the anonymous ID for a voter's QR code. A nil Pseudonymizer
+// uses an empty key, which keeps IDs stable but not secret.
+func (p *Pseudonymizer) VoterID(qrCode string) string {
+	var key []byte
+	if p != nil {
+		key = p.key
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("ballot:" + qrCode))
+	return "anon-" + hex.EncodeToString(mac.Sum(nil)[:6])
+}
@@ -2,10 +2,24 @@ package services
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/models"
 	"github.com/abrezinsky/derbyvote/internal/repository"
 	"github.com/abrezinsky/derbyvote/pkg/derbynet"
 )
@@ -24,6 +38,18 @@ type ResultsService struct {
 	repo     ResultsServiceRepository
 	settings SettingsServicer
 	client   derbynet.Client
+	cache    resultsCache
+	events   EventSink
+	writes   *WriteGauge
+	pseudo   *Pseudonymizer
+}
+
+// resultsCache holds vote result rows for the repository results version they were read at
+type resultsCache struct {
+	mu      sync.RWMutex
+	valid   bool
+	version uint64
+	rows    []repository.VoteResultRow
 }
 
 // NewResultsService creates a new ResultsService
@@ -31,6 +57,21 @@ func NewResultsService(log logger.Logger, repo ResultsServiceRepository, setting
 	return &ResultsService{log: log, repo: repo, settings: settings, client: client}
 }
 
+// SetEventSink sets where winner override events are recorded
+func (s *ResultsService) SetEventSink(sink EventSink) {
+	s.events = sink
+}
+
+// SetPseudonymizer sets how voters are identified in anonymized ballot exports
+func (s *ResultsService) SetPseudonymizer(p *Pseudonymizer) {
+	s.pseudo = p
+}
+
+// SetWriteGauge sets the gauge whose vote write rate and latency GetStats reports
+func (s *ResultsService) SetWriteGauge(gauge *WriteGauge) {
+	s.writes = gauge
+}
+
 // CarResult represents a car's vote result in a category
 type CarResult struct {
 	CarID     int    `json:"car_id"`
@@ -40,6 +81,61 @@ type CarResult struct {
 	PhotoURL  string `json:"photo_url"`
 	VoteCount int    `json:"vote_count"`
 	Rank      int    `json:"rank"`
+	WriteIn   bool   `json:"write_in,omitempty"` // Typed in by voters rather than a registered car
+}
+
+// Display orders for a category's ranked cars. Ranks and winners are always
+// decided by votes; these only change the order cars are listed in.
+const (
+	ResultSortVotes     = "votes_desc"
+	ResultSortCarNumber = "car_number"
+	ResultSortRacerName = "racer_name"
+)
+
+// ValidateResultSort checks a display order; empty means by votes
+func ValidateResultSort(order string) error {
+	switch order {
+	case "", ResultSortVotes, ResultSortCarNumber, ResultSortRacerName:
+		return nil
+	}
+	return errors.Validationf("sort must be %s, %s or %s", ResultSortVotes, ResultSortCarNumber, ResultSortRacerName)
+}
+
+// SortCarResults orders ranked cars for display. Orders other than car_number
+// and racer_name list them by votes.
+func SortCarResults(votes []CarResult, order string) {
+	switch order {
+	case ResultSortCarNumber:
+		sort.SliceStable(votes, func(i, j int) bool {
+			ni, nj := carNumberValue(votes[i].CarNumber), carNumberValue(votes[j].CarNumber)
+			if ni != nj {
+				return ni < nj
+			}
+			return votes[i].Rank < votes[j].Rank
+		})
+	case ResultSortRacerName:
+		sort.SliceStable(votes, func(i, j int) bool {
+			ri, rj := strings.ToLower(votes[i].RacerName), strings.ToLower(votes[j].RacerName)
+			if ri != rj {
+				return ri < rj
+			}
+			return votes[i].Rank < votes[j].Rank
+		})
+	default:
+		sort.SliceStable(votes, func(i, j int) bool { return votes[i].Rank < votes[j].Rank })
+	}
+}
+
+// carNumberValue returns the leading digits of a car number as an integer, so
+// numbers sort numerically the way car lists do. Numbers without leading
+// digits, such as write-ins, count as 0.
+func carNumberValue(carNumber string) int {
+	end := 0
+	for end < len(carNumber) && carNumber[end] >= '0' && carNumber[end] <= '9' {
+		end++
+	}
+	n, _ := strconv.Atoi(carNumber[:end])
+	return n
 }
 
 // CategoryResult represents results for a single category
@@ -50,28 +146,52 @@ type CategoryResult struct {
 	GroupName           string      `json:"group_name,omitempty"`
 	TotalVotes          int         `json:"total_votes"`
 	Votes               []CarResult `json:"votes"`
+	PendingWriteIns     []CarResult `json:"pending_write_ins,omitempty"` // Write-ins awaiting admin approval; they cannot win
 	HasOverride         bool        `json:"has_override"`
 	OverrideCarID       *int        `json:"override_car_id,omitempty"`
 	OverrideReason      string      `json:"override_reason,omitempty"`
 	OverriddenAt        string      `json:"overridden_at,omitempty"`
 }
 
+// CarCategoryResult represents how a single car placed in one category
+type CarCategoryResult struct {
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	VoteCount    int    `json:"vote_count"`
+	Rank         int    `json:"rank"`
+	TotalVotes   int    `json:"total_votes"`
+}
+
 // FullResults contains all voting results
 type FullResults struct {
 	Categories []CategoryResult       `json:"categories"`
 	Stats      map[string]interface{} `json:"stats"`
 }
 
-// GetResults retrieves full voting results
+// GetResults retrieves full voting results, leaving out test voters
 func (s *ResultsService) GetResults(ctx context.Context) (*FullResults, error) {
-	// Get categories
-	categories, err := s.repo.ListCategories(ctx)
+	// Get vote results with car details (cached until votes change)
+	voteRows, err := s.voteResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildResults(ctx, voteRows)
+}
+
+// GetResultsIncludingTest is GetResults counting test voters too, for admin
+// debugging. It bypasses the cache.
+func (s *ResultsService) GetResultsIncludingTest(ctx context.Context) (*FullResults, error) {
+	voteRows, err := s.repo.GetAllVoteResultsWithCars(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return s.buildResults(ctx, voteRows)
+}
 
-	// Get vote results with car details (single query, only cars with votes)
-	voteRows, err := s.repo.GetVoteResultsWithCars(ctx)
+// buildResults ranks vote rows within each active category
+func (s *ResultsService) buildResults(ctx context.Context, voteRows []repository.VoteResultRow) (*FullResults, error) {
+	// Get categories
+	categories, err := s.repo.ListCategories(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -82,18 +202,26 @@ func (s *ResultsService) GetResults(ctx context.Context) (*FullResults, error) {
 		return nil, err
 	}
 
-	// Group votes by category
+	// Group votes by category. Write-ins an admin has not approved are kept
+	// out of the ranking, so they count toward the total but cannot win.
 	votesByCategory := make(map[int][]CarResult)
+	pendingByCategory := make(map[int][]CarResult)
 	totalByCategory := make(map[int]int)
 	for _, row := range voteRows {
-		votesByCategory[row.CategoryID] = append(votesByCategory[row.CategoryID], CarResult{
+		car := CarResult{
 			CarID:     row.CarID,
 			CarNumber: row.CarNumber,
 			CarName:   row.CarName,
 			RacerName: row.RacerName,
 			PhotoURL:  row.PhotoURL,
 			VoteCount: row.VoteCount,
-		})
+			WriteIn:   row.WriteIn,
+		}
+		if row.WriteIn && !row.Eligible {
+			pendingByCategory[row.CategoryID] = append(pendingByCategory[row.CategoryID], car)
+		} else {
+			votesByCategory[row.CategoryID] = append(votesByCategory[row.CategoryID], car)
+		}
 		totalByCategory[row.CategoryID] += row.VoteCount
 	}
 
@@ -113,9 +241,10 @@ func (s *ResultsService) GetResults(ctx context.Context) (*FullResults, error) {
 			CategoryName:   cat.Name,
 			GroupID:        cat.GroupID,
 			GroupName:      cat.GroupName,
-			TotalVotes:     totalByCategory[cat.ID],
-			Votes:          votes,
-			HasOverride:    hasOverride,
+			TotalVotes:      totalByCategory[cat.ID],
+			Votes:           votes,
+			PendingWriteIns: pendingByCategory[cat.ID],
+			HasOverride:     hasOverride,
 			OverrideCarID:  cat.OverrideWinnerCarID,
 			OverrideReason: cat.OverrideReason,
 			OverriddenAt:   cat.OverriddenAt,
@@ -128,6 +257,226 @@ func (s *ResultsService) GetResults(ctx context.Context) (*FullResults, error) {
 	}, nil
 }
 
+// voteResults returns vote result rows, serving the cached copy while the
+// repository results version is unchanged. Cached rows must not be modified.
+func (s *ResultsService) voteResults(ctx context.Context) ([]repository.VoteResultRow, error) {
+	version := s.repo.ResultsVersion()
+
+	s.cache.mu.RLock()
+	if s.cache.valid && s.cache.version == version {
+		rows := s.cache.rows
+		s.cache.mu.RUnlock()
+		return rows, nil
+	}
+	s.cache.mu.RUnlock()
+
+	rows, err := s.repo.GetVoteResultsWithCars(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows are stored against the version read before the query, so a write
+	// that lands mid-query simply causes another refresh on the next call
+	s.cache.mu.Lock()
+	s.cache.valid = true
+	s.cache.version = version
+	s.cache.rows = rows
+	s.cache.mu.Unlock()
+	return rows, nil
+}
+
+// TallyMismatch is a category whose recount disagrees with the primary results
+type TallyMismatch struct {
+	CategoryID       int                `json:"category_id"`
+	CategoryName     string             `json:"category_name"`
+	Cars             []CarTallyMismatch `json:"cars"`
+	PrimaryWinners   []int              `json:"primary_winners"` // Car IDs with the most votes
+	RecountedWinners []int              `json:"recounted_winners"`
+}
+
+// CarTallyMismatch is one car's differing vote counts
+type CarTallyMismatch struct {
+	CarID     int `json:"car_id"`
+	Primary   int `json:"primary"`
+	Recounted int `json:"recounted"`
+}
+
+// TallyVerification reports whether recounting the raw votes reproduces the
+// primary results
+type TallyVerification struct {
+	Consistent        bool            `json:"consistent"`
+	CategoriesChecked int             `json:"categories_checked"`
+	VotesCounted      int             `json:"votes_counted"`
+	Mismatches        []TallyMismatch `json:"mismatches,omitempty"`
+}
+
+// VerifyTallies recounts every active category from the raw votes and compares
+// the counts with GetResults. The recount applies the counting rules (test
+// voters, complete ballots, category car subsets) in Go rather than SQL and
+// bypasses the results cache, so a bug in either path shows up as a mismatch.
+func (s *ResultsService) VerifyTallies(ctx context.Context) (*TallyVerification, error) {
+	primary, err := s.GetResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recount, votesCounted, err := s.recountVotes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &TallyVerification{
+		Consistent:        true,
+		CategoriesChecked: len(primary.Categories),
+		VotesCounted:      votesCounted,
+	}
+	for _, cat := range primary.Categories {
+		primaryCounts := make(map[int]int, len(cat.Votes))
+		for _, vote := range cat.Votes {
+			primaryCounts[vote.CarID] = vote.VoteCount
+		}
+		recounted := recount[cat.CategoryID]
+
+		var cars []CarTallyMismatch
+		for carID, count := range primaryCounts {
+			if recounted[carID] != count {
+				cars = append(cars, CarTallyMismatch{CarID: carID, Primary: count, Recounted: recounted[carID]})
+			}
+		}
+		for carID, count := range recounted {
+			if _, ok := primaryCounts[carID]; !ok {
+				cars = append(cars, CarTallyMismatch{CarID: carID, Recounted: count})
+			}
+		}
+		if len(cars) == 0 {
+			continue
+		}
+		sort.Slice(cars, func(i, j int) bool { return cars[i].CarID < cars[j].CarID })
+		verification.Consistent = false
+		verification.Mismatches = append(verification.Mismatches, TallyMismatch{
+			CategoryID:       cat.CategoryID,
+			CategoryName:     cat.CategoryName,
+			Cars:             cars,
+			PrimaryWinners:   topCars(primaryCounts),
+			RecountedWinners: topCars(recounted),
+		})
+	}
+
+	if !verification.Consistent {
+		s.log.WarnContext(ctx, "Vote tally verification found discrepancies", "categories", len(verification.Mismatches))
+	}
+	return verification, nil
+}
+
+// recountVotes tallies counted votes per category and car from the raw votes,
+// returning the tallies and how many votes counted
+func (s *ResultsService) recountVotes(ctx context.Context) (map[int]map[int]int, int, error) {
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	votes, err := s.repo.ListRawVotes(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	requireComplete, err := s.settings.RequireCompleteBallot(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	active := make(map[int]models.Category, len(categories))
+	for _, cat := range categories {
+		active[cat.ID] = cat
+	}
+
+	// Every category each voter voted in, counted or not, decides whether
+	// their ballot is complete
+	votedIn := make(map[int]map[int]bool)
+	voterTypes := make(map[int]string)
+	for _, vote := range votes {
+		if votedIn[vote.VoterID] == nil {
+			votedIn[vote.VoterID] = make(map[int]bool)
+		}
+		votedIn[vote.VoterID][vote.CategoryID] = true
+		voterTypes[vote.VoterID] = vote.VoterType
+	}
+	complete := func(voterID int) bool {
+		for _, cat := range categories {
+			if len(cat.AllowedVoterTypes) > 0 && !slices.Contains(cat.AllowedVoterTypes, voterTypes[voterID]) {
+				continue
+			}
+			if !votedIn[voterID][cat.ID] {
+				return false
+			}
+		}
+		return true
+	}
+
+	tallies := make(map[int]map[int]int)
+	counted := 0
+	for _, vote := range votes {
+		cat, ok := active[vote.CategoryID]
+		if !ok || vote.IsTest {
+			continue
+		}
+		if len(cat.CarIDs) > 0 && !vote.WriteIn && !slices.Contains(cat.CarIDs, vote.CarID) {
+			continue
+		}
+		if requireComplete && !complete(vote.VoterID) {
+			continue
+		}
+		if tallies[vote.CategoryID] == nil {
+			tallies[vote.CategoryID] = make(map[int]int)
+		}
+		tallies[vote.CategoryID][vote.CarID]++
+		counted++
+	}
+	return tallies, counted, nil
+}
+
+// topCars returns the IDs of the cars with the most votes, in ID order
+func topCars(counts map[int]int) []int {
+	best := 0
+	for _, count := range counts {
+		if count > best {
+			best = count
+		}
+	}
+	winners := []int{}
+	if best == 0 {
+		return winners
+	}
+	for carID, count := range counts {
+		if count == best {
+			winners = append(winners, carID)
+		}
+	}
+	sort.Ints(winners)
+	return winners
+}
+
+// ResultsVersion identifies a state of the results so clients can skip
+// refetching results they already have
+type ResultsVersion struct {
+	Version    uint64
+	ModifiedAt time.Time
+}
+
+// Version returns the current results version and when it last changed
+func (s *ResultsService) Version() ResultsVersion {
+	return ResultsVersion{
+		Version:    s.repo.ResultsVersion(),
+		ModifiedAt: s.repo.ResultsModifiedAt(),
+	}
+}
+
+// InvalidateCache drops cached results so the next read goes to the database
+func (s *ResultsService) InvalidateCache() {
+	s.cache.mu.Lock()
+	s.cache.valid = false
+	s.cache.rows = nil
+	s.cache.mu.Unlock()
+}
+
 // GetCategoryResults retrieves results for a specific category
 func (s *ResultsService) GetCategoryResults(ctx context.Context, categoryID int) (*CategoryResult, error) {
 	results, err := s.GetResults(ctx)
@@ -143,7 +492,105 @@ func (s *ResultsService) GetCategoryResults(ctx context.Context, categoryID int)
 	return nil, nil
 }
 
-// GetStats retrieves voting statistics including voting_open status
+// GetCarResults returns a car's vote count and placement in every category it
+// received votes in. Unknown cars return a not found error.
+func (s *ResultsService) GetCarResults(ctx context.Context, carID int) ([]CarCategoryResult, error) {
+	if _, err := s.repo.GetCar(ctx, carID); err != nil {
+		return nil, err
+	}
+
+	results, err := s.GetResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	carResults := []CarCategoryResult{}
+	for _, cat := range results.Categories {
+		for _, vote := range cat.Votes {
+			if vote.CarID == carID {
+				carResults = append(carResults, CarCategoryResult{
+					CategoryID:   cat.CategoryID,
+					CategoryName: cat.CategoryName,
+					VoteCount:    vote.VoteCount,
+					Rank:         vote.Rank,
+					TotalVotes:   cat.TotalVotes,
+				})
+				break
+			}
+		}
+	}
+	return carResults, nil
+}
+
+// MaxTopCars caps how many cars the overall leaderboard may list
+const MaxTopCars = 100
+
+// TopCar is a car ranked by its votes summed across every category. Cars with
+// the same total share a rank. Categories is only filled in on request.
+type TopCar struct {
+	Rank       int                 `json:"rank"`
+	CarID      int                 `json:"car_id"`
+	CarNumber  string              `json:"car_number"`
+	CarName    string              `json:"car_name"`
+	RacerName  string              `json:"racer_name"`
+	TotalVotes int                 `json:"total_votes"`
+	Categories []CarCategoryResult `json:"categories,omitempty"`
+}
+
+// TopCars returns the overall most popular cars, a fun statistic that is not
+// tied to any award. With breakdown set, each car lists its votes and place
+// in every category it received votes in.
+func (s *ResultsService) TopCars(ctx context.Context, limit int, breakdown bool) ([]TopCar, error) {
+	if limit < 1 || limit > MaxTopCars {
+		return nil, errors.Validationf("limit must be between 1 and %d", MaxTopCars)
+	}
+
+	rows, err := s.repo.ListTopCars(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var byCar map[int][]CarCategoryResult
+	if breakdown {
+		results, err := s.GetResults(ctx)
+		if err != nil {
+			return nil, err
+		}
+		byCar = make(map[int][]CarCategoryResult)
+		for _, cat := range results.Categories {
+			for _, vote := range cat.Votes {
+				byCar[vote.CarID] = append(byCar[vote.CarID], CarCategoryResult{
+					CategoryID:   cat.CategoryID,
+					CategoryName: cat.CategoryName,
+					VoteCount:    vote.VoteCount,
+					Rank:         vote.Rank,
+					TotalVotes:   cat.TotalVotes,
+				})
+			}
+		}
+	}
+
+	cars := make([]TopCar, 0, len(rows))
+	for i, row := range rows {
+		rank := i + 1
+		if i > 0 && row.TotalVotes == rows[i-1].TotalVotes {
+			rank = cars[i-1].Rank
+		}
+		cars = append(cars, TopCar{
+			Rank:       rank,
+			CarID:      row.CarID,
+			CarNumber:  row.CarNumber,
+			CarName:    row.CarName,
+			RacerName:  row.RacerName,
+			TotalVotes: row.TotalVotes,
+			Categories: byCar[row.CarID],
+		})
+	}
+	return cars, nil
+}
+
+// GetStats retrieves voting statistics including voting_open status and,
+// when a write gauge is set, vote write throughput over the last minute
 func (s *ResultsService) GetStats(ctx context.Context) (map[string]interface{}, error) {
 	stats, err := s.repo.GetVotingStats(ctx)
 	if err != nil {
@@ -156,6 +603,11 @@ func (s *ResultsService) GetStats(ctx context.Context) (map[string]interface{},
 		stats["voting_open"] = votingOpen
 	}
 
+	if s.writes != nil {
+		stats["votes_per_minute"] = s.writes.VotesPerMinute()
+		stats["write_latency_ms_p95"] = s.writes.LatencyP95Ms()
+	}
+
 	return stats, nil
 }
 
@@ -190,7 +642,8 @@ func (s *ResultsService) GetWinners(ctx context.Context) ([]map[string]interface
 type ResultsPushResult struct {
 	Status        string              `json:"status"`
 	Message       string              `json:"message,omitempty"`
-	WinnersPushed int                 `json:"winners_pushed"`
+	DryRun        bool                `json:"dry_run,omitempty"`
+	WinnersPushed int                 `json:"winners_pushed"` // In a dry run, winners that would be pushed
 	Skipped       int                 `json:"skipped"`
 	Errors        int                 `json:"errors"`
 	Details       []ResultsPushDetail `json:"details,omitempty"`
@@ -198,26 +651,149 @@ type ResultsPushResult struct {
 
 // ResultsPushDetail contains detail for one category's push result
 type ResultsPushDetail struct {
+	CategoryName  string `json:"category_name"`
+	Status        string `json:"status"`
+	Message       string `json:"message,omitempty"`
+	AwardID       *int   `json:"award_id,omitempty"`
+	RacerID       *int   `json:"racer_id,omitempty"`
+	Reassigned    bool   `json:"reassigned,omitempty"`
+	OriginalCarID *int   `json:"original_car_id,omitempty"`
+}
+
+// PushStatusDryRun is the detail status of a winner a dry run would have pushed
+const PushStatusDryRun = "dry_run"
+
+// PushResultsToDerbyNet pushes voting results to DerbyNet as award winners.
+// A dry run goes through the same checks but sends nothing to DerbyNet. Each
+// push, dry run or not, is recorded in the push history.
+func (s *ResultsService) PushResultsToDerbyNet(ctx context.Context, derbyNetURL string, dryRun bool) (*ResultsPushResult, error) {
+	result, err := s.pushResults(ctx, derbyNetURL, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	result.DryRun = dryRun
+	s.recordPush(ctx, derbyNetURL, result)
+	return result, nil
+}
+
+// recordPush saves a push to the push history. The push has already
+// happened, so a failure to record it is only logged.
+func (s *ResultsService) recordPush(ctx context.Context, derbyNetURL string, result *ResultsPushResult) {
+	record := models.PushRecord{
+		DerbyNetURL:   derbyNetURL,
+		DryRun:        result.DryRun,
+		Status:        result.Status,
+		Message:       result.Message,
+		WinnersPushed: result.WinnersPushed,
+		Skipped:       result.Skipped,
+		Errors:        result.Errors,
+	}
+	if len(result.Details) > 0 {
+		details, err := json.Marshal(result.Details)
+		if err != nil {
+			s.log.WarnContext(ctx, "Failed to encode push details", "error", err)
+		}
+		record.Details = details
+	}
+	if _, err := s.repo.SavePushRecord(ctx, record); err != nil {
+		s.log.WarnContext(ctx, "Failed to record DerbyNet push", "error", err)
+	}
+}
+
+// ListPushHistory returns every recorded push to DerbyNet, newest first
+func (s *ResultsService) ListPushHistory(ctx context.Context) ([]models.PushRecord, error) {
+	return s.repo.ListPushHistory(ctx)
+}
+
+// PushReadiness lists what would keep winners from being pushed to DerbyNet.
+// Ready is true when every winning category and car is linked.
+type PushReadiness struct {
+	Ready              bool                 `json:"ready"`
+	UnlinkedCategories []UnlinkedCategory   `json:"unlinked_categories"`
+	UnlinkedWinners    []UnlinkedWinningCar `json:"unlinked_winners"`
+}
+
+// UnlinkedCategory is a category with a winner but no DerbyNet award
+type UnlinkedCategory struct {
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	VoteCount    int    `json:"vote_count"`
+}
+
+// UnlinkedWinningCar is a winning car with no DerbyNet racer
+type UnlinkedWinningCar struct {
+	CategoryID   int    `json:"category_id"`
 	CategoryName string `json:"category_name"`
-	Status       string `json:"status"`
-	Message      string `json:"message,omitempty"`
+	CarID        int    `json:"car_id"`
+	CarNumber    string `json:"car_number"`
+	RacerName    string `json:"racer_name"`
 }
 
-// PushResultsToDerbyNet pushes voting results to DerbyNet as award winners
-func (s *ResultsService) PushResultsToDerbyNet(ctx context.Context, derbyNetURL string) (*ResultsPushResult, error) {
-	// Set the URL on the client
-	s.client.SetBaseURL(derbyNetURL)
+// PushReadiness checks the current winners for missing DerbyNet links without
+// contacting DerbyNet, so gaps can be fixed before results are pushed. Winners
+// reassigned by auto_runner_up are checked as the push would send them.
+func (s *ResultsService) PushReadiness(ctx context.Context) (*PushReadiness, error) {
+	winners, err := s.repo.GetWinnersForDerbyNet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := s.ResolveMultiWins(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	readiness := &PushReadiness{
+		UnlinkedCategories: []UnlinkedCategory{},
+		UnlinkedWinners:    []UnlinkedWinningCar{},
+	}
+	for _, w := range winners {
+		if rw, ok := resolved[w.CategoryID]; ok && rw.OriginalCarID == w.CarID {
+			racerID, err := s.repo.GetCarDerbyNetRacerID(ctx, rw.CarID)
+			if err != nil {
+				return nil, err
+			}
+			w.CarID = rw.CarID
+			w.DerbyNetRacerID = racerID
+			w.VoteCount = rw.VoteCount
+		}
 
-	// Configure credentials for automatic authentication
-	derbyNetRole, _ := s.repo.GetSetting(ctx, "derbynet_role")
-	derbyNetPassword, _ := s.repo.GetSetting(ctx, "derbynet_password")
-	if derbyNetRole != "" && derbyNetPassword != "" {
-		s.client.SetCredentials(derbyNetRole, derbyNetPassword)
+		if w.DerbyNetAwardID == nil {
+			readiness.UnlinkedCategories = append(readiness.UnlinkedCategories, UnlinkedCategory{
+				CategoryID:   w.CategoryID,
+				CategoryName: w.CategoryName,
+				VoteCount:    w.VoteCount,
+			})
+		}
+		if w.DerbyNetRacerID == nil {
+			unlinked := UnlinkedWinningCar{CategoryID: w.CategoryID, CategoryName: w.CategoryName, CarID: w.CarID}
+			// The car is only looked up for its label; a removed car still counts as unlinked
+			if car, err := s.repo.GetCar(ctx, w.CarID); err == nil {
+				unlinked.CarNumber = car.CarNumber
+				unlinked.RacerName = car.RacerName
+			}
+			readiness.UnlinkedWinners = append(readiness.UnlinkedWinners, unlinked)
+		}
 	}
+	readiness.Ready = len(readiness.UnlinkedCategories) == 0 && len(readiness.UnlinkedWinners) == 0
+	return readiness, nil
+}
+
+func (s *ResultsService) pushResults(ctx context.Context, derbyNetURL string, dryRun bool) (*ResultsPushResult, error) {
+	if !dryRun {
+		// Set the URL on the client
+		useDerbyNetURL(ctx, s.client, s.repo, derbyNetURL)
+
+		// Configure credentials for automatic authentication
+		derbyNetRole, _ := s.repo.GetSetting(ctx, "derbynet_role")
+		derbyNetPassword, _ := s.repo.GetSetting(ctx, "derbynet_password")
+		if derbyNetRole != "" && derbyNetPassword != "" {
+			s.client.SetCredentials(derbyNetRole, derbyNetPassword)
+		}
 
-	// Save DerbyNet URL to settings
-	if err := s.repo.SetSetting(ctx, "derbynet_url", derbyNetURL); err != nil {
-		return nil, fmt.Errorf("failed to save DerbyNet URL: %w", err)
+		// Save DerbyNet URL to settings
+		if err := s.repo.SetSetting(ctx, "derbynet_url", derbyNetURL); err != nil {
+			return nil, fmt.Errorf("failed to save DerbyNet URL: %w", err)
+		}
 	}
 
 	// Get winners with DerbyNet IDs
@@ -236,13 +812,43 @@ func (s *ResultsService) PushResultsToDerbyNet(ctx context.Context, derbyNetURL
 		}, nil
 	}
 
-	s.log.Info("Pushing results to DerbyNet", "count", len(winners))
+	// Apply auto_runner_up resolution for groups that opt in
+	resolved, err := s.ResolveMultiWins(ctx)
+	if err != nil {
+		return &ResultsPushResult{
+			Status:  "error",
+			Message: fmt.Sprintf("Failed to resolve multiple wins: %v", err),
+		}, nil
+	}
+
+	s.log.InfoContext(ctx, "Pushing results to DerbyNet", "count", len(winners), "dry_run", dryRun)
 
 	result := &ResultsPushResult{Status: "success"}
 
 	for _, w := range winners {
 		detail := ResultsPushDetail{CategoryName: w.CategoryName}
 
+		if rw, ok := resolved[w.CategoryID]; ok && rw.OriginalCarID == w.CarID {
+			racerID, err := s.repo.GetCarDerbyNetRacerID(ctx, rw.CarID)
+			if err != nil {
+				detail.Status = "error"
+				detail.Message = fmt.Sprintf("Failed to look up runner-up car: %v", err)
+				result.Errors++
+				result.Details = append(result.Details, detail)
+				continue
+			}
+			originalCarID := w.CarID
+			w.CarID = rw.CarID
+			w.DerbyNetRacerID = racerID
+			w.VoteCount = rw.VoteCount
+			detail.Reassigned = true
+			detail.OriginalCarID = &originalCarID
+			detail.Message = fmt.Sprintf("Reassigned to runner-up car #%s (car #%s exceeded max wins in %s)", rw.CarNumber, rw.OriginalCarNumber, rw.GroupName)
+		}
+
+		detail.AwardID = w.DerbyNetAwardID
+		detail.RacerID = w.DerbyNetRacerID
+
 		// Check if we have the required DerbyNet IDs
 		if w.DerbyNetAwardID == nil {
 			detail.Status = "skipped"
@@ -259,19 +865,26 @@ func (s *ResultsService) PushResultsToDerbyNet(ctx context.Context, derbyNetURL
 			continue
 		}
 
+		if dryRun {
+			detail.Status = PushStatusDryRun
+			result.WinnersPushed++
+			result.Details = append(result.Details, detail)
+			continue
+		}
+
 		// Push to DerbyNet
 		err := s.client.SetAwardWinner(ctx, *w.DerbyNetAwardID, *w.DerbyNetRacerID)
 		if err != nil {
-			s.log.Error("Error pushing winner to DerbyNet",
+			s.log.ErrorContext(ctx, "Error pushing winner to DerbyNet",
 				"category", w.CategoryName,
 				"award_id", *w.DerbyNetAwardID,
 				"racer_id", *w.DerbyNetRacerID,
 				"error", err)
 			detail.Status = "error"
-			detail.Message = err.Error()
+			detail.Message = derbynet.Explain(err)
 			result.Errors++
 		} else {
-			s.log.Info("Pushed winner to DerbyNet",
+			s.log.InfoContext(ctx, "Pushed winner to DerbyNet",
 				"category", w.CategoryName,
 				"award_id", *w.DerbyNetAwardID,
 				"racer_id", *w.DerbyNetRacerID)
@@ -287,15 +900,34 @@ func (s *ResultsService) PushResultsToDerbyNet(ctx context.Context, derbyNetURL
 	} else if result.Skipped > 0 {
 		result.Message = fmt.Sprintf("%d winners pushed, %d skipped (missing DerbyNet links)", result.WinnersPushed, result.Skipped)
 	}
+	if dryRun {
+		result.Message = fmt.Sprintf("Dry run: %d winners would be pushed, %d skipped", result.WinnersPushed, result.Skipped)
+	}
 
 	return result, nil
 }
 
-// TieConflict represents a category with tied vote counts
+// TieConflict represents a category with tied vote counts. A near tie is one
+// where the leader is ahead by no more than the tie_margin setting; Margin is
+// the leader's lead over the runner-up.
 type TieConflict struct {
 	CategoryID   int         `json:"category_id"`
 	CategoryName string      `json:"category_name"`
 	TiedCars     []CarResult `json:"tied_cars"`
+	NearTie      bool        `json:"near_tie,omitempty"`
+	Margin       int         `json:"margin,omitempty"`
+}
+
+// ExactTies returns only the exact ties, which must be resolved before results
+// are frozen or pushed; near ties are warnings for review
+func ExactTies(ties []TieConflict) []TieConflict {
+	var exact []TieConflict
+	for _, tie := range ties {
+		if !tie.NearTie {
+			exact = append(exact, tie)
+		}
+	}
+	return exact
 }
 
 // MultiWinConflict represents a car winning multiple awards (exceeding group limit)
@@ -310,13 +942,21 @@ type MultiWinConflict struct {
 	MaxWinsPerCar int      `json:"max_wins_per_car"`
 }
 
-// DetectTies finds categories where multiple cars share the highest vote count
+// DetectTies finds categories where multiple cars share the highest vote count,
+// plus near ties where the runner-up is within the tie_margin setting
 func (s *ResultsService) DetectTies(ctx context.Context) ([]TieConflict, error) {
 	results, err := s.GetResults(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	margin := 0
+	if s.settings != nil {
+		if margin, err = s.settings.GetTieMargin(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	var ties []TieConflict
 	for _, cat := range results.Categories {
 		// Skip categories that already have a manual override
@@ -348,6 +988,26 @@ func (s *ResultsService) DetectTies(ctx context.Context) ([]TieConflict, error)
 				CategoryName: cat.CategoryName,
 				TiedCars:     tiedCars,
 			})
+			continue
+		}
+
+		// Otherwise flag a near tie if the runner-up is within the margin
+		lead := maxVotes - cat.Votes[1].VoteCount
+		if margin > 0 && lead <= margin {
+			var closeCars []CarResult
+			for _, vote := range cat.Votes {
+				if maxVotes-vote.VoteCount > margin {
+					break
+				}
+				closeCars = append(closeCars, vote)
+			}
+			ties = append(ties, TieConflict{
+				CategoryID:   cat.CategoryID,
+				CategoryName: cat.CategoryName,
+				TiedCars:     closeCars,
+				NearTie:      true,
+				Margin:       lead,
+			})
 		}
 	}
 
@@ -371,6 +1031,10 @@ func (s *ResultsService) DetectMultipleWins(ctx context.Context) ([]MultiWinConf
 	groupLimits := make(map[int]int)
 	groupNames := make(map[int]string)
 	for _, g := range groups {
+		// Groups using auto_runner_up are resolved automatically, not flagged
+		if g.MultiWinStrategy == models.MultiWinStrategyAutoRunnerUp {
+			continue
+		}
 		if g.MaxWinsPerCar != nil && *g.MaxWinsPerCar > 0 {
 			groupLimits[g.ID] = *g.MaxWinsPerCar
 			groupNames[g.ID] = g.Name
@@ -469,6 +1133,263 @@ func (s *ResultsService) DetectMultipleWins(ctx context.Context) ([]MultiWinConf
 	return multiWins, nil
 }
 
+// Resolution states for a category's winner
+const (
+	ResolutionClean              = "clean"
+	ResolutionTieUnresolved      = "tie_unresolved"
+	ResolutionTieResolved        = "tie_resolved"
+	ResolutionMultiWinUnresolved = "multi_win_unresolved"
+)
+
+// CategoryResolution is one category's entry in the resolution checklist.
+// Blocking is true when the category must be resolved before results are pushed.
+type CategoryResolution struct {
+	CategoryID     int    `json:"category_id"`
+	CategoryName   string `json:"category_name"`
+	GroupID        *int   `json:"group_id,omitempty"`
+	GroupName      string `json:"group_name,omitempty"`
+	Status         string `json:"status"`
+	Blocking       bool   `json:"blocking"`
+	MultiWinCarID  *int   `json:"multi_win_car_id,omitempty"`
+	OverrideCarID  *int   `json:"override_car_id,omitempty"`
+	OverrideReason string `json:"override_reason,omitempty"`
+}
+
+// ResolutionStatus summarizes the conflicts left before results can be pushed.
+// Ready is true when no category is blocking.
+type ResolutionStatus struct {
+	Ready      bool                 `json:"ready"`
+	Categories []CategoryResolution `json:"categories"`
+}
+
+// ResolutionStatus reports, for every category, whether its winner is clean,
+// tied (unresolved, or resolved by an override) or part of an unresolved
+// multi-win. An unresolved tie is reported ahead of a multi-win because
+// resolving the tie can change which car wins.
+func (s *ResultsService) ResolutionStatus(ctx context.Context) (*ResolutionStatus, error) {
+	results, err := s.GetResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ties, err := s.DetectTies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	multiWins, err := s.DetectMultipleWins(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tied := make(map[int]bool)
+	for _, tie := range ExactTies(ties) {
+		tied[tie.CategoryID] = true
+	}
+	multiWinCar := make(map[int]int)
+	for _, mw := range multiWins {
+		for _, id := range mw.CategoryIDs {
+			multiWinCar[id] = mw.CarID
+		}
+	}
+
+	status := &ResolutionStatus{Ready: true, Categories: []CategoryResolution{}}
+	for _, cat := range results.Categories {
+		entry := CategoryResolution{
+			CategoryID:     cat.CategoryID,
+			CategoryName:   cat.CategoryName,
+			GroupID:        cat.GroupID,
+			GroupName:      cat.GroupName,
+			Status:         ResolutionClean,
+			OverrideCarID:  cat.OverrideCarID,
+			OverrideReason: cat.OverrideReason,
+		}
+		carID, inMultiWin := multiWinCar[cat.CategoryID]
+		switch {
+		case tied[cat.CategoryID]:
+			entry.Status = ResolutionTieUnresolved
+			entry.Blocking = true
+		case inMultiWin:
+			entry.Status = ResolutionMultiWinUnresolved
+			entry.Blocking = true
+			entry.MultiWinCarID = &carID
+		case cat.HasOverride && len(cat.Votes) >= 2 && cat.Votes[0].VoteCount == cat.Votes[1].VoteCount:
+			// DetectTies skips overridden categories, so a tie under an override is resolved
+			entry.Status = ResolutionTieResolved
+		}
+		if entry.Blocking {
+			status.Ready = false
+		}
+		status.Categories = append(status.Categories, entry)
+	}
+	return status, nil
+}
+
+// ResolvedWin describes a category win that was reassigned to a runner-up
+type ResolvedWin struct {
+	CategoryID        int    `json:"category_id"`
+	CategoryName      string `json:"category_name"`
+	GroupID           int    `json:"group_id"`
+	GroupName         string `json:"group_name"`
+	OriginalCarID     int    `json:"original_car_id"`
+	OriginalCarNumber string `json:"original_car_number"`
+	CarID             int    `json:"car_id"`
+	CarNumber         string `json:"car_number"`
+	RacerName         string `json:"racer_name"`
+	VoteCount         int    `json:"vote_count"`
+}
+
+// ResolveMultiWins applies the auto_runner_up strategy to groups that opt in.
+// A car over the group's max_wins_per_car keeps its highest-vote wins and the
+// remaining categories go to the next eligible runner-up. Manual overrides are
+// never reassigned. Returns the reassigned wins keyed by category ID.
+func (s *ResultsService) ResolveMultiWins(ctx context.Context) (map[int]ResolvedWin, error) {
+	return s.resolveMultiWins(ctx, nil)
+}
+
+// resolveMultiWins is ResolveMultiWins over the given category results, such
+// as a frozen snapshot's. Nil categories means the current results.
+func (s *ResultsService) resolveMultiWins(ctx context.Context, categories []CategoryResult) (map[int]ResolvedWin, error) {
+	groups, err := s.repo.ListCategoryGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	autoGroups := make(map[int]models.CategoryGroup)
+	for _, g := range groups {
+		if g.MultiWinStrategy == models.MultiWinStrategyAutoRunnerUp && g.MaxWinsPerCar != nil && *g.MaxWinsPerCar > 0 {
+			autoGroups[g.ID] = g
+		}
+	}
+
+	resolved := make(map[int]ResolvedWin)
+	if len(autoGroups) == 0 {
+		return resolved, nil
+	}
+
+	if categories == nil {
+		results, err := s.GetResults(ctx)
+		if err != nil {
+			return nil, err
+		}
+		categories = results.Categories
+	}
+
+	// Categories per auto group, in display order
+	categoriesByGroup := make(map[int][]CategoryResult)
+	for _, cat := range categories {
+		if cat.GroupID == nil {
+			continue
+		}
+		if _, ok := autoGroups[*cat.GroupID]; ok {
+			categoriesByGroup[*cat.GroupID] = append(categoriesByGroup[*cat.GroupID], cat)
+		}
+	}
+
+	for groupID, cats := range categoriesByGroup {
+		group := autoGroups[groupID]
+		for categoryID, rw := range resolveGroupWins(cats, *group.MaxWinsPerCar) {
+			rw.GroupID = groupID
+			rw.GroupName = group.Name
+			resolved[categoryID] = rw
+		}
+	}
+
+	return resolved, nil
+}
+
+// resolveGroupWins reassigns wins within one group so no car exceeds maxWins
+func resolveGroupWins(cats []CategoryResult, maxWins int) map[int]ResolvedWin {
+	// winnerIdx is the index into Votes of the current winner (-1 means none).
+	// Overrides count toward the limit but are fixed, so they are not tracked here.
+	winnerIdx := make(map[int]int)
+	winCount := make(map[int]int)
+	for _, cat := range cats {
+		if cat.HasOverride && cat.OverrideCarID != nil {
+			winCount[*cat.OverrideCarID]++
+			continue
+		}
+		if len(cat.Votes) == 0 || cat.Votes[0].VoteCount == 0 {
+			winnerIdx[cat.CategoryID] = -1
+			continue
+		}
+		winnerIdx[cat.CategoryID] = 0
+		winCount[cat.Votes[0].CarID]++
+	}
+
+	// Each pass demotes the weakest excess win; indexes only move forward so this terminates.
+	// Cars are visited in ID order so the outcome does not depend on map iteration.
+	for changed := true; changed; {
+		changed = false
+		carIDs := make([]int, 0, len(winCount))
+		for carID := range winCount {
+			carIDs = append(carIDs, carID)
+		}
+		sort.Ints(carIDs)
+		for _, carID := range carIDs {
+			if winCount[carID] <= maxWins {
+				continue
+			}
+
+			// Find the car's lowest-vote win that has an available runner-up;
+			// on equal votes the later category is given up
+			weakest, weakestNext := -1, -1
+			for i, cat := range cats {
+				idx, ok := winnerIdx[cat.CategoryID]
+				if !ok || idx < 0 || cat.Votes[idx].CarID != carID {
+					continue
+				}
+				next := nextRunnerUp(cat.Votes, idx, winCount, maxWins)
+				if next < 0 {
+					continue
+				}
+				if weakest < 0 || cat.Votes[idx].VoteCount <= cats[weakest].Votes[winnerIdx[cats[weakest].CategoryID]].VoteCount {
+					weakest, weakestNext = i, next
+				}
+			}
+			if weakest < 0 {
+				continue // Only overrides or wins without a runner-up remain
+			}
+
+			winCount[carID]--
+			winCount[cats[weakest].Votes[weakestNext].CarID]++
+			winnerIdx[cats[weakest].CategoryID] = weakestNext
+			changed = true
+			break
+		}
+	}
+
+	resolved := make(map[int]ResolvedWin)
+	for _, cat := range cats {
+		idx, ok := winnerIdx[cat.CategoryID]
+		if !ok || idx <= 0 {
+			continue
+		}
+		original := cat.Votes[0]
+		winner := cat.Votes[idx]
+		resolved[cat.CategoryID] = ResolvedWin{
+			CategoryID:        cat.CategoryID,
+			CategoryName:      cat.CategoryName,
+			OriginalCarID:     original.CarID,
+			OriginalCarNumber: original.CarNumber,
+			CarID:             winner.CarID,
+			CarNumber:         winner.CarNumber,
+			RacerName:         winner.RacerName,
+			VoteCount:         winner.VoteCount,
+		}
+	}
+	return resolved
+}
+
+// nextRunnerUp returns the index of the next car after idx with votes that is
+// still under the group limit, or -1 if there is none
+func nextRunnerUp(votes []CarResult, idx int, winCount map[int]int, maxWins int) int {
+	for i := idx + 1; i < len(votes); i++ {
+		if votes[i].VoteCount > 0 && winCount[votes[i].CarID] < maxWins {
+			return i
+		}
+	}
+	return -1
+}
+
 // SetManualWinner sets a manual winner override for a category
 func (s *ResultsService) SetManualWinner(ctx context.Context, categoryID, carID int, reason string) error {
 	// Validate reason is not empty
@@ -493,22 +1414,70 @@ func (s *ResultsService) SetManualWinner(ctx context.Context, categoryID, carID
 	}
 
 	// Verify car exists
-	cars, err := s.repo.ListCars(ctx)
+	if _, err := s.repo.GetCar(ctx, carID); err != nil {
+		return err
+	}
+
+	if err := s.repo.SetManualWinner(ctx, categoryID, carID, reason); err != nil {
+		return err
+	}
+	recordEvent(s.events, Event{Type: EventWinnerOverridden, CategoryID: categoryID, CarID: carID})
+	return nil
+}
+
+// RandomTieBreakResult is the outcome of breaking a tie by random draw
+type RandomTieBreakResult struct {
+	CategoryID   int         `json:"category_id"`
+	CategoryName string      `json:"category_name"`
+	Winner       CarResult   `json:"winner"`
+	TiedCars     []CarResult `json:"tied_cars"`
+	Seed         string      `json:"seed"`
+	Reason       string      `json:"reason"`
+}
+
+// RandomTieBreak picks a winner among a category's tied cars at random and
+// records it as a manual override, so pushing results works as for any other
+// override. The seed is kept in the override reason: the winner is the tied
+// car, ordered by car ID, at index seed mod the number of tied cars.
+func (s *ResultsService) RandomTieBreak(ctx context.Context, categoryID int) (*RandomTieBreakResult, error) {
+	ties, err := s.DetectTies(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to verify car: %w", err)
+		return nil, err
 	}
-	carExists := false
-	for _, car := range cars {
-		if car.ID == carID {
-			carExists = true
+
+	var tie *TieConflict
+	for _, t := range ExactTies(ties) {
+		if t.CategoryID == categoryID {
+			tie = &t
 			break
 		}
 	}
-	if !carExists {
-		return fmt.Errorf("car %d not found", carID)
+	if tie == nil {
+		return nil, errors.Validationf("category %d has no tie to break", categoryID)
 	}
 
-	return s.repo.SetManualWinner(ctx, categoryID, carID, reason)
+	seed := make([]byte, 8)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, fmt.Errorf("failed to generate seed: %w", err)
+	}
+	tied := append([]CarResult(nil), tie.TiedCars...)
+	sort.Slice(tied, func(i, j int) bool { return tied[i].CarID < tied[j].CarID })
+	winner := tied[binary.BigEndian.Uint64(seed)%uint64(len(tied))]
+
+	result := &RandomTieBreakResult{
+		CategoryID:   tie.CategoryID,
+		CategoryName: tie.CategoryName,
+		Winner:       winner,
+		TiedCars:     tied,
+		Seed:         hex.EncodeToString(seed),
+	}
+	result.Reason = fmt.Sprintf("random draw (seed %s)", result.Seed)
+
+	if err := s.SetManualWinner(ctx, categoryID, winner.CarID, result.Reason); err != nil {
+		return nil, err
+	}
+	s.log.InfoContext(ctx, "Tie broken by random draw", "category_id", categoryID, "car_id", winner.CarID, "seed", result.Seed)
+	return result, nil
 }
 
 // ClearManualWinner removes the manual winner override for a category
@@ -590,3 +1559,377 @@ func (s *ResultsService) GetFinalWinners(ctx context.Context) ([]map[string]inte
 
 	return winners, nil
 }
+
+// resultsSnapshotKey is the setting that holds the frozen results snapshot
+const resultsSnapshotKey = "results_snapshot"
+
+// ResultsSnapshot is a frozen copy of the results taken when voting is finalized
+type ResultsSnapshot struct {
+	FrozenAt   string                   `json:"frozen_at"`
+	Categories []CategoryResult         `json:"categories"`
+	Winners    []map[string]interface{} `json:"winners"`
+}
+
+// FinalizeResult reports the outcome of FinalizeVoting. Snapshot is nil when
+// conflicts kept the results from being frozen.
+type FinalizeResult struct {
+	Ties      []TieConflict
+	MultiWins []MultiWinConflict
+	Snapshot  *ResultsSnapshot
+}
+
+// FinalizeVoting closes voting and, if no ties or multiple-win conflicts need
+// resolving, freezes a snapshot of the results. Voting stays closed either way.
+func (s *ResultsService) FinalizeVoting(ctx context.Context) (*FinalizeResult, error) {
+	if err := s.settings.CloseVoting(ctx); err != nil {
+		return nil, err
+	}
+
+	ties, err := s.DetectTies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	multiWins, err := s.DetectMultipleWins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if exactTies := ExactTies(ties); len(exactTies) > 0 || len(multiWins) > 0 {
+		s.log.InfoContext(ctx, "Voting closed; results not frozen due to conflicts", "ties", len(exactTies), "multi_wins", len(multiWins))
+		return &FinalizeResult{Ties: ties, MultiWins: multiWins}, nil
+	}
+
+	results, err := s.GetResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	winners, err := s.GetFinalWinners(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &ResultsSnapshot{
+		FrozenAt:   time.Now().UTC().Format(time.RFC3339),
+		Categories: results.Categories,
+		Winners:    winners,
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetSetting(ctx, resultsSnapshotKey, string(data)); err != nil {
+		return nil, err
+	}
+
+	s.log.InfoContext(ctx, "Voting finalized and results frozen", "categories", len(snapshot.Categories))
+	return &FinalizeResult{Snapshot: snapshot}, nil
+}
+
+// GetResultsSnapshot returns the frozen results, or nil if results have not been frozen
+func (s *ResultsService) GetResultsSnapshot(ctx context.Context) (*ResultsSnapshot, error) {
+	value, err := s.repo.GetSetting(ctx, resultsSnapshotKey)
+	if err == repository.ErrNotFound || (err == nil && value == "") {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot ResultsSnapshot
+	if err := json.Unmarshal([]byte(value), &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid results snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// Settings that hold the results embargo. The reveal code is stored hashed.
+const (
+	resultsEmbargoKey = "results_embargoed"
+	revealCodeKey     = "results_reveal_code"
+)
+
+// Reveal code length limits
+const (
+	MinRevealCodeLength = 4
+	MaxRevealCodeLength = 100
+)
+
+// ValidateRevealCode checks a reveal code before results are embargoed with it
+func ValidateRevealCode(code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) < MinRevealCodeLength || len(code) > MaxRevealCodeLength {
+		return errors.InvalidFields(map[string]string{
+			"reveal_code": fmt.Sprintf("must be %d to %d characters", MinRevealCodeLength, MaxRevealCodeLength),
+		})
+	}
+	return nil
+}
+
+// hashRevealCode returns the stored form of a reveal code: a random salt and
+// an HMAC of the code keyed with it, so equal codes never store alike
+func hashRevealCode(code string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate reveal code salt: %w", err)
+	}
+	return hex.EncodeToString(salt) + ":" + revealCodeMAC(salt, code), nil
+}
+
+// revealCodeMAC returns the hex HMAC-SHA256 of a reveal code keyed with salt
+func revealCodeMAC(salt []byte, code string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(strings.TrimSpace(code)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// revealCodeMatches reports whether code is the one stored
+func revealCodeMatches(stored, code string) bool {
+	saltHex, sum, salted := strings.Cut(stored, ":")
+	if !salted {
+		return false
+	}
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(sum), []byte(revealCodeMAC(salt, code))) == 1
+}
+
+// EmbargoResults hides the frozen results until RevealResults is called or
+// revealCode is presented to CheckReveal. Results must be frozen first.
+func (s *ResultsService) EmbargoResults(ctx context.Context, revealCode string) error {
+	if err := ValidateRevealCode(revealCode); err != nil {
+		return err
+	}
+	snapshot, err := s.GetResultsSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return errors.Conflict("results must be frozen before they can be embargoed")
+	}
+
+	hashed, err := hashRevealCode(revealCode)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetSetting(ctx, revealCodeKey, hashed); err != nil {
+		return err
+	}
+	if err := s.repo.SetSetting(ctx, resultsEmbargoKey, "true"); err != nil {
+		return err
+	}
+	s.log.InfoContext(ctx, "Results embargoed until revealed")
+	return nil
+}
+
+// ResultsEmbargoed reports whether results are hidden until revealed
+func (s *ResultsService) ResultsEmbargoed(ctx context.Context) (bool, error) {
+	return resultsEmbargoed(ctx, s.repo)
+}
+
+// resultsEmbargoed reads the embargo setting, for services that hide vote
+// counts while results are embargoed
+func resultsEmbargoed(ctx context.Context, settings settingGetter) (bool, error) {
+	value, err := settings.GetSetting(ctx, resultsEmbargoKey)
+	if err == repository.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return value == "true", nil
+}
+
+// CheckReveal returns ErrResultsEmbargoed while results are embargoed, unless
+// revealCode matches the code the embargo was set with
+func (s *ResultsService) CheckReveal(ctx context.Context, revealCode string) error {
+	embargoed, err := s.ResultsEmbargoed(ctx)
+	if err != nil || !embargoed {
+		return err
+	}
+	if revealCode != "" {
+		stored, err := s.repo.GetSetting(ctx, revealCodeKey)
+		if err != nil && err != repository.ErrNotFound {
+			return err
+		}
+		if stored != "" && revealCodeMatches(stored, revealCode) {
+			return nil
+		}
+	}
+	return ErrResultsEmbargoed
+}
+
+// RevealResults lifts the results embargo for everyone. The reveal code the
+// embargo was set with is required, otherwise it returns ErrResultsEmbargoed.
+// It is a no-op if results are not embargoed.
+func (s *ResultsService) RevealResults(ctx context.Context, revealCode string) error {
+	if err := s.CheckReveal(ctx, revealCode); err != nil {
+		return err
+	}
+	embargoed, err := s.ResultsEmbargoed(ctx)
+	if err != nil || !embargoed {
+		return err
+	}
+	if err := s.repo.SetSetting(ctx, resultsEmbargoKey, ""); err != nil {
+		return err
+	}
+	if err := s.repo.SetSetting(ctx, revealCodeKey, ""); err != nil {
+		return err
+	}
+	s.log.InfoContext(ctx, "Results embargo lifted")
+	return nil
+}
+
+// PublicWinner is a category winner as shown on the public leaderboard
+type PublicWinner struct {
+	CarNumber string `json:"car_number"`
+	CarName   string `json:"car_name"`
+	RacerName string `json:"racer_name"`
+	VoteCount int    `json:"vote_count"`
+}
+
+// PublicCategoryResult is one category on the public leaderboard. It carries
+// aggregate counts only, never anything about individual voters.
+type PublicCategoryResult struct {
+	CategoryName string        `json:"category_name"`
+	TotalVotes   int           `json:"total_votes"`
+	Winner       *PublicWinner `json:"winner"`
+}
+
+// CheckPublicResults returns a forbidden error unless the public leaderboard
+// is available: public_results_enabled is set and voting is closed
+func (s *ResultsService) CheckPublicResults(ctx context.Context) error {
+	enabled, err := s.settings.PublicResultsEnabled(ctx)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return errors.Forbidden("public results are not enabled")
+	}
+
+	open, err := s.settings.IsVotingOpen(ctx)
+	if err != nil {
+		return err
+	}
+	if open {
+		return errors.Forbidden("results are not available while voting is open")
+	}
+	return nil
+}
+
+// GetPublicResults returns category winners for the public leaderboard. It is
+// only available when public_results_enabled is set, and then only once voting
+// has closed, so the leaderboard cannot sway voters. Frozen results are served
+// when voting was finalized.
+func (s *ResultsService) GetPublicResults(ctx context.Context) ([]PublicCategoryResult, error) {
+	if err := s.CheckPublicResults(ctx); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.GetResultsSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot != nil {
+		return s.publicResults(ctx, snapshot.Categories)
+	}
+
+	results, err := s.GetResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.publicResults(ctx, results.Categories)
+}
+
+// publicResults reduces category results to each category's winner, honoring
+// manual overrides and wins auto_runner_up reassigns. Unresolved ties have no
+// winner.
+func (s *ResultsService) publicResults(ctx context.Context, categories []CategoryResult) ([]PublicCategoryResult, error) {
+	resolved, err := s.resolveMultiWins(ctx, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	public := make([]PublicCategoryResult, 0, len(categories))
+	for _, cat := range categories {
+		entry := PublicCategoryResult{CategoryName: cat.CategoryName, TotalVotes: cat.TotalVotes}
+		tied := len(cat.Votes) > 1 && cat.Votes[0].VoteCount == cat.Votes[1].VoteCount
+		rw, reassigned := resolved[cat.CategoryID]
+		for _, vote := range cat.Votes {
+			isWinner := vote.Rank == 1 && vote.VoteCount > 0 && !tied
+			if reassigned {
+				isWinner = vote.CarID == rw.CarID
+			}
+			if cat.HasOverride {
+				isWinner = cat.OverrideCarID != nil && vote.CarID == *cat.OverrideCarID
+			}
+			if isWinner {
+				entry.Winner = &PublicWinner{
+					CarNumber: vote.CarNumber,
+					CarName:   vote.CarName,
+					RacerName: vote.RacerName,
+					VoteCount: vote.VoteCount,
+				}
+				break
+			}
+		}
+		public = append(public, entry)
+	}
+	return public, nil
+}
+
+// CategoryBallot is one vote in a category's ballot export. When ballots are
+// anonymized, VoterID is a stable hash and VoterName is empty.
+type CategoryBallot struct {
+	VoterID   string
+	VoterName string
+	VoterType string
+	CarID     int
+	CarNumber string
+	CarName   string
+	RacerName string
+	WriteIn   bool
+	VotedAt   string
+}
+
+// GetCategoryBallots returns every vote cast in a category for a manual
+// recount. Voter identity is replaced with a hashed ID when anonymize_ballots
+// is set. Unknown categories return a not found error.
+func (s *ResultsService) GetCategoryBallots(ctx context.Context, categoryID int) ([]CategoryBallot, error) {
+	anonymize, err := s.settings.AnonymizeBallots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListCategoryBallots(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	ballots := make([]CategoryBallot, 0, len(rows))
+	for _, row := range rows {
+		ballot := CategoryBallot{
+			VoterID:   strconv.Itoa(row.VoterID),
+			VoterName: row.VoterName,
+			VoterType: row.VoterType,
+			CarID:     row.CarID,
+			CarNumber: row.CarNumber,
+			CarName:   row.CarName,
+			RacerName: row.RacerName,
+			WriteIn:   row.WriteIn,
+			VotedAt:   row.VotedAt,
+		}
+		if anonymize {
+			ballot.VoterID = s.pseudo.VoterID(row.VoterQR)
+			ballot.VoterName = ""
+		}
+		ballots = append(ballots, ballot)
+	}
+	return ballots, nil
+}
+
+// ListVotesForCategory returns every vote in a category with when it was
+// cast, earliest first, for timing tiebreaks and spotting bursts of votes
+func (s *ResultsService) ListVotesForCategory(ctx context.Context, categoryID int) ([]models.CategoryVote, error) {
+	return s.repo.ListVotesForCategory(ctx, categoryID)
+}
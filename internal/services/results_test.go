@@ -2,10 +2,18 @@ package services_test
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
+	apperrors "github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/repository"
 	"github.com/abrezinsky/derbyvote/internal/repository/mock"
 	"github.com/abrezinsky/derbyvote/internal/services"
 	"github.com/abrezinsky/derbyvote/internal/testutil"
@@ -15,7 +23,7 @@ import (
 // setupTestData creates test categories, cars, voters, and optionally votes
 // Returns category IDs and car IDs for use in tests
 func setupTestData(t *testing.T, ctx context.Context, repo interface {
-	CreateCategory(ctx context.Context, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string) (int64, error)
+	CreateCategory(ctx context.Context, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string, tags []string) (int64, error)
 	CreateCar(ctx context.Context, carNumber, racerName, carName, photoURL string) error
 	CreateVoter(ctx context.Context, qrCode string) (int, error)
 	GetVoterByQR(ctx context.Context, qrCode string) (int, error)
@@ -24,15 +32,15 @@ func setupTestData(t *testing.T, ctx context.Context, repo interface {
 	t.Helper()
 
 	// Create categories
-	cat1ID, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	cat1ID, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
-	cat2ID, err := repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil)
+	cat2ID, err := repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
-	cat3ID, err := repo.CreateCategory(ctx, "Most Creative", 3, nil, nil, nil)
+	cat3ID, err := repo.CreateCategory(ctx, "Most Creative", 3, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -235,6 +243,48 @@ func TestResultsService_GetResults_WithVotes(t *testing.T) {
 	}
 }
 
+func TestResultsService_GetResults_ExcludesTestVoters(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	_, _ = setupTestData(t, ctx, repo, true)
+
+	// Warm the cache so marking test voters has to invalidate it
+	if _, err := svc.GetResults(ctx); err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	for _, qr := range []string{"voter-qr-001", "voter-qr-002"} {
+		id, _ := repo.GetVoterByQR(ctx, qr)
+		if err := repo.SetVoterTest(ctx, id, true); err != nil {
+			t.Fatalf("SetVoterTest failed: %v", err)
+		}
+	}
+
+	results, err := svc.GetResults(ctx)
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	// Best Design drops to car 1 with 1 vote and car 2 with 2
+	bestDesign := results.Categories[0]
+	if bestDesign.TotalVotes != 3 {
+		t.Errorf("expected 3 counted votes, got %d", bestDesign.TotalVotes)
+	}
+	if len(bestDesign.Votes) == 0 || bestDesign.Votes[0].CarID != 2 {
+		t.Errorf("expected car 2 to lead without test voters, got %+v", bestDesign.Votes)
+	}
+
+	all, err := svc.GetResultsIncludingTest(ctx)
+	if err != nil {
+		t.Fatalf("GetResultsIncludingTest failed: %v", err)
+	}
+	if all.Categories[0].TotalVotes != 5 || all.Categories[0].Votes[0].CarID != 1 {
+		t.Errorf("expected test voters counted, got %+v", all.Categories[0])
+	}
+}
+
 func TestResultsService_GetResults_ProperRanking(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -379,6 +429,230 @@ func TestResultsService_GetCategoryResults_EmptyDatabase(t *testing.T) {
 	}
 }
 
+func TestResultsService_TopCars(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	cat1, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	cat2, _ := repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	_ = repo.CreateCar(ctx, "103", "Racer Three", "Car C", "")
+	cars, _ := repo.ListCars(ctx)
+	v1, _ := repo.CreateVoter(ctx, "V1")
+	v2, _ := repo.CreateVoter(ctx, "V2")
+	_ = repo.SaveVote(ctx, v1, int(cat1), cars[0].ID)
+	_ = repo.SaveVote(ctx, v2, int(cat2), cars[0].ID)
+	_ = repo.SaveVote(ctx, v2, int(cat1), cars[1].ID)
+	_ = repo.SaveVote(ctx, v1, int(cat2), cars[2].ID)
+
+	top, err := svc.TopCars(ctx, 10, false)
+	if err != nil {
+		t.Fatalf("TopCars failed: %v", err)
+	}
+	if len(top) != 3 || top[0].CarID != cars[0].ID || top[0].Rank != 1 || top[0].TotalVotes != 2 {
+		t.Fatalf("expected car 101 to lead with 2 votes, got %+v", top)
+	}
+	if top[1].Rank != 2 || top[2].Rank != 2 {
+		t.Errorf("expected cars with one vote each to share rank 2, got %d and %d", top[1].Rank, top[2].Rank)
+	}
+	if top[0].Categories != nil {
+		t.Errorf("expected no breakdown unless requested, got %+v", top[0].Categories)
+	}
+
+	top, err = svc.TopCars(ctx, 1, true)
+	if err != nil {
+		t.Fatalf("TopCars with breakdown failed: %v", err)
+	}
+	if len(top) != 1 || len(top[0].Categories) != 2 {
+		t.Fatalf("expected the leader's two categories, got %+v", top)
+	}
+	if c := top[0].Categories[0]; c.CategoryName != "Best Design" || c.VoteCount != 1 || c.TotalVotes != 2 {
+		t.Errorf("unexpected breakdown entry: %+v", c)
+	}
+
+	for _, limit := range []int{0, services.MaxTopCars + 1} {
+		_, err := svc.TopCars(ctx, limit, false)
+		var appErr *apperrors.Error
+		if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+			t.Errorf("limit %d: expected validation error, got %v", limit, err)
+		}
+	}
+}
+
+func TestResultsService_GetCarResults_GetResultsError(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, mockRepo)
+	svc := services.NewResultsService(log, mockRepo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	realRepo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := realRepo.ListCars(ctx)
+
+	mockRepo.ListCategoriesError = errors.New("database error")
+
+	if _, err := svc.GetCarResults(ctx, cars[0].ID); err == nil {
+		t.Error("expected error from GetResults, got nil")
+	}
+}
+
+func TestSortCarResults(t *testing.T) {
+	ranked := func() []services.CarResult {
+		return []services.CarResult{
+			{CarID: 1, CarNumber: "12", RacerName: "charlie", VoteCount: 5, Rank: 1},
+			{CarID: 2, CarNumber: "3", RacerName: "Alice", VoteCount: 3, Rank: 2},
+			{CarID: 3, CarNumber: "", RacerName: "bob", VoteCount: 2, Rank: 3, WriteIn: true},
+			{CarID: 4, CarNumber: "3", RacerName: "alice", VoteCount: 1, Rank: 4},
+		}
+	}
+	ids := func(votes []services.CarResult) []int {
+		out := make([]int, len(votes))
+		for i, v := range votes {
+			out[i] = v.CarID
+		}
+		return out
+	}
+
+	tests := []struct {
+		order string
+		want  []int
+	}{
+		{"", []int{1, 2, 3, 4}},
+		{services.ResultSortVotes, []int{1, 2, 3, 4}},
+		{services.ResultSortCarNumber, []int{3, 2, 4, 1}},
+		{services.ResultSortRacerName, []int{2, 4, 3, 1}},
+	}
+	for _, tt := range tests {
+		if err := services.ValidateResultSort(tt.order); err != nil {
+			t.Errorf("ValidateResultSort(%q) failed: %v", tt.order, err)
+		}
+		votes := ranked()
+		services.SortCarResults(votes, tt.order)
+		if got := ids(votes); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("sort %q: expected %v, got %v", tt.order, tt.want, got)
+		}
+		// Display order never changes ranks
+		for _, v := range votes {
+			if v.CarID == 1 && v.Rank != 1 {
+				t.Errorf("sort %q changed the winner's rank to %d", tt.order, v.Rank)
+			}
+		}
+	}
+
+	err := services.ValidateResultSort("votes_asc")
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+		t.Errorf("expected validation error for unknown sort, got %v", err)
+	}
+}
+
+func TestResultsService_GetCategoryBallots(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	first, _ := repo.CreateVoterFull(ctx, nil, "Pat", "", "judge", "QR-ONE", "")
+	second, _ := repo.CreateVoterFull(ctx, nil, "Sam", "", "general", "QR-TWO", "")
+	repo.SaveVote(ctx, int(first), int(catID), cars[0].ID)
+	repo.SaveVote(ctx, int(second), int(catID), cars[0].ID)
+
+	ballots, err := svc.GetCategoryBallots(ctx, int(catID))
+	if err != nil {
+		t.Fatalf("GetCategoryBallots failed: %v", err)
+	}
+	if len(ballots) != 2 || ballots[0].VoterName != "Pat" || ballots[0].VoterID != fmt.Sprint(first) {
+		t.Fatalf("expected named ballots, got %+v", ballots)
+	}
+
+	repo.SetSetting(ctx, "anonymize_ballots", "true")
+	anon, err := svc.GetCategoryBallots(ctx, int(catID))
+	if err != nil {
+		t.Fatalf("GetCategoryBallots failed: %v", err)
+	}
+	if anon[0].VoterName != "" || anon[0].VoterID == ballots[0].VoterID {
+		t.Errorf("expected voter identity to be hidden, got %+v", anon[0])
+	}
+	if anon[0].VoterID == anon[1].VoterID {
+		t.Error("expected different voters to get different anonymized IDs")
+	}
+	if again, _ := svc.GetCategoryBallots(ctx, int(catID)); again[0].VoterID != anon[0].VoterID {
+		t.Error("expected anonymized IDs to be stable between exports")
+	}
+}
+
+func TestPseudonymizer_VoterID(t *testing.T) {
+	a := services.NewPseudonymizer([]byte("secret-a"))
+	b := services.NewPseudonymizer([]byte("secret-b"))
+
+	if a.VoterID("QR-ONE") != a.VoterID("QR-ONE") {
+		t.Error("expected the same voter to keep the same ID")
+	}
+	if a.VoterID("QR-ONE") == a.VoterID("QR-TWO") {
+		t.Error("expected different voters to get different IDs")
+	}
+	if a.VoterID("QR-ONE") == b.VoterID("QR-ONE") {
+		t.Error("expected IDs to depend on the secret")
+	}
+	if id := a.VoterID("QR-ONE"); !strings.HasPrefix(id, "anon-") || len(id) != len("anon-")+12 {
+		t.Errorf("unexpected ID format %q", id)
+	}
+
+	var unset *services.Pseudonymizer
+	if unset.VoterID("QR-ONE") != unset.VoterID("QR-ONE") {
+		t.Error("expected a nil Pseudonymizer to give stable IDs")
+	}
+}
+
+func TestResultsService_GetCategoryBallots_SecretKeysAnonymousIDs(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoterFull(ctx, nil, "Pat", "", "judge", "QR-ONE", "")
+	repo.SaveVote(ctx, int(voterID), int(catID), cars[0].ID)
+	repo.SetSetting(ctx, "anonymize_ballots", "true")
+
+	svc.SetPseudonymizer(services.NewPseudonymizer([]byte("first")))
+	first, _ := svc.GetCategoryBallots(ctx, int(catID))
+	svc.SetPseudonymizer(services.NewPseudonymizer([]byte("second")))
+	second, _ := svc.GetCategoryBallots(ctx, int(catID))
+
+	if len(first) != 1 || len(second) != 1 {
+		t.Fatalf("expected one ballot, got %+v and %+v", first, second)
+	}
+	if first[0].VoterID != services.NewPseudonymizer([]byte("first")).VoterID("QR-ONE") {
+		t.Errorf("expected ID keyed by the configured secret, got %q", first[0].VoterID)
+	}
+	if first[0].VoterID == second[0].VoterID {
+		t.Error("expected a different secret to produce a different anonymous ID")
+	}
+}
+
+func TestResultsService_GetCategoryBallots_NotFound(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+
+	_, err := svc.GetCategoryBallots(context.Background(), 9999)
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrNotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
 func TestResultsService_GetStats_ReturnsStatistics(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -797,7 +1071,7 @@ func TestResultsService_PushResultsToDerbyNet_NoVotes(t *testing.T) {
 	awardID := 10
 	_, _ = repo.UpsertCategory(ctx, "Best Design", 1, &awardID)
 
-	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local")
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
 	if err != nil {
 		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
 	}
@@ -836,7 +1110,7 @@ func TestResultsService_PushResultsToDerbyNet_Success(t *testing.T) {
 	voter, _ := repo.CreateVoter(ctx, "PUSH-QR")
 	_ = repo.SaveVote(ctx, voter, categoryID, carID)
 
-	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local")
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
 	if err != nil {
 		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
 	}
@@ -870,7 +1144,7 @@ func TestResultsService_PushResultsToDerbyNet_MissingAwardID(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category WITHOUT DerbyNet award ID
-	_, _ = repo.CreateCategory(ctx, "Local Category", 1, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Local Category", 1, nil, nil, nil, nil)
 	categories, _ := repo.ListCategories(ctx)
 	categoryID := categories[0].ID
 
@@ -883,7 +1157,7 @@ func TestResultsService_PushResultsToDerbyNet_MissingAwardID(t *testing.T) {
 	voter, _ := repo.CreateVoter(ctx, "LOCAL-QR")
 	_ = repo.SaveVote(ctx, voter, categoryID, carID)
 
-	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local")
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
 	if err != nil {
 		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
 	}
@@ -927,7 +1201,7 @@ func TestResultsService_PushResultsToDerbyNet_MissingRacerID(t *testing.T) {
 	voter, _ := repo.CreateVoter(ctx, "MANUAL-QR")
 	_ = repo.SaveVote(ctx, voter, categoryID, carID)
 
-	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local")
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
 	if err != nil {
 		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
 	}
@@ -964,7 +1238,7 @@ func TestResultsService_PushResultsToDerbyNet_ClientError(t *testing.T) {
 	voter, _ := repo.CreateVoter(ctx, "ERROR-QR")
 	_ = repo.SaveVote(ctx, voter, categoryID, carID)
 
-	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local")
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
 	if err != nil {
 		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
 	}
@@ -980,6 +1254,32 @@ func TestResultsService_PushResultsToDerbyNet_ClientError(t *testing.T) {
 	}
 }
 
+func TestResultsService_PushResultsToDerbyNet_InsufficientRole(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	roleErr := fmt.Errorf("DerbyNet error: %w", &derbynet.DerbyNetError{Code: derbynet.CodeNotAuthorized, Description: "Not authorized"})
+	mockClient := derbynet.NewMockClient(derbynet.WithSetWinnerError(roleErr))
+	svc := services.NewResultsService(log, repo, settingsSvc, mockClient)
+	ctx := context.Background()
+
+	awardID := 41
+	_, _ = repo.UpsertCategory(ctx, "Role Category", 1, &awardID)
+	categories, _ := repo.ListCategories(ctx)
+	_ = repo.UpsertCar(ctx, 410, "411", "Role Racer", "Role Car", "", "")
+	cars, _ := repo.ListCars(ctx)
+	voter, _ := repo.CreateVoter(ctx, "ROLE-QR")
+	_ = repo.SaveVote(ctx, voter, categories[0].ID, cars[0].ID)
+
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
+	if err != nil {
+		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
+	}
+	if len(result.Details) != 1 || !strings.HasPrefix(result.Details[0].Message, "Insufficient DerbyNet role") {
+		t.Errorf("expected insufficient role message, got %+v", result.Details)
+	}
+}
+
 func TestResultsService_PushResultsToDerbyNet_MultipleCategories(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -1009,7 +1309,7 @@ func TestResultsService_PushResultsToDerbyNet_MultipleCategories(t *testing.T) {
 	_ = repo.SaveVote(ctx, voter, cat1ID, car1ID)
 	_ = repo.SaveVote(ctx, voter, cat2ID, car2ID)
 
-	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local")
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
 	if err != nil {
 		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
 	}
@@ -1036,7 +1336,7 @@ func TestResultsService_PushResultsToDerbyNet_SavesURL(t *testing.T) {
 	svc := services.NewResultsService(log, repo, settingsSvc, mockClient)
 	ctx := context.Background()
 
-	_, err := svc.PushResultsToDerbyNet(ctx, "http://push-test.local")
+	_, err := svc.PushResultsToDerbyNet(ctx, "http://push-test.local", false)
 	if err != nil {
 		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
 	}
@@ -1051,6 +1351,126 @@ func TestResultsService_PushResultsToDerbyNet_SavesURL(t *testing.T) {
 	}
 }
 
+func TestResultsService_PushResultsToDerbyNet_DryRunAndHistory(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	mockClient := derbynet.NewMockClient()
+	svc := services.NewResultsService(log, repo, settingsSvc, mockClient)
+	ctx := context.Background()
+
+	awardID := 50
+	_, _ = repo.UpsertCategory(ctx, "Category 1", 1, &awardID)
+	_, _ = repo.UpsertCategory(ctx, "Category 2", 2, nil)
+	categories, _ := repo.ListCategories(ctx)
+	_ = repo.UpsertCar(ctx, 501, "501", "Racer 1", "Car 1", "", "")
+	cars, _ := repo.ListCars(ctx)
+	voter, _ := repo.CreateVoter(ctx, "DRY-QR")
+	_ = repo.SaveVote(ctx, voter, categories[0].ID, cars[0].ID)
+	_ = repo.SaveVote(ctx, voter, categories[1].ID, cars[0].ID)
+
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://dry.local", true)
+	if err != nil {
+		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
+	}
+	if !result.DryRun || result.WinnersPushed != 1 || result.Skipped != 1 {
+		t.Errorf("expected dry run with 1 winner and 1 skipped, got %+v", result)
+	}
+	if winners := mockClient.GetAwardWinners(); len(winners) != 0 {
+		t.Errorf("expected nothing sent to DerbyNet in a dry run, got %v", winners)
+	}
+	if savedURL, _ := repo.GetSetting(ctx, "derbynet_url"); savedURL != "" {
+		t.Errorf("expected a dry run not to save the DerbyNet URL, got %q", savedURL)
+	}
+
+	if _, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false); err != nil {
+		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
+	}
+
+	history, err := svc.ListPushHistory(ctx)
+	if err != nil {
+		t.Fatalf("ListPushHistory failed: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 pushes in history, got %d", len(history))
+	}
+	if history[0].DryRun || history[0].DerbyNetURL != "http://derbynet.local" || history[0].WinnersPushed != 1 {
+		t.Errorf("unexpected latest push: %+v", history[0])
+	}
+	if !history[1].DryRun || history[1].DerbyNetURL != "http://dry.local" {
+		t.Errorf("unexpected first push: %+v", history[1])
+	}
+
+	var details []services.ResultsPushDetail
+	if err := json.Unmarshal(history[0].Details, &details); err != nil {
+		t.Fatalf("failed to decode push details: %v", err)
+	}
+	if len(details) != 2 {
+		t.Fatalf("expected 2 award outcomes, got %+v", details)
+	}
+	for _, detail := range details {
+		if detail.Status == "success" && (detail.AwardID == nil || *detail.AwardID != awardID || detail.RacerID == nil || *detail.RacerID != 501) {
+			t.Errorf("expected award %d -> racer 501, got %+v", awardID, detail)
+		}
+	}
+}
+
+func TestResultsService_PushReadiness(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	mockClient := derbynet.NewMockClient()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), mockClient)
+	ctx := context.Background()
+
+	readiness, err := svc.PushReadiness(ctx)
+	if err != nil {
+		t.Fatalf("PushReadiness failed: %v", err)
+	}
+	if !readiness.Ready {
+		t.Errorf("expected ready with no votes, got %+v", readiness)
+	}
+
+	awardID := 50
+	_, _ = repo.UpsertCategory(ctx, "Linked", 1, &awardID)
+	_, _ = repo.UpsertCategory(ctx, "Unlinked", 2, nil)
+	_, _ = repo.UpsertCategory(ctx, "No Votes", 3, nil)
+	categories, _ := repo.ListCategories(ctx)
+	_ = repo.UpsertCar(ctx, 501, "501", "Racer 1", "Car 1", "", "")
+	_ = repo.CreateCar(ctx, "77", "Walk-up Racer", "Car 2", "")
+	cars, _ := repo.ListCars(ctx)
+	carIDs := map[string]int{}
+	for _, car := range cars {
+		carIDs[car.CarNumber] = car.ID
+	}
+	voter, _ := repo.CreateVoter(ctx, "READY-QR")
+	_ = repo.SaveVote(ctx, voter, categories[0].ID, carIDs["77"])
+	_ = repo.SaveVote(ctx, voter, categories[1].ID, carIDs["501"])
+
+	readiness, err = svc.PushReadiness(ctx)
+	if err != nil {
+		t.Fatalf("PushReadiness failed: %v", err)
+	}
+	if readiness.Ready {
+		t.Error("expected not ready with unlinked winners")
+	}
+	if len(readiness.UnlinkedCategories) != 1 || readiness.UnlinkedCategories[0].CategoryName != "Unlinked" || readiness.UnlinkedCategories[0].VoteCount != 1 {
+		t.Errorf("expected only the unlinked category with votes, got %+v", readiness.UnlinkedCategories)
+	}
+	if len(readiness.UnlinkedWinners) != 1 || readiness.UnlinkedWinners[0].CarNumber != "77" || readiness.UnlinkedWinners[0].CategoryName != "Linked" {
+		t.Errorf("expected car 77 as the unlinked winner, got %+v", readiness.UnlinkedWinners)
+	}
+	if winners := mockClient.GetAwardWinners(); len(winners) != 0 {
+		t.Errorf("expected nothing sent to DerbyNet, got %v", winners)
+	}
+
+	racerID := 577
+	_ = repo.SetCarDerbyNetRacerID(ctx, carIDs["77"], &racerID)
+	_ = repo.SetCategoryDerbyNetAwardID(ctx, categories[1].ID, &awardID)
+	if readiness, _ = svc.PushReadiness(ctx); !readiness.Ready {
+		t.Errorf("expected ready once everything is linked, got %+v", readiness)
+	}
+}
+
 // errTest is a test error for mock clients
 var errTest = &testError{}
 
@@ -1173,7 +1593,7 @@ func TestResultsService_PushResultsToDerbyNet_GetWinnersError(t *testing.T) {
 	svc := services.NewResultsService(log, mockRepo, settingsSvc, mockClient)
 
 	ctx := context.Background()
-	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local")
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
 	if err != nil {
 		t.Fatalf("expected no error, got: %v", err)
 	}
@@ -1209,7 +1629,7 @@ func TestResultsService_PushResultsToDerbyNet_SetSettingError(t *testing.T) {
 	// Configure mock to fail on SetSetting (saving DerbyNet URL)
 	mockRepo.SetSettingError = errors.New("database error saving URL")
 
-	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local")
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
 	if err == nil {
 		t.Fatal("expected error when SetSetting fails, got nil")
 	}
@@ -1245,7 +1665,7 @@ func TestResultsService_PushResultsToDerbyNet_WithCredentials(t *testing.T) {
 	voter, _ := repo.CreateVoter(ctx, "PUSH-QR")
 	_ = repo.SaveVote(ctx, voter, categoryID, carID)
 
-	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local")
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
 	if err != nil {
 		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
 	}
@@ -1294,7 +1714,7 @@ func TestResultsService_DetectTies_SimpleTie(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and cars
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
 	cars, _ := repo.ListCars(ctx)
@@ -1340,6 +1760,58 @@ func TestResultsService_DetectTies_SimpleTie(t *testing.T) {
 	}
 }
 
+func TestResultsService_RandomTieBreak(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	cars, _ := repo.ListCars(ctx)
+	v1, _ := repo.CreateVoter(ctx, "V1")
+	v2, _ := repo.CreateVoter(ctx, "V2")
+	repo.SaveVote(ctx, v1, int(catID), cars[0].ID)
+	repo.SaveVote(ctx, v2, int(catID), cars[1].ID)
+
+	result, err := svc.RandomTieBreak(ctx, int(catID))
+	if err != nil {
+		t.Fatalf("RandomTieBreak failed: %v", err)
+	}
+	if len(result.TiedCars) != 2 {
+		t.Fatalf("expected 2 tied cars, got %d", len(result.TiedCars))
+	}
+
+	// The seed reproduces the draw
+	seed, err := hex.DecodeString(result.Seed)
+	if err != nil || len(seed) != 8 {
+		t.Fatalf("expected an 8 byte hex seed, got %q", result.Seed)
+	}
+	expected := result.TiedCars[binary.BigEndian.Uint64(seed)%2]
+	if result.Winner.CarID != expected.CarID {
+		t.Errorf("expected seed to pick car %d, got %d", expected.CarID, result.Winner.CarID)
+	}
+
+	// The draw is stored as an override naming the seed
+	categories, _ := repo.ListCategories(ctx)
+	cat := categories[0]
+	if cat.OverrideWinnerCarID == nil || *cat.OverrideWinnerCarID != result.Winner.CarID {
+		t.Errorf("expected override for car %d, got %v", result.Winner.CarID, cat.OverrideWinnerCarID)
+	}
+	if cat.OverrideReason != "random draw (seed "+result.Seed+")" {
+		t.Errorf("unexpected override reason %q", cat.OverrideReason)
+	}
+
+	// With the override in place the tie is resolved
+	_, err = svc.RandomTieBreak(ctx, int(catID))
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+		t.Errorf("expected validation error once the tie is broken, got %v", err)
+	}
+}
+
 func TestResultsService_DetectTies_ThreeWayTie(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -1348,7 +1820,7 @@ func TestResultsService_DetectTies_ThreeWayTie(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and cars
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
 	_ = repo.CreateCar(ctx, "103", "Racer Three", "Car C", "")
@@ -1378,35 +1850,130 @@ func TestResultsService_DetectTies_ThreeWayTie(t *testing.T) {
 	}
 }
 
-func TestResultsService_DetectTies_IgnoresCategoriesWithNoVotes(t *testing.T) {
+func TestResultsService_DetectTies_NearTie(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
 	settingsSvc := services.NewSettingsService(log, repo)
 	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
 	ctx := context.Background()
 
-	// Create category with no votes
-	_, _ = repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	closeID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	exactID, _ := repo.CreateCategory(ctx, "Best Paint", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	_ = repo.CreateCar(ctx, "103", "Racer Three", "Car C", "")
+	cars, _ := repo.ListCars(ctx)
+
+	// Best Design: Car A 3 votes, Car B 2, Car C 1
+	// Best Paint: Car A 1 vote, Car B 1 (exact tie)
+	for i, carIdx := range []int{0, 0, 0, 1, 1, 2} {
+		voterID, _ := repo.CreateVoter(ctx, fmt.Sprintf("V%d", i))
+		repo.SaveVote(ctx, voterID, int(closeID), cars[carIdx].ID)
+		if i < 2 {
+			repo.SaveVote(ctx, voterID, int(exactID), cars[i].ID)
+		}
+	}
 
+	// Margin 0 reports only the exact tie
 	ties, err := svc.DetectTies(ctx)
 	if err != nil {
 		t.Fatalf("DetectTies failed: %v", err)
 	}
+	if len(ties) != 1 || ties[0].CategoryName != "Best Paint" || ties[0].NearTie {
+		t.Fatalf("expected only the exact tie in Best Paint, got %+v", ties)
+	}
 
-	if len(ties) != 0 {
-		t.Errorf("expected no ties for category with no votes, got %d", len(ties))
+	margin := 1
+	if err := settingsSvc.UpdateSettings(ctx, services.Settings{TieMargin: &margin}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	ties, err = svc.DetectTies(ctx)
+	if err != nil {
+		t.Fatalf("DetectTies failed: %v", err)
+	}
+	if len(ties) != 2 {
+		t.Fatalf("expected exact tie and near tie, got %d", len(ties))
+	}
+
+	var nearTie *services.TieConflict
+	for i := range ties {
+		if ties[i].NearTie {
+			nearTie = &ties[i]
+		}
+	}
+	if nearTie == nil || nearTie.CategoryName != "Best Design" {
+		t.Fatalf("expected near tie in Best Design, got %+v", ties)
+	}
+	if nearTie.Margin != 1 {
+		t.Errorf("expected margin 1, got %d", nearTie.Margin)
+	}
+	if len(nearTie.TiedCars) != 2 {
+		t.Errorf("expected the 2 cars within the margin, got %d", len(nearTie.TiedCars))
+	}
+	if exact := services.ExactTies(ties); len(exact) != 1 || exact[0].CategoryName != "Best Paint" {
+		t.Errorf("expected ExactTies to keep only Best Paint, got %+v", exact)
 	}
 }
 
-func TestResultsService_DetectTies_WithExistingOverride(t *testing.T) {
+func TestResultsService_FinalizeVoting_NearTieDoesNotBlockFreeze(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
 	settingsSvc := services.NewSettingsService(log, repo)
 	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
 	ctx := context.Background()
 
-	// Create category and cars
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	cars, _ := repo.ListCars(ctx)
+	for i, carIdx := range []int{0, 0, 1} {
+		voterID, _ := repo.CreateVoter(ctx, fmt.Sprintf("V%d", i))
+		repo.SaveVote(ctx, voterID, int(catID), cars[carIdx].ID)
+	}
+
+	margin := 2
+	if err := settingsSvc.UpdateSettings(ctx, services.Settings{TieMargin: &margin}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+
+	result, err := svc.FinalizeVoting(ctx)
+	if err != nil {
+		t.Fatalf("FinalizeVoting failed: %v", err)
+	}
+	if result.Snapshot == nil {
+		t.Fatal("expected results to be frozen despite the near tie")
+	}
+}
+
+func TestResultsService_DetectTies_IgnoresCategoriesWithNoVotes(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	// Create category with no votes
+	_, _ = repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+
+	ties, err := svc.DetectTies(ctx)
+	if err != nil {
+		t.Fatalf("DetectTies failed: %v", err)
+	}
+
+	if len(ties) != 0 {
+		t.Errorf("expected no ties for category with no votes, got %d", len(ties))
+	}
+}
+
+func TestResultsService_DetectTies_WithExistingOverride(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	// Create category and cars
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
 	cars, _ := repo.ListCars(ctx)
@@ -1465,13 +2032,13 @@ func TestResultsService_DetectMultipleWins_OneCarWinsMultiple(t *testing.T) {
 
 	// Create a category group with max_wins_per_car = 1
 	maxWins := 1
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design related categories", nil, &maxWins, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design related categories", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create categories in the group
-	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil)
-	cat3ID, _ := repo.CreateCategory(ctx, "Best Paint", 3, &groupIDInt, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil, nil)
+	cat3ID, _ := repo.CreateCategory(ctx, "Best Paint", 3, &groupIDInt, nil, nil, nil)
 
 	// Create cars
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Super Car", "")
@@ -1531,12 +2098,12 @@ func TestResultsService_DetectMultipleWins_WithOverrides(t *testing.T) {
 
 	// Create a category group with max_wins_per_car = 1
 	maxWins := 1
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design related categories", nil, &maxWins, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design related categories", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create categories in the group
-	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil, nil)
 
 	// Create cars
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Super Car", "")
@@ -1595,7 +2162,7 @@ func TestResultsService_SetManualWinner_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1651,12 +2218,13 @@ func TestResultsService_SetManualWinner_NonExistentCar(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category but no car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	// Try to set winner with non-existent car
 	err := svc.SetManualWinner(ctx, int(catID), 9999, "Test reason")
-	if err == nil {
-		t.Error("expected error for non-existent car, got nil")
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrNotFound {
+		t.Errorf("expected not found error for non-existent car, got %v", err)
 	}
 }
 
@@ -1668,7 +2236,7 @@ func TestResultsService_SetManualWinner_EmptyReason(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1694,7 +2262,7 @@ func TestResultsService_ClearManualWinner_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1726,7 +2294,7 @@ func TestResultsService_GetFinalWinners_WithOverride(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and cars
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
 	cars, _ := repo.ListCars(ctx)
@@ -1778,7 +2346,7 @@ func TestResultsService_GetFinalWinners_WithoutOverride(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and cars
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
 	cars, _ := repo.ListCars(ctx)
@@ -1826,7 +2394,7 @@ func TestResultsService_GetResults_IncludesOverrideInfo(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 	carID := cars[0].ID
@@ -1870,7 +2438,7 @@ func TestResultsService_DetectTies_ThreeCars(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	// Create 3 cars
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -1908,7 +2476,7 @@ func TestResultsService_DetectTies_ZeroVotes(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category but no votes
-	repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 
 	ties, err := svc.DetectTies(ctx)
 	if err != nil {
@@ -1929,7 +2497,7 @@ func TestResultsService_DetectTies_OnlyOneCar(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category and single car
-	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
 	cars, _ := repo.ListCars(ctx)
 
@@ -1959,14 +2527,14 @@ func TestResultsService_DetectMultipleWins_DifferentGroups(t *testing.T) {
 
 	// Create two different groups with max_wins_per_car = 1
 	maxWins := 1
-	group1ID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, &maxWins, 1)
-	group2ID, _ := repo.CreateCategoryGroup(ctx, "Speed Awards", "Speed", nil, &maxWins, 2)
+	group1ID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, &maxWins, "", 1)
+	group2ID, _ := repo.CreateCategoryGroup(ctx, "Speed Awards", "Speed", nil, &maxWins, "", 2)
 	group1IDInt := int(group1ID)
 	group2IDInt := int(group2ID)
 
 	// Create categories in different groups
-	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &group1IDInt, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Fastest Looking", 2, &group2IDInt, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &group1IDInt, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Fastest Looking", 2, &group2IDInt, nil, nil, nil)
 
 	// Create car
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -1997,13 +2565,13 @@ func TestResultsService_DetectMultipleWins_GroupWithoutLimit(t *testing.T) {
 	ctx := context.Background()
 
 	// Create group WITHOUT max_wins_per_car
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, nil, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, nil, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create multiple categories in the group
-	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil)
-	cat3ID, _ := repo.CreateCategory(ctx, "Best Paint", 3, &groupIDInt, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil, nil)
+	cat3ID, _ := repo.CreateCategory(ctx, "Best Paint", 3, &groupIDInt, nil, nil, nil)
 
 	// Create car
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -2037,12 +2605,12 @@ func TestResultsService_DetectMultipleWins_MaxTwoWinsTwo(t *testing.T) {
 
 	// Create group with max_wins_per_car = 2
 	maxWins := 2
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, &maxWins, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create 2 categories in the group
-	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil, nil)
 
 	// Create car
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -2074,13 +2642,13 @@ func TestResultsService_DetectMultipleWins_MaxTwoWinsThree(t *testing.T) {
 
 	// Create group with max_wins_per_car = 2
 	maxWins := 2
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, &maxWins, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create 3 categories in the group
-	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil)
-	cat3ID, _ := repo.CreateCategory(ctx, "Best Paint", 3, &groupIDInt, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil, nil)
+	cat3ID, _ := repo.CreateCategory(ctx, "Best Paint", 3, &groupIDInt, nil, nil, nil)
 
 	// Create car
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -2118,14 +2686,14 @@ func TestResultsService_DetectMultipleWins_MultipleCarsViolating(t *testing.T) {
 
 	// Create group with max_wins_per_car = 1
 	maxWins := 1
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, &maxWins, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create 4 categories in the group
-	cat1ID, _ := repo.CreateCategory(ctx, "Category 1", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Category 2", 2, &groupIDInt, nil, nil)
-	cat3ID, _ := repo.CreateCategory(ctx, "Category 3", 3, &groupIDInt, nil, nil)
-	cat4ID, _ := repo.CreateCategory(ctx, "Category 4", 4, &groupIDInt, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Category 1", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Category 2", 2, &groupIDInt, nil, nil, nil)
+	cat3ID, _ := repo.CreateCategory(ctx, "Category 3", 3, &groupIDInt, nil, nil, nil)
+	cat4ID, _ := repo.CreateCategory(ctx, "Category 4", 4, &groupIDInt, nil, nil, nil)
 
 	// Create 2 cars
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -2163,12 +2731,12 @@ func TestResultsService_DetectMultipleWins_ReturnsGroupInfo(t *testing.T) {
 
 	// Create group with max_wins_per_car = 1
 	maxWins := 1
-	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design categories", nil, &maxWins, 1)
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "Design categories", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create 2 categories in the group
-	cat1ID, _ := repo.CreateCategory(ctx, "Category 1", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := repo.CreateCategory(ctx, "Category 2", 2, &groupIDInt, nil, nil)
+	cat1ID, _ := repo.CreateCategory(ctx, "Category 1", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Category 2", 2, &groupIDInt, nil, nil, nil)
 
 	// Create car
 	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -2275,7 +2843,7 @@ func TestResultsService_SetManualWinner_ListCategoriesError(t *testing.T) {
 	}
 }
 
-func TestResultsService_SetManualWinner_ListCarsError(t *testing.T) {
+func TestResultsService_SetManualWinner_GetCarError(t *testing.T) {
 	realRepo := testutil.NewTestRepository(t)
 	mockRepo := mock.NewRepository(realRepo)
 	log := logger.New()
@@ -2284,14 +2852,14 @@ func TestResultsService_SetManualWinner_ListCarsError(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category first (without error)
-	catID, _ := realRepo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, _ := realRepo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 
-	// Now inject error for ListCars
-	mockRepo.ListCarsError = errors.New("database error")
+	// Now inject error for GetCar
+	mockRepo.GetCarError = errors.New("database error")
 
 	err := svc.SetManualWinner(ctx, int(catID), 1, "test reason")
 	if err == nil {
-		t.Error("expected error from ListCars, got nil")
+		t.Error("expected error from GetCar, got nil")
 	}
 }
 
@@ -2320,7 +2888,7 @@ func TestResultsService_GetFinalWinners_GetResultsError(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a category first (without error)
-	_, _ = realRepo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	_, _ = realRepo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 
 	// Now inject error for GetVoteResultsWithCars
 	mockRepo.GetVoteResultsWithCarsError = errors.New("database error")
@@ -2340,11 +2908,11 @@ func TestResultsService_DetectMultipleWins_GetCarError(t *testing.T) {
 
 	// Create group with max_wins_per_car = 1
 	maxWins := 1
-	groupID, _ := realRepo.CreateCategoryGroup(ctx, "Test Group", "Test", nil, &maxWins, 1)
+	groupID, _ := realRepo.CreateCategoryGroup(ctx, "Test Group", "Test", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create category in the group
-	cat1ID, _ := realRepo.CreateCategory(ctx, "Category 1", 1, &groupIDInt, nil, nil)
+	cat1ID, _ := realRepo.CreateCategory(ctx, "Category 1", 1, &groupIDInt, nil, nil, nil)
 
 	// Create car and set override to a non-existent car
 	_ = realRepo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -2373,7 +2941,7 @@ func TestResultsService_DetectTies_AllVotesZero(t *testing.T) {
 	ctx := context.Background()
 
 	// Create category
-	cat1ID, _ := realRepo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	cat1ID, _ := realRepo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 
 	// Create two cars but don't cast any votes (votes will be 0)
 	_ = realRepo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -2418,12 +2986,12 @@ func TestResultsService_DetectMultipleWins_OverrideWinnerInVotes(t *testing.T) {
 
 	// Create group with max_wins_per_car = 1
 	maxWins := 1
-	groupID, _ := realRepo.CreateCategoryGroup(ctx, "Test Group", "Test", nil, &maxWins, 1)
+	groupID, _ := realRepo.CreateCategoryGroup(ctx, "Test Group", "Test", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create 2 categories in the group
-	cat1ID, _ := realRepo.CreateCategory(ctx, "Category 1", 1, &groupIDInt, nil, nil)
-	cat2ID, _ := realRepo.CreateCategory(ctx, "Category 2", 2, &groupIDInt, nil, nil)
+	cat1ID, _ := realRepo.CreateCategory(ctx, "Category 1", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := realRepo.CreateCategory(ctx, "Category 2", 2, &groupIDInt, nil, nil, nil)
 
 	// Create two cars
 	_ = realRepo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -2473,11 +3041,11 @@ func TestResultsService_DetectMultipleWins_GetCarErrorWithMock(t *testing.T) {
 
 	// Create group with max_wins_per_car = 1
 	maxWins := 1
-	groupID, _ := realRepo.CreateCategoryGroup(ctx, "Test Group", "Test", nil, &maxWins, 1)
+	groupID, _ := realRepo.CreateCategoryGroup(ctx, "Test Group", "Test", nil, &maxWins, "", 1)
 	groupIDInt := int(groupID)
 
 	// Create category in the group
-	cat1ID, _ := realRepo.CreateCategory(ctx, "Category 1", 1, &groupIDInt, nil, nil)
+	cat1ID, _ := realRepo.CreateCategory(ctx, "Category 1", 1, &groupIDInt, nil, nil, nil)
 
 	// Create two cars
 	_ = realRepo.CreateCar(ctx, "101", "Racer One", "Car A", "")
@@ -2502,3 +3070,903 @@ func TestResultsService_DetectMultipleWins_GetCarErrorWithMock(t *testing.T) {
 	}
 }
 
+
+// setupAutoRunnerUpGroup creates a max-1 group where car 101 wins both categories
+// (3 votes in Best Design, 2 in Most Creative) and car 102 is runner-up in Most Creative
+func setupAutoRunnerUpGroup(t *testing.T, ctx context.Context, repo interface {
+	CreateCategoryGroup(ctx context.Context, name, description string, exclusivityPoolID *int, maxWinsPerCar *int, multiWinStrategy string, displayOrder int) (int64, error)
+	CreateCategory(ctx context.Context, name string, displayOrder int, groupID *int, allowedVoterTypes []string, allowedRanks []string, tags []string) (int64, error)
+	CreateCar(ctx context.Context, carNumber, racerName, carName, photoURL string) error
+	CreateVoter(ctx context.Context, qrCode string) (int, error)
+	SaveVote(ctx context.Context, voterID, categoryID, carID int) error
+}, strategy string) (cat1ID, cat2ID int) {
+	t.Helper()
+
+	maxWins := 1
+	groupID, err := repo.CreateCategoryGroup(ctx, "Design Awards", "Design", nil, &maxWins, strategy, 1)
+	if err != nil {
+		t.Fatalf("CreateCategoryGroup failed: %v", err)
+	}
+	groupIDInt := int(groupID)
+
+	c1, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil, nil)
+	c2, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Super Car", "")
+	_ = repo.CreateCar(ctx, "102", "Racer Two", "Other Car", "")
+
+	votes := []struct {
+		qr    string
+		catID int64
+		carID int
+	}{
+		{"V1", c1, 1}, {"V2", c1, 1}, {"V3", c1, 1},
+		{"V1", c2, 1}, {"V2", c2, 1}, {"V3", c2, 2},
+	}
+	voters := make(map[string]int)
+	for _, v := range votes {
+		if _, ok := voters[v.qr]; !ok {
+			voters[v.qr], _ = repo.CreateVoter(ctx, v.qr)
+		}
+		if err := repo.SaveVote(ctx, voters[v.qr], int(v.catID), v.carID); err != nil {
+			t.Fatalf("SaveVote failed: %v", err)
+		}
+	}
+	return int(c1), int(c2)
+}
+
+func TestResultsService_DetectMultipleWins_AutoRunnerUpNotFlagged(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	setupAutoRunnerUpGroup(t, ctx, repo, "auto_runner_up")
+
+	multiWins, err := svc.DetectMultipleWins(ctx)
+	if err != nil {
+		t.Fatalf("DetectMultipleWins failed: %v", err)
+	}
+	if len(multiWins) != 0 {
+		t.Errorf("expected no conflicts for auto_runner_up group, got %d", len(multiWins))
+	}
+}
+
+func TestResultsService_ResolutionStatus(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	maxWins := 1
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "", nil, &maxWins, "", 1)
+	group := int(groupID)
+	designID, _ := repo.CreateCategory(ctx, "Best Design", 1, &group, nil, nil, nil)
+	creativeID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &group, nil, nil, nil)
+	speedID, _ := repo.CreateCategory(ctx, "Fastest Looking", 3, nil, nil, nil, nil)
+	paintID, _ := repo.CreateCategory(ctx, "Best Paint", 4, nil, nil, nil, nil)
+	cleanID, _ := repo.CreateCategory(ctx, "Best Wheels", 5, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	cars, _ := repo.ListCars(ctx)
+	v1, _ := repo.CreateVoter(ctx, "V1")
+	v2, _ := repo.CreateVoter(ctx, "V2")
+
+	// Car A wins both grouped categories, over the limit of one
+	_ = repo.SaveVote(ctx, v1, int(designID), cars[0].ID)
+	_ = repo.SaveVote(ctx, v1, int(creativeID), cars[0].ID)
+	// Two ties, one resolved by an override
+	_ = repo.SaveVote(ctx, v1, int(speedID), cars[0].ID)
+	_ = repo.SaveVote(ctx, v2, int(speedID), cars[1].ID)
+	_ = repo.SaveVote(ctx, v1, int(paintID), cars[0].ID)
+	_ = repo.SaveVote(ctx, v2, int(paintID), cars[1].ID)
+	_ = repo.SetManualWinner(ctx, int(paintID), cars[1].ID, "judges' pick")
+	_ = repo.SaveVote(ctx, v1, int(cleanID), cars[1].ID)
+
+	status, err := svc.ResolutionStatus(ctx)
+	if err != nil {
+		t.Fatalf("ResolutionStatus failed: %v", err)
+	}
+	if status.Ready {
+		t.Error("expected not ready with unresolved conflicts")
+	}
+
+	byID := make(map[int]services.CategoryResolution)
+	for _, c := range status.Categories {
+		byID[c.CategoryID] = c
+	}
+	expected := map[int64]string{
+		designID:   services.ResolutionMultiWinUnresolved,
+		creativeID: services.ResolutionMultiWinUnresolved,
+		speedID:    services.ResolutionTieUnresolved,
+		paintID:    services.ResolutionTieResolved,
+		cleanID:    services.ResolutionClean,
+	}
+	for id, want := range expected {
+		got := byID[int(id)]
+		if got.Status != want {
+			t.Errorf("category %d: expected %s, got %s", id, want, got.Status)
+		}
+		if blocking := want == services.ResolutionMultiWinUnresolved || want == services.ResolutionTieUnresolved; got.Blocking != blocking {
+			t.Errorf("category %d: expected blocking=%v", id, blocking)
+		}
+	}
+	if mw := byID[int(designID)].MultiWinCarID; mw == nil || *mw != cars[0].ID {
+		t.Errorf("expected multi-win car %d, got %v", cars[0].ID, mw)
+	}
+	paint := byID[int(paintID)]
+	if paint.OverrideCarID == nil || *paint.OverrideCarID != cars[1].ID || paint.OverrideReason != "judges' pick" {
+		t.Errorf("expected the override on Best Paint, got %+v", paint)
+	}
+
+	// Overriding the tie and one multi-win clears every blocker
+	_ = repo.SetManualWinner(ctx, int(speedID), cars[0].ID, "")
+	_ = repo.SetManualWinner(ctx, int(creativeID), cars[1].ID, "")
+	svc.InvalidateCache()
+	if status, _ = svc.ResolutionStatus(ctx); !status.Ready {
+		t.Errorf("expected ready once conflicts are resolved, got %+v", status.Categories)
+	}
+}
+
+func TestResultsService_ResolveMultiWins_PromotesRunnerUp(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	cat1ID, cat2ID := setupAutoRunnerUpGroup(t, ctx, repo, "auto_runner_up")
+
+	resolved, err := svc.ResolveMultiWins(ctx)
+	if err != nil {
+		t.Fatalf("ResolveMultiWins failed: %v", err)
+	}
+	if len(resolved) != 1 {
+		t.Fatalf("expected 1 reassigned win, got %d", len(resolved))
+	}
+	if _, ok := resolved[cat1ID]; ok {
+		t.Error("expected highest-vote win (Best Design) to be kept")
+	}
+	rw, ok := resolved[cat2ID]
+	if !ok {
+		t.Fatal("expected Most Creative to be reassigned")
+	}
+	if rw.OriginalCarID != 1 || rw.CarID != 2 {
+		t.Errorf("expected car 1 -> car 2, got car %d -> car %d", rw.OriginalCarID, rw.CarID)
+	}
+	if rw.CarNumber != "102" || rw.VoteCount != 1 {
+		t.Errorf("expected runner-up #102 with 1 vote, got #%s with %d", rw.CarNumber, rw.VoteCount)
+	}
+	if rw.GroupName != "Design Awards" {
+		t.Errorf("expected group name 'Design Awards', got %q", rw.GroupName)
+	}
+}
+
+func TestResultsService_ResolveMultiWins_ManualGroupUnchanged(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	setupAutoRunnerUpGroup(t, ctx, repo, "manual")
+
+	resolved, err := svc.ResolveMultiWins(ctx)
+	if err != nil {
+		t.Fatalf("ResolveMultiWins failed: %v", err)
+	}
+	if len(resolved) != 0 {
+		t.Errorf("expected no reassignment for manual group, got %d", len(resolved))
+	}
+}
+
+func TestResultsService_ResolveMultiWins_NoRunnerUp(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	maxWins := 1
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "", nil, &maxWins, "auto_runner_up", 1)
+	groupIDInt := int(groupID)
+	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, &groupIDInt, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, &groupIDInt, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Super Car", "")
+	v1, _ := repo.CreateVoter(ctx, "V1")
+	_ = repo.SaveVote(ctx, v1, int(cat1ID), 1)
+	_ = repo.SaveVote(ctx, v1, int(cat2ID), 1)
+
+	resolved, err := svc.ResolveMultiWins(ctx)
+	if err != nil {
+		t.Fatalf("ResolveMultiWins failed: %v", err)
+	}
+	// With no other candidates, the car keeps its wins rather than leaving a category empty
+	if len(resolved) != 0 {
+		t.Errorf("expected no reassignment without a runner-up, got %d", len(resolved))
+	}
+}
+
+func TestResultsService_ResolveMultiWins_Deterministic(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	maxWins := 1
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "", nil, &maxWins, "auto_runner_up", 1)
+	groupIDInt := int(groupID)
+	var catIDs []int
+	for i, name := range []string{"Best Design", "Most Creative", "Best Paint", "Coolest"} {
+		id, _ := repo.CreateCategory(ctx, name, i+1, &groupIDInt, nil, nil, nil)
+		catIDs = append(catIDs, int(id))
+	}
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	_ = repo.CreateCar(ctx, "103", "Racer Three", "Car C", "")
+	var voters []int
+	for _, qr := range []string{"V1", "V2", "V3", "V4"} {
+		id, _ := repo.CreateVoter(ctx, qr)
+		voters = append(voters, id)
+	}
+
+	// Cars 1 and 2 each win two categories; car 3 is runner-up everywhere but
+	// can only take one, so the outcome depends on which car is demoted first
+	for i, carID := range []int{1, 1, 2, 2} {
+		winnerVotes := 3 - i%2
+		for v := 0; v < winnerVotes; v++ {
+			_ = repo.SaveVote(ctx, voters[v], catIDs[i], carID)
+		}
+		_ = repo.SaveVote(ctx, voters[3], catIDs[i], 3)
+	}
+
+	for run := 0; run < 20; run++ {
+		resolved, err := svc.ResolveMultiWins(ctx)
+		if err != nil {
+			t.Fatalf("ResolveMultiWins failed: %v", err)
+		}
+		if len(resolved) != 1 {
+			t.Fatalf("run %d: expected 1 reassigned win, got %d", run, len(resolved))
+		}
+		rw, ok := resolved[catIDs[1]]
+		if !ok || rw.OriginalCarID != 1 || rw.CarID != 3 {
+			t.Fatalf("run %d: expected Most Creative to go from car 1 to car 3, got %+v", run, resolved)
+		}
+	}
+}
+
+func TestResultsService_ResolveMultiWins_ListCategoryGroupsError(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	mockRepo.ListCategoryGroupsError = errors.New("database error")
+	log := logger.New()
+	svc := services.NewResultsService(log, mockRepo, services.NewSettingsService(log, mockRepo), derbynet.NewMockClient())
+
+	_, err := svc.ResolveMultiWins(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestResultsService_PushResultsToDerbyNet_AutoRunnerUp(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	mockClient := derbynet.NewMockClient()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), mockClient)
+	ctx := context.Background()
+
+	// Link categories and cars to DerbyNet before building the group
+	award1, award2 := 10, 20
+	_, _ = repo.UpsertCategory(ctx, "Best Design", 1, &award1)
+	_, _ = repo.UpsertCategory(ctx, "Most Creative", 2, &award2)
+	_ = repo.UpsertCar(ctx, 100, "101", "Racer One", "Super Car", "", "")
+	_ = repo.UpsertCar(ctx, 200, "102", "Racer Two", "Other Car", "", "")
+
+	maxWins := 1
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Design Awards", "", nil, &maxWins, "auto_runner_up", 1)
+	groupIDInt := int(groupID)
+	categories, _ := repo.ListCategories(ctx)
+	for _, c := range categories {
+		_ = repo.UpdateCategory(ctx, c.ID, c.Name, c.DisplayOrder, &groupIDInt, nil, nil, true, nil)
+	}
+	cat1ID, cat2ID := categories[0].ID, categories[1].ID
+
+	v1, _ := repo.CreateVoter(ctx, "V1")
+	v2, _ := repo.CreateVoter(ctx, "V2")
+	v3, _ := repo.CreateVoter(ctx, "V3")
+	_ = repo.SaveVote(ctx, v1, cat1ID, 1)
+	_ = repo.SaveVote(ctx, v2, cat1ID, 1)
+	_ = repo.SaveVote(ctx, v3, cat1ID, 1)
+	_ = repo.SaveVote(ctx, v1, cat2ID, 1)
+	_ = repo.SaveVote(ctx, v2, cat2ID, 1)
+	_ = repo.SaveVote(ctx, v3, cat2ID, 2)
+
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
+	if err != nil {
+		t.Fatalf("PushResultsToDerbyNet failed: %v", err)
+	}
+	if result.WinnersPushed != 2 {
+		t.Fatalf("expected 2 winners pushed, got %d", result.WinnersPushed)
+	}
+
+	awardWinners := mockClient.GetAwardWinners()
+	if awardWinners[award1] != 100 {
+		t.Errorf("expected award %d -> racer 100, got %d", award1, awardWinners[award1])
+	}
+	if awardWinners[award2] != 200 {
+		t.Errorf("expected award %d -> runner-up racer 200, got %d", award2, awardWinners[award2])
+	}
+
+	var reassigned int
+	for _, d := range result.Details {
+		if d.Reassigned {
+			reassigned++
+			if d.CategoryName != "Most Creative" {
+				t.Errorf("expected Most Creative to be reassigned, got %q", d.CategoryName)
+			}
+			if d.OriginalCarID == nil || *d.OriginalCarID != 1 {
+				t.Errorf("expected original car 1, got %v", d.OriginalCarID)
+			}
+		}
+	}
+	if reassigned != 1 {
+		t.Errorf("expected 1 reassigned detail, got %d", reassigned)
+	}
+}
+
+func TestResultsService_PushResultsToDerbyNet_ResolveMultiWinsError(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	log := logger.New()
+	svc := services.NewResultsService(log, mockRepo, services.NewSettingsService(log, mockRepo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	awardID := 10
+	_, _ = realRepo.UpsertCategory(ctx, "Best Design", 1, &awardID)
+	_ = realRepo.UpsertCar(ctx, 100, "101", "Racer", "Car", "", "")
+	categories, _ := realRepo.ListCategories(ctx)
+	voter, _ := realRepo.CreateVoter(ctx, "V1")
+	_ = realRepo.SaveVote(ctx, voter, categories[0].ID, 1)
+
+	mockRepo.ListCategoryGroupsError = errors.New("database error")
+
+	result, err := svc.PushResultsToDerbyNet(ctx, "http://derbynet.local", false)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Status != "error" {
+		t.Errorf("expected status 'error', got %q", result.Status)
+	}
+}
+
+func TestResultsService_GetResults_CachesUntilVotesChange(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, mockRepo)
+	svc := services.NewResultsService(log, mockRepo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	categoryIDs, carIDs := setupTestData(t, ctx, realRepo, true)
+	if _, err := svc.GetResults(ctx); err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+
+	// While nothing changes, results come from the cache and skip the query
+	mockRepo.GetVoteResultsWithCarsError = errors.New("database error")
+	if _, err := svc.GetResults(ctx); err != nil {
+		t.Fatalf("expected cached results, got %v", err)
+	}
+
+	// Saving a vote invalidates the cache
+	voterID, _ := realRepo.GetVoterByQR(ctx, "voter-qr-001")
+	realRepo.SaveVote(ctx, voterID, categoryIDs[0], carIDs[2])
+	if _, err := svc.GetResults(ctx); err == nil {
+		t.Fatal("expected query after vote change, got cached results")
+	}
+
+	// Fresh results reflect the new vote
+	mockRepo.GetVoteResultsWithCarsError = nil
+	results, err := svc.GetResults(ctx)
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	if results.Categories[0].TotalVotes != 5 || results.Categories[0].Votes[0].VoteCount != 2 {
+		t.Errorf("expected refreshed results for category 1, got %+v", results.Categories[0])
+	}
+}
+
+func TestResultsService_GetResults_OverrideInvalidatesCache(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	log := logger.New()
+	svc := services.NewResultsService(log, mockRepo, services.NewSettingsService(log, mockRepo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	categoryIDs, carIDs := setupTestData(t, ctx, realRepo, true)
+	svc.GetResults(ctx)
+
+	mockRepo.GetVoteResultsWithCarsError = errors.New("database error")
+	if err := svc.SetManualWinner(ctx, categoryIDs[0], carIDs[1], "Judges decision"); err != nil {
+		t.Fatalf("SetManualWinner failed: %v", err)
+	}
+	if _, err := svc.GetResults(ctx); err == nil {
+		t.Fatal("expected override to invalidate cached results")
+	}
+}
+
+func TestResultsService_InvalidateCache(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	log := logger.New()
+	svc := services.NewResultsService(log, mockRepo, services.NewSettingsService(log, mockRepo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	setupTestData(t, ctx, realRepo, true)
+	svc.GetResults(ctx)
+
+	mockRepo.GetVoteResultsWithCarsError = errors.New("database error")
+	svc.InvalidateCache()
+	if _, err := svc.GetResults(ctx); err == nil {
+		t.Fatal("expected forced refresh to query the repository")
+	}
+}
+
+func TestResultsService_GetResults_ConcurrentAccess(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+	ctx := context.Background()
+
+	categoryIDs, carIDs := setupTestData(t, ctx, repo, true)
+	voterID, _ := repo.GetVoterByQR(ctx, "voter-qr-005")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if i == 0 {
+					repo.SaveVote(ctx, voterID, categoryIDs[2], carIDs[j%len(carIDs)])
+				}
+				if i == 1 && j%5 == 0 {
+					svc.InvalidateCache()
+				}
+				if _, err := svc.GetResults(ctx); err != nil {
+					t.Errorf("GetResults failed: %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// After all writers finish, results must match the database
+	results, _ := svc.GetResults(ctx)
+	if results.Categories[2].TotalVotes != 4 {
+		t.Errorf("expected 4 votes in category 3 after concurrent updates, got %d", results.Categories[2].TotalVotes)
+	}
+}
+
+// setupBenchmarkResults creates a results service backed by a populated database
+func setupBenchmarkResults(b *testing.B) *services.ResultsService {
+	b.Helper()
+	repo, err := repository.New(":memory:")
+	if err != nil {
+		b.Fatalf("failed to create repository: %v", err)
+	}
+	ctx := context.Background()
+
+	for i := 1; i <= 10; i++ {
+		repo.CreateCategory(ctx, fmt.Sprintf("Category %d", i), i, nil, nil, nil, nil)
+	}
+	for i := 1; i <= 50; i++ {
+		repo.CreateCar(ctx, fmt.Sprintf("%d", 100+i), fmt.Sprintf("Racer %d", i), "", "")
+	}
+	for v := 1; v <= 200; v++ {
+		voterID, _ := repo.CreateVoter(ctx, fmt.Sprintf("BENCH-%03d", v))
+		for c := 1; c <= 10; c++ {
+			repo.SaveVote(ctx, voterID, c, (v*c)%50+1)
+		}
+	}
+
+	log := logger.New()
+	return services.NewResultsService(log, repo, services.NewSettingsService(log, repo), derbynet.NewMockClient())
+}
+
+func BenchmarkGetResults_Cached(b *testing.B) {
+	svc := setupBenchmarkResults(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := svc.GetResults(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetResults_Uncached(b *testing.B) {
+	svc := setupBenchmarkResults(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			svc.InvalidateCache()
+			if _, err := svc.GetResults(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestResultsService_FinalizeVoting_FreezesResults(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	_, _ = setupTestData(t, ctx, repo, true)
+	_ = settingsSvc.OpenVoting(ctx)
+
+	if snapshot, err := svc.GetResultsSnapshot(ctx); err != nil || snapshot != nil {
+		t.Fatalf("expected no snapshot before finalizing, got %v, %v", snapshot, err)
+	}
+
+	result, err := svc.FinalizeVoting(ctx)
+	if err != nil {
+		t.Fatalf("FinalizeVoting failed: %v", err)
+	}
+	if result.Snapshot == nil {
+		t.Fatalf("expected results to be frozen, got conflicts %+v %+v", result.Ties, result.MultiWins)
+	}
+	if open, _ := settingsSvc.IsVotingOpen(ctx); open {
+		t.Error("expected voting to be closed")
+	}
+
+	stored, err := svc.GetResultsSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("GetResultsSnapshot failed: %v", err)
+	}
+	if stored == nil || stored.FrozenAt != result.Snapshot.FrozenAt || len(stored.Categories) != len(result.Snapshot.Categories) {
+		t.Errorf("expected stored snapshot to match %+v, got %+v", result.Snapshot, stored)
+	}
+}
+
+func TestResultsService_FinalizeVoting_ConflictsBlockFreeze(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	v1, _ := repo.CreateVoter(ctx, "V1")
+	v2, _ := repo.CreateVoter(ctx, "V2")
+	repo.SaveVote(ctx, v1, int(catID), 1)
+	repo.SaveVote(ctx, v2, int(catID), 2)
+	_ = settingsSvc.OpenVoting(ctx)
+
+	result, err := svc.FinalizeVoting(ctx)
+	if err != nil {
+		t.Fatalf("FinalizeVoting failed: %v", err)
+	}
+	if result.Snapshot != nil || len(result.Ties) != 1 {
+		t.Errorf("expected one tie and no snapshot, got %+v", result)
+	}
+	if open, _ := settingsSvc.IsVotingOpen(ctx); open {
+		t.Error("expected voting to stay closed")
+	}
+	if snapshot, _ := svc.GetResultsSnapshot(ctx); snapshot != nil {
+		t.Error("expected no snapshot to be stored")
+	}
+}
+
+func TestResultsService_EmbargoResults(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	_, _ = setupTestData(t, ctx, repo, true)
+
+	var appErr *apperrors.Error
+	if err := svc.EmbargoResults(ctx, "curtain"); !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrConflict {
+		t.Errorf("expected conflict embargoing unfrozen results, got %v", err)
+	}
+	if _, err := svc.FinalizeVoting(ctx); err != nil {
+		t.Fatalf("FinalizeVoting failed: %v", err)
+	}
+	if err := svc.EmbargoResults(ctx, "abc"); !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrInvalidFields {
+		t.Errorf("expected invalid fields for a short reveal code, got %v", err)
+	}
+	if err := svc.CheckReveal(ctx, ""); err != nil {
+		t.Errorf("expected results visible before the embargo, got %v", err)
+	}
+
+	if err := svc.EmbargoResults(ctx, "curtain"); err != nil {
+		t.Fatalf("EmbargoResults failed: %v", err)
+	}
+	if embargoed, _ := svc.ResultsEmbargoed(ctx); !embargoed {
+		t.Error("expected results to be embargoed")
+	}
+	for _, code := range []string{"", "wrong"} {
+		if err := svc.CheckReveal(ctx, code); err != services.ErrResultsEmbargoed {
+			t.Errorf("expected ErrResultsEmbargoed for code %q, got %v", code, err)
+		}
+	}
+	if err := svc.CheckReveal(ctx, "curtain"); err != nil {
+		t.Errorf("expected the reveal code to show results, got %v", err)
+	}
+
+	stored, _ := repo.GetSetting(ctx, "results_reveal_code")
+	if strings.Contains(stored, "curtain") || !strings.Contains(stored, ":") {
+		t.Errorf("expected a salted hash of the reveal code, got %q", stored)
+	}
+
+	if err := svc.RevealResults(ctx, "wrong"); err != services.ErrResultsEmbargoed {
+		t.Errorf("expected a wrong code to leave the embargo, got %v", err)
+	}
+	if embargoed, _ := svc.ResultsEmbargoed(ctx); !embargoed {
+		t.Fatal("expected results to stay embargoed without the reveal code")
+	}
+	if err := svc.RevealResults(ctx, "curtain"); err != nil {
+		t.Fatalf("RevealResults failed: %v", err)
+	}
+	if err := svc.CheckReveal(ctx, ""); err != nil {
+		t.Errorf("expected results visible once revealed, got %v", err)
+	}
+	if err := svc.RevealResults(ctx, ""); err != nil {
+		t.Errorf("expected revealing twice to succeed, got %v", err)
+	}
+}
+
+func TestResultsService_EmbargoClearedByNewEvent(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	_, _ = setupTestData(t, ctx, repo, true)
+	if _, err := svc.FinalizeVoting(ctx); err != nil {
+		t.Fatalf("FinalizeVoting failed: %v", err)
+	}
+	if err := svc.EmbargoResults(ctx, "curtain"); err != nil {
+		t.Fatalf("EmbargoResults failed: %v", err)
+	}
+
+	if _, err := settingsSvc.StartNewEvent(ctx); err != nil {
+		t.Fatalf("StartNewEvent failed: %v", err)
+	}
+	if embargoed, _ := svc.ResultsEmbargoed(ctx); embargoed {
+		t.Error("expected a new event to clear the embargo")
+	}
+}
+
+func TestResultsService_FinalizedResultsClearedWhenVotesCanChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, settingsSvc *services.SettingsService) error
+	}{
+		{"open voting", func(ctx context.Context, settingsSvc *services.SettingsService) error {
+			return settingsSvc.OpenVoting(ctx)
+		}},
+		{"voting timer", func(ctx context.Context, settingsSvc *services.SettingsService) error {
+			_, err := settingsSvc.StartVotingTimer(ctx, 5)
+			return err
+		}},
+		{"reset votes", func(ctx context.Context, settingsSvc *services.SettingsService) error {
+			_, err := settingsSvc.ResetTables(ctx, []string{"votes"})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := testutil.NewTestRepository(t)
+			log := logger.New()
+			settingsSvc := services.NewSettingsService(log, repo)
+			svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+			ctx := context.Background()
+
+			_, _ = setupTestData(t, ctx, repo, true)
+			if _, err := svc.FinalizeVoting(ctx); err != nil {
+				t.Fatalf("FinalizeVoting failed: %v", err)
+			}
+			if err := svc.EmbargoResults(ctx, "curtain"); err != nil {
+				t.Fatalf("EmbargoResults failed: %v", err)
+			}
+
+			if err := tt.change(ctx, settingsSvc); err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+			if snapshot, _ := svc.GetResultsSnapshot(ctx); snapshot != nil {
+				t.Error("expected the frozen results to be discarded")
+			}
+			if embargoed, _ := svc.ResultsEmbargoed(ctx); embargoed {
+				t.Error("expected the embargo to be lifted")
+			}
+		})
+	}
+}
+
+func TestResultsService_GetPublicResults(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	designID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	speedID, _ := repo.CreateCategory(ctx, "Fastest Look", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Car A", "")
+	_ = repo.CreateCar(ctx, "102", "Racer Two", "Car B", "")
+	v1, _ := repo.CreateVoter(ctx, "V1")
+	v2, _ := repo.CreateVoter(ctx, "V2")
+	repo.SaveVote(ctx, v1, int(designID), 1)
+	repo.SaveVote(ctx, v2, int(designID), 1)
+	repo.SaveVote(ctx, v1, int(speedID), 1)
+	repo.SaveVote(ctx, v2, int(speedID), 2)
+
+	assertForbidden := func(when string) {
+		t.Helper()
+		_, err := svc.GetPublicResults(ctx)
+		var appErr *apperrors.Error
+		if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrForbidden {
+			t.Errorf("%s: expected forbidden error, got %v", when, err)
+		}
+	}
+
+	_ = settingsSvc.OpenVoting(ctx)
+	assertForbidden("disabled")
+
+	_ = settingsSvc.SetPublicResultsEnabled(ctx, true)
+	assertForbidden("voting open")
+
+	_ = settingsSvc.CloseVoting(ctx)
+	results, err := svc.GetPublicResults(ctx)
+	if err != nil {
+		t.Fatalf("GetPublicResults failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 categories, got %d", len(results))
+	}
+	if w := results[0].Winner; w == nil || w.CarNumber != "101" || w.VoteCount != 2 || results[0].TotalVotes != 2 {
+		t.Errorf("expected car 101 to win Best Design with 2 votes, got %+v", results[0])
+	}
+	if results[1].Winner != nil {
+		t.Errorf("expected no winner for a tied category, got %+v", results[1].Winner)
+	}
+
+	// An override settles the tie
+	_ = repo.SetManualWinner(ctx, int(speedID), 2, "judges")
+	results, _ = svc.GetPublicResults(ctx)
+	if w := results[1].Winner; w == nil || w.CarNumber != "102" {
+		t.Errorf("expected override winner 102, got %+v", w)
+	}
+}
+
+func TestResultsService_GetPublicResults_AutoRunnerUp(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	setupAutoRunnerUpGroup(t, ctx, repo, "auto_runner_up")
+	_ = settingsSvc.SetPublicResultsEnabled(ctx, true)
+	_ = settingsSvc.CloseVoting(ctx)
+
+	results, err := svc.GetPublicResults(ctx)
+	if err != nil {
+		t.Fatalf("GetPublicResults failed: %v", err)
+	}
+	winners := make(map[string]string)
+	for _, r := range results {
+		if r.Winner != nil {
+			winners[r.CategoryName] = r.Winner.CarNumber
+		}
+	}
+	if winners["Best Design"] != "101" {
+		t.Errorf("expected car 101 to keep Best Design, got %q", winners["Best Design"])
+	}
+	if winners["Most Creative"] != "102" {
+		t.Errorf("expected runner-up 102 to win Most Creative, got %q", winners["Most Creative"])
+	}
+}
+
+func TestResultsService_GetPublicResults_Frozen(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	_, _ = setupTestData(t, ctx, repo, true)
+	_ = settingsSvc.SetPublicResultsEnabled(ctx, true)
+	if _, err := svc.FinalizeVoting(ctx); err != nil {
+		t.Fatalf("FinalizeVoting failed: %v", err)
+	}
+	results, err := svc.GetPublicResults(ctx)
+	if err != nil {
+		t.Fatalf("GetPublicResults failed: %v", err)
+	}
+	if len(results) == 0 {
+		t.Error("expected frozen results")
+	}
+
+	// Reopening voting hides the leaderboard again
+	_ = settingsSvc.OpenVoting(ctx)
+	_, err = svc.GetPublicResults(ctx)
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrForbidden {
+		t.Errorf("expected forbidden error while voting is open, got %v", err)
+	}
+}
+
+func TestResultsService_VerifyTallies(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	categoryIDs, _ := setupTestData(t, ctx, repo, true)
+
+	verification, err := svc.VerifyTallies(ctx)
+	if err != nil {
+		t.Fatalf("VerifyTallies failed: %v", err)
+	}
+	if !verification.Consistent || len(verification.Mismatches) != 0 {
+		t.Errorf("expected consistent tallies, got %+v", verification)
+	}
+	if verification.CategoriesChecked != 3 {
+		t.Errorf("expected 3 categories checked, got %d", verification.CategoriesChecked)
+	}
+	if verification.VotesCounted == 0 {
+		t.Error("expected counted votes")
+	}
+
+	// Complete-ballot filtering must agree between both counts
+	_ = settingsSvc.SetRequireCompleteBallot(ctx, true)
+	if verification, err = svc.VerifyTallies(ctx); err != nil || !verification.Consistent {
+		t.Errorf("expected consistent tallies with complete ballots required, got %+v, %v", verification, err)
+	}
+	_ = settingsSvc.SetRequireCompleteBallot(ctx, false)
+
+	// A vote written behind the repository's back leaves the cached primary
+	// results stale, which the recount must flag
+	if _, err := svc.GetResults(ctx); err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	voterID, err := repo.CreateVoter(ctx, "VERIFY-STALE")
+	if err != nil {
+		t.Fatalf("CreateVoter failed: %v", err)
+	}
+	if _, err := svc.GetResults(ctx); err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	if _, err := repo.DB().ExecContext(ctx, `INSERT INTO votes (voter_id, category_id, car_id) VALUES (?, ?, 3)`, voterID, categoryIDs[0]); err != nil {
+		t.Fatalf("insert vote failed: %v", err)
+	}
+
+	verification, err = svc.VerifyTallies(ctx)
+	if err != nil {
+		t.Fatalf("VerifyTallies failed: %v", err)
+	}
+	if verification.Consistent || len(verification.Mismatches) != 1 {
+		t.Fatalf("expected one mismatched category, got %+v", verification)
+	}
+	mismatch := verification.Mismatches[0]
+	if mismatch.CategoryID != categoryIDs[0] || len(mismatch.Cars) != 1 || mismatch.Cars[0].CarID != 3 {
+		t.Errorf("unexpected mismatch: %+v", mismatch)
+	}
+	if mismatch.Cars[0].Recounted != mismatch.Cars[0].Primary+1 {
+		t.Errorf("expected the recount to have one more vote, got %+v", mismatch.Cars[0])
+	}
+}
@@ -2,10 +2,19 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
 	"github.com/abrezinsky/derbyvote/internal/repository"
 )
@@ -20,6 +29,7 @@ type SettingsService struct {
 	log         logger.Logger
 	repo        repository.SettingsRepository
 	broadcaster Broadcaster
+	events      EventSink
 }
 
 // NewSettingsService creates a new SettingsService
@@ -32,6 +42,11 @@ func (s *SettingsService) SetBroadcaster(b Broadcaster) {
 	s.broadcaster = b
 }
 
+// SetEventSink sets where voting opened and closed events are recorded
+func (s *SettingsService) SetEventSink(sink EventSink) {
+	s.events = sink
+}
+
 // IsVotingOpen checks if voting is currently open
 func (s *SettingsService) IsVotingOpen(ctx context.Context) (bool, error) {
 	value, err := s.repo.GetSetting(ctx, "voting_open")
@@ -44,13 +59,35 @@ func (s *SettingsService) IsVotingOpen(ctx context.Context) (bool, error) {
 	return value == "true", nil
 }
 
-// SetVotingOpen sets the voting open status
+// SetVotingOpen sets the voting open status, recording an event when it changes
 func (s *SettingsService) SetVotingOpen(ctx context.Context, open bool) error {
+	wasOpen, err := s.IsVotingOpen(ctx)
+	if err != nil {
+		return err
+	}
+
 	value := "false"
 	if open {
 		value = "true"
 	}
-	return s.repo.SetSetting(ctx, "voting_open", value)
+	if err := s.repo.SetSetting(ctx, "voting_open", value); err != nil {
+		return err
+	}
+	// Reopening restarts the idle auto-close clock
+	if open && !wasOpen {
+		if err := s.repo.SetSetting(ctx, "voting_opened_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+
+	if open != wasOpen {
+		eventType := EventVotingClosed
+		if open {
+			eventType = EventVotingOpened
+		}
+		recordEvent(s.events, Event{Type: eventType})
+	}
+	return nil
 }
 
 // GetDerbyNetURL returns the configured DerbyNet URL
@@ -137,6 +174,210 @@ func (s *SettingsService) SetRequireRegisteredQR(ctx context.Context, require bo
 	return s.repo.SetSetting(ctx, "require_registered_qr", value)
 }
 
+// OpenVotingOnePerDevice checks if open voting ties each browser to a single generated code
+func (s *SettingsService) OpenVotingOnePerDevice(ctx context.Context) (bool, error) {
+	value, err := s.repo.GetSetting(ctx, "open_voting_one_per_device")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return false, nil // Default to false (a device may get any number of codes)
+		}
+		return false, err
+	}
+	return value == "true", nil
+}
+
+// SetOpenVotingOnePerDevice sets whether open voting allows one generated code per device
+func (s *SettingsService) SetOpenVotingOnePerDevice(ctx context.Context, enabled bool) error {
+	value := "false"
+	if enabled {
+		value = "true"
+	}
+	return s.repo.SetSetting(ctx, "open_voting_one_per_device", value)
+}
+
+// PublicResultsEnabled checks if the public results leaderboard is enabled
+func (s *SettingsService) PublicResultsEnabled(ctx context.Context) (bool, error) {
+	value, err := s.repo.GetSetting(ctx, "public_results_enabled")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return false, nil // Default to false (results stay private)
+		}
+		return false, err
+	}
+	return value == "true", nil
+}
+
+// SetPublicResultsEnabled sets whether the public results leaderboard is enabled
+func (s *SettingsService) SetPublicResultsEnabled(ctx context.Context, enabled bool) error {
+	value := "false"
+	if enabled {
+		value = "true"
+	}
+	return s.repo.SetSetting(ctx, "public_results_enabled", value)
+}
+
+// RequireCompleteBallot checks if a voter's votes only count once they have
+// voted in every active category open to their voter type
+func (s *SettingsService) RequireCompleteBallot(ctx context.Context) (bool, error) {
+	value, err := s.repo.GetSetting(ctx, "require_complete_ballot")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return false, nil // Default to false (every vote counts)
+		}
+		return false, err
+	}
+	return value == "true", nil
+}
+
+// SetRequireCompleteBallot sets whether incomplete ballots are left out of results
+func (s *SettingsService) SetRequireCompleteBallot(ctx context.Context, enabled bool) error {
+	value := "false"
+	if enabled {
+		value = "true"
+	}
+	return s.repo.SetSetting(ctx, "require_complete_ballot", value)
+}
+
+// AllowVoteChanges checks if voters may change a vote once cast. When off,
+// votes are final: a first vote in a category is accepted but never replaced.
+func (s *SettingsService) AllowVoteChanges(ctx context.Context) (bool, error) {
+	value, err := s.repo.GetSetting(ctx, "allow_vote_changes")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return true, nil // Default to true (voters may change their picks)
+		}
+		return false, err
+	}
+	return value != "false", nil
+}
+
+// SetAllowVoteChanges sets whether voters may change a vote once cast
+func (s *SettingsService) SetAllowVoteChanges(ctx context.Context, enabled bool) error {
+	value := "false"
+	if enabled {
+		value = "true"
+	}
+	return s.repo.SetSetting(ctx, "allow_vote_changes", value)
+}
+
+// AnonymizeBallots checks if ballot exports replace voter identity with a hashed ID
+func (s *SettingsService) AnonymizeBallots(ctx context.Context) (bool, error) {
+	value, err := s.repo.GetSetting(ctx, "anonymize_ballots")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return false, nil // Default to false (coordinators see who voted)
+		}
+		return false, err
+	}
+	return value == "true", nil
+}
+
+// ballotKeySetting holds the key for anonymous voter IDs when no -secret is given
+const ballotKeySetting = "ballot_anonymization_key"
+
+// BallotKey returns the stored key for anonymous voter IDs, generating and
+// saving one on first use. It is never exported with the other settings.
+func (s *SettingsService) BallotKey(ctx context.Context) ([]byte, error) {
+	value, err := s.repo.GetSetting(ctx, ballotKeySetting)
+	if err == nil && value != "" {
+		return hex.DecodeString(value)
+	}
+	if err != nil && err != repository.ErrNotFound {
+		return nil, err
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate ballot key: %w", err)
+	}
+	if err := s.repo.SetSetting(ctx, ballotKeySetting, hex.EncodeToString(key)); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// SetAnonymizeBallots sets whether ballot exports hide voter identity
+func (s *SettingsService) SetAnonymizeBallots(ctx context.Context, enabled bool) error {
+	value := "false"
+	if enabled {
+		value = "true"
+	}
+	return s.repo.SetSetting(ctx, "anonymize_ballots", value)
+}
+
+// DerbyNetHealthPolling checks if background DerbyNet health polling is enabled
+func (s *SettingsService) DerbyNetHealthPolling(ctx context.Context) (bool, error) {
+	value, err := s.repo.GetSetting(ctx, "derbynet_health_polling")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return false, nil // Default to false (opt-in)
+		}
+		return false, err
+	}
+	return value == "true", nil
+}
+
+// SetDerbyNetHealthPolling sets whether DerbyNet connectivity is polled in the background
+func (s *SettingsService) SetDerbyNetHealthPolling(ctx context.Context, enabled bool) error {
+	value := "false"
+	if enabled {
+		value = "true"
+	}
+	return s.repo.SetSetting(ctx, "derbynet_health_polling", value)
+}
+
+// Car number formats for the car_number_format setting
+const (
+	CarNumberFormatAny     = "any"     // Any text, trimmed
+	CarNumberFormatNumeric = "numeric" // Digits only, leading zeros dropped
+)
+
+// CarNumberFormat returns how car numbers are validated and normalized
+func (s *SettingsService) CarNumberFormat(ctx context.Context) (string, error) {
+	value, err := s.repo.GetSetting(ctx, "car_number_format")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return CarNumberFormatAny, nil
+		}
+		return "", err
+	}
+	if value != CarNumberFormatNumeric {
+		return CarNumberFormatAny, nil
+	}
+	return value, nil
+}
+
+// MaintenanceMessage returns the message voters see while maintenance mode is on.
+// An empty message means maintenance mode is off.
+func (s *SettingsService) MaintenanceMessage(ctx context.Context) (string, error) {
+	value, err := s.repo.GetSetting(ctx, "maintenance_message")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return "", nil // Default to off
+		}
+		return "", err
+	}
+	return value, nil
+}
+
+// SetMaintenanceMessage turns maintenance mode on with message, or off when message is blank
+func (s *SettingsService) SetMaintenanceMessage(ctx context.Context, message string) error {
+	return s.repo.SetSetting(ctx, "maintenance_message", strings.TrimSpace(message))
+}
+
+// PostVoteRedirectURL returns where voters are sent once their ballot is
+// complete. An empty URL keeps them on the default confirmation view.
+func (s *SettingsService) PostVoteRedirectURL(ctx context.Context) (string, error) {
+	value, err := s.repo.GetSetting(ctx, "post_vote_redirect_url")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	return value, nil
+}
+
 // AllSettings returns commonly used settings as a map
 func (s *SettingsService) AllSettings(ctx context.Context) (map[string]interface{}, error) {
 	settings := make(map[string]interface{})
@@ -159,15 +400,30 @@ func (s *SettingsService) AllSettings(ctx context.Context) (map[string]interface
 	return settings, nil
 }
 
-// OpenVoting opens voting and broadcasts the status change
+// OpenVoting opens voting, discards any finalized results and broadcasts the
+// status change
 func (s *SettingsService) OpenVoting(ctx context.Context) error {
 	if err := s.SetVotingOpen(ctx, true); err != nil {
 		return err
 	}
+	if err := s.clearFinalizedResults(ctx); err != nil {
+		return err
+	}
 	s.broadcast(true, "")
 	return nil
 }
 
+// clearFinalizedResults discards the frozen results snapshot and any embargo
+// on it, which no longer match once votes can change
+func (s *SettingsService) clearFinalizedResults(ctx context.Context) error {
+	for _, key := range []string{resultsSnapshotKey, resultsEmbargoKey, revealCodeKey} {
+		if err := s.SetSetting(ctx, key, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CloseVoting closes voting, clears the timer, and broadcasts the status change
 func (s *SettingsService) CloseVoting(ctx context.Context) error {
 	if err := s.SetVotingOpen(ctx, false); err != nil {
@@ -179,13 +435,154 @@ func (s *SettingsService) CloseVoting(ctx context.Context) error {
 	return nil
 }
 
+// Voting timer limits
+const (
+	// DefaultMaxVotingMinutes is the longest timer allowed when max_voting_minutes is unset
+	DefaultMaxVotingMinutes = 60
+	// MaxVotingMinutesLimit caps max_voting_minutes itself
+	MaxVotingMinutesLimit = 24 * 60
+	// MaxMaintenanceMessageLength caps the maintenance message shown to voters
+	MaxMaintenanceMessageLength = 500
+)
+
+// DefaultVotingTimerPresets are the quick-pick timer durations used when none are configured
+var DefaultVotingTimerPresets = []int{1, 5, 10, 15}
+
+// GetMaxVotingMinutes returns the longest voting timer allowed, defaulting to DefaultMaxVotingMinutes
+func (s *SettingsService) GetMaxVotingMinutes(ctx context.Context) (int, error) {
+	value, err := s.repo.GetSetting(ctx, "max_voting_minutes")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return DefaultMaxVotingMinutes, nil
+		}
+		return 0, err
+	}
+	max, err := strconv.Atoi(value)
+	if err != nil || max <= 0 {
+		return DefaultMaxVotingMinutes, nil // Unset or invalid value, fall back to default
+	}
+	return max, nil
+}
+
+// GetTieMargin returns how many votes apart the top two cars can be and still
+// be reported as a near tie. Defaults to 0, which only reports exact ties.
+func (s *SettingsService) GetTieMargin(ctx context.Context) (int, error) {
+	value, err := s.repo.GetSetting(ctx, "tie_margin")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	margin, err := strconv.Atoi(value)
+	if err != nil || margin < 0 {
+		return 0, nil // Unset or invalid value, only report exact ties
+	}
+	return margin, nil
+}
+
+// GetAutoCloseIdleMinutes returns how many minutes without a vote close voting
+// automatically. Defaults to 0, which never closes voting for inactivity.
+func (s *SettingsService) GetAutoCloseIdleMinutes(ctx context.Context) (int, error) {
+	value, err := s.repo.GetSetting(ctx, "auto_close_idle_minutes")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	minutes, err := strconv.Atoi(value)
+	if err != nil || minutes < 0 {
+		return 0, nil // Unset or invalid value, never auto-close
+	}
+	return minutes, nil
+}
+
+// VotingOpenedAt returns when voting was last opened, or the zero time if
+// that was never recorded
+func (s *SettingsService) VotingOpenedAt(ctx context.Context) (time.Time, error) {
+	value, err := s.repo.GetSetting(ctx, "voting_opened_at")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	openedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, nil
+	}
+	return openedAt, nil
+}
+
+// GetVotingTimerPresets returns the quick-pick timer durations in minutes,
+// defaulting to DefaultVotingTimerPresets
+func (s *SettingsService) GetVotingTimerPresets(ctx context.Context) ([]int, error) {
+	value, err := s.repo.GetSetting(ctx, "voting_timer_presets")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return DefaultVotingTimerPresets, nil
+		}
+		return nil, err
+	}
+	if value == "" {
+		return DefaultVotingTimerPresets, nil
+	}
+	var presets []int
+	if err := json.Unmarshal([]byte(value), &presets); err != nil {
+		return nil, err
+	}
+	return presets, nil
+}
+
+// GetVotingInstructionsByType returns the voting instructions configured for
+// specific voter types. Returns an empty map when none are configured.
+func (s *SettingsService) GetVotingInstructionsByType(ctx context.Context) (map[string]string, error) {
+	value, err := s.repo.GetSetting(ctx, "voting_instructions_by_type")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	instructions := map[string]string{}
+	if value == "" {
+		return instructions, nil
+	}
+	if err := json.Unmarshal([]byte(value), &instructions); err != nil {
+		return nil, err
+	}
+	return instructions, nil
+}
+
+// GetVotingInstructions returns the voting instructions for a voter type,
+// falling back to the default voting_instructions when the type has none
+func (s *SettingsService) GetVotingInstructions(ctx context.Context, voterType string) (string, error) {
+	byType, err := s.GetVotingInstructionsByType(ctx)
+	if err != nil {
+		return "", err
+	}
+	if instructions := strings.TrimSpace(byType[voterType]); instructions != "" {
+		return byType[voterType], nil
+	}
+	instructions, err := s.repo.GetSetting(ctx, "voting_instructions")
+	if err == repository.ErrNotFound {
+		return "", nil
+	}
+	return instructions, err
+}
+
 // StartVotingTimer starts a voting timer for the specified minutes, opens voting, and broadcasts
 func (s *SettingsService) StartVotingTimer(ctx context.Context, minutes int) (string, error) {
-	if minutes <= 0 || minutes > 60 {
-		return "", ErrInvalidTimerMinutes
+	maxMinutes, err := s.GetMaxVotingMinutes(ctx)
+	if err != nil {
+		return "", err
+	}
+	if minutes <= 0 || minutes > maxMinutes {
+		return "", &InvalidTimerMinutesError{Max: maxMinutes}
 	}
 
-	closeTime := time.Now().Add(time.Duration(minutes) * time.Minute)
+	closeTime := time.Now().UTC().Add(time.Duration(minutes) * time.Minute)
 	closeTimeStr := closeTime.Format(time.RFC3339)
 
 	if err := s.SetSetting(ctx, "voting_close_time", closeTimeStr); err != nil {
@@ -195,29 +592,226 @@ func (s *SettingsService) StartVotingTimer(ctx context.Context, minutes int) (st
 	if err := s.SetVotingOpen(ctx, true); err != nil {
 		return "", err
 	}
+	if err := s.clearFinalizedResults(ctx); err != nil {
+		return "", err
+	}
 
 	s.broadcast(true, closeTimeStr)
 	return closeTimeStr, nil
 }
 
+// VotingClock is the voting status and countdown at ServerTime
+type VotingClock struct {
+	Open             bool
+	SecondsRemaining int
+	ServerTime       time.Time
+}
+
+// GetVotingClock returns whether voting is open and how many seconds remain on
+// the voting timer. Once the timer has elapsed voting reads as closed, even if
+// the hub has not closed it yet.
+func (s *SettingsService) GetVotingClock(ctx context.Context) (*VotingClock, error) {
+	open, err := s.IsVotingOpen(ctx)
+	if err != nil {
+		return nil, err
+	}
+	closeTimeStr, err := s.repo.GetSetting(ctx, "voting_close_time")
+	if err != nil && err != repository.ErrNotFound {
+		return nil, err
+	}
+
+	clock := &VotingClock{Open: open, ServerTime: time.Now()}
+	if closeTime, err := time.Parse(time.RFC3339, closeTimeStr); err == nil && open {
+		if clock.ServerTime.Before(closeTime) {
+			clock.SecondsRemaining = int(closeTime.Sub(clock.ServerTime).Seconds())
+		} else {
+			clock.Open = false
+		}
+	}
+	return clock, nil
+}
+
 // Settings represents application settings for update operations
 type Settings struct {
-	DerbyNetURL         string
-	BaseURL             string
-	DerbyNetRole        string
-	DerbyNetPassword    string
-	RequireRegisteredQR *bool
-	VotingInstructions  string
-	VoterTypes          []string
+	DerbyNetURL              string
+	DerbyNetURLFallback      *string // nil leaves the fallback unchanged; blank removes it
+	BaseURL                  string
+	DerbyNetRole             string
+	DerbyNetPassword         string
+	RequireRegisteredQR      *bool
+	OpenVotingOnePerDevice   *bool
+	PublicResultsEnabled     *bool
+	DerbyNetHealthPolling    *bool
+	AnonymizeBallots         *bool
+	RequireCompleteBallot    *bool
+	AllowVoteChanges         *bool
+	MaintenanceMessage       *string // nil leaves maintenance mode unchanged; blank turns it off
+	PostVoteRedirectURL      *string // nil leaves the redirect unchanged; blank removes it
+	CarNumberFormat          string  // "any" or "numeric"; empty leaves the current format unchanged
+	VotingInstructions       string
+	VotingInstructionsByType map[string]string // nil leaves the current instructions unchanged
+	VoterTypes               []string
+	EventName                string
+	LogoURL                  *string // nil leaves the logo unchanged; blank removes it
+	ThemeColor               string
+	Timezone                 string
+	MaxVotingMinutes         int   // 0 leaves the current value unchanged
+	VotingTimerPresets       []int // nil leaves the current presets unchanged
+	TieMargin                *int  // nil leaves the current margin unchanged
+	AutoCloseIdleMinutes     *int  // nil leaves the current value unchanged; 0 disables
 }
 
-// UpdateSettings updates multiple settings at once
+// ValidateSettings checks settings values and returns an error per offending field
+func ValidateSettings(settings Settings) error {
+	fields := make(map[string]string)
+
+	if settings.DerbyNetURL != "" && !isHTTPURL(settings.DerbyNetURL) {
+		fields["derbynet_url"] = "must be a valid http or https URL"
+	}
+	if settings.DerbyNetURLFallback != nil {
+		if u := strings.TrimSpace(*settings.DerbyNetURLFallback); u != "" && !isHTTPURL(u) {
+			fields["derbynet_url_fallback"] = "must be a valid http or https URL"
+		}
+	}
+	if settings.BaseURL != "" && !isHTTPURL(settings.BaseURL) {
+		fields["base_url"] = "must be a valid http or https URL"
+	}
+	seen := make(map[string]bool)
+	for _, t := range settings.VoterTypes {
+		name := strings.TrimSpace(t)
+		if name == "" {
+			fields["voter_types"] = "voter types cannot be blank"
+			break
+		}
+		if seen[name] {
+			fields["voter_types"] = "duplicate voter type: " + name
+			break
+		}
+		seen[name] = true
+	}
+	if settings.LogoURL != nil {
+		if u := strings.TrimSpace(*settings.LogoURL); u != "" && !isHTTPURL(u) && !isLocalPath(u) {
+			fields["logo_url"] = "must be an http or https URL or a path starting with /"
+		}
+	}
+	if settings.ThemeColor != "" && !themeColorPattern.MatchString(settings.ThemeColor) {
+		fields["theme_color"] = "must be a hex color like #2563eb"
+	}
+	if settings.Timezone != "" {
+		if _, err := time.LoadLocation(settings.Timezone); err != nil {
+			fields["timezone"] = "must be a valid IANA time zone such as America/Chicago"
+		}
+	}
+	if settings.MaxVotingMinutes < 0 || settings.MaxVotingMinutes > MaxVotingMinutesLimit {
+		fields["max_voting_minutes"] = "must be between 1 and " + strconv.Itoa(MaxVotingMinutesLimit)
+	}
+	for voterType := range settings.VotingInstructionsByType {
+		if strings.TrimSpace(voterType) == "" {
+			fields["voting_instructions_by_type"] = "voter types cannot be blank"
+			break
+		}
+	}
+	if settings.CarNumberFormat != "" && settings.CarNumberFormat != CarNumberFormatAny && settings.CarNumberFormat != CarNumberFormatNumeric {
+		fields["car_number_format"] = "must be any or numeric"
+	}
+	if settings.MaintenanceMessage != nil && len(*settings.MaintenanceMessage) > MaxMaintenanceMessageLength {
+		fields["maintenance_message"] = "must be " + strconv.Itoa(MaxMaintenanceMessageLength) + " characters or fewer"
+	}
+	if settings.PostVoteRedirectURL != nil {
+		if u := strings.TrimSpace(*settings.PostVoteRedirectURL); u != "" && !isHTTPURL(u) {
+			fields["post_vote_redirect_url"] = "must be a valid http or https URL"
+		}
+	}
+	if settings.TieMargin != nil && *settings.TieMargin < 0 {
+		fields["tie_margin"] = "must be zero or more"
+	}
+	if settings.AutoCloseIdleMinutes != nil && (*settings.AutoCloseIdleMinutes < 0 || *settings.AutoCloseIdleMinutes > MaxVotingMinutesLimit) {
+		fields["auto_close_idle_minutes"] = "must be between 0 and " + strconv.Itoa(MaxVotingMinutesLimit)
+	}
+	if settings.VotingTimerPresets != nil && len(settings.VotingTimerPresets) == 0 {
+		fields["voting_timer_presets"] = "at least one preset is required"
+	}
+	for _, preset := range settings.VotingTimerPresets {
+		if preset <= 0 {
+			fields["voting_timer_presets"] = "presets must be positive"
+			break
+		}
+	}
+
+	if len(fields) > 0 {
+		return errors.InvalidFields(fields)
+	}
+	return nil
+}
+
+// validateTimerPresets checks that the presets being saved, or already stored,
+// fit within the max timer being saved, or already stored
+func (s *SettingsService) validateTimerPresets(ctx context.Context, settings Settings) error {
+	if settings.MaxVotingMinutes == 0 && settings.VotingTimerPresets == nil {
+		return nil
+	}
+
+	maxMinutes := settings.MaxVotingMinutes
+	if maxMinutes == 0 {
+		var err error
+		if maxMinutes, err = s.GetMaxVotingMinutes(ctx); err != nil {
+			return err
+		}
+	}
+	presets := settings.VotingTimerPresets
+	if presets == nil {
+		var err error
+		if presets, err = s.GetVotingTimerPresets(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, preset := range presets {
+		if preset > maxMinutes {
+			return errors.InvalidFields(map[string]string{
+				"voting_timer_presets": "presets cannot exceed the maximum of " + strconv.Itoa(maxMinutes) + " minutes",
+			})
+		}
+	}
+	return nil
+}
+
+// themeColorPattern matches a six-digit hex color such as #2563eb
+var themeColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// isHTTPURL reports whether s is an absolute http(s) URL with a host
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// isLocalPath reports whether s is a path on this server. Browsers treat "//"
+// and "/\" as protocol-relative URLs to another host, so those are rejected.
+func isLocalPath(s string) bool {
+	return strings.HasPrefix(s, "/") && !strings.HasPrefix(s, "//") && !strings.HasPrefix(s, "/\\")
+}
+
+// UpdateSettings validates and updates multiple settings at once
 func (s *SettingsService) UpdateSettings(ctx context.Context, settings Settings) error {
+	if err := ValidateSettings(settings); err != nil {
+		return err
+	}
+	if err := s.validateTimerPresets(ctx, settings); err != nil {
+		return err
+	}
 	if settings.DerbyNetURL != "" {
 		if err := s.SetDerbyNetURL(ctx, settings.DerbyNetURL); err != nil {
 			return err
 		}
 	}
+	if settings.DerbyNetURLFallback != nil {
+		if err := s.SetSetting(ctx, "derbynet_url_fallback", strings.TrimSpace(*settings.DerbyNetURLFallback)); err != nil {
+			return err
+		}
+	}
 	if settings.BaseURL != "" {
 		if err := s.SetBaseURL(ctx, settings.BaseURL); err != nil {
 			return err
@@ -238,19 +832,361 @@ func (s *SettingsService) UpdateSettings(ctx context.Context, settings Settings)
 			return err
 		}
 	}
+	if settings.OpenVotingOnePerDevice != nil {
+		if err := s.SetOpenVotingOnePerDevice(ctx, *settings.OpenVotingOnePerDevice); err != nil {
+			return err
+		}
+	}
+	if settings.PublicResultsEnabled != nil {
+		if err := s.SetPublicResultsEnabled(ctx, *settings.PublicResultsEnabled); err != nil {
+			return err
+		}
+	}
+	if settings.DerbyNetHealthPolling != nil {
+		if err := s.SetDerbyNetHealthPolling(ctx, *settings.DerbyNetHealthPolling); err != nil {
+			return err
+		}
+	}
+	if settings.AnonymizeBallots != nil {
+		if err := s.SetAnonymizeBallots(ctx, *settings.AnonymizeBallots); err != nil {
+			return err
+		}
+	}
+	if settings.RequireCompleteBallot != nil {
+		if err := s.SetRequireCompleteBallot(ctx, *settings.RequireCompleteBallot); err != nil {
+			return err
+		}
+	}
+	if settings.AllowVoteChanges != nil {
+		if err := s.SetAllowVoteChanges(ctx, *settings.AllowVoteChanges); err != nil {
+			return err
+		}
+		// Connected voter pages learn the policy from the voting status
+		open, err := s.IsVotingOpen(ctx)
+		if err != nil {
+			return err
+		}
+		closeTime, _ := s.repo.GetSetting(ctx, "voting_close_time")
+		s.broadcast(open, closeTime)
+	}
+	if settings.MaintenanceMessage != nil {
+		if err := s.SetMaintenanceMessage(ctx, *settings.MaintenanceMessage); err != nil {
+			return err
+		}
+	}
+	if settings.PostVoteRedirectURL != nil {
+		if err := s.SetSetting(ctx, "post_vote_redirect_url", strings.TrimSpace(*settings.PostVoteRedirectURL)); err != nil {
+			return err
+		}
+	}
+	if settings.CarNumberFormat != "" {
+		if err := s.SetSetting(ctx, "car_number_format", settings.CarNumberFormat); err != nil {
+			return err
+		}
+	}
 	if settings.VotingInstructions != "" {
 		if err := s.SetSetting(ctx, "voting_instructions", settings.VotingInstructions); err != nil {
 			return err
 		}
 	}
+	if settings.VotingInstructionsByType != nil {
+		data, err := json.Marshal(settings.VotingInstructionsByType)
+		if err != nil {
+			return err
+		}
+		if err := s.SetSetting(ctx, "voting_instructions_by_type", string(data)); err != nil {
+			return err
+		}
+	}
 	if len(settings.VoterTypes) > 0 {
 		if err := s.SetVoterTypes(ctx, settings.VoterTypes); err != nil {
 			return err
 		}
 	}
+	if settings.EventName != "" {
+		if err := s.SetSetting(ctx, "event_name", settings.EventName); err != nil {
+			return err
+		}
+	}
+	if settings.LogoURL != nil {
+		if err := s.SetSetting(ctx, "logo_url", strings.TrimSpace(*settings.LogoURL)); err != nil {
+			return err
+		}
+	}
+	if settings.ThemeColor != "" {
+		if err := s.SetSetting(ctx, "theme_color", settings.ThemeColor); err != nil {
+			return err
+		}
+	}
+	if settings.Timezone != "" {
+		if err := s.SetSetting(ctx, "timezone", settings.Timezone); err != nil {
+			return err
+		}
+	}
+	if settings.MaxVotingMinutes != 0 {
+		if err := s.SetSetting(ctx, "max_voting_minutes", strconv.Itoa(settings.MaxVotingMinutes)); err != nil {
+			return err
+		}
+	}
+	if settings.VotingTimerPresets != nil {
+		data, err := json.Marshal(settings.VotingTimerPresets)
+		if err != nil {
+			return err
+		}
+		if err := s.SetSetting(ctx, "voting_timer_presets", string(data)); err != nil {
+			return err
+		}
+	}
+	if settings.TieMargin != nil {
+		if err := s.SetSetting(ctx, "tie_margin", strconv.Itoa(*settings.TieMargin)); err != nil {
+			return err
+		}
+	}
+	if settings.AutoCloseIdleMinutes != nil {
+		if err := s.SetSetting(ctx, "auto_close_idle_minutes", strconv.Itoa(*settings.AutoCloseIdleMinutes)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// GetLocation returns the configured display time zone.
+// Timestamps are stored in UTC; this zone is only used when rendering them.
+// Defaults to the server's local zone when unset or invalid.
+func (s *SettingsService) GetLocation(ctx context.Context) (*time.Location, error) {
+	value, err := s.repo.GetSetting(ctx, "timezone")
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return time.Local, nil
+		}
+		return nil, err
+	}
+	if value == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		return time.Local, nil // Invalid value, fall back to server zone
+	}
+	return loc, nil
+}
+
+// Default branding used when the corresponding settings are unset
+const (
+	DefaultEventName  = "DerbyVote"
+	DefaultThemeColor = "#2563eb"
+)
+
+// Branding holds the event name, logo and theme color shown on voter-facing pages
+type Branding struct {
+	EventName  string `json:"event_name"`
+	LogoURL    string `json:"logo_url"`
+	ThemeColor string `json:"theme_color"`
+}
+
+// GetBranding returns the configured branding, falling back to defaults for unset values
+func (s *SettingsService) GetBranding(ctx context.Context) (*Branding, error) {
+	branding := &Branding{EventName: DefaultEventName, ThemeColor: DefaultThemeColor}
+
+	values := map[string]*string{
+		"event_name":  &branding.EventName,
+		"logo_url":    &branding.LogoURL,
+		"theme_color": &branding.ThemeColor,
+	}
+	for key, dest := range values {
+		value, err := s.repo.GetSetting(ctx, key)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if value != "" {
+			*dest = value
+		}
+	}
+	return branding, nil
+}
+
+// PortableSettings lists the configuration keys that can be exported and imported.
+// Runtime state such as voting_open and timers is deliberately excluded.
+var PortableSettings = map[string]bool{
+	"derbynet_url":                true,
+	"derbynet_url_fallback":       true,
+	"base_url":                    true,
+	"derbynet_role":               true,
+	"derbynet_password":           true,
+	"require_registered_qr":       true,
+	"open_voting_one_per_device":  true,
+	"public_results_enabled":      true,
+	"derbynet_health_polling":     true,
+	"anonymize_ballots":           true,
+	"require_complete_ballot":     true,
+	"allow_vote_changes":          true,
+	"car_number_format":           true,
+	"voting_instructions":         true,
+	"voting_instructions_by_type": true,
+	"voter_types":                 true,
+	"event_name":                  true,
+	"logo_url":                    true,
+	"theme_color":                 true,
+	"timezone":                    true,
+	"max_voting_minutes":          true,
+	"voting_timer_presets":        true,
+	"tie_margin":                  true,
+	"auto_close_idle_minutes":     true,
+	"post_vote_redirect_url":      true,
+}
+
+// SensitiveSettings are only exported or imported when explicitly requested
+var SensitiveSettings = map[string]bool{
+	"derbynet_password": true,
+}
+
+// SettingsImportResult reports which keys were applied and which were skipped
+type SettingsImportResult struct {
+	Imported []string `json:"imported"`
+	Skipped  []string `json:"skipped"`
+}
+
+// ExportSettings returns the portable settings, omitting sensitive ones unless includeSensitive is set
+func (s *SettingsService) ExportSettings(ctx context.Context, includeSensitive bool) (map[string]string, error) {
+	all, err := s.repo.ListSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make(map[string]string)
+	for key, value := range all {
+		if !PortableSettings[key] {
+			continue
+		}
+		if SensitiveSettings[key] && !includeSensitive {
+			continue
+		}
+		exported[key] = value
+	}
+	return exported, nil
+}
+
+// ImportSettings validates and applies exported settings. Sensitive keys are
+// skipped unless includeSensitive is set; any invalid or unknown key aborts the import.
+func (s *SettingsService) ImportSettings(ctx context.Context, values map[string]string, includeSensitive bool) (*SettingsImportResult, error) {
+	fields := make(map[string]string)
+	settings := Settings{
+		DerbyNetURL:        values["derbynet_url"],
+		BaseURL:            values["base_url"],
+		DerbyNetRole:       values["derbynet_role"],
+		VotingInstructions: values["voting_instructions"],
+		EventName:          values["event_name"],
+		ThemeColor:         values["theme_color"],
+		Timezone:           values["timezone"],
+		CarNumberFormat:    values["car_number_format"],
+	}
+
+	for key := range values {
+		if !PortableSettings[key] {
+			fields[key] = "unknown setting"
+		}
+	}
+	for _, key := range []string{"require_registered_qr", "open_voting_one_per_device", "public_results_enabled", "derbynet_health_polling", "anonymize_ballots", "require_complete_ballot", "allow_vote_changes"} {
+		if v, ok := values[key]; ok && v != "true" && v != "false" {
+			fields[key] = "must be true or false"
+		}
+	}
+	if v, ok := values["voter_types"]; ok {
+		if err := json.Unmarshal([]byte(v), &settings.VoterTypes); err != nil {
+			fields["voter_types"] = "must be a JSON list of strings"
+		}
+	}
+	if v, ok := values["voting_instructions_by_type"]; ok {
+		if err := json.Unmarshal([]byte(v), &settings.VotingInstructionsByType); err != nil {
+			fields["voting_instructions_by_type"] = "must be a JSON object of strings"
+		}
+	}
+	if v, ok := values["derbynet_url_fallback"]; ok {
+		settings.DerbyNetURLFallback = &v
+	}
+	if v, ok := values["post_vote_redirect_url"]; ok {
+		settings.PostVoteRedirectURL = &v
+	}
+	if v, ok := values["logo_url"]; ok {
+		settings.LogoURL = &v
+	}
+	if v, ok := values["max_voting_minutes"]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			fields["max_voting_minutes"] = "must be a positive whole number"
+		} else {
+			settings.MaxVotingMinutes = n
+		}
+	}
+	if v, ok := values["tie_margin"]; ok {
+		if n, err := strconv.Atoi(v); err != nil || n < 0 {
+			fields["tie_margin"] = "must be a whole number, zero or more"
+		}
+	}
+	if v, ok := values["auto_close_idle_minutes"]; ok {
+		if n, err := strconv.Atoi(v); err != nil || n < 0 || n > MaxVotingMinutesLimit {
+			fields["auto_close_idle_minutes"] = "must be a whole number between 0 and " + strconv.Itoa(MaxVotingMinutesLimit)
+		}
+	}
+	if v, ok := values["voting_timer_presets"]; ok {
+		if err := json.Unmarshal([]byte(v), &settings.VotingTimerPresets); err != nil {
+			fields["voting_timer_presets"] = "must be a JSON list of whole numbers"
+		} else if settings.VotingTimerPresets == nil {
+			settings.VotingTimerPresets = []int{}
+		}
+	}
+	if err := ValidateSettings(settings); err != nil {
+		var appErr *errors.Error
+		if stderrors.As(err, &appErr) {
+			for k, v := range appErr.Fields {
+				fields[k] = v
+			}
+		}
+	}
+	if err := s.validateTimerPresets(ctx, settings); err != nil {
+		var appErr *errors.Error
+		if !stderrors.As(err, &appErr) {
+			return nil, err
+		}
+		for k, v := range appErr.Fields {
+			fields[k] = v
+		}
+	}
+	if len(fields) > 0 {
+		return nil, errors.InvalidFields(fields)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := &SettingsImportResult{Imported: []string{}, Skipped: []string{}}
+	for _, key := range keys {
+		if SensitiveSettings[key] && !includeSensitive {
+			result.Skipped = append(result.Skipped, key)
+			continue
+		}
+		var err error
+		if key == "voter_types" {
+			err = s.SetVoterTypes(ctx, settings.VoterTypes)
+		} else {
+			err = s.repo.SetSetting(ctx, key, values[key])
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Imported = append(result.Imported, key)
+	}
+
+	s.log.InfoContext(ctx, "Imported settings", "imported", len(result.Imported), "skipped", len(result.Skipped))
+	return result, nil
+}
+
 // ResetTablesResult contains the result of a database reset
 type ResetTablesResult struct {
 	Tables  []string
@@ -303,12 +1239,43 @@ func (s *SettingsService) ResetTables(ctx context.Context, tables []string) (*Re
 		}
 	}
 
+	// Finalized results are stale once the votes behind them are gone
+	if containsTable(tablesToReset, "votes") && !containsTable(tablesToReset, "settings") {
+		if err := s.clearFinalizedResults(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	return &ResetTablesResult{
 		Tables:  tablesToReset,
 		Message: "Successfully deleted data from tables",
 	}, nil
 }
 
+// NewEventResult reports how many rows StartNewEvent cleared per table
+type NewEventResult struct {
+	Cleared map[string]int64
+}
+
+// StartNewEvent clears votes, voters and manual winner overrides so the same
+// categories, cars and settings can be reused for another event. Voting is
+// left closed and any frozen results snapshot and embargo are discarded.
+func (s *SettingsService) StartNewEvent(ctx context.Context) (*NewEventResult, error) {
+	cleared, err := s.repo.ClearEventData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.clearFinalizedResults(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.CloseVoting(ctx); err != nil {
+		return nil, err
+	}
+
+	s.log.InfoContext(ctx, "Started new event", "votes", cleared["votes"], "voters", cleared["voters"], "overrides", cleared["overrides"])
+	return &NewEventResult{Cleared: cleared}, nil
+}
+
 func containsTable(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
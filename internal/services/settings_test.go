@@ -3,8 +3,12 @@ package services_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	apperrors "github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
 	"github.com/abrezinsky/derbyvote/internal/repository"
 	"github.com/abrezinsky/derbyvote/internal/repository/mock"
@@ -325,6 +329,335 @@ func TestSettingsService_UpdateSettings(t *testing.T) {
 	}
 }
 
+func TestSettingsService_UpdateSettings_RejectsInvalidValues(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewSettingsService(log, repo)
+	ctx := context.Background()
+
+	err := svc.UpdateSettings(ctx, services.Settings{
+		DerbyNetURL: "derbynet.local",
+		BaseURL:     "ftp://voting.local",
+		VoterTypes:  []string{"Parent", "Parent"},
+	})
+
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrInvalidFields {
+		t.Fatalf("expected invalid fields error, got %v", err)
+	}
+	for _, field := range []string{"derbynet_url", "base_url", "voter_types"} {
+		if appErr.Fields[field] == "" {
+			t.Errorf("expected error for %s, got %v", field, appErr.Fields)
+		}
+	}
+
+	// Nothing should have been saved
+	url, _ := svc.GetDerbyNetURL(ctx)
+	if url == "derbynet.local" {
+		t.Error("expected invalid derbynet URL not to be saved")
+	}
+}
+
+func TestValidateSettings_BlankVoterType(t *testing.T) {
+	err := services.ValidateSettings(services.Settings{VoterTypes: []string{"Parent", "  "}})
+
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Fields["voter_types"] == "" {
+		t.Errorf("expected voter_types error, got %v", err)
+	}
+
+	if err := services.ValidateSettings(services.Settings{BaseURL: "https://voting.local:8080"}); err != nil {
+		t.Errorf("expected valid settings, got %v", err)
+	}
+}
+
+func TestSettingsService_ExportSettings_OmitsSensitiveAndRuntimeKeys(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewSettingsService(log, repo)
+	ctx := context.Background()
+
+	_ = svc.UpdateSettings(ctx, services.Settings{
+		DerbyNetURL:      "http://derbynet.local",
+		DerbyNetPassword: "secret",
+	})
+
+	exported, err := svc.ExportSettings(ctx, false)
+	if err != nil {
+		t.Fatalf("ExportSettings failed: %v", err)
+	}
+	if exported["derbynet_url"] != "http://derbynet.local" {
+		t.Errorf("expected derbynet_url in export, got %v", exported)
+	}
+	if _, ok := exported["derbynet_password"]; ok {
+		t.Error("expected derbynet_password to be omitted")
+	}
+	if _, ok := exported["voting_open"]; ok {
+		t.Error("expected runtime voting_open to be omitted")
+	}
+
+	exported, _ = svc.ExportSettings(ctx, true)
+	if exported["derbynet_password"] != "secret" {
+		t.Errorf("expected derbynet_password with includeSensitive, got %v", exported)
+	}
+}
+
+func TestSettingsService_ExportSettings_ListError(t *testing.T) {
+	mockRepo := mock.NewRepository(testutil.NewTestRepository(t))
+	mockRepo.ListSettingsError = errors.New("database error")
+	svc := services.NewSettingsService(logger.New(), mockRepo)
+
+	if _, err := svc.ExportSettings(context.Background(), false); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestSettingsService_StartNewEvent(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	voterID, _ := repo.CreateVoter(ctx, "EVENT-QR")
+	_ = repo.SaveVote(ctx, voterID, int(catID), 1)
+	_ = repo.SetSetting(ctx, "results_snapshot", `{"categories":[]}`)
+	_ = svc.OpenVoting(ctx)
+
+	result, err := svc.StartNewEvent(ctx)
+	if err != nil {
+		t.Fatalf("StartNewEvent failed: %v", err)
+	}
+	if result.Cleared["votes"] != 1 || result.Cleared["voters"] != 1 || result.Cleared["overrides"] != 0 {
+		t.Errorf("unexpected cleared counts: %v", result.Cleared)
+	}
+	if open, _ := svc.IsVotingOpen(ctx); open {
+		t.Error("expected voting to be closed after starting a new event")
+	}
+	if snapshot, _ := repo.GetSetting(ctx, "results_snapshot"); snapshot != "" {
+		t.Errorf("expected results snapshot to be discarded, got %q", snapshot)
+	}
+}
+
+func TestSettingsService_StartNewEvent_RepoError(t *testing.T) {
+	mockRepo := mock.NewRepository(testutil.NewTestRepository(t))
+	mockRepo.ClearEventDataError = errors.New("database error")
+	svc := services.NewSettingsService(logger.New(), mockRepo)
+	ctx := context.Background()
+
+	_ = svc.OpenVoting(ctx)
+	if _, err := svc.StartNewEvent(ctx); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if open, _ := svc.IsVotingOpen(ctx); !open {
+		t.Error("expected voting to stay open when clearing fails")
+	}
+}
+
+func TestSettingsService_ImportSettings_AppliesAndSkipsSensitive(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewSettingsService(log, repo)
+	ctx := context.Background()
+
+	result, err := svc.ImportSettings(ctx, map[string]string{
+		"base_url":              "http://voting.local",
+		"require_registered_qr": "true",
+		"voter_types":           `["general","racer","Parent"]`,
+		"derbynet_password":     "secret",
+	}, false)
+	if err != nil {
+		t.Fatalf("ImportSettings failed: %v", err)
+	}
+	if len(result.Imported) != 3 || len(result.Skipped) != 1 || result.Skipped[0] != "derbynet_password" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	baseURL, _ := svc.GetBaseURL(ctx)
+	if baseURL != "http://voting.local" {
+		t.Errorf("expected base URL to be imported, got %q", baseURL)
+	}
+	if required, _ := svc.RequireRegisteredQR(ctx); !required {
+		t.Error("expected require_registered_qr to be imported")
+	}
+	if _, err := repo.GetSetting(ctx, "derbynet_password"); err == nil {
+		t.Error("expected derbynet_password to be skipped")
+	}
+}
+
+func TestSettingsService_ImportSettings_RejectsInvalid(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	_, err := svc.ImportSettings(ctx, map[string]string{
+		"base_url":              "not a url",
+		"require_registered_qr": "maybe",
+		"voter_types":           "Parent",
+		"voting_open":           "true",
+	}, false)
+
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrInvalidFields {
+		t.Fatalf("expected invalid fields error, got %v", err)
+	}
+	for _, field := range []string{"base_url", "require_registered_qr", "voter_types", "voting_open"} {
+		if appErr.Fields[field] == "" {
+			t.Errorf("expected error for %s, got %v", field, appErr.Fields)
+		}
+	}
+}
+
+func TestSettingsService_ImportSettings_SetSettingError(t *testing.T) {
+	mockRepo := mock.NewRepository(testutil.NewTestRepository(t))
+	mockRepo.SetSettingError = errors.New("database error")
+	svc := services.NewSettingsService(logger.New(), mockRepo)
+
+	_, err := svc.ImportSettings(context.Background(), map[string]string{"base_url": "http://voting.local"}, false)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestSettingsService_GetBranding_Defaults(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+
+	branding, err := svc.GetBranding(context.Background())
+	if err != nil {
+		t.Fatalf("GetBranding failed: %v", err)
+	}
+	if branding.EventName != services.DefaultEventName || branding.ThemeColor != services.DefaultThemeColor || branding.LogoURL != "" {
+		t.Errorf("expected default branding, got %+v", branding)
+	}
+}
+
+func TestSettingsService_GetBranding_Configured(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	logo := "/branding/logo"
+	err := svc.UpdateSettings(ctx, services.Settings{
+		EventName:  "Pack 42 Derby",
+		LogoURL:    &logo,
+		ThemeColor: "#ff6600",
+	})
+	if err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+
+	branding, err := svc.GetBranding(ctx)
+	if err != nil {
+		t.Fatalf("GetBranding failed: %v", err)
+	}
+	if branding.EventName != "Pack 42 Derby" || branding.LogoURL != "/branding/logo" || branding.ThemeColor != "#ff6600" {
+		t.Errorf("unexpected branding: %+v", branding)
+	}
+
+	// A blank logo URL clears the logo
+	cleared := ""
+	if err := svc.UpdateSettings(ctx, services.Settings{LogoURL: &cleared}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if branding, _ = svc.GetBranding(ctx); branding.LogoURL != "" {
+		t.Errorf("expected logo to be cleared, got %q", branding.LogoURL)
+	}
+}
+
+func TestSettingsService_GetBranding_DatabaseError(t *testing.T) {
+	mockRepo := mock.NewRepository(testutil.NewTestRepository(t))
+	mockRepo.GetSettingError = errors.New("database error")
+	svc := services.NewSettingsService(logger.New(), mockRepo)
+
+	if _, err := svc.GetBranding(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestValidateSettings_Branding(t *testing.T) {
+	bad := "javascript:alert(1)"
+	err := services.ValidateSettings(services.Settings{LogoURL: &bad, ThemeColor: "blue"})
+
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected invalid fields error, got %v", err)
+	}
+	for _, field := range []string{"logo_url", "theme_color"} {
+		if appErr.Fields[field] == "" {
+			t.Errorf("expected error for %s, got %v", field, appErr.Fields)
+		}
+	}
+
+	good := "https://example.com/logo.png"
+	if err := services.ValidateSettings(services.Settings{LogoURL: &good, ThemeColor: "#2563EB"}); err != nil {
+		t.Errorf("expected valid branding, got %v", err)
+	}
+
+	for _, logo := range []string{"//evil.example/logo.png", "/\\evil.example/logo.png", "ftp://example.com/logo.png", "logo.png"} {
+		if err := services.ValidateSettings(services.Settings{LogoURL: &logo}); err == nil {
+			t.Errorf("expected %q to be rejected", logo)
+		}
+	}
+	for _, logo := range []string{"", "/branding/logo?v=1", "http://example.com/logo.png"} {
+		if err := services.ValidateSettings(services.Settings{LogoURL: &logo}); err != nil {
+			t.Errorf("expected %q to be accepted, got %v", logo, err)
+		}
+	}
+}
+
+func TestSettingsService_GetLocation(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	loc, err := svc.GetLocation(ctx)
+	if err != nil {
+		t.Fatalf("GetLocation failed: %v", err)
+	}
+	if loc != time.Local {
+		t.Errorf("expected server local zone by default, got %v", loc)
+	}
+
+	if err := svc.UpdateSettings(ctx, services.Settings{Timezone: "America/Chicago"}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	loc, _ = svc.GetLocation(ctx)
+	if loc.String() != "America/Chicago" {
+		t.Errorf("expected America/Chicago, got %v", loc)
+	}
+
+	// An invalid stored value falls back to the server zone
+	_ = repo.SetSetting(ctx, "timezone", "Mars/Olympus")
+	loc, _ = svc.GetLocation(ctx)
+	if loc != time.Local {
+		t.Errorf("expected fallback to server local zone, got %v", loc)
+	}
+}
+
+func TestSettingsService_GetLocation_DatabaseError(t *testing.T) {
+	mockRepo := mock.NewRepository(testutil.NewTestRepository(t))
+	mockRepo.GetSettingError = errors.New("database error")
+	svc := services.NewSettingsService(logger.New(), mockRepo)
+
+	if _, err := svc.GetLocation(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestValidateSettings_Timezone(t *testing.T) {
+	err := services.ValidateSettings(services.Settings{Timezone: "Mars/Olympus"})
+
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Fields["timezone"] == "" {
+		t.Errorf("expected timezone error, got %v", err)
+	}
+
+	if err := services.ValidateSettings(services.Settings{Timezone: "Europe/London"}); err != nil {
+		t.Errorf("expected valid timezone, got %v", err)
+	}
+}
+
 func TestSettingsService_UpdateSettings_Partial(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -422,10 +755,13 @@ func TestSettingsService_StartVotingTimer_Valid(t *testing.T) {
 		t.Fatalf("StartVotingTimer failed: %v", err)
 	}
 
-	// Should return a close time string
+	// Should return a close time string stored in UTC
 	if closeTime == "" {
 		t.Error("expected close time to be set")
 	}
+	if !strings.HasSuffix(closeTime, "Z") {
+		t.Errorf("expected UTC close time, got %q", closeTime)
+	}
 
 	// Verify voting is now open
 	open, _ := svc.IsVotingOpen(ctx)
@@ -633,11 +969,11 @@ func TestSettingsService_ResetTables_DeletesCategories(t *testing.T) {
 	ctx := context.Background()
 
 	// Create some categories
-	_, err := repo.CreateCategory(ctx, "Test Category 1", 1, nil, nil, nil)
+	_, err := repo.CreateCategory(ctx, "Test Category 1", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
-	_, err = repo.CreateCategory(ctx, "Test Category 2", 2, nil, nil, nil)
+	_, err = repo.CreateCategory(ctx, "Test Category 2", 2, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -808,6 +1144,360 @@ func TestSettingsService_RequireRegisteredQR_Default(t *testing.T) {
 	}
 }
 
+func TestSettingsService_PublicResultsEnabled(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if enabled, err := svc.PublicResultsEnabled(ctx); err != nil || enabled {
+		t.Fatalf("expected public results disabled by default, got %v, %v", enabled, err)
+	}
+
+	enable := true
+	if err := svc.UpdateSettings(ctx, services.Settings{PublicResultsEnabled: &enable}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if enabled, _ := svc.PublicResultsEnabled(ctx); !enabled {
+		t.Error("expected public results to be enabled")
+	}
+}
+
+func TestSettingsService_AnonymizeBallots(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if enabled, err := svc.AnonymizeBallots(ctx); err != nil || enabled {
+		t.Fatalf("expected anonymized ballots off by default, got %v, %v", enabled, err)
+	}
+
+	enable := true
+	if err := svc.UpdateSettings(ctx, services.Settings{AnonymizeBallots: &enable}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if enabled, _ := svc.AnonymizeBallots(ctx); !enabled {
+		t.Error("expected anonymized ballots to be enabled")
+	}
+}
+
+func TestSettingsService_BallotKey(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	key, err := svc.BallotKey(ctx)
+	if err != nil {
+		t.Fatalf("BallotKey failed: %v", err)
+	}
+	if len(key) != 32 {
+		t.Fatalf("expected a 32 byte key, got %d bytes", len(key))
+	}
+	again, _ := svc.BallotKey(ctx)
+	if string(again) != string(key) {
+		t.Error("expected the stored ballot key to be reused")
+	}
+
+	exported, _ := svc.ExportSettings(ctx, true)
+	if _, ok := exported["ballot_anonymization_key"]; ok {
+		t.Error("expected ballot_anonymization_key to never be exported")
+	}
+}
+
+func TestSettingsService_RequireCompleteBallot(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if enabled, err := svc.RequireCompleteBallot(ctx); err != nil || enabled {
+		t.Fatalf("expected complete ballots not required by default, got %v, %v", enabled, err)
+	}
+
+	enable := true
+	if err := svc.UpdateSettings(ctx, services.Settings{RequireCompleteBallot: &enable}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if enabled, _ := svc.RequireCompleteBallot(ctx); !enabled {
+		t.Error("expected complete ballots to be required")
+	}
+}
+
+func TestSettingsService_AllowVoteChanges(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if allowed, err := svc.AllowVoteChanges(ctx); err != nil || !allowed {
+		t.Fatalf("expected vote changes allowed by default, got %v, %v", allowed, err)
+	}
+
+	disable := false
+	if err := svc.UpdateSettings(ctx, services.Settings{AllowVoteChanges: &disable}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if allowed, _ := svc.AllowVoteChanges(ctx); allowed {
+		t.Error("expected vote changes to be disallowed")
+	}
+}
+
+func TestSettingsService_GetVotingClock(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewSettingsService(log, repo)
+	ctx := context.Background()
+
+	// Open without a timer
+	clock, err := svc.GetVotingClock(ctx)
+	if err != nil {
+		t.Fatalf("GetVotingClock failed: %v", err)
+	}
+	if !clock.Open || clock.SecondsRemaining != 0 || clock.ServerTime.IsZero() {
+		t.Errorf("expected open with no countdown, got %+v", clock)
+	}
+
+	if _, err := svc.StartVotingTimer(ctx, 5); err != nil {
+		t.Fatalf("StartVotingTimer failed: %v", err)
+	}
+	clock, _ = svc.GetVotingClock(ctx)
+	if !clock.Open || clock.SecondsRemaining <= 290 || clock.SecondsRemaining > 300 {
+		t.Errorf("expected about 300 seconds remaining, got %+v", clock)
+	}
+
+	// An elapsed timer reads as closed even before the hub closes voting
+	repo.SetSetting(ctx, "voting_close_time", time.Now().Add(-time.Second).UTC().Format(time.RFC3339))
+	clock, _ = svc.GetVotingClock(ctx)
+	if clock.Open || clock.SecondsRemaining != 0 {
+		t.Errorf("expected closed with 0 seconds once the timer elapsed, got %+v", clock)
+	}
+
+	svc.CloseVoting(ctx)
+	clock, _ = svc.GetVotingClock(ctx)
+	if clock.Open || clock.SecondsRemaining != 0 {
+		t.Errorf("expected closed, got %+v", clock)
+	}
+}
+
+func TestSettingsService_MaintenanceMessage(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if message, err := svc.MaintenanceMessage(ctx); err != nil || message != "" {
+		t.Fatalf("expected maintenance mode off by default, got %q, %v", message, err)
+	}
+
+	message := "  Voting opens at 7pm  "
+	if err := svc.UpdateSettings(ctx, services.Settings{MaintenanceMessage: &message}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.MaintenanceMessage(ctx); got != "Voting opens at 7pm" {
+		t.Errorf("expected trimmed maintenance message, got %q", got)
+	}
+
+	// Settings that leave the message out keep maintenance mode on
+	if err := svc.UpdateSettings(ctx, services.Settings{EventName: "Spring Derby"}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.MaintenanceMessage(ctx); got == "" {
+		t.Error("expected maintenance mode to stay on")
+	}
+
+	cleared := ""
+	if err := svc.UpdateSettings(ctx, services.Settings{MaintenanceMessage: &cleared}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.MaintenanceMessage(ctx); got != "" {
+		t.Errorf("expected maintenance mode off after clearing, got %q", got)
+	}
+
+	tooLong := strings.Repeat("x", services.MaxMaintenanceMessageLength+1)
+	err := svc.UpdateSettings(ctx, services.Settings{MaintenanceMessage: &tooLong})
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Fields["maintenance_message"] == "" {
+		t.Errorf("expected maintenance_message field error, got %v", err)
+	}
+}
+
+func TestSettingsService_PostVoteRedirectURL(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if got, err := svc.PostVoteRedirectURL(ctx); err != nil || got != "" {
+		t.Fatalf("expected no redirect by default, got %q, %v", got, err)
+	}
+
+	redirect := " https://pack123.example.org/feedback "
+	if err := svc.UpdateSettings(ctx, services.Settings{PostVoteRedirectURL: &redirect}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.PostVoteRedirectURL(ctx); got != "https://pack123.example.org/feedback" {
+		t.Errorf("expected trimmed redirect URL, got %q", got)
+	}
+
+	for _, bad := range []string{"/thanks", "javascript:alert(1)", "pack123.example.org"} {
+		err := svc.UpdateSettings(ctx, services.Settings{PostVoteRedirectURL: &bad})
+		var appErr *apperrors.Error
+		if !errors.As(err, &appErr) || appErr.Fields["post_vote_redirect_url"] == "" {
+			t.Errorf("expected post_vote_redirect_url field error for %q, got %v", bad, err)
+		}
+	}
+
+	cleared := ""
+	if err := svc.UpdateSettings(ctx, services.Settings{PostVoteRedirectURL: &cleared}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.PostVoteRedirectURL(ctx); got != "" {
+		t.Errorf("expected redirect cleared, got %q", got)
+	}
+}
+
+func TestSettingsService_DerbyNetURLFallback(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	fallback := " http://10.0.0.42 "
+	if err := svc.UpdateSettings(ctx, services.Settings{DerbyNetURLFallback: &fallback}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.GetSetting(ctx, "derbynet_url_fallback"); got != "http://10.0.0.42" {
+		t.Errorf("expected trimmed fallback URL, got %q", got)
+	}
+
+	bad := "10.0.0.42"
+	err := svc.UpdateSettings(ctx, services.Settings{DerbyNetURLFallback: &bad})
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Fields["derbynet_url_fallback"] == "" {
+		t.Errorf("expected derbynet_url_fallback field error, got %v", err)
+	}
+
+	// Leaving it nil keeps the fallback; blank removes it
+	if err := svc.UpdateSettings(ctx, services.Settings{}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.GetSetting(ctx, "derbynet_url_fallback"); got != "http://10.0.0.42" {
+		t.Errorf("expected fallback unchanged, got %q", got)
+	}
+	cleared := ""
+	if err := svc.UpdateSettings(ctx, services.Settings{DerbyNetURLFallback: &cleared}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.GetSetting(ctx, "derbynet_url_fallback"); got != "" {
+		t.Errorf("expected fallback cleared, got %q", got)
+	}
+}
+
+func TestSettingsService_TieMargin(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if margin, err := svc.GetTieMargin(ctx); err != nil || margin != 0 {
+		t.Fatalf("expected tie margin 0 by default, got %d, %v", margin, err)
+	}
+
+	margin := 2
+	if err := svc.UpdateSettings(ctx, services.Settings{TieMargin: &margin}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.GetTieMargin(ctx); got != 2 {
+		t.Errorf("expected tie margin 2, got %d", got)
+	}
+
+	negative := -1
+	if err := svc.UpdateSettings(ctx, services.Settings{TieMargin: &negative}); err == nil {
+		t.Error("expected error for negative tie margin")
+	}
+
+	if _, err := svc.ImportSettings(ctx, map[string]string{"tie_margin": "abc"}, false); err == nil {
+		t.Error("expected import to reject a non-numeric tie margin")
+	}
+	if _, err := svc.ImportSettings(ctx, map[string]string{"tie_margin": "0"}, false); err != nil {
+		t.Fatalf("ImportSettings failed: %v", err)
+	}
+	if got, _ := svc.GetTieMargin(ctx); got != 0 {
+		t.Errorf("expected imported tie margin 0, got %d", got)
+	}
+}
+
+func TestSettingsService_AutoCloseIdleMinutes(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if minutes, err := svc.GetAutoCloseIdleMinutes(ctx); err != nil || minutes != 0 {
+		t.Errorf("expected auto-close disabled by default, got %d, %v", minutes, err)
+	}
+	minutes := 20
+	if err := svc.UpdateSettings(ctx, services.Settings{AutoCloseIdleMinutes: &minutes}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.GetAutoCloseIdleMinutes(ctx); got != 20 {
+		t.Errorf("expected 20 idle minutes, got %d", got)
+	}
+	for _, bad := range []int{-1, services.MaxVotingMinutesLimit + 1} {
+		if err := svc.UpdateSettings(ctx, services.Settings{AutoCloseIdleMinutes: &bad}); err == nil {
+			t.Errorf("expected validation error for %d idle minutes", bad)
+		}
+	}
+	if _, err := svc.ImportSettings(ctx, map[string]string{"auto_close_idle_minutes": "soon"}, false); err == nil {
+		t.Error("expected validation error importing a non-numeric value")
+	}
+
+	// Opening voting records when, so the idle clock starts there
+	_ = svc.CloseVoting(ctx)
+	if err := svc.OpenVoting(ctx); err != nil {
+		t.Fatalf("OpenVoting failed: %v", err)
+	}
+	if openedAt, err := svc.VotingOpenedAt(ctx); err != nil || time.Since(openedAt) > time.Minute {
+		t.Errorf("expected voting opened just now, got %v, %v", openedAt, err)
+	}
+}
+
+func TestSettingsService_VotingInstructionsByType(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if byType, err := svc.GetVotingInstructionsByType(ctx); err != nil || len(byType) != 0 {
+		t.Fatalf("expected no per-type instructions by default, got %v, %v", byType, err)
+	}
+
+	err := svc.UpdateSettings(ctx, services.Settings{
+		VotingInstructions:       "Vote for your favorites",
+		VotingInstructionsByType: map[string]string{"judge": "Score every category"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.GetVotingInstructions(ctx, "judge"); got != "Score every category" {
+		t.Errorf("expected judge instructions, got %q", got)
+	}
+	if got, _ := svc.GetVotingInstructions(ctx, "general"); got != "Vote for your favorites" {
+		t.Errorf("expected default instructions for general voters, got %q", got)
+	}
+
+	if err := svc.UpdateSettings(ctx, services.Settings{VotingInstructionsByType: map[string]string{" ": "x"}}); err == nil {
+		t.Error("expected error for a blank voter type")
+	}
+
+	if _, err := svc.ImportSettings(ctx, map[string]string{"voting_instructions_by_type": `{"judge": 1}`}, false); err == nil {
+		t.Error("expected import to reject non-string instructions")
+	}
+	if _, err := svc.ImportSettings(ctx, map[string]string{"voting_instructions_by_type": `["judge"]`}, false); err == nil {
+		t.Error("expected import to reject a JSON list")
+	}
+	if _, err := svc.ImportSettings(ctx, map[string]string{"voting_instructions_by_type": `{"racer": "Pick any car but your own"}`}, false); err != nil {
+		t.Fatalf("ImportSettings failed: %v", err)
+	}
+	if got, _ := svc.GetVotingInstructions(ctx, "judge"); got != "Vote for your favorites" {
+		t.Errorf("expected judge to fall back to default after import, got %q", got)
+	}
+	if got, _ := svc.GetVotingInstructions(ctx, "racer"); got != "Pick any car but your own" {
+		t.Errorf("expected imported racer instructions, got %q", got)
+	}
+}
+
 func TestSettingsService_RequireRegisteredQR_Toggle(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -930,6 +1620,104 @@ func TestSettingsService_StartVotingTimer_SetVotingOpenError(t *testing.T) {
 	}
 }
 
+func TestSettingsService_StartVotingTimer_ConfiguredMax(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if max, _ := svc.GetMaxVotingMinutes(ctx); max != services.DefaultMaxVotingMinutes {
+		t.Errorf("expected default max %d, got %d", services.DefaultMaxVotingMinutes, max)
+	}
+	_, err := svc.StartVotingTimer(ctx, 120)
+	var timerErr *services.InvalidTimerMinutesError
+	if !errors.As(err, &timerErr) || timerErr.Max != services.DefaultMaxVotingMinutes {
+		t.Errorf("expected InvalidTimerMinutesError with the default max, got %v", err)
+	}
+
+	if err := svc.UpdateSettings(ctx, services.Settings{MaxVotingMinutes: 240}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if _, err := svc.StartVotingTimer(ctx, 240); err != nil {
+		t.Errorf("expected 240 minutes to be allowed, got %v", err)
+	}
+	_, err = svc.StartVotingTimer(ctx, 241)
+	if !errors.As(err, &timerErr) || timerErr.Max != 240 {
+		t.Errorf("expected InvalidTimerMinutesError with max 240, got %v", err)
+	}
+	if err != nil && err.Error() != "minutes must be between 1 and 240" {
+		t.Errorf("expected the message to state the max, got %q", err.Error())
+	}
+}
+
+func TestSettingsService_VotingTimerPresets(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	presets, err := svc.GetVotingTimerPresets(ctx)
+	if err != nil {
+		t.Fatalf("GetVotingTimerPresets failed: %v", err)
+	}
+	if fmt.Sprint(presets) != fmt.Sprint(services.DefaultVotingTimerPresets) {
+		t.Errorf("expected default presets, got %v", presets)
+	}
+
+	// Presets must fit within the stored max
+	err = svc.UpdateSettings(ctx, services.Settings{VotingTimerPresets: []int{10, 90}})
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Fields["voting_timer_presets"] == "" {
+		t.Fatalf("expected voting_timer_presets field error, got %v", err)
+	}
+
+	// ...or the max saved alongside them
+	if err := svc.UpdateSettings(ctx, services.Settings{MaxVotingMinutes: 120, VotingTimerPresets: []int{10, 90}}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	presets, _ = svc.GetVotingTimerPresets(ctx)
+	if fmt.Sprint(presets) != "[10 90]" {
+		t.Errorf("expected saved presets, got %v", presets)
+	}
+
+	// Lowering the max below a stored preset is rejected
+	err = svc.UpdateSettings(ctx, services.Settings{MaxVotingMinutes: 60})
+	if !errors.As(err, &appErr) || appErr.Fields["voting_timer_presets"] == "" {
+		t.Errorf("expected voting_timer_presets field error, got %v", err)
+	}
+
+	err = svc.UpdateSettings(ctx, services.Settings{MaxVotingMinutes: -1, VotingTimerPresets: []int{0}})
+	if !errors.As(err, &appErr) || appErr.Fields["max_voting_minutes"] == "" || appErr.Fields["voting_timer_presets"] == "" {
+		t.Errorf("expected field errors for invalid max and presets, got %v", err)
+	}
+}
+
+func TestSettingsService_ImportSettings_TimerSettings(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	_, err := svc.ImportSettings(ctx, map[string]string{
+		"max_voting_minutes":   "30",
+		"voting_timer_presets": "[10, 45]",
+	}, false)
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Fields["voting_timer_presets"] == "" {
+		t.Fatalf("expected voting_timer_presets field error, got %v", err)
+	}
+
+	if _, err := svc.ImportSettings(ctx, map[string]string{
+		"max_voting_minutes":   "240",
+		"voting_timer_presets": "[30, 60, 120]",
+	}, false); err != nil {
+		t.Fatalf("ImportSettings failed: %v", err)
+	}
+	if max, _ := svc.GetMaxVotingMinutes(ctx); max != 240 {
+		t.Errorf("expected imported max 240, got %d", max)
+	}
+	if presets, _ := svc.GetVotingTimerPresets(ctx); fmt.Sprint(presets) != "[30 60 120]" {
+		t.Errorf("expected imported presets, got %v", presets)
+	}
+}
+
 func TestSettingsService_UpdateSettings_DerbyNetURLError(t *testing.T) {
 	realRepo := testutil.NewTestRepository(t)
 	mockRepo := mock.NewRepository(realRepo)
@@ -1443,3 +2231,26 @@ func TestGetVoterTypes_UnmarshalError(t *testing.T) {
 		t.Fatal("expected error from GetVoterTypes with invalid JSON, got nil")
 	}
 }
+
+func TestSettingsService_CarNumberFormat(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	svc := services.NewSettingsService(logger.New(), repo)
+	ctx := context.Background()
+
+	if format, err := svc.CarNumberFormat(ctx); err != nil || format != services.CarNumberFormatAny {
+		t.Fatalf("expected default format any, got %q, %v", format, err)
+	}
+
+	if err := svc.UpdateSettings(ctx, services.Settings{CarNumberFormat: services.CarNumberFormatNumeric}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if got, _ := svc.CarNumberFormat(ctx); got != services.CarNumberFormatNumeric {
+		t.Errorf("expected numeric format, got %q", got)
+	}
+
+	err := svc.UpdateSettings(ctx, services.Settings{CarNumberFormat: "roman"})
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Fields["car_number_format"] == "" {
+		t.Errorf("expected car_number_format field error, got %v", err)
+	}
+}
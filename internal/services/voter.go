@@ -2,10 +2,12 @@ package services
 
 import (
 	"context"
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"strings"
@@ -13,7 +15,9 @@ import (
 
 	"github.com/skip2/go-qrcode"
 
+	"github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/models"
 	"github.com/abrezinsky/derbyvote/internal/repository"
 )
 
@@ -23,6 +27,8 @@ type VoterService struct {
 	repo       repository.VoterRepository
 	settings   SettingsServicer
 	randReader io.Reader // for testing: defaults to crypto/rand.Reader
+	events     EventSink
+	pseudo     *Pseudonymizer
 }
 
 // NewVoterService creates a new VoterService
@@ -40,15 +46,27 @@ func (s *VoterService) SetRandReader(reader io.Reader) {
 	s.randReader = reader
 }
 
+// SetEventSink sets where voter registration events are recorded
+func (s *VoterService) SetEventSink(sink EventSink) {
+	s.events = sink
+}
+
+// SetPseudonymizer sets how voters are identified in recorded events
+func (s *VoterService) SetPseudonymizer(p *Pseudonymizer) {
+	s.pseudo = p
+}
+
 // Voter represents a voter for create/update operations
 type Voter struct {
-	ID        int
-	CarID     *int
-	Name      string
-	Email     string
-	VoterType string
-	QRCode    string
-	Notes     string
+	ID         int
+	CarID      *int
+	Name       string
+	Email      string
+	VoterType  string
+	VoterGroup string // den or other grouping, independent of VoterType; empty for none
+	QRCode     string
+	Notes      string
+	IsTest     bool // votes are kept but left out of results
 }
 
 // ListVoters returns all voters with car info
@@ -56,6 +74,27 @@ func (s *VoterService) ListVoters(ctx context.Context) ([]map[string]interface{}
 	return s.repo.ListVoters(ctx)
 }
 
+// ListVotersInGroup returns the voters in one voter group
+func (s *VoterService) ListVotersInGroup(ctx context.Context, group string) ([]map[string]interface{}, error) {
+	voters, err := s.repo.ListVoters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	group = strings.TrimSpace(group)
+	inGroup := []map[string]interface{}{}
+	for _, voter := range voters {
+		if voterGroup, _ := voter["voter_group"].(string); voterGroup == group {
+			inGroup = append(inGroup, voter)
+		}
+	}
+	return inGroup, nil
+}
+
+// VoterGroupTurnout returns how many voters in each voter group have voted
+func (s *VoterService) VoterGroupTurnout(ctx context.Context) ([]models.VoterGroupTurnout, error) {
+	return s.repo.ListVoterGroupTurnout(ctx)
+}
+
 // CreateVoter creates a new voter
 func (s *VoterService) CreateVoter(ctx context.Context, voter Voter) (int64, string, error) {
 	// Generate QR code if not provided
@@ -71,12 +110,32 @@ func (s *VoterService) CreateVoter(ctx context.Context, voter Voter) (int64, str
 	}
 
 	id, err := s.repo.CreateVoterFull(ctx, voter.CarID, voter.Name, voter.Email, voter.VoterType, voter.QRCode, voter.Notes)
-	return id, voter.QRCode, err
+	if err != nil {
+		return 0, "", err
+	}
+	if voter.IsTest {
+		if err := s.repo.SetVoterTest(ctx, int(id), true); err != nil {
+			return 0, "", err
+		}
+	}
+	if group := strings.TrimSpace(voter.VoterGroup); group != "" {
+		if err := s.repo.SetVoterGroup(ctx, int(id), group); err != nil {
+			return 0, "", err
+		}
+	}
+	recordEvent(s.events, Event{Type: EventVoterRegistered, VoterID: s.pseudo.VoterID(voter.QRCode)})
+	return id, voter.QRCode, nil
 }
 
 // UpdateVoter updates a voter
 func (s *VoterService) UpdateVoter(ctx context.Context, voter Voter) error {
-	return s.repo.UpdateVoter(ctx, voter.ID, voter.CarID, voter.Name, voter.Email, voter.VoterType, voter.Notes)
+	if err := s.repo.UpdateVoter(ctx, voter.ID, voter.CarID, voter.Name, voter.Email, voter.VoterType, voter.Notes); err != nil {
+		return err
+	}
+	if err := s.repo.SetVoterTest(ctx, voter.ID, voter.IsTest); err != nil {
+		return err
+	}
+	return s.repo.SetVoterGroup(ctx, voter.ID, strings.TrimSpace(voter.VoterGroup))
 }
 
 // DeleteVoter deletes a voter
@@ -84,6 +143,113 @@ func (s *VoterService) DeleteVoter(ctx context.Context, id int) error {
 	return s.repo.DeleteVoter(ctx, id)
 }
 
+// CountVotesForVoter returns the number of votes a voter has cast
+func (s *VoterService) CountVotesForVoter(ctx context.Context, voterID int) (int, error) {
+	return s.repo.CountVotesForVoter(ctx, voterID)
+}
+
+// ClearVoterVotes removes all of a voter's votes so they can redo their ballot.
+// While voting is closed this is refused unless force is set.
+func (s *VoterService) ClearVoterVotes(ctx context.Context, voterID int, force bool) (int64, error) {
+	if !force {
+		open, err := s.settings.IsVotingOpen(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if !open {
+			return 0, ErrVotingClosed
+		}
+	}
+
+	cleared, err := s.repo.ClearVoterVotes(ctx, voterID)
+	if err != nil {
+		return 0, err
+	}
+	s.log.InfoContext(ctx, "Cleared voter votes", "voter_id", voterID, "count", cleared, "force", force)
+	return cleared, nil
+}
+
+// maxVoidReasonLength caps the reason recorded when a vote is voided
+const maxVoidReasonLength = 500
+
+// VoidVote removes a voter's vote in a category as invalid, recording the
+// reason in the audit log. Returns the car the vote was for.
+func (s *VoterService) VoidVote(ctx context.Context, voterID, categoryID int, reason string) (int, error) {
+	reason = strings.TrimSpace(reason)
+	fields := map[string]string{}
+	if voterID <= 0 {
+		fields["voter_id"] = "voter_id is required"
+	}
+	if categoryID <= 0 {
+		fields["category_id"] = "category_id is required"
+	}
+	if reason == "" {
+		fields["reason"] = "reason is required"
+	} else if len([]rune(reason)) > maxVoidReasonLength {
+		fields["reason"] = fmt.Sprintf("reason must be at most %d characters", maxVoidReasonLength)
+	}
+	if len(fields) > 0 {
+		return 0, errors.InvalidFields(fields)
+	}
+
+	carID, err := s.repo.VoidVote(ctx, voterID, categoryID, reason)
+	if err != nil {
+		return 0, err
+	}
+	s.log.InfoContext(ctx, "Voided vote", "voter_id", voterID, "category", categoryID, "car", carID, "reason", reason)
+	recordEvent(s.events, Event{Type: EventVoteVoided, CategoryID: categoryID, CarID: carID})
+	return carID, nil
+}
+
+// ListAuditLog returns the audit log, newest entry first
+func (s *VoterService) ListAuditLog(ctx context.Context) ([]models.AuditEntry, error) {
+	return s.repo.ListAuditLog(ctx)
+}
+
+// DefaultStaleVoterMinutes is how long a partial voter must be idle to be listed as stale
+const DefaultStaleVoterMinutes = 15
+
+// ListStaleVoters returns voters who started but did not finish their ballot
+// and have been idle for at least the given number of minutes
+func (s *VoterService) ListStaleVoters(ctx context.Context, minutes int) ([]models.StaleVoter, error) {
+	if minutes <= 0 {
+		return nil, errors.Validation("minutes must be a positive whole number")
+	}
+	return s.repo.ListStaleVoters(ctx, time.Now().Add(-time.Duration(minutes)*time.Minute))
+}
+
+// VoterFilter selects voters for bulk operations
+type VoterFilter struct {
+	VoterType string // empty matches all voter types
+	HasVoted  *bool  // nil matches voters regardless of voting status
+}
+
+// IsEmpty reports whether the filter would match every voter
+func (f VoterFilter) IsEmpty() bool {
+	return f.VoterType == "" && f.HasVoted == nil
+}
+
+// BulkDeleteVoters deletes all voters matching the filter along with their votes
+func (s *VoterService) BulkDeleteVoters(ctx context.Context, filter VoterFilter) (int64, error) {
+	deleted, err := s.repo.DeleteVotersByFilter(ctx, filter.VoterType, filter.HasVoted)
+	if err != nil {
+		return 0, err
+	}
+	s.log.InfoContext(ctx, "Bulk deleted voters", "voter_type", filter.VoterType, "count", deleted)
+	return deleted, nil
+}
+
+// ClearTestVoters deletes every test voter and their votes, for use before
+// voting goes live
+func (s *VoterService) ClearTestVoters(ctx context.Context) (int64, error) {
+	deleted, err := s.repo.DeleteTestVoters(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.log.InfoContext(ctx, "Cleared test voters", "count", deleted)
+	return deleted, nil
+}
+
 // GenerateQRCodes generates multiple QR codes and creates voters
 func (s *VoterService) GenerateQRCodes(ctx context.Context, count int) ([]string, error) {
 	if count <= 0 || count > 200 {
@@ -99,13 +265,77 @@ func (s *VoterService) GenerateQRCodes(ctx context.Context, count int) ([]string
 		qrCodes[i] = qrCode
 
 		if err := s.repo.InsertVoterIgnore(ctx, qrCode); err != nil {
-			s.log.Error("Error creating voter", "qr_code", qrCode, "error", err)
+			s.log.ErrorContext(ctx, "Error creating voter", "qr_code", qrCode, "error", err)
 		}
 	}
 
 	return qrCodes, nil
 }
 
+// MockVoter is a demo voter created by SeedMockVoters
+type MockVoter struct {
+	Name      string `json:"name"`
+	Email     string `json:"email,omitempty"`
+	VoterType string `json:"voter_type"`
+	QRCode    string `json:"qr_code"`
+}
+
+// MockVoters returns the demo voters SeedMockVoters creates. QR codes are
+// derived from the voter names so reseeding is idempotent.
+func MockVoters() []MockVoter {
+	people := []struct {
+		Name      string
+		Email     string
+		VoterType string
+	}{
+		{"Karen Johnson", "karen.johnson@example.com", "general"},
+		{"David Williams", "david.williams@example.com", "general"},
+		{"Lisa Chen", "lisa.chen@example.com", "general"},
+		{"Robert Davis", "robert.davis@example.com", "general"},
+		{"Jennifer Brown", "jennifer.brown@example.com", "general"},
+		{"Michael Martinez", "michael.martinez@example.com", "general"},
+		{"Grandma Wilson", "", "general"},
+		{"Grandpa Garcia", "", "general"},
+		{"Tom Anderson", "tom.anderson@example.com", "Race Committee"},
+		{"Susan Taylor", "susan.taylor@example.com", "Race Committee"},
+		{"Mark Thomas", "mark.thomas@example.com", "Cubmaster"},
+		{"Patricia Moore", "patricia.moore@example.com", "general"},
+	}
+	voters := make([]MockVoter, len(people))
+	for i, p := range people {
+		voters[i] = MockVoter{Name: p.Name, Email: p.Email, VoterType: p.VoterType, QRCode: GenerateReadableCode("demo-voter-" + p.Name)}
+	}
+	return voters
+}
+
+// SeedMockVoters creates the demo voters, skipping any that already exist
+func (s *VoterService) SeedMockVoters(ctx context.Context) (int, error) {
+	var addedCount int
+	var firstError error
+	for _, voter := range MockVoters() {
+		_, exists, err := s.repo.GetVoterByQRCode(ctx, voter.QRCode)
+		if err != nil {
+			if firstError == nil {
+				firstError = fmt.Errorf("failed to check if voter exists: %w", err)
+			}
+			continue
+		}
+		if exists {
+			continue
+		}
+		if _, err := s.repo.CreateVoterFull(ctx, nil, voter.Name, voter.Email, voter.VoterType, voter.QRCode, "Demo voter"); err != nil {
+			s.log.ErrorContext(ctx, "Error seeding voter", "name", voter.Name, "error", err)
+			if firstError == nil {
+				firstError = fmt.Errorf("failed to create voter %q: %w", voter.Name, err)
+			}
+			continue
+		}
+		addedCount++
+	}
+
+	return addedCount, firstError
+}
+
 // GenerateReadableCode creates a short, readable code from input data
 // Uses only clear characters (no O/0/I/1/L) - format: XX-YYY
 func GenerateReadableCode(seed string) string {
@@ -134,8 +364,75 @@ func (s *VoterService) GenerateQRImage(ctx context.Context, voterID int) ([]byte
 	if err != nil || baseURL == "" {
 		return nil, fmt.Errorf("base_url not configured")
 	}
-	votingURL := fmt.Sprintf("%s/vote/%s", strings.TrimSuffix(baseURL, "/"), qrCode)
-	return qrcode.Encode(votingURL, qrcode.Medium, 256)
+	// Encode the short link; the full voting URL makes a denser code that
+	// scans poorly on cheap phones. A read-only instance cannot create a
+	// missing short link, so it falls back to the full URL.
+	token, err := s.ShortToken(ctx, voterID)
+	if stderrors.Is(err, repository.ErrReadOnly) {
+		voteURL := fmt.Sprintf("%s/vote/%s", strings.TrimSuffix(baseURL, "/"), qrCode)
+		return qrcode.Encode(voteURL, qrcode.Medium, 256)
+	}
+	if err != nil {
+		return nil, err
+	}
+	shortURL := fmt.Sprintf("%s/v/%s", strings.TrimSuffix(baseURL, "/"), token)
+	return qrcode.Encode(shortURL, qrcode.Medium, 256)
+}
+
+// Short link tokens use the same unambiguous characters as readable codes
+const (
+	shortTokenChars  = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+	shortTokenLength = 6
+)
+
+// ShortToken returns the token for a voter's short link (/v/{token}),
+// creating one the first time it is asked for
+func (s *VoterService) ShortToken(ctx context.Context, voterID int) (string, error) {
+	token, err := s.repo.GetVoterShortToken(ctx, voterID)
+	if err != repository.ErrNotFound {
+		return token, err
+	}
+
+	maxRetries := 10
+	for i := 0; i < maxRetries; i++ {
+		bytes := make([]byte, shortTokenLength)
+		if _, err := io.ReadFull(s.randReader, bytes); err != nil {
+			return "", fmt.Errorf("failed to generate short token: %w", err)
+		}
+		for j, b := range bytes {
+			bytes[j] = shortTokenChars[int(b)%len(shortTokenChars)]
+		}
+		token = string(bytes)
+
+		_, err := s.repo.GetQRCodeByShortToken(ctx, token)
+		if err == repository.ErrNotFound {
+			if err := s.repo.SetVoterShortToken(ctx, voterID, token); err != nil {
+				// Another request may have created the voter's token first
+				if existing, getErr := s.repo.GetVoterShortToken(ctx, voterID); getErr == nil {
+					return existing, nil
+				}
+				return "", fmt.Errorf("failed to save short token: %w", err)
+			}
+			return token, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("error checking short token uniqueness: %w", err)
+		}
+
+		s.log.DebugContext(ctx, "Generated short token already exists, retrying", "token", token, "attempt", i+1)
+	}
+
+	return "", fmt.Errorf("failed to generate unique short token after %d attempts", maxRetries)
+}
+
+// ResolveShortToken returns the QR code of the voter a short link token
+// belongs to. Tokens are matched case-insensitively.
+func (s *VoterService) ResolveShortToken(ctx context.Context, token string) (string, error) {
+	qrCode, err := s.repo.GetQRCodeByShortToken(ctx, strings.ToUpper(strings.TrimSpace(token)))
+	if err == repository.ErrNotFound {
+		return "", errors.NotFound("short link not found")
+	}
+	return qrCode, err
 }
 
 // GenerateUniqueCode generates a unique random code that doesn't exist in the database
@@ -172,12 +469,105 @@ func (s *VoterService) GenerateUniqueCode(ctx context.Context) (string, error) {
 		}
 
 		// Code exists, try again
-		s.log.Debug("Generated code already exists, retrying", "code", code, "attempt", i+1)
+		s.log.DebugContext(ctx, "Generated code already exists, retrying", "code", code, "attempt", i+1)
 	}
 
 	return "", fmt.Errorf("failed to generate unique code after %d attempts", maxRetries)
 }
 
+// deviceKeySetting holds the key that signs device tokens for one-per-device open voting
+const deviceKeySetting = "device_signing_key"
+
+// DeviceVoterCode returns the open-voting code a device token is tied to. It
+// reports false when open_voting_one_per_device is off or the token is missing
+// or fails verification.
+func (s *VoterService) DeviceVoterCode(ctx context.Context, token string) (string, bool) {
+	if enabled, err := s.settings.OpenVotingOnePerDevice(ctx); err != nil || !enabled {
+		return "", false
+	}
+	i := strings.LastIndex(token, ".")
+	if i <= 0 {
+		return "", false
+	}
+	code := token[:i]
+	key, err := s.deviceKey(ctx)
+	if err != nil {
+		return "", false
+	}
+	if !hmac.Equal([]byte(token[i+1:]), []byte(signDeviceCode(key, code))) {
+		return "", false
+	}
+	return code, true
+}
+
+// BindDevice limits a device to one generated code when open voting is
+// limited to one ballot per device. A device already tied to a code is
+// refused any other code that isn't a voter yet; a device with no valid token
+// is tied to qrCode if it is new, and the token to store is returned. Codes of
+// existing voters are always allowed, so voters created by admins still work
+// on any device.
+func (s *VoterService) BindDevice(ctx context.Context, token, qrCode string) (string, error) {
+	if qrCode == "" {
+		return "", nil
+	}
+	enabled, err := s.settings.OpenVotingOnePerDevice(ctx)
+	if err != nil || !enabled {
+		return "", err
+	}
+	requireRegistered, err := s.settings.RequireRegisteredQR(ctx)
+	if err != nil || requireRegistered {
+		return "", err
+	}
+
+	_, err = s.repo.GetVoterByQR(ctx, qrCode)
+	if err == nil {
+		return "", nil
+	}
+	if err != repository.ErrNotFound {
+		return "", err
+	}
+
+	if code, ok := s.DeviceVoterCode(ctx, token); ok {
+		if code != qrCode {
+			return "", errors.Forbidden("This device has already been used to vote")
+		}
+		return "", nil
+	}
+
+	key, err := s.deviceKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	return qrCode + "." + signDeviceCode(key, qrCode), nil
+}
+
+// deviceKey returns the device token signing key, generating and saving one on first use
+func (s *VoterService) deviceKey(ctx context.Context) ([]byte, error) {
+	value, err := s.settings.GetSetting(ctx, deviceKeySetting)
+	if err == nil && value != "" {
+		return hex.DecodeString(value)
+	}
+	if err != nil && err != repository.ErrNotFound {
+		return nil, err
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(s.randReader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate device key: %w", err)
+	}
+	if err := s.settings.SetSetting(ctx, deviceKeySetting, hex.EncodeToString(key)); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// signDeviceCode returns the hex HMAC of a code under the device key
+func signDeviceCode(key []byte, code string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // GenerateDynamicQRImage generates a QR code for /vote/new URL
 // This allows anyone to scan and get their own unique code (open voting mode)
 func (s *VoterService) GenerateDynamicQRImage(ctx context.Context) ([]byte, error) {
@@ -198,3 +588,23 @@ func (s *VoterService) GenerateDynamicQRImage(ctx context.Context) ([]byte, erro
 	voteURL := fmt.Sprintf("%s/vote/new", strings.TrimSuffix(baseURL, "/"))
 	return qrcode.Encode(voteURL, qrcode.Medium, 256)
 }
+
+// GenerateCategoryQRImage generates an open voting QR code that opens the
+// ballot on one category, for a station dedicated to that category
+func (s *VoterService) GenerateCategoryQRImage(ctx context.Context, categoryID int) ([]byte, error) {
+	requireRegistered, err := s.settings.RequireRegisteredQR(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error checking settings: %w", err)
+	}
+	if requireRegistered {
+		return nil, ErrOpenVotingDisabled
+	}
+
+	baseURL, err := s.settings.GetBaseURL(ctx)
+	if err != nil || baseURL == "" {
+		return nil, fmt.Errorf("base_url not configured")
+	}
+
+	voteURL := fmt.Sprintf("%s/vote/new?category=%d", strings.TrimSuffix(baseURL, "/"), categoryID)
+	return qrcode.Encode(voteURL, qrcode.Medium, 256)
+}
@@ -1,16 +1,21 @@
 package services_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	apperrors "github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/repository"
 	"github.com/abrezinsky/derbyvote/internal/repository/mock"
 	"github.com/abrezinsky/derbyvote/internal/services"
 	"github.com/abrezinsky/derbyvote/internal/testutil"
+	"github.com/skip2/go-qrcode"
 )
 
 func TestVoterService_CreateVoter_AllFields(t *testing.T) {
@@ -230,6 +235,277 @@ func TestVoterService_DeleteVoter(t *testing.T) {
 	}
 }
 
+
+func TestVoterService_BulkDeleteVoters(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewVoterService(log, repo, settingsSvc)
+	ctx := context.Background()
+
+	_, _, _ = svc.CreateVoter(ctx, services.Voter{Name: "Walk-up", QRCode: "BULK-1"})
+	_, _, _ = svc.CreateVoter(ctx, services.Voter{Name: "Walk-up 2", QRCode: "BULK-2"})
+	_, _, _ = svc.CreateVoter(ctx, services.Voter{Name: "Judge", VoterType: "judge", QRCode: "BULK-3"})
+
+	deleted, err := svc.BulkDeleteVoters(ctx, services.VoterFilter{VoterType: "general"})
+	if err != nil {
+		t.Fatalf("BulkDeleteVoters failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 voters deleted, got %d", deleted)
+	}
+
+	voters, _ := svc.ListVoters(ctx)
+	if len(voters) != 1 {
+		t.Fatalf("expected 1 voter remaining, got %d", len(voters))
+	}
+	if voters[0]["voter_type"] != "judge" {
+		t.Errorf("expected judge to remain, got %v", voters[0]["voter_type"])
+	}
+}
+
+func TestVoterService_TestVoters(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewVoterService(log, repo, settingsSvc)
+	ctx := context.Background()
+
+	testID, _, err := svc.CreateVoter(ctx, services.Voter{Name: "Setup", QRCode: "TEST-1", IsTest: true})
+	if err != nil {
+		t.Fatalf("CreateVoter failed: %v", err)
+	}
+	realID, _, _ := svc.CreateVoter(ctx, services.Voter{Name: "Parent", QRCode: "REAL-1"})
+
+	isTest := func(id int64) bool {
+		voters, _ := svc.ListVoters(ctx)
+		for _, v := range voters {
+			if v["id"] == id {
+				return v["is_test"] == true
+			}
+		}
+		t.Fatalf("voter %d not found", id)
+		return false
+	}
+	if !isTest(testID) || isTest(realID) {
+		t.Fatal("expected only TEST-1 to be a test voter")
+	}
+
+	// Updating a voter sets the flag like any other field
+	if err := svc.UpdateVoter(ctx, services.Voter{ID: int(realID), Name: "Parent", VoterType: "general", IsTest: true}); err != nil {
+		t.Fatalf("UpdateVoter failed: %v", err)
+	}
+	if !isTest(realID) {
+		t.Error("expected update to mark REAL-1 as a test voter")
+	}
+	if err := svc.UpdateVoter(ctx, services.Voter{ID: int(realID), Name: "Parent", VoterType: "general"}); err != nil {
+		t.Fatalf("UpdateVoter failed: %v", err)
+	}
+
+	deleted, err := svc.ClearTestVoters(ctx)
+	if err != nil {
+		t.Fatalf("ClearTestVoters failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 test voter deleted, got %d", deleted)
+	}
+	voters, _ := svc.ListVoters(ctx)
+	if len(voters) != 1 || voters[0]["qr_code"] != "REAL-1" {
+		t.Errorf("expected only REAL-1 to remain, got %v", voters)
+	}
+}
+
+func TestVoterService_BulkDeleteVoters_RepoError(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	mockRepo.DeleteVotersByFilterError = errors.New("database error")
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, realRepo)
+	svc := services.NewVoterService(log, mockRepo, settingsSvc)
+
+	_, err := svc.BulkDeleteVoters(context.Background(), services.VoterFilter{VoterType: "general"})
+	if err == nil {
+		t.Fatal("expected error from BulkDeleteVoters, got nil")
+	}
+}
+
+func TestVoterService_ClearVoterVotes(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewVoterService(log, repo, settingsSvc)
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoter(ctx, "REDO-QR")
+	_ = repo.SaveVote(ctx, voterID, int(catID), cars[0].ID)
+
+	// Closed voting requires force
+	_ = settingsSvc.SetVotingOpen(ctx, false)
+	if _, err := svc.ClearVoterVotes(ctx, voterID, false); err != services.ErrVotingClosed {
+		t.Fatalf("expected ErrVotingClosed, got %v", err)
+	}
+
+	cleared, err := svc.ClearVoterVotes(ctx, voterID, true)
+	if err != nil {
+		t.Fatalf("ClearVoterVotes failed: %v", err)
+	}
+	if cleared != 1 {
+		t.Errorf("expected 1 vote cleared, got %d", cleared)
+	}
+
+	// Open voting needs no force
+	_ = settingsSvc.SetVotingOpen(ctx, true)
+	if _, err := svc.ClearVoterVotes(ctx, voterID, false); err != nil {
+		t.Errorf("expected clear to succeed while voting is open, got %v", err)
+	}
+}
+
+func TestVoterService_VoidVote(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewVoterService(log, repo, services.NewSettingsService(log, repo))
+	sink := &recordingSink{}
+	svc.SetEventSink(sink)
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoter(ctx, "VOID-QR")
+	_ = repo.SaveVote(ctx, voterID, int(catID), cars[0].ID)
+
+	// A reason is required
+	_, err := svc.VoidVote(ctx, voterID, int(catID), "   ")
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Fields["reason"] == "" {
+		t.Fatalf("expected reason field error, got %v", err)
+	}
+
+	carID, err := svc.VoidVote(ctx, voterID, int(catID), "  Judge double-scanned  ")
+	if err != nil {
+		t.Fatalf("VoidVote failed: %v", err)
+	}
+	if carID != cars[0].ID {
+		t.Errorf("expected voided car %d, got %d", cars[0].ID, carID)
+	}
+
+	entries, _ := svc.ListAuditLog(ctx)
+	if len(entries) != 1 || entries[0].Reason != "Judge double-scanned" {
+		t.Errorf("expected trimmed reason in the audit log, got %+v", entries)
+	}
+	assertEventTypes(t, sink, services.EventVoteVoided)
+
+	// The vote is gone, so voiding it again is not found
+	_, err = svc.VoidVote(ctx, voterID, int(catID), "Again")
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrNotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestVoterService_ClearVoterVotes_RepoError(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	mockRepo.ClearVoterVotesError = errors.New("database error")
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, realRepo)
+	svc := services.NewVoterService(log, mockRepo, settingsSvc)
+
+	_, err := svc.ClearVoterVotes(context.Background(), 1, true)
+	if err == nil {
+		t.Fatal("expected error from ClearVoterVotes, got nil")
+	}
+}
+
+func TestVoterService_ListStaleVoters(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewVoterService(log, repo, services.NewSettingsService(log, repo))
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Fastest Looking", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoter(ctx, "PARTIAL-QR")
+	_ = repo.SaveVote(ctx, voterID, int(catID), cars[0].ID)
+
+	// The voter just voted, so they are not idle yet
+	stale, err := svc.ListStaleVoters(ctx, 15)
+	if err != nil {
+		t.Fatalf("ListStaleVoters failed: %v", err)
+	}
+	if len(stale) != 0 {
+		t.Errorf("expected no stale voters, got %+v", stale)
+	}
+
+	for _, minutes := range []int{0, -5} {
+		if _, err := svc.ListStaleVoters(ctx, minutes); err == nil {
+			t.Errorf("expected validation error for %d minutes", minutes)
+		}
+	}
+}
+
+func TestVoterService_VoterGroups(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewVoterService(log, repo, services.NewSettingsService(log, repo))
+	ctx := context.Background()
+
+	id, _, err := svc.CreateVoter(ctx, services.Voter{Name: "Alex", VoterGroup: "  Wolf Den  "})
+	if err != nil {
+		t.Fatalf("CreateVoter failed: %v", err)
+	}
+	if _, _, err := svc.CreateVoter(ctx, services.Voter{Name: "Sam", VoterType: "racer"}); err != nil {
+		t.Fatalf("CreateVoter failed: %v", err)
+	}
+
+	wolves, err := svc.ListVotersInGroup(ctx, "Wolf Den")
+	if err != nil {
+		t.Fatalf("ListVotersInGroup failed: %v", err)
+	}
+	if len(wolves) != 1 || wolves[0]["name"] != "Alex" {
+		t.Errorf("expected Alex in Wolf Den, got %v", wolves)
+	}
+	if ungrouped, _ := svc.ListVotersInGroup(ctx, ""); len(ungrouped) != 1 || ungrouped[0]["name"] != "Sam" {
+		t.Errorf("expected Sam ungrouped, got %v", ungrouped)
+	}
+
+	// The group is independent of the voter type and survives an update
+	if err := svc.UpdateVoter(ctx, services.Voter{ID: int(id), Name: "Alex", VoterType: "racer", VoterGroup: "Bear Den"}); err != nil {
+		t.Fatalf("UpdateVoter failed: %v", err)
+	}
+	turnout, err := svc.VoterGroupTurnout(ctx)
+	if err != nil {
+		t.Fatalf("VoterGroupTurnout failed: %v", err)
+	}
+	if len(turnout) != 2 || turnout[0].VoterGroup != "Bear Den" || turnout[0].Voters != 1 || turnout[1].VoterGroup != "" {
+		t.Errorf("unexpected turnout: %+v", turnout)
+	}
+}
+
+func TestVoterFilter_IsEmpty(t *testing.T) {
+	hasVoted := true
+	tests := []struct {
+		name   string
+		filter services.VoterFilter
+		want   bool
+	}{
+		{"empty", services.VoterFilter{}, true},
+		{"voter type", services.VoterFilter{VoterType: "general"}, false},
+		{"has voted", services.VoterFilter{HasVoted: &hasVoted}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.IsEmpty(); got != tt.want {
+				t.Errorf("IsEmpty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestVoterService_ListVoters_Empty(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -454,6 +730,36 @@ func TestVoterService_GenerateQRImage_ExistingVoter(t *testing.T) {
 	}
 }
 
+func TestVoterService_GenerateQRImage_ReadOnlyUsesVoteURL(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "derby.db")
+	writer, err := repository.New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	writer.SetSetting(ctx, "base_url", "http://test.local:8080")
+	id, _ := writer.CreateVoter(ctx, "RO-QR")
+	writer.Close()
+
+	repo, err := repository.NewReadOnly(path)
+	if err != nil {
+		t.Fatalf("NewReadOnly failed: %v", err)
+	}
+	defer repo.Close()
+	log := logger.New()
+	svc := services.NewVoterService(log, repo, services.NewSettingsService(log, repo))
+
+	// No short link exists and a read-only instance cannot create one
+	imageData, err := svc.GenerateQRImage(ctx, id)
+	if err != nil {
+		t.Fatalf("GenerateQRImage failed: %v", err)
+	}
+	want, _ := qrcode.Encode("http://test.local:8080/vote/RO-QR", qrcode.Medium, 256)
+	if !bytes.Equal(imageData, want) {
+		t.Error("expected the QR code to encode the full voting URL")
+	}
+}
+
 func TestVoterService_GenerateQRImage_NonExistentVoter(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -505,6 +811,54 @@ func TestVoterService_GenerateQRImage_WithCustomBaseURL(t *testing.T) {
 	}
 }
 
+func TestVoterService_ShortToken(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	svc := services.NewVoterService(log, repo, settingsSvc)
+	ctx := context.Background()
+
+	if err := settingsSvc.SetBaseURL(ctx, "http://test.local:8080"); err != nil {
+		t.Fatalf("SetBaseURL failed: %v", err)
+	}
+	id, _, err := svc.CreateVoter(ctx, services.Voter{Name: "Short Link", QRCode: "SHORT-1"})
+	if err != nil {
+		t.Fatalf("CreateVoter failed: %v", err)
+	}
+
+	// Generating the QR image creates the voter's token
+	if _, err := svc.GenerateQRImage(ctx, int(id)); err != nil {
+		t.Fatalf("GenerateQRImage failed: %v", err)
+	}
+	token, err := repo.GetVoterShortToken(ctx, int(id))
+	if err != nil {
+		t.Fatalf("expected a short token after generating the QR image: %v", err)
+	}
+	if len(token) != 6 {
+		t.Errorf("expected a 6 character token, got %q", token)
+	}
+
+	// The token is stable
+	again, err := svc.ShortToken(ctx, int(id))
+	if err != nil {
+		t.Fatalf("ShortToken failed: %v", err)
+	}
+	if again != token {
+		t.Errorf("expected token %q to be reused, got %q", token, again)
+	}
+
+	qrCode, err := svc.ResolveShortToken(ctx, strings.ToLower(token))
+	if err != nil {
+		t.Fatalf("ResolveShortToken failed: %v", err)
+	}
+	if qrCode != "SHORT-1" {
+		t.Errorf("expected qr_code SHORT-1, got %q", qrCode)
+	}
+	if _, err := svc.ResolveShortToken(ctx, "NOPE22"); err == nil {
+		t.Error("expected error for unknown token")
+	}
+}
+
 func TestVoterService_CreateVoter_WithCarID(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -787,6 +1141,70 @@ func TestVoterService_GenerateUniqueCode_RetriesOnDuplicate(t *testing.T) {
 	}
 }
 
+func TestVoterService_BindDevice(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, realRepo)
+	svc := services.NewVoterService(log, realRepo, settingsSvc)
+	ctx := context.Background()
+
+	// Off by default: nothing is bound
+	if token, err := svc.BindDevice(ctx, "", "open-one"); err != nil || token != "" {
+		t.Fatalf("expected no binding while disabled, got %q, %v", token, err)
+	}
+
+	settingsSvc.SetOpenVotingOnePerDevice(ctx, true)
+
+	token, err := svc.BindDevice(ctx, "", "open-one")
+	if err != nil || token == "" {
+		t.Fatalf("expected a device token for a new code, got %q, %v", token, err)
+	}
+	if code, ok := svc.DeviceVoterCode(ctx, token); !ok || code != "open-one" {
+		t.Errorf("expected token to be tied to open-one, got %q, %v", code, ok)
+	}
+
+	// The same code keeps working without a new token
+	if again, err := svc.BindDevice(ctx, token, "open-one"); err != nil || again != "" {
+		t.Errorf("expected the bound code to be allowed, got %q, %v", again, err)
+	}
+
+	// A second new code is refused
+	_, err = svc.BindDevice(ctx, token, "open-two")
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrForbidden {
+		t.Errorf("expected forbidden for a second code, got %v", err)
+	}
+
+	// Voters created by admins work on any device
+	realRepo.CreateVoter(ctx, "ADMIN-QR")
+	if _, err := svc.BindDevice(ctx, token, "ADMIN-QR"); err != nil {
+		t.Errorf("expected an existing voter to be allowed, got %v", err)
+	}
+}
+
+func TestVoterService_DeviceVoterCode_RejectsTamperedToken(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, realRepo)
+	svc := services.NewVoterService(log, realRepo, settingsSvc)
+	ctx := context.Background()
+	settingsSvc.SetOpenVotingOnePerDevice(ctx, true)
+
+	token, _ := svc.BindDevice(ctx, "", "open-one")
+	sig := token[strings.LastIndex(token, ".")+1:]
+
+	for _, bad := range []string{"", "open-one", "open-two." + sig, token + "00"} {
+		if _, ok := svc.DeviceVoterCode(ctx, bad); ok {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+
+	settingsSvc.SetOpenVotingOnePerDevice(ctx, false)
+	if _, ok := svc.DeviceVoterCode(ctx, token); ok {
+		t.Error("expected tokens to be ignored while disabled")
+	}
+}
+
 func TestVoterService_GenerateDynamicQRImage_Success(t *testing.T) {
 	realRepo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -836,6 +1254,31 @@ func TestVoterService_GenerateDynamicQRImage_OpenVotingDisabled(t *testing.T) {
 	}
 }
 
+func TestVoterService_GenerateCategoryQRImage(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, realRepo)
+	svc := services.NewVoterService(log, realRepo, settingsSvc)
+	ctx := context.Background()
+
+	settingsSvc.SetRequireRegisteredQR(ctx, false)
+	settingsSvc.SetBaseURL(ctx, "http://localhost:8080/")
+
+	png, err := svc.GenerateCategoryQRImage(ctx, 7)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	want, _ := qrcode.Encode("http://localhost:8080/vote/new?category=7", qrcode.Medium, 256)
+	if !bytes.Equal(png, want) {
+		t.Error("expected the QR code to encode the category's open voting URL")
+	}
+
+	settingsSvc.SetRequireRegisteredQR(ctx, true)
+	if _, err := svc.GenerateCategoryQRImage(ctx, 7); err != services.ErrOpenVotingDisabled {
+		t.Errorf("expected ErrOpenVotingDisabled, got: %v", err)
+	}
+}
+
 func TestVoterService_GenerateDynamicQRImage_BaseURLNotConfigured(t *testing.T) {
 	realRepo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -1025,3 +1468,47 @@ func TestVoterService_GenerateUniqueCode_RandReadFailure(t *testing.T) {
 		t.Errorf("expected 'failed to generate random code' in error, got: %v", err)
 	}
 }
+
+func TestVoterService_SeedMockVoters(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	svc := services.NewVoterService(log, repo, services.NewSettingsService(log, repo))
+	ctx := context.Background()
+
+	count, err := svc.SeedMockVoters(ctx)
+	if err != nil {
+		t.Fatalf("SeedMockVoters failed: %v", err)
+	}
+	if count != 12 {
+		t.Errorf("expected 12 voters seeded, got %d", count)
+	}
+
+	// Reseeding is idempotent
+	count, err = svc.SeedMockVoters(ctx)
+	if err != nil {
+		t.Fatalf("SeedMockVoters (second) failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected 0 voters on reseed, got %d", count)
+	}
+
+	voters, _ := repo.ListVoters(ctx)
+	if len(voters) != 12 {
+		t.Errorf("expected 12 voters in database, got %d", len(voters))
+	}
+}
+
+func TestVoterService_SeedMockVoters_LookupError(t *testing.T) {
+	mockRepo := mock.NewRepository(testutil.NewTestRepository(t))
+	mockRepo.GetVoterByQRCodeError = errors.New("database error")
+	log := logger.New()
+	svc := services.NewVoterService(log, mockRepo, services.NewSettingsService(log, mockRepo))
+
+	count, err := svc.SeedMockVoters(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if count != 0 {
+		t.Errorf("expected 0 voters added, got %d", count)
+	}
+}
@@ -2,7 +2,14 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	stderrors "errors"
+	"fmt"
+	mathrand "math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
@@ -26,6 +33,9 @@ type VotingService struct {
 	category CategoryServicer
 	car      CarServicer
 	settings SettingsServicer
+	events   EventSink
+	writes   *WriteGauge
+	pseudo   *Pseudonymizer
 }
 
 // NewVotingService creates a new VotingService
@@ -49,11 +59,13 @@ type VoteData struct {
 
 // VoteResult contains the result of a vote submission
 type VoteResult struct {
-	Status               string `json:"status"`
-	Message              string `json:"message"`
-	ConflictCleared      bool   `json:"conflict_cleared,omitempty"`
-	ConflictCategoryID   int    `json:"conflict_category_id,omitempty"`
-	ConflictCategoryName string `json:"conflict_category_name,omitempty"`
+	Status               string      `json:"status"`
+	Message              string      `json:"message"`
+	ConflictCleared      bool        `json:"conflict_cleared,omitempty"`
+	ConflictCategoryID   int         `json:"conflict_category_id,omitempty"`
+	ConflictCategoryName string      `json:"conflict_category_name,omitempty"`
+	WriteInCarID         int         `json:"write_in_car_id,omitempty"` // Car a write-in resolved to
+	Tally                map[int]int `json:"tally,omitempty"`           // Car ID → votes; only set on request for live-count categories
 }
 
 // GetVoteData retrieves all data needed for voting
@@ -63,6 +75,9 @@ func (s *VotingService) GetVoteData(ctx context.Context, qrCode string) (*VoteDa
 	if err != nil {
 		return nil, err
 	}
+	if err := s.repo.TouchVoterActivity(ctx, voterID); err != nil {
+		return nil, err
+	}
 
 	// Get voter type
 	voterType, err := s.repo.GetVoterType(ctx, voterID)
@@ -91,8 +106,8 @@ func (s *VotingService) GetVoteData(ctx context.Context, qrCode string) (*VoteDa
 		return nil, err
 	}
 
-	// Get voting instructions (if configured)
-	instructions, _ := s.settings.GetSetting(ctx, "voting_instructions")
+	// Get voting instructions for this voter type (if configured)
+	instructions, _ := s.settings.GetVotingInstructions(ctx, voterType)
 
 	return &VoteData{
 		Categories:   categories,
@@ -102,6 +117,335 @@ func (s *VotingService) GetVoteData(ctx context.Context, qrCode string) (*VoteDa
 	}, nil
 }
 
+// VoterInstructions are the voting instructions shown to a voter
+type VoterInstructions struct {
+	VoterType    string `json:"voter_type"`
+	Instructions string `json:"instructions"`
+}
+
+// GetVoterInstructions returns the voting instructions for the voter type of
+// the given QR code without creating the voter. Unknown QR codes get the
+// instructions for general voters, or are rejected as forbidden when
+// pre-registered QR codes are required.
+func (s *VotingService) GetVoterInstructions(ctx context.Context, qrCode string) (*VoterInstructions, error) {
+	voterType := "general"
+	voterID, err := s.repo.GetVoterByQR(ctx, qrCode)
+	switch {
+	case err == repository.ErrNotFound:
+		requireRegistered, settingsErr := s.settings.RequireRegisteredQR(ctx)
+		if settingsErr != nil {
+			return nil, settingsErr
+		}
+		if requireRegistered {
+			return nil, errors.Forbidden("QR code is not registered")
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if voterType, err = s.repo.GetVoterType(ctx, voterID); err != nil {
+			return nil, err
+		}
+	}
+
+	instructions, err := s.settings.GetVotingInstructions(ctx, voterType)
+	if err != nil {
+		return nil, err
+	}
+	return &VoterInstructions{VoterType: voterType, Instructions: instructions}, nil
+}
+
+// VoteSelection is a voter's current pick in one category
+type VoteSelection struct {
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	CarID        int    `json:"car_id"`
+}
+
+// PendingCategory is a category the voter has not voted in yet
+type PendingCategory struct {
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+}
+
+// VoterVoteSummary lists a voter's selections and the categories still to vote
+// in. Counted is false while complete ballots are required and this one is not.
+type VoterVoteSummary struct {
+	Selections          []VoteSelection   `json:"selections"`
+	Remaining           []PendingCategory `json:"remaining"`
+	Complete            bool              `json:"complete"`
+	CategoriesVoted     int               `json:"categories_voted"`
+	CategoriesAvailable int               `json:"categories_available"`
+	Counted             bool              `json:"counted"`
+	RedirectURL         string            `json:"redirect_url,omitempty"` // Set once Complete, if post_vote_redirect_url is configured
+}
+
+// GetVoterVoteSummary returns what a voter has selected so far without
+// creating the voter. Unknown QR codes are rejected as forbidden when
+// pre-registered QR codes are required, and reported as not found otherwise.
+func (s *VotingService) GetVoterVoteSummary(ctx context.Context, qrCode string) (*VoterVoteSummary, error) {
+	voterID, err := s.repo.GetVoterByQR(ctx, qrCode)
+	if err == repository.ErrNotFound {
+		requireRegistered, settingsErr := s.settings.RequireRegisteredQR(ctx)
+		if settingsErr != nil {
+			return nil, settingsErr
+		}
+		if requireRegistered {
+			return nil, errors.Forbidden("QR code is not registered")
+		}
+		return nil, errors.NotFound("voter not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.TouchVoterActivity(ctx, voterID); err != nil {
+		return nil, err
+	}
+
+	voterType, err := s.repo.GetVoterType(ctx, voterID)
+	if err != nil {
+		return nil, err
+	}
+
+	allCategories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories := filterCategoriesByVoterType(allCategories, voterType)
+
+	votes, err := s.repo.GetVoterVotes(ctx, voterID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &VoterVoteSummary{
+		Selections: []VoteSelection{},
+		Remaining:  []PendingCategory{},
+	}
+	for _, cat := range categories {
+		if carID, ok := votes[cat.ID]; ok {
+			summary.Selections = append(summary.Selections, VoteSelection{CategoryID: cat.ID, CategoryName: cat.Name, CarID: carID})
+		} else {
+			summary.Remaining = append(summary.Remaining, PendingCategory{CategoryID: cat.ID, CategoryName: cat.Name})
+		}
+	}
+	summary.Complete = len(summary.Remaining) == 0
+	summary.CategoriesVoted = len(summary.Selections)
+	summary.CategoriesAvailable = len(categories)
+
+	requireComplete, err := s.settings.RequireCompleteBallot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summary.Counted = summary.Complete || !requireComplete
+	if summary.Complete {
+		if summary.RedirectURL, err = s.settings.PostVoteRedirectURL(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return summary, nil
+}
+
+// VoterValidation is the outcome of checking a scanned QR code before the
+// ballot is shown. Open voting accepts any code, so it only reports Valid.
+type VoterValidation struct {
+	Valid                  bool   `json:"valid"`
+	VoterName              string `json:"voter_name,omitempty"`
+	AlreadyVotedCategories []int  `json:"already_voted_categories,omitempty"`
+}
+
+// ValidateVoterQR checks a scanned QR code without creating a voter or
+// touching their ballot. While pre-registered QR codes are required, unknown
+// codes are not found and known ones return the voter's name and the
+// categories they already voted in.
+func (s *VotingService) ValidateVoterQR(ctx context.Context, qrCode string) (*VoterValidation, error) {
+	requireRegistered, err := s.settings.RequireRegisteredQR(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !requireRegistered {
+		return &VoterValidation{Valid: true}, nil
+	}
+
+	id, exists, err := s.repo.GetVoterByQRCode(ctx, qrCode)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NotFound("QR code is not registered")
+	}
+	voterID := int(id)
+
+	name, err := s.repo.GetVoterName(ctx, voterID)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := s.repo.GetVoterVotes(ctx, voterID)
+	if err != nil {
+		return nil, err
+	}
+	categoryIDs := make([]int, 0, len(votes))
+	for categoryID := range votes {
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	sort.Ints(categoryIDs)
+
+	return &VoterValidation{Valid: true, VoterName: name, AlreadyVotedCategories: categoryIDs}, nil
+}
+
+// PublicCategory is a voter-facing category with the cars that can be voted for in it
+type PublicCategory struct {
+	ID                int         `json:"id"`
+	Name              string      `json:"name"`
+	DisplayOrder      int         `json:"display_order"`
+	GroupID           *int        `json:"group_id"`
+	GroupName         string      `json:"group_name,omitempty"`
+	ExclusivityPoolID *int        `json:"exclusivity_pool_id,omitempty"`
+	AllowedVoterTypes []string    `json:"allowed_voter_types,omitempty"`
+	AllowedRanks      []string    `json:"allowed_ranks,omitempty"`
+	ShowLiveCounts    bool        `json:"show_live_counts"`
+	BannerURL         string      `json:"banner_url,omitempty"`
+	Cars              []PublicCar `json:"cars"`
+}
+
+// PublicCar is a car in the public category listing. VoteCount is only set in
+// categories with show_live_counts, so hidden tallies are never serialized.
+type PublicCar struct {
+	models.Car
+	VoteCount *int `json:"vote_count,omitempty"`
+}
+
+// ListPublicCategories returns active categories, each with the eligible active
+// cars allowed by the category's rank restrictions and car subset. Categories with
+// show_live_counts also carry each car's current vote count, unless results
+// are embargoed.
+func (s *VotingService) ListPublicCategories(ctx context.Context) ([]PublicCategory, error) {
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cars, err := s.repo.ListEligibleCars(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Live counts would give away embargoed results
+	embargoed, err := resultsEmbargoed(ctx, s.settings)
+	if err != nil {
+		return nil, err
+	}
+
+	var counts map[int]map[int]int
+	for _, cat := range categories {
+		if cat.ShowLiveCounts && !embargoed {
+			if counts, err = s.repo.GetVoteResults(ctx); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	result := make([]PublicCategory, 0, len(categories))
+	for _, cat := range categories {
+		showCounts := cat.ShowLiveCounts && !embargoed
+		categoryCars := filterCarsForCategory(cars, cat)
+		publicCars := make([]PublicCar, 0, len(categoryCars))
+		for _, car := range categoryCars {
+			publicCar := PublicCar{Car: car}
+			if showCounts {
+				count := counts[cat.ID][car.ID]
+				publicCar.VoteCount = &count
+			}
+			publicCars = append(publicCars, publicCar)
+		}
+		result = append(result, PublicCategory{
+			ID:                cat.ID,
+			Name:              cat.Name,
+			DisplayOrder:      cat.DisplayOrder,
+			GroupID:           cat.GroupID,
+			GroupName:         cat.GroupName,
+			ExclusivityPoolID: cat.ExclusivityPoolID,
+			AllowedVoterTypes: cat.AllowedVoterTypes,
+			AllowedRanks:      cat.AllowedRanks,
+			ShowLiveCounts:    showCounts,
+			BannerURL:         cat.BannerURL,
+			Cars:              publicCars,
+		})
+	}
+	return result, nil
+}
+
+// LiveTally returns the current vote count of each car competing in a
+// category. It returns nil unless the category is active and has
+// show_live_counts, and while results are embargoed, so hidden tallies never
+// reach voters.
+func (s *VotingService) LiveTally(ctx context.Context, categoryID int) (map[int]int, error) {
+	embargoed, err := resultsEmbargoed(ctx, s.settings)
+	if err != nil || embargoed {
+		return nil, err
+	}
+
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idx := slices.IndexFunc(categories, func(c models.Category) bool { return c.ID == categoryID })
+	if idx < 0 || !categories[idx].ShowLiveCounts {
+		return nil, nil
+	}
+
+	cars, err := s.repo.ListEligibleCars(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.repo.GetVoteResults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tally := make(map[int]int)
+	for _, car := range filterCarsForCategory(cars, categories[idx]) {
+		tally[car.ID] = counts[categoryID][car.ID]
+	}
+	return tally, nil
+}
+
+// filterCarsForCategory returns the cars competing in a category: those in
+// its car subset, if it has one, whose rank the category allows
+func filterCarsForCategory(cars []models.Car, cat models.Category) []models.Car {
+	cars = filterCarsByRank(cars, cat.AllowedRanks)
+	if len(cat.CarIDs) == 0 {
+		return cars
+	}
+	inSubset := make(map[int]bool, len(cat.CarIDs))
+	for _, id := range cat.CarIDs {
+		inSubset[id] = true
+	}
+	filtered := make([]models.Car, 0, len(cat.CarIDs))
+	for _, car := range cars {
+		if inSubset[car.ID] {
+			filtered = append(filtered, car)
+		}
+	}
+	return filtered
+}
+
+// filterCarsByRank returns the cars whose rank is in allowedRanks (all cars if empty)
+func filterCarsByRank(cars []models.Car, allowedRanks []string) []models.Car {
+	filtered := make([]models.Car, 0, len(cars))
+	if len(allowedRanks) == 0 {
+		return append(filtered, cars...)
+	}
+	for _, car := range cars {
+		for _, rank := range allowedRanks {
+			if car.Rank == rank {
+				filtered = append(filtered, car)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 // filterCategoriesByVoterType filters categories to only include those allowed for the voter type
 func filterCategoriesByVoterType(categories []models.Category, voterType string) []models.Category {
 	var filtered []models.Category
@@ -123,6 +467,93 @@ func filterCategoriesByVoterType(categories []models.Category, voterType string)
 	return filtered
 }
 
+// SetEventSink sets where voter registration and vote events are recorded
+func (s *VotingService) SetEventSink(sink EventSink) {
+	s.events = sink
+}
+
+// SetPseudonymizer sets how voters are identified in recorded events
+func (s *VotingService) SetPseudonymizer(p *Pseudonymizer) {
+	s.pseudo = p
+}
+
+// SetWriteGauge sets the gauge that vote write throughput and latency are recorded in
+func (s *VotingService) SetWriteGauge(gauge *WriteGauge) {
+	s.writes = gauge
+}
+
+// observeWrite records a vote write that began at start. Failed writes count
+// toward latency but not throughput, since lock waits are what the gauge is for.
+func (s *VotingService) observeWrite(votes int, start time.Time, err error) {
+	if s.writes == nil {
+		return
+	}
+	if err != nil {
+		votes = 0
+	}
+	s.writes.ObserveWrite(votes, time.Since(start))
+}
+
+// IdleAutoCloseCheckInterval is how often RunIdleAutoClose checks for inactivity
+const IdleAutoCloseCheckInterval = 30 * time.Second
+
+// idleTimeoutReason is the audit log reason for closing voting for inactivity
+const idleTimeoutReason = "idle timeout"
+
+// CloseVotingIfIdle closes voting when it is open, the auto_close_idle_minutes
+// setting is on, and nobody has voted for that many minutes since voting was
+// last opened. Returns whether voting was closed.
+func (s *VotingService) CloseVotingIfIdle(ctx context.Context) (bool, error) {
+	minutes, err := s.settings.GetAutoCloseIdleMinutes(ctx)
+	if err != nil || minutes == 0 {
+		return false, err
+	}
+	open, err := s.settings.IsVotingOpen(ctx)
+	if err != nil || !open {
+		return false, err
+	}
+
+	idleSince := time.Now().Add(-time.Duration(minutes) * time.Minute)
+	openedAt, err := s.settings.VotingOpenedAt(ctx)
+	if err != nil || openedAt.After(idleSince) {
+		return false, err
+	}
+	voted, err := s.repo.AnyVoteSince(ctx, idleSince)
+	if err != nil || voted {
+		return false, err
+	}
+
+	if err := s.settings.CloseVoting(ctx); err != nil {
+		return false, err
+	}
+	s.log.InfoContext(ctx, "Voting automatically closed after no votes", "idle_minutes", minutes)
+	if err := s.repo.AddAuditEntry(ctx, models.AuditEntry{
+		Action: models.AuditActionVotingAutoClosed,
+		Reason: idleTimeoutReason,
+	}); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// RunIdleAutoClose checks every interval whether voting should close for
+// inactivity, until ctx is cancelled
+func (s *VotingService) RunIdleAutoClose(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.CloseVotingIfIdle(ctx); err != nil {
+				s.log.ErrorContext(ctx, "Idle auto-close check failed", "error", err)
+			}
+		}
+	}
+}
+
 // GetOrCreateVoter gets an existing voter or creates a new one based on settings
 func (s *VotingService) GetOrCreateVoter(ctx context.Context, qrCode string) (int, error) {
 	voterID, err := s.repo.GetVoterByQR(ctx, qrCode)
@@ -135,21 +566,25 @@ func (s *VotingService) GetOrCreateVoter(ctx context.Context, qrCode string) (in
 		if requireRegistered {
 			return 0, ErrUnregisteredQR
 		}
-		return s.repo.CreateVoter(ctx, qrCode)
+		voterID, err := s.repo.CreateVoter(ctx, qrCode)
+		if err != nil {
+			return 0, err
+		}
+		recordEvent(s.events, Event{Type: EventVoterRegistered, VoterID: s.pseudo.VoterID(qrCode)})
+		return voterID, nil
 	}
 	return voterID, err
 }
 
-// SubmitVote processes a vote submission with exclusivity conflict handling
+// SubmitVote processes a vote submission with exclusivity conflict handling.
+// A vote for a car the voter already chose elsewhere in the same exclusivity pool
+// is rejected with a conflict error, unless vote.Replace is set, in which case the
+// earlier vote is cleared and the new one saved.
 func (s *VotingService) SubmitVote(ctx context.Context, vote models.Vote) (*VoteResult, error) {
-	// Check if voting is open
-	open, err := s.settings.IsVotingOpen(ctx)
-	if err != nil {
+	// Fail fast before creating the voter; SaveVote enforces this again
+	if err := s.requireVotingOpen(ctx, false); err != nil {
 		return nil, err
 	}
-	if !open {
-		return nil, ErrVotingClosed
-	}
 
 	// Get or create voter
 	voterID, err := s.GetOrCreateVoter(ctx, vote.VoterQR)
@@ -157,6 +592,76 @@ func (s *VotingService) SubmitVote(ctx context.Context, vote models.Vote) (*Vote
 		return nil, err
 	}
 
+	return s.castVote(ctx, voterID, vote, false)
+}
+
+// maxAssistReasonLength caps the note recorded with an assisted vote
+const maxAssistReasonLength = 500
+
+// SubmitAssistedVote records a vote an admin casts on behalf of an existing
+// voter, such as a helper at a kiosk for a young scout. It is saved even while
+// voting is closed but is otherwise checked like SubmitVote, and is recorded
+// in the audit log with the optional reason.
+func (s *VotingService) SubmitAssistedVote(ctx context.Context, voterID int, vote models.Vote, reason string) (*VoteResult, error) {
+	reason = strings.TrimSpace(reason)
+	fields := map[string]string{}
+	if vote.CategoryID <= 0 {
+		fields["category_id"] = "category_id is required"
+	}
+	if vote.CarID <= 0 && strings.TrimSpace(vote.WriteIn) == "" {
+		fields["car_id"] = "car_id or write_in is required"
+	}
+	if len([]rune(reason)) > maxAssistReasonLength {
+		fields["reason"] = fmt.Sprintf("reason must be at most %d characters", maxAssistReasonLength)
+	}
+	if len(fields) > 0 {
+		return nil, errors.InvalidFields(fields)
+	}
+
+	qrCode, err := s.repo.GetVoterQRCode(ctx, voterID)
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFound("voter not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	vote.VoterQR = qrCode
+
+	result, err := s.castVote(ctx, voterID, vote, true)
+	if err != nil {
+		return nil, err
+	}
+
+	carID := vote.CarID
+	if result.WriteInCarID != 0 {
+		carID = result.WriteInCarID
+	}
+	if err := s.repo.AddAuditEntry(ctx, models.AuditEntry{
+		Action:     models.AuditActionVoteAssisted,
+		VoterID:    &voterID,
+		CategoryID: &vote.CategoryID,
+		CarID:      &carID,
+		Reason:     reason,
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// castVote checks and saves a vote for a known voter. Override saves it even
+// while voting is closed.
+func (s *VotingService) castVote(ctx context.Context, voterID int, vote models.Vote, override bool) (*VoteResult, error) {
+	// Resolve a write-in to its car so it is saved like any other vote
+	var writeInCarID int
+	var err error
+	if vote.CarID == 0 && strings.TrimSpace(vote.WriteIn) != "" {
+		writeInCarID, err = s.resolveWriteIn(ctx, vote.CategoryID, vote.WriteIn)
+		if err != nil {
+			return nil, err
+		}
+		vote.CarID = writeInCarID
+	}
+
 	var conflictCategoryID int
 	var conflictCategoryName string
 	var hadConflict bool
@@ -173,33 +678,62 @@ func (s *VotingService) SubmitVote(ctx context.Context, vote models.Vote) (*Vote
 			}
 			return nil, err
 		}
-		if !car.Eligible {
+		if !car.Eligible && !car.WriteIn {
 			return nil, ErrCarNotEligible
 		}
+		if car.WriteIn {
+			cat, err := s.findCategory(ctx, vote.CategoryID)
+			if err != nil {
+				return nil, err
+			}
+			if err := requireWriteInAllowed(cat); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.requireCarInCategory(ctx, vote.CategoryID, vote.CarID); err != nil {
+			return nil, err
+		}
 		conflictCategoryID, conflictCategoryName, hadConflict, err = s.checkExclusivityConflict(ctx, voterID, vote.CarID, vote.CategoryID)
 		if err != nil {
 			return nil, err
 		}
 
-		// Clear conflicting vote if found
-		if hadConflict {
-			if err := s.repo.ClearConflictingVote(ctx, voterID, conflictCategoryID, vote.CarID); err != nil {
-				return nil, err
-			}
-			s.log.Info("Cleared conflicting vote", "voter_id", voterID, "category", conflictCategoryID, "car", vote.CarID)
+		// Reject conflicting votes unless the voter asked to replace them
+		if hadConflict && !vote.Replace {
+			return nil, errors.Conflictf("you already voted for this car in %q; only one vote per car is allowed in this group", conflictCategoryName)
+		}
+	}
+
+	// Replacing clears the earlier vote, which is a change too
+	if hadConflict && !override {
+		if err := s.requireVoteChangeAllowed(ctx, voterID, conflictCategoryID, 0); err != nil {
+			return nil, err
 		}
 	}
 
 	// Save the vote
-	if err := s.repo.SaveVote(ctx, voterID, vote.CategoryID, vote.CarID); err != nil {
+	if err := s.SaveVote(ctx, voterID, vote.CategoryID, vote.CarID, override); err != nil {
 		return nil, err
 	}
 
-	s.log.Info("Vote recorded", "qr", vote.VoterQR, "voter_id", voterID, "category", vote.CategoryID, "car", vote.CarID)
+	// Only clear the conflicting vote once the new one is in place
+	if hadConflict {
+		if err := s.repo.ClearConflictingVote(ctx, voterID, conflictCategoryID, vote.CarID); err != nil {
+			return nil, err
+		}
+		s.log.InfoContext(ctx, "Cleared conflicting vote", "voter_id", voterID, "category", conflictCategoryID, "car", vote.CarID)
+	}
+
+	s.log.InfoContext(ctx, "Vote recorded", "qr", vote.VoterQR, "voter_id", voterID, "category", vote.CategoryID, "car", vote.CarID, "assisted", override)
+	if hadConflict {
+		s.recordVote(vote.VoterQR, conflictCategoryID, 0)
+	}
+	s.recordVote(vote.VoterQR, vote.CategoryID, vote.CarID)
 
 	result := &VoteResult{
-		Status:  "success",
-		Message: "Vote recorded",
+		Status:       "success",
+		Message:      "Vote recorded",
+		WriteInCarID: writeInCarID,
 	}
 
 	if hadConflict {
@@ -211,19 +745,828 @@ func (s *VotingService) SubmitVote(ctx context.Context, vote models.Vote) (*Vote
 	return result, nil
 }
 
-// checkExclusivityConflict checks if voting for a car in a category conflicts with existing votes
-func (s *VotingService) checkExclusivityConflict(ctx context.Context, voterID, carID, categoryID int) (conflictCategoryID int, conflictCategoryName string, hasConflict bool, err error) {
-	// Get the exclusivity pool for the target category
-	poolID, hasPool, err := s.repo.GetExclusivityPoolID(ctx, categoryID)
-	if err != nil {
-		return 0, "", false, err
-	}
+// Ballot entry statuses
+const (
+	BallotEntryAccepted   = "accepted"
+	BallotEntryRejected   = "rejected"
+	BallotEntryRolledBack = "rolled_back"
+)
 
-	// If no exclusivity pool, no conflict is possible
-	if !hasPool {
-		return 0, "", false, nil
-	}
+// Ballot is a set of selections submitted together, keyed by category ID.
+// A car ID of 0 removes the voter's selection in that category.
+type Ballot struct {
+	VoterQR      string
+	Votes        map[int]int
+	Replace      bool
+	AllOrNothing bool
+}
 
-	// Check for conflicting votes
-	return s.repo.FindConflictingVote(ctx, voterID, carID, categoryID, poolID)
+// BallotEntryResult is the outcome of one category on a submitted ballot
+type BallotEntryResult struct {
+	CategoryID        int    `json:"category_id"`
+	Status            string `json:"status"`
+	Error             string `json:"error,omitempty"`
+	ClearedCategoryID int    `json:"cleared_category_id,omitempty"`
+}
+
+// BallotResult contains the per-category outcome of a ballot submission.
+// RedirectURL is set once the voter's ballot is complete and the
+// post_vote_redirect_url setting is configured.
+type BallotResult struct {
+	Results     []BallotEntryResult `json:"results"`
+	Accepted    int                 `json:"accepted"`
+	Committed   bool                `json:"committed"`
+	RedirectURL string              `json:"redirect_url,omitempty"`
+}
+
+// SubmitBallot checks every entry of a ballot the same way SubmitVote checks a
+// single vote and saves the accepted entries in one transaction. Rejected
+// entries are reported per category; with AllOrNothing set, any rejection
+// discards the whole ballot.
+func (s *VotingService) SubmitBallot(ctx context.Context, ballot Ballot) (*BallotResult, error) {
+	if len(ballot.Votes) == 0 {
+		return nil, errors.Validation("ballot has no votes")
+	}
+
+	if err := s.requireVotingOpen(ctx, false); err != nil {
+		return nil, err
+	}
+
+	voterID, err := s.GetOrCreateVoter(ctx, ballot.VoterQR)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryList, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories := make(map[int]models.Category, len(categoryList))
+	for _, cat := range categoryList {
+		categories[cat.ID] = cat
+	}
+
+	existing, err := s.repo.GetVoterVotes(ctx, voterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// projected is the voter's ballot as it will stand once saved; categories
+	// on this ballot start empty and fill in as their entries are accepted
+	projected := make(map[int]int, len(existing))
+	for categoryID, carID := range existing {
+		if _, onBallot := ballot.Votes[categoryID]; !onBallot {
+			projected[categoryID] = carID
+		}
+	}
+
+	categoryIDs := make([]int, 0, len(ballot.Votes))
+	for categoryID := range ballot.Votes {
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	sort.Ints(categoryIDs)
+
+	allowChanges, err := s.settings.AllowVoteChanges(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BallotResult{Results: make([]BallotEntryResult, 0, len(categoryIDs))}
+	toSave := make(map[int]int, len(categoryIDs))
+	var rejected bool
+
+	for _, categoryID := range categoryIDs {
+		entry := BallotEntryResult{CategoryID: categoryID, Status: BallotEntryAccepted}
+		carID := ballot.Votes[categoryID]
+
+		cleared, err := s.checkBallotEntry(ctx, ballot, categories, projected, categoryID, carID)
+		if err == nil && !allowChanges {
+			if err = voteChangeError(existing, categoryID, carID); err == nil && cleared != 0 {
+				err = voteChangeError(existing, cleared, 0)
+			}
+		}
+		if err != nil {
+			var appErr *errors.Error
+			var svcErr *ServiceError
+			if !stderrors.As(err, &appErr) && !stderrors.As(err, &svcErr) {
+				return nil, err
+			}
+			entry.Status = BallotEntryRejected
+			entry.Error = err.Error()
+			rejected = true
+			// The voter keeps their earlier pick in a rejected category
+			if prev, ok := existing[categoryID]; ok {
+				projected[categoryID] = prev
+			}
+		} else {
+			if cleared != 0 {
+				toSave[cleared] = 0
+				delete(projected, cleared)
+				entry.ClearedCategoryID = cleared
+			}
+			// Resubmitting a pick the voter already has changes nothing, so it
+			// is not saved again
+			if existing[categoryID] != carID {
+				toSave[categoryID] = carID
+			}
+			if carID != 0 {
+				projected[categoryID] = carID
+			}
+			result.Accepted++
+		}
+		result.Results = append(result.Results, entry)
+	}
+
+	if rejected && ballot.AllOrNothing {
+		for i := range result.Results {
+			if result.Results[i].Status == BallotEntryAccepted {
+				result.Results[i].Status = BallotEntryRolledBack
+			}
+		}
+		result.Accepted = 0
+		s.log.InfoContext(ctx, "Ballot rejected", "qr", ballot.VoterQR, "voter_id", voterID, "entries", len(categoryIDs))
+		return result, nil
+	}
+
+	if len(toSave) > 0 {
+		start := time.Now()
+		err := s.repo.SaveBallot(ctx, voterID, toSave)
+		s.observeWrite(len(toSave), start, err)
+		if err != nil {
+			return nil, err
+		}
+
+		savedIDs := make([]int, 0, len(toSave))
+		for categoryID := range toSave {
+			savedIDs = append(savedIDs, categoryID)
+		}
+		sort.Ints(savedIDs)
+		for _, categoryID := range savedIDs {
+			s.recordVote(ballot.VoterQR, categoryID, toSave[categoryID])
+		}
+	}
+
+	result.Committed = result.Accepted > 0
+
+	voterType, err := s.repo.GetVoterType(ctx, voterID)
+	if err != nil {
+		return nil, err
+	}
+	if ballotComplete(filterCategoriesByVoterType(categoryList, voterType), projected) {
+		if result.RedirectURL, err = s.settings.PostVoteRedirectURL(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	s.log.InfoContext(ctx, "Ballot recorded", "qr", ballot.VoterQR, "voter_id", voterID, "accepted", result.Accepted, "entries", len(categoryIDs))
+	return result, nil
+}
+
+// ballotComplete reports whether votes has a pick in every one of categories
+func ballotComplete(categories []models.Category, votes map[int]int) bool {
+	for _, cat := range categories {
+		if _, ok := votes[cat.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// checkBallotEntry validates one ballot entry against the projected ballot.
+// It returns the category of an earlier vote that must be cleared to make
+// room for the entry, or 0 if none.
+func (s *VotingService) checkBallotEntry(ctx context.Context, ballot Ballot, categories map[int]models.Category, projected map[int]int, categoryID, carID int) (int, error) {
+	cat, ok := categories[categoryID]
+	if !ok {
+		return 0, errors.NotFound("category not found")
+	}
+	if carID == 0 {
+		return 0, nil
+	}
+
+	car, err := s.repo.GetCar(ctx, carID)
+	if err != nil {
+		var appErr *errors.Error
+		if stderrors.As(err, &appErr) && appErr.Kind == errors.ErrNotFound {
+			return 0, ErrCarNotFound
+		}
+		return 0, err
+	}
+	if err := s.checkCarForCategory(ctx, cat, car); err != nil {
+		return 0, err
+	}
+
+	if cat.ExclusivityPoolID == nil {
+		return 0, nil
+	}
+	for otherID, otherCarID := range projected {
+		other := categories[otherID]
+		if otherID == categoryID || otherCarID != carID || other.ExclusivityPoolID == nil || *other.ExclusivityPoolID != *cat.ExclusivityPoolID {
+			continue
+		}
+		if _, onBallot := ballot.Votes[otherID]; onBallot {
+			return 0, errors.Conflictf("this car is also selected for %q on this ballot; only one vote per car is allowed in this group", other.Name)
+		}
+		if !ballot.Replace {
+			return 0, errors.Conflictf("you already voted for this car in %q; only one vote per car is allowed in this group", other.Name)
+		}
+		return otherID, nil
+	}
+	return 0, nil
+}
+
+// SaveVote records a voter's pick in a category; a car ID of 0 clears it.
+// Every vote write goes through here so that votes are refused while voting
+// is closed, and changes are refused while votes are final. Admin changes set
+// override to save regardless.
+func (s *VotingService) SaveVote(ctx context.Context, voterID, categoryID, carID int, override bool) error {
+	if err := s.requireVotingOpen(ctx, override); err != nil {
+		return err
+	}
+	if !override {
+		if err := s.requireVoteChangeAllowed(ctx, voterID, categoryID, carID); err != nil {
+			return err
+		}
+	}
+	start := time.Now()
+	err := s.repo.SaveVote(ctx, voterID, categoryID, carID)
+	s.observeWrite(1, start, err)
+	return err
+}
+
+// recordVote records a voter's pick in a category, or its removal when carID is 0
+func (s *VotingService) recordVote(qrCode string, categoryID, carID int) {
+	event := Event{Type: EventVoteCast, VoterID: s.pseudo.VoterID(qrCode), CategoryID: categoryID, CarID: carID}
+	if carID == 0 {
+		event.Type = EventVoteCleared
+	}
+	recordEvent(s.events, event)
+}
+
+// maxWriteInLength caps the length of a write-in car name
+const maxWriteInLength = 100
+
+// resolveWriteIn returns the car for a write-in vote, creating it on first use.
+// The category must allow write-ins.
+func (s *VotingService) resolveWriteIn(ctx context.Context, categoryID int, name string) (int, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if len(name) > maxWriteInLength {
+		return 0, errors.Validationf("write-in must be at most %d characters", maxWriteInLength)
+	}
+
+	cat, err := s.findCategory(ctx, categoryID)
+	if err != nil {
+		return 0, err
+	}
+	if err := requireWriteInAllowed(cat); err != nil {
+		return 0, err
+	}
+	return s.repo.GetOrCreateWriteInCar(ctx, name)
+}
+
+// findCategory returns the active category with the given ID
+func (s *VotingService) findCategory(ctx context.Context, categoryID int) (models.Category, error) {
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return models.Category{}, err
+	}
+	for _, cat := range categories {
+		if cat.ID == categoryID {
+			return cat, nil
+		}
+	}
+	return models.Category{}, errors.NotFound("category not found")
+}
+
+// requireWriteInAllowed rejects a write-in, new or an existing write-in car,
+// in a category that does not accept them
+func requireWriteInAllowed(cat models.Category) error {
+	if !cat.AllowWriteIn {
+		return errors.Validation("this category does not accept write-in votes")
+	}
+	return nil
+}
+
+// checkCarForCategory applies the checks every vote for car in cat gets: the
+// car must be eligible, a write-in only where the category accepts them, and
+// in the category's car subset. Write-ins take votes before an admin approves
+// them; approval only decides whether they can win.
+func (s *VotingService) checkCarForCategory(ctx context.Context, cat models.Category, car *models.Car) error {
+	if !car.Eligible && !car.WriteIn {
+		return ErrCarNotEligible
+	}
+	if car.WriteIn {
+		if err := requireWriteInAllowed(cat); err != nil {
+			return err
+		}
+	}
+	return s.requireCarInCategory(ctx, cat.ID, car.ID)
+}
+
+// requireCarInCategory rejects a car outside the category's car subset
+func (s *VotingService) requireCarInCategory(ctx context.Context, categoryID, carID int) error {
+	allowed, err := s.repo.CategoryAllowsCar(ctx, categoryID, carID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.Validation("car is not competing in this category")
+	}
+	return nil
+}
+
+// requireVotingOpen returns ErrVotingClosed while voting is closed, unless override is set
+func (s *VotingService) requireVotingOpen(ctx context.Context, override bool) error {
+	if override {
+		return nil
+	}
+	open, err := s.settings.IsVotingOpen(ctx)
+	if err != nil {
+		return err
+	}
+	if !open {
+		return ErrVotingClosed
+	}
+	return nil
+}
+
+// requireVoteChangeAllowed returns a conflict error when the allow_vote_changes
+// setting is off and saving carID would change the voter's pick in categoryID
+func (s *VotingService) requireVoteChangeAllowed(ctx context.Context, voterID, categoryID, carID int) error {
+	allowed, err := s.settings.AllowVoteChanges(ctx)
+	if err != nil || allowed {
+		return err
+	}
+	votes, err := s.repo.GetVoterVotes(ctx, voterID)
+	if err != nil {
+		return err
+	}
+	return voteChangeError(votes, categoryID, carID)
+}
+
+// voteChangeError returns a conflict error if existing already holds a
+// different pick for categoryID; a first vote in the category is always allowed
+func voteChangeError(existing map[int]int, categoryID, carID int) error {
+	if prev, ok := existing[categoryID]; ok && prev != carID {
+		return errors.Conflict("votes are final once cast and cannot be changed")
+	}
+	return nil
+}
+
+// checkExclusivityConflict checks if voting for a car in a category conflicts with existing votes
+func (s *VotingService) checkExclusivityConflict(ctx context.Context, voterID, carID, categoryID int) (conflictCategoryID int, conflictCategoryName string, hasConflict bool, err error) {
+	// Get the exclusivity pool for the target category
+	poolID, hasPool, err := s.repo.GetExclusivityPoolID(ctx, categoryID)
+	if err != nil {
+		return 0, "", false, err
+	}
+
+	// If no exclusivity pool, no conflict is possible
+	if !hasPool {
+		return 0, "", false, nil
+	}
+
+	// Check for conflicting votes
+	return s.repo.FindConflictingVote(ctx, voterID, carID, categoryID, poolID)
+}
+
+// VotesExport holds an instance's voters and votes. It is what
+// GET /api/admin/votes/export returns and what MergeVotes accepts, so votes
+// recorded on a failover instance can be merged back.
+type VotesExport struct {
+	ExportedAt string        `json:"exported_at"`
+	Voters     []ExportVoter `json:"voters"`
+	Votes      []ExportVote  `json:"votes"`
+}
+
+// ExportVoter is a voter in a votes export
+type ExportVoter struct {
+	QRCode    string `json:"qr_code"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	VoterType string `json:"voter_type,omitempty"`
+	Notes     string `json:"notes,omitempty"`
+	CarNumber string `json:"car_number,omitempty"` // The voter's own car
+}
+
+// ExportVote is a vote in a votes export. Categories are matched by name and
+// cars by number when merging, since IDs can differ between instances.
+type ExportVote struct {
+	VoterQR      string `json:"voter_qr"`
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	CarID        int    `json:"car_id"`
+	CarNumber    string `json:"car_number"`
+	CarName      string `json:"car_name,omitempty"`
+	WriteIn      bool   `json:"write_in,omitempty"` // Matched by car_name instead of car_number
+	VotedAt      string `json:"voted_at,omitempty"`
+}
+
+// MergeConflict is a vote from the other instance that disagrees with one
+// recorded here and was left for manual resolution
+type MergeConflict struct {
+	VoterQR              string `json:"voter_qr"`
+	CategoryID           int    `json:"category_id"`
+	CategoryName         string `json:"category_name"`
+	LocalCarID           int    `json:"local_car_id,omitempty"` // The voter's car in this category here
+	RemoteCarID          int    `json:"remote_car_id"`
+	RemoteCarNumber      string `json:"remote_car_number"`
+	ConflictCategoryID   int    `json:"conflict_category_id,omitempty"` // Category in the same exclusivity pool that already has this car
+	ConflictCategoryName string `json:"conflict_category_name,omitempty"`
+	Reason               string `json:"reason"`
+}
+
+// MergeSkipped is a vote from the other instance that could not be matched here
+type MergeSkipped struct {
+	VoterQR      string `json:"voter_qr"`
+	CategoryName string `json:"category_name"`
+	CarNumber    string `json:"car_number"`
+	Reason       string `json:"reason"`
+}
+
+// MergeResult reports the outcome of merging another instance's votes
+type MergeResult struct {
+	VotersAdded int             `json:"voters_added"`
+	VotesAdded  int             `json:"votes_added"`
+	Duplicates  int             `json:"duplicates"`
+	Conflicts   []MergeConflict `json:"conflicts"`
+	Skipped     []MergeSkipped  `json:"skipped"`
+}
+
+// ExportVotes returns every voter and vote in the form MergeVotes accepts
+func (s *VotingService) ExportVotes(ctx context.Context) (*VotesExport, error) {
+	voterRows, err := s.repo.ListVotersForExport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	voteRows, err := s.repo.ListVotesForExport(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &VotesExport{
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Voters:     make([]ExportVoter, 0, len(voterRows)),
+		Votes:      make([]ExportVote, 0, len(voteRows)),
+	}
+	for _, v := range voterRows {
+		export.Voters = append(export.Voters, ExportVoter{
+			QRCode:    v.QRCode,
+			Name:      v.Name,
+			Email:     v.Email,
+			VoterType: v.VoterType,
+			Notes:     v.Notes,
+			CarNumber: v.CarNumber,
+		})
+	}
+	for _, v := range voteRows {
+		export.Votes = append(export.Votes, ExportVote{
+			VoterQR:      v.VoterQR,
+			CategoryID:   v.CategoryID,
+			CategoryName: v.CategoryName,
+			CarID:        v.CarID,
+			CarNumber:    v.CarNumber,
+			CarName:      v.CarName,
+			WriteIn:      v.WriteIn,
+			VotedAt:      v.VotedAt,
+		})
+	}
+	return export, nil
+}
+
+// MergeVotes adds the voters and votes from another instance's export.
+// Categories are matched by name and cars by number. A vote is added only if
+// the voter has no vote here in that category; the same vote is counted as a
+// duplicate, and a different car, or a car that breaks an exclusivity pool,
+// is reported as a conflict without changing either vote. Votes that would be
+// refused if cast here, such as for an ineligible car or by a voter type the
+// category excludes, are skipped. Merged votes keep the time they were cast.
+func (s *VotingService) MergeVotes(ctx context.Context, export VotesExport) (*MergeResult, error) {
+	if len(export.Voters) == 0 && len(export.Votes) == 0 {
+		return nil, errors.Validation("export contains no voters or votes")
+	}
+
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categoryByName := make(map[string]models.Category, len(categories))
+	for _, cat := range categories {
+		categoryByName[strings.ToLower(strings.TrimSpace(cat.Name))] = cat
+	}
+	cars, err := s.repo.ListCars(ctx)
+	if err != nil {
+		return nil, err
+	}
+	carByNumber := make(map[string]models.Car, len(cars))
+	for _, car := range cars {
+		if car.CarNumber != "" {
+			carByNumber[car.CarNumber] = car
+		}
+	}
+
+	voters := make([]repository.VoterMergeRow, 0, len(export.Voters))
+	exported := make(map[string]bool, len(export.Voters))
+	voterTypes := make(map[string]string, len(export.Voters))
+	for _, v := range export.Voters {
+		if v.QRCode == "" || exported[v.QRCode] {
+			continue
+		}
+		exported[v.QRCode] = true
+		row := repository.VoterMergeRow{QRCode: v.QRCode, Name: v.Name, Email: v.Email, VoterType: v.VoterType, Notes: v.Notes}
+		if row.VoterType == "" {
+			row.VoterType = "general"
+		}
+		if car, ok := carByNumber[v.CarNumber]; ok {
+			row.CarID = &car.ID
+		}
+		voterTypes[v.QRCode] = row.VoterType
+		voters = append(voters, row)
+	}
+
+	result := &MergeResult{Conflicts: []MergeConflict{}, Skipped: []MergeSkipped{}}
+	localVoterIDs := make(map[string]int)
+	localVotes := make(map[string]map[int]int)
+	// Write-ins with no car here yet get a negative placeholder ID so repeats
+	// of the same name compare equal; the car is created by the merge itself
+	newWriteIns := make(map[string]int)
+	var votes []repository.VoteMergeRow
+	for _, v := range export.Votes {
+		skip := func(reason string) {
+			result.Skipped = append(result.Skipped, MergeSkipped{VoterQR: v.VoterQR, CategoryName: v.CategoryName, CarNumber: v.CarNumber, Reason: reason})
+		}
+		if v.VoterQR == "" {
+			skip("missing voter_qr")
+			continue
+		}
+		cat, ok := categoryByName[strings.ToLower(strings.TrimSpace(v.CategoryName))]
+		if !ok {
+			skip("no active category with this name")
+			continue
+		}
+
+		var car *models.Car
+		var carID int
+		var writeIn string
+		if v.WriteIn {
+			writeIn = strings.Join(strings.Fields(v.CarName), " ")
+			if writeIn == "" {
+				skip("write-in vote without a car name")
+				continue
+			}
+			if len(writeIn) > maxWriteInLength {
+				skip(fmt.Sprintf("write-in must be at most %d characters", maxWriteInLength))
+				continue
+			}
+			carID, err = s.repo.FindWriteInCar(ctx, writeIn)
+			switch {
+			case err == nil:
+				if car, err = s.repo.GetCar(ctx, carID); err != nil {
+					return nil, err
+				}
+			case err == repository.ErrNotFound:
+				key := strings.ToLower(writeIn)
+				if carID, ok = newWriteIns[key]; !ok {
+					carID = -(len(newWriteIns) + 1)
+					newWriteIns[key] = carID
+				}
+			default:
+				return nil, err
+			}
+		} else {
+			found, ok := carByNumber[v.CarNumber]
+			if !ok {
+				skip("no car with this number")
+				continue
+			}
+			car, carID = &found, found.ID
+		}
+
+		// Votes already recorded here, including ones queued earlier in this merge
+		current, loaded := localVotes[v.VoterQR]
+		if !loaded {
+			current = map[int]int{}
+			voterID, err := s.repo.GetVoterByQR(ctx, v.VoterQR)
+			switch {
+			case err == nil:
+				localVoterIDs[v.VoterQR] = voterID
+				if current, err = s.repo.GetVoterVotes(ctx, voterID); err != nil {
+					return nil, err
+				}
+				// An existing voter keeps their type here
+				if voterTypes[v.VoterQR], err = s.repo.GetVoterType(ctx, voterID); err != nil {
+					return nil, err
+				}
+			case err != repository.ErrNotFound:
+				return nil, err
+			}
+			localVotes[v.VoterQR] = current
+		}
+
+		if localCarID, voted := current[cat.ID]; voted {
+			if localCarID == carID {
+				result.Duplicates++
+				continue
+			}
+			result.Conflicts = append(result.Conflicts, MergeConflict{
+				VoterQR:         v.VoterQR,
+				CategoryID:      cat.ID,
+				CategoryName:    v.CategoryName,
+				LocalCarID:      localCarID,
+				RemoteCarID:     max(carID, 0),
+				RemoteCarNumber: v.CarNumber,
+				Reason:          "voted for a different car on each instance",
+			})
+			continue
+		}
+		if other, ok := poolConflict(categories, current, cat, carID); ok {
+			result.Conflicts = append(result.Conflicts, MergeConflict{
+				VoterQR:              v.VoterQR,
+				CategoryID:           cat.ID,
+				CategoryName:         v.CategoryName,
+				RemoteCarID:          max(carID, 0),
+				RemoteCarNumber:      v.CarNumber,
+				ConflictCategoryID:   other.ID,
+				ConflictCategoryName: other.Name,
+				Reason:               "car already has this voter's vote in a category in the same exclusivity pool",
+			})
+			continue
+		}
+
+		// The checks a vote cast here would get
+		voterType := voterTypes[v.VoterQR]
+		if voterType == "" {
+			voterType = "general"
+		}
+		if len(filterCategoriesByVoterType([]models.Category{cat}, voterType)) == 0 {
+			skip("voter type is not allowed to vote in this category")
+			continue
+		}
+		if err := s.checkMergedCar(ctx, cat, car); err != nil {
+			var appErr *errors.Error
+			var svcErr *ServiceError
+			if !stderrors.As(err, &appErr) && !stderrors.As(err, &svcErr) {
+				return nil, err
+			}
+			skip(err.Error())
+			continue
+		}
+
+		if !exported[v.VoterQR] {
+			if _, known := localVoterIDs[v.VoterQR]; !known {
+				exported[v.VoterQR] = true
+				voters = append(voters, repository.VoterMergeRow{QRCode: v.VoterQR, VoterType: "general"})
+			}
+		}
+		current[cat.ID] = carID
+		row := repository.VoteMergeRow{VoterQR: v.VoterQR, CategoryID: cat.ID, CarID: max(carID, 0)}
+		if carID < 0 {
+			row.WriteIn = writeIn
+		}
+		row.VotedAt = parseVotedAt(v.VotedAt)
+		votes = append(votes, row)
+	}
+
+	if result.VotersAdded, result.VotesAdded, err = s.repo.MergeVotes(ctx, voters, votes); err != nil {
+		return nil, err
+	}
+
+	s.log.InfoContext(ctx, "Merged votes from another instance",
+		"voters_added", result.VotersAdded, "votes_added", result.VotesAdded,
+		"duplicates", result.Duplicates, "conflicts", len(result.Conflicts), "skipped", len(result.Skipped))
+	return result, nil
+}
+
+// votedAtLayouts lists the formats an exported voted_at may be in. Values
+// without a zone (such as SQLite's CURRENT_TIMESTAMP) are UTC.
+var votedAtLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05",
+}
+
+// parseVotedAt returns when an exported vote was cast, or the zero time if
+// the value is missing or unreadable
+func parseVotedAt(value string) time.Time {
+	for _, layout := range votedAtLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+// checkMergedCar applies the car checks castVote makes to a merged vote. car
+// is nil for a write-in with no car here yet, which only needs the category
+// to accept write-ins.
+func (s *VotingService) checkMergedCar(ctx context.Context, cat models.Category, car *models.Car) error {
+	if car == nil {
+		return requireWriteInAllowed(cat)
+	}
+	if err := s.checkCarForCategory(ctx, cat, car); err != nil {
+		return err
+	}
+	if !car.WriteIn && len(filterCarsByRank([]models.Car{*car}, cat.AllowedRanks)) == 0 {
+		return errors.Validation("car's rank is not allowed in this category")
+	}
+	return nil
+}
+
+// poolConflict returns the category in cat's exclusivity pool where votes
+// already have carID, if any
+func poolConflict(categories []models.Category, votes map[int]int, cat models.Category, carID int) (models.Category, bool) {
+	if cat.ExclusivityPoolID == nil {
+		return models.Category{}, false
+	}
+	for _, other := range categories {
+		if other.ID == cat.ID || other.ExclusivityPoolID == nil || *other.ExclusivityPoolID != *cat.ExclusivityPoolID {
+			continue
+		}
+		if votes[other.ID] == carID {
+			return other, true
+		}
+	}
+	return models.Category{}, false
+}
+
+// SeedMockVotes casts a random vote in every category each voter may vote in
+// but has not voted in yet, honouring rank filters and exclusivity pools.
+// Requires existing categories and eligible cars.
+func (s *VotingService) SeedMockVotes(ctx context.Context) (int, error) {
+	categories, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return 0, err
+	}
+	cars, err := s.repo.ListEligibleCars(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(categories) == 0 || len(cars) == 0 {
+		return 0, errors.Validation("seeding votes requires existing categories and eligible cars")
+	}
+
+	voters, err := s.repo.ListVoters(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var created int
+	for _, voter := range voters {
+		voterID := int(voter["id"].(int64))
+		voterType, _ := voter["voter_type"].(string)
+
+		votes, err := s.repo.GetVoterVotes(ctx, voterID)
+		if err != nil {
+			return created, err
+		}
+
+		// Cars already chosen within each exclusivity pool
+		usedInPool := make(map[int]map[int]bool)
+		for _, cat := range categories {
+			if carID, ok := votes[cat.ID]; ok && cat.ExclusivityPoolID != nil {
+				markPoolCar(usedInPool, *cat.ExclusivityPoolID, carID)
+			}
+		}
+
+		for _, cat := range filterCategoriesByVoterType(categories, voterType) {
+			if _, voted := votes[cat.ID]; voted {
+				continue
+			}
+
+			candidates := filterCarsForCategory(cars, cat)
+			if cat.ExclusivityPoolID != nil {
+				available := candidates[:0:0]
+				for _, car := range candidates {
+					if !usedInPool[*cat.ExclusivityPoolID][car.ID] {
+						available = append(available, car)
+					}
+				}
+				candidates = available
+			}
+			if len(candidates) == 0 {
+				continue
+			}
+
+			car := candidates[mathrand.IntN(len(candidates))]
+			// Seeding is an admin action, so it is allowed while voting is closed
+			if err := s.SaveVote(ctx, voterID, cat.ID, car.ID, true); err != nil {
+				return created, err
+			}
+			if cat.ExclusivityPoolID != nil {
+				markPoolCar(usedInPool, *cat.ExclusivityPoolID, car.ID)
+			}
+			created++
+		}
+	}
+
+	s.log.InfoContext(ctx, "Seeded mock votes", "count", created)
+	return created, nil
+}
+
+// markPoolCar records that a car has been voted for within an exclusivity pool
+func markPoolCar(used map[int]map[int]bool, poolID, carID int) {
+	if used[poolID] == nil {
+		used[poolID] = make(map[int]bool)
+	}
+	used[poolID][carID] = true
 }
@@ -3,8 +3,13 @@ package services_test
 import (
 	"context"
 	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	apperrors "github.com/abrezinsky/derbyvote/internal/errors"
 	"github.com/abrezinsky/derbyvote/internal/logger"
 	"github.com/abrezinsky/derbyvote/internal/models"
 	"github.com/abrezinsky/derbyvote/internal/repository"
@@ -73,12 +78,12 @@ func TestGetVoteData_ReturnsCategoriesAndCars(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test categories
-	catID1, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID1, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
 
-	catID2, err := repo.CreateCategory(ctx, "Most Creative", 2, nil, nil, nil)
+	catID2, err := repo.CreateCategory(ctx, "Most Creative", 2, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -144,7 +149,7 @@ func TestGetVoteData_ReturnsExistingVotes(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Create test category
-	catID, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -202,7 +207,7 @@ func TestSubmitVote_RecordsNewVote(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Create test category
-	catID, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -269,7 +274,7 @@ func TestSubmitVote_UpdatesExistingVote(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Create test category
-	catID, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -344,7 +349,7 @@ func TestSubmitVote_HandlesDeselection(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Create test category
-	catID, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -424,19 +429,19 @@ func TestSubmitVote_HandlesExclusivityConflicts(t *testing.T) {
 
 	// Create a category group with an exclusivity pool
 	poolID := 1
-	groupID, err := repo.CreateCategoryGroup(ctx, "Speed Awards", "Speed related categories", &poolID, nil, 1)
+	groupID, err := repo.CreateCategoryGroup(ctx, "Speed Awards", "Speed related categories", &poolID, nil, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
 
 	// Create two categories in the same exclusivity pool (via the group)
 	groupIDInt := int(groupID)
-	catID1, err := repo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil)
+	catID1, err := repo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 1 failed: %v", err)
 	}
 
-	catID2, err := repo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil)
+	catID2, err := repo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 2 failed: %v", err)
 	}
@@ -470,12 +475,26 @@ func TestSubmitVote_HandlesExclusivityConflicts(t *testing.T) {
 		t.Error("expected no conflict on first vote")
 	}
 
-	// Vote for the SAME car in second category (should trigger conflict)
+	// Vote for the SAME car in second category without replace (should be rejected)
 	vote2 := models.Vote{
 		VoterQR:    qrCode,
 		CategoryID: int(catID2),
 		CarID:      carID,
 	}
+	_, err = votingSvc.SubmitVote(ctx, vote2)
+	if err == nil {
+		t.Fatal("expected conflict error when replace is not set")
+	}
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrConflict {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Fastest Looking") {
+		t.Errorf("expected error to name the conflicting category, got %q", err.Error())
+	}
+
+	// Retry with replace (should clear the conflicting vote)
+	vote2.Replace = true
 	result2, err := votingSvc.SubmitVote(ctx, vote2)
 	if err != nil {
 		t.Fatalf("SubmitVote second category failed: %v", err)
@@ -529,19 +548,19 @@ func TestSubmitVote_NoConflictDifferentCars(t *testing.T) {
 
 	// Create a category group with an exclusivity pool
 	poolID := 1
-	groupID, err := repo.CreateCategoryGroup(ctx, "Speed Awards", "Speed related categories", &poolID, nil, 1)
+	groupID, err := repo.CreateCategoryGroup(ctx, "Speed Awards", "Speed related categories", &poolID, nil, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
 
 	// Create two categories in the same exclusivity pool
 	groupIDInt := int(groupID)
-	catID1, err := repo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil)
+	catID1, err := repo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 1 failed: %v", err)
 	}
 
-	catID2, err := repo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil)
+	catID2, err := repo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 2 failed: %v", err)
 	}
@@ -625,12 +644,12 @@ func TestSubmitVote_NoConflictNoPool(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Create two categories WITHOUT a group (no exclusivity pool)
-	catID1, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil)
+	catID1, err := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 1 failed: %v", err)
 	}
 
-	catID2, err := repo.CreateCategory(ctx, "Most Creative", 2, nil, nil, nil)
+	catID2, err := repo.CreateCategory(ctx, "Most Creative", 2, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 2 failed: %v", err)
 	}
@@ -701,19 +720,19 @@ func TestSubmitVote_MultipleVotersIndependent(t *testing.T) {
 
 	// Create a category group with an exclusivity pool
 	poolID := 1
-	groupID, err := repo.CreateCategoryGroup(ctx, "Speed Awards", "Speed related categories", &poolID, nil, 1)
+	groupID, err := repo.CreateCategoryGroup(ctx, "Speed Awards", "Speed related categories", &poolID, nil, "", 1)
 	if err != nil {
 		t.Fatalf("CreateCategoryGroup failed: %v", err)
 	}
 
 	// Create two categories in the same exclusivity pool
 	groupIDInt := int(groupID)
-	catID1, err := repo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil)
+	catID1, err := repo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 1 failed: %v", err)
 	}
 
-	catID2, err := repo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil)
+	catID2, err := repo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 2 failed: %v", err)
 	}
@@ -776,6 +795,20 @@ func TestSubmitVote_MultipleVotersIndependent(t *testing.T) {
 }
 
 // TestGetVoteData_EmptyDatabase tests GetVoteData with no data
+func TestGetVoteData_RecordsVoterActivity(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	if _, err := votingSvc.GetVoteData(ctx, "ACT-QR"); err != nil {
+		t.Fatalf("GetVoteData failed: %v", err)
+	}
+
+	voters, _ := repo.ListVoters(ctx)
+	if len(voters) != 1 || voters[0]["last_activity_at"] == nil {
+		t.Errorf("expected last_activity_at to be recorded, got %v", voters)
+	}
+}
+
 func TestGetVoteData_EmptyDatabase(t *testing.T) {
 	votingSvc, _, _, _, _ := setupVotingService(t)
 	ctx := context.Background()
@@ -817,7 +850,7 @@ func TestSubmitVote_VotingClosed(t *testing.T) {
 	settingsSvc.CloseVoting(ctx)
 
 	// Create test category
-	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -898,7 +931,7 @@ func TestSubmitVote_IneligibleCar(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test category
-	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -937,7 +970,7 @@ func TestSubmitVote_EligibleCar(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test category
-	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -967,13 +1000,125 @@ func TestSubmitVote_EligibleCar(t *testing.T) {
 	}
 }
 
+// TestSubmitVote_WriteIn tests that write-ins create a marked car, reuse it, and show in results
+func TestSubmitVote_WriteIn(t *testing.T) {
+	votingSvc, categorySvc, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	catID, err := categorySvc.CreateCategory(ctx, services.Category{Name: "Crowd Favorite", DisplayOrder: 1, AllowWriteIn: true})
+	if err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+
+	first, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "WRITE-QR1", CategoryID: int(catID), WriteIn: "  Grandpa's   Rocket "})
+	if err != nil {
+		t.Fatalf("SubmitVote failed: %v", err)
+	}
+	if first.WriteInCarID == 0 {
+		t.Fatal("expected write-in car ID in result")
+	}
+	second, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "WRITE-QR2", CategoryID: int(catID), WriteIn: "grandpa's rocket"})
+	if err != nil {
+		t.Fatalf("SubmitVote failed: %v", err)
+	}
+	if second.WriteInCarID != first.WriteInCarID {
+		t.Errorf("expected write-ins to share car %d, got %d", first.WriteInCarID, second.WriteInCarID)
+	}
+
+	car, _ := repo.GetCar(ctx, first.WriteInCarID)
+	if car.CarName != "Grandpa's Rocket" || !car.WriteIn {
+		t.Errorf("expected write-in car named \"Grandpa's Rocket\", got %+v", car)
+	}
+
+	resultsSvc := services.NewResultsService(logger.New(), repo, settingsSvc, derbynet.NewMockClient())
+	results, err := resultsSvc.GetResults(ctx)
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	// Until an admin approves it the write-in is listed apart and cannot win
+	cat := results.Categories[0]
+	if len(cat.Votes) != 0 || cat.TotalVotes != 2 {
+		t.Errorf("expected no ranked cars and 2 total votes, got %+v", cat)
+	}
+	if len(cat.PendingWriteIns) != 1 || !cat.PendingWriteIns[0].WriteIn || cat.PendingWriteIns[0].VoteCount != 2 {
+		t.Errorf("expected one pending write-in with 2 votes, got %+v", cat.PendingWriteIns)
+	}
+
+	repo.SetCarEligibility(ctx, first.WriteInCarID, true)
+	results, _ = resultsSvc.GetResults(ctx)
+	votes := results.Categories[0].Votes
+	if len(votes) != 1 || !votes[0].WriteIn || votes[0].VoteCount != 2 || votes[0].Rank != 1 {
+		t.Errorf("expected the approved write-in to rank first with 2 votes, got %+v", votes)
+	}
+}
+
+// TestSubmitVote_WriteInNotAllowed tests that write-ins are rejected unless the category allows them
+func TestSubmitVote_WriteInNotAllowed(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+
+	_, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "WRITE-QR3", CategoryID: int(catID), WriteIn: "Mystery Car"})
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+		t.Errorf("expected validation error, got %v", err)
+	}
+
+	_, err = votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "WRITE-QR3", CategoryID: 999, WriteIn: "Mystery Car"})
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrNotFound {
+		t.Errorf("expected not found error for missing category, got %v", err)
+	}
+
+	_, err = votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "WRITE-QR3", CategoryID: int(catID), WriteIn: strings.Repeat("x", 101)})
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+		t.Errorf("expected validation error for long write-in, got %v", err)
+	}
+}
+
+// TestSubmitVote_WriteInCarOutsideWriteInCategory tests that a write-in car's ID is
+// only accepted in categories that allow write-ins
+func TestSubmitVote_WriteInCarOutsideWriteInCategory(t *testing.T) {
+	votingSvc, categorySvc, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+
+	writeInID, _ := categorySvc.CreateCategory(ctx, services.Category{Name: "Crowd Favorite", DisplayOrder: 1, AllowWriteIn: true})
+	closedID, _ := repo.CreateCategory(ctx, "Best Design", 2, nil, nil, nil, nil)
+
+	first, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "WRITE-QR4", CategoryID: int(writeInID), WriteIn: "Mystery Car"})
+	if err != nil {
+		t.Fatalf("SubmitVote failed: %v", err)
+	}
+	if _, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "WRITE-QR5", CategoryID: int(writeInID), CarID: first.WriteInCarID}); err != nil {
+		t.Errorf("expected the write-in car's ID to be accepted in its category, got %v", err)
+	}
+
+	_, err = votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "WRITE-QR4", CategoryID: int(closedID), CarID: first.WriteInCarID})
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+		t.Errorf("expected validation error voting for a write-in car in another category, got %v", err)
+	}
+
+	result, err := votingSvc.SubmitBallot(ctx, services.Ballot{
+		VoterQR: "WRITE-QR4",
+		Votes:   map[int]int{int(closedID): first.WriteInCarID},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+	if statuses := ballotStatuses(result); statuses[int(closedID)] != services.BallotEntryRejected {
+		t.Errorf("expected the ballot entry to be rejected, got %q", statuses[int(closedID)])
+	}
+}
+
 // TestGetVoteData_FiltersIneligibleCars tests that GetVoteData excludes ineligible cars
 func TestGetVoteData_FiltersIneligibleCars(t *testing.T) {
 	votingSvc, _, _, _, repo := setupVotingService(t)
 	ctx := context.Background()
 
 	// Create test category
-	_, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	_, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -1028,7 +1173,7 @@ func TestSubmitVote_CarNotFound(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test category
-	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -1128,7 +1273,7 @@ func TestSubmitVote_RequireRegisteredQR_RejectsUnregistered(t *testing.T) {
 	}
 
 	// Create a category and car
-	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	catID, err := repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory failed: %v", err)
 	}
@@ -1295,7 +1440,7 @@ func TestSubmitVote_SaveVoteError(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Create test category and car
-	catID, _ := realRepo.CreateCategory(ctx, "Test Cat", 1, nil, nil, nil)
+	catID, _ := realRepo.CreateCategory(ctx, "Test Cat", 1, nil, nil, nil, nil)
 	realRepo.CreateCar(ctx, "101", "Test Racer", "Test Car", "")
 	cars, _ := realRepo.ListCars(ctx)
 
@@ -1328,7 +1473,7 @@ func TestCheckExclusivityConflict_GetExclusivityPoolIDError(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Create test category and car
-	catID, _ := realRepo.CreateCategory(ctx, "Test Cat", 1, nil, nil, nil)
+	catID, _ := realRepo.CreateCategory(ctx, "Test Cat", 1, nil, nil, nil, nil)
 	realRepo.CreateCar(ctx, "101", "Test Racer", "Test Car", "")
 	cars, _ := realRepo.ListCars(ctx)
 
@@ -1361,7 +1506,7 @@ func TestSubmitVote_GetCarError(t *testing.T) {
 	settingsSvc.OpenVoting(ctx)
 
 	// Create test category and car
-	catID, _ := realRepo.CreateCategory(ctx, "Test Cat", 1, nil, nil, nil)
+	catID, _ := realRepo.CreateCategory(ctx, "Test Cat", 1, nil, nil, nil, nil)
 	realRepo.CreateCar(ctx, "101", "Test Racer", "Test Car", "")
 	cars, _ := realRepo.ListCars(ctx)
 
@@ -1376,6 +1521,64 @@ func TestSubmitVote_GetCarError(t *testing.T) {
 	}
 }
 
+func TestSubmitAssistedVote(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	poolID := 1
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Speed Awards", "", &poolID, nil, "", 1)
+	group := int(groupID)
+	cat1, _ := repo.CreateCategory(ctx, "Fastest Looking", 1, &group, nil, nil, nil)
+	cat2, _ := repo.CreateCategory(ctx, "Most Aerodynamic", 2, &group, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer One", "Lightning", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoter(ctx, "ASSIST-QR")
+
+	// Voting being closed does not stop an assisted vote
+	_ = settingsSvc.CloseVoting(ctx)
+	vote := models.Vote{CategoryID: int(cat1), CarID: cars[0].ID}
+	if _, err := votingSvc.SubmitAssistedVote(ctx, voterID, vote, "  Helped by Mrs. Lee "); err != nil {
+		t.Fatalf("SubmitAssistedVote failed: %v", err)
+	}
+	votes, _ := repo.GetVoterVotes(ctx, voterID)
+	if votes[int(cat1)] != cars[0].ID {
+		t.Errorf("expected the assisted vote to be saved, got %v", votes)
+	}
+
+	entries, _ := repo.ListAuditLog(ctx)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Action != models.AuditActionVoteAssisted || entry.Reason != "Helped by Mrs. Lee" {
+		t.Errorf("unexpected audit entry: %+v", entry)
+	}
+	if entry.VoterID == nil || *entry.VoterID != voterID || entry.CategoryID == nil || *entry.CategoryID != int(cat1) || entry.CarID == nil || *entry.CarID != cars[0].ID {
+		t.Errorf("unexpected audit entry IDs: %+v", entry)
+	}
+
+	// Exclusivity is enforced as for a normal vote
+	vote = models.Vote{CategoryID: int(cat2), CarID: cars[0].ID}
+	_, err := votingSvc.SubmitAssistedVote(ctx, voterID, vote, "")
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrConflict {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+	if entries, _ := repo.ListAuditLog(ctx); len(entries) != 1 {
+		t.Errorf("expected a rejected vote not to be audited, got %d entries", len(entries))
+	}
+
+	_, err = votingSvc.SubmitAssistedVote(ctx, 9999, models.Vote{CategoryID: int(cat1), CarID: cars[0].ID}, "")
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrNotFound {
+		t.Errorf("expected not found for an unknown voter, got %v", err)
+	}
+
+	_, err = votingSvc.SubmitAssistedVote(ctx, voterID, models.Vote{}, "")
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrInvalidFields || appErr.Fields["category_id"] == "" || appErr.Fields["car_id"] == "" {
+		t.Errorf("expected invalid category and car fields, got %v", err)
+	}
+}
+
 func TestSubmitVote_ClearConflictingVoteError(t *testing.T) {
 	realRepo := testutil.NewTestRepository(t)
 	mockRepo := mock.NewRepository(realRepo)
@@ -1394,12 +1597,12 @@ func TestSubmitVote_ClearConflictingVoteError(t *testing.T) {
 
 	// Create a category group with an exclusivity pool
 	poolID := 1
-	groupID, _ := realRepo.CreateCategoryGroup(ctx, "Speed Awards", "Speed related categories", &poolID, nil, 1)
+	groupID, _ := realRepo.CreateCategoryGroup(ctx, "Speed Awards", "Speed related categories", &poolID, nil, "", 1)
 
 	// Create two categories in the same exclusivity pool (via the group)
 	groupIDInt := int(groupID)
-	catID1, _ := realRepo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil)
-	catID2, _ := realRepo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil)
+	catID1, _ := realRepo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil, nil)
+	catID2, _ := realRepo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil, nil)
 
 	// Create a test car
 	realRepo.CreateCar(ctx, "101", "John Smith", "Lightning Bolt", "")
@@ -1493,7 +1696,7 @@ func TestGetVoteData_IncludesInstructions(t *testing.T) {
 	votingService := services.NewVotingService(log, repo, categoryService, carService, settingsService)
 	
 	// Create test data
-	repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
 	repo.CreateVoter(ctx, "TEST-QR")
 	
@@ -1516,6 +1719,36 @@ func TestGetVoteData_IncludesInstructions(t *testing.T) {
 	}
 }
 
+func TestGetVoteData_UsesVoterTypeInstructions(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	_, _ = repo.CreateVoterFull(ctx, nil, "Judge", "", "judge", "JUDGE-QR", "")
+	err := settingsSvc.UpdateSettings(ctx, services.Settings{
+		VotingInstructions:       "Vote for your favorites",
+		VotingInstructionsByType: map[string]string{"judge": "Score every category"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+
+	voteData, err := votingSvc.GetVoteData(ctx, "JUDGE-QR")
+	if err != nil {
+		t.Fatalf("GetVoteData failed: %v", err)
+	}
+	if voteData.Instructions != "Score every category" {
+		t.Errorf("expected judge instructions, got %q", voteData.Instructions)
+	}
+
+	voteData, err = votingSvc.GetVoteData(ctx, "GENERAL-QR")
+	if err != nil {
+		t.Fatalf("GetVoteData failed: %v", err)
+	}
+	if voteData.Instructions != "Vote for your favorites" {
+		t.Errorf("expected default instructions, got %q", voteData.Instructions)
+	}
+}
+
 func TestGetVoteData_NoInstructions(t *testing.T) {
 	repo := testutil.NewTestRepository(t)
 	log := logger.New()
@@ -1529,7 +1762,7 @@ func TestGetVoteData_NoInstructions(t *testing.T) {
 	votingService := services.NewVotingService(log, repo, categoryService, carService, settingsService)
 
 	// Create test data
-	repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil)
+	repo.CreateCategory(ctx, "Test Category", 1, nil, nil, nil, nil)
 	repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
 	repo.CreateVoter(ctx, "TEST-QR")
 
@@ -1562,11 +1795,11 @@ func TestGetVoteData_FiltersCategoriesByVoterType_NoRestrictions(t *testing.T) {
 	votingService := services.NewVotingService(log, repo, categoryService, carService, settingsService)
 
 	// Create categories with no voter type restrictions
-	catID1, err := repo.CreateCategory(ctx, "Open Category 1", 1, nil, nil, nil)
+	catID1, err := repo.CreateCategory(ctx, "Open Category 1", 1, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 1 failed: %v", err)
 	}
-	catID2, err := repo.CreateCategory(ctx, "Open Category 2", 2, nil, []string{}, nil)
+	catID2, err := repo.CreateCategory(ctx, "Open Category 2", 2, nil, []string{}, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 2 failed: %v", err)
 	}
@@ -1618,11 +1851,11 @@ func TestGetVoteData_FiltersCategoriesByVoterType_MatchingType(t *testing.T) {
 	votingService := services.NewVotingService(log, repo, categoryService, carService, settingsService)
 
 	// Create categories with voter type restrictions
-	catID1, err := repo.CreateCategory(ctx, "Racer Only Category", 1, nil, []string{"racer"}, nil)
+	catID1, err := repo.CreateCategory(ctx, "Racer Only Category", 1, nil, []string{"racer"}, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 1 failed: %v", err)
 	}
-	catID2, err := repo.CreateCategory(ctx, "Committee Only Category", 2, nil, []string{"committee"}, nil)
+	catID2, err := repo.CreateCategory(ctx, "Committee Only Category", 2, nil, []string{"committee"}, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 2 failed: %v", err)
 	}
@@ -1669,11 +1902,11 @@ func TestGetVoteData_FiltersCategoriesByVoterType_NonMatchingType(t *testing.T)
 	votingService := services.NewVotingService(log, repo, categoryService, carService, settingsService)
 
 	// Create categories with voter type restrictions
-	_, err := repo.CreateCategory(ctx, "Committee Only 1", 1, nil, []string{"committee"}, nil)
+	_, err := repo.CreateCategory(ctx, "Committee Only 1", 1, nil, []string{"committee"}, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 1 failed: %v", err)
 	}
-	_, err = repo.CreateCategory(ctx, "Committee Only 2", 2, nil, []string{"committee", "admin"}, nil)
+	_, err = repo.CreateCategory(ctx, "Committee Only 2", 2, nil, []string{"committee", "admin"}, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 2 failed: %v", err)
 	}
@@ -1709,19 +1942,19 @@ func TestGetVoteData_FiltersCategoriesByVoterType_MixedCategories(t *testing.T)
 	votingService := services.NewVotingService(log, repo, categoryService, carService, settingsService)
 
 	// Create mix of categories
-	catID1, err := repo.CreateCategory(ctx, "Everyone Category", 1, nil, nil, nil) // No restrictions
+	catID1, err := repo.CreateCategory(ctx, "Everyone Category", 1, nil, nil, nil, nil) // No restrictions
 	if err != nil {
 		t.Fatalf("CreateCategory 1 failed: %v", err)
 	}
-	catID2, err := repo.CreateCategory(ctx, "Racer Category", 2, nil, []string{"racer"}, nil) // Racer only
+	catID2, err := repo.CreateCategory(ctx, "Racer Category", 2, nil, []string{"racer"}, nil, nil) // Racer only
 	if err != nil {
 		t.Fatalf("CreateCategory 2 failed: %v", err)
 	}
-	_, err = repo.CreateCategory(ctx, "Committee Category", 3, nil, []string{"committee"}, nil) // Committee only
+	_, err = repo.CreateCategory(ctx, "Committee Category", 3, nil, []string{"committee"}, nil, nil) // Committee only
 	if err != nil {
 		t.Fatalf("CreateCategory 3 failed: %v", err)
 	}
-	catID4, err := repo.CreateCategory(ctx, "Racer or General", 4, nil, []string{"racer", "general"}, nil) // Racer or general
+	catID4, err := repo.CreateCategory(ctx, "Racer or General", 4, nil, []string{"racer", "general"}, nil, nil) // Racer or general
 	if err != nil {
 		t.Fatalf("CreateCategory 4 failed: %v", err)
 	}
@@ -1822,11 +2055,11 @@ func TestGetVoteData_FiltersCategoriesByVoterType_DefaultVoterType(t *testing.T)
 	votingService := services.NewVotingService(log, repo, categoryService, carService, settingsService)
 
 	// Create categories
-	catID1, err := repo.CreateCategory(ctx, "General Category", 1, nil, []string{"general"}, nil)
+	catID1, err := repo.CreateCategory(ctx, "General Category", 1, nil, []string{"general"}, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 1 failed: %v", err)
 	}
-	_, err = repo.CreateCategory(ctx, "Racer Category", 2, nil, []string{"racer"}, nil)
+	_, err = repo.CreateCategory(ctx, "Racer Category", 2, nil, []string{"racer"}, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateCategory 2 failed: %v", err)
 	}
@@ -1852,3 +2085,1114 @@ func TestGetVoteData_FiltersCategoriesByVoterType_DefaultVoterType(t *testing.T)
 		t.Errorf("expected to see general category %d, got %d", catID1, voteData.Categories[0].ID)
 	}
 }
+
+func TestListPublicCategories_FiltersCarsByEligibilityAndRank(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	_, _ = repo.CreateCategory(ctx, "Open Category", 1, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Tigers Only", 2, nil, nil, []string{"Tiger"}, nil)
+	inactiveID, _ := repo.CreateCategory(ctx, "Retired", 3, nil, nil, nil, nil)
+	_ = repo.UpdateCategory(ctx, int(inactiveID), "Retired", 3, nil, nil, nil, false, nil)
+
+	_ = repo.CreateCar(ctx, "101", "Tiger Racer", "Car 1", "")
+	_ = repo.CreateCar(ctx, "102", "Wolf Racer", "Car 2", "")
+	_ = repo.CreateCar(ctx, "103", "Ineligible Tiger", "Car 3", "")
+	cars, _ := repo.ListCars(ctx)
+	_ = repo.UpdateCar(ctx, cars[0].ID, "101", "Tiger Racer", "Car 1", "", "Tiger")
+	_ = repo.UpdateCar(ctx, cars[1].ID, "102", "Wolf Racer", "Car 2", "", "Wolf")
+	_ = repo.UpdateCar(ctx, cars[2].ID, "103", "Ineligible Tiger", "Car 3", "", "Tiger")
+	_ = repo.SetCarEligibility(ctx, cars[2].ID, false)
+
+	categories, err := votingSvc.ListPublicCategories(ctx)
+	if err != nil {
+		t.Fatalf("ListPublicCategories failed: %v", err)
+	}
+	if len(categories) != 2 {
+		t.Fatalf("expected 2 active categories, got %d", len(categories))
+	}
+
+	if len(categories[0].Cars) != 2 {
+		t.Errorf("expected 2 eligible cars in open category, got %d", len(categories[0].Cars))
+	}
+	if len(categories[1].Cars) != 1 || categories[1].Cars[0].CarNumber != "101" {
+		t.Errorf("expected only car 101 in Tigers Only, got %+v", categories[1].Cars)
+	}
+}
+
+func TestCategoryCarSubset_LimitsListingAndVotes(t *testing.T) {
+	votingSvc, categorySvc, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+
+	openID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	siblingID, _ := repo.CreateCategory(ctx, "Best Sibling Car", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Scout", "Car 1", "")
+	_ = repo.CreateCar(ctx, "102", "Sibling", "Car 2", "")
+	cars, _ := repo.ListCars(ctx)
+	if err := categorySvc.SetCategoryCars(ctx, int(siblingID), []int{cars[1].ID}); err != nil {
+		t.Fatalf("SetCategoryCars failed: %v", err)
+	}
+
+	categories, err := votingSvc.ListPublicCategories(ctx)
+	if err != nil {
+		t.Fatalf("ListPublicCategories failed: %v", err)
+	}
+	if len(categories[0].Cars) != 2 {
+		t.Errorf("expected every car in %s, got %d", categories[0].Name, len(categories[0].Cars))
+	}
+	if len(categories[1].Cars) != 1 || categories[1].Cars[0].ID != cars[1].ID {
+		t.Errorf("expected only the sibling car in %s, got %+v", categories[1].Name, categories[1].Cars)
+	}
+
+	_, err = votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "SUBSET-QR", CategoryID: int(siblingID), CarID: cars[0].ID})
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+		t.Errorf("expected validation error for a car outside the subset, got %v", err)
+	}
+	if _, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "SUBSET-QR", CategoryID: int(siblingID), CarID: cars[1].ID}); err != nil {
+		t.Errorf("expected a vote for the sibling car to succeed, got %v", err)
+	}
+
+	result, err := votingSvc.SubmitBallot(ctx, services.Ballot{
+		VoterQR: "SUBSET-BALLOT",
+		Votes:   map[int]int{int(openID): cars[0].ID, int(siblingID): cars[0].ID},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+	statuses := ballotStatuses(result)
+	if statuses[int(openID)] != services.BallotEntryAccepted || statuses[int(siblingID)] != services.BallotEntryRejected {
+		t.Errorf("expected only the open category accepted, got %v", statuses)
+	}
+
+	// Clearing the subset lets every car compete again
+	_ = categorySvc.SetCategoryCars(ctx, int(siblingID), nil)
+	categories, _ = votingSvc.ListPublicCategories(ctx)
+	if len(categories[1].Cars) != 2 {
+		t.Errorf("expected every car after clearing the subset, got %d", len(categories[1].Cars))
+	}
+}
+
+func TestListPublicCategories_LiveCounts(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	hiddenID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	liveID, _ := repo.CreateCategory(ctx, "Crowd Favorite", 2, nil, nil, nil, nil)
+	_ = repo.SetCategoryShowLiveCounts(ctx, int(liveID), true)
+	_ = repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoter(ctx, "LIVE-QR")
+	_ = repo.SaveVote(ctx, voterID, int(hiddenID), cars[0].ID)
+	_ = repo.SaveVote(ctx, voterID, int(liveID), cars[0].ID)
+
+	categories, err := votingSvc.ListPublicCategories(ctx)
+	if err != nil {
+		t.Fatalf("ListPublicCategories failed: %v", err)
+	}
+	if categories[0].ShowLiveCounts || categories[0].Cars[0].VoteCount != nil {
+		t.Errorf("expected no counts in hidden category, got %+v", categories[0])
+	}
+	if count := categories[1].Cars[0].VoteCount; count == nil || *count != 1 {
+		t.Errorf("expected live count of 1, got %v", count)
+	}
+}
+
+func TestLiveTally(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	hiddenID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	liveID, _ := repo.CreateCategory(ctx, "Crowd Favorite", 2, nil, nil, nil, nil)
+	_ = repo.SetCategoryShowLiveCounts(ctx, int(liveID), true)
+	_ = repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	_ = repo.CreateCar(ctx, "102", "Racer 2", "Car 2", "")
+	cars, _ := repo.ListCars(ctx)
+	voterID, _ := repo.CreateVoter(ctx, "TALLY-QR")
+	_ = repo.SaveVote(ctx, voterID, int(liveID), cars[0].ID)
+
+	tally, err := votingSvc.LiveTally(ctx, int(liveID))
+	if err != nil {
+		t.Fatalf("LiveTally failed: %v", err)
+	}
+	if want := map[int]int{cars[0].ID: 1, cars[1].ID: 0}; !reflect.DeepEqual(tally, want) {
+		t.Errorf("expected tally %v, got %v", want, tally)
+	}
+
+	for _, id := range []int{int(hiddenID), 999} {
+		if tally, err := votingSvc.LiveTally(ctx, id); err != nil || tally != nil {
+			t.Errorf("expected no tally for category %d, got %v, %v", id, tally, err)
+		}
+	}
+
+	_ = repo.SetSetting(ctx, "results_embargoed", "true")
+	if tally, err := votingSvc.LiveTally(ctx, int(liveID)); err != nil || tally != nil {
+		t.Errorf("expected no tally while results are embargoed, got %v, %v", tally, err)
+	}
+}
+
+func TestListPublicCategories_ListCategoriesError(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	mockRepo.ListCategoriesError = errors.New("database error")
+	log := logger.New()
+	client := derbynet.NewMockClient()
+	votingSvc := services.NewVotingService(log, mockRepo, services.NewCategoryService(log, mockRepo, client), services.NewCarService(log, mockRepo, client), services.NewSettingsService(log, mockRepo))
+
+	if _, err := votingSvc.ListPublicCategories(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestGetVoterVoteSummary_ReturnsSelectionsAndRemaining(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Most Creative", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	voterID, _ := repo.CreateVoter(ctx, "SUMMARY-QR")
+	_ = repo.SaveVote(ctx, voterID, int(cat1ID), 1)
+
+	summary, err := votingSvc.GetVoterVoteSummary(ctx, "SUMMARY-QR")
+	if err != nil {
+		t.Fatalf("GetVoterVoteSummary failed: %v", err)
+	}
+	if len(summary.Selections) != 1 || summary.Selections[0].CategoryID != int(cat1ID) || summary.Selections[0].CarID != 1 {
+		t.Errorf("expected one selection for Best Design, got %+v", summary.Selections)
+	}
+	if len(summary.Remaining) != 1 || summary.Remaining[0].CategoryName != "Most Creative" {
+		t.Errorf("expected Most Creative remaining, got %+v", summary.Remaining)
+	}
+	if summary.Complete {
+		t.Error("expected summary to be incomplete")
+	}
+}
+
+func TestGetVoterVoteSummary_CompletionProgress(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	cat1ID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	cat2ID, _ := repo.CreateCategory(ctx, "Most Creative", 2, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Racers' Choice", 3, nil, []string{"racer"}, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	voterID, _ := repo.CreateVoter(ctx, "PROGRESS-QR")
+	_ = repo.SaveVote(ctx, voterID, int(cat1ID), 1)
+
+	summary, err := votingSvc.GetVoterVoteSummary(ctx, "PROGRESS-QR")
+	if err != nil {
+		t.Fatalf("GetVoterVoteSummary failed: %v", err)
+	}
+	if summary.CategoriesVoted != 1 || summary.CategoriesAvailable != 2 {
+		t.Errorf("expected 1 of 2 categories voted, got %d of %d", summary.CategoriesVoted, summary.CategoriesAvailable)
+	}
+	if !summary.Counted {
+		t.Error("expected votes to count while complete ballots are not required")
+	}
+
+	_ = settingsSvc.SetRequireCompleteBallot(ctx, true)
+	summary, _ = votingSvc.GetVoterVoteSummary(ctx, "PROGRESS-QR")
+	if summary.Counted {
+		t.Error("expected an incomplete ballot not to count while complete ballots are required")
+	}
+
+	_ = repo.SaveVote(ctx, voterID, int(cat2ID), 1)
+	summary, _ = votingSvc.GetVoterVoteSummary(ctx, "PROGRESS-QR")
+	if !summary.Complete || !summary.Counted || summary.CategoriesVoted != 2 {
+		t.Errorf("expected a complete, counted ballot, got %+v", summary)
+	}
+
+	// A complete ballot carries the thank you page, so finishing needs no resubmission
+	redirect := "https://pack123.example.org/thanks"
+	_ = settingsSvc.UpdateSettings(ctx, services.Settings{PostVoteRedirectURL: &redirect})
+	summary, _ = votingSvc.GetVoterVoteSummary(ctx, "PROGRESS-QR")
+	if summary.RedirectURL != redirect {
+		t.Errorf("expected redirect to %q, got %q", redirect, summary.RedirectURL)
+	}
+}
+
+func TestGetVoterVoteSummary_UnknownQR(t *testing.T) {
+	votingSvc, _, _, settingsSvc, _ := setupVotingService(t)
+	ctx := context.Background()
+
+	_, err := votingSvc.GetVoterVoteSummary(ctx, "UNKNOWN-QR")
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrNotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	_ = settingsSvc.SetRequireRegisteredQR(ctx, true)
+	_, err = votingSvc.GetVoterVoteSummary(ctx, "UNKNOWN-QR")
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrForbidden {
+		t.Errorf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestValidateVoterQR(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	// Open voting accepts any code without creating a voter
+	validation, err := votingSvc.ValidateVoterQR(ctx, "ANY-QR")
+	if err != nil || !validation.Valid || validation.VoterName != "" {
+		t.Fatalf("expected generic valid result, got %+v, %v", validation, err)
+	}
+	if _, exists, _ := repo.GetVoterByQRCode(ctx, "ANY-QR"); exists {
+		t.Error("expected validation not to create a voter")
+	}
+
+	_ = settingsSvc.SetRequireRegisteredQR(ctx, true)
+	_, err = votingSvc.ValidateVoterQR(ctx, "UNKNOWN-QR")
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrNotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	cat1, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_, _ = repo.CreateCategory(ctx, "Fastest", 2, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	voterID, _ := repo.CreateVoterFull(ctx, nil, "Alex Smith", "", "general", "REG-QR", "")
+	_ = repo.SaveVote(ctx, int(voterID), int(cat1), 1)
+
+	validation, err = votingSvc.ValidateVoterQR(ctx, "REG-QR")
+	if err != nil {
+		t.Fatalf("ValidateVoterQR failed: %v", err)
+	}
+	if !validation.Valid || validation.VoterName != "Alex Smith" {
+		t.Errorf("expected valid named voter, got %+v", validation)
+	}
+	if len(validation.AlreadyVotedCategories) != 1 || validation.AlreadyVotedCategories[0] != int(cat1) {
+		t.Errorf("expected already voted in category %d, got %v", cat1, validation.AlreadyVotedCategories)
+	}
+}
+
+func TestSeedMockVotes_RequiresCategoriesAndCars(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	_, err := votingSvc.SeedMockVotes(ctx)
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+		t.Fatalf("expected validation error without categories, got %v", err)
+	}
+
+	repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_, err = votingSvc.SeedMockVotes(ctx)
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+		t.Fatalf("expected validation error without cars, got %v", err)
+	}
+}
+
+func TestSeedMockVotes_FillsMissingVotes(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	repo.CreateCategory(ctx, "Committee Pick", 2, nil, []string{"Race Committee"}, nil, nil)
+	repo.CreateCar(ctx, "101", "Racer One", "Car One", "")
+	repo.CreateCar(ctx, "102", "Racer Two", "Car Two", "")
+	voter1, _ := repo.CreateVoterFull(ctx, nil, "Voter One", "", "general", "SEED-1", "")
+	repo.CreateVoterFull(ctx, nil, "Voter Two", "", "general", "SEED-2", "")
+
+	// Voter one already voted in Best Design
+	cars, _ := repo.ListCars(ctx)
+	repo.SaveVote(ctx, int(voter1), int(catID), cars[0].ID)
+
+	count, err := votingSvc.SeedMockVotes(ctx)
+	if err != nil {
+		t.Fatalf("SeedMockVotes failed: %v", err)
+	}
+	// Only voter two's Best Design vote is missing; Committee Pick is restricted
+	if count != 1 {
+		t.Errorf("expected 1 vote seeded, got %d", count)
+	}
+
+	votes, _ := repo.GetVoterVotes(ctx, int(voter1))
+	if votes[int(catID)] != cars[0].ID {
+		t.Error("expected existing vote to be left unchanged")
+	}
+
+	count, _ = votingSvc.SeedMockVotes(ctx)
+	if count != 0 {
+		t.Errorf("expected 0 votes on reseed, got %d", count)
+	}
+}
+
+func TestSeedMockVotes_HonoursExclusivityPool(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	poolID := 1
+	groupID, _ := repo.CreateCategoryGroup(ctx, "Speed Awards", "", &poolID, nil, "", 1)
+	groupIDInt := int(groupID)
+	repo.CreateCategory(ctx, "Fastest Looking", 1, &groupIDInt, nil, nil, nil)
+	repo.CreateCategory(ctx, "Most Aerodynamic", 2, &groupIDInt, nil, nil, nil)
+	repo.CreateCar(ctx, "101", "Racer One", "Car One", "")
+	voterID, _ := repo.CreateVoterFull(ctx, nil, "Voter One", "", "general", "SEED-1", "")
+
+	count, err := votingSvc.SeedMockVotes(ctx)
+	if err != nil {
+		t.Fatalf("SeedMockVotes failed: %v", err)
+	}
+	// With a single car, only one category in the pool can receive it
+	if count != 1 {
+		t.Errorf("expected 1 vote seeded, got %d", count)
+	}
+	votes, _ := repo.GetVoterVotes(ctx, int(voterID))
+	if len(votes) != 1 {
+		t.Errorf("expected voter to have 1 vote, got %d", len(votes))
+	}
+}
+
+func TestSeedMockVotes_SaveVoteError(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	log := logger.New()
+	derbynetClient := derbynet.NewMockClient()
+	settingsSvc := services.NewSettingsService(log, mockRepo)
+	votingSvc := services.NewVotingService(log, mockRepo,
+		services.NewCategoryService(log, mockRepo, derbynetClient),
+		services.NewCarService(log, mockRepo, derbynetClient), settingsSvc)
+	ctx := context.Background()
+
+	realRepo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	realRepo.CreateCar(ctx, "101", "Racer One", "Car One", "")
+	realRepo.CreateVoterFull(ctx, nil, "Voter One", "", "general", "SEED-1", "")
+	mockRepo.SaveVoteError = errors.New("database error")
+
+	if _, err := votingSvc.SeedMockVotes(ctx); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+// setupBallotData creates two categories sharing an exclusivity pool, one
+// unpooled category and three cars, the last of which is ineligible
+func setupBallotData(t *testing.T, repo *repository.Repository) (pooled1, pooled2, unpooled int, cars []models.Car) {
+	t.Helper()
+	ctx := context.Background()
+
+	poolID := 1
+	groupID, err := repo.CreateCategoryGroup(ctx, "Design Awards", "", &poolID, nil, "", 1)
+	if err != nil {
+		t.Fatalf("CreateCategoryGroup failed: %v", err)
+	}
+	groupIDInt := int(groupID)
+	cat1, _ := repo.CreateCategory(ctx, "Best Paint", 1, &groupIDInt, nil, nil, nil)
+	cat2, _ := repo.CreateCategory(ctx, "Best Theme", 2, &groupIDInt, nil, nil, nil)
+	cat3, _ := repo.CreateCategory(ctx, "Fastest", 3, nil, nil, nil, nil)
+
+	repo.CreateCar(ctx, "101", "Racer 1", "Car 1", "")
+	repo.CreateCar(ctx, "102", "Racer 2", "Car 2", "")
+	repo.CreateCar(ctx, "103", "Racer 3", "Car 3", "")
+	cars, _ = repo.ListCars(ctx)
+	if err := repo.SetCarEligibility(ctx, cars[2].ID, false); err != nil {
+		t.Fatalf("SetCarEligibility failed: %v", err)
+	}
+	return int(cat1), int(cat2), int(cat3), cars
+}
+
+func ballotStatuses(result *services.BallotResult) map[int]string {
+	statuses := make(map[int]string, len(result.Results))
+	for _, entry := range result.Results {
+		statuses[entry.CategoryID] = entry.Status
+	}
+	return statuses
+}
+
+func TestSubmitBallot_SavesAllEntries(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+	cat1, cat2, cat3, cars := setupBallotData(t, repo)
+
+	result, err := votingSvc.SubmitBallot(ctx, services.Ballot{
+		VoterQR: "BALLOT-QR",
+		Votes:   map[int]int{cat1: cars[0].ID, cat2: cars[1].ID, cat3: cars[0].ID},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+	if !result.Committed || result.Accepted != 3 {
+		t.Errorf("expected 3 accepted and committed, got %+v", result)
+	}
+	if len(result.Results) != 3 || result.Results[0].CategoryID != cat1 {
+		t.Errorf("expected results ordered by category, got %+v", result.Results)
+	}
+
+	voterID, _ := repo.GetVoterByQR(ctx, "BALLOT-QR")
+	votes, _ := repo.GetVoterVotes(ctx, voterID)
+	if len(votes) != 3 || votes[cat2] != cars[1].ID {
+		t.Errorf("expected all three votes saved, got %v", votes)
+	}
+}
+
+func TestSubmitBallot_PartialAcceptance(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+	cat1, cat2, cat3, cars := setupBallotData(t, repo)
+
+	result, err := votingSvc.SubmitBallot(ctx, services.Ballot{
+		VoterQR: "BALLOT-QR",
+		Votes: map[int]int{
+			cat1: cars[0].ID,
+			cat2: cars[0].ID, // same car twice in one pool
+			cat3: cars[2].ID, // ineligible
+			999:  cars[0].ID, // unknown category
+		},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+
+	statuses := ballotStatuses(result)
+	if statuses[cat1] != services.BallotEntryAccepted {
+		t.Errorf("expected category %d accepted, got %q", cat1, statuses[cat1])
+	}
+	for _, id := range []int{cat2, cat3, 999} {
+		if statuses[id] != services.BallotEntryRejected {
+			t.Errorf("expected category %d rejected, got %q", id, statuses[id])
+		}
+	}
+	if !strings.Contains(result.Results[1].Error, "Best Paint") {
+		t.Errorf("expected pool conflict to name the other category, got %q", result.Results[1].Error)
+	}
+	if !result.Committed || result.Accepted != 1 {
+		t.Errorf("expected 1 accepted entry to be committed, got %+v", result)
+	}
+
+	voterID, _ := repo.GetVoterByQR(ctx, "BALLOT-QR")
+	votes, _ := repo.GetVoterVotes(ctx, voterID)
+	if len(votes) != 1 || votes[cat1] != cars[0].ID {
+		t.Errorf("expected only the accepted vote saved, got %v", votes)
+	}
+}
+
+func TestSubmitBallot_AllOrNothing(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+	cat1, _, cat3, cars := setupBallotData(t, repo)
+
+	result, err := votingSvc.SubmitBallot(ctx, services.Ballot{
+		VoterQR:      "BALLOT-QR",
+		Votes:        map[int]int{cat1: cars[0].ID, cat3: cars[2].ID},
+		AllOrNothing: true,
+	})
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+
+	statuses := ballotStatuses(result)
+	if statuses[cat1] != services.BallotEntryRolledBack || statuses[cat3] != services.BallotEntryRejected {
+		t.Errorf("expected rolled_back and rejected, got %v", statuses)
+	}
+	if result.Committed || result.Accepted != 0 {
+		t.Errorf("expected nothing committed, got %+v", result)
+	}
+
+	voterID, _ := repo.GetVoterByQR(ctx, "BALLOT-QR")
+	votes, _ := repo.GetVoterVotes(ctx, voterID)
+	if len(votes) != 0 {
+		t.Errorf("expected no votes saved, got %v", votes)
+	}
+}
+
+func TestSubmitBallot_RedirectsCompleteBallot(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+	cat1, cat2, cat3, cars := setupBallotData(t, repo)
+
+	// Without a redirect configured a complete ballot stays on the confirmation view
+	result, err := votingSvc.SubmitBallot(ctx, services.Ballot{
+		VoterQR: "NO-REDIRECT-QR",
+		Votes:   map[int]int{cat1: cars[0].ID, cat2: cars[1].ID, cat3: cars[0].ID},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+	if result.RedirectURL != "" {
+		t.Errorf("expected no redirect when unset, got %q", result.RedirectURL)
+	}
+
+	redirect := "https://pack123.example.org/thanks"
+	if err := settingsSvc.UpdateSettings(ctx, services.Settings{PostVoteRedirectURL: &redirect}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+
+	result, err = votingSvc.SubmitBallot(ctx, services.Ballot{
+		VoterQR: "REDIRECT-QR",
+		Votes:   map[int]int{cat1: cars[0].ID, cat3: cars[0].ID},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+	if result.RedirectURL != "" {
+		t.Errorf("expected no redirect for an incomplete ballot, got %q", result.RedirectURL)
+	}
+
+	// Finishing the ballot later counts the votes already saved
+	result, err = votingSvc.SubmitBallot(ctx, services.Ballot{
+		VoterQR: "REDIRECT-QR",
+		Votes:   map[int]int{cat2: cars[1].ID},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+	if result.RedirectURL != redirect {
+		t.Errorf("expected redirect to %q, got %q", redirect, result.RedirectURL)
+	}
+}
+
+func TestSubmitBallot_UnchangedPicksNotSaved(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+	cat1, cat2, cat3, cars := setupBallotData(t, repo)
+	gauge := services.NewWriteGauge()
+	votingSvc.SetWriteGauge(gauge)
+
+	ballot := services.Ballot{
+		VoterQR: "RESUBMIT-QR",
+		Votes:   map[int]int{cat1: cars[0].ID, cat2: cars[1].ID, cat3: cars[0].ID},
+	}
+	if _, err := votingSvc.SubmitBallot(ctx, ballot); err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+
+	// Resubmitting the same ballot is accepted without writing the votes again
+	result, err := votingSvc.SubmitBallot(ctx, ballot)
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+	if !result.Committed || result.Accepted != 3 {
+		t.Errorf("expected resubmitted ballot accepted, got %+v", result)
+	}
+	if got := gauge.VotesPerMinute(); got != 3 {
+		t.Errorf("expected only the first submission's 3 votes written, got %d", got)
+	}
+}
+
+func TestSubmitBallot_ExistingVoteConflict(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+	cat1, cat2, _, cars := setupBallotData(t, repo)
+
+	if _, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "BALLOT-QR", CategoryID: cat1, CarID: cars[0].ID}); err != nil {
+		t.Fatalf("SubmitVote failed: %v", err)
+	}
+
+	ballot := services.Ballot{VoterQR: "BALLOT-QR", Votes: map[int]int{cat2: cars[0].ID}}
+	result, err := votingSvc.SubmitBallot(ctx, ballot)
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+	if result.Results[0].Status != services.BallotEntryRejected || result.Committed {
+		t.Errorf("expected conflict with the earlier vote to be rejected, got %+v", result)
+	}
+
+	ballot.Replace = true
+	result, err = votingSvc.SubmitBallot(ctx, ballot)
+	if err != nil {
+		t.Fatalf("SubmitBallot with replace failed: %v", err)
+	}
+	if result.Results[0].Status != services.BallotEntryAccepted || result.Results[0].ClearedCategoryID != cat1 {
+		t.Errorf("expected earlier vote in category %d to be cleared, got %+v", cat1, result.Results[0])
+	}
+
+	voterID, _ := repo.GetVoterByQR(ctx, "BALLOT-QR")
+	votes, _ := repo.GetVoterVotes(ctx, voterID)
+	if len(votes) != 1 || votes[cat2] != cars[0].ID {
+		t.Errorf("expected vote moved to category %d, got %v", cat2, votes)
+	}
+}
+
+func TestSubmitBallot_MovesCarWithinPool(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+	cat1, cat2, _, cars := setupBallotData(t, repo)
+
+	votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "BALLOT-QR", CategoryID: cat1, CarID: cars[0].ID})
+
+	// Swapping the picks in one ballot is not a conflict
+	result, err := votingSvc.SubmitBallot(ctx, services.Ballot{
+		VoterQR: "BALLOT-QR",
+		Votes:   map[int]int{cat1: cars[1].ID, cat2: cars[0].ID},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+	if result.Accepted != 2 {
+		t.Errorf("expected both entries accepted, got %+v", result.Results)
+	}
+}
+
+func TestSubmitBallot_Errors(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	cat1, _, _, cars := setupBallotData(t, repo)
+	ballot := services.Ballot{VoterQR: "BALLOT-QR", Votes: map[int]int{cat1: cars[0].ID}}
+
+	settingsSvc.CloseVoting(ctx)
+	if _, err := votingSvc.SubmitBallot(ctx, ballot); err != services.ErrVotingClosed {
+		t.Errorf("expected ErrVotingClosed, got %v", err)
+	}
+
+	settingsSvc.OpenVoting(ctx)
+	_, err := votingSvc.SubmitBallot(ctx, services.Ballot{VoterQR: "BALLOT-QR"})
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+		t.Errorf("expected validation error for empty ballot, got %v", err)
+	}
+}
+
+func TestSubmitBallot_SaveBallotError(t *testing.T) {
+	realRepo := testutil.NewTestRepository(t)
+	mockRepo := mock.NewRepository(realRepo)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, mockRepo)
+	votingSvc := services.NewVotingService(log, mockRepo, nil, nil, settingsSvc)
+	ctx := context.Background()
+
+	settingsSvc.OpenVoting(ctx)
+	cat1, _, _, cars := setupBallotData(t, realRepo)
+	mockRepo.SaveBallotError = errors.New("database error")
+
+	_, err := votingSvc.SubmitBallot(ctx, services.Ballot{VoterQR: "BALLOT-QR", Votes: map[int]int{cat1: cars[0].ID}})
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("expected database error, got %v", err)
+	}
+}
+
+func TestSaveVote_BlockedWhileVotingClosed(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	voterID, _ := repo.CreateVoter(ctx, "SAVE-QR")
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+
+	settingsSvc.CloseVoting(ctx)
+	if err := votingSvc.SaveVote(ctx, voterID, int(catID), cars[0].ID, false); err != services.ErrVotingClosed {
+		t.Fatalf("expected ErrVotingClosed, got %v", err)
+	}
+	votes, _ := repo.GetVoterVotes(ctx, voterID)
+	if len(votes) != 0 {
+		t.Errorf("expected no votes saved, got %v", votes)
+	}
+
+	settingsSvc.OpenVoting(ctx)
+	if err := votingSvc.SaveVote(ctx, voterID, int(catID), cars[0].ID, false); err != nil {
+		t.Fatalf("SaveVote failed while open: %v", err)
+	}
+}
+
+func TestSaveVote_OverrideWhileVotingClosed(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	voterID, _ := repo.CreateVoter(ctx, "SAVE-QR")
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	cars, _ := repo.ListCars(ctx)
+
+	settingsSvc.CloseVoting(ctx)
+	if err := votingSvc.SaveVote(ctx, voterID, int(catID), cars[0].ID, true); err != nil {
+		t.Fatalf("expected admin override to save, got %v", err)
+	}
+	votes, _ := repo.GetVoterVotes(ctx, voterID)
+	if votes[int(catID)] != cars[0].ID {
+		t.Errorf("expected override vote saved, got %v", votes)
+	}
+
+	// Seeding is an admin action and also bypasses the check
+	if _, err := votingSvc.SeedMockVotes(ctx); err != nil {
+		t.Errorf("expected SeedMockVotes to work while closed, got %v", err)
+	}
+}
+
+func TestSubmitVote_ReplaceWhileVotingClosedKeepsEarlierVote(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+	cat1, cat2, _, cars := setupBallotData(t, repo)
+
+	votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "CLOSED-QR", CategoryID: cat1, CarID: cars[0].ID})
+	settingsSvc.CloseVoting(ctx)
+
+	_, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "CLOSED-QR", CategoryID: cat2, CarID: cars[0].ID, Replace: true})
+	if err != services.ErrVotingClosed {
+		t.Fatalf("expected ErrVotingClosed, got %v", err)
+	}
+
+	voterID, _ := repo.GetVoterByQR(ctx, "CLOSED-QR")
+	votes, _ := repo.GetVoterVotes(ctx, voterID)
+	if len(votes) != 1 || votes[cat1] != cars[0].ID {
+		t.Errorf("expected earlier vote untouched, got %v", votes)
+	}
+}
+
+func TestGetVoteData_ReadOnlyRepository(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "derby.db")
+	writer, err := repository.New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	writer.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	writer.CreateVoter(ctx, "RO-VOTER")
+	writer.Close()
+
+	repo, err := repository.NewReadOnly(path)
+	if err != nil {
+		t.Fatalf("NewReadOnly failed: %v", err)
+	}
+	defer repo.Close()
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	votingSvc := services.NewVotingService(log, repo, services.NewCategoryService(log, repo, nil), services.NewCarService(log, repo, nil), settingsSvc)
+
+	// Reading a ballot records activity, which a read-only instance skips
+	if _, err := votingSvc.GetVoteData(ctx, "RO-VOTER"); err != nil {
+		t.Errorf("expected vote data from a read-only repository, got %v", err)
+	}
+	if _, err := votingSvc.GetVoterVoteSummary(ctx, "RO-VOTER"); err != nil {
+		t.Errorf("expected a vote summary from a read-only repository, got %v", err)
+	}
+}
+
+func TestMergeVotes(t *testing.T) {
+	localSvc, _, _, _, local := setupVotingService(t)
+	remoteSvc, _, _, _, remote := setupVotingService(t)
+	ctx := context.Background()
+
+	// Same event configured on both instances, created in a different order so IDs differ
+	poolID := 1
+	localGroup, _ := local.CreateCategoryGroup(ctx, "Speed", "", &poolID, nil, "", 1)
+	localGroupID := int(localGroup)
+	localFastest, _ := local.CreateCategory(ctx, "Fastest Looking", 1, &localGroupID, nil, nil, nil)
+	local.CreateCategory(ctx, "Most Aerodynamic", 2, &localGroupID, nil, nil, nil)
+	localDesign, _ := local.CreateCategory(ctx, "Best Design", 3, nil, nil, nil, nil)
+	local.CreateCar(ctx, "101", "Alex", "Bolt", "")
+	local.CreateCar(ctx, "202", "Blake", "Comet", "")
+
+	remote.CreateCategory(ctx, "Remote Only", 1, nil, nil, nil, nil)
+	remoteDesign, _ := remote.CreateCategory(ctx, "Best Design", 2, nil, nil, nil, nil)
+	remoteGroup, _ := remote.CreateCategoryGroup(ctx, "Speed", "", &poolID, nil, "", 1)
+	remoteGroupID := int(remoteGroup)
+	remoteFastest, _ := remote.CreateCategory(ctx, "Fastest Looking", 3, &remoteGroupID, nil, nil, nil)
+	remoteAero, _ := remote.CreateCategory(ctx, "Most Aerodynamic", 4, &remoteGroupID, nil, nil, nil)
+	remote.CreateCar(ctx, "202", "Blake", "Comet", "")
+	remote.CreateCar(ctx, "101", "Alex", "Bolt", "")
+
+	localCars := map[string]int{}
+	cars, _ := local.ListCars(ctx)
+	for _, c := range cars {
+		localCars[c.CarNumber] = c.ID
+	}
+	remoteCars := map[string]int{}
+	cars, _ = remote.ListCars(ctx)
+	for _, c := range cars {
+		remoteCars[c.CarNumber] = c.ID
+	}
+
+	// Voter A voted on both instances
+	localA, _ := local.CreateVoter(ctx, "VOTER-A")
+	local.SaveVote(ctx, localA, int(localFastest), localCars["101"])
+	local.SaveVote(ctx, localA, int(localDesign), localCars["101"])
+	remoteA, _ := remote.CreateVoter(ctx, "VOTER-A")
+	remote.SaveVote(ctx, remoteA, int(remoteFastest), remoteCars["101"]) // duplicate
+	remote.SaveVote(ctx, remoteA, int(remoteDesign), remoteCars["202"])  // different car
+	remote.SaveVote(ctx, remoteA, int(remoteAero), remoteCars["101"])    // breaks the exclusivity pool
+
+	// Voter B only voted on the remote instance, including a category missing here
+	remoteB, _ := remote.CreateVoterFull(ctx, nil, "Casey", "", "judge", "VOTER-B", "")
+	remote.SaveVote(ctx, int(remoteB), int(remoteDesign), remoteCars["202"])
+	remote.SaveVote(ctx, int(remoteB), 1, remoteCars["101"])
+
+	export, err := remoteSvc.ExportVotes(ctx)
+	if err != nil {
+		t.Fatalf("ExportVotes failed: %v", err)
+	}
+	if len(export.Voters) != 2 || len(export.Votes) != 5 {
+		t.Fatalf("expected 2 voters and 5 votes in export, got %d and %d", len(export.Voters), len(export.Votes))
+	}
+
+	result, err := localSvc.MergeVotes(ctx, *export)
+	if err != nil {
+		t.Fatalf("MergeVotes failed: %v", err)
+	}
+	if result.VotersAdded != 1 || result.VotesAdded != 1 || result.Duplicates != 1 {
+		t.Errorf("expected 1 voter, 1 vote and 1 duplicate, got %+v", result)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].CategoryName != "Remote Only" {
+		t.Errorf("expected the unknown category to be skipped, got %+v", result.Skipped)
+	}
+	if len(result.Conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts, got %+v", result.Conflicts)
+	}
+	differ := result.Conflicts[0]
+	if differ.CategoryID != int(localDesign) || differ.LocalCarID != localCars["101"] || differ.RemoteCarID != localCars["202"] {
+		t.Errorf("unexpected different-car conflict: %+v", differ)
+	}
+	if pool := result.Conflicts[1]; pool.ConflictCategoryID != int(localFastest) {
+		t.Errorf("expected exclusivity conflict with Fastest Looking, got %+v", pool)
+	}
+
+	// Local votes are untouched and voter B's vote is added
+	if votes, _ := local.GetVoterVotes(ctx, localA); len(votes) != 2 || votes[int(localDesign)] != localCars["101"] {
+		t.Errorf("expected voter A's local votes untouched, got %v", votes)
+	}
+	localB, err := local.GetVoterByQR(ctx, "VOTER-B")
+	if err != nil {
+		t.Fatalf("expected voter B to be added: %v", err)
+	}
+	if voterType, _ := local.GetVoterType(ctx, localB); voterType != "judge" {
+		t.Errorf("expected voter B's type to be kept, got %q", voterType)
+	}
+	if votes, _ := local.GetVoterVotes(ctx, localB); votes[int(localDesign)] != localCars["202"] {
+		t.Errorf("expected voter B's vote to be merged, got %v", votes)
+	}
+
+	// Merging again changes nothing
+	again, err := localSvc.MergeVotes(ctx, *export)
+	if err != nil {
+		t.Fatalf("second MergeVotes failed: %v", err)
+	}
+	if again.VotersAdded != 0 || again.VotesAdded != 0 || again.Duplicates != 2 {
+		t.Errorf("expected a repeat merge to only find duplicates, got %+v", again)
+	}
+}
+
+func TestMergeVotes_ChecksVotesLikeCastVote(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	repo.CreateCategory(ctx, "Judges Pick", 1, nil, []string{"judge"}, nil, nil)
+	repo.CreateCategory(ctx, "Lions Best", 2, nil, nil, []string{"Lion"}, nil)
+	subset, _ := repo.CreateCategory(ctx, "Finalists", 3, nil, nil, nil, nil)
+	repo.CreateCategory(ctx, "Best Design", 4, nil, nil, nil, nil)
+	creative, _ := repo.CreateCategory(ctx, "Most Creative", 5, nil, nil, nil, nil)
+	repo.SetCategoryAllowWriteIn(ctx, int(creative), true)
+	repo.CreateCar(ctx, "101", "Alex", "Bolt", "")
+	repo.CreateCar(ctx, "202", "Blake", "Comet", "")
+	cars, _ := repo.ListCars(ctx)
+	repo.UpdateCar(ctx, cars[0].ID, "101", "Alex", "Bolt", "", "Tiger")
+	repo.SetCarEligibility(ctx, cars[1].ID, false)
+	repo.SetCategoryCars(ctx, int(subset), []int{cars[1].ID})
+
+	export := exportOf("VOTER-M", []services.ExportVote{
+		{CategoryName: "Judges Pick", CarNumber: "101"},
+		{CategoryName: "Lions Best", CarNumber: "101"},
+		{CategoryName: "Finalists", CarNumber: "101"},
+		{CategoryName: "Best Design", CarNumber: "202"},
+		{CategoryName: "Best Design", CarName: "Rocket", WriteIn: true},
+		{CategoryName: "Most Creative", CarName: "Rocket", WriteIn: true, VotedAt: "2026-03-14T18:30:00Z"},
+	})
+	result, err := votingSvc.MergeVotes(ctx, export)
+	if err != nil {
+		t.Fatalf("MergeVotes failed: %v", err)
+	}
+	if result.VotesAdded != 1 {
+		t.Errorf("expected only the write-in vote to be added, got %+v", result)
+	}
+	reasons := map[string]string{}
+	for _, s := range result.Skipped {
+		reasons[s.CategoryName] += s.Reason
+	}
+	want := map[string]string{
+		"Judges Pick": "voter type is not allowed to vote in this category",
+		"Lions Best":  "car's rank is not allowed in this category",
+		"Finalists":   "car is not competing in this category",
+		"Best Design": services.ErrCarNotEligible.Error() + "this category does not accept write-in votes",
+	}
+	for name, reason := range want {
+		if reasons[name] != reason {
+			t.Errorf("expected %s skipped with %q, got %q", name, reason, reasons[name])
+		}
+	}
+
+	// The write-in car is created by the merge, and the vote keeps its time
+	carID, err := repo.FindWriteInCar(ctx, "rocket")
+	if err != nil {
+		t.Fatalf("expected the write-in car to be created: %v", err)
+	}
+	voterID, _ := repo.GetVoterByQR(ctx, "VOTER-M")
+	if votes, _ := repo.GetVoterVotes(ctx, voterID); len(votes) != 1 || votes[int(creative)] != carID {
+		t.Errorf("expected only the write-in vote, got %v", votes)
+	}
+	exported, _ := votingSvc.ExportVotes(ctx)
+	if len(exported.Votes) != 1 || !strings.HasPrefix(exported.Votes[0].VotedAt, "2026-03-14") {
+		t.Errorf("expected the merged vote to keep voted_at, got %+v", exported.Votes)
+	}
+}
+
+func TestMergeVotes_RejectedWriteInCreatesNoCar(t *testing.T) {
+	votingSvc, _, _, _, repo := setupVotingService(t)
+	ctx := context.Background()
+	repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+
+	export := exportOf("VOTER-W", []services.ExportVote{
+		{CategoryName: "Best Design", CarName: "Orphan", WriteIn: true},
+	})
+	result, err := votingSvc.MergeVotes(ctx, export)
+	if err != nil {
+		t.Fatalf("MergeVotes failed: %v", err)
+	}
+	if result.VotesAdded != 0 || len(result.Skipped) != 1 {
+		t.Errorf("expected the write-in to be skipped, got %+v", result)
+	}
+	if _, err := repo.FindWriteInCar(ctx, "Orphan"); err != repository.ErrNotFound {
+		t.Errorf("expected no write-in car for a skipped vote, got %v", err)
+	}
+}
+
+// exportOf builds an export of votes by one voter
+func exportOf(qr string, votes []services.ExportVote) services.VotesExport {
+	for i := range votes {
+		votes[i].VoterQR = qr
+	}
+	return services.VotesExport{Voters: []services.ExportVoter{{QRCode: qr}}, Votes: votes}
+}
+
+func TestMergeVotes_Empty(t *testing.T) {
+	votingSvc, _, _, _, _ := setupVotingService(t)
+
+	_, err := votingSvc.MergeVotes(context.Background(), services.VotesExport{})
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrValidation {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestSubmitVote_VoteChangesDisallowed(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+	cat1, cat2, _, cars := setupBallotData(t, repo)
+	_ = settingsSvc.SetAllowVoteChanges(ctx, false)
+
+	vote := models.Vote{VoterQR: "FINAL-QR", CategoryID: cat1, CarID: cars[0].ID}
+	if _, err := votingSvc.SubmitVote(ctx, vote); err != nil {
+		t.Fatalf("expected first vote to be allowed, got %v", err)
+	}
+	if _, err := votingSvc.SubmitVote(ctx, vote); err != nil {
+		t.Errorf("expected repeating the same vote to be allowed, got %v", err)
+	}
+
+	var appErr *apperrors.Error
+	for _, carID := range []int{cars[1].ID, 0} {
+		_, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "FINAL-QR", CategoryID: cat1, CarID: carID})
+		if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrConflict {
+			t.Errorf("expected conflict changing vote to car %d, got %v", carID, err)
+		}
+	}
+
+	// Replacing would clear the first vote, so it is a change too
+	_, err := votingSvc.SubmitVote(ctx, models.Vote{VoterQR: "FINAL-QR", CategoryID: cat2, CarID: cars[0].ID, Replace: true})
+	if !errors.As(err, &appErr) || appErr.Kind != apperrors.ErrConflict {
+		t.Errorf("expected conflict replacing a final vote, got %v", err)
+	}
+
+	voterID, _ := repo.GetVoterByQR(ctx, "FINAL-QR")
+	if votes, _ := repo.GetVoterVotes(ctx, voterID); votes[cat1] != cars[0].ID {
+		t.Errorf("expected original vote kept, got %v", votes)
+	}
+}
+
+func TestSubmitBallot_VoteChangesDisallowed(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+	settingsSvc.OpenVoting(ctx)
+	cat1, _, cat3, cars := setupBallotData(t, repo)
+	_ = settingsSvc.SetAllowVoteChanges(ctx, false)
+
+	if _, err := votingSvc.SubmitBallot(ctx, services.Ballot{VoterQR: "FINAL-QR", Votes: map[int]int{cat1: cars[0].ID}}); err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+
+	result, err := votingSvc.SubmitBallot(ctx, services.Ballot{
+		VoterQR: "FINAL-QR",
+		Votes:   map[int]int{cat1: cars[1].ID, cat3: cars[0].ID},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBallot failed: %v", err)
+	}
+	statuses := ballotStatuses(result)
+	if statuses[cat1] != services.BallotEntryRejected || statuses[cat3] != services.BallotEntryAccepted {
+		t.Errorf("expected the change rejected and the new vote accepted, got %v", statuses)
+	}
+}
+
+func TestCloseVotingIfIdle(t *testing.T) {
+	votingSvc, _, _, settingsSvc, repo := setupVotingService(t)
+	ctx := context.Background()
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	voterID, _ := repo.CreateVoter(ctx, "IDLE-QR")
+	openedLongAgo := func() {
+		t.Helper()
+		if err := repo.SetSetting(ctx, "voting_opened_at", time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)); err != nil {
+			t.Fatalf("SetSetting failed: %v", err)
+		}
+	}
+	openedLongAgo()
+
+	// Disabled by default
+	if closed, err := votingSvc.CloseVotingIfIdle(ctx); err != nil || closed {
+		t.Fatalf("expected no auto-close while disabled, got %v, %v", closed, err)
+	}
+
+	minutes := 10
+	if err := settingsSvc.UpdateSettings(ctx, services.Settings{AutoCloseIdleMinutes: &minutes}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+
+	// A recent vote keeps voting open
+	if err := repo.SaveVote(ctx, voterID, int(catID), 1); err != nil {
+		t.Fatalf("SaveVote failed: %v", err)
+	}
+	if closed, err := votingSvc.CloseVotingIfIdle(ctx); err != nil || closed {
+		t.Fatalf("expected voting to stay open after a recent vote, got %v, %v", closed, err)
+	}
+
+	// Idle for longer than the setting
+	if _, err := repo.DB().ExecContext(ctx, `UPDATE voters SET last_voted_at = ?`, time.Now().Add(-time.Hour).UTC()); err != nil {
+		t.Fatalf("failed to age vote: %v", err)
+	}
+	closed, err := votingSvc.CloseVotingIfIdle(ctx)
+	if err != nil || !closed {
+		t.Fatalf("expected voting to auto-close, got %v, %v", closed, err)
+	}
+	if open, _ := settingsSvc.IsVotingOpen(ctx); open {
+		t.Error("expected voting to be closed")
+	}
+	entries, _ := repo.ListAuditLog(ctx)
+	if len(entries) != 1 || entries[0].Action != models.AuditActionVotingAutoClosed || entries[0].Reason != "idle timeout" {
+		t.Errorf("expected an idle timeout audit entry, got %+v", entries)
+	}
+
+	// Already closed
+	if closed, err := votingSvc.CloseVotingIfIdle(ctx); err != nil || closed {
+		t.Errorf("expected nothing to close, got %v, %v", closed, err)
+	}
+
+	// Reopening restarts the idle clock even though the last vote is old
+	if err := settingsSvc.OpenVoting(ctx); err != nil {
+		t.Fatalf("OpenVoting failed: %v", err)
+	}
+	if closed, err := votingSvc.CloseVotingIfIdle(ctx); err != nil || closed {
+		t.Errorf("expected voting to stay open right after reopening, got %v, %v", closed, err)
+	}
+	openedLongAgo()
+	if closed, _ := votingSvc.CloseVotingIfIdle(ctx); !closed {
+		t.Error("expected voting to auto-close once idle again")
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// writeGaugeWindow is how many one-second slots the write gauge keeps
+const writeGaugeWindow = 60
+
+// writeLatencyBoundsMs are the upper bounds of the write latency histogram
+// buckets; slower writes fall in a final overflow bucket
+var writeLatencyBoundsMs = [...]float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// writeGaugeSlot holds the vote writes seen in one second
+type writeGaugeSlot struct {
+	second  atomic.Int64
+	votes   atomic.Int64
+	latency [len(writeLatencyBoundsMs) + 1]atomic.Int64
+}
+
+// WriteGauge tracks vote-write throughput and latency over the last minute,
+// so staff can see when the single SQLite writer starts falling behind. It uses
+// only atomic counters; a write racing with a slot being recycled may be
+// dropped, which is fine for a gauge.
+type WriteGauge struct {
+	slots [writeGaugeWindow]writeGaugeSlot
+}
+
+// NewWriteGauge creates an empty WriteGauge
+func NewWriteGauge() *WriteGauge {
+	return &WriteGauge{}
+}
+
+// ObserveWrite records a write of votes votes that took d
+func (g *WriteGauge) ObserveWrite(votes int, d time.Duration) {
+	slot := g.slot(time.Now().Unix())
+	slot.votes.Add(int64(votes))
+	slot.latency[latencyBucket(d)].Add(1)
+}
+
+// VotesPerMinute returns how many votes were written in the last minute
+func (g *WriteGauge) VotesPerMinute() int64 {
+	var votes int64
+	g.eachRecentSlot(func(slot *writeGaugeSlot) {
+		votes += slot.votes.Load()
+	})
+	return votes
+}
+
+// LatencyP95Ms returns the 95th percentile write latency over the last minute
+// in milliseconds, rounded up to its histogram bucket. Returns 0 when nothing
+// was written.
+func (g *WriteGauge) LatencyP95Ms() float64 {
+	var counts [len(writeLatencyBoundsMs) + 1]int64
+	var total int64
+	g.eachRecentSlot(func(slot *writeGaugeSlot) {
+		for i := range counts {
+			n := slot.latency[i].Load()
+			counts[i] += n
+			total += n
+		}
+	})
+	if total == 0 {
+		return 0
+	}
+
+	target := (total*95 + 99) / 100
+	var seen int64
+	for i, n := range counts {
+		seen += n
+		if seen >= target && i < len(writeLatencyBoundsMs) {
+			return writeLatencyBoundsMs[i]
+		}
+	}
+	return writeLatencyBoundsMs[len(writeLatencyBoundsMs)-1]
+}
+
+// slot returns the slot for second, clearing it first if it last held an
+// older second
+func (g *WriteGauge) slot(second int64) *writeGaugeSlot {
+	slot := &g.slots[second%writeGaugeWindow]
+	if old := slot.second.Load(); old != second && slot.second.CompareAndSwap(old, second) {
+		slot.votes.Store(0)
+		for i := range slot.latency {
+			slot.latency[i].Store(0)
+		}
+	}
+	return slot
+}
+
+// eachRecentSlot calls fn for every slot written within the last minute
+func (g *WriteGauge) eachRecentSlot(fn func(slot *writeGaugeSlot)) {
+	now := time.Now().Unix()
+	for i := range g.slots {
+		slot := &g.slots[i]
+		if second := slot.second.Load(); second > now-writeGaugeWindow && second <= now {
+			fn(slot)
+		}
+	}
+}
+
+// latencyBucket returns the histogram bucket for a write that took d
+func latencyBucket(d time.Duration) int {
+	ms := float64(d) / float64(time.Millisecond)
+	for i, bound := range writeLatencyBoundsMs {
+		if ms <= bound {
+			return i
+		}
+	}
+	return len(writeLatencyBoundsMs)
+}
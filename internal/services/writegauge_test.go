@@ -0,0 +1,98 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/abrezinsky/derbyvote/internal/logger"
+	"github.com/abrezinsky/derbyvote/internal/services"
+	"github.com/abrezinsky/derbyvote/internal/testutil"
+	"github.com/abrezinsky/derbyvote/pkg/derbynet"
+)
+
+func TestWriteGauge_Empty(t *testing.T) {
+	gauge := services.NewWriteGauge()
+
+	if got := gauge.VotesPerMinute(); got != 0 {
+		t.Errorf("expected 0 votes per minute, got %d", got)
+	}
+	if got := gauge.LatencyP95Ms(); got != 0 {
+		t.Errorf("expected 0 p95 latency, got %v", got)
+	}
+}
+
+func TestWriteGauge_RateAndP95(t *testing.T) {
+	gauge := services.NewWriteGauge()
+
+	for i := 0; i < 19; i++ {
+		gauge.ObserveWrite(1, 3*time.Millisecond)
+	}
+	gauge.ObserveWrite(4, 400*time.Millisecond)
+
+	if got := gauge.VotesPerMinute(); got != 23 {
+		t.Errorf("expected 23 votes per minute, got %d", got)
+	}
+	// 19 of 20 writes finished within the 5ms bucket
+	if got := gauge.LatencyP95Ms(); got != 5 {
+		t.Errorf("expected p95 of 5ms, got %v", got)
+	}
+
+	gauge.ObserveWrite(0, 400*time.Millisecond)
+	if got := gauge.LatencyP95Ms(); got != 500 {
+		t.Errorf("expected p95 of 500ms after a second slow write, got %v", got)
+	}
+	if got := gauge.VotesPerMinute(); got != 23 {
+		t.Errorf("expected a failed write not to count as votes, got %d", got)
+	}
+}
+
+func TestWriteGauge_OverflowReportsLargestBound(t *testing.T) {
+	gauge := services.NewWriteGauge()
+	gauge.ObserveWrite(1, time.Minute)
+
+	if got := gauge.LatencyP95Ms(); got != 5000 {
+		t.Errorf("expected p95 capped at 5000ms, got %v", got)
+	}
+}
+
+func TestWriteGauge_RecordsVotesAndReportsStats(t *testing.T) {
+	repo := testutil.NewTestRepository(t)
+	log := logger.New()
+	settingsSvc := services.NewSettingsService(log, repo)
+	categorySvc := services.NewCategoryService(log, repo, derbynet.NewMockClient())
+	carSvc := services.NewCarService(log, repo, derbynet.NewMockClient())
+	votingSvc := services.NewVotingService(log, repo, categorySvc, carSvc, settingsSvc)
+	resultsSvc := services.NewResultsService(log, repo, settingsSvc, derbynet.NewMockClient())
+	ctx := context.Background()
+
+	stats, err := resultsSvc.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if _, ok := stats["votes_per_minute"]; ok {
+		t.Error("expected no write stats without a gauge")
+	}
+
+	gauge := services.NewWriteGauge()
+	votingSvc.SetWriteGauge(gauge)
+	resultsSvc.SetWriteGauge(gauge)
+
+	catID, _ := repo.CreateCategory(ctx, "Best Design", 1, nil, nil, nil, nil)
+	_ = repo.CreateCar(ctx, "101", "Racer", "Car", "")
+	voterID, _ := repo.CreateVoter(ctx, "GAUGE-QR")
+	if err := votingSvc.SaveVote(ctx, voterID, int(catID), 1, true); err != nil {
+		t.Fatalf("SaveVote failed: %v", err)
+	}
+
+	stats, err = resultsSvc.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if stats["votes_per_minute"] != int64(1) {
+		t.Errorf("expected 1 vote per minute, got %v", stats["votes_per_minute"])
+	}
+	if p95, ok := stats["write_latency_ms_p95"].(float64); !ok || p95 <= 0 {
+		t.Errorf("expected a positive write_latency_ms_p95, got %v", stats["write_latency_ms_p95"])
+	}
+}
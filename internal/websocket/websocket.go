@@ -28,6 +28,11 @@ type Hub struct {
 	unregister chan *Client
 	mutex      sync.RWMutex
 	settings   services.SettingsServicer
+
+	// readOnly hubs leave closing voting to the writable instance; the
+	// countdown tracks lastVotingOpen to report when that instance does
+	readOnly       bool
+	lastVotingOpen *bool
 }
 
 // Client is a middleman between the websocket connection and the hub
@@ -49,6 +54,14 @@ func New(log logger.Logger, settings services.SettingsServicer) *Hub {
 	}
 }
 
+// SetReadOnly makes the countdown broadcast the time remaining without
+// closing voting when it runs out, for instances that open the database
+// read-only. Voting status changes made by the writable instance are
+// broadcast as the countdown sees them.
+func (h *Hub) SetReadOnly(readOnly bool) {
+	h.readOnly = readOnly
+}
+
 // Start begins the hub's main loop in a goroutine
 func (h *Hub) Start() {
 	go h.run()
@@ -71,11 +84,8 @@ func (h *Hub) run() {
 				closeTime, _ := h.settings.GetSetting(ctx, "voting_close_time")
 
 				client.send <- models.WSMessage{
-					Type: "voting_status",
-					Payload: map[string]interface{}{
-						"open":       votingOpen,
-						"close_time": closeTime,
-					},
+					Type:    "voting_status",
+					Payload: h.votingStatus(ctx, votingOpen, closeTime),
 				}
 			}()
 
@@ -115,10 +125,21 @@ func (h *Hub) BroadcastMessage(msgType string, payload interface{}) {
 
 // BroadcastVotingStatus implements services.Broadcaster
 func (h *Hub) BroadcastVotingStatus(open bool, closeTime string) {
-	h.BroadcastMessage("voting_status", map[string]interface{}{
-		"open":       open,
-		"close_time": closeTime,
-	})
+	h.BroadcastMessage("voting_status", h.votingStatus(context.Background(), open, closeTime))
+}
+
+// votingStatus builds the voting_status payload. allow_vote_changes tells the
+// voter page whether to warn that votes are final once cast.
+func (h *Hub) votingStatus(ctx context.Context, open bool, closeTime string) map[string]interface{} {
+	allowVoteChanges, err := h.settings.AllowVoteChanges(ctx)
+	if err != nil {
+		allowVoteChanges = true
+	}
+	return map[string]interface{}{
+		"open":               open,
+		"close_time":         closeTime,
+		"allow_vote_changes": allowVoteChanges,
+	}
 }
 
 // readPump pumps messages from the websocket connection to the hub
@@ -195,7 +216,7 @@ func (c *Client) writePump() {
 func (h *Hub) ServeWs(w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		h.log.Error("WebSocket upgrade error", "error", err)
+		h.log.ErrorContext(r.Context(), "WebSocket upgrade error", "error", err)
 		return
 	}
 
@@ -230,6 +251,10 @@ func (h *Hub) StartVotingCountdown(ctx context.Context) {
 // checkAndUpdateCountdown checks the timer and broadcasts updates
 func (h *Hub) checkAndUpdateCountdown() {
 	ctx := context.Background()
+	if h.readOnly {
+		h.followVotingStatus(ctx)
+	}
+
 	closeTimeStr, err := h.settings.GetSetting(ctx, "voting_close_time")
 	if err != nil || closeTimeStr == "" {
 		return
@@ -242,6 +267,11 @@ func (h *Hub) checkAndUpdateCountdown() {
 
 	now := time.Now()
 	if now.After(closeTime) {
+		if h.readOnly {
+			// The writable instance closes voting; followVotingStatus reports it
+			return
+		}
+
 		// Time's up! Close voting
 		votingOpen, _ := h.settings.IsVotingOpen(ctx)
 		if votingOpen {
@@ -249,10 +279,7 @@ func (h *Hub) checkAndUpdateCountdown() {
 			h.settings.SetSetting(ctx, "voting_close_time", "")
 			h.log.Info("Voting automatically closed by timer")
 
-			h.BroadcastMessage("voting_status", map[string]interface{}{
-				"open":       false,
-				"close_time": "",
-			})
+			h.BroadcastMessage("voting_status", h.votingStatus(ctx, false, ""))
 		}
 	} else {
 		// Send countdown update
@@ -262,3 +289,17 @@ func (h *Hub) checkAndUpdateCountdown() {
 		})
 	}
 }
+
+// followVotingStatus broadcasts the voting status when it has changed since
+// the last check, for read-only hubs whose database another instance writes
+func (h *Hub) followVotingStatus(ctx context.Context) {
+	open, err := h.settings.IsVotingOpen(ctx)
+	if err != nil {
+		return
+	}
+	if h.lastVotingOpen != nil && *h.lastVotingOpen != open {
+		closeTime, _ := h.settings.GetSetting(ctx, "voting_close_time")
+		h.BroadcastMessage("voting_status", h.votingStatus(ctx, open, closeTime))
+	}
+	h.lastVotingOpen = &open
+}
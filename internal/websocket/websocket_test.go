@@ -74,22 +74,90 @@ func (m *mockSettingsService) CloseVoting(ctx context.Context) error
 func (m *mockSettingsService) StartVotingTimer(ctx context.Context, min int) (string, error) {
 	return "", nil
 }
+func (m *mockSettingsService) GetVotingClock(ctx context.Context) (*services.VotingClock, error) {
+	return &services.VotingClock{}, nil
+}
+func (m *mockSettingsService) GetMaxVotingMinutes(ctx context.Context) (int, error) {
+	return services.DefaultMaxVotingMinutes, nil
+}
+func (m *mockSettingsService) GetTieMargin(ctx context.Context) (int, error) {
+	return 0, nil
+}
+func (m *mockSettingsService) GetAutoCloseIdleMinutes(ctx context.Context) (int, error) {
+	return 0, nil
+}
+func (m *mockSettingsService) VotingOpenedAt(ctx context.Context) (time.Time, error) {
+	return time.Time{}, nil
+}
+func (m *mockSettingsService) GetVotingInstructions(ctx context.Context, voterType string) (string, error) {
+	return "", nil
+}
+func (m *mockSettingsService) GetVotingInstructionsByType(ctx context.Context) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+func (m *mockSettingsService) GetVotingTimerPresets(ctx context.Context) ([]int, error) {
+	return services.DefaultVotingTimerPresets, nil
+}
 func (m *mockSettingsService) UpdateSettings(ctx context.Context, s services.Settings) error {
 	return nil
 }
 func (m *mockSettingsService) ResetTables(ctx context.Context, t []string) (*services.ResetTablesResult, error) {
 	return nil, nil
 }
+func (m *mockSettingsService) StartNewEvent(ctx context.Context) (*services.NewEventResult, error) {
+	return nil, nil
+}
 func (m *mockSettingsService) SetBroadcaster(b services.Broadcaster) {}
 func (m *mockSettingsService) RequireRegisteredQR(ctx context.Context) (bool, error) {
 	return false, nil
 }
+func (m *mockSettingsService) PublicResultsEnabled(ctx context.Context) (bool, error) {
+	return false, nil
+}
+func (m *mockSettingsService) DerbyNetHealthPolling(ctx context.Context) (bool, error) {
+	return false, nil
+}
+func (m *mockSettingsService) AnonymizeBallots(ctx context.Context) (bool, error) {
+	return false, nil
+}
+func (m *mockSettingsService) RequireCompleteBallot(ctx context.Context) (bool, error) {
+	return false, nil
+}
+func (m *mockSettingsService) AllowVoteChanges(ctx context.Context) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.settings["allow_vote_changes"] != "false", nil
+}
+func (m *mockSettingsService) OpenVotingOnePerDevice(ctx context.Context) (bool, error) {
+	return false, nil
+}
+func (m *mockSettingsService) MaintenanceMessage(ctx context.Context) (string, error) {
+	return "", nil
+}
+func (m *mockSettingsService) PostVoteRedirectURL(ctx context.Context) (string, error) {
+	return "", nil
+}
+func (m *mockSettingsService) CarNumberFormat(ctx context.Context) (string, error) {
+	return "any", nil
+}
 func (m *mockSettingsService) GetVoterTypes(ctx context.Context) ([]string, error) {
 	return []string{"general", "racer"}, nil
 }
 func (m *mockSettingsService) SetVoterTypes(ctx context.Context, types []string) error {
 	return nil
 }
+func (m *mockSettingsService) ExportSettings(ctx context.Context, includeSensitive bool) (map[string]string, error) {
+	return nil, nil
+}
+func (m *mockSettingsService) ImportSettings(ctx context.Context, values map[string]string, includeSensitive bool) (*services.SettingsImportResult, error) {
+	return nil, nil
+}
+func (m *mockSettingsService) GetBranding(ctx context.Context) (*services.Branding, error) {
+	return &services.Branding{}, nil
+}
+func (m *mockSettingsService) GetLocation(ctx context.Context) (*time.Location, error) {
+	return time.UTC, nil
+}
 
 func TestNew_CreatesHubWithDependencies(t *testing.T) {
 	log := logger.New()
@@ -167,6 +235,40 @@ func TestHub_BroadcastVotingStatus_ImplementsBroadcaster(t *testing.T) {
 	}
 }
 
+func TestHub_VotingStatusIncludesVoteChangePolicy(t *testing.T) {
+	log := logger.New()
+	settings := newMockSettingsService()
+	settings.settings["allow_vote_changes"] = "false"
+	hub := New(log, settings)
+	hub.Start()
+
+	server := httptest.NewServer(http.HandlerFunc(hub.ServeWs))
+	defer server.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+server.URL[4:], nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer ws.Close()
+
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, message, err := ws.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read initial voting_status: %v", err)
+	}
+
+	var msg struct {
+		Type    string                 `json:"type"`
+		Payload map[string]interface{} `json:"payload"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil {
+		t.Fatalf("failed to unmarshal message: %v", err)
+	}
+	if msg.Type != "voting_status" || msg.Payload["allow_vote_changes"] != false {
+		t.Errorf("expected voting_status with allow_vote_changes false, got %s", message)
+	}
+}
+
 func TestHub_StartVotingCountdown_ContextCancellation(t *testing.T) {
 	log := logger.New()
 	settings := newMockSettingsService()
@@ -688,6 +790,65 @@ func TestCheckAndUpdateCountdown_ClosesExpiredVoting(t *testing.T) {
 	}
 }
 
+func TestCheckAndUpdateCountdown_ReadOnlyLeavesVotingToWriter(t *testing.T) {
+	log := logger.New()
+	settings := newMockSettingsService()
+	settings.votingOpen = true
+	closeTime := time.Now().Add(-1 * time.Second).Format(time.RFC3339)
+	settings.settings["voting_close_time"] = closeTime
+
+	hub := New(log, settings)
+	hub.SetReadOnly(true)
+	hub.Start()
+
+	server := httptest.NewServer(http.HandlerFunc(hub.ServeWs))
+	defer server.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+server.URL[4:], nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer ws.Close()
+
+	// Read and discard the initial voting_status message
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := ws.ReadMessage(); err != nil {
+		t.Fatalf("failed to read initial voting_status: %v", err)
+	}
+
+	// The timer has run out, but closing voting is the writable instance's job
+	hub.checkAndUpdateCountdown()
+
+	settings.mu.Lock()
+	votingOpen, setVotingCall := settings.votingOpen, settings.setVotingCall
+	keptCloseTime := settings.settings["voting_close_time"]
+	settings.mu.Unlock()
+	if !votingOpen || setVotingCall != 0 || keptCloseTime != closeTime {
+		t.Errorf("expected no writes from a read-only hub, got open=%v, %d SetVotingOpen calls, close time %q", votingOpen, setVotingCall, keptCloseTime)
+	}
+
+	// Once the writable instance closes voting, clients hear about it
+	settings.mu.Lock()
+	settings.votingOpen = false
+	settings.settings["voting_close_time"] = ""
+	settings.mu.Unlock()
+	hub.checkAndUpdateCountdown()
+
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, message, err := ws.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	var msg models.WSMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		t.Fatalf("failed to unmarshal message: %v", err)
+	}
+	payload, _ := msg.Payload.(map[string]interface{})
+	if msg.Type != "voting_status" || payload["open"] != false {
+		t.Errorf("expected voting_status closed, got %s", message)
+	}
+}
+
 func TestCheckAndUpdateCountdown_InvalidCloseTime(t *testing.T) {
 	log := logger.New()
 	settings := newMockSettingsService()
@@ -4,12 +4,15 @@ package derbynet
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/abrezinsky/derbyvote/internal/logger"
@@ -96,6 +99,72 @@ type Outcome struct {
 	Description string `json:"description"`
 }
 
+// Outcome codes DerbyNet reports for authentication failures
+const (
+	CodeNotAuthorized     = "notauthorized"      // the logged-in role may not perform the action
+	CodeLoginFailed       = "login"              // role.login rejected the password
+	CodeAuthFailed        = "authfailed"         // older servers' wording for a rejected password
+	CodeIncorrectPassword = "incorrect-password" // newer servers' wording for a rejected password
+)
+
+// DerbyNetError is a failure outcome returned by DerbyNet. Client methods wrap
+// it, so callers can inspect the code with errors.As or the helpers below.
+type DerbyNetError struct {
+	Code        string
+	Description string
+}
+
+func (e *DerbyNetError) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Description, e.Code)
+}
+
+// WrongPassword reports whether DerbyNet rejected the role's password
+func (e *DerbyNetError) WrongPassword() bool {
+	switch e.Code {
+	case CodeLoginFailed, CodeAuthFailed, CodeIncorrectPassword:
+		return true
+	}
+	return false
+}
+
+// NotAuthorized reports whether the role lacks permission for the action
+func (e *DerbyNetError) NotAuthorized() bool {
+	return e.Code == CodeNotAuthorized
+}
+
+// Err returns the outcome as a *DerbyNetError, or nil unless it is a failure
+func (o Outcome) Err() error {
+	if o.Summary != "failure" {
+		return nil
+	}
+	return &DerbyNetError{Code: o.Code, Description: o.Description}
+}
+
+// IsWrongPassword reports whether err is DerbyNet rejecting the password
+func IsWrongPassword(err error) bool {
+	var dnErr *DerbyNetError
+	return errors.As(err, &dnErr) && dnErr.WrongPassword()
+}
+
+// IsNotAuthorized reports whether err is DerbyNet refusing the role access
+func IsNotAuthorized(err error) bool {
+	var dnErr *DerbyNetError
+	return errors.As(err, &dnErr) && dnErr.NotAuthorized()
+}
+
+// Explain describes err for an admin, naming a wrong password or a role
+// without enough access instead of DerbyNet's raw outcome
+func Explain(err error) string {
+	switch {
+	case IsWrongPassword(err):
+		return "Wrong DerbyNet password"
+	case IsNotAuthorized(err):
+		return "Insufficient DerbyNet role: log in as a role with award permissions (e.g. RaceCoordinator)"
+	default:
+		return err.Error()
+	}
+}
+
 // CreateAwardResponse is the response from creating an award
 type CreateAwardResponse struct {
 	Awards  []Award `json:"awards"`
@@ -127,32 +196,69 @@ type Client interface {
 	BaseURL() string
 	// SetBaseURL updates the DerbyNet base URL
 	SetBaseURL(url string)
+	// SetFallbackURL sets a second DerbyNet URL to try when the base URL
+	// cannot be reached; empty disables the fallback
+	SetFallbackURL(url string)
+	// UseURLs sets the base and fallback URLs unless the client already has
+	// them, possibly swapped by an earlier failover
+	UseURLs(baseURL, fallbackURL string)
+	// Clone returns a client with the same URLs and credentials but its own
+	// session state, for callers that run alongside the original
+	Clone() Client
 }
 
 // HTTPClient is a real HTTP client for DerbyNet
 type HTTPClient struct {
+	httpClient *http.Client
+	log        logger.Logger
+
+	// mu guards the URLs and login state, which requests running on other
+	// goroutines change when they fail over or log in
+	mu            sync.Mutex
 	baseURL       string
-	httpClient    *http.Client
-	log           logger.Logger
+	fallbackURL   string
 	role          string
 	password      string
 	authenticated bool
 }
 
+// connectTimeout bounds how long a request waits for DerbyNet to accept the
+// connection. A host that has moved usually never answers rather than
+// refusing, and the fallback URL has to get its turn well inside an admin
+// request's deadline.
+var connectTimeout = 3 * time.Second
+
+// dialer makes the connections for NewTransport
+var dialer = (&net.Dialer{KeepAlive: 30 * time.Second}).DialContext
+
+// NewTransport returns an HTTP transport that gives up connecting to DerbyNet
+// after a few seconds, so an unreachable host fails over to the fallback URL
+func NewTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+		defer cancel()
+		return dialer(ctx, network, addr)
+	}
+	return transport
+}
+
 // NewHTTPClient creates a new DerbyNet HTTP client with cookie support
 func NewHTTPClient(baseURL string, log logger.Logger) *HTTPClient {
 	jar, _ := cookiejar.New(nil)
 	return &HTTPClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-			Jar:     jar,
+			Timeout:   30 * time.Second,
+			Jar:       jar,
+			Transport: NewTransport(),
 		},
 		log: log,
 	}
 }
 
-// NewHTTPClientWithHTTPClient creates a new DerbyNet client with a custom http.Client
+// NewHTTPClientWithHTTPClient creates a new DerbyNet client with a custom
+// http.Client. Give it a NewTransport so unreachable hosts fail over promptly.
 func NewHTTPClientWithHTTPClient(baseURL string, httpClient *http.Client, log logger.Logger) *HTTPClient {
 	return &HTTPClient{
 		baseURL:    baseURL,
@@ -163,37 +269,142 @@ func NewHTTPClientWithHTTPClient(baseURL string, httpClient *http.Client, log lo
 
 // BaseURL returns the configured DerbyNet base URL
 func (c *HTTPClient) BaseURL() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.baseURL
 }
 
 // SetBaseURL updates the DerbyNet base URL
 func (c *HTTPClient) SetBaseURL(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.baseURL = url
 }
 
+// SetFallbackURL sets a second DerbyNet URL to try when the base URL cannot
+// be reached. When the fallback answers it becomes the base URL, and the old
+// base URL becomes the fallback.
+func (c *HTTPClient) SetFallbackURL(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fallbackURL = url
+}
+
+// UseURLs sets the base and fallback URLs from configuration. When the client
+// already has them, including with the two swapped after failing over, it is
+// left alone so requests keep going to the URL that last answered.
+func (c *HTTPClient) UseURLs(baseURL, fallbackURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.baseURL == baseURL && c.fallbackURL == fallbackURL {
+		return
+	}
+	if fallbackURL != "" && c.baseURL == fallbackURL && c.fallbackURL == baseURL {
+		return
+	}
+	c.baseURL, c.fallbackURL = baseURL, fallbackURL
+}
+
+// Clone returns a client with the same URLs and credentials that keeps its own
+// URL and login state. The underlying http.Client, and so its cookie jar, is
+// shared, which is safe for concurrent use.
+func (c *HTTPClient) Clone() Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &HTTPClient{
+		baseURL:     c.baseURL,
+		fallbackURL: c.fallbackURL,
+		httpClient:  c.httpClient,
+		log:         c.log,
+		role:        c.role,
+		password:    c.password,
+	}
+}
+
+// do sends req, resending it to the fallback URL when the base URL cannot be
+// reached. Only connection failures fail over; an HTTP error status does not.
+func (c *HTTPClient) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	c.mu.Lock()
+	primary, fallback := c.baseURL, c.fallbackURL
+	c.mu.Unlock()
+	if err == nil || fallback == "" || fallback == primary || req.Context().Err() != nil {
+		return resp, err
+	}
+	if !strings.HasPrefix(req.URL.String(), primary) {
+		return nil, err
+	}
+
+	retry, rerr := http.NewRequestWithContext(req.Context(), req.Method, fallback+strings.TrimPrefix(req.URL.String(), primary), nil)
+	if rerr != nil {
+		return nil, err
+	}
+	retry.Header = req.Header.Clone()
+	if req.GetBody != nil {
+		if retry.Body, rerr = req.GetBody(); rerr != nil {
+			return nil, err
+		}
+		retry.ContentLength = req.ContentLength
+	}
+
+	c.log.WarnContext(req.Context(), "DerbyNet unreachable, trying fallback URL", "url", primary, "fallback", fallback, "error", err)
+	resp, ferr := c.httpClient.Do(retry)
+	if ferr != nil {
+		return nil, fmt.Errorf("%w (fallback %s: %v)", err, fallback, ferr)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// A concurrent request may already have switched, or the URLs been replaced
+	if c.baseURL == primary && c.fallbackURL == fallback {
+		c.log.InfoContext(req.Context(), "Switched to fallback DerbyNet URL", "url", fallback)
+		c.baseURL, c.fallbackURL = fallback, primary
+		// The session cookie belongs to the old host
+		c.authenticated = false
+	}
+	return resp, nil
+}
+
 // SetCredentials configures authentication credentials for automatic login
 func (c *HTTPClient) SetCredentials(role, password string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.role = role
 	c.password = password
 	c.authenticated = false // Reset auth state when credentials change
 }
 
+// session returns the stored credentials and whether they are logged in
+func (c *HTTPClient) session() (role, password string, authenticated bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.role, c.password, c.authenticated
+}
+
 // doRequest executes an HTTP POST request to DerbyNet and handles common error checking
 // It validates the HTTP status, parses the JSON response, and checks the outcome field for failures
 // Automatically re-authenticates if the session has expired
 func (c *HTTPClient) doRequest(ctx context.Context, action string, params url.Values, response interface{}) error {
+	return c.doRequestRetry(ctx, action, params, response, true)
+}
+
+// doRequestRetry is doRequest with control over the re-authentication retry.
+// The retry happens at most once, so a role that logs in fine but lacks
+// permission for the action surfaces as a notauthorized DerbyNetError.
+func (c *HTTPClient) doRequestRetry(ctx context.Context, action string, params url.Values, response interface{}, retry bool) error {
 	// Ensure we're authenticated before making the request
-	if !c.authenticated && c.role != "" && c.password != "" {
-		c.log.Debug("Not authenticated, logging in before request")
-		if err := c.Login(ctx, c.role, c.password); err != nil {
+	role, password, authenticated := c.session()
+	if !authenticated && role != "" && password != "" {
+		c.log.DebugContext(ctx, "Not authenticated, logging in before request")
+		if err := c.Login(ctx, role, password); err != nil {
 			return fmt.Errorf("failed to authenticate: %w", err)
 		}
 	}
 
-	apiURL := fmt.Sprintf("%s/action.php", c.baseURL)
+	apiURL := fmt.Sprintf("%s/action.php", c.BaseURL())
 	params.Set("action", action)
 
-	c.log.Debug("DerbyNet request", "method", "POST", "url", apiURL, "action", action, "body", params.Encode())
+	c.log.DebugContext(ctx, "DerbyNet request", "method", "POST", "url", apiURL, "action", action, "body", params.Encode())
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(params.Encode()))
 	if err != nil {
@@ -201,7 +412,7 @@ func (c *HTTPClient) doRequest(ctx context.Context, action string, params url.Va
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to connect to DerbyNet: %w", err)
 	}
@@ -212,7 +423,7 @@ func (c *HTTPClient) doRequest(ctx context.Context, action string, params url.Va
 		return fmt.Errorf("failed to read response: %w", err)
 	}
 
-	c.log.Debug("DerbyNet response", "status", resp.StatusCode, "body", string(body))
+	c.log.DebugContext(ctx, "DerbyNet response", "status", resp.StatusCode, "body", string(body))
 
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("DerbyNet returned status %d: %s", resp.StatusCode, string(body))
@@ -224,18 +435,20 @@ func (c *HTTPClient) doRequest(ctx context.Context, action string, params url.Va
 	}
 	if err := json.Unmarshal(body, &outcomeCheck); err == nil {
 		// If we get "notauthorized", try to re-authenticate and retry once
-		if outcomeCheck.Outcome.Code == "notauthorized" && c.role != "" && c.password != "" {
-			c.log.Debug("Session expired, re-authenticating")
+		if retry && outcomeCheck.Outcome.Code == CodeNotAuthorized && role != "" && password != "" {
+			c.log.DebugContext(ctx, "Session expired, re-authenticating")
+			c.mu.Lock()
 			c.authenticated = false
-			if err := c.Login(ctx, c.role, c.password); err != nil {
+			c.mu.Unlock()
+			if err := c.Login(ctx, role, password); err != nil {
 				return fmt.Errorf("failed to re-authenticate: %w", err)
 			}
 			// Retry the original request
-			return c.doRequest(ctx, action, params, response)
+			return c.doRequestRetry(ctx, action, params, response, false)
 		}
 
-		if outcomeCheck.Outcome.Summary == "failure" {
-			return fmt.Errorf("DerbyNet error: %s (%s)", outcomeCheck.Outcome.Description, outcomeCheck.Outcome.Code)
+		if err := outcomeCheck.Outcome.Err(); err != nil {
+			return fmt.Errorf("DerbyNet error: %w", err)
 		}
 	}
 
@@ -259,7 +472,7 @@ func (c *HTTPClient) Login(ctx context.Context, role, password string) error {
 	params.Set("name", role)
 	params.Set("password", password)
 
-	apiURL := fmt.Sprintf("%s/action.php", c.baseURL)
+	apiURL := fmt.Sprintf("%s/action.php", c.BaseURL())
 	params.Set("action", "role.login")
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(params.Encode()))
@@ -268,7 +481,7 @@ func (c *HTTPClient) Login(ctx context.Context, role, password string) error {
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to connect to DerbyNet: %w", err)
 	}
@@ -279,7 +492,7 @@ func (c *HTTPClient) Login(ctx context.Context, role, password string) error {
 		return fmt.Errorf("failed to read response: %w", err)
 	}
 
-	c.log.Debug("DerbyNet login response", "status", resp.StatusCode, "body", string(body))
+	c.log.DebugContext(ctx, "DerbyNet login response", "status", resp.StatusCode, "body", string(body))
 
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("DerbyNet returned status %d: %s", resp.StatusCode, string(body))
@@ -290,31 +503,33 @@ func (c *HTTPClient) Login(ctx context.Context, role, password string) error {
 		return fmt.Errorf("failed to parse login response: %w", err)
 	}
 
-	if response.Outcome.Summary == "failure" {
-		return fmt.Errorf("DerbyNet login failed: %s (%s)", response.Outcome.Description, response.Outcome.Code)
+	if err := response.Outcome.Err(); err != nil {
+		return fmt.Errorf("DerbyNet login failed: %w", err)
 	}
 
 	// Save credentials for re-authentication
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.role = role
 	c.password = password
 	c.authenticated = true
 
-	c.log.Info("DerbyNet login successful", "role", role)
+	c.log.InfoContext(ctx, "DerbyNet login successful", "role", role)
 	return nil
 }
 
 // FetchRacers retrieves all racers from DerbyNet
 func (c *HTTPClient) FetchRacers(ctx context.Context) ([]Racer, error) {
-	reqURL := fmt.Sprintf("%s/action.php?query=racer.list&render=200x200", c.baseURL)
+	reqURL := fmt.Sprintf("%s/action.php?query=racer.list&render=200x200", c.BaseURL())
 
-	c.log.Debug("DerbyNet request", "method", "GET", "url", reqURL)
+	c.log.DebugContext(ctx, "DerbyNet request", "method", "GET", "url", reqURL)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to DerbyNet: %w", err)
 	}
@@ -325,7 +540,7 @@ func (c *HTTPClient) FetchRacers(ctx context.Context) ([]Racer, error) {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	c.log.Debug("DerbyNet response", "status", resp.StatusCode, "body", string(body))
+	c.log.DebugContext(ctx, "DerbyNet response", "status", resp.StatusCode, "body", string(body))
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("DerbyNet returned status %d", resp.StatusCode)
@@ -341,16 +556,16 @@ func (c *HTTPClient) FetchRacers(ctx context.Context) ([]Racer, error) {
 
 // FetchAwards retrieves all awards/categories from DerbyNet
 func (c *HTTPClient) FetchAwards(ctx context.Context) ([]Award, error) {
-	reqURL := fmt.Sprintf("%s/action.php?query=award.list", c.baseURL)
+	reqURL := fmt.Sprintf("%s/action.php?query=award.list", c.BaseURL())
 
-	c.log.Debug("DerbyNet request", "method", "GET", "url", reqURL)
+	c.log.DebugContext(ctx, "DerbyNet request", "method", "GET", "url", reqURL)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to DerbyNet: %w", err)
 	}
@@ -361,7 +576,7 @@ func (c *HTTPClient) FetchAwards(ctx context.Context) ([]Award, error) {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	c.log.Debug("DerbyNet response", "status", resp.StatusCode, "body", string(body))
+	c.log.DebugContext(ctx, "DerbyNet response", "status", resp.StatusCode, "body", string(body))
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("DerbyNet returned status %d", resp.StatusCode)
@@ -377,16 +592,16 @@ func (c *HTTPClient) FetchAwards(ctx context.Context) ([]Award, error) {
 
 // FetchAwardTypes retrieves all award types from DerbyNet
 func (c *HTTPClient) FetchAwardTypes(ctx context.Context) ([]AwardType, error) {
-	reqURL := fmt.Sprintf("%s/action.php?query=award.list", c.baseURL)
+	reqURL := fmt.Sprintf("%s/action.php?query=award.list", c.BaseURL())
 
-	c.log.Debug("DerbyNet request", "method", "GET", "url", reqURL)
+	c.log.DebugContext(ctx, "DerbyNet request", "method", "GET", "url", reqURL)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to DerbyNet: %w", err)
 	}
@@ -397,7 +612,7 @@ func (c *HTTPClient) FetchAwardTypes(ctx context.Context) ([]AwardType, error) {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	c.log.Debug("DerbyNet response", "status", resp.StatusCode, "body", string(body))
+	c.log.DebugContext(ctx, "DerbyNet response", "status", resp.StatusCode, "body", string(body))
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("DerbyNet returned status %d", resp.StatusCode)
@@ -423,12 +638,12 @@ func (c *HTTPClient) CreateAward(ctx context.Context, name string, awardTypeID i
 		return 0, err
 	}
 
-	c.log.Debug("CreateAward parsed response", "awards_count", len(response.Awards))
+	c.log.DebugContext(ctx, "CreateAward parsed response", "awards_count", len(response.Awards))
 
 	// Find the award with matching name to get its ID
 	for _, award := range response.Awards {
 		if award.AwardName == name {
-			c.log.Debug("Found matching award", "name", name, "award_id", award.AwardID)
+			c.log.DebugContext(ctx, "Found matching award", "name", name, "award_id", award.AwardID)
 			return award.AwardID, nil
 		}
 	}
@@ -6,10 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/abrezinsky/derbyvote/internal/logger"
 )
@@ -21,6 +24,10 @@ func (noopLogger) Debug(msg string, args ...any) {}
 func (noopLogger) Info(msg string, args ...any)  {}
 func (noopLogger) Warn(msg string, args ...any)  {}
 func (noopLogger) Error(msg string, args ...any) {}
+func (noopLogger) DebugContext(ctx context.Context, msg string, args ...any) {}
+func (noopLogger) InfoContext(ctx context.Context, msg string, args ...any)  {}
+func (noopLogger) WarnContext(ctx context.Context, msg string, args ...any)  {}
+func (noopLogger) ErrorContext(ctx context.Context, msg string, args ...any) {}
 func (n noopLogger) SetLevel(level slog.Level) {}
 func (n noopLogger) GetLevel() slog.Level { return slog.LevelInfo }
 func (n noopLogger) EnableHTTPLogging() {}
@@ -486,6 +493,20 @@ func TestHTTPClient_SetBaseURL(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_Clone(t *testing.T) {
+	client := NewHTTPClient("http://original.local", noopLogger{})
+	client.SetFallbackURL("http://fallback.local")
+	clone := client.Clone()
+
+	if clone.BaseURL() != "http://original.local" {
+		t.Errorf("expected clone to keep the base URL, got %q", clone.BaseURL())
+	}
+	clone.SetBaseURL("http://clone.local")
+	if client.BaseURL() != "http://original.local" {
+		t.Errorf("expected repointing the clone to leave the original alone, got %q", client.BaseURL())
+	}
+}
+
 func TestDefaultMockAwards(t *testing.T) {
 	awards := DefaultMockAwards()
 	if len(awards) != 6 {
@@ -500,6 +521,176 @@ func TestDefaultMockAwards(t *testing.T) {
 
 // ==================== Login Tests ====================
 
+// unreachableURL returns the URL of a server that has already shut down
+func unreachableURL() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+func TestHTTPClient_FallbackURL(t *testing.T) {
+	var loginBody string
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("action") == "role.login" {
+			loginBody = r.Form.Get("name") + "/" + r.Form.Get("password")
+			w.Write([]byte(`{"outcome":{"summary":"success"}}`))
+			return
+		}
+		w.Write([]byte(`{"racers":[{"racerid":1,"firstname":"Ann","lastname":"Lee","carnumber":7}]}`))
+	}))
+	defer fallback.Close()
+
+	primary := unreachableURL()
+	client := NewHTTPClient(primary, noopLogger{})
+	client.SetFallbackURL(fallback.URL)
+
+	racers, err := client.FetchRacers(context.Background())
+	if err != nil {
+		t.Fatalf("FetchRacers failed: %v", err)
+	}
+	if len(racers) != 1 {
+		t.Errorf("expected 1 racer from fallback, got %d", len(racers))
+	}
+	if client.BaseURL() != fallback.URL {
+		t.Errorf("expected active URL to switch to fallback, got %q", client.BaseURL())
+	}
+	if client.fallbackURL != primary {
+		t.Errorf("expected old URL to become the fallback, got %q", client.fallbackURL)
+	}
+
+	// POST bodies are resent intact
+	client.SetBaseURL(primary)
+	client.SetFallbackURL(fallback.URL)
+	if err := client.Login(context.Background(), "RaceCoordinator", "secret"); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if loginBody != "RaceCoordinator/secret" {
+		t.Errorf("expected login form on fallback, got %q", loginBody)
+	}
+}
+
+func TestHTTPClient_UseURLs_KeepsFailover(t *testing.T) {
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"racers":[]}`))
+	}))
+	defer fallback.Close()
+
+	primary := unreachableURL()
+	client := NewHTTPClient("", noopLogger{})
+	client.UseURLs(primary, fallback.URL)
+	if _, err := client.FetchRacers(context.Background()); err != nil {
+		t.Fatalf("FetchRacers failed: %v", err)
+	}
+
+	// Reapplying the same settings keeps the URL that answered
+	client.UseURLs(primary, fallback.URL)
+	if client.BaseURL() != fallback.URL || client.fallbackURL != primary {
+		t.Errorf("expected to stay on the fallback, got active %q, fallback %q", client.BaseURL(), client.fallbackURL)
+	}
+
+	// New settings replace both
+	client.UseURLs("http://new.local", "")
+	if client.BaseURL() != "http://new.local" || client.fallbackURL != "" {
+		t.Errorf("expected the new URLs, got active %q, fallback %q", client.BaseURL(), client.fallbackURL)
+	}
+}
+
+func TestHTTPClient_FallbackURL_PrimaryNeverAnswers(t *testing.T) {
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"racers":[{"racerid":1,"firstname":"Ann","lastname":"Lee","carnumber":7}]}`))
+	}))
+	defer fallback.Close()
+
+	// The old host swallows connection attempts instead of refusing them
+	defaultDialer, defaultTimeout := dialer, connectTimeout
+	defer func() { dialer, connectTimeout = defaultDialer, defaultTimeout }()
+	connectTimeout = 100 * time.Millisecond
+	dialer = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if addr == "derbynet-moved.local:80" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return defaultDialer(ctx, network, addr)
+	}
+
+	client := NewHTTPClient("http://derbynet-moved.local", noopLogger{})
+	client.SetFallbackURL(fallback.URL)
+
+	// Well inside the request's own deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	racers, err := client.FetchRacers(ctx)
+	if err != nil {
+		t.Fatalf("FetchRacers failed: %v", err)
+	}
+	if len(racers) != 1 || client.BaseURL() != fallback.URL {
+		t.Errorf("expected 1 racer from the fallback, got %d from %q", len(racers), client.BaseURL())
+	}
+}
+
+func TestHTTPClient_FallbackURL_Concurrent(t *testing.T) {
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"racers":[]}`))
+	}))
+	defer fallback.Close()
+
+	primary := unreachableURL()
+	client := NewHTTPClient(primary, noopLogger{})
+	client.SetFallbackURL(fallback.URL)
+
+	// Run with -race: requests failing over together must not corrupt the URLs
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.FetchRacers(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	if client.BaseURL() != fallback.URL || client.fallbackURL != primary {
+		t.Errorf("expected a single switch to the fallback, got active %q, fallback %q", client.BaseURL(), client.fallbackURL)
+	}
+}
+
+func TestHTTPClient_FallbackURL_NotUsedForHTTPErrors(t *testing.T) {
+	fallbackCalls := 0
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallbackCalls++
+	}))
+	defer fallback.Close()
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer primary.Close()
+
+	client := NewHTTPClient(primary.URL, noopLogger{})
+	client.SetFallbackURL(fallback.URL)
+
+	if _, err := client.FetchRacers(context.Background()); err == nil {
+		t.Fatal("expected error for HTTP 500")
+	}
+	if fallbackCalls != 0 || client.BaseURL() != primary.URL {
+		t.Errorf("expected no failover on an HTTP error, got %d fallback calls, active %q", fallbackCalls, client.BaseURL())
+	}
+}
+
+func TestHTTPClient_FallbackURL_BothUnreachable(t *testing.T) {
+	primary := unreachableURL()
+	client := NewHTTPClient(primary, noopLogger{})
+	client.SetFallbackURL(unreachableURL())
+
+	_, err := client.FetchRacers(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "fallback") {
+		t.Fatalf("expected error mentioning the fallback, got %v", err)
+	}
+	if client.BaseURL() != primary {
+		t.Errorf("expected active URL unchanged, got %q", client.BaseURL())
+	}
+}
+
 func TestHTTPClient_Login_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -560,6 +751,94 @@ func TestHTTPClient_Login_IncorrectPassword(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_Login_FailureCodes(t *testing.T) {
+	tests := []struct {
+		code          string
+		wrongPassword bool
+	}{
+		{CodeLoginFailed, true},
+		{CodeAuthFailed, true},
+		{CodeIncorrectPassword, true},
+		{"sql", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"outcome":{"summary":"failure","code":%q,"description":"Refused"}}`, tt.code)
+			}))
+			defer server.Close()
+
+			client := NewHTTPClient(server.URL, noopLogger{})
+			err := client.Login(context.Background(), "RaceCoordinator", "wrongpassword")
+
+			var dnErr *DerbyNetError
+			if !errors.As(err, &dnErr) {
+				t.Fatalf("expected *DerbyNetError, got %v", err)
+			}
+			if dnErr.Code != tt.code || dnErr.Description != "Refused" {
+				t.Errorf("unexpected error fields: %+v", dnErr)
+			}
+			if IsWrongPassword(err) != tt.wrongPassword {
+				t.Errorf("IsWrongPassword = %v, want %v", !tt.wrongPassword, tt.wrongPassword)
+			}
+			if IsNotAuthorized(err) {
+				t.Error("login failure should not be reported as not authorized")
+			}
+		})
+	}
+}
+
+func TestHTTPClient_NotAuthorizedAfterLogin(t *testing.T) {
+	winnerCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("action") == "role.login" {
+			w.Write([]byte(`{"outcome":{"summary":"success"}}`))
+			return
+		}
+		winnerCalls++
+		w.Write([]byte(`{"outcome":{"summary":"failure","code":"notauthorized","description":"Not authorized"}}`))
+	}))
+	defer server.Close()
+
+	// The role logs in fine but may not set winners, so the retry is attempted once
+	client := NewHTTPClient(server.URL, noopLogger{})
+	client.SetCredentials("Judge", "password")
+
+	err := client.SetAwardWinner(context.Background(), 42, 123)
+	if !IsNotAuthorized(err) {
+		t.Fatalf("expected not authorized error, got %v", err)
+	}
+	if IsWrongPassword(err) {
+		t.Error("not authorized should not be reported as a wrong password")
+	}
+	if winnerCalls != 2 {
+		t.Errorf("expected 2 award.winner calls, got %d", winnerCalls)
+	}
+	if !strings.Contains(err.Error(), "DerbyNet error: Not authorized (notauthorized)") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("DerbyNet login failed: %w", &DerbyNetError{Code: CodeIncorrectPassword}), "Wrong DerbyNet password"},
+		{&DerbyNetError{Code: CodeNotAuthorized}, "Insufficient DerbyNet role: log in as a role with award permissions (e.g. RaceCoordinator)"},
+		{&DerbyNetError{Code: "invalid-award", Description: "Award does not exist"}, "Award does not exist (invalid-award)"},
+		{errors.New("failed to connect to DerbyNet: refused"), "failed to connect to DerbyNet: refused"},
+	}
+
+	for _, tt := range tests {
+		if got := Explain(tt.err); got != tt.want {
+			t.Errorf("Explain(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
 func TestHTTPClient_Login_ServerError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
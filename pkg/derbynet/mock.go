@@ -11,6 +11,7 @@ type MockClient struct {
 	awards           []Award
 	awardTypes       []AwardType
 	baseURL          string
+	fallbackURL      string
 	fetchErr         error
 	awardsErr        error
 	awardTypesErr    error
@@ -20,6 +21,7 @@ type MockClient struct {
 	awardWinners     map[int]int // awardID -> racerID
 	nextAwardID      int         // counter for generating new award IDs
 	credentialsSet   bool        // tracks if SetCredentials was called
+	fetchRacersCalls int         // number of FetchRacers calls
 }
 
 // MockOption configures the mock client
@@ -120,6 +122,26 @@ func (m *MockClient) SetBaseURL(url string) {
 	m.baseURL = url
 }
 
+// SetFallbackURL records the fallback URL
+func (m *MockClient) SetFallbackURL(url string) {
+	m.fallbackURL = url
+}
+
+// UseURLs sets the base and fallback URLs
+func (m *MockClient) UseURLs(baseURL, fallbackURL string) {
+	m.baseURL, m.fallbackURL = baseURL, fallbackURL
+}
+
+// FallbackURL returns the fallback URL last set
+func (m *MockClient) FallbackURL() string {
+	return m.fallbackURL
+}
+
+// Clone returns the mock itself, so tests see every call in one place
+func (m *MockClient) Clone() Client {
+	return m
+}
+
 // SetCredentials configures authentication credentials
 func (m *MockClient) SetCredentials(role, password string) {
 	m.credentialsSet = true
@@ -135,12 +157,18 @@ func (m *MockClient) Login(ctx context.Context, role, password string) error {
 
 // FetchRacers returns the configured mock racers or error
 func (m *MockClient) FetchRacers(ctx context.Context) ([]Racer, error) {
+	m.fetchRacersCalls++
 	if m.fetchErr != nil {
 		return nil, m.fetchErr
 	}
 	return m.racers, nil
 }
 
+// FetchRacersCalls returns how many times FetchRacers has been called
+func (m *MockClient) FetchRacersCalls() int {
+	return m.fetchRacersCalls
+}
+
 // FetchAwards returns the configured mock awards or error
 func (m *MockClient) FetchAwards(ctx context.Context) ([]Award, error) {
 	if m.awardsErr != nil {
@@ -26,6 +26,9 @@ document.addEventListener('DOMContentLoaded', function() {
     // Rank filter
     $('#rank-filter').addEventListener('change', handleRankFilterChange);
 
+    // Write-in cars are listed separately so they can be ruled ineligible
+    $('#show-write-ins').addEventListener('change', loadCars);
+
     // Modal buttons
     $('#modal-cancel').addEventListener('click', closeModal);
     $('#modal-save').addEventListener('click', saveCar);
@@ -74,7 +77,7 @@ async function handleEligibilityToggle(e, target) {
 async function loadCars() {
     Loading.show('#cars-list');
     try {
-        allCars = await API.get('/api/admin/cars');
+        allCars = await API.get($('#show-write-ins').checked ? '/api/admin/cars?write_ins=true' : '/api/admin/cars');
         renderCars(allCars);
     } catch (error) {
         console.error('Error loading cars:', error);
@@ -6,6 +6,7 @@ let editingId = null;
 let editingGroupId = null;
 let voterTypes = [];
 let ranks = [];
+let derbyNetAwards = [];
 
 // ===== VOTER TYPES =====
 async function loadVoterTypes() {
@@ -64,6 +65,51 @@ function populateRanksMultiselect() {
     `).join('');
 }
 
+// ===== DERBYNET AWARDS =====
+async function loadDerbyNetAwards() {
+    const select = $('#category-derbynet-award');
+    try {
+        derbyNetAwards = await API.get('/api/admin/derbynet/awards') || [];
+        select.disabled = false;
+    } catch (error) {
+        console.error('Error loading DerbyNet awards:', error);
+        derbyNetAwards = [];
+        select.disabled = true;
+        $('#derbynet-award-hint').textContent = `DerbyNet awards unavailable: ${error.message}`;
+    }
+}
+
+function populateDerbyNetAwardDropdown(selectedId) {
+    const select = $('#category-derbynet-award');
+    select.innerHTML = '<option value="">Not linked</option>';
+    derbyNetAwards.forEach(award => {
+        const option = document.createElement('option');
+        option.value = award.awardid;
+        option.textContent = award.awardtype ? `${award.awardname} (${award.awardtype})` : award.awardname;
+        select.appendChild(option);
+    });
+
+    // Keep an existing link visible even if DerbyNet no longer lists it
+    if (selectedId && !derbyNetAwards.some(a => a.awardid === selectedId)) {
+        const option = document.createElement('option');
+        option.value = selectedId;
+        option.textContent = `Award #${selectedId}`;
+        select.appendChild(option);
+    }
+    select.value = selectedId || '';
+}
+
+// Saves the category's DerbyNet award link if the selection changed
+async function saveDerbyNetAward(id, currentAwardId) {
+    const select = $('#category-derbynet-award');
+    if (select.disabled) return;
+
+    const awardId = select.value ? parseInt(select.value) : null;
+    if (awardId === (currentAwardId || null)) return;
+
+    await API.put(`/api/admin/categories/${id}/derbynet-award`, {award_id: awardId});
+}
+
 // ===== GROUP MANAGEMENT =====
 async function loadGroups() {
     try {
@@ -87,12 +133,15 @@ function renderGroups() {
     container.innerHTML = groups.map(group => {
         let exclusivityText = 'No exclusivity';
         if (group.exclusivity_pool_id !== null) {
-            exclusivityText = `Pool ${group.exclusivity_pool_id}`;
+            exclusivityText = esc(group.exclusivity_pool_name || `Pool ${group.exclusivity_pool_id}`);
         }
 
         let maxWinsText = '';
         if (group.max_wins_per_car !== null && group.max_wins_per_car !== undefined) {
             maxWinsText = `<span class="inline-block bg-blue-200 text-blue-800 rounded px-2 py-1 mr-2">Max ${group.max_wins_per_car} win${group.max_wins_per_car > 1 ? 's' : ''}/car</span>`;
+            if (group.multi_win_strategy === 'auto_runner_up') {
+                maxWinsText += `<span class="inline-block bg-green-200 text-green-800 rounded px-2 py-1 mr-2">Auto runner-up</span>`;
+            }
         }
 
         return `
@@ -132,7 +181,7 @@ function updateGroupDropdown() {
         // textContent is safe, no need to escape
         option.textContent = group.name;
         if (group.exclusivity_pool_id) {
-            option.textContent += ` (Pool ${group.exclusivity_pool_id})`;
+            option.textContent += ` (${group.exclusivity_pool_name || `Pool ${group.exclusivity_pool_id}`})`;
         }
         select.appendChild(option);
     });
@@ -153,6 +202,7 @@ function showGroupModal(title = 'Add Category Group', id = null) {
         $('#group-description').value = group.description || '';
         $('#group-order').value = group.display_order;
         $('#group-max-wins').value = group.max_wins_per_car || '';
+        $('#group-multi-win-strategy').value = group.multi_win_strategy || 'manual';
 
         // Handle exclusivity pool
         if (group.exclusivity_pool_id === null) {
@@ -169,6 +219,7 @@ function showGroupModal(title = 'Add Category Group', id = null) {
         $('#group-order').value = (groups && groups.length ? groups.length : 0) + 1;
         $('#group-exclusivity').value = '';
         $('#group-max-wins').value = '';
+        $('#group-multi-win-strategy').value = 'manual';
     }
 
     showModal('group-modal');
@@ -227,6 +278,7 @@ async function saveGroup() {
         description: $('#group-description').value || null,
         exclusivity_pool_id: exclusivityPoolId,
         max_wins_per_car: maxWinsPerCar,
+        multi_win_strategy: $('#group-multi-win-strategy').value,
         display_order: displayOrder
     };
 
@@ -285,6 +337,27 @@ function renderCategories() {
             groupBadge = `<span class="inline-block bg-purple-100 text-purple-800 text-xs rounded px-2 py-1 mr-2">${esc(cat.group_name)}</span>`;
         }
 
+        const tagBadges = (cat.tags || []).map(tag =>
+            `<span class="inline-block bg-yellow-100 text-yellow-800 text-xs rounded px-2 py-1 mr-1">#${esc(tag)}</span>`
+        ).join('');
+
+        const writeInBadge = cat.allow_write_in
+            ? '<span class="inline-block bg-orange-100 text-orange-800 text-xs rounded px-2 py-1 mr-2">Write-ins</span>'
+            : '';
+
+        const carSubsetBadge = cat.car_ids && cat.car_ids.length > 0
+            ? `<span class="inline-block bg-indigo-100 text-indigo-800 text-xs rounded px-2 py-1 mr-2">${cat.car_ids.length} car${cat.car_ids.length === 1 ? '' : 's'} only</span>`
+            : '';
+
+        const liveCountsBadge = cat.show_live_counts
+            ? '<span class="inline-block bg-green-100 text-green-800 text-xs rounded px-2 py-1 mr-2">Live counts</span>'
+            : '';
+
+        let derbyNetBadge = '';
+        if (cat.derbynet_award_id) {
+            derbyNetBadge = `<span class="inline-block bg-blue-50 text-blue-700 text-xs rounded px-2 py-1 mr-2">DerbyNet #${cat.derbynet_award_id}</span>`;
+        }
+
         let voterTypesBadges = '';
         if (cat.allowed_voter_types && cat.allowed_voter_types.length > 0) {
             // Show each voter type as a separate badge
@@ -313,6 +386,11 @@ function renderCategories() {
                 <div class="font-semibold text-lg">${esc(cat.name)}</div>
                 <div class="text-sm text-gray-600">
                     ${groupBadge}
+                    ${tagBadges}
+                    ${writeInBadge}
+                    ${liveCountsBadge}
+                    ${carSubsetBadge}
+                    ${derbyNetBadge}
                     ${voterTypesBadges}
                     ${ranksBadges}
                     <span class="text-gray-500">Order: ${cat.display_order}</span>
@@ -320,6 +398,10 @@ function renderCategories() {
                 </div>
             </div>
             <div class="flex space-x-2">
+                <select data-action="move-group" title="Move to group" class="border border-gray-300 rounded px-2 py-2 text-sm">
+                    ${groupOptions(cat.group_id)}
+                </select>
+                ${cat.active ? `<a href="/api/admin/categories/${cat.id}/qr.png" target="_blank" title="Open voting QR code for this category" class="px-4 py-2 bg-gray-600 text-white rounded hover:bg-gray-700">QR</a>` : ''}
                 <button data-action="edit" class="px-4 py-2 bg-blue-600 text-white rounded hover:bg-blue-700">
                     Edit
                 </button>
@@ -332,6 +414,38 @@ function renderCategories() {
     }).join('');
 }
 
+// Options for a category's group picker, with its current group selected
+function groupOptions(selectedId) {
+    const options = [`<option value="" ${selectedId ? '' : 'selected'}>No group</option>`];
+    groups.forEach(group => {
+        options.push(`<option value="${group.id}" ${group.id === selectedId ? 'selected' : ''}>${esc(group.name)}</option>`);
+    });
+    return options.join('');
+}
+
+// Move a category to another group without resending the whole category
+async function moveCategoryToGroup(id, groupId) {
+    try {
+        const result = await API.put(`/api/admin/categories/${id}/group`, {group_id: groupId});
+        const warnings = [];
+        if (result.exclusivity_conflicts > 0) {
+            warnings.push(`${result.exclusivity_conflicts} voter(s) now picked the same car in two categories of this pool`);
+        }
+        if (result.multi_wins && result.multi_wins.length > 0) {
+            warnings.push(`${result.multi_wins.length} car(s) now win more awards than the group allows`);
+        }
+        if (warnings.length > 0) {
+            Toast.warning(`Category moved. ${warnings.join('; ')} - review on the Results page`);
+        } else {
+            Toast.success('Category moved');
+        }
+    } catch (error) {
+        console.error('Error moving category:', error);
+        Toast.error(error.message || 'Failed to move category');
+    }
+    loadCategories();
+}
+
 function showCategoryModal(title = 'Add Category', id = null) {
     editingId = id;
     $('#modal-title').textContent = title;
@@ -341,6 +455,11 @@ function showCategoryModal(title = 'Add Category', id = null) {
         $('#category-name').value = cat.name;
         $('#category-order').value = cat.display_order;
         $('#category-group').value = cat.group_id || '';
+        $('#category-tags').value = (cat.tags || []).join(', ');
+        $('#category-allow-write-in').checked = !!cat.allow_write_in;
+        $('#category-show-live-counts').checked = !!cat.show_live_counts;
+        $('#category-banner-url').value = cat.banner_url || '';
+        populateDerbyNetAwardDropdown(cat.derbynet_award_id);
 
         // Set voter type checkboxes
         const allowedTypes = cat.allowed_voter_types || [];
@@ -357,6 +476,11 @@ function showCategoryModal(title = 'Add Category', id = null) {
         $('#category-name').value = '';
         $('#category-order').value = categories.length + 1;
         $('#category-group').value = '';
+        $('#category-tags').value = '';
+        $('#category-allow-write-in').checked = false;
+        $('#category-show-live-counts').checked = false;
+        $('#category-banner-url').value = '';
+        populateDerbyNetAwardDropdown(null);
 
         // Clear all voter type checkboxes for new category
         document.querySelectorAll('.voter-type-checkbox').forEach(checkbox => {
@@ -369,6 +493,7 @@ function showCategoryModal(title = 'Add Category', id = null) {
         });
     }
 
+    $('#category-banner-file').value = '';
     showModal('category-modal');
 }
 
@@ -390,7 +515,11 @@ async function toggleCategory(id, active) {
             group_id: cat.group_id || null,
             active: active,
             allowed_voter_types: cat.allowed_voter_types || null,
-            allowed_ranks: cat.allowed_ranks || null
+            allowed_ranks: cat.allowed_ranks || null,
+            tags: cat.tags || null,
+            allow_write_in: !!cat.allow_write_in,
+            show_live_counts: !!cat.show_live_counts,
+            banner_url: cat.banner_url || ''
         });
         loadCategories();
         Toast.success(active ? 'Category activated' : 'Category deactivated');
@@ -400,6 +529,17 @@ async function toggleCategory(id, active) {
     }
 }
 
+// Upload the banner image chosen in the category modal, if any
+async function uploadCategoryBanner(id) {
+    const file = $('#category-banner-file').files[0];
+    if (!file) return;
+
+    const formData = new FormData();
+    formData.append('banner', file);
+    const response = await fetch(`/api/admin/categories/${id}/banner`, {method: 'POST', body: formData});
+    await API.handleResponse(response);
+}
+
 async function saveCategory() {
     if (!validateRequired([['#category-name', 'Category name']])) return;
 
@@ -407,6 +547,10 @@ async function saveCategory() {
     const groupValue = $('#category-group').value;
     const groupId = groupValue ? parseInt(groupValue) : null;
 
+    const tags = $('#category-tags').value.split(',')
+        .map(tag => tag.trim())
+        .filter(tag => tag !== '');
+
     // Collect selected voter types
     const selectedVoterTypes = Array.from(document.querySelectorAll('.voter-type-checkbox:checked'))
         .map(checkbox => checkbox.value);
@@ -427,17 +571,29 @@ async function saveCategory() {
                 group_id: groupId,
                 active: cat.active,
                 allowed_voter_types: selectedVoterTypes.length > 0 ? selectedVoterTypes : null,
-                allowed_ranks: selectedRanks.length > 0 ? selectedRanks : null
+                allowed_ranks: selectedRanks.length > 0 ? selectedRanks : null,
+                tags: tags.length > 0 ? tags : null,
+                allow_write_in: $('#category-allow-write-in').checked,
+                show_live_counts: $('#category-show-live-counts').checked,
+                banner_url: $('#category-banner-url').value.trim()
             });
+            await saveDerbyNetAward(editingId, cat.derbynet_award_id);
+            await uploadCategoryBanner(editingId);
             Toast.success('Category updated');
         } else {
-            await API.post('/api/admin/categories', {
+            const created = await API.post('/api/admin/categories', {
                 name: $('#category-name').value.trim(),
                 display_order: order,
                 group_id: groupId,
                 allowed_voter_types: selectedVoterTypes.length > 0 ? selectedVoterTypes : null,
-                allowed_ranks: selectedRanks.length > 0 ? selectedRanks : null
+                allowed_ranks: selectedRanks.length > 0 ? selectedRanks : null,
+                tags: tags.length > 0 ? tags : null,
+                allow_write_in: $('#category-allow-write-in').checked,
+                show_live_counts: $('#category-show-live-counts').checked,
+                banner_url: $('#category-banner-url').value.trim()
             });
+            await saveDerbyNetAward(created.id, null);
+            await uploadCategoryBanner(created.id);
             Toast.success('Category created');
         }
         hideCategoryModal();
@@ -470,6 +626,10 @@ document.addEventListener('DOMContentLoaded', () => {
 
     // Sync from DerbyNet
     $('#sync-derbynet').addEventListener('click', syncFromDerbyNet);
+    $('#preview-derbynet').addEventListener('click', previewDerbyNetSync);
+    $('#close-derbynet-diff').addEventListener('click', () => $('#derbynet-diff').classList.add('hidden'));
+    $('#import-categories').addEventListener('click', () => $('#import-categories-file').click());
+    $('#import-categories-file').addEventListener('change', importCategoriesCSV);
 
     // Modal backdrop close
     setupModalBackdropClose('group-modal', hideGroupModal);
@@ -500,12 +660,18 @@ document.addEventListener('DOMContentLoaded', () => {
         }
     });
 
+    delegate('#categories-list', '[data-action="move-group"]', 'change', (e, target) => {
+        const categoryId = parseInt(target.closest('[data-category-id]').dataset.categoryId);
+        moveCategoryToGroup(categoryId, target.value ? parseInt(target.value) : null);
+    });
+
     // Initial load
     async function init() {
         await loadVoterTypes();
         await loadRanks();
         await loadGroups();
         await loadCategories();
+        loadDerbyNetAwards();
     }
     init();
 });
@@ -560,3 +726,65 @@ async function syncFromDerbyNet() {
         Loading.hide(syncBtn);
     }
 }
+
+async function previewDerbyNetSync() {
+    const previewBtn = $('#preview-derbynet');
+    Loading.show(previewBtn);
+
+    try {
+        const diff = await API.get('/api/admin/derbynet/categories-diff');
+        const list = (items) => items.length
+            ? `<ul class="list-disc ml-5">${items.map(item => `<li>${escapeHtml(item)}</li>`).join('')}</ul>`
+            : '<p class="text-gray-500 ml-5">None</p>';
+
+        $('#derbynet-diff-content').innerHTML = `
+            <p class="font-medium">Awards to import from DerbyNet</p>
+            ${list(diff.awards_to_import.map(a => a.awardname))}
+            <p class="font-medium">Local categories to push to DerbyNet</p>
+            ${list(diff.local_only.map(c => c.name))}
+            <p class="font-medium">Matched</p>
+            ${list(diff.matched.map(m => m.linked
+                ? `${m.category_name} = ${m.award_name}`
+                : `${m.category_name} (will be linked by name)`))}
+        `;
+        $('#derbynet-diff').classList.remove('hidden');
+    } catch (error) {
+        console.error('Error previewing DerbyNet sync:', error);
+        showSyncStatus(`Error: ${error.message}`, true);
+    } finally {
+        Loading.hide(previewBtn);
+    }
+}
+
+async function importCategoriesCSV(event) {
+    const file = event.target.files[0];
+    event.target.value = '';
+    if (!file) return;
+
+    const formData = new FormData();
+    formData.append('file', file);
+
+    try {
+        const response = await fetch('/api/admin/categories/import', {method: 'POST', body: formData});
+        const result = await API.handleResponse(response);
+
+        if (!result.committed) {
+            const problems = result.results
+                .filter(line => line.status === 'rejected')
+                .map(line => `Line ${line.line}: ${escapeHtml(line.error)}`);
+            showSyncStatus(`Import failed, nothing was saved.<br>${problems.join('<br>')}`, true);
+            return;
+        }
+
+        let message = `Imported! Categories: ${result.created} created, ${result.updated} updated.`;
+        if (result.groups_created > 0) {
+            message += ` Groups created: ${result.groups_created}.`;
+        }
+        showSyncStatus(message);
+        loadGroups();
+        loadCategories();
+    } catch (error) {
+        console.error('Error importing categories:', error);
+        showSyncStatus(`Error: ${error.message}`, true);
+    }
+}
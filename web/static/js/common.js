@@ -106,10 +106,12 @@ class APIError extends Error {
 const API = {
     async handleResponse(response) {
         if (!response.ok) {
-            const errorData = await response.json().catch(() => ({
-                error: 'Request failed',
-                code: 'UNKNOWN_ERROR'
-            }));
+            const body = await response.json().catch(() => ({}));
+
+            // Error responses are {error: {code, message, field?}}; extra
+            // top-level keys (e.g. confirmation_required) sit beside it
+            const { error: detail, ...extra } = body;
+            const errorData = { ...extra, ...(detail || {}) };
 
             // Handle unauthorized errors - redirect to login
             if (response.status === 401 || errorData.code === 'UNAUTHORIZED') {
@@ -118,7 +120,7 @@ const API = {
             }
 
             throw new APIError(
-                errorData.error || errorData.message || `HTTP ${response.status}`,
+                errorData.message || `HTTP ${response.status}`,
                 errorData.code || 'UNKNOWN_ERROR',
                 response.status,
                 errorData
@@ -127,8 +129,8 @@ const API = {
         return response.json().catch(() => ({}));
     },
 
-    async get(url) {
-        const response = await fetch(url);
+    async get(url, headers) {
+        const response = await fetch(url, headers ? { headers } : undefined);
         return this.handleResponse(response);
     },
 
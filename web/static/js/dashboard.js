@@ -92,6 +92,32 @@ async function toggleVoting() {
     }
 }
 
+// Close voting and freeze results; conflicts leave voting closed but unfrozen
+async function finalizeVoting() {
+    if (!confirm('Close voting and freeze the results?')) return;
+    const revealCode = prompt('To hide the results until the awards ceremony, enter a reveal code. Leave blank to show them now.', '');
+    if (revealCode === null) return;
+
+    const finalizeBtn = $('#finalize-voting');
+    Loading.show(finalizeBtn);
+
+    try {
+        const embargo = revealCode.trim() !== '';
+        await API.post('/api/admin/voting/finalize', embargo ? { embargo, reveal_code: revealCode } : {});
+        Toast.success(embargo ? 'Voting closed and results frozen under embargo' : 'Voting closed and results frozen');
+    } catch (error) {
+        if (error.status === 409) {
+            Toast.warning('Voting closed, but conflicts must be resolved on the Results page before results can be frozen');
+        } else {
+            console.error('Error finalizing voting:', error);
+            Toast.error(error.message || 'Failed to finalize voting');
+        }
+    } finally {
+        loadStats();
+        Loading.hide(finalizeBtn);
+    }
+}
+
 // Set timer
 async function setTimer(minutes) {
     const messageEl = $('#timer-message');
@@ -110,11 +136,31 @@ async function setTimer(minutes) {
     }
 }
 
+// Longest timer the server accepts; refreshed from the presets endpoint
+let maxTimerMinutes = 60;
+
+// Load quick-pick timer buttons and the timer maximum
+async function loadTimerPresets() {
+    try {
+        const data = await API.get('/api/admin/voting-timer/presets');
+        maxTimerMinutes = data.max_minutes;
+        $('#custom-timer').max = maxTimerMinutes;
+        $('#timer-presets').innerHTML = data.presets.map(minutes =>
+            `<button data-timer="${minutes}" class="flex-1 bg-gray-100 hover:bg-gray-200 px-4 py-2 rounded-lg font-semibold">${minutes} min</button>`
+        ).join('');
+        $$('[data-timer]').forEach(btn => {
+            btn.addEventListener('click', () => setTimer(parseInt(btn.dataset.timer)));
+        });
+    } catch (error) {
+        console.error('Error loading timer presets:', error);
+    }
+}
+
 // Set custom timer
 function setCustomTimer() {
     const minutes = parseInt($('#custom-timer').value);
-    if (!minutes || minutes < 1 || minutes > 60) {
-        Toast.warning('Please enter a number between 1 and 60');
+    if (!minutes || minutes < 1 || minutes > maxTimerMinutes) {
+        Toast.warning(`Please enter a number between 1 and ${maxTimerMinutes}`);
         return;
     }
     setTimer(minutes);
@@ -123,12 +169,9 @@ function setCustomTimer() {
 // Initialize
 document.addEventListener('DOMContentLoaded', () => {
     $('#toggle-voting').addEventListener('click', toggleVoting);
+    $('#finalize-voting').addEventListener('click', finalizeVoting);
 
-    // Timer buttons
-    $$('[data-timer]').forEach(btn => {
-        btn.addEventListener('click', () => setTimer(parseInt(btn.dataset.timer)));
-    });
-
+    loadTimerPresets();
     $('#set-custom-timer').addEventListener('click', setCustomTimer);
 
     loadStats();
@@ -4,6 +4,9 @@ let conflictsData = null;
 let resultsData = null;
 let votingOpen = true; // Default to true (safe default - disables conflict resolution)
 
+// Reveal code for embargoed results, kept for this browser tab only
+let revealCode = sessionStorage.getItem('results_reveal_code') || '';
+
 // Display preferences
 let showDetails = localStorage.getItem('results_show_details') !== 'false'; // default true
 let showOnlyConflicts = localStorage.getItem('results_show_only_conflicts') === 'true'; // default false
@@ -31,13 +34,14 @@ function updatePushButtonState() {
 
 async function loadConflicts() {
     try {
-        const data = await API.get('/api/admin/results/conflicts');
+        const data = await API.get('/api/admin/results/conflicts', revealCode ? { 'X-Reveal': revealCode } : undefined);
         conflictsData = data;
 
         const tieCount = data.ties ? data.ties.length : 0;
+        const nearTieCount = data.near_ties ? data.near_ties.length : 0;
         const multiWinCount = data.multi_wins ? data.multi_wins.length : 0;
 
-        if (tieCount === 0 && multiWinCount === 0) {
+        if (tieCount === 0 && nearTieCount === 0 && multiWinCount === 0) {
             // No conflicts - hide panel
             $('#conflicts-panel').classList.add('hidden');
         } else {
@@ -48,6 +52,9 @@ async function loadConflicts() {
             if (tieCount > 0) {
                 parts.push(`${tieCount} tie${tieCount > 1 ? 's' : ''} requiring resolution`);
             }
+            if (nearTieCount > 0) {
+                parts.push(`${nearTieCount} near tie${nearTieCount > 1 ? 's' : ''} to review`);
+            }
             if (multiWinCount > 0) {
                 parts.push(`${multiWinCount} car${multiWinCount > 1 ? 's' : ''} won multiple awards`);
             }
@@ -113,6 +120,37 @@ function buildConflictsModalContent() {
         html += '</div>';
     }
 
+    // Show near ties (warnings only; they do not block pushing)
+    if (conflictsData.near_ties && conflictsData.near_ties.length > 0) {
+        html += '<div class="mb-6"><h4 class="text-lg font-semibold text-gray-800 mb-3">Near Ties</h4>';
+        html += '<div class="bg-gray-50 border border-gray-200 rounded p-3 mb-4 text-sm text-gray-700">These categories were decided by a narrow margin. Review them before pushing; selecting a winner is optional.</div>';
+        conflictsData.near_ties.forEach(tie => {
+            html += `
+                <div class="border rounded-lg p-4 mb-4 bg-orange-50">
+                    <div class="font-semibold text-gray-900 mb-2">${esc(tie.category_name)}</div>
+                    <div class="text-sm text-gray-600 mb-3">Leader ahead by ${tie.margin} vote${tie.margin > 1 ? 's' : ''}</div>
+                    <div class="space-y-2">
+                        ${tie.tied_cars.map(car => `
+                            <div class="flex items-center justify-between bg-white p-2 rounded">
+                                <div>
+                                    <span class="font-medium">Car #${esc(car.car_number)}</span>
+                                    <span class="text-gray-600">- ${esc(car.racer_name)} (${car.vote_count} votes)</span>
+                                </div>
+                                <button ${votingOpen ? 'disabled' : ''}
+                                        onclick="setManualWinner(${tie.category_id}, ${car.car_id}, '${escapeJs(car.car_number)}', '${escapeJs(car.racer_name)}', '${escapeJs(tie.category_name)}')"
+                                        class="px-3 py-1 text-white text-sm rounded ${votingOpen ? 'bg-gray-400 cursor-not-allowed' : 'bg-blue-600 hover:bg-blue-700'}"
+                                        ${votingOpen ? 'title="Close voting first to resolve conflicts"' : ''}>
+                                    Select as Winner
+                                </button>
+                            </div>
+                        `).join('')}
+                    </div>
+                </div>
+            `;
+        });
+        html += '</div>';
+    }
+
     // Show multiple wins
     if (conflictsData.multi_wins && conflictsData.multi_wins.length > 0) {
         html += '<div class="mb-6"><h4 class="text-lg font-semibold text-gray-800 mb-3">Multiple Award Winners</h4>';
@@ -247,9 +285,10 @@ async function confirmManualWinner() {
         // If modal was open, refresh its content or close if no conflicts
         if (modalWasOpen) {
             const tieCount = conflictsData.ties ? conflictsData.ties.length : 0;
+            const nearTieCount = conflictsData.near_ties ? conflictsData.near_ties.length : 0;
             const multiWinCount = conflictsData.multi_wins ? conflictsData.multi_wins.length : 0;
 
-            if (tieCount === 0 && multiWinCount === 0) {
+            if (tieCount === 0 && nearTieCount === 0 && multiWinCount === 0) {
                 hideConflictsModal();
             } else {
                 // Refresh modal content with new conflicts data
@@ -267,6 +306,15 @@ async function setManualWinner(categoryID, carID, carNumber, racerName, category
     showManualWinnerModal(categoryID, carID, carNumber, racerName, categoryName);
 }
 
+// Describe a car in the results; write-ins have no number and get a badge so
+// coordinators can decide whether they may win
+function carLabel(car) {
+    if (car.write_in) {
+        return `${esc(car.car_name)} <span class="inline-block bg-orange-100 text-orange-800 text-xs rounded px-2 py-1 ml-1">Write-in</span>`;
+    }
+    return `Car #${esc(car.car_number)}${car.car_name ? ` - ${esc(car.car_name)}` : ''}`;
+}
+
 // Store pending clear override action
 let pendingClearOverride = null;
 
@@ -352,7 +400,7 @@ function applyDisplayFilters() {
 async function loadResults() {
     Loading.show('#results-container');
     try {
-        const results = await API.get('/api/admin/results');
+        const results = await API.get('/api/admin/results', revealCode ? { 'X-Reveal': revealCode } : undefined);
         resultsData = results;
         const container = $('#results-container');
 
@@ -363,7 +411,8 @@ async function loadResults() {
 
         container.innerHTML = results.map(category => {
             const votes = category.votes || [];
-            const totalVotes = votes.reduce((sum, v) => sum + v.vote_count, 0);
+            const pendingWriteIns = category.pending_write_ins || [];
+            const totalVotes = [...votes, ...pendingWriteIns].reduce((sum, v) => sum + v.vote_count, 0);
 
             // Sort by vote count descending
             votes.sort((a, b) => b.vote_count - a.vote_count);
@@ -392,7 +441,14 @@ async function loadResults() {
                 <div class="category-card bg-white rounded-lg shadow-lg p-6" data-has-conflict="${hasConflict}">
                     <div class="flex items-center justify-between mb-4">
                         <h2 class="text-2xl font-bold text-gray-800">${esc(category.category_name)}</h2>
-                        <span class="text-sm text-gray-600">${totalVotes} total votes</span>
+                        <div class="flex items-center space-x-3">
+                            ${totalVotes > 0 ? `
+                                <a href="/api/admin/categories/${category.category_id}/ballots.csv"
+                                   class="text-xs text-blue-600 hover:text-blue-800 underline"
+                                   title="Download every ballot in this category for a recount">Ballots CSV</a>
+                            ` : ''}
+                            <span class="text-sm text-gray-600">${totalVotes} total votes</span>
+                        </div>
                     </div>
 
                     ${winners.length > 0 ? `
@@ -420,7 +476,7 @@ async function loadResults() {
                                                 ${esc(w.racer_name) || 'Unknown Driver'}
                                             </div>
                                             <div class="text-lg text-yellow-800">
-                                                Car #${esc(w.car_number)}${w.car_name ? ` - ${esc(w.car_name)}` : ''}
+                                                ${carLabel(w)}
                                             </div>
                                             <div class="text-sm text-yellow-700">
                                                 ${w.vote_count} votes
@@ -465,7 +521,7 @@ async function loadResults() {
                                                     ${index + 1}. ${esc(vote.racer_name) || 'Unknown'}
                                                 </div>
                                                 <div class="text-sm text-gray-600">
-                                                    Car #${esc(vote.car_number)}${vote.car_name ? ` - ${esc(vote.car_name)}` : ''}
+                                                    ${carLabel(vote)}
                                                 </div>
                                             </div>
                                         </div>
@@ -480,10 +536,26 @@ async function loadResults() {
                             `;
                         }).join('')}
 
-                        ${votes.length === 0 ? `
+                        ${votes.length === 0 && pendingWriteIns.length === 0 ? `
                             <div class="text-center text-gray-500 py-4">No votes for this category yet</div>
                         ` : ''}
                     </div>
+
+                    ${pendingWriteIns.length > 0 ? `
+                        <div class="mt-4 border-t pt-3">
+                            <div class="text-sm font-semibold text-orange-800 mb-2">
+                                Write-ins awaiting approval
+                                <a href="/admin/cars" class="ml-2 text-xs font-normal text-blue-600 hover:text-blue-800 underline"
+                                   title="Mark a write-in eligible on the Cars page to let it win">Review on Cars page</a>
+                            </div>
+                            ${pendingWriteIns.map(car => `
+                                <div class="flex items-center justify-between text-sm text-gray-700 py-1">
+                                    <span>${carLabel(car)}</span>
+                                    <span>${car.vote_count} votes</span>
+                                </div>
+                            `).join('')}
+                        </div>
+                    ` : ''}
                 </div>
             `;
         }).join('');
@@ -492,6 +564,10 @@ async function loadResults() {
         applyDisplayFilters();
 
     } catch (error) {
+        if (error.status === 423) {
+            showEmbargoed();
+            return;
+        }
         console.error('Error loading results:', error);
         $('#results-container').innerHTML =
             '<div class="bg-red-50 border border-red-400 rounded-lg p-4 text-red-700">Error loading results</div>';
@@ -500,6 +576,51 @@ async function loadResults() {
     }
 }
 
+// ===== EMBARGO =====
+// Results frozen with an embargo stay hidden until the reveal code is entered
+// or the embargo is lifted for everyone
+function showEmbargoed() {
+    const wrongCode = revealCode !== '';
+    $('#results-container').innerHTML = `
+        <div class="bg-white rounded-lg shadow p-8 text-center">
+            <p class="text-2xl mb-2">🔒</p>
+            <h3 class="text-lg font-bold mb-2">Results are embargoed</h3>
+            <p class="text-gray-600 mb-4">Results are hidden until the awards ceremony. Enter the reveal code to view them on this device.</p>
+            ${wrongCode ? '<p class="text-red-600 text-sm mb-2">That reveal code is not correct.</p>' : ''}
+            <div class="flex justify-center gap-2">
+                <input type="password" id="reveal-code" placeholder="Reveal code" class="border border-gray-300 rounded-lg px-4 py-2">
+                <button id="reveal-view" class="bg-blue-600 text-white px-4 py-2 rounded-lg hover:bg-blue-700">View Results</button>
+                <button id="reveal-lift" class="bg-gray-800 text-white px-4 py-2 rounded-lg hover:bg-gray-900">Lift Embargo</button>
+            </div>
+        </div>`;
+
+    $('#reveal-view').addEventListener('click', () => {
+        revealCode = $('#reveal-code').value.trim();
+        sessionStorage.setItem('results_reveal_code', revealCode);
+        loadResults();
+        loadConflicts();
+    });
+    $('#reveal-lift').addEventListener('click', liftEmbargo);
+}
+
+async function liftEmbargo() {
+    const code = $('#reveal-code').value.trim() || revealCode;
+    if (!code) {
+        Toast.error('Enter the reveal code to lift the embargo');
+        return;
+    }
+    if (!confirm('Lift the embargo and show the results to every admin?')) return;
+    try {
+        await API.post('/api/admin/results/reveal', { reveal_code: code });
+        Toast.success('Results revealed');
+        loadResults();
+        loadConflicts();
+    } catch (error) {
+        console.error('Error lifting embargo:', error);
+        Toast.error(error.message || 'Failed to lift embargo');
+    }
+}
+
 // ===== DERBYNET PUSH =====
 function showPushStatus(message, isError = false, isWarning = false) {
     const statusEl = $('#push-status');
@@ -570,6 +691,38 @@ async function pushResultsToDerbyNet() {
     }
 }
 
+// Download the event summary report as a PDF
+async function downloadEventReport() {
+    const reportBtn = $('#download-report');
+    Loading.show(reportBtn);
+
+    try {
+        const headers = {'Accept': 'application/pdf'};
+        if (revealCode) {
+            headers['X-Reveal'] = revealCode;
+        }
+        const response = await fetch('/api/admin/report', {headers});
+        if (response.status === 423) {
+            throw new Error('Results are embargoed; enter the reveal code to download the report');
+        }
+        if (!response.ok) {
+            throw new Error('Failed to generate report');
+        }
+
+        const blob = await response.blob();
+        const url = URL.createObjectURL(blob);
+        const a = document.createElement('a');
+        a.href = url;
+        a.download = 'event-report.pdf';
+        a.click();
+        URL.revokeObjectURL(url);
+    } catch (error) {
+        Toast.error(error.message);
+    } finally {
+        Loading.hide(reportBtn);
+    }
+}
+
 // Initialize
 document.addEventListener('DOMContentLoaded', () => {
     // Set initial checkbox states from localStorage
@@ -603,6 +756,7 @@ document.addEventListener('DOMContentLoaded', () => {
 
     // Wire up buttons
     $('#push-derbynet').addEventListener('click', pushResultsToDerbyNet);
+    $('#download-report').addEventListener('click', downloadEventReport);
     $('#review-conflicts-btn').addEventListener('click', showConflictsModal);
     $('#close-conflicts-modal').addEventListener('click', hideConflictsModal);
 
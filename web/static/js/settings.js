@@ -9,16 +9,37 @@ async function loadSettings() {
         if (settings.derbynet_url) {
             $('#derbynet-url').value = settings.derbynet_url;
         }
+        $('#derbynet-url-fallback').value = settings.derbynet_url_fallback || '';
         if (settings.base_url) {
             $('#base-url').value = settings.base_url;
         }
         if (settings.voting_instructions) {
             $('#voting-instructions').value = settings.voting_instructions;
         }
+        const byType = settings.voting_instructions_by_type || {};
+        $('#voting-instructions-by-type').value = Object.keys(byType).length ? JSON.stringify(byType, null, 2) : '';
+        $('#post-vote-redirect-url').value = settings.post_vote_redirect_url || '';
         if (settings.derbynet_role) {
             $('#derbynet-role').value = settings.derbynet_role;
         }
+        $('#event-name').value = settings.event_name || '';
+        $('#logo-url').value = settings.logo_url || '';
+        $('#theme-color').value = settings.theme_color || '';
+        $('#timezone').value = settings.timezone || '';
+        $('#car-number-format').value = settings.car_number_format || 'any';
+        $('#maintenance-message').value = settings.maintenance_message || '';
+        showMaintenanceStatus(settings.maintenance_message);
+        $('#max-voting-minutes').value = settings.max_voting_minutes || '';
+        $('#voting-timer-presets').value = (settings.voting_timer_presets || []).join(', ');
+        $('#auto-close-idle-minutes').value = settings.auto_close_idle_minutes || 0;
+        $('#tie-margin').value = settings.tie_margin || 0;
         $('#require-registered-qr').checked = settings.require_registered_qr === true;
+        $('#open-voting-one-per-device').checked = settings.open_voting_one_per_device === true;
+        $('#public-results-enabled').checked = settings.public_results_enabled === true;
+        $('#anonymize-ballots').checked = settings.anonymize_ballots === true;
+        $('#require-complete-ballot').checked = settings.require_complete_ballot === true;
+        $('#allow-vote-changes').checked = settings.allow_vote_changes !== false;
+        $('#derbynet-health-polling').checked = settings.derbynet_health_polling === true;
 
         // Load voter types
         if (settings.voter_types) {
@@ -34,6 +55,37 @@ async function loadSettings() {
     }
 }
 
+// Map settings fields to their inputs so server validation errors can be highlighted
+const SETTINGS_FIELD_INPUTS = {
+    derbynet_url: '#derbynet-url',
+    derbynet_url_fallback: '#derbynet-url-fallback',
+    base_url: '#base-url',
+    derbynet_role: '#derbynet-role',
+    voting_instructions: '#voting-instructions',
+    voting_instructions_by_type: '#voting-instructions-by-type',
+    event_name: '#event-name',
+    logo_url: '#logo-url',
+    theme_color: '#theme-color',
+    timezone: '#timezone',
+    car_number_format: '#car-number-format',
+    maintenance_message: '#maintenance-message',
+    post_vote_redirect_url: '#post-vote-redirect-url',
+    max_voting_minutes: '#max-voting-minutes',
+    voting_timer_presets: '#voting-timer-presets',
+    auto_close_idle_minutes: '#auto-close-idle-minutes',
+    tie_margin: '#tie-margin'
+};
+
+// Highlight inputs named in a 422 field error map; clears previous highlights
+function highlightFieldErrors(error) {
+    Object.values(SETTINGS_FIELD_INPUTS).forEach(sel => $(sel)?.classList.remove('border-red-500'));
+    if (!error || !error.fields) return;
+    Object.keys(error.fields).forEach(field => {
+        const sel = SETTINGS_FIELD_INPUTS[field];
+        if (sel) $(sel)?.classList.add('border-red-500');
+    });
+}
+
 // Toggle Require Registered QR
 async function toggleRequireRegisteredQR() {
     const checked = $('#require-registered-qr').checked;
@@ -57,6 +109,127 @@ async function toggleRequireRegisteredQR() {
     }
 }
 
+// Toggle One Ballot Per Device for open voting
+async function toggleOnePerDevice() {
+    const checked = $('#open-voting-one-per-device').checked;
+    const messageEl = $('#one-per-device-message');
+
+    try {
+        await API.post('/api/admin/settings', {open_voting_one_per_device: checked});
+        messageEl.textContent = checked ?
+            'Enabled - Each device gets one ballot' :
+            'Disabled - Each scan gets a new ballot';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+        setTimeout(() => { messageEl.textContent = ''; }, 3000);
+    } catch (error) {
+        $('#open-voting-one-per-device').checked = !checked;
+        console.error('Error saving setting:', error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    }
+}
+
+// Toggle Public Results
+async function togglePublicResults() {
+    const checked = $('#public-results-enabled').checked;
+    const messageEl = $('#public-results-message');
+
+    try {
+        await API.post('/api/admin/settings', {public_results_enabled: checked});
+        messageEl.textContent = checked ?
+            'Enabled - Winners are public once voting closes' :
+            'Disabled - Results are only visible to admins';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+        setTimeout(() => { messageEl.textContent = ''; }, 3000);
+    } catch (error) {
+        $('#public-results-enabled').checked = !checked;
+        console.error('Error saving setting:', error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    }
+}
+
+// Toggle Anonymized Ballot Exports
+async function toggleAnonymizeBallots() {
+    const checked = $('#anonymize-ballots').checked;
+    const messageEl = $('#anonymize-ballots-message');
+
+    try {
+        await API.post('/api/admin/settings', {anonymize_ballots: checked});
+        messageEl.textContent = checked ?
+            'Enabled - Ballot exports show hashed voter IDs' :
+            'Disabled - Ballot exports show voter names';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+        setTimeout(() => { messageEl.textContent = ''; }, 3000);
+    } catch (error) {
+        $('#anonymize-ballots').checked = !checked;
+        console.error('Error saving setting:', error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    }
+}
+
+// Toggle Required Complete Ballots
+async function toggleRequireCompleteBallot() {
+    const checked = $('#require-complete-ballot').checked;
+    const messageEl = $('#require-complete-ballot-message');
+
+    try {
+        await API.post('/api/admin/settings', {require_complete_ballot: checked});
+        messageEl.textContent = checked ?
+            'Enabled - Only complete ballots count in results' :
+            'Disabled - Every vote counts in results';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+        setTimeout(() => { messageEl.textContent = ''; }, 3000);
+    } catch (error) {
+        $('#require-complete-ballot').checked = !checked;
+        console.error('Error saving setting:', error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    }
+}
+
+// Toggle Allow Vote Changes
+async function toggleAllowVoteChanges() {
+    const checked = $('#allow-vote-changes').checked;
+    const messageEl = $('#allow-vote-changes-message');
+
+    try {
+        await API.post('/api/admin/settings', {allow_vote_changes: checked});
+        messageEl.textContent = checked ?
+            'Enabled - Voters can change their votes' :
+            'Disabled - Votes are final once cast';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+        setTimeout(() => { messageEl.textContent = ''; }, 3000);
+    } catch (error) {
+        $('#allow-vote-changes').checked = !checked;
+        console.error('Error saving setting:', error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    }
+}
+
+// Save the near-tie margin
+async function saveTieMargin() {
+    const messageEl = $('#tie-margin-message');
+    const margin = parseInt($('#tie-margin').value) || 0;
+
+    try {
+        await API.post('/api/admin/settings', {tie_margin: margin});
+        highlightFieldErrors(null);
+        messageEl.textContent = margin > 0 ?
+            `Saved - Leads of ${margin} vote${margin > 1 ? 's' : ''} or less are flagged as near ties` :
+            'Saved - Only exact ties are flagged';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+        setTimeout(() => { messageEl.textContent = ''; }, 3000);
+    } catch (error) {
+        console.error('Error saving tie margin:', error);
+        highlightFieldErrors(error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    }
+}
+
 // Update dynamic QR code section visibility and generate QR
 async function updateDynamicQRSection() {
     const requireRegistered = $('#require-registered-qr').checked;
@@ -195,10 +368,129 @@ async function saveBaseURL() {
 
     try {
         await API.post('/api/admin/settings', {base_url: url});
+        highlightFieldErrors(null);
         messageEl.textContent = 'Base URL saved successfully! QR codes will now use this URL.';
         messageEl.className = 'mt-2 text-sm text-green-600';
     } catch (error) {
         console.error('Error saving base URL:', error);
+        highlightFieldErrors(error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    } finally {
+        Loading.hide(saveBtn);
+    }
+}
+
+// Save Timezone
+async function saveTimezone() {
+    if (!validateRequired([['#timezone', 'Timezone']])) return;
+
+    const messageEl = $('#timezone-message');
+    const saveBtn = $('#save-timezone');
+
+    messageEl.textContent = 'Saving...';
+    messageEl.className = 'mt-2 text-sm text-blue-600';
+    Loading.show(saveBtn);
+
+    try {
+        await API.post('/api/admin/settings', {timezone: $('#timezone').value.trim()});
+        highlightFieldErrors(null);
+        messageEl.textContent = 'Timezone saved successfully!';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+    } catch (error) {
+        console.error('Error saving timezone:', error);
+        highlightFieldErrors(error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    } finally {
+        Loading.hide(saveBtn);
+    }
+}
+
+// Save car number format
+async function saveCarNumberFormat() {
+    const messageEl = $('#car-number-format-message');
+    const saveBtn = $('#save-car-number-format');
+
+    messageEl.textContent = 'Saving...';
+    messageEl.className = 'mt-2 text-sm text-blue-600';
+    Loading.show(saveBtn);
+
+    try {
+        await API.post('/api/admin/settings', {car_number_format: $('#car-number-format').value});
+        highlightFieldErrors(null);
+        messageEl.textContent = 'Car number format saved. It applies to cars added or edited from now on.';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+    } catch (error) {
+        console.error('Error saving car number format:', error);
+        highlightFieldErrors(error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    } finally {
+        Loading.hide(saveBtn);
+    }
+}
+
+// Show whether voter pages are currently paused
+function showMaintenanceStatus(message) {
+    const statusEl = $('#maintenance-message-status');
+    if (message) {
+        statusEl.textContent = 'Voter pages are paused.';
+        statusEl.className = 'mt-2 text-sm text-orange-600';
+    } else {
+        statusEl.textContent = 'Voter pages are live.';
+        statusEl.className = 'mt-2 text-sm text-green-600';
+    }
+}
+
+// Set or clear the maintenance message; a blank message resumes voting
+async function saveMaintenance(message) {
+    try {
+        await API.post('/api/admin/settings', {maintenance_message: message});
+        highlightFieldErrors(null);
+        $('#maintenance-message').value = message;
+        showMaintenanceStatus(message);
+        Toast.success(message ? 'Voter pages paused' : 'Voter pages resumed');
+    } catch (error) {
+        console.error('Error saving maintenance message:', error);
+        highlightFieldErrors(error);
+        Toast.error(error.message || 'Failed to save maintenance message');
+    }
+}
+
+// Save voting timer maximum and presets
+async function saveTimerSettings() {
+    const messageEl = $('#timer-settings-message');
+    const saveBtn = $('#save-timer-settings');
+
+    const maxMinutes = parseInt($('#max-voting-minutes').value) || 0;
+    const idleMinutes = parseInt($('#auto-close-idle-minutes').value) || 0;
+    const presets = $('#voting-timer-presets').value
+        .split(',')
+        .map(p => p.trim())
+        .filter(p => p !== '')
+        .map(p => parseInt(p));
+    if (presets.some(p => isNaN(p))) {
+        Toast.warning('Timer presets must be whole numbers separated by commas');
+        return;
+    }
+
+    messageEl.textContent = 'Saving...';
+    messageEl.className = 'mt-2 text-sm text-blue-600';
+    Loading.show(saveBtn);
+
+    try {
+        await API.post('/api/admin/settings', {
+            max_voting_minutes: maxMinutes,
+            voting_timer_presets: presets.length ? presets : undefined,
+            auto_close_idle_minutes: idleMinutes
+        });
+        highlightFieldErrors(null);
+        messageEl.textContent = 'Timer settings saved successfully!';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+    } catch (error) {
+        console.error('Error saving timer settings:', error);
+        highlightFieldErrors(error);
         messageEl.textContent = `Error: ${error.message}`;
         messageEl.className = 'mt-2 text-sm text-red-600';
     } finally {
@@ -209,19 +501,69 @@ async function saveBaseURL() {
 // Save Voting Instructions
 async function saveInstructions() {
     const instructions = $('#voting-instructions').value;
+    const byTypeText = $('#voting-instructions-by-type').value.trim();
     const messageEl = $('#instructions-message');
     const saveBtn = $('#save-instructions');
 
+    let byType = {};
+    if (byTypeText) {
+        try {
+            byType = JSON.parse(byTypeText);
+        } catch (error) {
+            byType = null;
+        }
+        if (!byType || typeof byType !== 'object' || Array.isArray(byType) ||
+            Object.values(byType).some(value => typeof value !== 'string')) {
+            messageEl.textContent = 'Error: instructions by voter type must be a JSON object of strings';
+            messageEl.className = 'mt-2 text-sm text-red-600';
+            return;
+        }
+    }
+
     messageEl.textContent = 'Saving...';
     messageEl.className = 'mt-2 text-sm text-blue-600';
     Loading.show(saveBtn);
 
     try {
-        await API.post('/api/admin/settings', {voting_instructions: instructions});
+        await API.post('/api/admin/settings', {
+            voting_instructions: instructions,
+            voting_instructions_by_type: byType,
+            post_vote_redirect_url: $('#post-vote-redirect-url').value.trim()
+        });
+        highlightFieldErrors(null);
         messageEl.textContent = 'Instructions saved successfully!';
         messageEl.className = 'mt-2 text-sm text-green-600';
     } catch (error) {
         console.error('Error saving instructions:', error);
+        highlightFieldErrors(error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    } finally {
+        Loading.hide(saveBtn);
+    }
+}
+
+// Save Branding
+async function saveBranding() {
+    const messageEl = $('#branding-message');
+    const saveBtn = $('#save-branding');
+
+    messageEl.textContent = 'Saving...';
+    messageEl.className = 'mt-2 text-sm text-blue-600';
+    Loading.show(saveBtn);
+
+    try {
+        await API.post('/api/admin/settings', {
+            event_name: $('#event-name').value.trim(),
+            logo_url: $('#logo-url').value.trim(),
+            theme_color: $('#theme-color').value.trim()
+        });
+        highlightFieldErrors(null);
+        messageEl.textContent = 'Branding saved successfully!';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+    } catch (error) {
+        console.error('Error saving branding:', error);
+        highlightFieldErrors(error);
         messageEl.textContent = `Error: ${error.message}`;
         messageEl.className = 'mt-2 text-sm text-red-600';
     } finally {
@@ -229,6 +571,30 @@ async function saveInstructions() {
     }
 }
 
+// Upload a logo image and use it for branding
+async function uploadLogo(e) {
+    const file = e.target.files[0];
+    if (!file) return;
+
+    const messageEl = $('#branding-message');
+    const formData = new FormData();
+    formData.append('logo', file);
+
+    try {
+        const response = await fetch('/api/admin/branding/logo', {method: 'POST', body: formData});
+        const result = await API.handleResponse(response);
+        $('#logo-url').value = result.logo_url;
+        messageEl.textContent = 'Logo uploaded successfully!';
+        messageEl.className = 'mt-2 text-sm text-green-600';
+    } catch (error) {
+        console.error('Error uploading logo:', error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    } finally {
+        e.target.value = '';
+    }
+}
+
 // Save DerbyNet Settings (URL and Credentials)
 async function saveDerbyNetSettings() {
     if (!validateRequired([['#derbynet-url', 'DerbyNet URL']])) return;
@@ -252,10 +618,12 @@ async function saveDerbyNetSettings() {
     try {
         await API.post('/api/admin/settings', {
             derbynet_url: url,
+            derbynet_url_fallback: $('#derbynet-url-fallback').value.trim(),
             derbynet_role: role,
             derbynet_password: password
         });
 
+        highlightFieldErrors(null);
         let message = 'DerbyNet settings saved successfully!';
         if (role) {
             message += ` Authentication configured as ${role}.`;
@@ -266,6 +634,7 @@ async function saveDerbyNetSettings() {
         $('#derbynet-password').value = '';
     } catch (error) {
         console.error('Error saving DerbyNet settings:', error);
+        highlightFieldErrors(error);
         messageEl.textContent = `Error: ${error.message}`;
         messageEl.className = 'text-sm text-red-600';
     } finally {
@@ -286,7 +655,10 @@ async function testDerbyNet() {
     Loading.show(testBtn);
 
     try {
-        const result = await API.post('/api/admin/test-derbynet', {derbynet_url: url});
+        const result = await API.post('/api/admin/test-derbynet', {
+            derbynet_url: url,
+            derbynet_url_fallback: $('#derbynet-url-fallback').value.trim()
+        });
 
         let message = `✓ Connection successful! Found ${result.total_racers} racers`;
         if (result.total_awards > 0) {
@@ -294,10 +666,24 @@ async function testDerbyNet() {
         }
         if (result.authenticated) {
             message += ` (authenticated as ${result.role})`;
+        } else if (result.auth_error) {
+            message += ` (authentication failed: ${result.auth_error})`;
+        }
+        if (result.active_url && result.active_url !== url) {
+            message += `. Primary URL unreachable; connected via fallback ${result.active_url}`;
+        }
+        message += `. Latency: racers ${result.racer_list.latency_ms} ms, awards ${result.award_list.latency_ms} ms`;
+        if (!result.session_cookie) {
+            message += '. No session cookie received';
+        }
+
+        const awardsFailed = !!result.award_list.error;
+        if (awardsFailed) {
+            message += `. Award list failed (HTTP ${result.award_list.http_status || 'no response'}): ${result.award_list.error}`;
         }
 
         messageEl.textContent = message;
-        messageEl.className = 'mt-2 text-sm text-green-600';
+        messageEl.className = awardsFailed || result.auth_error ? 'mt-2 text-sm text-yellow-600' : 'mt-2 text-sm text-green-600';
     } catch (error) {
         console.error('Error testing connection:', error);
         messageEl.textContent = `✗ Connection failed: ${error.message}`;
@@ -307,43 +693,62 @@ async function testDerbyNet() {
     }
 }
 
-// Seed Categories
-async function seedCategories() {
-    const messageEl = $('#seed-categories-message');
-    const seedBtn = $('#seed-categories');
+// Toggle background DerbyNet health polling
+async function toggleDerbyNetHealthPolling() {
+    const checked = $('#derbynet-health-polling').checked;
 
-    messageEl.textContent = 'Seeding categories...';
-    messageEl.className = 'mt-2 text-sm text-blue-600';
-    Loading.show(seedBtn);
+    try {
+        await API.post('/api/admin/settings', {derbynet_health_polling: checked});
+        loadDerbyNetStatus();
+    } catch (error) {
+        $('#derbynet-health-polling').checked = !checked;
+        console.error('Error saving setting:', error);
+        Toast.error(`Failed to save setting: ${error.message}`);
+    }
+}
 
+// Show the latest background DerbyNet health check
+async function loadDerbyNetStatus() {
+    const statusEl = $('#derbynet-status');
     try {
-        const result = await API.post('/api/admin/seed-mock-data', {seed_type: 'categories'});
-        messageEl.textContent = `Success! ${result.message}`;
-        messageEl.className = 'mt-2 text-sm text-green-600';
+        const status = await API.get('/api/admin/derbynet/status');
+        if (!status.enabled) {
+            statusEl.textContent = '';
+        } else if (!status.configured) {
+            statusEl.textContent = 'DerbyNet URL not configured';
+            statusEl.className = 'text-sm mt-2 text-gray-500';
+        } else if (!status.checked_at) {
+            statusEl.textContent = 'Waiting for first check...';
+            statusEl.className = 'text-sm mt-2 text-gray-500';
+        } else if (status.connected) {
+            statusEl.textContent = `● Connected (${status.latency_ms} ms, checked ${new Date(status.checked_at).toLocaleTimeString()})`;
+            statusEl.className = 'text-sm mt-2 text-green-600';
+        } else {
+            statusEl.textContent = `● Unreachable (${status.consecutive_failures} failed check(s)): ${status.error}`;
+            statusEl.className = 'text-sm mt-2 text-red-600';
+        }
     } catch (error) {
-        console.error('Error seeding categories:', error);
-        messageEl.textContent = `Error: ${error.message}`;
-        messageEl.className = 'mt-2 text-sm text-red-600';
-    } finally {
-        Loading.hide(seedBtn);
+        console.error('Error loading DerbyNet status:', error);
     }
 }
 
-// Seed Cars
-async function seedCars() {
-    const messageEl = $('#seed-cars-message');
-    const seedBtn = $('#seed-cars');
+// Seed mock data of the given type (categories, cars, voters or votes)
+async function seedMockData(seedType) {
+    const messageEl = $(`#seed-${seedType}-message`);
+    const seedBtn = $(`#seed-${seedType}`);
+    const clear = $('#seed-clear').checked;
 
-    messageEl.textContent = 'Seeding cars...';
+    messageEl.textContent = `Seeding ${seedType}...`;
     messageEl.className = 'mt-2 text-sm text-blue-600';
     Loading.show(seedBtn);
 
     try {
-        const result = await API.post('/api/admin/seed-mock-data', {seed_type: 'cars'});
+        const url = `/api/admin/seed-mock-data${clear ? '?clear=true' : ''}`;
+        const result = await API.post(url, {seed_type: seedType});
         messageEl.textContent = `Success! ${result.message}`;
         messageEl.className = 'mt-2 text-sm text-green-600';
     } catch (error) {
-        console.error('Error seeding cars:', error);
+        console.error(`Error seeding ${seedType}:`, error);
         messageEl.textContent = `Error: ${error.message}`;
         messageEl.className = 'mt-2 text-sm text-red-600';
     } finally {
@@ -351,6 +756,25 @@ async function seedCars() {
     }
 }
 
+// Show the demo data a seed would create, without writing anything
+async function previewMockData(seedType) {
+    const messageEl = $(`#seed-${seedType}-message`);
+    try {
+        const preview = await API.post('/api/admin/seed-mock-data/preview', {seed_type: seedType});
+        const labels = {
+            categories: c => c.name,
+            cars: c => `#${c.car_number} ${c.car_name} (${c.racer_name})`,
+            voters: v => `${v.name} - ${v.voter_type} (${v.qr_code})`
+        };
+        messageEl.textContent = `Would add: ${preview[seedType].map(labels[seedType]).join(', ')}`;
+        messageEl.className = 'mt-2 text-sm text-gray-600';
+    } catch (error) {
+        console.error(`Error previewing ${seedType}:`, error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    }
+}
+
 // Handle automatic vote clearing dependencies
 function updateResetDependencies() {
     const votesCheckbox = $('#reset-votes');
@@ -424,6 +848,34 @@ async function resetSelected() {
     }
 }
 
+// Start New Event (keeps categories, cars and settings)
+async function startNewEvent() {
+    const messageEl = $('#new-event-message');
+
+    const confirmed = await Confirm.danger(
+        'This will DELETE all votes, voters and manual winner overrides and close voting. Categories, cars and settings are kept.',
+        'Start a New Event?'
+    );
+    if (!confirmed) return;
+
+    const newEventBtn = $('#new-event');
+    Loading.show(newEventBtn);
+
+    try {
+        const result = await API.post('/api/admin/new-event', {confirm: true});
+        const cleared = result.cleared || {};
+        messageEl.textContent = `Cleared ${cleared.votes || 0} votes, ${cleared.voters || 0} voters and ${cleared.overrides || 0} overrides. Voting is closed.`;
+        messageEl.className = 'mt-2 text-sm text-green-600';
+        Toast.success('New event started');
+    } catch (error) {
+        console.error('Error starting new event:', error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    } finally {
+        Loading.hide(newEventBtn);
+    }
+}
+
 // Reset ALL Data
 async function resetAll() {
     const messageEl = $('#reset-message');
@@ -545,17 +997,115 @@ async function saveVoterTypes() {
     }
 }
 
+// Export settings as a downloaded JSON file
+function exportSettings() {
+    const includeSensitive = $('#settings-include-sensitive').checked;
+    window.location.href = `/api/admin/settings/export${includeSensitive ? '?include_sensitive=true' : ''}`;
+}
+
+// Import settings from a previously exported JSON file
+async function importSettings(e) {
+    const file = e.target.files[0];
+    if (!file) return;
+
+    const messageEl = $('#settings-transfer-message');
+    try {
+        const settings = JSON.parse(await file.text());
+        const result = await API.post('/api/admin/settings/import', {
+            settings,
+            include_sensitive: $('#settings-include-sensitive').checked
+        });
+        let message = `Imported ${result.imported.length} setting(s).`;
+        if (result.skipped.length > 0) {
+            message += ` Skipped: ${result.skipped.join(', ')}.`;
+        }
+        messageEl.textContent = message;
+        messageEl.className = 'mt-2 text-sm text-green-600';
+        loadSettings();
+    } catch (error) {
+        console.error('Error importing settings:', error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    } finally {
+        e.target.value = '';
+    }
+}
+
+// Download every voter and vote for merging into another instance
+function exportVotes() {
+    window.location.href = '/api/admin/votes/export';
+}
+
+// Merge votes exported by another instance and list any conflicts
+async function mergeVotes(e) {
+    const file = e.target.files[0];
+    if (!file) return;
+
+    const messageEl = $('#merge-votes-message');
+    const conflictsEl = $('#merge-votes-conflicts');
+    conflictsEl.innerHTML = '';
+    try {
+        const result = await API.post('/api/admin/merge-votes', JSON.parse(await file.text()));
+        let message = `Added ${result.votes_added} vote(s) and ${result.voters_added} voter(s); ${result.duplicates} already recorded.`;
+        if (result.skipped.length > 0) {
+            message += ` Skipped ${result.skipped.length} that didn't match a category or car here.`;
+        }
+        if (result.conflicts.length > 0) {
+            message += ` ${result.conflicts.length} conflict(s) need manual resolution:`;
+        }
+        messageEl.textContent = message;
+        messageEl.className = 'mt-2 text-sm text-green-600';
+        conflictsEl.innerHTML = result.conflicts.map(c =>
+            `<li>${esc(c.voter_qr)} in ${esc(c.category_name)}: ${esc(c.reason)} (other instance chose car #${esc(c.remote_car_number)})</li>`
+        ).join('');
+    } catch (error) {
+        console.error('Error merging votes:', error);
+        messageEl.textContent = `Error: ${error.message}`;
+        messageEl.className = 'mt-2 text-sm text-red-600';
+    } finally {
+        e.target.value = '';
+    }
+}
+
 // Initialize
 document.addEventListener('DOMContentLoaded', () => {
     $('#save-base-url').addEventListener('click', saveBaseURL);
+    $('#save-timezone').addEventListener('click', saveTimezone);
+    $('#save-car-number-format').addEventListener('click', saveCarNumberFormat);
+    $('#save-timer-settings').addEventListener('click', saveTimerSettings);
+    $('#save-maintenance').addEventListener('click', () => {
+        if (!validateRequired([['#maintenance-message', 'Maintenance message']])) return;
+        saveMaintenance($('#maintenance-message').value.trim());
+    });
+    $('#clear-maintenance').addEventListener('click', () => saveMaintenance(''));
     $('#save-instructions').addEventListener('click', saveInstructions);
+    $('#save-branding').addEventListener('click', saveBranding);
+    $('#logo-file').addEventListener('change', uploadLogo);
     $('#save-derbynet').addEventListener('click', saveDerbyNetSettings);
     $('#test-derbynet').addEventListener('click', testDerbyNet);
-    $('#seed-categories').addEventListener('click', seedCategories);
-    $('#seed-cars').addEventListener('click', seedCars);
+    $('#derbynet-health-polling').addEventListener('change', toggleDerbyNetHealthPolling);
+    loadDerbyNetStatus();
+    setInterval(loadDerbyNetStatus, 30000);
+    ['categories', 'cars', 'voters', 'votes'].forEach(seedType => {
+        $(`#seed-${seedType}`).addEventListener('click', () => seedMockData(seedType));
+    });
+    ['categories', 'cars', 'voters'].forEach(seedType => {
+        $(`#preview-${seedType}`).addEventListener('click', () => previewMockData(seedType));
+    });
     $('#reset-db').addEventListener('click', resetSelected);
     $('#reset-all').addEventListener('click', resetAll);
+    $('#new-event').addEventListener('click', startNewEvent);
+    $('#export-votes').addEventListener('click', exportVotes);
+    $('#merge-votes-file').addEventListener('change', mergeVotes);
     $('#require-registered-qr').addEventListener('change', toggleRequireRegisteredQR);
+    $('#open-voting-one-per-device').addEventListener('change', toggleOnePerDevice);
+    $('#public-results-enabled').addEventListener('change', togglePublicResults);
+    $('#anonymize-ballots').addEventListener('change', toggleAnonymizeBallots);
+    $('#require-complete-ballot').addEventListener('change', toggleRequireCompleteBallot);
+    $('#allow-vote-changes').addEventListener('change', toggleAllowVoteChanges);
+    $('#save-tie-margin').addEventListener('click', saveTieMargin);
+    $('#export-settings').addEventListener('click', exportSettings);
+    $('#import-settings-file').addEventListener('change', importSettings);
 
     // Dynamic QR code buttons
     $('#download-dynamic-qr').addEventListener('click', downloadDynamicQR);
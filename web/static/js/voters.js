@@ -9,6 +9,7 @@ async function loadVoters() {
     Loading.show('#voters-table');
     try {
         voters = await API.get('/api/admin/voters') || [];
+        populateVoterGroups();
         renderVoters();
     } catch (error) {
         console.error('Error loading voters:', error);
@@ -62,6 +63,19 @@ function populateVoterTypeDropdowns() {
     }
 }
 
+// Fill the group filter and the modal's suggestions from the groups in use
+function populateVoterGroups() {
+    const groups = [...new Set(voters.map(v => v.voter_group).filter(Boolean))].sort();
+
+    const filterSelect = $('#filter-group');
+    const currentValue = filterSelect.value;
+    filterSelect.innerHTML = '<option value="">All Groups</option>' +
+        groups.map(group => `<option value="${esc(group)}">${esc(group)}</option>`).join('');
+    filterSelect.value = groups.includes(currentValue) ? currentValue : '';
+
+    $('#voter-group-options').innerHTML = groups.map(group => `<option value="${esc(group)}">`).join('');
+}
+
 function populateCarDropdown() {
     const select = $('#voter-car');
     // Keep the first "None" option and clear the rest
@@ -77,7 +91,9 @@ function populateCarDropdown() {
 
 function renderVoters() {
     const filterType = $('#filter-type').value;
-    const filteredVoters = filterType ? voters.filter(v => v.voter_type === filterType) : voters;
+    const filterGroup = $('#filter-group').value;
+    const filteredVoters = voters.filter(v =>
+        (!filterType || v.voter_type === filterType) && (!filterGroup || v.voter_group === filterGroup));
 
     const tbody = $('#voters-table');
 
@@ -98,6 +114,8 @@ function renderVoters() {
                 <span class="px-2 py-1 text-xs rounded-full ${getTypeColor(voter.voter_type)}">
                     ${capitalizeFirst(voter.voter_type || 'general')}
                 </span>
+                ${voter.is_test ? '<span class="px-2 py-1 text-xs rounded-full bg-yellow-100 text-yellow-800">Test</span>' : ''}
+                ${voter.voter_group ? `<span class="px-2 py-1 text-xs rounded-full bg-indigo-100 text-indigo-800">${esc(voter.voter_group)}</span>` : ''}
             </td>
             <td class="px-6 py-4 whitespace-nowrap">
                 ${voter.car_number ? `#${esc(voter.car_number)} - ${esc(voter.racer_name) || ''}` : '<span class="text-gray-400">None</span>'}
@@ -145,8 +163,10 @@ function showVoterModal(title = 'Add Voter', voter = null) {
         $('#voter-name').value = voter.name || '';
         $('#voter-email').value = voter.email || '';
         $('#voter-type').value = voter.voter_type || 'general';
+        $('#voter-group').value = voter.voter_group || '';
         $('#voter-car').value = voter.car_id || '';
         $('#voter-notes').value = voter.notes || '';
+        $('#voter-is-test').checked = !!voter.is_test;
         $('#voter-qr-code').textContent = voter.qr_code;
         $('#voter-qr-image').src = `/api/admin/voters/${voter.id}/qr`;
         $('#qr-code-display').classList.remove('hidden');
@@ -154,8 +174,10 @@ function showVoterModal(title = 'Add Voter', voter = null) {
         $('#voter-name').value = '';
         $('#voter-email').value = '';
         $('#voter-type').value = 'general';
+        $('#voter-group').value = '';
         $('#voter-car').value = '';
         $('#voter-notes').value = '';
+        $('#voter-is-test').checked = false;
         $('#qr-code-display').classList.add('hidden');
     }
 
@@ -177,7 +199,7 @@ function editVoter(id) {
 async function deleteVoter(id) {
     const voter = voters.find(v => v.id === id);
     const confirmed = await Confirm.danger(
-        `This will permanently delete voter "${voter.qr_code}" and ALL of their votes.`,
+        `This will permanently delete voter "${voter.qr_code}".`,
         'Delete Voter?'
     );
     if (!confirmed) return;
@@ -187,18 +209,51 @@ async function deleteVoter(id) {
         await loadVoters();
         Toast.success('Voter deleted');
     } catch (error) {
+        // A voter with votes needs a second confirmation, since their ballot is lost too
+        if (error.confirmation_required) {
+            const force = await Confirm.danger(error.message, 'Delete Voter and Votes?');
+            if (!force) return;
+            try {
+                await API.delete(`/api/admin/voters/${id}?force=true`);
+                await loadVoters();
+                Toast.success('Voter deleted');
+            } catch (retryError) {
+                console.error('Error deleting voter with force:', retryError);
+                Toast.error(retryError.message || 'Failed to delete voter');
+            }
+            return;
+        }
         console.error('Error deleting voter:', error);
         Toast.error('Failed to delete voter');
     }
 }
 
+async function clearTestVoters() {
+    const confirmed = await Confirm.danger(
+        'This will permanently delete every test voter and their votes.',
+        'Clear Test Voters?'
+    );
+    if (!confirmed) return;
+
+    try {
+        const result = await API.post('/api/admin/voters/clear-test');
+        await loadVoters();
+        Toast.success(`Deleted ${result.deleted} test voter${result.deleted === 1 ? '' : 's'}`);
+    } catch (error) {
+        console.error('Error clearing test voters:', error);
+        Toast.error(error.message || 'Failed to clear test voters');
+    }
+}
+
 async function saveVoter() {
     const data = {
         name: $('#voter-name').value,
         email: $('#voter-email').value,
         voter_type: $('#voter-type').value,
+        voter_group: $('#voter-group').value.trim(),
         car_id: $('#voter-car').value ? parseInt($('#voter-car').value) : null,
-        notes: $('#voter-notes').value
+        notes: $('#voter-notes').value,
+        is_test: $('#voter-is-test').checked
     };
 
     const saveBtn = $('#modal-save');
@@ -264,7 +319,9 @@ document.addEventListener('DOMContentLoaded', () => {
     $('#modal-cancel').addEventListener('click', hideVoterModal);
     $('#modal-save').addEventListener('click', saveVoter);
     $('#filter-type').addEventListener('change', renderVoters);
+    $('#filter-group').addEventListener('change', renderVoters);
     $('#export-qr').addEventListener('click', printQRCodes);
+    $('#clear-test-voters').addEventListener('click', clearTestVoters);
 
     // Close QR modal on backdrop click
     setupModalBackdropClose('qr-modal', closeQRModal);
@@ -22,3 +22,9 @@ func GetStaticFS() fs.FS {
 	sub, _ := fs.Sub(staticFS, "static")
 	return sub
 }
+
+// GetOpenAPISpec returns the OpenAPI document describing the /api routes
+func GetOpenAPISpec() []byte {
+	data, _ := staticFS.ReadFile("static/openapi.json")
+	return data
+}
@@ -37,6 +37,7 @@ func TestEmbeddedStaticFilesExist(t *testing.T) {
 		"js/results.js",
 		"js/voters.js",
 		"js/settings.js",
+		"openapi.json",
 	}
 
 	for _, file := range requiredFiles {